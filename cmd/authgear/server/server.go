@@ -61,6 +61,9 @@ func (c *Controller) Start() {
 	}
 	defer configSrcController.Close()
 
+	wrk.StartScheduledTasks(configSrcController.GetConfigSource())
+	defer wrk.Stop()
+
 	var specs []server.Spec
 
 	if c.ServeMain {
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/authgear/authgear-server/cmd/authgear/users"
+	adminauthz "github.com/authgear/authgear-server/pkg/lib/admin/authz"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+)
+
+var (
+	UsersAppConfigPath    string
+	UsersSecretConfigPath string
+	UsersAdminAPIEndpoint string
+	UsersAdminAPIAuth     string
+	UsersImportBatchSize  int
+
+	UsersExportFormat         string
+	UsersExportIncludeSecrets bool
+	UsersExportOutputPath     string
+	UsersExportPollInterval   time.Duration
+)
+
+func init() {
+	cmdUsers.AddCommand(cmdUsersImport)
+
+	cmdUsersImport.Flags().StringVarP(&UsersAppConfigPath, "config", "c", "authgear.yaml", "App config YAML path")
+	cmdUsersImport.Flags().StringVarP(&UsersSecretConfigPath, "secret-config", "f", "authgear.secrets.yaml", "App secrets YAML path")
+	cmdUsersImport.Flags().StringVar(&UsersAdminAPIEndpoint, "endpoint", "http://localhost:3002", "Admin API endpoint")
+	cmdUsersImport.Flags().StringVar(&UsersAdminAPIAuth, "admin-api-auth", string(config.AdminAPIAuthJWT), "Admin API authorization mode (jwt|none)")
+	cmdUsersImport.Flags().IntVar(&UsersImportBatchSize, "batch-size", 100, "Maximum number of users imported in a request")
+
+	cmdUsers.AddCommand(cmdUsersExport)
+
+	cmdUsersExport.Flags().StringVarP(&UsersAppConfigPath, "config", "c", "authgear.yaml", "App config YAML path")
+	cmdUsersExport.Flags().StringVarP(&UsersSecretConfigPath, "secret-config", "f", "authgear.secrets.yaml", "App secrets YAML path")
+	cmdUsersExport.Flags().StringVar(&UsersAdminAPIEndpoint, "endpoint", "http://localhost:3002", "Admin API endpoint")
+	cmdUsersExport.Flags().StringVar(&UsersAdminAPIAuth, "admin-api-auth", string(config.AdminAPIAuthJWT), "Admin API authorization mode (jwt|none)")
+	cmdUsersExport.Flags().StringVar(&UsersExportFormat, "format", "ndjson", "Output format (ndjson|csv)")
+	cmdUsersExport.Flags().BoolVar(&UsersExportIncludeSecrets, "include-secrets", false, "Export authenticator secrets, such as password hashes")
+	cmdUsersExport.Flags().StringVarP(&UsersExportOutputPath, "output", "o", "", "Output file path; standard output if not given")
+	cmdUsersExport.Flags().DurationVar(&UsersExportPollInterval, "poll-interval", 2*time.Second, "Interval between progress checks")
+}
+
+var cmdUsers = &cobra.Command{
+	Use:   "users",
+	Short: "Manage users",
+}
+
+var cmdUsersImport = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Import users from NDJSON file",
+	Long: "Import users from NDJSON file, or standard input if file is not given.\n" +
+		"The result of each user is written to standard output as NDJSON.",
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		input := os.Stdin
+		if len(args) == 1 {
+			f, err := os.Open(args[0])
+			if err != nil {
+				log.Fatalf("cannot open input file: %s", err)
+			}
+			defer f.Close()
+			input = f
+		}
+
+		if UsersImportBatchSize <= 0 {
+			log.Fatalf("invalid batch size: %d", UsersImportBatchSize)
+		}
+
+		addAuthz, err := loadAdminAPIAuthz(config.AdminAPIAuth(UsersAdminAPIAuth))
+		if err != nil {
+			log.Fatalf("cannot load config: %s", err)
+		}
+
+		summary, err := users.Import(input, os.Stdout, users.ImportOptions{
+			Endpoint:  UsersAdminAPIEndpoint,
+			BatchSize: UsersImportBatchSize,
+			AddAuthz:  addAuthz,
+		})
+		if err != nil {
+			log.Fatalf("failed to import users: %s", err)
+		}
+
+		log.Printf("import completed: %d created, %d skipped, %d failed", summary.Created, summary.Skipped, summary.Failed)
+		if summary.Failed > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+var cmdUsersExport = &cobra.Command{
+	Use:   "export",
+	Short: "Export users as NDJSON or CSV",
+	Long: "Export all users in background, and write the output to file or standard output when completed.\n" +
+		"Progress is reported to standard error.",
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		format := strings.ToUpper(UsersExportFormat)
+		if format != "NDJSON" && format != "CSV" {
+			log.Fatalf("invalid format: %s", UsersExportFormat)
+		}
+		if UsersExportPollInterval <= 0 {
+			log.Fatalf("invalid poll interval: %s", UsersExportPollInterval)
+		}
+
+		addAuthz, err := loadAdminAPIAuthz(config.AdminAPIAuth(UsersAdminAPIAuth))
+		if err != nil {
+			log.Fatalf("cannot load config: %s", err)
+		}
+
+		output := os.Stdout
+		if UsersExportOutputPath != "" {
+			f, err := os.Create(UsersExportOutputPath)
+			if err != nil {
+				log.Fatalf("cannot create output file: %s", err)
+			}
+			defer f.Close()
+			output = f
+		}
+
+		err = users.Export(output, users.ExportOptions{
+			Endpoint:       UsersAdminAPIEndpoint,
+			Format:         format,
+			IncludeSecrets: UsersExportIncludeSecrets,
+			PollInterval:   UsersExportPollInterval,
+			AddAuthz:       addAuthz,
+			OnProgress: func(exported int, total int) {
+				log.Printf("exported %d/%d users", exported, total)
+			},
+		})
+		if err != nil {
+			log.Fatalf("failed to export users: %s", err)
+		}
+	},
+}
+
+func loadAdminAPIAuthz(auth config.AdminAPIAuth) (func(hdr http.Header) error, error) {
+	switch auth {
+	case config.AdminAPIAuthNone, config.AdminAPIAuthJWT:
+		break
+	default:
+		return nil, fmt.Errorf("invalid admin API auth mode: %s", auth)
+	}
+
+	appYAML, err := ioutil.ReadFile(UsersAppConfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read app config file: %w", err)
+	}
+	appConfig, err := config.Parse(appYAML)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse app config: %w", err)
+	}
+
+	var authKey *config.AdminAPIAuthKey
+	if auth == config.AdminAPIAuthJWT {
+		secretYAML, err := ioutil.ReadFile(UsersSecretConfigPath)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read secret config file: %w", err)
+		}
+		secretConfig, err := config.ParseSecret(secretYAML)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse secret config: %w", err)
+		}
+		var ok bool
+		authKey, ok = secretConfig.LookupData(config.AdminAPIAuthKeyKey).(*config.AdminAPIAuthKey)
+		if !ok {
+			return nil, fmt.Errorf("admin API auth key is not configured")
+		}
+	}
+
+	adder := &adminauthz.Adder{Clock: clock.NewSystemClock()}
+	return func(hdr http.Header) error {
+		return adder.AddAuthz(auth, appConfig.ID, authKey, "cli", hdr)
+	}, nil
+}
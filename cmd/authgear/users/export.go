@@ -0,0 +1,172 @@
+package users
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type ExportOptions struct {
+	// Endpoint is the base URL of the Admin API server.
+	Endpoint string
+	// Format is the output format, NDJSON or CSV.
+	Format string
+	// IncludeSecrets indicates whether authenticator secrets are exported.
+	IncludeSecrets bool
+	// PollInterval is the interval between progress checks.
+	PollInterval time.Duration
+	// AddAuthz adds the authorization header to the request.
+	AddAuthz func(hdr http.Header) error
+	// OnProgress is called with the export progress after each check.
+	OnProgress func(exported int, total int)
+}
+
+type exportJob struct {
+	ID            string  `json:"id"`
+	Status        string  `json:"status"`
+	TotalUsers    int     `json:"totalUsers"`
+	ExportedUsers int     `json:"exportedUsers"`
+	Error         *string `json:"error"`
+}
+
+const createExportMutation = `
+mutation ($input: CreateUserExportInput!) {
+	createUserExport(input: $input) {
+		userExport { id status totalUsers exportedUsers error }
+	}
+}
+`
+
+const getExportQuery = `
+query ($id: ID!) {
+	node(id: $id) {
+		... on UserExport { id status totalUsers exportedUsers error }
+	}
+}
+`
+
+// Export starts a user export, waits until it is completed, and writes the
+// output to output.
+func Export(output io.Writer, opts ExportOptions) error {
+	var created struct {
+		CreateUserExport struct {
+			UserExport exportJob `json:"userExport"`
+		} `json:"createUserExport"`
+	}
+	err := graphqlRequest(opts, createExportMutation, map[string]interface{}{
+		"input": map[string]interface{}{
+			"format":         opts.Format,
+			"includeSecrets": opts.IncludeSecrets,
+		},
+	}, &created)
+	if err != nil {
+		return err
+	}
+
+	job := created.CreateUserExport.UserExport
+	for {
+		if opts.OnProgress != nil {
+			opts.OnProgress(job.ExportedUsers, job.TotalUsers)
+		}
+
+		switch job.Status {
+		case "COMPLETED":
+			return download(output, job.ID, opts)
+		case "FAILED":
+			if job.Error != nil {
+				return fmt.Errorf("export failed: %s", *job.Error)
+			}
+			return errors.New("export failed")
+		}
+
+		time.Sleep(opts.PollInterval)
+
+		var polled struct {
+			Node *exportJob `json:"node"`
+		}
+		err := graphqlRequest(opts, getExportQuery, map[string]interface{}{
+			"id": job.ID,
+		}, &polled)
+		if err != nil {
+			return err
+		}
+		if polled.Node == nil {
+			return errors.New("export not found")
+		}
+		job = *polled.Node
+	}
+}
+
+func graphqlRequest(opts ExportOptions, query string, variables map[string]interface{}, data interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	})
+	if err != nil {
+		return err
+	}
+
+	url := strings.TrimSuffix(opts.Endpoint, "/") + "/graphql"
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if err := opts.AddAuthz(req.Header); err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("graphql error: %s", result.Errors[0].Message)
+	}
+
+	return json.Unmarshal(result.Data, data)
+}
+
+func download(output io.Writer, id string, opts ExportOptions) error {
+	url := strings.TrimSuffix(opts.Endpoint, "/") + "/users/exports/" + id
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return err
+	}
+	if err := opts.AddAuthz(req.Header); err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	_, err = io.Copy(output, resp.Body)
+	return err
+}
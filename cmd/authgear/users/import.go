@@ -0,0 +1,144 @@
+package users
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/authgear/authgear-server/pkg/lib/feature/userimport"
+)
+
+// maxBatchBytes keeps each request well within the request body limit of
+// the Admin API.
+const maxBatchBytes = 512 * 1024
+
+type ImportOptions struct {
+	// Endpoint is the base URL of the Admin API server.
+	Endpoint string
+	// BatchSize is the maximum number of records sent in a request.
+	BatchSize int
+	// AddAuthz adds the authorization header to the request.
+	AddAuthz func(hdr http.Header) error
+}
+
+type ImportSummary struct {
+	Created int
+	Skipped int
+	Failed  int
+}
+
+type batch struct {
+	// lineIndexes maps the index of record in the batch to line index of input.
+	lineIndexes []int
+	body        bytes.Buffer
+}
+
+// Import reads NDJSON records from input, imports them in batches, and
+// writes the result of each record as NDJSON to output. Index of the results
+// are zero-based line numbers of input.
+func Import(input io.Reader, output io.Writer, opts ImportOptions) (*ImportSummary, error) {
+	summary := &ImportSummary{}
+	encoder := json.NewEncoder(output)
+
+	reader := bufio.NewReader(input)
+	b := &batch{}
+	lineIndex := 0
+	for {
+		line, readErr := reader.ReadBytes('\n')
+		if readErr != nil && readErr != io.EOF {
+			return summary, readErr
+		}
+
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 {
+			if len(line)+1 > maxBatchBytes {
+				err := writeResult(encoder, summary, userimport.NewFailedResult(
+					lineIndex,
+					userimport.InvalidRecord.New("record is too large"),
+				))
+				if err != nil {
+					return summary, err
+				}
+			} else {
+				if len(b.lineIndexes) >= opts.BatchSize || b.body.Len()+len(line)+1 > maxBatchBytes {
+					if err := send(b, encoder, summary, opts); err != nil {
+						return summary, err
+					}
+					b = &batch{}
+				}
+				b.lineIndexes = append(b.lineIndexes, lineIndex)
+				b.body.Write(line)
+				b.body.WriteByte('\n')
+			}
+		}
+
+		if readErr == io.EOF {
+			break
+		}
+		lineIndex++
+	}
+
+	if len(b.lineIndexes) > 0 {
+		if err := send(b, encoder, summary, opts); err != nil {
+			return summary, err
+		}
+	}
+
+	return summary, nil
+}
+
+func send(b *batch, encoder *json.Encoder, summary *ImportSummary, opts ImportOptions) error {
+	url := strings.TrimSuffix(opts.Endpoint, "/") + "/users/import"
+	req, err := http.NewRequest("POST", url, bytes.NewReader(b.body.Bytes()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	if err := opts.AddAuthz(req.Header); err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	for decoder.More() {
+		var result userimport.Result
+		if err := decoder.Decode(&result); err != nil {
+			return err
+		}
+		if result.Index < 0 || result.Index >= len(b.lineIndexes) {
+			return fmt.Errorf("unexpected result index: %d", result.Index)
+		}
+		result.Index = b.lineIndexes[result.Index]
+
+		if err := writeResult(encoder, summary, &result); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeResult(encoder *json.Encoder, summary *ImportSummary, result *userimport.Result) error {
+	switch result.Outcome {
+	case userimport.OutcomeCreated:
+		summary.Created++
+	case userimport.OutcomeSkipped:
+		summary.Skipped++
+	case userimport.OutcomeFailed:
+		summary.Failed++
+	}
+	return encoder.Encode(result)
+}
@@ -0,0 +1,16 @@
+-- +migrate Up
+
+CREATE TABLE _auth_user_lockout
+(
+    user_id         text PRIMARY KEY REFERENCES _auth_user (id),
+    app_id          text                        NOT NULL,
+    failed_attempts integer                     NOT NULL,
+    first_failed_at timestamp without time zone,
+    lockout_count   integer                     NOT NULL,
+    locked_until    timestamp without time zone,
+    updated_at      timestamp without time zone NOT NULL
+);
+
+-- +migrate Down
+
+DROP TABLE _auth_user_lockout;
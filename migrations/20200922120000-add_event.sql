@@ -0,0 +1,25 @@
+-- +migrate Up
+
+CREATE TABLE _auth_event
+(
+    id                 text PRIMARY KEY,
+    app_id             text                        NOT NULL,
+    seq                bigint                      NOT NULL,
+    type               text                        NOT NULL,
+    data               jsonb                       NOT NULL,
+    user_id            text,
+    created_at         timestamp without time zone NOT NULL,
+    status             text                        NOT NULL,
+    attempts           integer                     NOT NULL,
+    next_attempt_at    timestamp without time zone,
+    last_attempt_at    timestamp without time zone,
+    last_status_code   integer,
+    last_error         text,
+    delivered_handlers jsonb                       NOT NULL DEFAULT '[]'::jsonb
+);
+CREATE INDEX _auth_event_delivery_idx ON _auth_event (app_id, status, next_attempt_at);
+CREATE INDEX _auth_event_user_id_idx ON _auth_event (app_id, user_id);
+
+-- +migrate Down
+
+DROP TABLE _auth_event;
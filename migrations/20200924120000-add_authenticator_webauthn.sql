@@ -0,0 +1,19 @@
+-- +migrate Up
+
+CREATE TABLE _auth_authenticator_webauthn
+(
+    id               text PRIMARY KEY REFERENCES _auth_authenticator (id),
+    app_id           text   NOT NULL,
+    credential_id    text   NOT NULL,
+    public_key       bytea  NOT NULL,
+    aaguid           text   NOT NULL,
+    attestation_type text   NOT NULL,
+    sign_count       bigint NOT NULL,
+    display_name     text   NOT NULL
+);
+ALTER TABLE _auth_authenticator_webauthn
+    ADD CONSTRAINT _auth_authenticator_webauthn_key UNIQUE (app_id, credential_id);
+
+-- +migrate Down
+
+DROP TABLE _auth_authenticator_webauthn;
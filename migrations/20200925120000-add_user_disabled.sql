@@ -0,0 +1,11 @@
+-- +migrate Up
+
+ALTER TABLE _auth_user ADD COLUMN is_disabled boolean NOT NULL DEFAULT false;
+ALTER TABLE _auth_user ADD COLUMN disable_reason text;
+ALTER TABLE _auth_user ADD COLUMN disabled_until timestamp without time zone;
+
+-- +migrate Down
+
+ALTER TABLE _auth_user DROP COLUMN disabled_until;
+ALTER TABLE _auth_user DROP COLUMN disable_reason;
+ALTER TABLE _auth_user DROP COLUMN is_disabled;
@@ -0,0 +1,9 @@
+-- +migrate Up
+
+ALTER TABLE _auth_user ADD COLUMN delete_at timestamp without time zone;
+CREATE INDEX _auth_user_delete_at_idx ON _auth_user (app_id, delete_at) WHERE delete_at IS NOT NULL;
+
+-- +migrate Down
+
+DROP INDEX _auth_user_delete_at_idx;
+ALTER TABLE _auth_user DROP COLUMN delete_at;
@@ -0,0 +1,7 @@
+-- +migrate Up
+
+ALTER TABLE _auth_user ADD COLUMN profile jsonb NOT NULL DEFAULT '{}'::jsonb;
+
+-- +migrate Down
+
+ALTER TABLE _auth_user DROP COLUMN profile;
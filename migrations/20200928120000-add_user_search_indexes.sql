@@ -0,0 +1,21 @@
+-- +migrate Up
+
+CREATE INDEX _auth_user_id_prefix_idx ON _auth_user (app_id, id text_pattern_ops);
+CREATE INDEX _auth_user_created_at_idx ON _auth_user (app_id, created_at);
+CREATE INDEX _auth_user_last_login_at_idx ON _auth_user (app_id, last_login_at);
+CREATE INDEX _auth_user_labels_idx ON _auth_user USING gin (labels jsonb_path_ops);
+CREATE INDEX _auth_identity_user_id_idx ON _auth_identity (user_id, type);
+CREATE INDEX _auth_identity_login_id_search_idx ON _auth_identity_login_id (app_id, lower(login_id) text_pattern_ops);
+CREATE INDEX _auth_identity_oauth_provider_user_id_idx ON _auth_identity_oauth (app_id, provider_user_id);
+CREATE INDEX _auth_verified_claim_user_id_idx ON _auth_verified_claim (user_id);
+
+-- +migrate Down
+
+DROP INDEX _auth_verified_claim_user_id_idx;
+DROP INDEX _auth_identity_oauth_provider_user_id_idx;
+DROP INDEX _auth_identity_login_id_search_idx;
+DROP INDEX _auth_identity_user_id_idx;
+DROP INDEX _auth_user_labels_idx;
+DROP INDEX _auth_user_last_login_at_idx;
+DROP INDEX _auth_user_created_at_idx;
+DROP INDEX _auth_user_id_prefix_idx;
@@ -0,0 +1,21 @@
+-- +migrate Up
+
+CREATE TABLE _auth_audit_log
+(
+    id         text PRIMARY KEY,
+    app_id     text                        NOT NULL,
+    created_at timestamp without time zone NOT NULL,
+    activity   text                        NOT NULL,
+    actor_type text                        NOT NULL,
+    actor_id   text,
+    user_id    text,
+    ip_address text,
+    user_agent text,
+    data       jsonb                       NOT NULL
+);
+CREATE INDEX _auth_audit_log_created_at_idx ON _auth_audit_log (app_id, created_at);
+CREATE INDEX _auth_audit_log_user_id_idx ON _auth_audit_log (app_id, user_id);
+
+-- +migrate Down
+
+DROP TABLE _auth_audit_log;
@@ -0,0 +1,33 @@
+-- +migrate Up
+
+CREATE TABLE _auth_user_export
+(
+    id              text PRIMARY KEY,
+    app_id          text                        NOT NULL,
+    created_at      timestamp without time zone NOT NULL,
+    updated_at      timestamp without time zone NOT NULL,
+    completed_at    timestamp without time zone,
+    expire_at       timestamp without time zone NOT NULL,
+    status          text                        NOT NULL,
+    format          text                        NOT NULL,
+    include_secrets boolean                     NOT NULL,
+    total_users     integer                     NOT NULL,
+    exported_users  integer                     NOT NULL,
+    error           text
+);
+CREATE INDEX _auth_user_export_created_at_idx ON _auth_user_export (app_id, created_at);
+CREATE INDEX _auth_user_export_expire_at_idx ON _auth_user_export (app_id, expire_at);
+
+CREATE TABLE _auth_user_export_chunk
+(
+    export_id text    NOT NULL REFERENCES _auth_user_export (id) ON DELETE CASCADE,
+    seq       integer NOT NULL,
+    app_id    text    NOT NULL,
+    data      bytea   NOT NULL,
+    PRIMARY KEY (export_id, seq)
+);
+
+-- +migrate Down
+
+DROP TABLE _auth_user_export_chunk;
+DROP TABLE _auth_user_export;
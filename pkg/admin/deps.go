@@ -8,16 +8,24 @@ import (
 	"github.com/authgear/authgear-server/pkg/admin/service"
 	"github.com/authgear/authgear-server/pkg/admin/transport"
 	adminauthz "github.com/authgear/authgear-server/pkg/lib/admin/authz"
+	"github.com/authgear/authgear-server/pkg/lib/audit"
+	authenticatorlockout "github.com/authgear/authgear-server/pkg/lib/authn/authenticator/lockout"
 	authenticatorservice "github.com/authgear/authgear-server/pkg/lib/authn/authenticator/service"
 	identityservice "github.com/authgear/authgear-server/pkg/lib/authn/identity/service"
+	"github.com/authgear/authgear-server/pkg/lib/authn/mfa"
 	"github.com/authgear/authgear-server/pkg/lib/authn/otp"
 	"github.com/authgear/authgear-server/pkg/lib/authn/sso"
 	"github.com/authgear/authgear-server/pkg/lib/authn/user"
 	"github.com/authgear/authgear-server/pkg/lib/deps"
 	"github.com/authgear/authgear-server/pkg/lib/feature/forgotpassword"
+	"github.com/authgear/authgear-server/pkg/lib/feature/userdeletion"
+	"github.com/authgear/authgear-server/pkg/lib/feature/userexport"
+	"github.com/authgear/authgear-server/pkg/lib/feature/userimport"
 	"github.com/authgear/authgear-server/pkg/lib/feature/verification"
+	"github.com/authgear/authgear-server/pkg/lib/hook"
 	"github.com/authgear/authgear-server/pkg/lib/infra/middleware"
 	"github.com/authgear/authgear-server/pkg/lib/interaction"
+	"github.com/authgear/authgear-server/pkg/lib/session"
 )
 
 var DependencySet = wire.NewSet(
@@ -28,16 +36,31 @@ var DependencySet = wire.NewSet(
 
 	loader.DependencySet,
 	wire.Bind(new(loader.UserService), new(*user.Queries)),
+	wire.Bind(new(loader.UserCommandService), new(*user.Commands)),
+	wire.Bind(new(loader.UserDeletionService), new(*userdeletion.Service)),
 	wire.Bind(new(loader.IdentityService), new(*identityservice.Service)),
 	wire.Bind(new(loader.AuthenticatorService), new(*authenticatorservice.Service)),
 	wire.Bind(new(loader.InteractionService), new(*service.InteractionService)),
 	wire.Bind(new(loader.VerificationService), new(*verification.Service)),
+	wire.Bind(new(loader.LockoutService), new(*authenticatorlockout.Service)),
+	wire.Bind(new(loader.EventQueries), new(*hook.EventQueries)),
+	wire.Bind(new(loader.EventDeliveryService), new(*hook.DeliveryService)),
+	wire.Bind(new(loader.AuditLogQueries), new(*audit.Queries)),
+	wire.Bind(new(loader.UserExportService), new(*userexport.Service)),
+	wire.Bind(new(loader.SessionManager), new(*session.Manager)),
+	wire.Bind(new(loader.MFAService), new(*mfa.Service)),
+	wire.Bind(new(transport.UserExportService), new(*userexport.Service)),
 
 	graphql.DependencySet,
 	wire.Bind(new(graphql.UserLoader), new(*loader.UserLoader)),
 	wire.Bind(new(graphql.IdentityLoader), new(*loader.IdentityLoader)),
 	wire.Bind(new(graphql.AuthenticatorLoader), new(*loader.AuthenticatorLoader)),
 	wire.Bind(new(graphql.VerificationLoader), new(*loader.VerificationLoader)),
+	wire.Bind(new(graphql.EventLoader), new(*loader.EventLoader)),
+	wire.Bind(new(graphql.AuditLogLoader), new(*loader.AuditLogLoader)),
+	wire.Bind(new(graphql.UserExportLoader), new(*loader.UserExportLoader)),
+	wire.Bind(new(graphql.SessionLoader), new(*loader.SessionLoader)),
+	wire.Bind(new(graphql.AuditLogger), new(*audit.Service)),
 
 	service.DependencySet,
 	wire.Bind(new(service.InteractionGraphService), new(*interaction.Service)),
@@ -50,5 +73,6 @@ var DependencySet = wire.NewSet(
 	wire.Bind(new(forgotpassword.URLProvider), new(*WebEndpoints)),
 
 	transport.DependencySet,
+	wire.Bind(new(transport.UserImporter), new(*userimport.Service)),
 	adminauthz.DependencySet,
 )
@@ -0,0 +1,146 @@
+package graphql
+
+import (
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/relay"
+
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+	"github.com/authgear/authgear-server/pkg/lib/audit"
+	"github.com/authgear/authgear-server/pkg/util/graphqlutil"
+)
+
+const typeAuditLog = "AuditLog"
+
+var auditLogActorType = graphql.NewEnum(graphql.EnumConfig{
+	Name: "AuditLogActorType",
+	Values: graphql.EnumValueConfigMap{
+		"ANONYMOUS": &graphql.EnumValueConfig{
+			Value: string(audit.ActorTypeAnonymous),
+		},
+		"USER": &graphql.EnumValueConfig{
+			Value: string(audit.ActorTypeUser),
+		},
+		"ADMIN": &graphql.EnumValueConfig{
+			Value: string(audit.ActorTypeAdmin),
+		},
+		"PORTAL": &graphql.EnumValueConfig{
+			Value: string(audit.ActorTypePortal),
+		},
+	},
+})
+
+var nodeAuditLog = entity(
+	graphql.NewObject(graphql.ObjectConfig{
+		Name:        typeAuditLog,
+		Description: "Audit log entry",
+		Interfaces: []*graphql.Interface{
+			nodeDefs.NodeInterface,
+		},
+		Fields: graphql.Fields{
+			"id": entityIDField(typeAuditLog, func(obj interface{}) (string, error) {
+				return obj.(*audit.Log).ID, nil
+			}),
+			"createdAt": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.DateTime),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*audit.Log).CreatedAt, nil
+				},
+			},
+			"activity": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return string(p.Source.(*audit.Log).Activity), nil
+				},
+			},
+			"actorType": &graphql.Field{
+				Type: graphql.NewNonNull(auditLogActorType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return string(p.Source.(*audit.Log).Actor.Type), nil
+				},
+			},
+			"actorID": &graphql.Field{
+				Type:        graphql.String,
+				Description: "ID of the actor, if identified",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if id := p.Source.(*audit.Log).Actor.ID; id != "" {
+						return id, nil
+					}
+					return nil, nil
+				},
+			},
+			"user": &graphql.Field{
+				Type:        nodeUser,
+				Description: "The user the activity is about",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					l := p.Source.(*audit.Log)
+					if l.UserID == "" {
+						return nil, nil
+					}
+					return GQLContext(p.Context).Users.Get(l.UserID).Value, nil
+				},
+			},
+			"ipAddress": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if ip := p.Source.(*audit.Log).IPAddress; ip != "" {
+						return ip, nil
+					}
+					return nil, nil
+				},
+			},
+			"userAgent": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if ua := p.Source.(*audit.Log).UserAgent; ua != "" {
+						return ua, nil
+					}
+					return nil, nil
+				},
+			},
+			"data": &graphql.Field{
+				Type:        graphql.NewNonNull(AuditLogData),
+				Description: "Details of the activity",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*audit.Log).Data, nil
+				},
+			},
+		},
+	}),
+	&audit.Log{},
+	func(ctx *Context, id string) (interface{}, error) {
+		return ctx.AuditLogs.Get(id).Value, nil
+	},
+)
+
+var connAuditLog = graphqlutil.NewConnectionDef(nodeAuditLog)
+
+func parseAuditLogFilter(args map[string]interface{}) (audit.Filter, error) {
+	var filter audit.Filter
+
+	if activity, ok := args["activity"].(string); ok {
+		filter.Activity = audit.Activity(activity)
+	}
+	if userNodeID, ok := args["userID"].(string); ok {
+		resolvedNodeID := relay.FromGlobalID(userNodeID)
+		if resolvedNodeID == nil || resolvedNodeID.Type != typeUser {
+			return filter, apierrors.NewInvalid("invalid user ID")
+		}
+		filter.UserID = resolvedNodeID.ID
+	}
+	if actorType, ok := args["actorType"].(string); ok {
+		filter.ActorType = audit.ActorType(actorType)
+	}
+	if actorID, ok := args["actorID"].(string); ok {
+		filter.ActorID = actorID
+	}
+	if t, ok := args["createdAfter"].(time.Time); ok {
+		filter.CreatedAfter = &t
+	}
+	if t, ok := args["createdBefore"].(time.Time); ok {
+		filter.CreatedBefore = &t
+	}
+
+	return filter, nil
+}
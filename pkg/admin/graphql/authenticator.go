@@ -28,6 +28,9 @@ var authenticatorType = graphql.NewEnum(graphql.EnumConfig{
 		"OOB_OTP": &graphql.EnumValueConfig{
 			Value: "oob_otp",
 		},
+		"WEBAUTHN": &graphql.EnumValueConfig{
+			Value: "webauthn",
+		},
 	},
 })
 
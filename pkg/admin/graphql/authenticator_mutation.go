@@ -29,6 +29,7 @@ var deleteAuthenticatorPayload = graphql.NewObject(graphql.ObjectConfig{
 
 var _ = registerMutationField(
 	"deleteAuthenticator",
+	[]string{"authenticatorID"},
 	&graphql.Field{
 		Description: "Delete authenticator of user",
 		Type:        graphql.NewNonNull(deleteAuthenticatorPayload),
@@ -2,20 +2,34 @@ package graphql
 
 import (
 	"context"
+	"time"
 
 	"github.com/authgear/authgear-server/pkg/admin/model"
+	"github.com/authgear/authgear-server/pkg/lib/audit"
 	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator"
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity"
+	"github.com/authgear/authgear-server/pkg/lib/authn/user"
+	"github.com/authgear/authgear-server/pkg/lib/feature/userexport"
+	"github.com/authgear/authgear-server/pkg/lib/hook"
+	"github.com/authgear/authgear-server/pkg/lib/session"
 	"github.com/authgear/authgear-server/pkg/util/graphqlutil"
 	"github.com/authgear/authgear-server/pkg/util/log"
 )
 
 type UserLoader interface {
 	Get(id string) *graphqlutil.Lazy
-	QueryPage(args graphqlutil.PageArgs) (*graphqlutil.PageResult, error)
+	QueryPage(filter user.Filter, sort user.Sort, args graphqlutil.PageArgs) (*graphqlutil.PageResult, error)
 
 	Create(identityDef model.IdentityDef, password string) *graphqlutil.Lazy
 	ResetPassword(id string, password string) *graphqlutil.Lazy
+	GetLockedUntil(id string) *graphqlutil.Lazy
+	Unlock(id string) *graphqlutil.Lazy
+	Disable(id string, reason *string, until *time.Time) *graphqlutil.Lazy
+	Reenable(id string) *graphqlutil.Lazy
+	UpdateProfile(id string, profile map[string]interface{}) *graphqlutil.Lazy
+	Delete(id string) *graphqlutil.Lazy
+	ScheduleDeletion(id string, deleteAt time.Time) *graphqlutil.Lazy
+	UnscheduleDeletion(id string) *graphqlutil.Lazy
 }
 
 type IdentityLoader interface {
@@ -39,6 +53,37 @@ type VerificationLoader interface {
 	SetVerified(userID string, claimName string, claimValue string, isVerified bool) *graphqlutil.Lazy
 }
 
+type EventLoader interface {
+	Get(id string) *graphqlutil.Lazy
+	QueryPage(filter hook.EventFilter, args graphqlutil.PageArgs) (*graphqlutil.PageResult, error)
+
+	Redeliver(id string) *graphqlutil.Lazy
+	SendTestEvent(url string) *graphqlutil.Lazy
+}
+
+type AuditLogLoader interface {
+	Get(id string) *graphqlutil.Lazy
+	QueryPage(filter audit.Filter, args graphqlutil.PageArgs) (*graphqlutil.PageResult, error)
+}
+
+type SessionLoader interface {
+	Get(id string) *graphqlutil.Lazy
+	List(userID string) *graphqlutil.Lazy
+	Revoke(session session.Session) *graphqlutil.Lazy
+	RevokeAll(userID string) *graphqlutil.Lazy
+	RevokeAllMFADeviceTokens(userID string) *graphqlutil.Lazy
+}
+
+type UserExportLoader interface {
+	Get(id string) *graphqlutil.Lazy
+
+	Create(format userexport.Format, includeSecrets bool) *graphqlutil.Lazy
+}
+
+type AuditLogger interface {
+	Log(activity audit.Activity, userID string, data map[string]interface{})
+}
+
 type Logger struct{ *log.Logger }
 
 func NewLogger(lf *log.Factory) Logger { return Logger{lf.New("admin-graphql")} }
@@ -49,6 +94,11 @@ type Context struct {
 	Identities     IdentityLoader
 	Authenticators AuthenticatorLoader
 	Verification   VerificationLoader
+	Events         EventLoader
+	AuditLogs      AuditLogLoader
+	UserExports    UserExportLoader
+	Sessions       SessionLoader
+	AuditLogger    AuditLogger
 }
 
 func (c *Context) Logger() *log.Logger {
@@ -0,0 +1,128 @@
+package graphql
+
+import (
+	"encoding/json"
+
+	"github.com/graphql-go/graphql"
+
+	"github.com/authgear/authgear-server/pkg/lib/hook"
+	"github.com/authgear/authgear-server/pkg/util/graphqlutil"
+)
+
+const typeEvent = "Event"
+
+var eventDeliveryStatus = graphql.NewEnum(graphql.EnumConfig{
+	Name: "EventDeliveryStatus",
+	Values: graphql.EnumValueConfigMap{
+		"PENDING": &graphql.EnumValueConfig{
+			Value: string(hook.DeliveryStatusPending),
+		},
+		"SUCCESS": &graphql.EnumValueConfig{
+			Value: string(hook.DeliveryStatusSuccess),
+		},
+		"FAILED": &graphql.EnumValueConfig{
+			Value: string(hook.DeliveryStatusFailed),
+		},
+	},
+})
+
+var nodeEvent = entity(
+	graphql.NewObject(graphql.ObjectConfig{
+		Name:        typeEvent,
+		Description: "Web-hook event",
+		Interfaces: []*graphql.Interface{
+			nodeDefs.NodeInterface,
+		},
+		Fields: graphql.Fields{
+			"id": entityIDField(typeEvent, func(obj interface{}) (string, error) {
+				return obj.(*hook.StoredEvent).ID, nil
+			}),
+			"seq": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*hook.StoredEvent).Seq, nil
+				},
+			},
+			"type": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return string(p.Source.(*hook.StoredEvent).Type), nil
+				},
+			},
+			"user": &graphql.Field{
+				Type:        nodeUser,
+				Description: "The user the event is about",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					e := p.Source.(*hook.StoredEvent)
+					if e.UserID == "" {
+						return nil, nil
+					}
+					return GQLContext(p.Context).Users.Get(e.UserID).Value, nil
+				},
+			},
+			"payload": &graphql.Field{
+				Type:        graphql.NewNonNull(EventPayload),
+				Description: "The event as delivered to web-hook handlers",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					var payload map[string]interface{}
+					err := json.Unmarshal(p.Source.(*hook.StoredEvent).Data, &payload)
+					if err != nil {
+						return nil, err
+					}
+					return payload, nil
+				},
+			},
+			"createdAt": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.DateTime),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*hook.StoredEvent).CreatedAt, nil
+				},
+			},
+			"status": &graphql.Field{
+				Type: graphql.NewNonNull(eventDeliveryStatus),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return string(p.Source.(*hook.StoredEvent).Status), nil
+				},
+			},
+			"attempts": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "Number of delivery attempts",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*hook.StoredEvent).Attempts, nil
+				},
+			},
+			"nextAttemptAt": &graphql.Field{
+				Type: graphql.DateTime,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*hook.StoredEvent).NextAttemptAt, nil
+				},
+			},
+			"lastAttemptAt": &graphql.Field{
+				Type: graphql.DateTime,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*hook.StoredEvent).LastAttemptAt, nil
+				},
+			},
+			"lastStatusCode": &graphql.Field{
+				Type:        graphql.Int,
+				Description: "HTTP status code of the last failed delivery attempt",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*hook.StoredEvent).LastStatusCode, nil
+				},
+			},
+			"lastError": &graphql.Field{
+				Type:        graphql.String,
+				Description: "Error of the last failed delivery attempt",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*hook.StoredEvent).LastError, nil
+				},
+			},
+		},
+	}),
+	&hook.StoredEvent{},
+	func(ctx *Context, id string) (interface{}, error) {
+		return ctx.Events.Get(id).Value, nil
+	},
+)
+
+var connEvent = graphqlutil.NewConnectionDef(nodeEvent)
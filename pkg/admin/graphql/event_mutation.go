@@ -0,0 +1,155 @@
+package graphql
+
+import (
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/relay"
+
+	"github.com/authgear/authgear-server/pkg/admin/model"
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+	"github.com/authgear/authgear-server/pkg/api/event"
+	"github.com/authgear/authgear-server/pkg/lib/hook"
+)
+
+func parseEventFilter(args map[string]interface{}) (hook.EventFilter, error) {
+	var filter hook.EventFilter
+
+	if typ, ok := args["type"].(string); ok {
+		filter.Type = event.Type(typ)
+	}
+	if userNodeID, ok := args["userID"].(string); ok {
+		resolvedNodeID := relay.FromGlobalID(userNodeID)
+		if resolvedNodeID == nil || resolvedNodeID.Type != typeUser {
+			return filter, apierrors.NewInvalid("invalid user ID")
+		}
+		filter.UserID = resolvedNodeID.ID
+	}
+	if status, ok := args["status"].(string); ok {
+		filter.Status = hook.DeliveryStatus(status)
+	}
+	if t, ok := args["createdAfter"].(time.Time); ok {
+		filter.CreatedAfter = &t
+	}
+	if t, ok := args["createdBefore"].(time.Time); ok {
+		filter.CreatedBefore = &t
+	}
+
+	return filter, nil
+}
+
+var redeliverEventInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "RedeliverEventInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"eventID": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewNonNull(graphql.ID),
+			Description: "Target event ID.",
+		},
+	},
+})
+
+var redeliverEventPayload = graphql.NewObject(graphql.ObjectConfig{
+	Name: "RedeliverEventPayload",
+	Fields: graphql.Fields{
+		"event": &graphql.Field{
+			Type: graphql.NewNonNull(nodeEvent),
+		},
+	},
+})
+
+var _ = registerMutationField(
+	"redeliverEvent",
+	[]string{"eventID"},
+	&graphql.Field{
+		Description: "Deliver web-hook event immediately",
+		Type:        graphql.NewNonNull(redeliverEventPayload),
+		Args: graphql.FieldConfigArgument{
+			"input": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(redeliverEventInput),
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			input := p.Args["input"].(map[string]interface{})
+
+			eventNodeID := input["eventID"].(string)
+			resolvedNodeID := relay.FromGlobalID(eventNodeID)
+			if resolvedNodeID == nil || resolvedNodeID.Type != typeEvent {
+				return nil, apierrors.NewInvalid("invalid event ID")
+			}
+			eventID := resolvedNodeID.ID
+
+			gqlCtx := GQLContext(p.Context)
+			return gqlCtx.Events.Get(eventID).
+				Map(func(e interface{}) (interface{}, error) {
+					if e == nil {
+						return nil, apierrors.NewNotFound("event not found")
+					}
+					return gqlCtx.Events.Redeliver(eventID), nil
+				}).
+				Map(func(e interface{}) (interface{}, error) {
+					return map[string]interface{}{
+						"event": e,
+					}, nil
+				}).
+				Value, nil
+		},
+	},
+)
+
+var sendTestEventInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "SendTestEventInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"url": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewNonNull(graphql.String),
+			Description: "URL of the web-hook handler.",
+		},
+	},
+})
+
+var sendTestEventPayload = graphql.NewObject(graphql.ObjectConfig{
+	Name: "SendTestEventPayload",
+	Fields: graphql.Fields{
+		"success": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.Boolean),
+			Description: "Indicate whether the handler accepted the event.",
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*model.TestEventResult).Success, nil
+			},
+		},
+		"statusCode": &graphql.Field{
+			Type:        graphql.Int,
+			Description: "HTTP status code of the handler response, if rejected.",
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*model.TestEventResult).StatusCode, nil
+			},
+		},
+		"error": &graphql.Field{
+			Type:        graphql.String,
+			Description: "Delivery error, if any.",
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*model.TestEventResult).Error, nil
+			},
+		},
+	},
+})
+
+var _ = registerMutationField(
+	"sendTestEvent",
+	[]string{"url"},
+	&graphql.Field{
+		Description: "Send a signed test event to web-hook handler",
+		Type:        graphql.NewNonNull(sendTestEventPayload),
+		Args: graphql.FieldConfigArgument{
+			"input": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(sendTestEventInput),
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			input := p.Args["input"].(map[string]interface{})
+			url := input["url"].(string)
+
+			gqlCtx := GQLContext(p.Context)
+			return gqlCtx.Events.SendTestEvent(url).Value, nil
+		},
+	},
+)
@@ -30,6 +30,7 @@ var deleteIdentityPayload = graphql.NewObject(graphql.ObjectConfig{
 
 var _ = registerMutationField(
 	"deleteIdentity",
+	[]string{"identityID"},
 	&graphql.Field{
 		Description: "Delete identity of user",
 		Type:        graphql.NewNonNull(deleteIdentityPayload),
@@ -101,6 +102,7 @@ var createIdentityPayload = graphql.NewObject(graphql.ObjectConfig{
 
 var _ = registerMutationField(
 	"createIdentity",
+	[]string{"userID", "definition"},
 	&graphql.Field{
 		Description: "Create new identity for user",
 		Type:        graphql.NewNonNull(createIdentityPayload),
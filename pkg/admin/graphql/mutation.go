@@ -2,13 +2,68 @@ package graphql
 
 import (
 	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/relay"
+
+	"github.com/authgear/authgear-server/pkg/lib/audit"
+	"github.com/authgear/authgear-server/pkg/lib/authn/user"
+	"github.com/authgear/authgear-server/pkg/util/graphqlutil"
 )
 
-func registerMutationField(name string, field *graphql.Field) *graphql.Field {
+// registerMutationField registers the mutation. Every successful invocation
+// of the mutation is recorded in the audit log, with the input fields listed
+// in loggedFields only.
+func registerMutationField(name string, loggedFields []string, field *graphql.Field) *graphql.Field {
+	resolve := field.Resolve
+	field.Resolve = func(p graphql.ResolveParams) (interface{}, error) {
+		result, err := resolve(p)
+		if err != nil {
+			return nil, err
+		}
+		return graphqlutil.NewLazyValue(result).
+			Map(func(payload interface{}) (interface{}, error) {
+				logMutation(p, name, loggedFields, payload)
+				return payload, nil
+			}).
+			Value, nil
+	}
+
 	mutationFields[name] = field
 	return field
 }
 
+// logMutation records the mutation in the audit log. The target user is
+// taken from the user in the payload, so that mutations targeting sessions,
+// identities or authenticators are recorded against their user.
+func logMutation(p graphql.ResolveParams, name string, loggedFields []string, payload interface{}) {
+	input, _ := p.Args["input"].(map[string]interface{})
+
+	data := map[string]interface{}{}
+	for _, k := range loggedFields {
+		if v, ok := input[k]; ok {
+			data[k] = v
+		}
+	}
+
+	var userID string
+	if m, ok := payload.(map[string]interface{}); ok {
+		if u, ok := m["user"].(*user.User); ok {
+			userID = u.ID
+		}
+	}
+	if userID == "" {
+		if userNodeID, ok := input["userID"].(string); ok {
+			if resolved := relay.FromGlobalID(userNodeID); resolved != nil && resolved.Type == typeUser {
+				userID = resolved.ID
+			}
+		}
+	}
+
+	GQLContext(p.Context).AuditLogger.Log(audit.ActivityAdminMutation, userID, map[string]interface{}{
+		"mutation": name,
+		"input":    data,
+	})
+}
+
 var mutationFields = graphql.Fields{}
 
 var mutation = graphql.NewObject(graphql.ObjectConfig{
@@ -4,6 +4,7 @@ import (
 	"github.com/graphql-go/graphql"
 	"github.com/graphql-go/relay"
 
+	"github.com/authgear/authgear-server/pkg/lib/authn/user"
 	"github.com/authgear/authgear-server/pkg/util/graphqlutil"
 )
 
@@ -14,10 +15,114 @@ var query = graphql.NewObject(graphql.ObjectConfig{
 		"users": &graphql.Field{
 			Description: "All users",
 			Type:        connUser.ConnectionType,
-			Args:        relay.ConnectionArgs,
+			Args: relay.NewConnectionArgs(graphql.FieldConfigArgument{
+				"searchKeyword": &graphql.ArgumentConfig{
+					Type:        graphql.String,
+					Description: "Search by login ID prefix, OAuth subject, or user ID prefix.",
+				},
+				"filter": &graphql.ArgumentConfig{
+					Type:        userFilter,
+					Description: "Filter the users.",
+				},
+				"sortBy": &graphql.ArgumentConfig{
+					Type:         userSortBy,
+					DefaultValue: string(user.SortByCreatedAt),
+					Description:  "Sort the users by the given column.",
+				},
+				"sortDirection": &graphql.ArgumentConfig{
+					Type:         sortDirection,
+					DefaultValue: string(user.SortDirectionAsc),
+					Description:  "Sort direction.",
+				},
+			}),
 			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				filter := parseUserFilter(p.Args)
+				sort := parseUserSort(p.Args)
+
+				args := relay.NewConnectionArguments(p.Args)
+				result, err := GQLContext(p.Context).Users.QueryPage(filter, sort, graphqlutil.NewPageArgs(args))
+				if err != nil {
+					return nil, err
+				}
+				return graphqlutil.NewConnection(result), nil
+			},
+		},
+		"events": &graphql.Field{
+			Description: "Persisted web-hook events",
+			Type:        connEvent.ConnectionType,
+			Args: relay.NewConnectionArgs(graphql.FieldConfigArgument{
+				"type": &graphql.ArgumentConfig{
+					Type:        graphql.String,
+					Description: "Filter by event type.",
+				},
+				"userID": &graphql.ArgumentConfig{
+					Type:        graphql.ID,
+					Description: "Filter by user ID.",
+				},
+				"status": &graphql.ArgumentConfig{
+					Type:        eventDeliveryStatus,
+					Description: "Filter by delivery status.",
+				},
+				"createdAfter": &graphql.ArgumentConfig{
+					Type:        graphql.DateTime,
+					Description: "Filter by creation time (inclusive).",
+				},
+				"createdBefore": &graphql.ArgumentConfig{
+					Type:        graphql.DateTime,
+					Description: "Filter by creation time (exclusive).",
+				},
+			}),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				filter, err := parseEventFilter(p.Args)
+				if err != nil {
+					return nil, err
+				}
+
+				args := relay.NewConnectionArguments(p.Args)
+				result, err := GQLContext(p.Context).Events.QueryPage(filter, graphqlutil.NewPageArgs(args))
+				if err != nil {
+					return nil, err
+				}
+				return graphqlutil.NewConnection(result), nil
+			},
+		},
+		"auditLogs": &graphql.Field{
+			Description: "Audit logs, latest first",
+			Type:        connAuditLog.ConnectionType,
+			Args: relay.NewConnectionArgs(graphql.FieldConfigArgument{
+				"activity": &graphql.ArgumentConfig{
+					Type:        graphql.String,
+					Description: "Filter by activity.",
+				},
+				"userID": &graphql.ArgumentConfig{
+					Type:        graphql.ID,
+					Description: "Filter by user ID.",
+				},
+				"actorType": &graphql.ArgumentConfig{
+					Type:        auditLogActorType,
+					Description: "Filter by actor type.",
+				},
+				"actorID": &graphql.ArgumentConfig{
+					Type:        graphql.String,
+					Description: "Filter by actor ID.",
+				},
+				"createdAfter": &graphql.ArgumentConfig{
+					Type:        graphql.DateTime,
+					Description: "Filter by creation time (inclusive).",
+				},
+				"createdBefore": &graphql.ArgumentConfig{
+					Type:        graphql.DateTime,
+					Description: "Filter by creation time (exclusive).",
+				},
+			}),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				filter, err := parseAuditLogFilter(p.Args)
+				if err != nil {
+					return nil, err
+				}
+
 				args := relay.NewConnectionArguments(p.Args)
-				result, err := GQLContext(p.Context).Users.QueryPage(graphqlutil.NewPageArgs(args))
+				result, err := GQLContext(p.Context).AuditLogs.QueryPage(filter, graphqlutil.NewPageArgs(args))
 				if err != nil {
 					return nil, err
 				}
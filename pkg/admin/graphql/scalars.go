@@ -11,3 +11,23 @@ var AuthenticatorClaims = graphqlutil.NewJSONObjectScalar(
 	"AuthenticatorClaims",
 	"The `AuthenticatorClaims` scalar type represents a set of claims belonging to an authenticator",
 )
+
+var UserProfile = graphqlutil.NewJSONObjectScalar(
+	"UserProfile",
+	"The `UserProfile` scalar type represents the custom profile attributes of a user",
+)
+
+var UserLabels = graphqlutil.NewJSONObjectScalar(
+	"UserLabels",
+	"The `UserLabels` scalar type represents the labels of a user",
+)
+
+var EventPayload = graphqlutil.NewJSONObjectScalar(
+	"EventPayload",
+	"The `EventPayload` scalar type represents a web-hook event as delivered to handlers",
+)
+
+var AuditLogData = graphqlutil.NewJSONObjectScalar(
+	"AuditLogData",
+	"The `AuditLogData` scalar type represents the details of an audited activity",
+)
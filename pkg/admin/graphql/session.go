@@ -0,0 +1,320 @@
+package graphql
+
+import (
+	"reflect"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/relay"
+
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+	"github.com/authgear/authgear-server/pkg/api/model"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/lib/session"
+	"github.com/authgear/authgear-server/pkg/lib/session/idpsession"
+	"github.com/authgear/authgear-server/pkg/util/graphqlutil"
+)
+
+const typeSession = "Session"
+
+var sessionType = graphql.NewEnum(graphql.EnumConfig{
+	Name: "SessionType",
+	Values: graphql.EnumValueConfigMap{
+		"IDP": &graphql.EnumValueConfig{
+			Value: string(session.TypeIdentityProvider),
+		},
+		"OFFLINE_GRANT": &graphql.EnumValueConfig{
+			Value: string(session.TypeOfflineGrant),
+		},
+	},
+})
+
+var userAgent = graphql.NewObject(graphql.ObjectConfig{
+	Name: "UserAgent",
+	Fields: graphql.Fields{
+		"raw": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.String),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(model.UserAgent).Raw, nil
+			},
+		},
+		"name": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.String),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(model.UserAgent).Name, nil
+			},
+		},
+		"version": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.String),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(model.UserAgent).Version, nil
+			},
+		},
+		"os": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.String),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(model.UserAgent).OS, nil
+			},
+		},
+		"osVersion": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.String),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(model.UserAgent).OSVersion, nil
+			},
+		},
+		"deviceModel": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.String),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(model.UserAgent).DeviceModel, nil
+			},
+		},
+	},
+})
+
+var nodeSession = entity(
+	graphql.NewObject(graphql.ObjectConfig{
+		Name:        typeSession,
+		Description: "Session of user, either an IdP session or an offline grant",
+		Interfaces: []*graphql.Interface{
+			nodeDefs.NodeInterface,
+		},
+		Fields: graphql.Fields{
+			"id": entityIDField(typeSession, func(obj interface{}) (string, error) {
+				return obj.(session.Session).SessionID(), nil
+			}),
+			"type": &graphql.Field{
+				Type: graphql.NewNonNull(sessionType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return string(p.Source.(session.Session).SessionType()), nil
+				},
+			},
+			"clientID": &graphql.Field{
+				Type:        graphql.String,
+				Description: "The OAuth client of offline grant",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if clientID := p.Source.(session.Session).GetClientID(); clientID != "" {
+						return clientID, nil
+					}
+					return nil, nil
+				},
+			},
+			"createdAt": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.DateTime),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(session.Session).GetCreatedAt(), nil
+				},
+			},
+			"lastAccessedAt": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.DateTime),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(session.Session).ToAPIModel().LastAccessedAt, nil
+				},
+			},
+			"createdByIP": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(session.Session).ToAPIModel().CreatedByIP, nil
+				},
+			},
+			"lastAccessedByIP": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(session.Session).ToAPIModel().LastAccessedByIP, nil
+				},
+			},
+			"userAgent": &graphql.Field{
+				Type:        graphql.NewNonNull(userAgent),
+				Description: "The user agent of last access",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(session.Session).ToAPIModel().UserAgent, nil
+				},
+			},
+		},
+	}),
+	&idpsession.IDPSession{},
+	func(ctx *Context, id string) (interface{}, error) {
+		return ctx.Sessions.Get(id).Value, nil
+	},
+)
+
+func init() {
+	// Offline grants are sessions too.
+	entityTypes[reflect.TypeOf(&oauth.OfflineGrant{})] = nodeSession
+}
+
+var connSession = graphqlutil.NewConnectionDef(nodeSession)
+
+var revokeSessionInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "RevokeSessionInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"sessionID": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewNonNull(graphql.ID),
+			Description: "Target session ID.",
+		},
+	},
+})
+
+var revokeSessionPayload = graphql.NewObject(graphql.ObjectConfig{
+	Name: "RevokeSessionPayload",
+	Fields: graphql.Fields{
+		"user": &graphql.Field{
+			Type: graphql.NewNonNull(nodeUser),
+		},
+	},
+})
+
+var _ = registerMutationField(
+	"revokeSession",
+	[]string{"sessionID"},
+	&graphql.Field{
+		Description: "Revoke session of user",
+		Type:        graphql.NewNonNull(revokeSessionPayload),
+		Args: graphql.FieldConfigArgument{
+			"input": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(revokeSessionInput),
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			input := p.Args["input"].(map[string]interface{})
+
+			sessionNodeID := input["sessionID"].(string)
+			resolvedNodeID := relay.FromGlobalID(sessionNodeID)
+			if resolvedNodeID == nil || resolvedNodeID.Type != typeSession {
+				return nil, apierrors.NewInvalid("invalid session ID")
+			}
+			sessionID := resolvedNodeID.ID
+
+			gqlCtx := GQLContext(p.Context)
+			return gqlCtx.Sessions.Get(sessionID).
+				Map(func(value interface{}) (interface{}, error) {
+					if value == nil {
+						return nil, apierrors.NewNotFound("session not found")
+					}
+					s := value.(session.Session)
+					userID := s.SessionAttrs().UserID
+					return gqlCtx.Sessions.Revoke(s).MapTo(gqlCtx.Users.Get(userID)), nil
+				}).
+				Map(func(u interface{}) (interface{}, error) {
+					return map[string]interface{}{
+						"user": u,
+					}, nil
+				}).
+				Value, nil
+		},
+	},
+)
+
+var revokeAllSessionsInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "RevokeAllSessionsInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"userID": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewNonNull(graphql.ID),
+			Description: "Target user ID.",
+		},
+	},
+})
+
+var revokeAllSessionsPayload = graphql.NewObject(graphql.ObjectConfig{
+	Name: "RevokeAllSessionsPayload",
+	Fields: graphql.Fields{
+		"user": &graphql.Field{
+			Type: graphql.NewNonNull(nodeUser),
+		},
+	},
+})
+
+var _ = registerMutationField(
+	"revokeAllSessions",
+	[]string{"userID"},
+	&graphql.Field{
+		Description: "Revoke all sessions of user",
+		Type:        graphql.NewNonNull(revokeAllSessionsPayload),
+		Args: graphql.FieldConfigArgument{
+			"input": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(revokeAllSessionsInput),
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			input := p.Args["input"].(map[string]interface{})
+
+			userNodeID := input["userID"].(string)
+			resolvedNodeID := relay.FromGlobalID(userNodeID)
+			if resolvedNodeID == nil || resolvedNodeID.Type != typeUser {
+				return nil, apierrors.NewInvalid("invalid user ID")
+			}
+			userID := resolvedNodeID.ID
+
+			gqlCtx := GQLContext(p.Context)
+			return gqlCtx.Users.Get(userID).
+				Map(func(u interface{}) (interface{}, error) {
+					if u == nil {
+						return nil, apierrors.NewNotFound("user not found")
+					}
+					return gqlCtx.Sessions.RevokeAll(userID).MapTo(u), nil
+				}).
+				Map(func(u interface{}) (interface{}, error) {
+					return map[string]interface{}{
+						"user": u,
+					}, nil
+				}).
+				Value, nil
+		},
+	},
+)
+
+var revokeAllMFADeviceTokensInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "RevokeAllMFADeviceTokensInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"userID": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewNonNull(graphql.ID),
+			Description: "Target user ID.",
+		},
+	},
+})
+
+var revokeAllMFADeviceTokensPayload = graphql.NewObject(graphql.ObjectConfig{
+	Name: "RevokeAllMFADeviceTokensPayload",
+	Fields: graphql.Fields{
+		"user": &graphql.Field{
+			Type: graphql.NewNonNull(nodeUser),
+		},
+	},
+})
+
+var _ = registerMutationField(
+	"revokeAllMFADeviceTokens",
+	[]string{"userID"},
+	&graphql.Field{
+		Description: "Revoke all remembered MFA devices of user",
+		Type:        graphql.NewNonNull(revokeAllMFADeviceTokensPayload),
+		Args: graphql.FieldConfigArgument{
+			"input": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(revokeAllMFADeviceTokensInput),
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			input := p.Args["input"].(map[string]interface{})
+
+			userNodeID := input["userID"].(string)
+			resolvedNodeID := relay.FromGlobalID(userNodeID)
+			if resolvedNodeID == nil || resolvedNodeID.Type != typeUser {
+				return nil, apierrors.NewInvalid("invalid user ID")
+			}
+			userID := resolvedNodeID.ID
+
+			gqlCtx := GQLContext(p.Context)
+			return gqlCtx.Users.Get(userID).
+				Map(func(u interface{}) (interface{}, error) {
+					if u == nil {
+						return nil, apierrors.NewNotFound("user not found")
+					}
+					return gqlCtx.Sessions.RevokeAllMFADeviceTokens(userID).MapTo(u), nil
+				}).
+				Map(func(u interface{}) (interface{}, error) {
+					return map[string]interface{}{
+						"user": u,
+					}, nil
+				}).
+				Value, nil
+		},
+	},
+)
@@ -1,12 +1,15 @@
 package graphql
 
 import (
+	"time"
+
 	"github.com/graphql-go/graphql"
 	"github.com/graphql-go/relay"
 
 	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator"
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity"
 	"github.com/authgear/authgear-server/pkg/lib/authn/user"
+	"github.com/authgear/authgear-server/pkg/lib/session"
 	"github.com/authgear/authgear-server/pkg/util/graphqlutil"
 )
 
@@ -31,6 +34,53 @@ var nodeUser = entity(
 					return p.Source.(*user.User).LastLoginAt, nil
 				},
 			},
+			"lockedUntil": &graphql.Field{
+				Type:        graphql.DateTime,
+				Description: "The end of current lockout of user due to failed authentication attempts",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					u := p.Source.(*user.User)
+					return GQLContext(p.Context).Users.GetLockedUntil(u.ID).Value, nil
+				},
+			},
+			"isDisabled": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Boolean),
+				Description: "Indicates if the user is disabled",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*user.User).IsDisabled, nil
+				},
+			},
+			"disableReason": &graphql.Field{
+				Type:        graphql.String,
+				Description: "The reason of disabling the user",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*user.User).DisableReason, nil
+				},
+			},
+			"disabledUntil": &graphql.Field{
+				Type:        graphql.DateTime,
+				Description: "The time when the disabled user is re-enabled automatically",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*user.User).DisabledUntil, nil
+				},
+			},
+			"deleteAt": &graphql.Field{
+				Type:        graphql.DateTime,
+				Description: "The time when the user is scheduled to be deleted",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*user.User).DeleteAt, nil
+				},
+			},
+			"profile": &graphql.Field{
+				Type:        graphql.NewNonNull(UserProfile),
+				Description: "The custom profile attributes of user",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					profile := p.Source.(*user.User).Profile
+					if profile == nil {
+						profile = map[string]interface{}{}
+					}
+					return profile, nil
+				},
+			},
 			"identities": &graphql.Field{
 				Type: connIdentity.ConnectionType,
 				Args: relay.ConnectionArgs,
@@ -65,6 +115,23 @@ var nodeUser = entity(
 					return result.Value, nil
 				},
 			},
+			"sessions": &graphql.Field{
+				Type: connSession.ConnectionType,
+				Args: relay.ConnectionArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					u := p.Source.(*user.User)
+					sessions := GQLContext(p.Context).Sessions.List(u.ID)
+					result := sessions.Map(func(value interface{}) (interface{}, error) {
+						var sessionList []interface{}
+						for _, s := range value.([]session.Session) {
+							sessionList = append(sessionList, s)
+						}
+						args := relay.NewConnectionArguments(p.Args)
+						return graphqlutil.NewConnectionFromArray(sessionList, args), nil
+					})
+					return result.Value, nil
+				},
+			},
 			"verifiedClaims": &graphql.Field{
 				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(claim))),
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
@@ -81,3 +148,112 @@ var nodeUser = entity(
 )
 
 var connUser = graphqlutil.NewConnectionDef(nodeUser)
+
+var userFilter = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "UserFilter",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"createdAfter": &graphql.InputObjectFieldConfig{
+			Type:        graphql.DateTime,
+			Description: "Filter by creation time (inclusive).",
+		},
+		"createdBefore": &graphql.InputObjectFieldConfig{
+			Type:        graphql.DateTime,
+			Description: "Filter by creation time (exclusive).",
+		},
+		"lastLoginAfter": &graphql.InputObjectFieldConfig{
+			Type:        graphql.DateTime,
+			Description: "Filter by last login time (inclusive).",
+		},
+		"lastLoginBefore": &graphql.InputObjectFieldConfig{
+			Type:        graphql.DateTime,
+			Description: "Filter by last login time (exclusive).",
+		},
+		"hasVerifiedClaim": &graphql.InputObjectFieldConfig{
+			Type:        graphql.Boolean,
+			Description: "Filter by whether the user has any verified claim. Unlike isVerified of the user, the verification criteria is not applied.",
+		},
+		"isAnonymous": &graphql.InputObjectFieldConfig{
+			Type:        graphql.Boolean,
+			Description: "Filter by whether the user has an anonymous identity.",
+		},
+		"isDisabled": &graphql.InputObjectFieldConfig{
+			Type:        graphql.Boolean,
+			Description: "Filter by disabled status.",
+		},
+		"labels": &graphql.InputObjectFieldConfig{
+			Type:        UserLabels,
+			Description: "Filter by labels. Users having all the given labels are matched.",
+		},
+	},
+})
+
+var userSortBy = graphql.NewEnum(graphql.EnumConfig{
+	Name: "UserSortBy",
+	Values: graphql.EnumValueConfigMap{
+		"CREATED_AT": &graphql.EnumValueConfig{
+			Value: string(user.SortByCreatedAt),
+		},
+		"LAST_LOGIN_AT": &graphql.EnumValueConfig{
+			Value: string(user.SortByLastLoginAt),
+		},
+	},
+})
+
+var sortDirection = graphql.NewEnum(graphql.EnumConfig{
+	Name: "SortDirection",
+	Values: graphql.EnumValueConfigMap{
+		"ASC": &graphql.EnumValueConfig{
+			Value: string(user.SortDirectionAsc),
+		},
+		"DESC": &graphql.EnumValueConfig{
+			Value: string(user.SortDirectionDesc),
+		},
+	},
+})
+
+func parseUserFilter(args map[string]interface{}) user.Filter {
+	var filter user.Filter
+
+	if keyword, ok := args["searchKeyword"].(string); ok {
+		filter.SearchKeyword = keyword
+	}
+
+	input, _ := args["filter"].(map[string]interface{})
+	if t, ok := input["createdAfter"].(time.Time); ok {
+		filter.CreatedAfter = &t
+	}
+	if t, ok := input["createdBefore"].(time.Time); ok {
+		filter.CreatedBefore = &t
+	}
+	if t, ok := input["lastLoginAfter"].(time.Time); ok {
+		filter.LastLoginAfter = &t
+	}
+	if t, ok := input["lastLoginBefore"].(time.Time); ok {
+		filter.LastLoginBefore = &t
+	}
+	if b, ok := input["hasVerifiedClaim"].(bool); ok {
+		filter.HasVerifiedClaim = &b
+	}
+	if b, ok := input["isAnonymous"].(bool); ok {
+		filter.IsAnonymous = &b
+	}
+	if b, ok := input["isDisabled"].(bool); ok {
+		filter.IsDisabled = &b
+	}
+	if labels, ok := input["labels"].(map[string]interface{}); ok {
+		filter.Labels = labels
+	}
+
+	return filter
+}
+
+func parseUserSort(args map[string]interface{}) user.Sort {
+	var sort user.Sort
+	if by, ok := args["sortBy"].(string); ok {
+		sort.By = user.SortBy(by)
+	}
+	if direction, ok := args["sortDirection"].(string); ok {
+		sort.Direction = user.SortDirection(direction)
+	}
+	return sort
+}
@@ -0,0 +1,174 @@
+package graphql
+
+import (
+	"github.com/graphql-go/graphql"
+
+	"github.com/authgear/authgear-server/pkg/lib/feature/userexport"
+)
+
+const typeUserExport = "UserExport"
+
+var userExportStatus = graphql.NewEnum(graphql.EnumConfig{
+	Name: "UserExportStatus",
+	Values: graphql.EnumValueConfigMap{
+		"PENDING": &graphql.EnumValueConfig{
+			Value: string(userexport.StatusPending),
+		},
+		"RUNNING": &graphql.EnumValueConfig{
+			Value: string(userexport.StatusRunning),
+		},
+		"COMPLETED": &graphql.EnumValueConfig{
+			Value: string(userexport.StatusCompleted),
+		},
+		"FAILED": &graphql.EnumValueConfig{
+			Value: string(userexport.StatusFailed),
+		},
+	},
+})
+
+var userExportFormat = graphql.NewEnum(graphql.EnumConfig{
+	Name: "UserExportFormat",
+	Values: graphql.EnumValueConfigMap{
+		"NDJSON": &graphql.EnumValueConfig{
+			Value: string(userexport.FormatNDJSON),
+		},
+		"CSV": &graphql.EnumValueConfig{
+			Value: string(userexport.FormatCSV),
+		},
+	},
+})
+
+var nodeUserExport = entity(
+	graphql.NewObject(graphql.ObjectConfig{
+		Name:        typeUserExport,
+		Description: "Export of all users, run in background",
+		Interfaces: []*graphql.Interface{
+			nodeDefs.NodeInterface,
+		},
+		Fields: graphql.Fields{
+			"id": entityIDField(typeUserExport, func(obj interface{}) (string, error) {
+				return obj.(*userexport.Job).ID, nil
+			}),
+			"createdAt": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.DateTime),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*userexport.Job).CreatedAt, nil
+				},
+			},
+			"completedAt": &graphql.Field{
+				Type: graphql.DateTime,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if t := p.Source.(*userexport.Job).CompletedAt; t != nil {
+						return *t, nil
+					}
+					return nil, nil
+				},
+			},
+			"expireAt": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.DateTime),
+				Description: "The time when the export is deleted",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*userexport.Job).ExpireAt, nil
+				},
+			},
+			"status": &graphql.Field{
+				Type: graphql.NewNonNull(userExportStatus),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return string(p.Source.(*userexport.Job).Status), nil
+				},
+			},
+			"format": &graphql.Field{
+				Type: graphql.NewNonNull(userExportFormat),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return string(p.Source.(*userexport.Job).Format), nil
+				},
+			},
+			"includeSecrets": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Boolean),
+				Description: "Indicate whether authenticator secrets are exported",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*userexport.Job).IncludeSecrets, nil
+				},
+			},
+			"totalUsers": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "Number of users when the export started",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*userexport.Job).TotalUsers, nil
+				},
+			},
+			"exportedUsers": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*userexport.Job).ExportedUsers, nil
+				},
+			},
+			"error": &graphql.Field{
+				Type:        graphql.String,
+				Description: "The reason of failure, if failed",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if e := p.Source.(*userexport.Job).Error; e != nil {
+						return *e, nil
+					}
+					return nil, nil
+				},
+			},
+		},
+	}),
+	&userexport.Job{},
+	func(ctx *Context, id string) (interface{}, error) {
+		return ctx.UserExports.Get(id).Value, nil
+	},
+)
+
+var createUserExportInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "CreateUserExportInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"format": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewNonNull(userExportFormat),
+			Description: "Output format.",
+		},
+		"includeSecrets": &graphql.InputObjectFieldConfig{
+			Type:        graphql.Boolean,
+			Description: "Export secrets of authenticators, such as password hashes.",
+		},
+	},
+})
+
+var createUserExportPayload = graphql.NewObject(graphql.ObjectConfig{
+	Name: "CreateUserExportPayload",
+	Fields: graphql.Fields{
+		"userExport": &graphql.Field{
+			Type: graphql.NewNonNull(nodeUserExport),
+		},
+	},
+})
+
+var _ = registerMutationField(
+	"createUserExport",
+	[]string{"format", "includeSecrets"},
+	&graphql.Field{
+		Description: "Export all users in background. The output can be downloaded when completed.",
+		Type:        graphql.NewNonNull(createUserExportPayload),
+		Args: graphql.FieldConfigArgument{
+			"input": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(createUserExportInput),
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			input := p.Args["input"].(map[string]interface{})
+
+			format := userexport.Format(input["format"].(string))
+			includeSecrets, _ := input["includeSecrets"].(bool)
+
+			gqlCtx := GQLContext(p.Context)
+			return gqlCtx.UserExports.Create(format, includeSecrets).
+				Map(func(job interface{}) (interface{}, error) {
+					return map[string]interface{}{
+						"userExport": job,
+					}, nil
+				}).
+				Value, nil
+		},
+	},
+)
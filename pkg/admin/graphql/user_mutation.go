@@ -1,6 +1,8 @@
 package graphql
 
 import (
+	"time"
+
 	"github.com/graphql-go/graphql"
 	"github.com/graphql-go/relay"
 
@@ -33,6 +35,7 @@ var createUserPayload = graphql.NewObject(graphql.ObjectConfig{
 
 var _ = registerMutationField(
 	"createUser",
+	[]string{"definition"},
 	&graphql.Field{
 		Description: "Create new user",
 		Type:        graphql.NewNonNull(createUserPayload),
@@ -89,6 +92,7 @@ var resetPasswordPayload = graphql.NewObject(graphql.ObjectConfig{
 
 var _ = registerMutationField(
 	"resetPassword",
+	[]string{"userID"},
 	&graphql.Field{
 		Description: "Reset password of user",
 		Type:        graphql.NewNonNull(resetPasswordPayload),
@@ -160,6 +164,7 @@ var setVerifiedStatusPayload = graphql.NewObject(graphql.ObjectConfig{
 
 var _ = registerMutationField(
 	"setVerifiedStatus",
+	[]string{"userID", "claimName", "claimValue", "isVerified"},
 	&graphql.Field{
 		Description: "Set verified status of a claim of user",
 		Type:        graphql.NewNonNull(setVerifiedStatusPayload),
@@ -199,3 +204,444 @@ var _ = registerMutationField(
 		},
 	},
 )
+
+var unlockUserInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "UnlockUserInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"userID": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewNonNull(graphql.ID),
+			Description: "Target user ID.",
+		},
+	},
+})
+
+var unlockUserPayload = graphql.NewObject(graphql.ObjectConfig{
+	Name: "UnlockUserPayload",
+	Fields: graphql.Fields{
+		"user": &graphql.Field{
+			Type: graphql.NewNonNull(nodeUser),
+		},
+	},
+})
+
+var _ = registerMutationField(
+	"unlockUser",
+	[]string{"userID"},
+	&graphql.Field{
+		Description: "Unlock user locked out due to failed authentication attempts",
+		Type:        graphql.NewNonNull(unlockUserPayload),
+		Args: graphql.FieldConfigArgument{
+			"input": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(unlockUserInput),
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			input := p.Args["input"].(map[string]interface{})
+
+			userNodeID := input["userID"].(string)
+			resolvedNodeID := relay.FromGlobalID(userNodeID)
+			if resolvedNodeID == nil || resolvedNodeID.Type != typeUser {
+				return nil, apierrors.NewInvalid("invalid user ID")
+			}
+			userID := resolvedNodeID.ID
+
+			gqlCtx := GQLContext(p.Context)
+			return gqlCtx.Users.Get(userID).
+				Map(func(u interface{}) (interface{}, error) {
+					if u == nil {
+						return nil, apierrors.NewNotFound("user not found")
+					}
+					return gqlCtx.Users.Unlock(userID), nil
+				}).
+				Map(func(u interface{}) (interface{}, error) {
+					return map[string]interface{}{
+						"user": u,
+					}, nil
+				}).
+				Value, nil
+		},
+	},
+)
+
+var disableUserInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "DisableUserInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"userID": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewNonNull(graphql.ID),
+			Description: "Target user ID.",
+		},
+		"reason": &graphql.InputObjectFieldConfig{
+			Type:        graphql.String,
+			Description: "Reason of disabling the user, shown to the user.",
+		},
+		"disabledUntil": &graphql.InputObjectFieldConfig{
+			Type:        graphql.DateTime,
+			Description: "Re-enable the user automatically at this time.",
+		},
+	},
+})
+
+var disableUserPayload = graphql.NewObject(graphql.ObjectConfig{
+	Name: "DisableUserPayload",
+	Fields: graphql.Fields{
+		"user": &graphql.Field{
+			Type: graphql.NewNonNull(nodeUser),
+		},
+	},
+})
+
+var _ = registerMutationField(
+	"disableUser",
+	[]string{"userID", "reason", "disabledUntil"},
+	&graphql.Field{
+		Description: "Disable user",
+		Type:        graphql.NewNonNull(disableUserPayload),
+		Args: graphql.FieldConfigArgument{
+			"input": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(disableUserInput),
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			input := p.Args["input"].(map[string]interface{})
+
+			userNodeID := input["userID"].(string)
+			resolvedNodeID := relay.FromGlobalID(userNodeID)
+			if resolvedNodeID == nil || resolvedNodeID.Type != typeUser {
+				return nil, apierrors.NewInvalid("invalid user ID")
+			}
+			userID := resolvedNodeID.ID
+
+			var reason *string
+			if r, ok := input["reason"].(string); ok {
+				reason = &r
+			}
+			var disabledUntil *time.Time
+			if t, ok := input["disabledUntil"].(time.Time); ok {
+				disabledUntil = &t
+			}
+
+			gqlCtx := GQLContext(p.Context)
+			return gqlCtx.Users.Get(userID).
+				Map(func(u interface{}) (interface{}, error) {
+					if u == nil {
+						return nil, apierrors.NewNotFound("user not found")
+					}
+					return gqlCtx.Users.Disable(userID, reason, disabledUntil), nil
+				}).
+				Map(func(u interface{}) (interface{}, error) {
+					return map[string]interface{}{
+						"user": u,
+					}, nil
+				}).
+				Value, nil
+		},
+	},
+)
+
+var enableUserInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "EnableUserInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"userID": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewNonNull(graphql.ID),
+			Description: "Target user ID.",
+		},
+	},
+})
+
+var enableUserPayload = graphql.NewObject(graphql.ObjectConfig{
+	Name: "EnableUserPayload",
+	Fields: graphql.Fields{
+		"user": &graphql.Field{
+			Type: graphql.NewNonNull(nodeUser),
+		},
+	},
+})
+
+var _ = registerMutationField(
+	"enableUser",
+	[]string{"userID"},
+	&graphql.Field{
+		Description: "Re-enable disabled user",
+		Type:        graphql.NewNonNull(enableUserPayload),
+		Args: graphql.FieldConfigArgument{
+			"input": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(enableUserInput),
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			input := p.Args["input"].(map[string]interface{})
+
+			userNodeID := input["userID"].(string)
+			resolvedNodeID := relay.FromGlobalID(userNodeID)
+			if resolvedNodeID == nil || resolvedNodeID.Type != typeUser {
+				return nil, apierrors.NewInvalid("invalid user ID")
+			}
+			userID := resolvedNodeID.ID
+
+			gqlCtx := GQLContext(p.Context)
+			return gqlCtx.Users.Get(userID).
+				Map(func(u interface{}) (interface{}, error) {
+					if u == nil {
+						return nil, apierrors.NewNotFound("user not found")
+					}
+					return gqlCtx.Users.Reenable(userID), nil
+				}).
+				Map(func(u interface{}) (interface{}, error) {
+					return map[string]interface{}{
+						"user": u,
+					}, nil
+				}).
+				Value, nil
+		},
+	},
+)
+
+var updateUserProfileInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "UpdateUserProfileInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"userID": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewNonNull(graphql.ID),
+			Description: "Target user ID.",
+		},
+		"profile": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewNonNull(UserProfile),
+			Description: "New profile of the user, replacing the current one.",
+		},
+	},
+})
+
+var updateUserProfilePayload = graphql.NewObject(graphql.ObjectConfig{
+	Name: "UpdateUserProfilePayload",
+	Fields: graphql.Fields{
+		"user": &graphql.Field{
+			Type: graphql.NewNonNull(nodeUser),
+		},
+	},
+})
+
+var _ = registerMutationField(
+	"updateUserProfile",
+	[]string{"userID"},
+	&graphql.Field{
+		Description: "Update custom profile attributes of user",
+		Type:        graphql.NewNonNull(updateUserProfilePayload),
+		Args: graphql.FieldConfigArgument{
+			"input": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(updateUserProfileInput),
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			input := p.Args["input"].(map[string]interface{})
+
+			userNodeID := input["userID"].(string)
+			resolvedNodeID := relay.FromGlobalID(userNodeID)
+			if resolvedNodeID == nil || resolvedNodeID.Type != typeUser {
+				return nil, apierrors.NewInvalid("invalid user ID")
+			}
+			userID := resolvedNodeID.ID
+
+			profile, ok := input["profile"].(map[string]interface{})
+			if !ok {
+				return nil, apierrors.NewInvalid("invalid profile")
+			}
+
+			gqlCtx := GQLContext(p.Context)
+			return gqlCtx.Users.Get(userID).
+				Map(func(u interface{}) (interface{}, error) {
+					if u == nil {
+						return nil, apierrors.NewNotFound("user not found")
+					}
+					return gqlCtx.Users.UpdateProfile(userID, profile), nil
+				}).
+				Map(func(u interface{}) (interface{}, error) {
+					return map[string]interface{}{
+						"user": u,
+					}, nil
+				}).
+				Value, nil
+		},
+	},
+)
+
+var deleteUserInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "DeleteUserInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"userID": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewNonNull(graphql.ID),
+			Description: "Target user ID.",
+		},
+	},
+})
+
+var deleteUserPayload = graphql.NewObject(graphql.ObjectConfig{
+	Name: "DeleteUserPayload",
+	Fields: graphql.Fields{
+		"deletedUserID": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.ID),
+		},
+	},
+})
+
+var _ = registerMutationField(
+	"deleteUser",
+	[]string{"userID"},
+	&graphql.Field{
+		Description: "Delete user and all data of user permanently",
+		Type:        graphql.NewNonNull(deleteUserPayload),
+		Args: graphql.FieldConfigArgument{
+			"input": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(deleteUserInput),
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			input := p.Args["input"].(map[string]interface{})
+
+			userNodeID := input["userID"].(string)
+			resolvedNodeID := relay.FromGlobalID(userNodeID)
+			if resolvedNodeID == nil || resolvedNodeID.Type != typeUser {
+				return nil, apierrors.NewInvalid("invalid user ID")
+			}
+			userID := resolvedNodeID.ID
+
+			gqlCtx := GQLContext(p.Context)
+			return gqlCtx.Users.Get(userID).
+				Map(func(u interface{}) (interface{}, error) {
+					if u == nil {
+						return nil, apierrors.NewNotFound("user not found")
+					}
+					return gqlCtx.Users.Delete(userID), nil
+				}).
+				Map(func(interface{}) (interface{}, error) {
+					return map[string]interface{}{
+						"deletedUserID": userNodeID,
+					}, nil
+				}).
+				Value, nil
+		},
+	},
+)
+
+var scheduleUserDeletionInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "ScheduleUserDeletionInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"userID": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewNonNull(graphql.ID),
+			Description: "Target user ID.",
+		},
+		"deleteAt": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewNonNull(graphql.DateTime),
+			Description: "Delete the user at this time.",
+		},
+	},
+})
+
+var scheduleUserDeletionPayload = graphql.NewObject(graphql.ObjectConfig{
+	Name: "ScheduleUserDeletionPayload",
+	Fields: graphql.Fields{
+		"user": &graphql.Field{
+			Type: graphql.NewNonNull(nodeUser),
+		},
+	},
+})
+
+var _ = registerMutationField(
+	"scheduleUserDeletion",
+	[]string{"userID", "deleteAt"},
+	&graphql.Field{
+		Description: "Schedule user to be deleted after a grace period",
+		Type:        graphql.NewNonNull(scheduleUserDeletionPayload),
+		Args: graphql.FieldConfigArgument{
+			"input": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(scheduleUserDeletionInput),
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			input := p.Args["input"].(map[string]interface{})
+
+			userNodeID := input["userID"].(string)
+			resolvedNodeID := relay.FromGlobalID(userNodeID)
+			if resolvedNodeID == nil || resolvedNodeID.Type != typeUser {
+				return nil, apierrors.NewInvalid("invalid user ID")
+			}
+			userID := resolvedNodeID.ID
+
+			deleteAt, ok := input["deleteAt"].(time.Time)
+			if !ok {
+				return nil, apierrors.NewInvalid("invalid deletion time")
+			}
+
+			gqlCtx := GQLContext(p.Context)
+			return gqlCtx.Users.Get(userID).
+				Map(func(u interface{}) (interface{}, error) {
+					if u == nil {
+						return nil, apierrors.NewNotFound("user not found")
+					}
+					return gqlCtx.Users.ScheduleDeletion(userID, deleteAt), nil
+				}).
+				Map(func(u interface{}) (interface{}, error) {
+					return map[string]interface{}{
+						"user": u,
+					}, nil
+				}).
+				Value, nil
+		},
+	},
+)
+
+var unscheduleUserDeletionInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "UnscheduleUserDeletionInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"userID": &graphql.InputObjectFieldConfig{
+			Type:        graphql.NewNonNull(graphql.ID),
+			Description: "Target user ID.",
+		},
+	},
+})
+
+var unscheduleUserDeletionPayload = graphql.NewObject(graphql.ObjectConfig{
+	Name: "UnscheduleUserDeletionPayload",
+	Fields: graphql.Fields{
+		"user": &graphql.Field{
+			Type: graphql.NewNonNull(nodeUser),
+		},
+	},
+})
+
+var _ = registerMutationField(
+	"unscheduleUserDeletion",
+	[]string{"userID"},
+	&graphql.Field{
+		Description: "Cancel scheduled deletion of user",
+		Type:        graphql.NewNonNull(unscheduleUserDeletionPayload),
+		Args: graphql.FieldConfigArgument{
+			"input": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(unscheduleUserDeletionInput),
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			input := p.Args["input"].(map[string]interface{})
+
+			userNodeID := input["userID"].(string)
+			resolvedNodeID := relay.FromGlobalID(userNodeID)
+			if resolvedNodeID == nil || resolvedNodeID.Type != typeUser {
+				return nil, apierrors.NewInvalid("invalid user ID")
+			}
+			userID := resolvedNodeID.ID
+
+			gqlCtx := GQLContext(p.Context)
+			return gqlCtx.Users.Get(userID).
+				Map(func(u interface{}) (interface{}, error) {
+					if u == nil {
+						return nil, apierrors.NewNotFound("user not found")
+					}
+					return gqlCtx.Users.UnscheduleDeletion(userID), nil
+				}).
+				Map(func(u interface{}) (interface{}, error) {
+					return map[string]interface{}{
+						"user": u,
+					}, nil
+				}).
+				Value, nil
+		},
+	},
+)
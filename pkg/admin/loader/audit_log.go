@@ -0,0 +1,42 @@
+package loader
+
+import (
+	"errors"
+
+	apimodel "github.com/authgear/authgear-server/pkg/api/model"
+	"github.com/authgear/authgear-server/pkg/lib/audit"
+	"github.com/authgear/authgear-server/pkg/util/graphqlutil"
+)
+
+type AuditLogQueries interface {
+	Get(id string) (*audit.Log, error)
+	Count(filter audit.Filter) (uint64, error)
+	QueryPage(filter audit.Filter, after, before apimodel.PageCursor, first, last *uint64) ([]apimodel.PageItem, error)
+}
+
+type AuditLogLoader struct {
+	AuditLogs AuditLogQueries
+}
+
+func (l *AuditLogLoader) Get(id string) *graphqlutil.Lazy {
+	return graphqlutil.NewLazy(func() (interface{}, error) {
+		log, err := l.AuditLogs.Get(id)
+		if errors.Is(err, audit.ErrLogNotFound) {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		return log, nil
+	})
+}
+
+func (l *AuditLogLoader) QueryPage(filter audit.Filter, args graphqlutil.PageArgs) (*graphqlutil.PageResult, error) {
+	values, err := l.AuditLogs.QueryPage(filter, apimodel.PageCursor(args.After), apimodel.PageCursor(args.Before), args.First, args.Last)
+	if err != nil {
+		return nil, err
+	}
+
+	return graphqlutil.NewPageResult(args, ConvertItems(values), graphqlutil.NewLazy(func() (interface{}, error) {
+		return l.AuditLogs.Count(filter)
+	})), nil
+}
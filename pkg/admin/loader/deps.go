@@ -7,4 +7,8 @@ var DependencySet = wire.NewSet(
 	wire.Struct(new(IdentityLoader), "*"),
 	wire.Struct(new(AuthenticatorLoader), "*"),
 	wire.Struct(new(VerificationLoader), "*"),
+	wire.Struct(new(EventLoader), "*"),
+	wire.Struct(new(AuditLogLoader), "*"),
+	wire.Struct(new(UserExportLoader), "*"),
+	wire.Struct(new(SessionLoader), "*"),
 )
@@ -0,0 +1,78 @@
+package loader
+
+import (
+	"errors"
+
+	"github.com/authgear/authgear-server/pkg/admin/model"
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+	apimodel "github.com/authgear/authgear-server/pkg/api/model"
+	"github.com/authgear/authgear-server/pkg/lib/hook"
+	"github.com/authgear/authgear-server/pkg/util/graphqlutil"
+)
+
+type EventQueries interface {
+	Get(id string) (*hook.StoredEvent, error)
+	Count(filter hook.EventFilter) (uint64, error)
+	QueryPage(filter hook.EventFilter, after, before apimodel.PageCursor, first, last *uint64) ([]apimodel.PageItem, error)
+}
+
+type EventDeliveryService interface {
+	Redeliver(id string) (*hook.StoredEvent, error)
+	SendTestEvent(url string) error
+}
+
+type EventLoader struct {
+	Events   EventQueries
+	Delivery EventDeliveryService
+}
+
+func (l *EventLoader) Get(id string) *graphqlutil.Lazy {
+	return graphqlutil.NewLazy(func() (interface{}, error) {
+		e, err := l.Events.Get(id)
+		if errors.Is(err, hook.ErrEventNotFound) {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		return e, nil
+	})
+}
+
+func (l *EventLoader) QueryPage(filter hook.EventFilter, args graphqlutil.PageArgs) (*graphqlutil.PageResult, error) {
+	values, err := l.Events.QueryPage(filter, apimodel.PageCursor(args.After), apimodel.PageCursor(args.Before), args.First, args.Last)
+	if err != nil {
+		return nil, err
+	}
+
+	return graphqlutil.NewPageResult(args, ConvertItems(values), graphqlutil.NewLazy(func() (interface{}, error) {
+		return l.Events.Count(filter)
+	})), nil
+}
+
+func (l *EventLoader) Redeliver(id string) *graphqlutil.Lazy {
+	return graphqlutil.NewLazy(func() (interface{}, error) {
+		return l.Delivery.Redeliver(id)
+	})
+}
+
+func (l *EventLoader) SendTestEvent(url string) *graphqlutil.Lazy {
+	return graphqlutil.NewLazy(func() (interface{}, error) {
+		err := l.Delivery.SendTestEvent(url)
+		if apierrors.IsKind(err, hook.UnknownWebHookHandler) {
+			return nil, err
+		}
+
+		result := &model.TestEventResult{Success: err == nil}
+		if err != nil {
+			errMsg := err.Error()
+			result.Error = &errMsg
+
+			var statusCodeErr *hook.InvalidStatusCodeError
+			if errors.As(err, &statusCodeErr) {
+				statusCode := statusCodeErr.StatusCode
+				result.StatusCode = &statusCode
+			}
+		}
+		return result, nil
+	})
+}
@@ -0,0 +1,69 @@
+package loader
+
+import (
+	"errors"
+
+	"github.com/authgear/authgear-server/pkg/lib/session"
+	"github.com/authgear/authgear-server/pkg/util/graphqlutil"
+)
+
+type SessionManager interface {
+	List(userID string) ([]session.Session, error)
+	Get(id string) (session.Session, error)
+	Revoke(session session.Session) error
+}
+
+type MFAService interface {
+	InvalidateAllDeviceTokens(userID string) error
+}
+
+type SessionLoader struct {
+	Sessions SessionManager
+	MFA      MFAService
+}
+
+func (l *SessionLoader) Get(id string) *graphqlutil.Lazy {
+	return graphqlutil.NewLazy(func() (interface{}, error) {
+		s, err := l.Sessions.Get(id)
+		if errors.Is(err, session.ErrSessionNotFound) {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		return s, nil
+	})
+}
+
+func (l *SessionLoader) List(userID string) *graphqlutil.Lazy {
+	return graphqlutil.NewLazy(func() (interface{}, error) {
+		return l.Sessions.List(userID)
+	})
+}
+
+func (l *SessionLoader) Revoke(s session.Session) *graphqlutil.Lazy {
+	return graphqlutil.NewLazy(func() (interface{}, error) {
+		return nil, l.Sessions.Revoke(s)
+	})
+}
+
+func (l *SessionLoader) RevokeAll(userID string) *graphqlutil.Lazy {
+	return graphqlutil.NewLazy(func() (interface{}, error) {
+		sessions, err := l.Sessions.List(userID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, s := range sessions {
+			if err := l.Sessions.Revoke(s); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+}
+
+func (l *SessionLoader) RevokeAllMFADeviceTokens(userID string) *graphqlutil.Lazy {
+	return graphqlutil.NewLazy(func() (interface{}, error) {
+		return nil, l.MFA.InvalidateAllDeviceTokens(userID)
+	})
+}
@@ -2,9 +2,11 @@ package loader
 
 import (
 	"errors"
+	"time"
 
 	"github.com/authgear/authgear-server/pkg/admin/model"
 	"github.com/authgear/authgear-server/pkg/api/apierrors"
+	"github.com/authgear/authgear-server/pkg/api/event"
 	apimodel "github.com/authgear/authgear-server/pkg/api/model"
 	"github.com/authgear/authgear-server/pkg/lib/authn/user"
 	"github.com/authgear/authgear-server/pkg/lib/interaction"
@@ -15,14 +17,34 @@ import (
 
 type UserService interface {
 	GetManyRaw(id []string) ([]*user.User, error)
-	Count() (uint64, error)
-	QueryPage(after, before apimodel.PageCursor, first, last *uint64) ([]apimodel.PageItem, error)
+	Count(filter user.Filter) (uint64, error)
+	QueryPage(filter user.Filter, sort user.Sort, after, before apimodel.PageCursor, first, last *uint64) ([]apimodel.PageItem, error)
+}
+
+type UserCommandService interface {
+	Disable(userID string, reason *string, until *time.Time) error
+	Reenable(userID string) error
+	UpdateProfile(userID string, profile map[string]interface{}, reason event.UserUpdateReason) error
+}
+
+type UserDeletionService interface {
+	Delete(userID string) error
+	ScheduleDeletion(userID string, deleteAt time.Time) error
+	UnscheduleDeletion(userID string) error
+}
+
+type LockoutService interface {
+	GetLockedUntil(userID string) (*time.Time, error)
+	Unlock(userID string) error
 }
 
 type UserLoader struct {
-	Users       UserService
-	Interaction InteractionService
-	loader      *graphqlutil.DataLoader `wire:"-"`
+	Users        UserService
+	UserCommands UserCommandService
+	Deletion     UserDeletionService
+	Interaction  InteractionService
+	Lockout      LockoutService
+	loader       *graphqlutil.DataLoader `wire:"-"`
 }
 
 func (l *UserLoader) Get(id string) *graphqlutil.Lazy {
@@ -52,14 +74,14 @@ func (l *UserLoader) Get(id string) *graphqlutil.Lazy {
 	return l.loader.Load(id)
 }
 
-func (l *UserLoader) QueryPage(args graphqlutil.PageArgs) (*graphqlutil.PageResult, error) {
-	values, err := l.Users.QueryPage(apimodel.PageCursor(args.After), apimodel.PageCursor(args.Before), args.First, args.Last)
+func (l *UserLoader) QueryPage(filter user.Filter, sort user.Sort, args graphqlutil.PageArgs) (*graphqlutil.PageResult, error) {
+	values, err := l.Users.QueryPage(filter, sort, apimodel.PageCursor(args.After), apimodel.PageCursor(args.Before), args.First, args.Last)
 	if err != nil {
 		return nil, err
 	}
 
 	return graphqlutil.NewPageResult(args, ConvertItems(values), graphqlutil.NewLazy(func() (interface{}, error) {
-		return l.Users.Count()
+		return l.Users.Count(filter)
 	})), nil
 }
 
@@ -109,3 +131,99 @@ func (l *UserLoader) ResetPassword(id string, password string) *graphqlutil.Lazy
 		return l.Get(id), nil
 	})
 }
+
+func (l *UserLoader) GetLockedUntil(id string) *graphqlutil.Lazy {
+	return graphqlutil.NewLazy(func() (interface{}, error) {
+		lockedUntil, err := l.Lockout.GetLockedUntil(id)
+		if err != nil {
+			return nil, err
+		}
+		if lockedUntil == nil {
+			return nil, nil
+		}
+		return *lockedUntil, nil
+	})
+}
+
+func (l *UserLoader) Unlock(id string) *graphqlutil.Lazy {
+	return graphqlutil.NewLazy(func() (interface{}, error) {
+		err := l.Lockout.Unlock(id)
+		if err != nil {
+			return nil, err
+		}
+
+		return l.Get(id), nil
+	})
+}
+
+func (l *UserLoader) Disable(id string, reason *string, until *time.Time) *graphqlutil.Lazy {
+	return graphqlutil.NewLazy(func() (interface{}, error) {
+		err := l.UserCommands.Disable(id, reason, until)
+		if err != nil {
+			return nil, err
+		}
+
+		l.loader.Reset(id)
+		return l.Get(id), nil
+	})
+}
+
+func (l *UserLoader) Reenable(id string) *graphqlutil.Lazy {
+	return graphqlutil.NewLazy(func() (interface{}, error) {
+		err := l.UserCommands.Reenable(id)
+		if err != nil {
+			return nil, err
+		}
+
+		l.loader.Reset(id)
+		return l.Get(id), nil
+	})
+}
+
+func (l *UserLoader) UpdateProfile(id string, profile map[string]interface{}) *graphqlutil.Lazy {
+	return graphqlutil.NewLazy(func() (interface{}, error) {
+		err := l.UserCommands.UpdateProfile(id, profile, event.UserUpdateReasonAdministrative)
+		if err != nil {
+			return nil, err
+		}
+
+		l.loader.Reset(id)
+		return l.Get(id), nil
+	})
+}
+
+func (l *UserLoader) Delete(id string) *graphqlutil.Lazy {
+	return graphqlutil.NewLazy(func() (interface{}, error) {
+		err := l.Deletion.Delete(id)
+		if err != nil {
+			return nil, err
+		}
+
+		l.loader.Reset(id)
+		return nil, nil
+	})
+}
+
+func (l *UserLoader) ScheduleDeletion(id string, deleteAt time.Time) *graphqlutil.Lazy {
+	return graphqlutil.NewLazy(func() (interface{}, error) {
+		err := l.Deletion.ScheduleDeletion(id, deleteAt)
+		if err != nil {
+			return nil, err
+		}
+
+		l.loader.Reset(id)
+		return l.Get(id), nil
+	})
+}
+
+func (l *UserLoader) UnscheduleDeletion(id string) *graphqlutil.Lazy {
+	return graphqlutil.NewLazy(func() (interface{}, error) {
+		err := l.Deletion.UnscheduleDeletion(id)
+		if err != nil {
+			return nil, err
+		}
+
+		l.loader.Reset(id)
+		return l.Get(id), nil
+	})
+}
@@ -0,0 +1,35 @@
+package loader
+
+import (
+	"errors"
+
+	"github.com/authgear/authgear-server/pkg/lib/feature/userexport"
+	"github.com/authgear/authgear-server/pkg/util/graphqlutil"
+)
+
+type UserExportService interface {
+	CreateJob(format userexport.Format, includeSecrets bool) (*userexport.Job, error)
+	GetJob(id string) (*userexport.Job, error)
+}
+
+type UserExportLoader struct {
+	Exports UserExportService
+}
+
+func (l *UserExportLoader) Get(id string) *graphqlutil.Lazy {
+	return graphqlutil.NewLazy(func() (interface{}, error) {
+		job, err := l.Exports.GetJob(id)
+		if errors.Is(err, userexport.ErrJobNotFound) {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		return job, nil
+	})
+}
+
+func (l *UserExportLoader) Create(format userexport.Format, includeSecrets bool) *graphqlutil.Lazy {
+	return graphqlutil.NewLazy(func() (interface{}, error) {
+		return l.Exports.CreateJob(format, includeSecrets)
+	})
+}
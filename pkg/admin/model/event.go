@@ -0,0 +1,7 @@
+package model
+
+type TestEventResult struct {
+	Success    bool
+	StatusCode *int
+	Error      *string
+}
@@ -32,11 +32,14 @@ func NewRouter(p *deps.RootProvider, configSource *configsource.ConfigSource, au
 		p.Middleware(func(p *deps.RequestProvider) httproute.Middleware {
 			return newAuthorizationMiddleware(p, auth)
 		}),
+		p.Middleware(newAuditMiddleware),
 	)
 
 	route := httproute.Route{Middleware: chain}
 
 	router.Add(transport.ConfigureGraphQLRoute(route), p.Handler(newGraphQLHandler))
+	router.Add(transport.ConfigureUserImportRoute(route), p.Handler(newUserImportHandler))
+	router.Add(transport.ConfigureUserExportRoute(route), p.Handler(newUserExportHandler))
 
 	return router
 }
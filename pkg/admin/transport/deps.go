@@ -6,4 +6,8 @@ import (
 
 var DependencySet = wire.NewSet(
 	wire.Struct(new(GraphQLHandler), "*"),
+	wire.Struct(new(UserImportHandler), "*"),
+	NewUserImportHandlerLogger,
+	wire.Struct(new(UserExportHandler), "*"),
+	NewUserExportHandlerLogger,
 )
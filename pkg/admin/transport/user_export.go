@@ -0,0 +1,96 @@
+package transport
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/graphql-go/relay"
+
+	"github.com/authgear/authgear-server/pkg/lib/feature/userexport"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	"github.com/authgear/authgear-server/pkg/util/httproute"
+	"github.com/authgear/authgear-server/pkg/util/log"
+)
+
+func ConfigureUserExportRoute(route httproute.Route) httproute.Route {
+	return route.
+		WithMethods("GET").
+		WithPathPattern("/users/exports/:id")
+}
+
+type UserExportService interface {
+	GetJob(id string) (*userexport.Job, error)
+	ReadChunk(jobID string, seq int) ([]byte, error)
+}
+
+type UserExportHandlerLogger struct{ *log.Logger }
+
+func NewUserExportHandlerLogger(lf *log.Factory) UserExportHandlerLogger {
+	return UserExportHandlerLogger{lf.New("handler-user-export")}
+}
+
+// UserExportHandler downloads the output of a completed user export.
+// The export is identified by its GraphQL node ID.
+type UserExportHandler struct {
+	Logger   UserExportHandlerLogger
+	Database *db.Handle
+	Exports  UserExportService
+}
+
+func (h *UserExportHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	resolved := relay.FromGlobalID(httproute.GetParam(r, "id"))
+	if resolved == nil || resolved.Type != "UserExport" {
+		http.Error(rw, "user export not found", http.StatusNotFound)
+		return
+	}
+
+	var job *userexport.Job
+	err := h.Database.ReadOnly(func() (err error) {
+		job, err = h.Exports.GetJob(resolved.ID)
+		return
+	})
+	if errors.Is(err, userexport.ErrJobNotFound) {
+		http.Error(rw, "user export not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		h.Logger.WithError(err).Error("failed to get user export")
+		http.Error(rw, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if job.Status != userexport.StatusCompleted {
+		http.Error(rw, "user export is not completed", http.StatusConflict)
+		return
+	}
+
+	switch job.Format {
+	case userexport.FormatCSV:
+		rw.Header().Set("Content-Type", "text/csv")
+	default:
+		rw.Header().Set("Content-Type", "application/x-ndjson")
+	}
+	rw.WriteHeader(http.StatusOK)
+
+	for seq := 0; ; seq++ {
+		var data []byte
+		err := h.Database.ReadOnly(func() (err error) {
+			data, err = h.Exports.ReadChunk(job.ID, seq)
+			return
+		})
+		if errors.Is(err, io.EOF) {
+			return
+		} else if err != nil {
+			h.Logger.WithError(err).Error("failed to read user export")
+			return
+		}
+
+		if _, err := rw.Write(data); err != nil {
+			h.Logger.WithError(err).Error("failed to write user export")
+			return
+		}
+		if f, ok := rw.(http.Flusher); ok {
+			f.Flush()
+		}
+	}
+}
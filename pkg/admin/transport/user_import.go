@@ -0,0 +1,102 @@
+package transport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+	"github.com/authgear/authgear-server/pkg/lib/feature/userimport"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	"github.com/authgear/authgear-server/pkg/lib/infra/middleware"
+	"github.com/authgear/authgear-server/pkg/util/httproute"
+	"github.com/authgear/authgear-server/pkg/util/log"
+)
+
+func ConfigureUserImportRoute(route httproute.Route) httproute.Route {
+	return route.
+		WithMethods("POST").
+		WithPathPattern("/users/import")
+}
+
+type UserImporter interface {
+	Import(index int, record *userimport.Record) (*userimport.Result, error)
+}
+
+type UserImportHandlerLogger struct{ *log.Logger }
+
+func NewUserImportHandlerLogger(lf *log.Factory) UserImportHandlerLogger {
+	return UserImportHandlerLogger{lf.New("handler-user-import")}
+}
+
+// UserImportHandler imports users given as NDJSON in request body.
+// Each record is imported in its own transaction, and the result of each
+// record is written as NDJSON in response body.
+type UserImportHandler struct {
+	Logger   UserImportHandlerLogger
+	Database *db.Handle
+	Importer UserImporter
+}
+
+func (h *UserImportHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	// The limit is applied again, so that exceeding it can be told apart
+	// from other read errors.
+	var buf bytes.Buffer
+	_, err := buf.ReadFrom(http.MaxBytesReader(rw, r.Body, middleware.MaxBodySize))
+	if err != nil {
+		if buf.Len() >= middleware.MaxBodySize {
+			http.Error(rw, "request body too large", http.StatusRequestEntityTooLarge)
+		} else {
+			http.Error(rw, "failed to read request body", http.StatusBadRequest)
+		}
+		return
+	}
+	body := buf.Bytes()
+
+	rw.Header().Set("Content-Type", "application/x-ndjson")
+	rw.WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(rw)
+
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	scanner.Buffer(nil, len(body)+1)
+	index := -1
+	for scanner.Scan() {
+		index++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		result := h.importLine(index, line)
+		if err := encoder.Encode(result); err != nil {
+			h.Logger.WithError(err).Error("failed to write import result")
+			return
+		}
+		if f, ok := rw.(http.Flusher); ok {
+			f.Flush()
+		}
+	}
+}
+
+func (h *UserImportHandler) importLine(index int, line []byte) *userimport.Result {
+	var record userimport.Record
+	decoder := json.NewDecoder(bytes.NewReader(line))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&record); err != nil {
+		return userimport.NewFailedResult(index, userimport.InvalidRecord.New("invalid JSON"))
+	}
+
+	var result *userimport.Result
+	err := h.Database.WithTx(func() (err error) {
+		result, err = h.Importer.Import(index, &record)
+		return
+	})
+	if err != nil {
+		if !apierrors.IsAPIError(err) {
+			h.Logger.WithError(err).WithField("index", index).Error("failed to import user")
+		}
+		return userimport.NewFailedResult(index, err)
+	}
+	return result
+}
@@ -11,10 +11,13 @@ import (
 	service3 "github.com/authgear/authgear-server/pkg/admin/service"
 	"github.com/authgear/authgear-server/pkg/admin/transport"
 	"github.com/authgear/authgear-server/pkg/lib/admin/authz"
+	"github.com/authgear/authgear-server/pkg/lib/audit"
+	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator/lockout"
 	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator/oob"
 	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator/password"
 	service2 "github.com/authgear/authgear-server/pkg/lib/authn/authenticator/service"
 	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator/totp"
+	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator/webauthn"
 	"github.com/authgear/authgear-server/pkg/lib/authn/challenge"
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity/anonymous"
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity/loginid"
@@ -28,12 +31,20 @@ import (
 	"github.com/authgear/authgear-server/pkg/lib/deps"
 	"github.com/authgear/authgear-server/pkg/lib/facade"
 	"github.com/authgear/authgear-server/pkg/lib/feature/forgotpassword"
+	"github.com/authgear/authgear-server/pkg/lib/feature/userdeletion"
+	"github.com/authgear/authgear-server/pkg/lib/feature/userexport"
+	"github.com/authgear/authgear-server/pkg/lib/feature/userimport"
 	"github.com/authgear/authgear-server/pkg/lib/feature/verification"
 	"github.com/authgear/authgear-server/pkg/lib/feature/welcomemessage"
 	"github.com/authgear/authgear-server/pkg/lib/hook"
 	"github.com/authgear/authgear-server/pkg/lib/infra/db"
 	"github.com/authgear/authgear-server/pkg/lib/infra/middleware"
 	"github.com/authgear/authgear-server/pkg/lib/interaction"
+	oauth2 "github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/pq"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/redis"
+	"github.com/authgear/authgear-server/pkg/lib/ratelimit"
+	"github.com/authgear/authgear-server/pkg/lib/session"
 	"github.com/authgear/authgear-server/pkg/lib/session/access"
 	"github.com/authgear/authgear-server/pkg/lib/session/idpsession"
 	"github.com/authgear/authgear-server/pkg/lib/translation"
@@ -105,6 +116,19 @@ var (
 	_wireSystemClockValue = clock.NewSystemClock()
 )
 
+func newAuditMiddleware(p *deps.RequestProvider) httproute.Middleware {
+	appProvider := p.AppProvider
+	rootProvider := appProvider.RootProvider
+	environmentConfig := rootProvider.EnvironmentConfig
+	trustProxy := environmentConfig.TrustProxy
+	clockClock := _wireSystemClockValue
+	auditMiddleware := &audit.Middleware{
+		TrustProxy: trustProxy,
+		Clock:      clockClock,
+	}
+	return auditMiddleware
+}
+
 func newGraphQLHandler(p *deps.RequestProvider) http.Handler {
 	appProvider := p.AppProvider
 	factory := appProvider.LoggerFactory
@@ -208,6 +232,7 @@ func newGraphQLHandler(p *deps.RequestProvider) http.Handler {
 		PasswordHistory: historyStore,
 		PasswordChecker: passwordChecker,
 		TaskQueue:       queue,
+		Database:        handle,
 	}
 	totpStore := &totp.Store{
 		SQLBuilder:  sqlBuilder,
@@ -229,15 +254,33 @@ func newGraphQLHandler(p *deps.RequestProvider) http.Handler {
 		Store:  oobStore,
 		Clock:  clockClock,
 	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	redisHandle := appProvider.Redis
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
 	service4 := &service2.Service{
 		Store:    store2,
 		Password: passwordProvider,
 		TOTP:     totpProvider,
 		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
 	}
 	verificationLogger := verification.NewLogger(factory)
 	verificationConfig := appConfig.Verification
-	redisHandle := appProvider.Redis
 	storeRedis := &verification.StoreRedis{
 		Redis: redisHandle,
 		AppID: appID,
@@ -267,25 +310,154 @@ func newGraphQLHandler(p *deps.RequestProvider) http.Handler {
 		Store:        store,
 		Identities:   identityFacade,
 		Verification: verificationService,
-	}
-	interactionLogger := interaction.NewLogger(factory)
-	authenticatorFacade := facade.AuthenticatorFacade{
-		Coordinator: coordinator,
+		Clock:        clockClock,
 	}
 	environmentConfig := rootProvider.EnvironmentConfig
-	staticAssetURLPrefix := environmentConfig.StaticAssetURLPrefix
 	engine := appProvider.TemplateEngine
 	translationService := &translation.Service{
 		Context:           context,
 		EnvironmentConfig: environmentConfig,
 		TemplateEngine:    engine,
 	}
+	welcomeMessageConfig := appConfig.WelcomeMessage
+	welcomemessageProvider := &welcomemessage.Provider{
+		Translation:          translationService,
+		WelcomeMessageConfig: welcomeMessageConfig,
+		TaskQueue:            queue,
+	}
+	rawCommands := &user.RawCommands{
+		Store:                  store,
+		Clock:                  clockClock,
+		WelcomeMessageProvider: welcomemessageProvider,
+		Queries:                queries,
+	}
+	hookLogger := hook.NewLogger(factory)
+	rawProvider := &user.RawProvider{
+		RawCommands: rawCommands,
+		Queries:     queries,
+	}
+	hookStore := &hook.Store{
+		Clock:       clockClock,
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	hookConfig := appConfig.Hook
+	webhookKeyMaterials := deps.ProvideWebhookKeyMaterials(secretConfig)
+	syncHTTPClient := hook.NewSyncHTTPClient(hookConfig)
+	asyncHTTPClient := hook.NewAsyncHTTPClient()
+	deliverer := &hook.Deliverer{
+		Config:    hookConfig,
+		Secret:    webhookKeyMaterials,
+		Clock:     clockClock,
+		SyncHTTP:  syncHTTPClient,
+		AsyncHTTP: asyncHTTPClient,
+	}
+	hookProvider := &hook.Provider{
+		Context:   context,
+		Logger:    hookLogger,
+		Database:  handle,
+		Clock:     clockClock,
+		Users:     rawProvider,
+		Store:     hookStore,
+		Deliverer: deliverer,
+		TaskQueue: queue,
+	}
+	userProfileConfig := appConfig.UserProfile
+	commands := &user.Commands{
+		Raw:               rawCommands,
+		Hooks:             hookProvider,
+		Verification:      verificationService,
+		UserProfileConfig: userProfileConfig,
+	}
+	storeDeviceTokenRedis := &mfa.StoreDeviceTokenRedis{
+		Redis: redisHandle,
+		AppID: appID,
+		Clock: clockClock,
+	}
+	storeRecoveryCodePQ := &mfa.StoreRecoveryCodePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	mfaService := &mfa.Service{
+		DeviceTokens:  storeDeviceTokenRedis,
+		RecoveryCodes: storeRecoveryCodePQ,
+		Clock:         clockClock,
+		Config:        authenticationConfig,
+	}
+	authenticationLockoutConfig := authenticationConfig.Lockout
+	lockoutStorePQ := &lockout.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	lockoutService := &lockout.Service{
+		Config:   authenticationLockoutConfig,
+		Clock:    clockClock,
+		Store:    lockoutStorePQ,
+		Database: handle,
+		Users:    rawProvider,
+		Hooks:    hookProvider,
+	}
+	authorizationStore := &pq.AuthorizationStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	storeRedisLogger := idpsession.NewStoreRedisLogger(factory)
+	idpsessionStoreRedis := &idpsession.StoreRedis{
+		Redis:  redisHandle,
+		AppID:  appID,
+		Clock:  clockClock,
+		Logger: storeRedisLogger,
+	}
+	redisLogger := redis.NewLogger(factory)
+	grantStore := &redis.GrantStore{
+		Redis:       redisHandle,
+		AppID:       appID,
+		Logger:      redisLogger,
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+		Clock:       clockClock,
+	}
+	userdeletionService := &userdeletion.Service{
+		Clock:           clockClock,
+		Users:           queries,
+		UserCommands:    rawCommands,
+		Identities:      serviceService,
+		Authenticators:  service4,
+		Verification:    verificationService,
+		MFA:             mfaService,
+		PasswordHistory: historyStore,
+		Lockout:         lockoutService,
+		Authorizations:  authorizationStore,
+		IDPSessions:     idpsessionStoreRedis,
+		OfflineGrants:   grantStore,
+		Hooks:           hookProvider,
+	}
+	interactionLogger := interaction.NewLogger(factory)
+	trustProxy := environmentConfig.TrustProxy
+	remoteIP := deps.ProvideRemoteIP(request, trustProxy)
+	authenticatorFacade := facade.AuthenticatorFacade{
+		Coordinator: coordinator,
+	}
+	staticAssetURLPrefix := environmentConfig.StaticAssetURLPrefix
+	rateLimitConfig := appConfig.RateLimit
 	webEndpoints := &WebEndpoints{}
+	ratelimitLogger := ratelimit.NewLogger(factory)
+	storageRedis := &ratelimit.StorageRedis{
+		AppID: appID,
+		Redis: redisHandle,
+		Clock: clockClock,
+	}
+	limiter := &ratelimit.Limiter{
+		Logger:  ratelimitLogger,
+		Storage: storageRedis,
+	}
 	messageSender := &otp.MessageSender{
 		StaticAssetURLPrefix: staticAssetURLPrefix,
+		RateLimitConfig:      rateLimitConfig,
 		Translation:          translationService,
 		Endpoints:            webEndpoints,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 	}
 	codeSender := &oob.CodeSender{
 		OTPMessageSender: messageSender,
@@ -294,6 +466,7 @@ func newGraphQLHandler(p *deps.RequestProvider) http.Handler {
 	userInfoDecoder := sso.UserInfoDecoder{
 		LoginIDNormalizerFactory: normalizerFactory,
 	}
+	oidcCache := rootProvider.OIDCCache
 	oAuthProviderFactory := &sso.OAuthProviderFactory{
 		Endpoints:                webEndpoints,
 		IdentityConfig:           identityConfig,
@@ -302,21 +475,7 @@ func newGraphQLHandler(p *deps.RequestProvider) http.Handler {
 		Clock:                    clockClock,
 		UserInfoDecoder:          userInfoDecoder,
 		LoginIDNormalizerFactory: normalizerFactory,
-	}
-	storeDeviceTokenRedis := &mfa.StoreDeviceTokenRedis{
-		Redis: redisHandle,
-		AppID: appID,
-		Clock: clockClock,
-	}
-	storeRecoveryCodePQ := &mfa.StoreRecoveryCodePQ{
-		SQLBuilder:  sqlBuilder,
-		SQLExecutor: sqlExecutor,
-	}
-	mfaService := &mfa.Service{
-		DeviceTokens:  storeDeviceTokenRedis,
-		RecoveryCodes: storeRecoveryCodePQ,
-		Clock:         clockClock,
-		Config:        authenticationConfig,
+		OIDCCache:                oidcCache,
 	}
 	forgotPasswordConfig := appConfig.ForgotPassword
 	forgotpasswordStore := &forgotpassword.Store{
@@ -327,10 +486,12 @@ func newGraphQLHandler(p *deps.RequestProvider) http.Handler {
 		StaticAssetURLPrefix: staticAssetURLPrefix,
 		Translation:          translationService,
 		Config:               forgotPasswordConfig,
+		RateLimitConfig:      rateLimitConfig,
 		Store:                forgotpasswordStore,
 		Clock:                clockClock,
 		URLs:                 webEndpoints,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 		Logger:               providerLogger,
 		Identities:           identityFacade,
 		Authenticators:       authenticatorFacade,
@@ -344,65 +505,21 @@ func newGraphQLHandler(p *deps.RequestProvider) http.Handler {
 		AppID: appID,
 		Clock: clockClock,
 	}
-	welcomeMessageConfig := appConfig.WelcomeMessage
-	welcomemessageProvider := &welcomemessage.Provider{
-		Translation:          translationService,
-		WelcomeMessageConfig: welcomeMessageConfig,
-		TaskQueue:            queue,
-	}
-	rawCommands := &user.RawCommands{
-		Store:                  store,
-		Clock:                  clockClock,
-		WelcomeMessageProvider: welcomemessageProvider,
-		Queries:                queries,
-	}
-	hookLogger := hook.NewLogger(factory)
-	rawProvider := &user.RawProvider{
-		RawCommands: rawCommands,
-		Queries:     queries,
+	userProvider := &user.Provider{
+		Commands: commands,
+		Queries:  queries,
 	}
-	hookStore := &hook.Store{
+	auditStorePQ := &audit.StorePQ{
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
-	hookConfig := appConfig.Hook
-	webhookKeyMaterials := deps.ProvideWebhookKeyMaterials(secretConfig)
-	syncHTTPClient := hook.NewSyncHTTPClient(hookConfig)
-	asyncHTTPClient := hook.NewAsyncHTTPClient()
-	deliverer := &hook.Deliverer{
-		Config:    hookConfig,
-		Secret:    webhookKeyMaterials,
-		Clock:     clockClock,
-		SyncHTTP:  syncHTTPClient,
-		AsyncHTTP: asyncHTTPClient,
-	}
-	hookProvider := &hook.Provider{
-		Context:   context,
-		Logger:    hookLogger,
-		Database:  handle,
-		Clock:     clockClock,
-		Users:     rawProvider,
-		Store:     hookStore,
-		Deliverer: deliverer,
-	}
-	commands := &user.Commands{
-		Raw:          rawCommands,
-		Hooks:        hookProvider,
-		Verification: verificationService,
-	}
-	userProvider := &user.Provider{
-		Commands: commands,
-		Queries:  queries,
+	auditService := &audit.Service{
+		Context:  context,
+		Clock:    clockClock,
+		Store:    auditStorePQ,
+		Database: handle,
 	}
-	trustProxy := environmentConfig.TrustProxy
 	cookieFactory := deps.NewCookieFactory(request, trustProxy)
-	storeRedisLogger := idpsession.NewStoreRedisLogger(factory)
-	idpsessionStoreRedis := &idpsession.StoreRedis{
-		Redis:  redisHandle,
-		AppID:  appID,
-		Clock:  clockClock,
-		Logger: storeRedisLogger,
-	}
 	eventStoreRedis := &access.EventStoreRedis{
 		Redis: redisHandle,
 		AppID: appID,
@@ -421,18 +538,19 @@ func newGraphQLHandler(p *deps.RequestProvider) http.Handler {
 		Clock:        clockClock,
 		Random:       rand,
 	}
-	httpConfig := appConfig.HTTP
 	cookieDef := idpsession.NewSessionCookieDef(httpConfig, sessionConfig)
 	mfaCookieDef := mfa.NewDeviceTokenCookieDef(httpConfig, authenticationConfig)
 	interactionContext := &interaction.Context{
 		Database:                 sqlExecutor,
 		Clock:                    clockClock,
 		Config:                   appConfig,
+		RemoteIP:                 remoteIP,
 		Identities:               identityFacade,
 		Authenticators:           authenticatorFacade,
 		AnonymousIdentities:      anonymousProvider,
 		OOBAuthenticators:        oobProvider,
 		OOBCodeSender:            codeSender,
+		WebAuthnAuthenticators:   webauthnProvider,
 		OAuthProviderFactory:     oAuthProviderFactory,
 		MFA:                      mfaService,
 		ForgotPassword:           forgotpasswordProvider,
@@ -443,6 +561,9 @@ func newGraphQLHandler(p *deps.RequestProvider) http.Handler {
 		Challenges:               challengeProvider,
 		Users:                    userProvider,
 		Hooks:                    hookProvider,
+		RateLimiter:              limiter,
+		Lockout:                  lockoutService,
+		Audit:                    auditService,
 		CookieFactory:            cookieFactory,
 		Sessions:                 idpsessionProvider,
 		SessionCookie:            cookieDef,
@@ -461,8 +582,11 @@ func newGraphQLHandler(p *deps.RequestProvider) http.Handler {
 		Graph: interactionService,
 	}
 	userLoader := &loader.UserLoader{
-		Users:       queries,
-		Interaction: serviceInteractionService,
+		Users:        queries,
+		UserCommands: commands,
+		Deletion:     userdeletionService,
+		Interaction:  serviceInteractionService,
+		Lockout:      lockoutService,
 	}
 	identityLoader := &loader.IdentityLoader{
 		Identities:  serviceService,
@@ -475,12 +599,76 @@ func newGraphQLHandler(p *deps.RequestProvider) http.Handler {
 	verificationLoader := &loader.VerificationLoader{
 		Verification: verificationService,
 	}
+	eventQueries := &hook.EventQueries{
+		Store: hookStore,
+	}
+	deliveryLogger := hook.NewDeliveryLogger(factory)
+	deliveryService := &hook.DeliveryService{
+		Config:    hookConfig,
+		Logger:    deliveryLogger,
+		Clock:     clockClock,
+		Store:     hookStore,
+		Deliverer: deliverer,
+		TaskQueue: queue,
+	}
+	eventLoader := &loader.EventLoader{
+		Events:   eventQueries,
+		Delivery: deliveryService,
+	}
+	auditQueries := &audit.Queries{
+		Store: auditStorePQ,
+	}
+	auditLogLoader := &loader.AuditLogLoader{
+		AuditLogs: auditQueries,
+	}
+	userexportStorePQ := &userexport.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	userexportService := &userexport.Service{
+		Clock:          clockClock,
+		Store:          userexportStorePQ,
+		Users:          queries,
+		Identities:     serviceService,
+		Authenticators: service4,
+		Verification:   verificationService,
+		TaskQueue:      queue,
+	}
+	userExportLoader := &loader.UserExportLoader{
+		Exports: userexportService,
+	}
+	manager := &idpsession.Manager{
+		Store:         idpsessionStoreRedis,
+		Clock:         clockClock,
+		Config:        sessionConfig,
+		CookieFactory: cookieFactory,
+		CookieDef:     cookieDef,
+	}
+	sessionManager := &oauth2.SessionManager{
+		Store: grantStore,
+		Clock: clockClock,
+	}
+	manager2 := &session.Manager{
+		Users:               queries,
+		Hooks:               hookProvider,
+		IDPSessions:         manager,
+		AccessTokenSessions: sessionManager,
+	}
+	sessionLoader := &loader.SessionLoader{
+		Sessions: manager2,
+		MFA:      mfaService,
+	}
 	graphqlContext := &graphql.Context{
 		GQLLogger:      logger,
 		Users:          userLoader,
 		Identities:     identityLoader,
 		Authenticators: authenticatorLoader,
 		Verification:   verificationLoader,
+		Events:         eventLoader,
+		AuditLogs:      auditLogLoader,
+		UserExports:    userExportLoader,
+		Sessions:       sessionLoader,
+		AuditLogger:    auditService,
 	}
 	devMode := environmentConfig.DevMode
 	graphQLHandler := &transport.GraphQLHandler{
@@ -494,3 +682,436 @@ func newGraphQLHandler(p *deps.RequestProvider) http.Handler {
 var (
 	_wireRandValue = idpsession.Rand(rand.SecureRand)
 )
+
+func newUserImportHandler(p *deps.RequestProvider) http.Handler {
+	appProvider := p.AppProvider
+	factory := appProvider.LoggerFactory
+	userImportHandlerLogger := transport.NewUserImportHandlerLogger(factory)
+	handle := appProvider.Database
+	configConfig := appProvider.Config
+	appConfig := configConfig.AppConfig
+	identityConfig := appConfig.Identity
+	authenticationConfig := appConfig.Authentication
+	secretConfig := configConfig.SecretConfig
+	databaseCredentials := deps.ProvideDatabaseCredentials(secretConfig)
+	appID := appConfig.ID
+	sqlBuilder := db.ProvideSQLBuilder(databaseCredentials, appID)
+	request := p.Request
+	context := deps.ProvideRequestContext(request)
+	sqlExecutor := db.SQLExecutor{
+		Context:  context,
+		Database: handle,
+	}
+	store := &user.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	clockClock := _wireSystemClockValue
+	rootProvider := appProvider.RootProvider
+	environmentConfig := rootProvider.EnvironmentConfig
+	engine := appProvider.TemplateEngine
+	translationService := &translation.Service{
+		Context:           context,
+		EnvironmentConfig: environmentConfig,
+		TemplateEngine:    engine,
+	}
+	welcomeMessageConfig := appConfig.WelcomeMessage
+	queue := appProvider.TaskQueue
+	provider := &welcomemessage.Provider{
+		Translation:          translationService,
+		WelcomeMessageConfig: welcomeMessageConfig,
+		TaskQueue:            queue,
+	}
+	serviceStore := &service.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	loginidStore := &loginid.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	loginIDConfig := identityConfig.LoginID
+	reservedNameChecker := rootProvider.ReservedNameChecker
+	typeCheckerFactory := &loginid.TypeCheckerFactory{
+		Config:              loginIDConfig,
+		ReservedNameChecker: reservedNameChecker,
+	}
+	checker := &loginid.Checker{
+		Config:             loginIDConfig,
+		TypeCheckerFactory: typeCheckerFactory,
+	}
+	normalizerFactory := &loginid.NormalizerFactory{
+		Config: loginIDConfig,
+	}
+	loginidProvider := &loginid.Provider{
+		Store:             loginidStore,
+		Config:            loginIDConfig,
+		Checker:           checker,
+		NormalizerFactory: normalizerFactory,
+		Clock:             clockClock,
+	}
+	oauthStore := &oauth.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	oauthProvider := &oauth.Provider{
+		Store: oauthStore,
+		Clock: clockClock,
+	}
+	anonymousStore := &anonymous.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	anonymousProvider := &anonymous.Provider{
+		Store: anonymousStore,
+		Clock: clockClock,
+	}
+	serviceService := &service.Service{
+		Authentication: authenticationConfig,
+		Identity:       identityConfig,
+		Store:          serviceStore,
+		LoginID:        loginidProvider,
+		OAuth:          oauthProvider,
+		Anonymous:      anonymousProvider,
+	}
+	store2 := &service2.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	passwordStore := &password.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	authenticatorConfig := appConfig.Authenticator
+	authenticatorPasswordConfig := authenticatorConfig.Password
+	logger := password.NewLogger(factory)
+	historyStore := &password.HistoryStore{
+		Clock:       clockClock,
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	passwordChecker := password.ProvideChecker(authenticatorPasswordConfig, historyStore)
+	passwordProvider := &password.Provider{
+		Store:           passwordStore,
+		Config:          authenticatorPasswordConfig,
+		Clock:           clockClock,
+		Logger:          logger,
+		PasswordHistory: historyStore,
+		PasswordChecker: passwordChecker,
+		TaskQueue:       queue,
+		Database:        handle,
+	}
+	totpStore := &totp.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	authenticatorTOTPConfig := authenticatorConfig.TOTP
+	totpProvider := &totp.Provider{
+		Store:  totpStore,
+		Config: authenticatorTOTPConfig,
+		Clock:  clockClock,
+	}
+	authenticatorOOBConfig := authenticatorConfig.OOB
+	oobStore := &oob.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	oobProvider := &oob.Provider{
+		Config: authenticatorOOBConfig,
+		Store:  oobStore,
+		Clock:  clockClock,
+	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	redisHandle := appProvider.Redis
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
+	service4 := &service2.Service{
+		Store:    store2,
+		Password: passwordProvider,
+		TOTP:     totpProvider,
+		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
+	}
+	verificationLogger := verification.NewLogger(factory)
+	verificationConfig := appConfig.Verification
+	storeRedis := &verification.StoreRedis{
+		Redis: redisHandle,
+		AppID: appID,
+		Clock: clockClock,
+	}
+	storePQ := &verification.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	verificationService := &verification.Service{
+		Logger:     verificationLogger,
+		Config:     verificationConfig,
+		Clock:      clockClock,
+		CodeStore:  storeRedis,
+		ClaimStore: storePQ,
+	}
+	coordinator := &facade.Coordinator{
+		Identities:     serviceService,
+		Authenticators: service4,
+		Verification:   verificationService,
+		IdentityConfig: identityConfig,
+	}
+	identityFacade := facade.IdentityFacade{
+		Coordinator: coordinator,
+	}
+	queries := &user.Queries{
+		Store:        store,
+		Identities:   identityFacade,
+		Verification: verificationService,
+		Clock:        clockClock,
+	}
+	rawCommands := &user.RawCommands{
+		Store:                  store,
+		Clock:                  clockClock,
+		WelcomeMessageProvider: provider,
+		Queries:                queries,
+	}
+	auditStorePQ := &audit.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	auditService := &audit.Service{
+		Context:  context,
+		Clock:    clockClock,
+		Store:    auditStorePQ,
+		Database: handle,
+	}
+	userimportService := &userimport.Service{
+		IdentityConfig:       identityConfig,
+		AuthenticationConfig: authenticationConfig,
+		UserCommands:         rawCommands,
+		Identities:           serviceService,
+		Authenticators:       service4,
+		Verification:         verificationService,
+		AuditLogger:          auditService,
+	}
+	userImportHandler := &transport.UserImportHandler{
+		Logger:   userImportHandlerLogger,
+		Database: handle,
+		Importer: userimportService,
+	}
+	return userImportHandler
+}
+
+func newUserExportHandler(p *deps.RequestProvider) http.Handler {
+	appProvider := p.AppProvider
+	factory := appProvider.LoggerFactory
+	userExportHandlerLogger := transport.NewUserExportHandlerLogger(factory)
+	handle := appProvider.Database
+	clockClock := _wireSystemClockValue
+	configConfig := appProvider.Config
+	secretConfig := configConfig.SecretConfig
+	databaseCredentials := deps.ProvideDatabaseCredentials(secretConfig)
+	appConfig := configConfig.AppConfig
+	appID := appConfig.ID
+	sqlBuilder := db.ProvideSQLBuilder(databaseCredentials, appID)
+	request := p.Request
+	context := deps.ProvideRequestContext(request)
+	sqlExecutor := db.SQLExecutor{
+		Context:  context,
+		Database: handle,
+	}
+	storePQ := &userexport.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	store := &user.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	authenticationConfig := appConfig.Authentication
+	identityConfig := appConfig.Identity
+	serviceStore := &service.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	loginidStore := &loginid.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	loginIDConfig := identityConfig.LoginID
+	rootProvider := appProvider.RootProvider
+	reservedNameChecker := rootProvider.ReservedNameChecker
+	typeCheckerFactory := &loginid.TypeCheckerFactory{
+		Config:              loginIDConfig,
+		ReservedNameChecker: reservedNameChecker,
+	}
+	checker := &loginid.Checker{
+		Config:             loginIDConfig,
+		TypeCheckerFactory: typeCheckerFactory,
+	}
+	normalizerFactory := &loginid.NormalizerFactory{
+		Config: loginIDConfig,
+	}
+	provider := &loginid.Provider{
+		Store:             loginidStore,
+		Config:            loginIDConfig,
+		Checker:           checker,
+		NormalizerFactory: normalizerFactory,
+		Clock:             clockClock,
+	}
+	oauthStore := &oauth.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	oauthProvider := &oauth.Provider{
+		Store: oauthStore,
+		Clock: clockClock,
+	}
+	anonymousStore := &anonymous.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	anonymousProvider := &anonymous.Provider{
+		Store: anonymousStore,
+		Clock: clockClock,
+	}
+	serviceService := &service.Service{
+		Authentication: authenticationConfig,
+		Identity:       identityConfig,
+		Store:          serviceStore,
+		LoginID:        provider,
+		OAuth:          oauthProvider,
+		Anonymous:      anonymousProvider,
+	}
+	store2 := &service2.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	passwordStore := &password.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	authenticatorConfig := appConfig.Authenticator
+	authenticatorPasswordConfig := authenticatorConfig.Password
+	logger := password.NewLogger(factory)
+	historyStore := &password.HistoryStore{
+		Clock:       clockClock,
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	passwordChecker := password.ProvideChecker(authenticatorPasswordConfig, historyStore)
+	queue := appProvider.TaskQueue
+	passwordProvider := &password.Provider{
+		Store:           passwordStore,
+		Config:          authenticatorPasswordConfig,
+		Clock:           clockClock,
+		Logger:          logger,
+		PasswordHistory: historyStore,
+		PasswordChecker: passwordChecker,
+		TaskQueue:       queue,
+		Database:        handle,
+	}
+	totpStore := &totp.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	authenticatorTOTPConfig := authenticatorConfig.TOTP
+	totpProvider := &totp.Provider{
+		Store:  totpStore,
+		Config: authenticatorTOTPConfig,
+		Clock:  clockClock,
+	}
+	authenticatorOOBConfig := authenticatorConfig.OOB
+	oobStore := &oob.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	oobProvider := &oob.Provider{
+		Config: authenticatorOOBConfig,
+		Store:  oobStore,
+		Clock:  clockClock,
+	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	redisHandle := appProvider.Redis
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
+	service4 := &service2.Service{
+		Store:    store2,
+		Password: passwordProvider,
+		TOTP:     totpProvider,
+		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
+	}
+	verificationLogger := verification.NewLogger(factory)
+	verificationConfig := appConfig.Verification
+	storeRedis := &verification.StoreRedis{
+		Redis: redisHandle,
+		AppID: appID,
+		Clock: clockClock,
+	}
+	verificationStorePQ := &verification.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	verificationService := &verification.Service{
+		Logger:     verificationLogger,
+		Config:     verificationConfig,
+		Clock:      clockClock,
+		CodeStore:  storeRedis,
+		ClaimStore: verificationStorePQ,
+	}
+	coordinator := &facade.Coordinator{
+		Identities:     serviceService,
+		Authenticators: service4,
+		Verification:   verificationService,
+		IdentityConfig: identityConfig,
+	}
+	identityFacade := facade.IdentityFacade{
+		Coordinator: coordinator,
+	}
+	queries := &user.Queries{
+		Store:        store,
+		Identities:   identityFacade,
+		Verification: verificationService,
+		Clock:        clockClock,
+	}
+	userexportService := &userexport.Service{
+		Clock:          clockClock,
+		Store:          storePQ,
+		Users:          queries,
+		Identities:     serviceService,
+		Authenticators: service4,
+		Verification:   verificationService,
+		TaskQueue:      queue,
+	}
+	userExportHandler := &transport.UserExportHandler{
+		Logger:   userExportHandlerLogger,
+		Database: handle,
+		Exports:  userexportService,
+	}
+	return userExportHandler
+}
@@ -0,0 +1,38 @@
+package event
+
+const (
+	Test Type = "test"
+)
+
+/*
+	@Callback
+		@Operation POST /test - Test event
+			A synthetic event sent manually to verify the handler.
+			@RequestBody
+				@JSONSchema {TestEvent}
+			@Response 200 {EmptyResponse}
+*/
+type TestEvent struct{}
+
+// @JSONSchema
+const TestEventSchema = `
+{
+	"$id": "#TestEvent",
+	"type": "object",
+	"properties": {
+		"id": { "type": "string" },
+		"seq": { "type": "integer" },
+		"type": { "type": "string", "enum": ["test"] },
+		"payload": { "type": "object" },
+		"context": { "$ref": "#EventContext" }
+	}
+}
+`
+
+func (e *TestEvent) EventType() Type {
+	return Test
+}
+
+func (e *TestEvent) UserID() string {
+	return ""
+}
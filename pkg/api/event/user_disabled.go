@@ -0,0 +1,61 @@
+package event
+
+import (
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/api/model"
+)
+
+const (
+	UserDisabled Type = "user_disabled"
+)
+
+/*
+	@Callback
+		@Operation POST /user_disabled - User disabled
+			User is disabled by the developer.
+			@RequestBody
+				@JSONSchema {UserDisabledEvent}
+			@Response 200 {EmptyResponse}
+*/
+type UserDisabledEvent struct {
+	User          model.User `json:"user"`
+	Reason        *string    `json:"reason,omitempty"`
+	DisabledUntil *time.Time `json:"disabled_until,omitempty"`
+}
+
+// @JSONSchema
+const UserDisabledEventSchema = `
+{
+	"$id": "#UserDisabledEvent",
+	"type": "object",
+	"properties": {
+		"id": { "type": "string" },
+		"seq": { "type": "integer" },
+		"type": { "type": "string", "enum": ["user_disabled"] },
+		"payload": { "$ref": "#UserDisabledEventPayload" },
+		"context": { "$ref": "#EventContext" }
+	}
+}
+`
+
+// @JSONSchema
+const UserDisabledEventPayloadSchema = `
+{
+	"$id": "#UserDisabledEventPayload",
+	"type": "object",
+	"properties": {
+		"user": { "$ref": "#User" },
+		"reason": { "type": "string" },
+		"disabled_until": { "type": "string", "format": "date-time" }
+	}
+}
+`
+
+func (e *UserDisabledEvent) EventType() Type {
+	return UserDisabled
+}
+
+func (e *UserDisabledEvent) UserID() string {
+	return e.User.ID
+}
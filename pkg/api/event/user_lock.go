@@ -0,0 +1,59 @@
+package event
+
+import (
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/api/model"
+)
+
+const (
+	UserLock Type = "user_lock"
+)
+
+/*
+	@Callback
+		@Operation POST /user_lock - User locked out
+			User is locked out due to repeated failed authentication attempts.
+			@RequestBody
+				@JSONSchema {UserLockEvent}
+			@Response 200 {EmptyResponse}
+*/
+type UserLockEvent struct {
+	User        model.User `json:"user"`
+	LockedUntil time.Time  `json:"locked_until"`
+}
+
+// @JSONSchema
+const UserLockEventSchema = `
+{
+	"$id": "#UserLockEvent",
+	"type": "object",
+	"properties": {
+		"id": { "type": "string" },
+		"seq": { "type": "integer" },
+		"type": { "type": "string", "enum": ["user_lock"] },
+		"payload": { "$ref": "#UserLockEventPayload" },
+		"context": { "$ref": "#EventContext" }
+	}
+}
+`
+
+// @JSONSchema
+const UserLockEventPayloadSchema = `
+{
+	"$id": "#UserLockEventPayload",
+	"type": "object",
+	"properties": {
+		"user": { "$ref": "#User" },
+		"locked_until": { "type": "string", "format": "date-time" }
+	}
+}
+`
+
+func (e *UserLockEvent) EventType() Type {
+	return UserLock
+}
+
+func (e *UserLockEvent) UserID() string {
+	return e.User.ID
+}
@@ -0,0 +1,55 @@
+package event
+
+import (
+	"github.com/authgear/authgear-server/pkg/api/model"
+)
+
+const (
+	UserReenabled Type = "user_reenabled"
+)
+
+/*
+	@Callback
+		@Operation POST /user_reenabled - User re-enabled
+			Disabled user is re-enabled by the developer.
+			@RequestBody
+				@JSONSchema {UserReenabledEvent}
+			@Response 200 {EmptyResponse}
+*/
+type UserReenabledEvent struct {
+	User model.User `json:"user"`
+}
+
+// @JSONSchema
+const UserReenabledEventSchema = `
+{
+	"$id": "#UserReenabledEvent",
+	"type": "object",
+	"properties": {
+		"id": { "type": "string" },
+		"seq": { "type": "integer" },
+		"type": { "type": "string", "enum": ["user_reenabled"] },
+		"payload": { "$ref": "#UserReenabledEventPayload" },
+		"context": { "$ref": "#EventContext" }
+	}
+}
+`
+
+// @JSONSchema
+const UserReenabledEventPayloadSchema = `
+{
+	"$id": "#UserReenabledEventPayload",
+	"type": "object",
+	"properties": {
+		"user": { "$ref": "#User" }
+	}
+}
+`
+
+func (e *UserReenabledEvent) EventType() Type {
+	return UserReenabled
+}
+
+func (e *UserReenabledEvent) UserID() string {
+	return e.User.ID
+}
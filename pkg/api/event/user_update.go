@@ -0,0 +1,90 @@
+package event
+
+import "github.com/authgear/authgear-server/pkg/api/model"
+
+const (
+	BeforeUserUpdate Type = "before_user_update"
+	AfterUserUpdate  Type = "after_user_update"
+)
+
+type UserUpdateReason string
+
+const (
+	UserUpdateReasonUpdateProfile  UserUpdateReason = "update_profile"
+	UserUpdateReasonAdministrative UserUpdateReason = "administrative"
+)
+
+/*
+	@Callback
+		@Operation POST /before_user_update - Before user update
+			User attributes are about to be updated.
+			@RequestBody
+				@JSONSchema {BeforeUserUpdateEvent}
+			@Response 200 {HookResponse}
+
+		@Operation POST /after_user_update - After user update
+			User attributes are updated.
+			@RequestBody
+				@JSONSchema {AfterUserUpdateEvent}
+			@Response 200 {EmptyResponse}
+*/
+type UserUpdateEvent struct {
+	Reason  UserUpdateReason       `json:"reason"`
+	Profile map[string]interface{} `json:"profile,omitempty"`
+	User    model.User             `json:"user"`
+}
+
+// @JSONSchema
+const BeforeUserUpdateEventSchema = `
+{
+	"$id": "#BeforeUserUpdateEvent",
+	"type": "object",
+	"properties": {
+		"id": { "type": "string" },
+		"seq": { "type": "integer" },
+		"type": { "type": "string", "enum": ["before_user_update"] },
+		"payload": { "$ref": "#UserUpdateEventPayload" },
+		"context": { "$ref": "#EventContext" }
+	}
+}
+`
+
+// @JSONSchema
+const AfterUserUpdateEventSchema = `
+{
+	"$id": "#AfterUserUpdateEvent",
+	"type": "object",
+	"properties": {
+		"id": { "type": "string" },
+		"seq": { "type": "integer" },
+		"type": { "type": "string", "enum": ["after_user_update"] },
+		"payload": { "$ref": "#UserUpdateEventPayload" },
+		"context": { "$ref": "#EventContext" }
+	}
+}
+`
+
+// @JSONSchema
+const UserUpdateEventPayloadSchema = `
+{
+	"$id": "#UserUpdateEventPayload",
+	"type": "object",
+	"properties": {
+		"reason": { "type": "string", "enum": ["update_profile", "administrative"] },
+		"profile": { "type": "object" },
+		"user": { "$ref": "#User" }
+	}
+}
+`
+
+func (e *UserUpdateEvent) BeforeEventType() Type {
+	return BeforeUserUpdate
+}
+
+func (e *UserUpdateEvent) AfterEventType() Type {
+	return AfterUserUpdate
+}
+
+func (e *UserUpdateEvent) UserID() string {
+	return e.User.ID
+}
@@ -7,18 +7,35 @@ import (
 	"strings"
 )
 
+type SessionInfoType string
+
+const (
+	// SessionInfoTypeUser is the type of sessions of users.
+	SessionInfoTypeUser SessionInfoType = "user"
+	// SessionInfoTypeClient is the type of sessions of clients authenticated
+	// with the client_credentials grant.
+	SessionInfoTypeClient SessionInfoType = "client"
+)
+
 type SessionInfo struct {
 	IsValid       bool
+	Type          SessionInfoType
 	UserID        string
 	UserAnonymous bool
 	UserVerified  bool
 
 	SessionACR string
 	SessionAMR []string
+
+	ClientID    string
+	ClientScope []string
 }
 
 const (
 	headerSessionValid  = "X-Authgear-Session-Valid"
+	headerSessionType   = "X-Authgear-Session-Type"
+	headerClientID      = "X-Authgear-Client-Id"
+	headerClientScope   = "X-Authgear-Client-Scope"
 	headerUserID        = "X-Authgear-User-Id"
 	headerUserVerified  = "X-Authgear-User-Verified"
 	headerUserAnonymous = "X-Authgear-User-Anonymous"
@@ -36,6 +53,14 @@ func (i *SessionInfo) PopulateHeaders(rw http.ResponseWriter) {
 		return
 	}
 
+	if i.Type == SessionInfoTypeClient {
+		rw.Header().Set(headerSessionType, string(SessionInfoTypeClient))
+		rw.Header().Set(headerClientID, i.ClientID)
+		rw.Header().Set(headerClientScope, strings.Join(i.ClientScope, " "))
+		return
+	}
+
+	rw.Header().Set(headerSessionType, string(SessionInfoTypeUser))
 	rw.Header().Set(headerUserID, i.UserID)
 	rw.Header().Set(headerUserAnonymous, strconv.FormatBool(i.UserAnonymous))
 	rw.Header().Set(headerUserVerified, strconv.FormatBool(i.UserVerified))
@@ -75,6 +100,14 @@ func NewSessionInfoFromHeaders(hdr http.Header) (info *SessionInfo, err error) {
 		return
 	}
 
+	if SessionInfoType(hdr.Get(headerSessionType)) == SessionInfoTypeClient {
+		info.IsValid = sessionValid
+		info.Type = SessionInfoTypeClient
+		info.ClientID = hdr.Get(headerClientID)
+		info.ClientScope = headerParseSpaceSeparated(hdr.Get(headerClientScope))
+		return
+	}
+
 	userID := hdr.Get(headerUserID)
 
 	anonymous, err := headerParseBool(headerUserAnonymous, hdr.Get(headerUserAnonymous))
@@ -95,6 +128,7 @@ func NewSessionInfoFromHeaders(hdr http.Header) (info *SessionInfo, err error) {
 	amr := headerParseSpaceSeparated(hdr.Get(headerSessionAmr))
 
 	info.IsValid = sessionValid
+	info.Type = SessionInfoTypeUser
 	info.UserID = userID
 	info.UserAnonymous = anonymous
 	info.UserVerified = verified
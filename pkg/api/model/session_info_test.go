@@ -39,6 +39,7 @@ func TestSessionInfo(t *testing.T) {
 				i.PopulateHeaders(rw)
 				So(rw.Header(), ShouldResemble, http.Header{
 					"X-Authgear-Session-Valid":  []string{"true"},
+					"X-Authgear-Session-Type":   []string{"user"},
 					"X-Authgear-User-Id":        []string{"user-id"},
 					"X-Authgear-User-Anonymous": []string{"true"},
 					"X-Authgear-User-Verified":  []string{"true"},
@@ -46,6 +47,23 @@ func TestSessionInfo(t *testing.T) {
 					"X-Authgear-Session-Amr":    []string{"pwd mfa otp"},
 				})
 			})
+
+			Convey("valid client credentials auth", func() {
+				var i = &model.SessionInfo{
+					IsValid:     true,
+					Type:        model.SessionInfoTypeClient,
+					ClientID:    "client-id",
+					ClientScope: []string{"api:read"},
+				}
+
+				i.PopulateHeaders(rw)
+				So(rw.Header(), ShouldResemble, http.Header{
+					"X-Authgear-Session-Valid": []string{"true"},
+					"X-Authgear-Session-Type":  []string{"client"},
+					"X-Authgear-Client-Id":     []string{"client-id"},
+					"X-Authgear-Client-Scope":  []string{"api:read"},
+				})
+			})
 		})
 
 		Convey("PopulateHeaders and NewSessionInfoFromHeaders are inverse", func() {
@@ -65,12 +83,20 @@ func TestSessionInfo(t *testing.T) {
 
 			test(&model.SessionInfo{
 				IsValid:       true,
+				Type:          model.SessionInfoTypeUser,
 				UserID:        "user-id",
 				UserAnonymous: true,
 				UserVerified:  true,
 				SessionACR:    "http://schemas.openid.net/pape/policies/2007/06/multi-factor",
 				SessionAMR:    []string{"pwd", "mfa", "otp"},
 			})
+
+			test(&model.SessionInfo{
+				IsValid:     true,
+				Type:        model.SessionInfoTypeClient,
+				ClientID:    "client-id",
+				ClientScope: []string{"api:read", "api:write"},
+			})
 		})
 	})
 }
@@ -9,4 +9,10 @@ type User struct {
 	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
 	IsAnonymous bool       `json:"is_anonymous"`
 	IsVerified  bool       `json:"is_verified"`
+
+	IsDisabled    bool       `json:"is_disabled"`
+	DisableReason *string    `json:"disable_reason,omitempty"`
+	DisabledUntil *time.Time `json:"disabled_until,omitempty"`
+
+	Profile map[string]interface{} `json:"profile"`
 }
@@ -3,6 +3,7 @@ package auth
 import (
 	"github.com/google/wire"
 
+	handlerheadless "github.com/authgear/authgear-server/pkg/auth/handler/headless"
 	handleroauth "github.com/authgear/authgear-server/pkg/auth/handler/oauth"
 	handlerwebapp "github.com/authgear/authgear-server/pkg/auth/handler/webapp"
 	viewmodelswebapp "github.com/authgear/authgear-server/pkg/auth/handler/webapp/viewmodels"
@@ -15,15 +16,18 @@ import (
 	"github.com/authgear/authgear-server/pkg/lib/authn/mfa"
 	"github.com/authgear/authgear-server/pkg/lib/authn/otp"
 	"github.com/authgear/authgear-server/pkg/lib/authn/sso"
+	"github.com/authgear/authgear-server/pkg/lib/authn/user"
 	"github.com/authgear/authgear-server/pkg/lib/deps"
 	"github.com/authgear/authgear-server/pkg/lib/feature/forgotpassword"
 	"github.com/authgear/authgear-server/pkg/lib/feature/verification"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
 	"github.com/authgear/authgear-server/pkg/lib/infra/middleware"
 	"github.com/authgear/authgear-server/pkg/lib/interaction"
 	"github.com/authgear/authgear-server/pkg/lib/oauth"
 	oauthhandler "github.com/authgear/authgear-server/pkg/lib/oauth/handler"
 	"github.com/authgear/authgear-server/pkg/lib/oauth/oidc"
 	oidchandler "github.com/authgear/authgear-server/pkg/lib/oauth/oidc/handler"
+	"github.com/authgear/authgear-server/pkg/lib/ratelimit"
 	"github.com/authgear/authgear-server/pkg/lib/session"
 	"github.com/authgear/authgear-server/pkg/lib/translation"
 	"github.com/authgear/authgear-server/pkg/util/httputil"
@@ -56,6 +60,8 @@ var DependencySet = wire.NewSet(
 
 	webapp.DependencySet,
 	wire.Bind(new(oauthhandler.WebAppAuthenticateURLProvider), new(*webapp.AuthenticateURLProvider)),
+	wire.Bind(new(oauthhandler.WebAppConsentURLProvider), new(*webapp.URLProvider)),
+	wire.Bind(new(oauthhandler.DeviceURLProvider), new(*webapp.URLProvider)),
 	wire.Bind(new(oidchandler.WebAppURLsProvider), new(*webapp.URLProvider)),
 	wire.Bind(new(sso.RedirectURLProvider), new(*webapp.URLProvider)),
 	wire.Bind(new(forgotpassword.URLProvider), new(*webapp.URLProvider)),
@@ -67,6 +73,8 @@ var DependencySet = wire.NewSet(
 	wire.Bind(new(handleroauth.ProtocolAuthorizeHandler), new(*oauthhandler.AuthorizationHandler)),
 	wire.Bind(new(handleroauth.ProtocolTokenHandler), new(*oauthhandler.TokenHandler)),
 	wire.Bind(new(handleroauth.ProtocolRevokeHandler), new(*oauthhandler.RevokeHandler)),
+	wire.Bind(new(handleroauth.ProtocolIntrospectionHandler), new(*oauthhandler.IntrospectionHandler)),
+	wire.Bind(new(handleroauth.ProtocolDeviceAuthorizationHandler), new(*oauthhandler.DeviceAuthorizationHandler)),
 	wire.Bind(new(handleroauth.ProtocolEndSessionHandler), new(*oidchandler.EndSessionHandler)),
 	wire.Bind(new(handleroauth.ProtocolUserInfoProvider), new(*oidc.IDTokenIssuer)),
 	wire.Bind(new(handleroauth.JWSSource), new(*oidc.IDTokenIssuer)),
@@ -74,6 +82,12 @@ var DependencySet = wire.NewSet(
 	wire.Bind(new(handleroauth.JSONResponseWriter), new(*httputil.JSONResponseWriter)),
 	ProvideOAuthMetadataProviders,
 
+	handlerheadless.DependencySet,
+	wire.Bind(new(handlerheadless.InteractionDatabase), new(*db.Handle)),
+	wire.Bind(new(handlerheadless.GraphService), new(*interaction.Service)),
+	wire.Bind(new(handlerheadless.TokenIssuer), new(*oauthhandler.TokenHandler)),
+	wire.Bind(new(handlerheadless.JSONResponseWriter), new(*httputil.JSONResponseWriter)),
+
 	viewmodelswebapp.DependencySet,
 	wire.Bind(new(viewmodelswebapp.TranslationService), new(*translation.Service)),
 
@@ -82,7 +96,13 @@ var DependencySet = wire.NewSet(
 	wire.Bind(new(handlerwebapp.SettingsMFAService), new(*mfa.Service)),
 	wire.Bind(new(handlerwebapp.SettingsIdentityService), new(*identityservice.Service)),
 	wire.Bind(new(handlerwebapp.SettingsVerificationService), new(*verification.Service)),
+	wire.Bind(new(handlerwebapp.SettingsConsentService), new(*oauthhandler.ConsentService)),
+	wire.Bind(new(handlerwebapp.SettingsProfileUserService), new(*user.Provider)),
+	wire.Bind(new(handlerwebapp.ConsentService), new(*oauthhandler.ConsentService)),
+	wire.Bind(new(handlerwebapp.DeviceService), new(*oauthhandler.DeviceService)),
+	wire.Bind(new(handlerwebapp.DeviceRateLimiter), new(*ratelimit.Limiter)),
 	wire.Bind(new(handlerwebapp.PasswordPolicy), new(*password.Checker)),
 	wire.Bind(new(handlerwebapp.LogoutSessionManager), new(*session.Manager)),
+	wire.Bind(new(handlerwebapp.SettingsSessionManager), new(*session.Manager)),
 	wire.Bind(new(handlerwebapp.WebAppService), new(*webapp.Service)),
 )
@@ -42,6 +42,7 @@ func (p *EndpointsProvider) urlOf(relPath string) *url.URL {
 func (p *EndpointsProvider) AuthorizeEndpointURL() *url.URL      { return p.urlOf("oauth2/authorize") }
 func (p *EndpointsProvider) TokenEndpointURL() *url.URL          { return p.urlOf("oauth2/token") }
 func (p *EndpointsProvider) RevokeEndpointURL() *url.URL         { return p.urlOf("oauth2/revoke") }
+func (p *EndpointsProvider) IntrospectEndpointURL() *url.URL     { return p.urlOf("oauth2/introspect") }
 func (p *EndpointsProvider) JWKSEndpointURL() *url.URL           { return p.urlOf("oauth2/jwks") }
 func (p *EndpointsProvider) UserInfoEndpointURL() *url.URL       { return p.urlOf("oauth2/userinfo") }
 func (p *EndpointsProvider) EndSessionEndpointURL() *url.URL     { return p.urlOf("oauth2/end_session") }
@@ -52,3 +53,9 @@ func (p *EndpointsProvider) SettingsEndpointURL() *url.URL       { return p.urlO
 func (p *EndpointsProvider) ResetPasswordEndpointURL() *url.URL  { return p.urlOf("./reset_password") }
 func (p *EndpointsProvider) VerifyIdentityEndpointURL() *url.URL { return p.urlOf("./verify_identity") }
 func (p *EndpointsProvider) SSOCallbackEndpointURL() *url.URL    { return p.urlOf("sso/oauth2/callback") }
+func (p *EndpointsProvider) ConsentEndpointURL() *url.URL        { return p.urlOf("./consent") }
+func (p *EndpointsProvider) DeviceEndpointURL() *url.URL         { return p.urlOf("./device") }
+
+func (p *EndpointsProvider) DeviceAuthorizationEndpointURL() *url.URL {
+	return p.urlOf("oauth2/device_authorization")
+}
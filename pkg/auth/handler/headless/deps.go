@@ -0,0 +1,10 @@
+package headless
+
+import (
+	"github.com/google/wire"
+)
+
+var DependencySet = wire.NewSet(
+	NewInteractionHandlerLogger,
+	wire.Struct(new(InteractionHandler), "*"),
+)
@@ -0,0 +1,359 @@
+package headless
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/authgear/authgear-server/pkg/api"
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+	"github.com/authgear/authgear-server/pkg/auth/webapp"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/interaction"
+	"github.com/authgear/authgear-server/pkg/lib/interaction/intents"
+	"github.com/authgear/authgear-server/pkg/lib/interaction/nodes"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
+	"github.com/authgear/authgear-server/pkg/lib/session"
+	"github.com/authgear/authgear-server/pkg/util/httproute"
+	"github.com/authgear/authgear-server/pkg/util/httputil"
+	"github.com/authgear/authgear-server/pkg/util/log"
+	"github.com/authgear/authgear-server/pkg/util/validation"
+)
+
+func ConfigureInteractionRoute(route httproute.Route) httproute.Route {
+	return route.
+		WithMethods("OPTIONS", "POST").
+		WithPathPattern("/api/v1/interaction")
+}
+
+const (
+	InteractionAPISchemaIDRequest  = "InteractionRequest"
+	InteractionAPISchemaIDResponse = "InteractionResponse"
+)
+
+var InteractionAPISchema = validation.NewMultipartSchema("").
+	Add(InteractionAPISchemaIDRequest, `
+		{
+			"type": "object",
+			"additionalProperties": false,
+			"properties": {
+				"state_id": { "type": "string", "minLength": 1 },
+				"client_id": { "type": "string" },
+				"intent": {
+					"type": "object",
+					"additionalProperties": false,
+					"properties": {
+						"kind": {
+							"type": "string",
+							"enum": ["login", "signup", "forgot_password", "reset_password", "add_identity"]
+						}
+					},
+					"required": ["kind"]
+				},
+				"input": {
+					"type": "object",
+					"additionalProperties": false,
+					"properties": {
+						"kind": {
+							"type": "string",
+							"enum": [
+								"login_id",
+								"anonymous",
+								"password",
+								"totp",
+								"totp_setup",
+								"oob_trigger",
+								"oob_setup",
+								"oob_otp",
+								"webauthn_trigger",
+								"webauthn_setup",
+								"webauthn",
+								"resend",
+								"recovery_code",
+								"recovery_codes_viewed",
+								"verification_code",
+								"forgot_password",
+								"reset_password"
+							]
+						},
+						"login_id_key": { "type": "string" },
+						"login_id": { "type": "string" },
+						"jwt": { "type": "string" },
+						"password": { "type": "string" },
+						"new_password": { "type": "string" },
+						"code": { "type": "string" },
+						"display_name": { "type": "string" },
+						"authenticator_index": { "type": "integer", "minimum": 0 },
+						"channel": { "type": "string", "enum": ["email", "sms"] },
+						"target": { "type": "string" }
+					},
+					"required": ["kind"]
+				}
+			}
+		}
+	`).
+	Add(InteractionAPISchemaIDResponse, `
+		{
+			"type": "object",
+			"properties": {
+				"finished": { "type": "boolean" },
+				"state_id": { "type": "string" },
+				"node": { "type": "string" },
+				"edges": {
+					"type": "array",
+					"items": {
+						"type": "object",
+						"properties": {
+							"input": { "type": "string" },
+							"login_id_keys": { "type": "array" },
+							"authenticators": { "type": "array" },
+							"oob_channels": { "type": "array", "items": { "type": "string" } }
+						},
+						"required": ["input"]
+					}
+				},
+				"data": { "type": "object" },
+				"tokens": { "type": "object" }
+			},
+			"required": ["finished"]
+		}
+	`).
+	Instantiate()
+
+var InvalidInteractionState = apierrors.NotFound.WithReason("InvalidInteractionState")
+var ErrInvalidInteractionState = InvalidInteractionState.New("invalid state or state not found")
+
+type InteractionIntent struct {
+	Kind string `json:"kind"`
+}
+
+type InteractionRequest struct {
+	StateID  string             `json:"state_id"`
+	ClientID string             `json:"client_id"`
+	Intent   *InteractionIntent `json:"intent"`
+	Input    *InteractionInput  `json:"input"`
+}
+
+func (p *InteractionRequest) Validate(ctx *validation.Context) {
+	if (p.StateID == "") == (p.Intent == nil) {
+		ctx.EmitErrorMessage("exactly one of state_id and intent is required")
+	}
+}
+
+type InteractionResponse struct {
+	Finished bool                   `json:"finished"`
+	StateID  string                 `json:"state_id,omitempty"`
+	Node     string                 `json:"node,omitempty"`
+	Edges    []InteractionEdge      `json:"edges,omitempty"`
+	Data     map[string]interface{} `json:"data,omitempty"`
+	Tokens   protocol.TokenResponse `json:"tokens,omitempty"`
+}
+
+type GraphService interface {
+	NewGraph(ctx *interaction.Context, intent interaction.Intent) (*interaction.Graph, error)
+	Get(instanceID string) (*interaction.Graph, error)
+	DryRun(webStateID string, fn func(*interaction.Context) (*interaction.Graph, error)) error
+	Run(webStateID string, graph *interaction.Graph, preserveGraph bool) error
+}
+
+type TokenIssuer interface {
+	IssueTokens(client config.OAuthClientConfig, attrs *session.Attrs) (session.Session, protocol.TokenResponse, error)
+}
+
+type InteractionDatabase interface {
+	WithTx(do func() error) error
+}
+
+type JSONResponseWriter interface {
+	WriteResponse(rw http.ResponseWriter, resp *api.Response)
+}
+
+type InteractionHandlerLogger struct{ *log.Logger }
+
+func NewInteractionHandlerLogger(lf *log.Factory) InteractionHandlerLogger {
+	return InteractionHandlerLogger{lf.New("handler-interaction")}
+}
+
+/*
+	@Operation POST /api/v1/interaction - Drive an interaction
+		Create an interaction with an intent, or feed an input to an
+		existing interaction identified by its state ID. Each step
+		returns a new state ID until the interaction finishes.
+
+		@Tag User
+
+		@RequestBody
+			Describe the intent or the state, and the input.
+			@JSONSchema {InteractionRequest}
+
+		@Response 200
+			The current node of the interaction and the accepted inputs,
+			or the issued tokens if the interaction has finished.
+			@JSONSchema {InteractionResponse}
+*/
+type InteractionHandler struct {
+	Logger      InteractionHandlerLogger
+	Database    InteractionDatabase
+	OAuthConfig *config.OAuthConfig
+	Graphs      GraphService
+	Tokens      TokenIssuer
+	JSON        JSONResponseWriter
+}
+
+func (h *InteractionHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	var result *InteractionResponse
+	var apiErr error
+	err := h.Database.WithTx(func() error {
+		var err error
+		result, err = h.Handle(resp, req)
+		// Like the web UI, errors of the interaction, such as invalid
+		// credentials, do not roll back the transaction. Otherwise
+		// the failed attempt would not be recorded for lockout and audit.
+		if apierrors.IsAPIError(err) {
+			apiErr = err
+			return nil
+		}
+		return err
+	})
+	if err == nil {
+		err = apiErr
+	}
+	if err == nil {
+		h.JSON.WriteResponse(resp, &api.Response{Result: result})
+	} else {
+		if !apierrors.IsAPIError(err) {
+			h.Logger.WithError(err).Error("interaction handler failed")
+		}
+		h.JSON.WriteResponse(resp, &api.Response{Error: err})
+	}
+}
+
+func (h *InteractionHandler) Handle(resp http.ResponseWriter, req *http.Request) (*InteractionResponse, error) {
+	var payload InteractionRequest
+	if err := httputil.BindJSONBody(req, resp, InteractionAPISchema.PartValidator(InteractionAPISchemaIDRequest), &payload); err != nil {
+		return nil, err
+	}
+
+	var input interface{}
+	if payload.Input != nil {
+		input = payload.Input
+	}
+
+	// Interaction states are addressed by graph instance ID, which is
+	// unguessable, so no CSRF protection is required.
+	webStateID := webapp.NewID()
+
+	var intent interaction.Intent
+	var graph *interaction.Graph
+	if payload.Intent != nil {
+		i, err := h.makeIntent(req, payload.Intent)
+		if err != nil {
+			return nil, err
+		}
+		intent = i
+	} else {
+		g, err := h.Graphs.Get(payload.StateID)
+		if errors.Is(err, interaction.ErrStateNotFound) {
+			return nil, ErrInvalidInteractionState
+		} else if err != nil {
+			return nil, err
+		}
+		graph = g
+	}
+
+	var edges []interaction.Edge
+	err := h.Graphs.DryRun(webStateID, func(ctx *interaction.Context) (*interaction.Graph, error) {
+		if graph == nil {
+			g, err := h.Graphs.NewGraph(ctx, intent)
+			if err != nil {
+				return nil, err
+			}
+			graph = g
+		} else {
+			err := graph.Apply(ctx)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		newGraph, newEdges, err := graph.Accept(ctx, input)
+		var inputRequired *interaction.ErrInputRequired
+		if err != nil && !errors.As(err, &inputRequired) {
+			return nil, err
+		}
+
+		// The new graph is persisted if more input is required or the
+		// graph finished.
+		graph = newGraph
+		edges = newEdges
+		return newGraph, nil
+	})
+	if errors.Is(err, interaction.ErrStateNotFound) {
+		return nil, ErrInvalidInteractionState
+	} else if err != nil {
+		return nil, err
+	}
+
+	if len(edges) != 0 {
+		return &InteractionResponse{
+			StateID: graph.InstanceID,
+			Node:    interaction.NodeKind(graph.CurrentNode()),
+			Edges:   DescribeEdges(edges),
+			Data:    DescribeNode(graph.CurrentNode()),
+		}, nil
+	}
+
+	// The graph finished. Resolve the client before applying its effect
+	// permanently, so that the interaction can be retried with a valid
+	// client.
+	var createSession *nodes.NodeDoCreateSession
+	var client config.OAuthClientConfig
+	for _, node := range graph.Nodes {
+		if n, ok := node.(*nodes.NodeDoCreateSession); ok {
+			createSession = n
+		}
+	}
+	if createSession != nil {
+		c, ok := h.OAuthConfig.GetClient(payload.ClientID)
+		if !ok || !c.IsFirstParty() {
+			return nil, apierrors.NewInvalid("a first-party client_id is required")
+		}
+		client = c
+	}
+
+	err = h.Graphs.Run(webStateID, graph, false)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &InteractionResponse{Finished: true}
+	if createSession != nil {
+		_, tokens, err := h.Tokens.IssueTokens(client, &createSession.Session.Attrs)
+		if err != nil {
+			return nil, err
+		}
+		result.Tokens = tokens
+	}
+
+	return result, nil
+}
+
+func (h *InteractionHandler) makeIntent(req *http.Request, intent *InteractionIntent) (interaction.Intent, error) {
+	switch intent.Kind {
+	case "login":
+		return intents.NewIntentLogin(), nil
+	case "signup":
+		return intents.NewIntentSignup(), nil
+	case "forgot_password":
+		return intents.NewIntentForgotPassword(), nil
+	case "reset_password":
+		return intents.NewIntentResetPassword(), nil
+	case "add_identity":
+		userID := session.GetUserID(req.Context())
+		if userID == nil {
+			return nil, apierrors.NewForbidden("authentication required")
+		}
+		return intents.NewIntentAddIdentity(*userID), nil
+	default:
+		return nil, apierrors.NewInvalid("unknown intent")
+	}
+}
@@ -0,0 +1,163 @@
+package headless
+
+import (
+	"github.com/authgear/authgear-server/pkg/lib/authn"
+	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator"
+	"github.com/authgear/authgear-server/pkg/lib/infra/mail"
+	"github.com/authgear/authgear-server/pkg/lib/interaction"
+	"github.com/authgear/authgear-server/pkg/lib/interaction/nodes"
+	corephone "github.com/authgear/authgear-server/pkg/util/phone"
+)
+
+type InteractionLoginIDKey struct {
+	Key  string `json:"key"`
+	Type string `json:"type"`
+}
+
+type InteractionAuthenticator struct {
+	Index        int    `json:"index"`
+	Channel      string `json:"channel"`
+	MaskedTarget string `json:"masked_target"`
+}
+
+// InteractionEdge describes an input accepted by the current node.
+type InteractionEdge struct {
+	Input          InputKind                  `json:"input"`
+	LoginIDKeys    []InteractionLoginIDKey    `json:"login_id_keys,omitempty"`
+	Authenticators []InteractionAuthenticator `json:"authenticators,omitempty"`
+	OOBChannels    []string                   `json:"oob_channels,omitempty"`
+}
+
+// DescribeEdges describes the inputs accepted by edges. Edges requiring
+// browser interaction (e.g. OAuth providers) are not supported and omitted.
+// nolint:gocyclo
+func DescribeEdges(edges []interaction.Edge) []InteractionEdge {
+	out := []InteractionEdge{}
+	for _, edge := range edges {
+		switch edge := edge.(type) {
+		case *nodes.EdgeUseIdentityLoginID:
+			e := InteractionEdge{Input: InputKindLoginID}
+			for _, c := range edge.Configs {
+				e.LoginIDKeys = append(e.LoginIDKeys, InteractionLoginIDKey{
+					Key:  c.Key,
+					Type: string(c.Type),
+				})
+			}
+			out = append(out, e)
+		case *nodes.EdgeUseIdentityAnonymous:
+			out = append(out, InteractionEdge{Input: InputKindAnonymous})
+		case *nodes.EdgeAuthenticationPassword, *nodes.EdgeCreateAuthenticatorPassword:
+			out = append(out, InteractionEdge{Input: InputKindPassword})
+		case *nodes.EdgeAuthenticationTOTP, *nodes.EdgeCreateAuthenticatorTOTP:
+			out = append(out, InteractionEdge{Input: InputKindTOTP})
+		case *nodes.EdgeCreateAuthenticatorTOTPSetup:
+			out = append(out, InteractionEdge{Input: InputKindTOTPSetup})
+		case *nodes.EdgeAuthenticationOOBTrigger:
+			e := InteractionEdge{Input: InputKindOOBTrigger}
+			for i, a := range edge.Authenticators {
+				channel, _ := a.Claims[authenticator.AuthenticatorClaimOOBOTPChannelType].(string)
+				e.Authenticators = append(e.Authenticators, InteractionAuthenticator{
+					Index:        i,
+					Channel:      channel,
+					MaskedTarget: maskOOBTarget(channel, oobTarget(a)),
+				})
+			}
+			out = append(out, e)
+		case *nodes.EdgeCreateAuthenticatorOOBSetup:
+			e := InteractionEdge{Input: InputKindOOBSetup}
+			if edge.Channel != "" {
+				e.OOBChannels = []string{string(edge.Channel)}
+			} else {
+				for _, c := range edge.AllowedChannels {
+					e.OOBChannels = append(e.OOBChannels, string(c))
+				}
+			}
+			out = append(out, e)
+		case *nodes.EdgeAuthenticationOOB, *nodes.EdgeCreateAuthenticatorOOB:
+			out = append(out, InteractionEdge{Input: InputKindOOBOTP})
+		case *nodes.EdgeAuthenticationWebAuthnTrigger:
+			out = append(out, InteractionEdge{Input: InputKindWebAuthnTrigger})
+		case *nodes.EdgeCreateAuthenticatorWebAuthnSetup:
+			out = append(out, InteractionEdge{Input: InputKindWebAuthnSetup})
+		case *nodes.EdgeAuthenticationWebAuthn, *nodes.EdgeCreateAuthenticatorWebAuthn:
+			out = append(out, InteractionEdge{Input: InputKindWebAuthn})
+		case *nodes.EdgeOOBResendCode, *nodes.EdgeVerifyIdentityResendCode:
+			out = append(out, InteractionEdge{Input: InputKindResend})
+		case *nodes.EdgeConsumeRecoveryCode:
+			out = append(out, InteractionEdge{Input: InputKindRecoveryCode})
+		case *nodes.EdgeGenerateRecoveryCodeEnd:
+			out = append(out, InteractionEdge{Input: InputKindRecoveryCodesViewed})
+		case *nodes.EdgeVerifyIdentityCheckCode:
+			out = append(out, InteractionEdge{Input: InputKindVerificationCode})
+		case *nodes.EdgeForgotPasswordSelectLoginID:
+			out = append(out, InteractionEdge{Input: InputKindForgotPassword})
+		case *nodes.EdgeResetPassword:
+			out = append(out, InteractionEdge{Input: InputKindResetPassword})
+		}
+	}
+	return out
+}
+
+// DescribeNode returns the data of the current node required to prompt
+// for inputs.
+func DescribeNode(node interaction.Node) map[string]interface{} {
+	switch node := node.(type) {
+	case *nodes.NodeCreateAuthenticatorTOTPSetup:
+		return map[string]interface{}{
+			"secret": node.Authenticator.Secret,
+		}
+	case *nodes.NodeGenerateRecoveryCodeBegin:
+		return map[string]interface{}{
+			"recovery_codes": node.RecoveryCodes,
+		}
+	case *nodes.NodeAuthenticationOOBTrigger:
+		return map[string]interface{}{
+			"channel":       node.Channel,
+			"masked_target": maskOOBTarget(node.Channel, node.Target),
+			"code_length":   node.CodeLength,
+			"send_cooldown": node.SendCooldown,
+		}
+	case *nodes.NodeCreateAuthenticatorOOBSetup:
+		return map[string]interface{}{
+			"channel":       node.Channel,
+			"masked_target": maskOOBTarget(node.Channel, node.Target),
+			"code_length":   node.CodeLength,
+			"send_cooldown": node.SendCooldown,
+		}
+	case *nodes.NodeAuthenticationWebAuthnTrigger:
+		return map[string]interface{}{
+			"webauthn_request_options": node.RequestOptions,
+		}
+	case *nodes.NodeCreateAuthenticatorWebAuthnSetup:
+		return map[string]interface{}{
+			"webauthn_creation_options": node.CreationOptions,
+		}
+	case *nodes.NodeVerifyIdentity:
+		return map[string]interface{}{
+			"channel":       node.Channel,
+			"code_length":   node.CodeLength,
+			"send_cooldown": node.SendCooldown,
+		}
+	default:
+		return nil
+	}
+}
+
+func oobTarget(a *authenticator.Info) string {
+	if email, ok := a.Claims[authenticator.AuthenticatorClaimOOBOTPEmail].(string); ok {
+		return email
+	}
+	phone, _ := a.Claims[authenticator.AuthenticatorClaimOOBOTPPhone].(string)
+	return phone
+}
+
+func maskOOBTarget(channel string, target string) string {
+	switch channel {
+	case string(authn.AuthenticatorOOBChannelSMS):
+		return corephone.Mask(target)
+	case string(authn.AuthenticatorOOBChannelEmail):
+		return mail.MaskAddress(target)
+	default:
+		return ""
+	}
+}
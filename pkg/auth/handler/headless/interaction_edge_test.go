@@ -0,0 +1,112 @@
+package headless
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/lib/authn"
+	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/interaction"
+	"github.com/authgear/authgear-server/pkg/lib/interaction/nodes"
+)
+
+func TestInteractionInput(t *testing.T) {
+	Convey("InteractionInput", t, func() {
+		Convey("should only be accepted as input of its kind", func() {
+			input := &InteractionInput{Kind: InputKindPassword, Password: "secret"}
+
+			var passwordInput nodes.InputAuthenticationPassword
+			So(interaction.Input(input, &passwordInput), ShouldBeTrue)
+			So(passwordInput.GetPassword(), ShouldEqual, "secret")
+
+			var totpInput nodes.InputAuthenticationTOTP
+			So(interaction.Input(input, &totpInput), ShouldBeFalse)
+			var loginIDInput nodes.InputUseIdentityLoginID
+			So(interaction.Input(input, &loginIDInput), ShouldBeFalse)
+		})
+
+		Convey("should convert fields of each kind", func() {
+			var loginIDInput nodes.InputUseIdentityLoginID
+			So(interaction.Input(&InteractionInput{
+				Kind:       InputKindLoginID,
+				LoginIDKey: "email",
+				LoginID:    "user@example.com",
+			}, &loginIDInput), ShouldBeTrue)
+			So(loginIDInput.GetLoginIDKey(), ShouldEqual, "email")
+			So(loginIDInput.GetLoginID(), ShouldEqual, "user@example.com")
+
+			var oobSetupInput nodes.InputCreateAuthenticatorOOBSetup
+			So(interaction.Input(&InteractionInput{
+				Kind:    InputKindOOBSetup,
+				Channel: "sms",
+				Target:  "+85298765432",
+			}, &oobSetupInput), ShouldBeTrue)
+			So(oobSetupInput.GetOOBChannel(), ShouldEqual, authn.AuthenticatorOOBChannelSMS)
+			So(oobSetupInput.GetOOBTarget(), ShouldEqual, "+85298765432")
+
+			var resetInput nodes.InputResetPasswordByCode
+			So(interaction.Input(&InteractionInput{
+				Kind:        InputKindResetPassword,
+				Code:        "code",
+				NewPassword: "new-password",
+			}, &resetInput), ShouldBeTrue)
+			So(resetInput.GetCode(), ShouldEqual, "code")
+			So(resetInput.GetNewPassword(), ShouldEqual, "new-password")
+
+			var webauthnInput nodes.InputCreateAuthenticatorWebAuthn
+			So(interaction.Input(&InteractionInput{
+				Kind:             InputKindWebAuthn,
+				WebAuthnResponse: `{"id":"credential-id"}`,
+				DisplayName:      "My Key",
+			}, &webauthnInput), ShouldBeTrue)
+			So(webauthnInput.GetWebAuthnAttestationResponse(), ShouldEqual, `{"id":"credential-id"}`)
+			So(webauthnInput.GetWebAuthnDisplayName(), ShouldEqual, "My Key")
+
+			var webauthnTriggerInput nodes.InputAuthenticationWebAuthnTrigger
+			So(interaction.Input(&InteractionInput{Kind: InputKindWebAuthn}, &webauthnTriggerInput), ShouldBeFalse)
+		})
+	})
+}
+
+func TestDescribeEdges(t *testing.T) {
+	Convey("DescribeEdges", t, func() {
+		Convey("should describe accepted inputs", func() {
+			edges := DescribeEdges([]interaction.Edge{
+				&nodes.EdgeUseIdentityLoginID{
+					Configs: []config.LoginIDKeyConfig{
+						{Key: "email", Type: config.LoginIDKeyTypeEmail},
+					},
+				},
+				&nodes.EdgeUseIdentityOAuthProvider{},
+				&nodes.EdgeAuthenticationPassword{},
+				&nodes.EdgeAuthenticationOOBTrigger{
+					Authenticators: []*authenticator.Info{
+						{
+							Claims: map[string]interface{}{
+								authenticator.AuthenticatorClaimOOBOTPChannelType: "email",
+								authenticator.AuthenticatorClaimOOBOTPEmail:       "user@example.com",
+							},
+						},
+					},
+				},
+			})
+			So(edges, ShouldResemble, []InteractionEdge{
+				{
+					Input: InputKindLoginID,
+					LoginIDKeys: []InteractionLoginIDKey{
+						{Key: "email", Type: "email"},
+					},
+				},
+				{Input: InputKindPassword},
+				{
+					Input: InputKindOOBTrigger,
+					Authenticators: []InteractionAuthenticator{
+						{Index: 0, Channel: "email", MaskedTarget: "us**@example.com"},
+					},
+				},
+			})
+		})
+	})
+}
@@ -0,0 +1,233 @@
+package headless
+
+import (
+	"fmt"
+
+	"github.com/authgear/authgear-server/pkg/lib/authn"
+	"github.com/authgear/authgear-server/pkg/lib/interaction/nodes"
+)
+
+type InputKind string
+
+const (
+	InputKindLoginID             InputKind = "login_id"
+	InputKindAnonymous           InputKind = "anonymous"
+	InputKindPassword            InputKind = "password"
+	InputKindTOTP                InputKind = "totp"
+	InputKindTOTPSetup           InputKind = "totp_setup"
+	InputKindOOBTrigger          InputKind = "oob_trigger"
+	InputKindOOBSetup            InputKind = "oob_setup"
+	InputKindOOBOTP              InputKind = "oob_otp"
+	InputKindWebAuthnTrigger     InputKind = "webauthn_trigger"
+	InputKindWebAuthnSetup       InputKind = "webauthn_setup"
+	InputKindWebAuthn            InputKind = "webauthn"
+	InputKindResend              InputKind = "resend"
+	InputKindRecoveryCode        InputKind = "recovery_code"
+	InputKindRecoveryCodesViewed InputKind = "recovery_codes_viewed"
+	InputKindVerificationCode    InputKind = "verification_code"
+	InputKindForgotPassword      InputKind = "forgot_password"
+	InputKindResetPassword       InputKind = "reset_password"
+)
+
+// InteractionInput is the JSON representation of inputs. It is converted
+// to the input type of its kind before feeding to the graph, so that it
+// cannot be accepted by edges expecting other kinds of input.
+type InteractionInput struct {
+	Kind               InputKind `json:"kind"`
+	LoginIDKey         string    `json:"login_id_key,omitempty"`
+	LoginID            string    `json:"login_id,omitempty"`
+	JWT                string    `json:"jwt,omitempty"`
+	Password           string    `json:"password,omitempty"`
+	NewPassword        string    `json:"new_password,omitempty"`
+	Code               string    `json:"code,omitempty"`
+	DisplayName        string    `json:"display_name,omitempty"`
+	AuthenticatorIndex int       `json:"authenticator_index,omitempty"`
+	Channel            string    `json:"channel,omitempty"`
+	Target             string    `json:"target,omitempty"`
+	WebAuthnResponse   string    `json:"webauthn_response,omitempty"`
+}
+
+func (i *InteractionInput) Input() interface{} {
+	switch i.Kind {
+	case InputKindLoginID:
+		return &inputLoginID{LoginIDKey: i.LoginIDKey, LoginID: i.LoginID}
+	case InputKindAnonymous:
+		return &inputAnonymous{JWT: i.JWT}
+	case InputKindPassword:
+		return &inputPassword{Password: i.Password}
+	case InputKindTOTP:
+		return &inputTOTP{Code: i.Code, DisplayName: i.DisplayName}
+	case InputKindTOTPSetup:
+		return &inputTOTPSetup{}
+	case InputKindOOBTrigger:
+		return &inputOOBTrigger{AuthenticatorIndex: i.AuthenticatorIndex}
+	case InputKindOOBSetup:
+		return &inputOOBSetup{Channel: i.Channel, Target: i.Target}
+	case InputKindOOBOTP:
+		return &inputOOBOTP{Code: i.Code}
+	case InputKindWebAuthnTrigger:
+		return &inputWebAuthnTrigger{}
+	case InputKindWebAuthnSetup:
+		return &inputWebAuthnSetup{}
+	case InputKindWebAuthn:
+		return &inputWebAuthn{Response: i.WebAuthnResponse, DisplayName: i.DisplayName}
+	case InputKindResend:
+		return &inputResend{}
+	case InputKindRecoveryCode:
+		return &inputRecoveryCode{Code: i.Code}
+	case InputKindRecoveryCodesViewed:
+		return &inputRecoveryCodesViewed{}
+	case InputKindVerificationCode:
+		return &inputVerificationCode{Code: i.Code}
+	case InputKindForgotPassword:
+		return &inputForgotPassword{LoginID: i.LoginID}
+	case InputKindResetPassword:
+		return &inputResetPassword{Code: i.Code, NewPassword: i.NewPassword}
+	default:
+		panic(fmt.Errorf("headless: unexpected input kind: %v", i.Kind))
+	}
+}
+
+type inputLoginID struct {
+	LoginIDKey string
+	LoginID    string
+}
+
+var _ nodes.InputUseIdentityLoginID = &inputLoginID{}
+
+func (i *inputLoginID) GetLoginIDKey() string { return i.LoginIDKey }
+func (i *inputLoginID) GetLoginID() string    { return i.LoginID }
+
+type inputAnonymous struct {
+	JWT string
+}
+
+var _ nodes.InputUseIdentityAnonymous = &inputAnonymous{}
+
+func (i *inputAnonymous) GetAnonymousRequestToken() string { return i.JWT }
+
+type inputPassword struct {
+	Password string
+}
+
+var _ nodes.InputAuthenticationPassword = &inputPassword{}
+var _ nodes.InputCreateAuthenticatorPassword = &inputPassword{}
+
+func (i *inputPassword) GetPassword() string { return i.Password }
+
+type inputTOTP struct {
+	Code        string
+	DisplayName string
+}
+
+var _ nodes.InputAuthenticationTOTP = &inputTOTP{}
+var _ nodes.InputCreateAuthenticatorTOTP = &inputTOTP{}
+
+func (i *inputTOTP) GetTOTP() string            { return i.Code }
+func (i *inputTOTP) GetTOTPDisplayName() string { return i.DisplayName }
+
+type inputTOTPSetup struct{}
+
+var _ nodes.InputCreateAuthenticatorTOTPSetup = &inputTOTPSetup{}
+
+func (i *inputTOTPSetup) SetupTOTP() {}
+
+type inputOOBTrigger struct {
+	AuthenticatorIndex int
+}
+
+var _ nodes.InputAuthenticationOOBTrigger = &inputOOBTrigger{}
+
+func (i *inputOOBTrigger) GetOOBAuthenticatorIndex() int { return i.AuthenticatorIndex }
+
+type inputOOBSetup struct {
+	Channel string
+	Target  string
+}
+
+var _ nodes.InputCreateAuthenticatorOOBSetup = &inputOOBSetup{}
+
+func (i *inputOOBSetup) GetOOBChannel() authn.AuthenticatorOOBChannel {
+	return authn.AuthenticatorOOBChannel(i.Channel)
+}
+func (i *inputOOBSetup) GetOOBTarget() string { return i.Target }
+
+type inputOOBOTP struct {
+	Code string
+}
+
+var _ nodes.InputAuthenticationOOB = &inputOOBOTP{}
+var _ nodes.InputCreateAuthenticatorOOB = &inputOOBOTP{}
+
+func (i *inputOOBOTP) GetOOBOTP() string { return i.Code }
+
+type inputWebAuthnTrigger struct{}
+
+var _ nodes.InputAuthenticationWebAuthnTrigger = &inputWebAuthnTrigger{}
+
+func (i *inputWebAuthnTrigger) TriggerWebAuthn() {}
+
+type inputWebAuthnSetup struct{}
+
+var _ nodes.InputCreateAuthenticatorWebAuthnSetup = &inputWebAuthnSetup{}
+
+func (i *inputWebAuthnSetup) SetupWebAuthn() {}
+
+type inputWebAuthn struct {
+	Response    string
+	DisplayName string
+}
+
+var _ nodes.InputAuthenticationWebAuthn = &inputWebAuthn{}
+var _ nodes.InputCreateAuthenticatorWebAuthn = &inputWebAuthn{}
+
+func (i *inputWebAuthn) GetWebAuthnAssertionResponse() string   { return i.Response }
+func (i *inputWebAuthn) GetWebAuthnAttestationResponse() string { return i.Response }
+func (i *inputWebAuthn) GetWebAuthnDisplayName() string         { return i.DisplayName }
+
+type inputResend struct{}
+
+var _ nodes.InputOOBResendCode = &inputResend{}
+var _ nodes.InputVerifyIdentityResendCode = &inputResend{}
+
+func (i *inputResend) DoResend() {}
+
+type inputRecoveryCode struct {
+	Code string
+}
+
+var _ nodes.InputConsumeRecoveryCode = &inputRecoveryCode{}
+
+func (i *inputRecoveryCode) GetRecoveryCode() string { return i.Code }
+
+type inputRecoveryCodesViewed struct{}
+
+var _ nodes.InputGenerateRecoveryCodeEnd = &inputRecoveryCodesViewed{}
+
+func (i *inputRecoveryCodesViewed) ViewedRecoveryCodes() {}
+
+type inputVerificationCode struct {
+	Code string
+}
+
+var _ nodes.InputVerifyIdentityCheckCode = &inputVerificationCode{}
+
+func (i *inputVerificationCode) GetVerificationCode() string { return i.Code }
+
+type inputForgotPassword struct {
+	LoginID string
+}
+
+var _ nodes.InputForgotPasswordSelectLoginID = &inputForgotPassword{}
+
+func (i *inputForgotPassword) GetLoginID() string { return i.LoginID }
+
+type inputResetPassword struct {
+	Code        string
+	NewPassword string
+}
+
+var _ nodes.InputResetPasswordByCode = &inputResetPassword{}
+
+func (i *inputResetPassword) GetCode() string        { return i.Code }
+func (i *inputResetPassword) GetNewPassword() string { return i.NewPassword }
@@ -0,0 +1,95 @@
+package headless
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/api"
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+	"github.com/authgear/authgear-server/pkg/lib/interaction"
+)
+
+// testDatabase runs the commit hooks only if the transaction is committed,
+// like db.Handle.
+type testDatabase struct {
+	pending   []func()
+	committed int
+}
+
+func (d *testDatabase) WithTx(do func() error) error {
+	d.pending = nil
+	err := do()
+	if err != nil {
+		return err
+	}
+	for _, fn := range d.pending {
+		fn()
+	}
+	d.committed++
+	return nil
+}
+
+// testGraphs rejects every input as invalid credentials, and records the
+// failed attempt when the transaction commits, like lockout.Service.
+type testGraphs struct {
+	db       *testDatabase
+	failures int
+}
+
+func (g *testGraphs) NewGraph(ctx *interaction.Context, intent interaction.Intent) (*interaction.Graph, error) {
+	return &interaction.Graph{}, nil
+}
+
+func (g *testGraphs) Get(instanceID string) (*interaction.Graph, error) {
+	return &interaction.Graph{InstanceID: instanceID}, nil
+}
+
+func (g *testGraphs) DryRun(webStateID string, fn func(*interaction.Context) (*interaction.Graph, error)) error {
+	g.db.pending = append(g.db.pending, func() { g.failures++ })
+	return interaction.ErrInvalidCredentials
+}
+
+func (g *testGraphs) Run(webStateID string, graph *interaction.Graph, preserveGraph bool) error {
+	panic("unexpected run")
+}
+
+type testJSONWriter struct {
+	responses []*api.Response
+}
+
+func (w *testJSONWriter) WriteResponse(rw http.ResponseWriter, resp *api.Response) {
+	w.responses = append(w.responses, resp)
+}
+
+func TestInteractionHandler(t *testing.T) {
+	Convey("InteractionHandler", t, func() {
+		database := &testDatabase{}
+		graphs := &testGraphs{db: database}
+		jsonWriter := &testJSONWriter{}
+		h := &InteractionHandler{
+			Database: database,
+			Graphs:   graphs,
+			JSON:     jsonWriter,
+		}
+
+		Convey("should record failed attempts across requests", func() {
+			for i := 0; i < 3; i++ {
+				body := `{"state_id": "state", "input": {"kind": "password", "password": "wrong"}}`
+				r, _ := http.NewRequest("POST", "/api/v1/interaction", strings.NewReader(body))
+				r.Header.Set("Content-Type", "application/json")
+				h.ServeHTTP(httptest.NewRecorder(), r)
+			}
+
+			So(database.committed, ShouldEqual, 3)
+			So(graphs.failures, ShouldEqual, 3)
+			So(jsonWriter.responses, ShouldHaveLength, 3)
+			for _, resp := range jsonWriter.responses {
+				So(apierrors.IsKind(resp.Error, interaction.InvalidCredentials), ShouldBeTrue)
+			}
+		})
+	})
+}
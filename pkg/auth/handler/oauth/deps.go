@@ -11,6 +11,10 @@ var DependencySet = wire.NewSet(
 	wire.Struct(new(TokenHandler), "*"),
 	NewRevokeHandlerLogger,
 	wire.Struct(new(RevokeHandler), "*"),
+	NewIntrospectHandlerLogger,
+	wire.Struct(new(IntrospectHandler), "*"),
+	NewDeviceAuthorizationHandlerLogger,
+	wire.Struct(new(DeviceAuthorizationHandler), "*"),
 	wire.Struct(new(MetadataHandler), "*"),
 	NewJWKSHandlerLogger,
 	wire.Struct(new(JWKSHandler), "*"),
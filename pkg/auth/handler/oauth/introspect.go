@@ -0,0 +1,63 @@
+package oauth
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
+	"github.com/authgear/authgear-server/pkg/util/httproute"
+	"github.com/authgear/authgear-server/pkg/util/httputil"
+	"github.com/authgear/authgear-server/pkg/util/log"
+)
+
+func ConfigureIntrospectRoute(route httproute.Route) httproute.Route {
+	return route.
+		WithMethods("POST", "OPTIONS").
+		WithPathPattern("/oauth2/introspect")
+}
+
+type ProtocolIntrospectionHandler interface {
+	Handle(r protocol.IntrospectionRequest) httputil.Result
+}
+
+type IntrospectHandlerLogger struct{ *log.Logger }
+
+func NewIntrospectHandlerLogger(lf *log.Factory) IntrospectHandlerLogger {
+	return IntrospectHandlerLogger{lf.New("handler-introspect")}
+}
+
+type IntrospectHandler struct {
+	Logger               IntrospectHandlerLogger
+	Database             *db.Handle
+	IntrospectionHandler ProtocolIntrospectionHandler
+}
+
+func (h *IntrospectHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		http.Error(rw, err.Error(), 400)
+		return
+	}
+
+	req := protocol.IntrospectionRequest{}
+	for name, values := range r.Form {
+		req[name] = values[0]
+	}
+
+	var result httputil.Result
+	err = h.Database.ReadOnly(func() error {
+		result = h.IntrospectionHandler.Handle(req)
+		if result.IsInternalError() {
+			return errAuthzInternalError
+		}
+		return nil
+	})
+
+	if err == nil || errors.Is(err, errAuthzInternalError) {
+		result.WriteResponse(rw, r)
+	} else {
+		h.Logger.WithError(err).Error("oauth introspect handler failed")
+		http.Error(rw, "Internal Server Error", 500)
+	}
+}
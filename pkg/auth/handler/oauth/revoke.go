@@ -1,6 +1,8 @@
 package oauth
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/authgear/authgear-server/pkg/lib/infra/db"
@@ -47,7 +49,17 @@ func (h *RevokeHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return h.RevokeHandler.Handle(req)
 	})
 
-	if err != nil {
+	var oauthError *protocol.OAuthProtocolError
+	if errors.As(err, &oauthError) {
+		rw.Header().Set("Content-Type", "application/json")
+		rw.Header().Set("Cache-Control", "no-store")
+		if oauthError.Response["error"] == "invalid_client" {
+			rw.WriteHeader(http.StatusUnauthorized)
+		} else {
+			rw.WriteHeader(http.StatusBadRequest)
+		}
+		_ = json.NewEncoder(rw).Encode(oauthError.Response)
+	} else if err != nil {
 		h.Logger.WithError(err).Error("oauth revoke handler failed")
 		http.Error(rw, "Internal Server Error", 500)
 	}
@@ -7,6 +7,8 @@ import (
 	"github.com/lestrrat-go/jwx/jwt"
 
 	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
 	"github.com/authgear/authgear-server/pkg/lib/session"
 	"github.com/authgear/authgear-server/pkg/util/httproute"
 	"github.com/authgear/authgear-server/pkg/util/log"
@@ -19,7 +21,7 @@ func ConfigureUserInfoRoute(route httproute.Route) httproute.Route {
 }
 
 type ProtocolUserInfoProvider interface {
-	LoadUserClaims(session.Session) (jwt.Token, error)
+	LoadUserClaims(s session.Session, scopes []string) (jwt.Token, error)
 }
 
 type UserInfoHandlerLogger struct{ *log.Logger }
@@ -36,9 +38,17 @@ type UserInfoHandler struct {
 
 func (h *UserInfoHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	s := session.GetSession(r.Context())
+	if s.SessionType() == session.TypeClientCredentials {
+		// Access tokens issued with client_credentials grant have no user.
+		errResp := protocol.NewErrorResponse("invalid_token", "access token is not issued to a user")
+		rw.Header().Add("WWW-Authenticate", errResp.ToWWWAuthenticateHeader())
+		rw.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
 	var claims jwt.Token
 	err := h.Database.WithTx(func() (err error) {
-		claims, err = h.UserInfoProvider.LoadUserClaims(s)
+		claims, err = h.UserInfoProvider.LoadUserClaims(s, oauth.SessionScopes(s))
 		return
 	})
 
@@ -44,6 +44,12 @@ func (i *AuthenticationBeginInput) GetOOBAuthenticatorIndex() int {
 	return i.AuthenticatorIndex
 }
 
+type AuthenticationBeginTriggerWebAuthn struct{}
+
+var _ nodes.InputAuthenticationWebAuthnTrigger = &AuthenticationBeginTriggerWebAuthn{}
+
+func (i *AuthenticationBeginTriggerWebAuthn) TriggerWebAuthn() {}
+
 type AuthenticationBeginNode interface {
 	GetAuthenticationEdges() ([]interaction.Edge, error)
 }
@@ -160,6 +166,15 @@ func (h *AuthenticationBeginHandler) ServeHTTP(w http.ResponseWriter, r *http.Re
 				return err
 			}
 			result.WriteResponse(w, r)
+		case *nodes.EdgeAuthenticationWebAuthnTrigger:
+			result, err := h.WebApp.PostInput(StateID(r), func() (input interface{}, err error) {
+				input = &AuthenticationBeginTriggerWebAuthn{}
+				return
+			})
+			if err != nil {
+				return err
+			}
+			result.WriteResponse(w, r)
 		default:
 			panic(fmt.Errorf("webapp: unexpected edge: %T", selectedEdge))
 		}
@@ -177,6 +192,7 @@ const (
 	AuthenticationTypePassword                        = AuthenticationType(string(authn.AuthenticatorTypePassword))
 	AuthenticationTypeTOTP                            = AuthenticationType(string(authn.AuthenticatorTypeTOTP))
 	AuthenticationTypeOOB                             = AuthenticationType(string(authn.AuthenticatorTypeOOB))
+	AuthenticationTypeWebAuthn                        = AuthenticationType(string(authn.AuthenticatorTypeWebAuthn))
 	AuthenticationTypeRecoveryCode AuthenticationType = "recovery_code"
 	AuthenticationTypeDeviceToken  AuthenticationType = "device_token"
 )
@@ -243,6 +259,19 @@ func DeriveAuthenticationAlternatives(stateID string, graph *interaction.Graph,
 					}).String(),
 				})
 			}
+		case *nodes.EdgeAuthenticationWebAuthnTrigger:
+			typ := AuthenticationTypeWebAuthn
+			if typ != currentType {
+				q := url.Values{}
+				q.Set("x_edge", strconv.Itoa(i))
+				alternatives = append(alternatives, AuthenticationAlternative{
+					Type: string(typ),
+					URL: webapp.AttachStateID(stateID, &url.URL{
+						Path:     "/authentication_begin",
+						RawQuery: q.Encode(),
+					}).String(),
+				})
+			}
 		case *nodes.EdgeAuthenticationOOBTrigger:
 			typ := AuthenticationTypeOOB
 			if typ != currentType {
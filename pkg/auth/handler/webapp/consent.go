@@ -0,0 +1,121 @@
+package webapp
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/authgear/authgear-server/pkg/auth/handler/webapp/viewmodels"
+	"github.com/authgear/authgear-server/pkg/auth/webapp"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	oauthhandler "github.com/authgear/authgear-server/pkg/lib/oauth/handler"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
+	"github.com/authgear/authgear-server/pkg/lib/session"
+	"github.com/authgear/authgear-server/pkg/util/httproute"
+	"github.com/authgear/authgear-server/pkg/util/template"
+	"github.com/authgear/authgear-server/pkg/util/urlutil"
+)
+
+const (
+	TemplateItemTypeAuthUIConsentHTML string = "auth_ui_consent.html"
+)
+
+var TemplateAuthUIConsentHTML = template.Register(template.T{
+	Type:                    TemplateItemTypeAuthUIConsentHTML,
+	IsHTML:                  true,
+	TranslationTemplateType: TemplateItemTypeAuthUITranslationJSON,
+	Defines:                 defines,
+	ComponentTemplateTypes:  components,
+})
+
+func ConfigureConsentRoute(route httproute.Route) httproute.Route {
+	return route.
+		WithMethods("OPTIONS", "POST", "GET").
+		WithPathPattern("/consent")
+}
+
+type ConsentViewModel struct {
+	ClientName string
+	ClientURI  string
+	Scopes     []string
+}
+
+type ConsentService interface {
+	GetClient(clientID string) (config.OAuthClientConfig, error)
+	Grant(userID string, clientID string, scopes []string) error
+}
+
+type ConsentHandler struct {
+	Database      *db.Handle
+	TrustProxy    config.TrustProxy
+	BaseViewModel *viewmodels.BaseViewModeler
+	Renderer      Renderer
+	Consents      ConsentService
+}
+
+func (h *ConsentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	userID := session.GetUserID(r.Context())
+	clientID := r.Form.Get("client_id")
+	scopes := strings.Fields(r.Form.Get("scope"))
+
+	client, err := h.Consents.GetClient(clientID)
+	if errors.Is(err, oauthhandler.ErrConsentClientNotFound) {
+		http.Error(w, "invalid client ID", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		panic(err)
+	}
+
+	if r.Method == "GET" {
+		data := map[string]interface{}{}
+
+		baseViewModel := h.BaseViewModel.ViewModel(r, nil)
+		viewmodels.Embed(data, baseViewModel)
+
+		viewModel := ConsentViewModel{
+			ClientName: client.Name(),
+			ClientURI:  client.ClientURI(),
+			Scopes:     scopes,
+		}
+		viewmodels.Embed(data, viewModel)
+
+		h.Renderer.RenderHTML(w, r, TemplateItemTypeAuthUIConsentHTML, data)
+		return
+	}
+
+	redirectURI := webapp.GetRedirectURI(r, bool(h.TrustProxy))
+
+	if r.Method == "POST" && r.Form.Get("x_action") == "allow" {
+		err := h.Database.WithTx(func() error {
+			return h.Consents.Grant(*userID, clientID, scopes)
+		})
+		var oauthError *protocol.OAuthProtocolError
+		if errors.As(err, &oauthError) {
+			http.Error(w, oauthError.Error(), http.StatusBadRequest)
+			return
+		} else if err != nil {
+			panic(err)
+		}
+
+		http.Redirect(w, r, redirectURI, http.StatusFound)
+		return
+	}
+
+	if r.Method == "POST" && r.Form.Get("x_action") == "deny" {
+		u, err := r.URL.Parse(redirectURI)
+		if err != nil {
+			panic(err)
+		}
+		u = urlutil.WithQueryParamsAdded(u, map[string]string{
+			webapp.ConsentDeniedQuery: "true",
+		})
+		http.Redirect(w, r, u.String(), http.StatusFound)
+		return
+	}
+}
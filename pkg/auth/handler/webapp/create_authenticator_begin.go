@@ -26,6 +26,12 @@ var _ nodes.InputCreateAuthenticatorTOTPSetup = &CreateAuthenticatorBeginSetupTO
 
 func (i *CreateAuthenticatorBeginSetupTOTP) SetupTOTP() {}
 
+type CreateAuthenticatorBeginSetupWebAuthn struct{}
+
+var _ nodes.InputCreateAuthenticatorWebAuthnSetup = &CreateAuthenticatorBeginSetupWebAuthn{}
+
+func (i *CreateAuthenticatorBeginSetupWebAuthn) SetupWebAuthn() {}
+
 type CreateAuthenticatorBeginNode interface {
 	GetCreateAuthenticatorEdges() ([]interaction.Edge, error)
 }
@@ -100,6 +106,15 @@ func (h *CreateAuthenticatorBeginHandler) ServeHTTP(w http.ResponseWriter, r *ht
 				return err
 			}
 			result.WriteResponse(w, r)
+		case *nodes.EdgeCreateAuthenticatorWebAuthnSetup:
+			result, err := h.WebApp.PostInput(StateID(r), func() (input interface{}, err error) {
+				input = &CreateAuthenticatorBeginSetupWebAuthn{}
+				return
+			})
+			if err != nil {
+				return err
+			}
+			result.WriteResponse(w, r)
 		default:
 			panic(fmt.Errorf("webapp: unexpected edge: %T", selectedEdge))
 		}
@@ -139,6 +154,8 @@ func DeriveCreateAuthenticatorAlternatives(stateID string, graph *interaction.Gr
 			typ = authn.AuthenticatorTypeOOB
 		case *nodes.EdgeCreateAuthenticatorTOTPSetup:
 			typ = authn.AuthenticatorTypeTOTP
+		case *nodes.EdgeCreateAuthenticatorWebAuthnSetup:
+			typ = authn.AuthenticatorTypeWebAuthn
 		default:
 			panic(fmt.Errorf("create_authenticator_begin: unexpected edge: %T", edge))
 		}
@@ -20,6 +20,8 @@ var DependencySet = wire.NewSet(
 	wire.Struct(new(CreatePasswordHandler), "*"),
 	wire.Struct(new(SetupTOTPHandler), "*"),
 	wire.Struct(new(EnterTOTPHandler), "*"),
+	wire.Struct(new(SetupWebAuthnHandler), "*"),
+	wire.Struct(new(EnterWebAuthnHandler), "*"),
 	wire.Struct(new(SetupOOBOTPHandler), "*"),
 	wire.Struct(new(EnterOOBOTPHandler), "*"),
 	wire.Struct(new(EnterRecoveryCodeHandler), "*"),
@@ -32,6 +34,11 @@ var DependencySet = wire.NewSet(
 	wire.Struct(new(ResetPasswordSuccessHandler), "*"),
 	wire.Struct(new(SettingsHandler), "*"),
 	wire.Struct(new(SettingsIdentityHandler), "*"),
+	wire.Struct(new(SettingsAuthorizedAppsHandler), "*"),
+	wire.Struct(new(SettingsSessionsHandler), "*"),
+	wire.Struct(new(SettingsProfileHandler), "*"),
+	wire.Struct(new(ConsentHandler), "*"),
+	wire.Struct(new(DeviceHandler), "*"),
 	wire.Struct(new(ChangePasswordHandler), "*"),
 	wire.Struct(new(LogoutHandler), "*"),
 	wire.Struct(new(AuthenticationBeginHandler), "*"),
@@ -0,0 +1,177 @@
+package webapp
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+	"github.com/authgear/authgear-server/pkg/auth/handler/webapp/viewmodels"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	oauthhandler "github.com/authgear/authgear-server/pkg/lib/oauth/handler"
+	"github.com/authgear/authgear-server/pkg/lib/ratelimit"
+	"github.com/authgear/authgear-server/pkg/lib/session"
+	"github.com/authgear/authgear-server/pkg/lib/session/access"
+	"github.com/authgear/authgear-server/pkg/util/httproute"
+	"github.com/authgear/authgear-server/pkg/util/template"
+)
+
+const (
+	TemplateItemTypeAuthUIDeviceHTML string = "auth_ui_device.html"
+)
+
+var TemplateAuthUIDeviceHTML = template.Register(template.T{
+	Type:                    TemplateItemTypeAuthUIDeviceHTML,
+	IsHTML:                  true,
+	TranslationTemplateType: TemplateItemTypeAuthUITranslationJSON,
+	Defines:                 defines,
+	ComponentTemplateTypes:  components,
+})
+
+func ConfigureDeviceRoute(route httproute.Route) httproute.Route {
+	return route.
+		WithMethods("OPTIONS", "POST", "GET").
+		WithPathPattern("/device")
+}
+
+type DeviceResult string
+
+const (
+	DeviceResultApproved DeviceResult = "approved"
+	DeviceResultDenied   DeviceResult = "denied"
+)
+
+type DeviceViewModel struct {
+	UserCode   string
+	ClientName string
+	Scopes     []string
+	Result     DeviceResult
+}
+
+type DeviceService interface {
+	Get(userCode string) (*oauthhandler.DeviceRequest, error)
+	Approve(userCode string, userID string, sessionID string) error
+	Deny(userCode string) error
+}
+
+type DeviceRateLimiter interface {
+	TakeToken(bucket ratelimit.Bucket) error
+}
+
+type DeviceHandler struct {
+	Database        *db.Handle
+	BaseViewModel   *viewmodels.BaseViewModeler
+	Renderer        Renderer
+	Devices         DeviceService
+	TrustProxy      config.TrustProxy
+	RateLimiter     DeviceRateLimiter
+	RateLimitConfig *config.RateLimitConfig
+}
+
+// takeUserCodeToken limits user code guesses per IP, as required by
+// RFC 8628 section 5.1.
+func (h *DeviceHandler) takeUserCodeToken(r *http.Request) error {
+	return h.RateLimiter.TakeToken(ratelimit.NewBucket(
+		"DeviceUserCodePerIP",
+		access.RemoteIP(r, bool(h.TrustProxy)),
+		h.RateLimitConfig.DeviceUserCodePerIP,
+	))
+}
+
+func (h *DeviceHandler) render(w http.ResponseWriter, r *http.Request, viewModel DeviceViewModel, err error) {
+	data := map[string]interface{}{}
+	var anyError interface{}
+	if err != nil {
+		anyError = err
+	}
+	baseViewModel := h.BaseViewModel.ViewModel(r, anyError)
+	viewmodels.Embed(data, baseViewModel)
+	viewmodels.Embed(data, viewModel)
+	h.Renderer.RenderHTML(w, r, TemplateItemTypeAuthUIDeviceHTML, data)
+}
+
+func (h *DeviceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	userCode := r.Form.Get("user_code")
+
+	if r.Method == "GET" {
+		if userCode == "" {
+			h.render(w, r, DeviceViewModel{}, nil)
+			return
+		}
+
+		var req *oauthhandler.DeviceRequest
+		err := h.takeUserCodeToken(r)
+		if err == nil {
+			err = h.Database.ReadOnly(func() (err error) {
+				req, err = h.Devices.Get(userCode)
+				return
+			})
+		}
+		if apierrors.IsKind(err, oauthhandler.InvalidUserCode) || apierrors.IsKind(err, ratelimit.RateLimited) {
+			h.render(w, r, DeviceViewModel{}, err)
+			return
+		} else if err != nil {
+			panic(err)
+		}
+
+		h.render(w, r, DeviceViewModel{
+			UserCode:   req.UserCode,
+			ClientName: req.Client.Name(),
+			Scopes:     req.Scopes,
+		}, nil)
+		return
+	}
+
+	if r.Method == "POST" && r.Form.Get("x_action") == "submit" {
+		u := url.URL{
+			Path:     r.URL.Path,
+			RawQuery: url.Values{"user_code": []string{r.Form.Get("x_user_code")}}.Encode(),
+		}
+		http.Redirect(w, r, u.String(), http.StatusFound)
+		return
+	}
+
+	if err := h.takeUserCodeToken(r); apierrors.IsKind(err, ratelimit.RateLimited) {
+		h.render(w, r, DeviceViewModel{}, err)
+		return
+	} else if err != nil {
+		panic(err)
+	}
+
+	var result DeviceResult
+	var err error
+	switch {
+	case r.Method == "POST" && r.Form.Get("x_action") == "allow":
+		s := session.GetSession(r.Context())
+		if s.SessionType() != session.TypeIdentityProvider {
+			http.Error(w, "device authorization requires a browser session", http.StatusBadRequest)
+			return
+		}
+		result = DeviceResultApproved
+		err = h.Database.WithTx(func() error {
+			return h.Devices.Approve(userCode, s.SessionAttrs().UserID, s.SessionID())
+		})
+	case r.Method == "POST" && r.Form.Get("x_action") == "deny":
+		result = DeviceResultDenied
+		err = h.Database.WithTx(func() error {
+			return h.Devices.Deny(userCode)
+		})
+	default:
+		http.Error(w, "unknown action", http.StatusBadRequest)
+		return
+	}
+
+	if apierrors.IsKind(err, oauthhandler.InvalidUserCode) {
+		h.render(w, r, DeviceViewModel{}, err)
+		return
+	} else if err != nil {
+		panic(err)
+	}
+
+	h.render(w, r, DeviceViewModel{Result: result}, nil)
+}
@@ -0,0 +1,164 @@
+package webapp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/authgear/authgear-server/pkg/auth/handler/webapp/viewmodels"
+	"github.com/authgear/authgear-server/pkg/auth/webapp"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	"github.com/authgear/authgear-server/pkg/lib/interaction"
+	"github.com/authgear/authgear-server/pkg/lib/interaction/nodes"
+	"github.com/authgear/authgear-server/pkg/util/httproute"
+	"github.com/authgear/authgear-server/pkg/util/template"
+	"github.com/authgear/authgear-server/pkg/util/validation"
+)
+
+const (
+	TemplateItemTypeAuthUIEnterWebAuthnHTML string = "auth_ui_enter_webauthn.html"
+)
+
+var TemplateAuthUIEnterWebAuthnHTML = template.Register(template.T{
+	Type:                    TemplateItemTypeAuthUIEnterWebAuthnHTML,
+	IsHTML:                  true,
+	TranslationTemplateType: TemplateItemTypeAuthUITranslationJSON,
+	Defines:                 defines,
+	ComponentTemplateTypes:  components,
+})
+
+const EnterWebAuthnRequestSchema = "EnterWebAuthnRequestSchema"
+
+var EnterWebAuthnSchema = validation.NewMultipartSchema("").
+	Add(EnterWebAuthnRequestSchema, `
+		{
+			"type": "object",
+			"properties": {
+				"x_response": { "type": "string", "minLength": 1 }
+			},
+			"required": ["x_response"]
+		}
+	`).Instantiate()
+
+func ConfigureEnterWebAuthnRoute(route httproute.Route) httproute.Route {
+	return route.
+		WithMethods("OPTIONS", "POST", "GET").
+		WithPathPattern("/enter_webauthn")
+}
+
+type EnterWebAuthnViewModel struct {
+	RequestOptions string
+	Alternatives   []AuthenticationAlternative
+}
+
+type EnterWebAuthnNode interface {
+	GetWebAuthnRequestOptions() json.RawMessage
+}
+
+type EnterWebAuthnHandler struct {
+	Database      *db.Handle
+	BaseViewModel *viewmodels.BaseViewModeler
+	Renderer      Renderer
+	WebApp        WebAppService
+}
+
+func (h *EnterWebAuthnHandler) GetData(r *http.Request, state *webapp.State, graph *interaction.Graph) (map[string]interface{}, error) {
+	data := map[string]interface{}{}
+
+	var node EnterWebAuthnNode
+	if !graph.FindLastNode(&node) {
+		panic(fmt.Errorf("enter_webauthn: expected graph has node implementing EnterWebAuthnNode"))
+	}
+
+	baseViewModel := h.BaseViewModel.ViewModel(r, state.Error)
+	alternatives, err := DeriveAuthenticationAlternatives(
+		// Use previous state ID because the current node is NodeAuthenticationWebAuthnTrigger.
+		state.PrevID,
+		graph,
+		AuthenticationTypeWebAuthn,
+		"",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	viewModel := EnterWebAuthnViewModel{
+		RequestOptions: string(node.GetWebAuthnRequestOptions()),
+		Alternatives:   alternatives,
+	}
+
+	viewmodels.Embed(data, baseViewModel)
+	viewmodels.Embed(data, viewModel)
+
+	return data, nil
+}
+
+type EnterWebAuthnInput struct {
+	Response    string
+	DeviceToken bool
+}
+
+var _ nodes.InputAuthenticationWebAuthn = &EnterWebAuthnInput{}
+var _ nodes.InputCreateDeviceToken = &EnterWebAuthnInput{}
+
+// GetWebAuthnAssertionResponse implements InputAuthenticationWebAuthn.
+func (i *EnterWebAuthnInput) GetWebAuthnAssertionResponse() string {
+	return i.Response
+}
+
+// CreateDeviceToken implements InputCreateDeviceToken.
+func (i *EnterWebAuthnInput) CreateDeviceToken() bool {
+	return i.DeviceToken
+}
+
+func (h *EnterWebAuthnHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if r.Method == "GET" {
+		err := h.Database.WithTx(func() error {
+			state, graph, err := h.WebApp.Get(StateID(r))
+			if err != nil {
+				return err
+			}
+
+			data, err := h.GetData(r, state, graph)
+			if err != nil {
+				return err
+			}
+
+			h.Renderer.RenderHTML(w, r, TemplateItemTypeAuthUIEnterWebAuthnHTML, data)
+			return nil
+		})
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if r.Method == "POST" {
+		err := h.Database.WithTx(func() error {
+			result, err := h.WebApp.PostInput(StateID(r), func() (input interface{}, err error) {
+				err = EnterWebAuthnSchema.PartValidator(EnterWebAuthnRequestSchema).ValidateValue(FormToJSON(r.Form))
+				if err != nil {
+					return
+				}
+
+				input = &EnterWebAuthnInput{
+					Response:    r.Form.Get("x_response"),
+					DeviceToken: r.Form.Get("x_device_token") == "true",
+				}
+				return
+			})
+			if err != nil {
+				return err
+			}
+			result.WriteResponse(w, r)
+			return nil
+		})
+		if err != nil {
+			panic(err)
+		}
+	}
+}
@@ -35,7 +35,9 @@ type SettingsViewModel struct {
 	MFAActivated             bool
 	SecondaryTOTPAllowed     bool
 	SecondaryOOBOTPAllowed   bool
+	SecondaryWebAuthnAllowed bool
 	SecondaryPasswordAllowed bool
+	UserProfileEnabled       bool
 }
 
 type SettingsAuthenticatorService interface {
@@ -51,6 +53,7 @@ type SettingsHandler struct {
 	BaseViewModel  *viewmodels.BaseViewModeler
 	Renderer       Renderer
 	Authentication *config.AuthenticationConfig
+	UserProfile    *config.UserProfileConfig
 	Authenticators SettingsAuthenticatorService
 	MFA            SettingsMFAService
 }
@@ -75,6 +78,7 @@ func (h *SettingsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 		totp := false
 		oobotp := false
+		webauthn := false
 		password := false
 		for _, typ := range h.Authentication.SecondaryAuthenticators {
 			switch typ {
@@ -84,6 +88,8 @@ func (h *SettingsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 				totp = true
 			case authn.AuthenticatorTypeOOB:
 				oobotp = true
+			case authn.AuthenticatorTypeWebAuthn:
+				webauthn = true
 			}
 		}
 
@@ -92,7 +98,9 @@ func (h *SettingsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			MFAActivated:             mfaActivated,
 			SecondaryTOTPAllowed:     totp,
 			SecondaryOOBOTPAllowed:   oobotp,
+			SecondaryWebAuthnAllowed: webauthn,
 			SecondaryPasswordAllowed: password,
+			UserProfileEnabled:       len(h.UserProfile.Schema.Properties) > 0,
 		}
 		viewmodels.Embed(data, viewModel)
 
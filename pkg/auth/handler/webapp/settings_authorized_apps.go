@@ -0,0 +1,104 @@
+package webapp
+
+import (
+	"net/http"
+
+	"github.com/authgear/authgear-server/pkg/auth/handler/webapp/viewmodels"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	oauthhandler "github.com/authgear/authgear-server/pkg/lib/oauth/handler"
+	"github.com/authgear/authgear-server/pkg/lib/session"
+	"github.com/authgear/authgear-server/pkg/util/httproute"
+	"github.com/authgear/authgear-server/pkg/util/httputil"
+	"github.com/authgear/authgear-server/pkg/util/template"
+)
+
+const (
+	TemplateItemTypeAuthUISettingsAuthorizedAppsHTML string = "auth_ui_settings_authorized_apps.html"
+)
+
+var TemplateAuthUISettingsAuthorizedAppsHTML = template.Register(template.T{
+	Type:                    TemplateItemTypeAuthUISettingsAuthorizedAppsHTML,
+	IsHTML:                  true,
+	TranslationTemplateType: TemplateItemTypeAuthUITranslationJSON,
+	Defines:                 defines,
+	ComponentTemplateTypes:  components,
+})
+
+func ConfigureSettingsAuthorizedAppsRoute(route httproute.Route) httproute.Route {
+	return route.
+		WithMethods("OPTIONS", "POST", "GET").
+		WithPathPattern("/settings/authorized_apps")
+}
+
+type SettingsAuthorizedApp struct {
+	AuthorizationID string
+	ClientName      string
+	ClientURI       string
+	Scopes          []string
+}
+
+type SettingsAuthorizedAppsViewModel struct {
+	AuthorizedApps []SettingsAuthorizedApp
+}
+
+type SettingsConsentService interface {
+	List(userID string) ([]*oauthhandler.Consent, error)
+	Revoke(userID string, authorizationID string) error
+}
+
+type SettingsAuthorizedAppsHandler struct {
+	Database      *db.Handle
+	BaseViewModel *viewmodels.BaseViewModeler
+	Renderer      Renderer
+	Consents      SettingsConsentService
+}
+
+func (h *SettingsAuthorizedAppsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	userID := session.GetUserID(r.Context())
+
+	if r.Method == "GET" {
+		err := h.Database.WithTx(func() error {
+			consents, err := h.Consents.List(*userID)
+			if err != nil {
+				return err
+			}
+
+			viewModel := SettingsAuthorizedAppsViewModel{}
+			for _, c := range consents {
+				viewModel.AuthorizedApps = append(viewModel.AuthorizedApps, SettingsAuthorizedApp{
+					AuthorizationID: c.Authorization.ID,
+					ClientName:      c.Client.Name(),
+					ClientURI:       c.Client.ClientURI(),
+					Scopes:          c.Authorization.Scopes,
+				})
+			}
+
+			data := map[string]interface{}{}
+			baseViewModel := h.BaseViewModel.ViewModel(r, nil)
+			viewmodels.Embed(data, baseViewModel)
+			viewmodels.Embed(data, viewModel)
+
+			h.Renderer.RenderHTML(w, r, TemplateItemTypeAuthUISettingsAuthorizedAppsHTML, data)
+			return nil
+		})
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if r.Method == "POST" && r.Form.Get("x_action") == "revoke" {
+		err := h.Database.WithTx(func() error {
+			return h.Consents.Revoke(*userID, r.Form.Get("x_authorization_id"))
+		})
+		if err != nil {
+			panic(err)
+		}
+
+		http.Redirect(w, r, httputil.HostRelative(r.URL).String(), http.StatusFound)
+	}
+}
@@ -0,0 +1,190 @@
+package webapp
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+	"github.com/authgear/authgear-server/pkg/api/event"
+	"github.com/authgear/authgear-server/pkg/api/model"
+	"github.com/authgear/authgear-server/pkg/auth/handler/webapp/viewmodels"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	"github.com/authgear/authgear-server/pkg/lib/session"
+	"github.com/authgear/authgear-server/pkg/util/httproute"
+	"github.com/authgear/authgear-server/pkg/util/httputil"
+	"github.com/authgear/authgear-server/pkg/util/template"
+)
+
+const (
+	TemplateItemTypeAuthUISettingsProfileHTML string = "auth_ui_settings_profile.html"
+)
+
+var TemplateAuthUISettingsProfileHTML = template.Register(template.T{
+	Type:                    TemplateItemTypeAuthUISettingsProfileHTML,
+	IsHTML:                  true,
+	TranslationTemplateType: TemplateItemTypeAuthUITranslationJSON,
+	Defines:                 defines,
+	ComponentTemplateTypes:  components,
+})
+
+func ConfigureSettingsProfileRoute(route httproute.Route) httproute.Route {
+	return route.
+		WithMethods("OPTIONS", "POST", "GET").
+		WithPathPattern("/settings/profile")
+}
+
+type SettingsProfileAttribute struct {
+	Name        string
+	Title       string
+	Description string
+	Type        string
+	Enum        []string
+	Required    bool
+	Value       string
+}
+
+type SettingsProfileViewModel struct {
+	Attributes []SettingsProfileAttribute
+}
+
+type SettingsProfileUserService interface {
+	Get(id string) (*model.User, error)
+	UpdateProfile(userID string, profile map[string]interface{}, reason event.UserUpdateReason) error
+}
+
+type SettingsProfileHandler struct {
+	Database          *db.Handle
+	BaseViewModel     *viewmodels.BaseViewModeler
+	Renderer          Renderer
+	UserProfileConfig *config.UserProfileConfig
+	Users             SettingsProfileUserService
+}
+
+func (h *SettingsProfileHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	userID := session.GetUserID(r.Context())
+
+	if r.Method == "GET" {
+		err := h.Database.WithTx(func() error {
+			u, err := h.Users.Get(*userID)
+			if err != nil {
+				return err
+			}
+
+			h.render(w, r, h.attributes(func(name string) string {
+				value, ok := u.Profile[name]
+				if !ok {
+					return ""
+				}
+				return fmt.Sprint(value)
+			}), nil)
+			return nil
+		})
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if r.Method == "POST" {
+		profile := h.parseForm(r.Form)
+		err := h.Database.WithTx(func() error {
+			return h.Users.UpdateProfile(*userID, profile, event.UserUpdateReasonUpdateProfile)
+		})
+		if err != nil && !apierrors.IsAPIError(err) {
+			panic(err)
+		} else if err != nil {
+			h.render(w, r, h.attributes(func(name string) string {
+				return r.Form.Get(profileFormField(name))
+			}), err)
+			return
+		}
+
+		http.Redirect(w, r, httputil.HostRelative(r.URL).String(), http.StatusFound)
+	}
+}
+
+func (h *SettingsProfileHandler) render(w http.ResponseWriter, r *http.Request, attrs []SettingsProfileAttribute, anyError interface{}) {
+	data := map[string]interface{}{}
+	baseViewModel := h.BaseViewModel.ViewModel(r, anyError)
+	viewmodels.Embed(data, baseViewModel)
+	viewmodels.Embed(data, SettingsProfileViewModel{Attributes: attrs})
+
+	h.Renderer.RenderHTML(w, r, TemplateItemTypeAuthUISettingsProfileHTML, data)
+}
+
+func (h *SettingsProfileHandler) attributes(value func(name string) string) []SettingsProfileAttribute {
+	required := map[string]bool{}
+	for _, name := range h.UserProfileConfig.Schema.Required {
+		required[name] = true
+	}
+
+	var attrs []SettingsProfileAttribute
+	for name, schema := range h.UserProfileConfig.Schema.Properties {
+		title := schema.Title
+		if title == "" {
+			title = name
+		}
+		var enum []string
+		for _, v := range schema.Enum {
+			enum = append(enum, fmt.Sprint(v))
+		}
+		attrs = append(attrs, SettingsProfileAttribute{
+			Name:        profileFormField(name),
+			Title:       title,
+			Description: schema.Description,
+			Type:        schema.Type,
+			Enum:        enum,
+			Required:    required[name],
+			Value:       value(name),
+		})
+	}
+	sort.Slice(attrs, func(i, j int) bool {
+		return attrs[i].Name < attrs[j].Name
+	})
+	return attrs
+}
+
+// parseForm converts the submitted form to profile. Empty fields are omitted.
+// Values that cannot be converted are kept as string, so that they are
+// reported by schema validation.
+func (h *SettingsProfileHandler) parseForm(form url.Values) map[string]interface{} {
+	profile := map[string]interface{}{}
+	for name, schema := range h.UserProfileConfig.Schema.Properties {
+		value := form.Get(profileFormField(name))
+
+		if schema.Type == "boolean" {
+			profile[name] = value == "true"
+			continue
+		}
+		if value == "" {
+			continue
+		}
+
+		switch schema.Type {
+		case "integer":
+			if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+				profile[name] = i
+				continue
+			}
+		case "number":
+			if f, err := strconv.ParseFloat(value, 64); err == nil {
+				profile[name] = f
+				continue
+			}
+		}
+		profile[name] = value
+	}
+	return profile
+}
+
+func profileFormField(name string) string {
+	return "x_profile_" + name
+}
@@ -0,0 +1,170 @@
+package webapp
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/auth/handler/webapp/viewmodels"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	"github.com/authgear/authgear-server/pkg/lib/session"
+	"github.com/authgear/authgear-server/pkg/util/httproute"
+	"github.com/authgear/authgear-server/pkg/util/httputil"
+	"github.com/authgear/authgear-server/pkg/util/template"
+)
+
+const (
+	TemplateItemTypeAuthUISettingsSessionsHTML string = "auth_ui_settings_sessions.html"
+)
+
+var TemplateAuthUISettingsSessionsHTML = template.Register(template.T{
+	Type:                    TemplateItemTypeAuthUISettingsSessionsHTML,
+	IsHTML:                  true,
+	TranslationTemplateType: TemplateItemTypeAuthUITranslationJSON,
+	Defines:                 defines,
+	ComponentTemplateTypes:  components,
+})
+
+func ConfigureSettingsSessionsRoute(route httproute.Route) httproute.Route {
+	return route.
+		WithMethods("OPTIONS", "POST", "GET").
+		WithPathPattern("/settings/sessions")
+}
+
+type SettingsSession struct {
+	ID               string
+	IsCurrent        bool
+	Browser          string
+	OS               string
+	DeviceModel      string
+	ClientName       string
+	CreatedAt        time.Time
+	LastAccessedAt   time.Time
+	LastAccessedByIP string
+}
+
+type SettingsSessionsViewModel struct {
+	Sessions []SettingsSession
+}
+
+type SettingsSessionManager interface {
+	List(userID string) ([]session.Session, error)
+	Get(id string) (session.Session, error)
+	Revoke(session session.Session) error
+}
+
+type SettingsSessionsHandler struct {
+	Database      *db.Handle
+	BaseViewModel *viewmodels.BaseViewModeler
+	Renderer      Renderer
+	OAuth         *config.OAuthConfig
+	Sessions      SettingsSessionManager
+}
+
+func (h *SettingsSessionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	userID := *session.GetUserID(r.Context())
+	currentSessionID := session.GetSession(r.Context()).SessionID()
+
+	if r.Method == "GET" {
+		err := h.Database.WithTx(func() error {
+			sessions, err := h.Sessions.List(userID)
+			if err != nil {
+				return err
+			}
+
+			viewModel := SettingsSessionsViewModel{}
+			for _, s := range sessions {
+				viewModel.Sessions = append(viewModel.Sessions, h.toViewModel(s, currentSessionID))
+			}
+
+			data := map[string]interface{}{}
+			baseViewModel := h.BaseViewModel.ViewModel(r, nil)
+			viewmodels.Embed(data, baseViewModel)
+			viewmodels.Embed(data, viewModel)
+
+			h.Renderer.RenderHTML(w, r, TemplateItemTypeAuthUISettingsSessionsHTML, data)
+			return nil
+		})
+		if err != nil {
+			panic(err)
+		}
+		return
+	}
+
+	var err error
+	switch {
+	case r.Method == "POST" && r.Form.Get("x_action") == "revoke":
+		sessionID := r.Form.Get("x_session_id")
+		if sessionID == currentSessionID {
+			http.Error(w, "cannot revoke current session", http.StatusBadRequest)
+			return
+		}
+		err = h.Database.WithTx(func() error {
+			s, err := h.Sessions.Get(sessionID)
+			if err != nil {
+				return err
+			}
+			if s.SessionAttrs().UserID != userID {
+				return session.ErrSessionNotFound
+			}
+			return h.Sessions.Revoke(s)
+		})
+	case r.Method == "POST" && r.Form.Get("x_action") == "revoke_all_other":
+		err = h.Database.WithTx(func() error {
+			sessions, err := h.Sessions.List(userID)
+			if err != nil {
+				return err
+			}
+			for _, s := range sessions {
+				if s.SessionID() == currentSessionID {
+					continue
+				}
+				if err := h.Sessions.Revoke(s); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	default:
+		http.Error(w, "unknown action", http.StatusBadRequest)
+		return
+	}
+
+	// The session may have been signed out by other means already.
+	if err != nil && !errors.Is(err, session.ErrSessionNotFound) {
+		panic(err)
+	}
+
+	http.Redirect(w, r, httputil.HostRelative(r.URL).String(), http.StatusFound)
+}
+
+func (h *SettingsSessionsHandler) toViewModel(s session.Session, currentSessionID string) SettingsSession {
+	m := s.ToAPIModel()
+	ua := m.UserAgent
+
+	vm := SettingsSession{
+		ID:               s.SessionID(),
+		IsCurrent:        s.SessionID() == currentSessionID,
+		Browser:          strings.TrimSpace(ua.Name + " " + ua.Version),
+		OS:               strings.TrimSpace(ua.OS + " " + ua.OSVersion),
+		DeviceModel:      strings.TrimSpace(ua.DeviceModel),
+		CreatedAt:        m.CreatedAt,
+		LastAccessedAt:   m.LastAccessedAt,
+		LastAccessedByIP: m.LastAccessedByIP,
+	}
+
+	if s.SessionType() == session.TypeOfflineGrant {
+		if client, ok := h.OAuth.GetClient(s.GetClientID()); ok {
+			vm.ClientName = client.Name()
+		}
+	}
+
+	return vm
+}
@@ -0,0 +1,172 @@
+package webapp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/auth/handler/webapp/viewmodels"
+	"github.com/authgear/authgear-server/pkg/auth/webapp"
+	"github.com/authgear/authgear-server/pkg/lib/authn"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	"github.com/authgear/authgear-server/pkg/lib/interaction"
+	"github.com/authgear/authgear-server/pkg/lib/interaction/nodes"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+	"github.com/authgear/authgear-server/pkg/util/httproute"
+	"github.com/authgear/authgear-server/pkg/util/template"
+	"github.com/authgear/authgear-server/pkg/util/validation"
+)
+
+const (
+	TemplateItemTypeAuthUISetupWebAuthnHTML string = "auth_ui_setup_webauthn.html"
+)
+
+var TemplateAuthUISetupWebAuthnHTML = template.Register(template.T{
+	Type:                    TemplateItemTypeAuthUISetupWebAuthnHTML,
+	IsHTML:                  true,
+	TranslationTemplateType: TemplateItemTypeAuthUITranslationJSON,
+	Defines:                 defines,
+	ComponentTemplateTypes:  components,
+})
+
+const SetupWebAuthnRequestSchema = "SetupWebAuthnRequestSchema"
+
+var SetupWebAuthnSchema = validation.NewMultipartSchema("").
+	Add(SetupWebAuthnRequestSchema, `
+	{
+		"type": "object",
+		"properties": {
+			"x_response": { "type": "string", "minLength": 1 },
+			"x_display_name": { "type": "string" }
+		},
+		"required": ["x_response"]
+	}
+	`).Instantiate()
+
+func ConfigureSetupWebAuthnRoute(route httproute.Route) httproute.Route {
+	return route.
+		WithMethods("OPTIONS", "POST", "GET").
+		WithPathPattern("/setup_webauthn")
+}
+
+type SetupWebAuthnViewModel struct {
+	CreationOptions string
+	Alternatives    []CreateAuthenticatorAlternative
+}
+
+type SetupWebAuthnNode interface {
+	GetWebAuthnCreationOptions() json.RawMessage
+}
+
+type SetupWebAuthnInput struct {
+	Response    string
+	DisplayName string
+}
+
+var _ nodes.InputCreateAuthenticatorWebAuthn = &SetupWebAuthnInput{}
+
+// GetWebAuthnAttestationResponse implements InputCreateAuthenticatorWebAuthn.
+func (i *SetupWebAuthnInput) GetWebAuthnAttestationResponse() string {
+	return i.Response
+}
+
+// GetWebAuthnDisplayName implements InputCreateAuthenticatorWebAuthn.
+func (i *SetupWebAuthnInput) GetWebAuthnDisplayName() string {
+	return i.DisplayName
+}
+
+type SetupWebAuthnHandler struct {
+	Database      *db.Handle
+	BaseViewModel *viewmodels.BaseViewModeler
+	Renderer      Renderer
+	WebApp        WebAppService
+	Clock         clock.Clock
+}
+
+func (h *SetupWebAuthnHandler) GetData(r *http.Request, state *webapp.State, graph *interaction.Graph) (map[string]interface{}, error) {
+	data := map[string]interface{}{}
+
+	var node SetupWebAuthnNode
+	if !graph.FindLastNode(&node) {
+		panic(fmt.Errorf("setup_webauthn: expected graph has node implementing SetupWebAuthnNode"))
+	}
+
+	baseViewModel := h.BaseViewModel.ViewModel(r, state.Error)
+	alternatives, err := DeriveCreateAuthenticatorAlternatives(
+		// Use previous state ID because the current node is NodeCreateAuthenticatorWebAuthnSetup.
+		state.PrevID,
+		graph,
+		authn.AuthenticatorTypeWebAuthn,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	viewModel := SetupWebAuthnViewModel{
+		CreationOptions: string(node.GetWebAuthnCreationOptions()),
+		Alternatives:    alternatives,
+	}
+
+	viewmodels.Embed(data, baseViewModel)
+	viewmodels.Embed(data, viewModel)
+	return data, nil
+}
+
+func (h *SetupWebAuthnHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if r.Method == "GET" {
+		err := h.Database.WithTx(func() error {
+			state, graph, err := h.WebApp.Get(StateID(r))
+			if err != nil {
+				return err
+			}
+
+			data, err := h.GetData(r, state, graph)
+			if err != nil {
+				return err
+			}
+
+			h.Renderer.RenderHTML(w, r, TemplateItemTypeAuthUISetupWebAuthnHTML, data)
+			return nil
+		})
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if r.Method == "POST" {
+		err := h.Database.WithTx(func() error {
+			result, err := h.WebApp.PostInput(StateID(r), func() (input interface{}, err error) {
+				err = SetupWebAuthnSchema.PartValidator(SetupWebAuthnRequestSchema).ValidateValue(FormToJSON(r.Form))
+				if err != nil {
+					return
+				}
+
+				displayName := r.Form.Get("x_display_name")
+				if displayName == "" {
+					now := h.Clock.NowUTC()
+					displayName = fmt.Sprintf("Security Key @ %s", now.Format(time.RFC3339))
+				}
+
+				input = &SetupWebAuthnInput{
+					Response:    r.Form.Get("x_response"),
+					DisplayName: displayName,
+				}
+				return
+			})
+			if err != nil {
+				return err
+			}
+			result.WriteResponse(w, r)
+			return nil
+		})
+		if err != nil {
+			panic(err)
+		}
+	}
+}
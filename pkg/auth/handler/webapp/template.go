@@ -66,6 +66,14 @@ const defineError = `
 		<li class="error-txt">{{ template "error-duplicated-identity" }}</li>
 	{{ else if eq .Error.reason "NewPasswordTypo" }}
 		<li class="error-txt">{{ template "error-new-password-typo" }}</li>
+	{{ else if eq .Error.reason "RateLimited" }}
+		<li class="error-txt">{{ template "error-rate-limited" }}</li>
+	{{ else if eq .Error.reason "AccountLocked" }}
+		<li class="error-txt">{{ template "error-account-locked" }}</li>
+	{{ else if eq .Error.reason "UserDisabled" }}
+		<li class="error-txt">{{ template "error-user-disabled" }}</li>
+	{{ else if eq .Error.reason "InvalidUserCode" }}
+		<li class="error-txt">{{ template "error-invalid-user-code" }}</li>
 	{{ else if eq .Error.reason "InvariantViolated" }}
 		{{ $cause := .Error.info.cause }}
 		{{ if (eq $cause.kind "RemoveLastIdentity") }}
@@ -3,6 +3,7 @@ package auth
 import (
 	"net/http"
 
+	headlesshandler "github.com/authgear/authgear-server/pkg/auth/handler/headless"
 	oauthhandler "github.com/authgear/authgear-server/pkg/auth/handler/oauth"
 	webapphandler "github.com/authgear/authgear-server/pkg/auth/handler/webapp"
 	"github.com/authgear/authgear-server/pkg/auth/webapp"
@@ -31,6 +32,7 @@ func NewRouter(p *deps.RootProvider, configSource *configsource.ConfigSource, st
 		},
 		p.Middleware(newPanicLogMiddleware),
 		p.Middleware(newSessionMiddleware),
+		p.Middleware(newAuditMiddleware),
 		p.Middleware(newCORSMiddleware),
 	)
 
@@ -86,6 +88,8 @@ func NewRouter(p *deps.RootProvider, configSource *configsource.ConfigSource, st
 	router.Add(webapphandler.ConfigureEnterLoginIDRoute(webappRoute), p.Handler(newWebAppEnterLoginIDHandler))
 	router.Add(webapphandler.ConfigureSetupTOTPRoute(webappRoute), p.Handler(newWebAppSetupTOTPHandler))
 	router.Add(webapphandler.ConfigureEnterTOTPRoute(webappRoute), p.Handler(newWebAppEnterTOTPHandler))
+	router.Add(webapphandler.ConfigureSetupWebAuthnRoute(webappRoute), p.Handler(newWebAppSetupWebAuthnHandler))
+	router.Add(webapphandler.ConfigureEnterWebAuthnRoute(webappRoute), p.Handler(newWebAppEnterWebAuthnHandler))
 	router.Add(webapphandler.ConfigureSetupOOBOTPRoute(webappRoute), p.Handler(newWebAppSetupOOBOTPHandler))
 	router.Add(webapphandler.ConfigureEnterOOBOTPRoute(webappRoute), p.Handler(newWebAppEnterOOBOTPHandler))
 	router.Add(webapphandler.ConfigureEnterRecoveryCodeRoute(webappRoute), p.Handler(newWebAppEnterRecoveryCodeHandler))
@@ -104,6 +108,11 @@ func NewRouter(p *deps.RootProvider, configSource *configsource.ConfigSource, st
 	router.Add(webapphandler.ConfigureLogoutRoute(webappAuthenticatedRoute), p.Handler(newWebAppLogoutHandler))
 	router.Add(webapphandler.ConfigureSettingsIdentityRoute(webappAuthenticatedRoute), p.Handler(newWebAppSettingsIdentityHandler))
 	router.Add(webapphandler.ConfigureSettingsRoute(webappAuthenticatedRoute), p.Handler(newWebAppSettingsHandler))
+	router.Add(webapphandler.ConfigureSettingsAuthorizedAppsRoute(webappAuthenticatedRoute), p.Handler(newWebAppSettingsAuthorizedAppsHandler))
+	router.Add(webapphandler.ConfigureSettingsSessionsRoute(webappAuthenticatedRoute), p.Handler(newWebAppSettingsSessionsHandler))
+	router.Add(webapphandler.ConfigureSettingsProfileRoute(webappAuthenticatedRoute), p.Handler(newWebAppSettingsProfileHandler))
+	router.Add(webapphandler.ConfigureConsentRoute(webappAuthenticatedRoute), p.Handler(newWebAppConsentHandler))
+	router.Add(webapphandler.ConfigureDeviceRoute(webappAuthenticatedRoute), p.Handler(newWebAppDeviceHandler))
 	router.Add(webapphandler.ConfigureChangePasswordRoute(webappAuthenticatedRoute), p.Handler(newWebAppChangePasswordHandler))
 
 	router.Add(webapphandler.ConfigureSSOCallbackRoute(webappSSOCallbackRoute), p.Handler(newWebAppSSOCallbackHandler))
@@ -114,11 +123,15 @@ func NewRouter(p *deps.RootProvider, configSource *configsource.ConfigSource, st
 	router.Add(oauthhandler.ConfigureAuthorizeRoute(rootRoute), p.Handler(newOAuthAuthorizeHandler))
 	router.Add(oauthhandler.ConfigureTokenRoute(rootRoute), p.Handler(newOAuthTokenHandler))
 	router.Add(oauthhandler.ConfigureRevokeRoute(rootRoute), p.Handler(newOAuthRevokeHandler))
+	router.Add(oauthhandler.ConfigureIntrospectRoute(rootRoute), p.Handler(newOAuthIntrospectHandler))
+	router.Add(oauthhandler.ConfigureDeviceAuthorizationRoute(rootRoute), p.Handler(newOAuthDeviceAuthorizationHandler))
 	router.Add(oauthhandler.ConfigureEndSessionRoute(rootRoute), p.Handler(newOAuthEndSessionHandler))
 	router.Add(oauthhandler.ConfigureChallengeRoute(apiRoute), p.Handler(newOAuthChallengeHandler))
 
 	router.Add(oauthhandler.ConfigureUserInfoRoute(scopedRoute), p.Handler(newOAuthUserInfoHandler))
 
+	router.Add(headlesshandler.ConfigureInteractionRoute(apiRoute), p.Handler(newHeadlessInteractionHandler))
+
 	if staticAsset.ServingEnabled {
 		fileServer := http.FileServer(http.Dir(staticAsset.Directory))
 		staticRoute := httproute.Route{
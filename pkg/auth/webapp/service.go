@@ -432,6 +432,10 @@ func (s *Service) deriveRedirectPath(graph *interaction.Graph) (path string) {
 		path = "/enter_oob_otp"
 	case *nodes.NodeCreateAuthenticatorTOTPSetup:
 		path = "/setup_totp"
+	case *nodes.NodeAuthenticationWebAuthnTrigger:
+		path = "/enter_webauthn"
+	case *nodes.NodeCreateAuthenticatorWebAuthnSetup:
+		path = "/setup_webauthn"
 	case *nodes.NodeGenerateRecoveryCodeBegin:
 		path = "/setup_recovery_code"
 	case *nodes.NodeVerifyIdentity:
@@ -20,6 +20,8 @@ type EndpointsProvider interface {
 	ResetPasswordEndpointURL() *url.URL
 	VerifyIdentityEndpointURL() *url.URL
 	SSOCallbackEndpointURL() *url.URL
+	ConsentEndpointURL() *url.URL
+	DeviceEndpointURL() *url.URL
 }
 
 type URLProvider struct {
@@ -57,6 +59,37 @@ func (p *URLProvider) SSOCallbackURL(c config.OAuthSSOProviderConfig) *url.URL {
 	return u
 }
 
+// ConsentDeniedQuery is added to the redirect URI of consent page when
+// the user denied the consent request.
+const ConsentDeniedQuery = "x_consent_denied"
+
+type ConsentURLOptions struct {
+	ClientID    string
+	Scopes      []string
+	RedirectURI string
+}
+
+func (p *URLProvider) ConsentURL(options ConsentURLOptions) *url.URL {
+	return urlutil.WithQueryParamsAdded(
+		p.Endpoints.ConsentEndpointURL(),
+		map[string]string{
+			"client_id":    options.ClientID,
+			"scope":        strings.Join(options.Scopes, " "),
+			"redirect_uri": options.RedirectURI,
+		},
+	)
+}
+
+// DeviceURL returns the verification URI of device authorization grant.
+// The user code is included if it is not empty.
+func (p *URLProvider) DeviceURL(userCode string) *url.URL {
+	u := p.Endpoints.DeviceEndpointURL()
+	if userCode == "" {
+		return u
+	}
+	return urlutil.WithQueryParamsAdded(u, map[string]string{"user_code": userCode})
+}
+
 type AnonymousIdentityProvider interface {
 	ParseRequestUnverified(requestJWT string) (r *anonymous.Request, err error)
 }
@@ -103,6 +136,26 @@ func (p *AuthenticateURLProvider) AuthenticateURL(options AuthenticateURLOptions
 	return p.Pages.PostIntent(intent, inputer)
 }
 
+type StepUpURLOptions struct {
+	RedirectURI string
+	UILocales   string
+	UserID      string
+	SessionID   string
+}
+
+// StepUpURL starts step-up authentication for the user of the IDP session.
+func (p *AuthenticateURLProvider) StepUpURL(options StepUpURLOptions) (httputil.Result, error) {
+	intent := &Intent{
+		RedirectURI: options.RedirectURI,
+		KeepState:   false,
+		UILocales:   options.UILocales,
+		Intent:      interactionintents.NewIntentStepUp(options.UserID, options.SessionID),
+	}
+	return p.Pages.PostIntent(intent, func() (interface{}, error) {
+		return nil, nil
+	})
+}
+
 func (p *AuthenticateURLProvider) processLoginHint(
 	options AuthenticateURLOptions,
 	intent *Intent,
@@ -6,14 +6,18 @@
 package auth
 
 import (
+	"github.com/authgear/authgear-server/pkg/auth/handler/headless"
 	"github.com/authgear/authgear-server/pkg/auth/handler/oauth"
 	webapp2 "github.com/authgear/authgear-server/pkg/auth/handler/webapp"
 	"github.com/authgear/authgear-server/pkg/auth/handler/webapp/viewmodels"
 	"github.com/authgear/authgear-server/pkg/auth/webapp"
+	"github.com/authgear/authgear-server/pkg/lib/audit"
+	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator/lockout"
 	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator/oob"
 	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator/password"
 	service2 "github.com/authgear/authgear-server/pkg/lib/authn/authenticator/service"
 	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator/totp"
+	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator/webauthn"
 	"github.com/authgear/authgear-server/pkg/lib/authn/challenge"
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity/anonymous"
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity/loginid"
@@ -38,6 +42,7 @@ import (
 	handler2 "github.com/authgear/authgear-server/pkg/lib/oauth/oidc/handler"
 	"github.com/authgear/authgear-server/pkg/lib/oauth/pq"
 	"github.com/authgear/authgear-server/pkg/lib/oauth/redis"
+	"github.com/authgear/authgear-server/pkg/lib/ratelimit"
 	"github.com/authgear/authgear-server/pkg/lib/session"
 	"github.com/authgear/authgear-server/pkg/lib/session/access"
 	"github.com/authgear/authgear-server/pkg/lib/session/idpsession"
@@ -112,6 +117,7 @@ func newOAuthAuthorizeHandler(p *deps.RequestProvider) http.Handler {
 		Redis: redisHandle,
 	}
 	interactionLogger := interaction.NewLogger(factory)
+	remoteIP := deps.ProvideRemoteIP(request, trustProxy)
 	authenticationConfig := appConfig.Authentication
 	identityConfig := appConfig.Identity
 	serviceStore := &service.Store{
@@ -184,6 +190,7 @@ func newOAuthAuthorizeHandler(p *deps.RequestProvider) http.Handler {
 		PasswordHistory: historyStore,
 		PasswordChecker: passwordChecker,
 		TaskQueue:       queue,
+		Database:        handle,
 	}
 	totpStore := &totp.Store{
 		SQLBuilder:  sqlBuilder,
@@ -205,11 +212,29 @@ func newOAuthAuthorizeHandler(p *deps.RequestProvider) http.Handler {
 		Store:  oobStore,
 		Clock:  clock,
 	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clock,
+	}
 	service3 := &service2.Service{
 		Store:    store2,
 		Password: passwordProvider,
 		TOTP:     totpProvider,
 		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
 	}
 	verificationLogger := verification.NewLogger(factory)
 	verificationConfig := appConfig.Verification
@@ -242,17 +267,30 @@ func newOAuthAuthorizeHandler(p *deps.RequestProvider) http.Handler {
 		Coordinator: coordinator,
 	}
 	staticAssetURLPrefix := environmentConfig.StaticAssetURLPrefix
+	rateLimitConfig := appConfig.RateLimit
 	engine := appProvider.TemplateEngine
 	translationService := &translation.Service{
 		Context:           context,
 		EnvironmentConfig: environmentConfig,
 		TemplateEngine:    engine,
 	}
+	ratelimitLogger := ratelimit.NewLogger(factory)
+	storageRedis := &ratelimit.StorageRedis{
+		AppID: appID,
+		Redis: redisHandle,
+		Clock: clock,
+	}
+	limiter := &ratelimit.Limiter{
+		Logger:  ratelimitLogger,
+		Storage: storageRedis,
+	}
 	messageSender := &otp.MessageSender{
 		StaticAssetURLPrefix: staticAssetURLPrefix,
+		RateLimitConfig:      rateLimitConfig,
 		Translation:          translationService,
 		Endpoints:            endpointsProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 	}
 	codeSender := &oob.CodeSender{
 		OTPMessageSender: messageSender,
@@ -264,6 +302,7 @@ func newOAuthAuthorizeHandler(p *deps.RequestProvider) http.Handler {
 	userInfoDecoder := sso.UserInfoDecoder{
 		LoginIDNormalizerFactory: normalizerFactory,
 	}
+	oidcCache := rootProvider.OIDCCache
 	oAuthProviderFactory := &sso.OAuthProviderFactory{
 		Endpoints:                endpointsProvider,
 		IdentityConfig:           identityConfig,
@@ -272,6 +311,7 @@ func newOAuthAuthorizeHandler(p *deps.RequestProvider) http.Handler {
 		Clock:                    clock,
 		UserInfoDecoder:          userInfoDecoder,
 		LoginIDNormalizerFactory: normalizerFactory,
+		OIDCCache:                oidcCache,
 	}
 	storeDeviceTokenRedis := &mfa.StoreDeviceTokenRedis{
 		Redis: redisHandle,
@@ -297,10 +337,12 @@ func newOAuthAuthorizeHandler(p *deps.RequestProvider) http.Handler {
 		StaticAssetURLPrefix: staticAssetURLPrefix,
 		Translation:          translationService,
 		Config:               forgotPasswordConfig,
+		RateLimitConfig:      rateLimitConfig,
 		Store:                forgotpasswordStore,
 		Clock:                clock,
 		URLs:                 webappURLProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 		Logger:               providerLogger,
 		Identities:           identityFacade,
 		Authenticators:       authenticatorFacade,
@@ -328,6 +370,7 @@ func newOAuthAuthorizeHandler(p *deps.RequestProvider) http.Handler {
 		Store:        userStore,
 		Identities:   identityFacade,
 		Verification: verificationService,
+		Clock:        clock,
 	}
 	rawCommands := &user.RawCommands{
 		Store:                  userStore,
@@ -341,6 +384,7 @@ func newOAuthAuthorizeHandler(p *deps.RequestProvider) http.Handler {
 		Queries:     queries,
 	}
 	hookStore := &hook.Store{
+		Clock:       clock,
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
@@ -363,16 +407,42 @@ func newOAuthAuthorizeHandler(p *deps.RequestProvider) http.Handler {
 		Users:     rawProvider,
 		Store:     hookStore,
 		Deliverer: deliverer,
+		TaskQueue: queue,
 	}
+	userProfileConfig := appConfig.UserProfile
 	commands := &user.Commands{
-		Raw:          rawCommands,
-		Hooks:        hookProvider,
-		Verification: verificationService,
+		Raw:               rawCommands,
+		Hooks:             hookProvider,
+		Verification:      verificationService,
+		UserProfileConfig: userProfileConfig,
 	}
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
+	authenticationLockoutConfig := authenticationConfig.Lockout
+	lockoutStorePQ := &lockout.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	lockoutService := &lockout.Service{
+		Config:   authenticationLockoutConfig,
+		Clock:    clock,
+		Store:    lockoutStorePQ,
+		Database: handle,
+		Users:    rawProvider,
+		Hooks:    hookProvider,
+	}
+	auditStorePQ := &audit.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	auditService := &audit.Service{
+		Context:  context,
+		Clock:    clock,
+		Store:    auditStorePQ,
+		Database: handle,
+	}
 	cookieFactory := deps.NewCookieFactory(request, trustProxy)
 	storeRedisLogger := idpsession.NewStoreRedisLogger(factory)
 	idpsessionStoreRedis := &idpsession.StoreRedis{
@@ -399,18 +469,19 @@ func newOAuthAuthorizeHandler(p *deps.RequestProvider) http.Handler {
 		Clock:        clock,
 		Random:       rand,
 	}
-	httpConfig := appConfig.HTTP
 	cookieDef := idpsession.NewSessionCookieDef(httpConfig, sessionConfig)
 	mfaCookieDef := mfa.NewDeviceTokenCookieDef(httpConfig, authenticationConfig)
 	interactionContext := &interaction.Context{
 		Database:                 sqlExecutor,
 		Clock:                    clock,
 		Config:                   appConfig,
+		RemoteIP:                 remoteIP,
 		Identities:               identityFacade,
 		Authenticators:           authenticatorFacade,
 		AnonymousIdentities:      provider,
 		OOBAuthenticators:        oobProvider,
 		OOBCodeSender:            codeSender,
+		WebAuthnAuthenticators:   webauthnProvider,
 		OAuthProviderFactory:     oAuthProviderFactory,
 		MFA:                      mfaService,
 		ForgotPassword:           forgotpasswordProvider,
@@ -421,6 +492,9 @@ func newOAuthAuthorizeHandler(p *deps.RequestProvider) http.Handler {
 		Challenges:               challengeProvider,
 		Users:                    userProvider,
 		Hooks:                    hookProvider,
+		RateLimiter:              limiter,
+		Lockout:                  lockoutService,
+		Audit:                    auditService,
 		CookieFactory:            cookieFactory,
 		Sessions:                 idpsessionProvider,
 		SessionCookie:            cookieDef,
@@ -460,6 +534,7 @@ func newOAuthAuthorizeHandler(p *deps.RequestProvider) http.Handler {
 		CodeGrants:     grantStore,
 		OAuthURLs:      urlProvider,
 		WebAppURLs:     authenticateURLProvider,
+		ConsentURLs:    webappURLProvider,
 		ValidateScopes: scopesValidator,
 		CodeGenerator:  tokenGenerator,
 		Clock:          clock,
@@ -489,6 +564,7 @@ func newOAuthTokenHandler(p *deps.RequestProvider) http.Handler {
 	appConfig := config.AppConfig
 	appID := appConfig.ID
 	oAuthConfig := appConfig.OAuth
+	rateLimitConfig := appConfig.RateLimit
 	rootProvider := appProvider.RootProvider
 	environmentConfig := rootProvider.EnvironmentConfig
 	trustProxy := environmentConfig.TrustProxy
@@ -541,10 +617,13 @@ func newOAuthTokenHandler(p *deps.RequestProvider) http.Handler {
 		Clock:        clockClock,
 		Random:       idpsessionRand,
 	}
-	interactionLogger := interaction.NewLogger(factory)
+	store := &user.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
 	authenticationConfig := appConfig.Authentication
 	identityConfig := appConfig.Identity
-	store := &service.Store{
+	serviceStore := &service.Store{
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
@@ -591,12 +670,12 @@ func newOAuthTokenHandler(p *deps.RequestProvider) http.Handler {
 	serviceService := &service.Service{
 		Authentication: authenticationConfig,
 		Identity:       identityConfig,
-		Store:          store,
+		Store:          serviceStore,
 		LoginID:        loginidProvider,
 		OAuth:          oauthProvider,
 		Anonymous:      anonymousProvider,
 	}
-	serviceStore := &service2.Store{
+	store2 := &service2.Store{
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
@@ -622,6 +701,7 @@ func newOAuthTokenHandler(p *deps.RequestProvider) http.Handler {
 		PasswordHistory: historyStore,
 		PasswordChecker: passwordChecker,
 		TaskQueue:       queue,
+		Database:        handle,
 	}
 	totpStore := &totp.Store{
 		SQLBuilder:  sqlBuilder,
@@ -643,11 +723,29 @@ func newOAuthTokenHandler(p *deps.RequestProvider) http.Handler {
 		Store:  oobStore,
 		Clock:  clockClock,
 	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
 	service3 := &service2.Service{
-		Store:    serviceStore,
+		Store:    store2,
 		Password: passwordProvider,
 		TOTP:     totpProvider,
 		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
 	}
 	verificationLogger := verification.NewLogger(factory)
 	verificationConfig := appConfig.Verification
@@ -676,6 +774,14 @@ func newOAuthTokenHandler(p *deps.RequestProvider) http.Handler {
 	identityFacade := facade.IdentityFacade{
 		Coordinator: coordinator,
 	}
+	queries := &user.Queries{
+		Store:        store,
+		Identities:   identityFacade,
+		Verification: verificationService,
+		Clock:        clockClock,
+	}
+	interactionLogger := interaction.NewLogger(factory)
+	remoteIP := deps.ProvideRemoteIP(request, trustProxy)
 	authenticatorFacade := facade.AuthenticatorFacade{
 		Coordinator: coordinator,
 	}
@@ -693,11 +799,23 @@ func newOAuthTokenHandler(p *deps.RequestProvider) http.Handler {
 	endpointsProvider := &EndpointsProvider{
 		OriginProvider: mainOriginProvider,
 	}
+	ratelimitLogger := ratelimit.NewLogger(factory)
+	storageRedis := &ratelimit.StorageRedis{
+		AppID: appID,
+		Redis: redisHandle,
+		Clock: clockClock,
+	}
+	limiter := &ratelimit.Limiter{
+		Logger:  ratelimitLogger,
+		Storage: storageRedis,
+	}
 	messageSender := &otp.MessageSender{
 		StaticAssetURLPrefix: staticAssetURLPrefix,
+		RateLimitConfig:      rateLimitConfig,
 		Translation:          translationService,
 		Endpoints:            endpointsProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 	}
 	codeSender := &oob.CodeSender{
 		OTPMessageSender: messageSender,
@@ -709,6 +827,7 @@ func newOAuthTokenHandler(p *deps.RequestProvider) http.Handler {
 	userInfoDecoder := sso.UserInfoDecoder{
 		LoginIDNormalizerFactory: normalizerFactory,
 	}
+	oidcCache := rootProvider.OIDCCache
 	oAuthProviderFactory := &sso.OAuthProviderFactory{
 		Endpoints:                endpointsProvider,
 		IdentityConfig:           identityConfig,
@@ -717,6 +836,7 @@ func newOAuthTokenHandler(p *deps.RequestProvider) http.Handler {
 		Clock:                    clockClock,
 		UserInfoDecoder:          userInfoDecoder,
 		LoginIDNormalizerFactory: normalizerFactory,
+		OIDCCache:                oidcCache,
 	}
 	storeDeviceTokenRedis := &mfa.StoreDeviceTokenRedis{
 		Redis: redisHandle,
@@ -742,10 +862,12 @@ func newOAuthTokenHandler(p *deps.RequestProvider) http.Handler {
 		StaticAssetURLPrefix: staticAssetURLPrefix,
 		Translation:          translationService,
 		Config:               forgotPasswordConfig,
+		RateLimitConfig:      rateLimitConfig,
 		Store:                forgotpasswordStore,
 		Clock:                clockClock,
 		URLs:                 urlProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 		Logger:               providerLogger,
 		Identities:           identityFacade,
 		Authenticators:       authenticatorFacade,
@@ -759,23 +881,14 @@ func newOAuthTokenHandler(p *deps.RequestProvider) http.Handler {
 		AppID: appID,
 		Clock: clockClock,
 	}
-	userStore := &user.Store{
-		SQLBuilder:  sqlBuilder,
-		SQLExecutor: sqlExecutor,
-	}
 	welcomeMessageConfig := appConfig.WelcomeMessage
 	welcomemessageProvider := &welcomemessage.Provider{
 		Translation:          translationService,
 		WelcomeMessageConfig: welcomeMessageConfig,
 		TaskQueue:            queue,
 	}
-	queries := &user.Queries{
-		Store:        userStore,
-		Identities:   identityFacade,
-		Verification: verificationService,
-	}
 	rawCommands := &user.RawCommands{
-		Store:                  userStore,
+		Store:                  store,
 		Clock:                  clockClock,
 		WelcomeMessageProvider: welcomemessageProvider,
 		Queries:                queries,
@@ -786,6 +899,7 @@ func newOAuthTokenHandler(p *deps.RequestProvider) http.Handler {
 		Queries:     queries,
 	}
 	hookStore := &hook.Store{
+		Clock:       clockClock,
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
@@ -808,29 +922,56 @@ func newOAuthTokenHandler(p *deps.RequestProvider) http.Handler {
 		Users:     rawProvider,
 		Store:     hookStore,
 		Deliverer: deliverer,
+		TaskQueue: queue,
 	}
+	userProfileConfig := appConfig.UserProfile
 	commands := &user.Commands{
-		Raw:          rawCommands,
-		Hooks:        hookProvider,
-		Verification: verificationService,
+		Raw:               rawCommands,
+		Hooks:             hookProvider,
+		Verification:      verificationService,
+		UserProfileConfig: userProfileConfig,
 	}
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
+	authenticationLockoutConfig := authenticationConfig.Lockout
+	lockoutStorePQ := &lockout.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	lockoutService := &lockout.Service{
+		Config:   authenticationLockoutConfig,
+		Clock:    clockClock,
+		Store:    lockoutStorePQ,
+		Database: handle,
+		Users:    rawProvider,
+		Hooks:    hookProvider,
+	}
+	auditStorePQ := &audit.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	auditService := &audit.Service{
+		Context:  context,
+		Clock:    clockClock,
+		Store:    auditStorePQ,
+		Database: handle,
+	}
 	cookieFactory := deps.NewCookieFactory(request, trustProxy)
-	httpConfig := appConfig.HTTP
 	cookieDef := idpsession.NewSessionCookieDef(httpConfig, sessionConfig)
 	mfaCookieDef := mfa.NewDeviceTokenCookieDef(httpConfig, authenticationConfig)
 	interactionContext := &interaction.Context{
 		Database:                 sqlExecutor,
 		Clock:                    clockClock,
 		Config:                   appConfig,
+		RemoteIP:                 remoteIP,
 		Identities:               identityFacade,
 		Authenticators:           authenticatorFacade,
 		AnonymousIdentities:      anonymousProvider,
 		OOBAuthenticators:        oobProvider,
 		OOBCodeSender:            codeSender,
+		WebAuthnAuthenticators:   webauthnProvider,
 		OAuthProviderFactory:     oAuthProviderFactory,
 		MFA:                      mfaService,
 		ForgotPassword:           forgotpasswordProvider,
@@ -841,6 +982,9 @@ func newOAuthTokenHandler(p *deps.RequestProvider) http.Handler {
 		Challenges:               challengeProvider,
 		Users:                    userProvider,
 		Hooks:                    hookProvider,
+		RateLimiter:              limiter,
+		Lockout:                  lockoutService,
+		Audit:                    auditService,
 		CookieFactory:            cookieFactory,
 		Sessions:                 provider,
 		SessionCookie:            cookieDef,
@@ -857,28 +1001,50 @@ func newOAuthTokenHandler(p *deps.RequestProvider) http.Handler {
 	}
 	oidcKeyMaterials := deps.ProvideOIDCKeyMaterials(secretConfig)
 	idTokenIssuer := &oidc.IDTokenIssuer{
+		Secrets:           oidcKeyMaterials,
+		Endpoints:         endpointsProvider,
+		Users:             queries,
+		Identities:        serviceService,
+		Verification:      verificationService,
+		UserProfileConfig: userProfileConfig,
+		Clock:             clockClock,
+	}
+	tokenGenerator := _wireTokenGeneratorValue
+	accessTokenEncoding := &oidc.AccessTokenEncoding{
 		Secrets:   oidcKeyMaterials,
 		Endpoints: endpointsProvider,
-		Users:     queries,
-		Clock:     clockClock,
 	}
-	tokenGenerator := _wireTokenGeneratorValue
+	oAuthClientSecrets := deps.ProvideOAuthClientSecrets(secretConfig)
+	clientAuthenticator := &handler.ClientAuthenticator{
+		Request:          request,
+		Config:           oAuthConfig,
+		Secrets:          oAuthClientSecrets,
+		Endpoints:        endpointsProvider,
+		ClientAssertions: grantStore,
+		Clock:            clockClock,
+	}
 	tokenHandler := &handler.TokenHandler{
-		Request:        request,
-		AppID:          appID,
-		Config:         oAuthConfig,
-		TrustProxy:     trustProxy,
-		Logger:         handlerTokenHandlerLogger,
-		Authorizations: authorizationStore,
-		CodeGrants:     grantStore,
-		OfflineGrants:  grantStore,
-		AccessGrants:   grantStore,
-		AccessEvents:   eventProvider,
-		Sessions:       provider,
-		Graphs:         interactionService,
-		IDTokenIssuer:  idTokenIssuer,
-		GenerateToken:  tokenGenerator,
-		Clock:          clockClock,
+		Request:             request,
+		AppID:               appID,
+		Config:              oAuthConfig,
+		RateLimitConfig:     rateLimitConfig,
+		TrustProxy:          trustProxy,
+		Logger:              handlerTokenHandlerLogger,
+		Authorizations:      authorizationStore,
+		CodeGrants:          grantStore,
+		DeviceGrants:        grantStore,
+		OfflineGrants:       grantStore,
+		AccessGrants:        grantStore,
+		AccessEvents:        eventProvider,
+		Sessions:            provider,
+		Users:               queries,
+		Graphs:              interactionService,
+		IDTokenIssuer:       idTokenIssuer,
+		GenerateToken:       tokenGenerator,
+		RateLimiter:         limiter,
+		Clock:               clockClock,
+		AccessTokenEncoding: accessTokenEncoding,
+		ClientAuthenticator: clientAuthenticator,
 	}
 	oauthTokenHandler := &oauth.TokenHandler{
 		Logger:       tokenHandlerLogger,
@@ -893,15 +1059,27 @@ func newOAuthRevokeHandler(p *deps.RequestProvider) http.Handler {
 	factory := appProvider.LoggerFactory
 	revokeHandlerLogger := oauth.NewRevokeHandlerLogger(factory)
 	handle := appProvider.Database
-	redisHandle := appProvider.Redis
 	config := appProvider.Config
 	appConfig := config.AppConfig
+	oAuthConfig := appConfig.OAuth
+	request := p.Request
+	secretConfig := config.SecretConfig
+	oAuthClientSecrets := deps.ProvideOAuthClientSecrets(secretConfig)
+	rootProvider := appProvider.RootProvider
+	environmentConfig := rootProvider.EnvironmentConfig
+	trustProxy := environmentConfig.TrustProxy
+	mainOriginProvider := &MainOriginProvider{
+		Request:    request,
+		TrustProxy: trustProxy,
+	}
+	endpointsProvider := &EndpointsProvider{
+		OriginProvider: mainOriginProvider,
+	}
+	redisHandle := appProvider.Redis
 	appID := appConfig.ID
 	logger := redis.NewLogger(factory)
-	secretConfig := config.SecretConfig
 	databaseCredentials := deps.ProvideDatabaseCredentials(secretConfig)
 	sqlBuilder := db.ProvideSQLBuilder(databaseCredentials, appID)
-	request := p.Request
 	context := deps.ProvideRequestContext(request)
 	sqlExecutor := db.SQLExecutor{
 		Context:  context,
@@ -916,9 +1094,24 @@ func newOAuthRevokeHandler(p *deps.RequestProvider) http.Handler {
 		SQLExecutor: sqlExecutor,
 		Clock:       clockClock,
 	}
+	clientAuthenticator := &handler.ClientAuthenticator{
+		Request:          request,
+		Config:           oAuthConfig,
+		Secrets:          oAuthClientSecrets,
+		Endpoints:        endpointsProvider,
+		ClientAssertions: grantStore,
+		Clock:            clockClock,
+	}
+	authorizationStore := &pq.AuthorizationStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
 	revokeHandler := &handler.RevokeHandler{
-		OfflineGrants: grantStore,
-		AccessGrants:  grantStore,
+		Config:              oAuthConfig,
+		ClientAuthenticator: clientAuthenticator,
+		Authorizations:      authorizationStore,
+		OfflineGrants:       grantStore,
+		AccessGrants:        grantStore,
 	}
 	oauthRevokeHandler := &oauth.RevokeHandler{
 		Logger:        revokeHandlerLogger,
@@ -928,40 +1121,18 @@ func newOAuthRevokeHandler(p *deps.RequestProvider) http.Handler {
 	return oauthRevokeHandler
 }
 
-func newOAuthMetadataHandler(p *deps.RequestProvider) http.Handler {
-	request := p.Request
-	appProvider := p.AppProvider
-	rootProvider := appProvider.RootProvider
-	environmentConfig := rootProvider.EnvironmentConfig
-	trustProxy := environmentConfig.TrustProxy
-	mainOriginProvider := &MainOriginProvider{
-		Request:    request,
-		TrustProxy: trustProxy,
-	}
-	endpointsProvider := &EndpointsProvider{
-		OriginProvider: mainOriginProvider,
-	}
-	metadataProvider := &oauth2.MetadataProvider{
-		Endpoints: endpointsProvider,
-	}
-	oidcMetadataProvider := &oidc.MetadataProvider{
-		Endpoints: endpointsProvider,
-	}
-	v := ProvideOAuthMetadataProviders(metadataProvider, oidcMetadataProvider)
-	metadataHandler := &oauth.MetadataHandler{
-		Providers: v,
-	}
-	return metadataHandler
-}
-
-func newOAuthJWKSHandler(p *deps.RequestProvider) http.Handler {
+func newOAuthIntrospectHandler(p *deps.RequestProvider) http.Handler {
 	appProvider := p.AppProvider
 	factory := appProvider.LoggerFactory
-	jwksHandlerLogger := oauth.NewJWKSHandlerLogger(factory)
+	introspectHandlerLogger := oauth.NewIntrospectHandlerLogger(factory)
+	handle := appProvider.Database
 	config := appProvider.Config
-	secretConfig := config.SecretConfig
-	oidcKeyMaterials := deps.ProvideOIDCKeyMaterials(secretConfig)
+	appConfig := config.AppConfig
+	oAuthConfig := appConfig.OAuth
+	introspectionHandlerLogger := handler.NewIntrospectionHandlerLogger(factory)
 	request := p.Request
+	secretConfig := config.SecretConfig
+	oAuthClientSecrets := deps.ProvideOAuthClientSecrets(secretConfig)
 	rootProvider := appProvider.RootProvider
 	environmentConfig := rootProvider.EnvironmentConfig
 	trustProxy := environmentConfig.TrustProxy
@@ -972,16 +1143,62 @@ func newOAuthJWKSHandler(p *deps.RequestProvider) http.Handler {
 	endpointsProvider := &EndpointsProvider{
 		OriginProvider: mainOriginProvider,
 	}
-	databaseCredentials := deps.ProvideDatabaseCredentials(secretConfig)
-	appConfig := config.AppConfig
+	redisHandle := appProvider.Redis
 	appID := appConfig.ID
+	logger := redis.NewLogger(factory)
+	databaseCredentials := deps.ProvideDatabaseCredentials(secretConfig)
 	sqlBuilder := db.ProvideSQLBuilder(databaseCredentials, appID)
 	context := deps.ProvideRequestContext(request)
-	handle := appProvider.Database
 	sqlExecutor := db.SQLExecutor{
 		Context:  context,
 		Database: handle,
 	}
+	clockClock := _wireSystemClockValue
+	grantStore := &redis.GrantStore{
+		Redis:       redisHandle,
+		AppID:       appID,
+		Logger:      logger,
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+		Clock:       clockClock,
+	}
+	clientAuthenticator := &handler.ClientAuthenticator{
+		Request:          request,
+		Config:           oAuthConfig,
+		Secrets:          oAuthClientSecrets,
+		Endpoints:        endpointsProvider,
+		ClientAssertions: grantStore,
+		Clock:            clockClock,
+	}
+	authorizationStore := &pq.AuthorizationStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	storeRedisLogger := idpsession.NewStoreRedisLogger(factory)
+	storeRedis := &idpsession.StoreRedis{
+		Redis:  redisHandle,
+		AppID:  appID,
+		Clock:  clockClock,
+		Logger: storeRedisLogger,
+	}
+	eventStoreRedis := &access.EventStoreRedis{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	eventProvider := &access.EventProvider{
+		Store: eventStoreRedis,
+	}
+	sessionConfig := appConfig.Session
+	idpsessionRand := _wireRandValue
+	provider := &idpsession.Provider{
+		Request:      request,
+		Store:        storeRedis,
+		AccessEvents: eventProvider,
+		TrustProxy:   trustProxy,
+		Config:       sessionConfig,
+		Clock:        clockClock,
+		Random:       idpsessionRand,
+	}
 	store := &user.Store{
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
@@ -1009,8 +1226,7 @@ func newOAuthJWKSHandler(p *deps.RequestProvider) http.Handler {
 	normalizerFactory := &loginid.NormalizerFactory{
 		Config: loginIDConfig,
 	}
-	clockClock := _wireSystemClockValue
-	provider := &loginid.Provider{
+	loginidProvider := &loginid.Provider{
 		Store:             loginidStore,
 		Config:            loginIDConfig,
 		Checker:           checker,
@@ -1037,7 +1253,7 @@ func newOAuthJWKSHandler(p *deps.RequestProvider) http.Handler {
 		Authentication: authenticationConfig,
 		Identity:       identityConfig,
 		Store:          serviceStore,
-		LoginID:        provider,
+		LoginID:        loginidProvider,
 		OAuth:          oauthProvider,
 		Anonymous:      anonymousProvider,
 	}
@@ -1051,7 +1267,7 @@ func newOAuthJWKSHandler(p *deps.RequestProvider) http.Handler {
 	}
 	authenticatorConfig := appConfig.Authenticator
 	authenticatorPasswordConfig := authenticatorConfig.Password
-	logger := password.NewLogger(factory)
+	passwordLogger := password.NewLogger(factory)
 	historyStore := &password.HistoryStore{
 		Clock:       clockClock,
 		SQLBuilder:  sqlBuilder,
@@ -1063,10 +1279,11 @@ func newOAuthJWKSHandler(p *deps.RequestProvider) http.Handler {
 		Store:           passwordStore,
 		Config:          authenticatorPasswordConfig,
 		Clock:           clockClock,
-		Logger:          logger,
+		Logger:          passwordLogger,
 		PasswordHistory: historyStore,
 		PasswordChecker: passwordChecker,
 		TaskQueue:       queue,
+		Database:        handle,
 	}
 	totpStore := &totp.Store{
 		SQLBuilder:  sqlBuilder,
@@ -1088,16 +1305,33 @@ func newOAuthJWKSHandler(p *deps.RequestProvider) http.Handler {
 		Store:  oobStore,
 		Clock:  clockClock,
 	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
 	service3 := &service2.Service{
 		Store:    store2,
 		Password: passwordProvider,
 		TOTP:     totpProvider,
 		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
 	}
 	verificationLogger := verification.NewLogger(factory)
 	verificationConfig := appConfig.Verification
-	redisHandle := appProvider.Redis
-	storeRedis := &verification.StoreRedis{
+	verificationStoreRedis := &verification.StoreRedis{
 		Redis: redisHandle,
 		AppID: appID,
 		Clock: clockClock,
@@ -1110,7 +1344,7 @@ func newOAuthJWKSHandler(p *deps.RequestProvider) http.Handler {
 		Logger:     verificationLogger,
 		Config:     verificationConfig,
 		Clock:      clockClock,
-		CodeStore:  storeRedis,
+		CodeStore:  verificationStoreRedis,
 		ClaimStore: storePQ,
 	}
 	coordinator := &facade.Coordinator{
@@ -1126,29 +1360,40 @@ func newOAuthJWKSHandler(p *deps.RequestProvider) http.Handler {
 		Store:        store,
 		Identities:   identityFacade,
 		Verification: verificationService,
+		Clock:        clockClock,
 	}
-	idTokenIssuer := &oidc.IDTokenIssuer{
-		Secrets:   oidcKeyMaterials,
-		Endpoints: endpointsProvider,
-		Users:     queries,
-		Clock:     clockClock,
+	introspectionHandler := &handler.IntrospectionHandler{
+		Config:              oAuthConfig,
+		Logger:              introspectionHandlerLogger,
+		ClientAuthenticator: clientAuthenticator,
+		Authorizations:      authorizationStore,
+		OfflineGrants:       grantStore,
+		AccessGrants:        grantStore,
+		Sessions:            provider,
+		Users:               queries,
+		Clock:               clockClock,
 	}
-	jwksHandler := &oauth.JWKSHandler{
-		Logger: jwksHandlerLogger,
-		JWKS:   idTokenIssuer,
+	introspectHandler := &oauth.IntrospectHandler{
+		Logger:               introspectHandlerLogger,
+		Database:             handle,
+		IntrospectionHandler: introspectionHandler,
 	}
-	return jwksHandler
+	return introspectHandler
 }
 
-func newOAuthUserInfoHandler(p *deps.RequestProvider) http.Handler {
+func newOAuthDeviceAuthorizationHandler(p *deps.RequestProvider) http.Handler {
 	appProvider := p.AppProvider
 	factory := appProvider.LoggerFactory
-	userInfoHandlerLogger := oauth.NewUserInfoHandlerLogger(factory)
+	deviceAuthorizationHandlerLogger := oauth.NewDeviceAuthorizationHandlerLogger(factory)
 	handle := appProvider.Database
 	config := appProvider.Config
-	secretConfig := config.SecretConfig
-	oidcKeyMaterials := deps.ProvideOIDCKeyMaterials(secretConfig)
+	appConfig := config.AppConfig
+	appID := appConfig.ID
+	handlerDeviceAuthorizationHandlerLogger := handler.NewDeviceAuthorizationHandlerLogger(factory)
 	request := p.Request
+	oAuthConfig := appConfig.OAuth
+	secretConfig := config.SecretConfig
+	oAuthClientSecrets := deps.ProvideOAuthClientSecrets(secretConfig)
 	rootProvider := appProvider.RootProvider
 	environmentConfig := rootProvider.EnvironmentConfig
 	trustProxy := environmentConfig.TrustProxy
@@ -1159,20 +1404,114 @@ func newOAuthUserInfoHandler(p *deps.RequestProvider) http.Handler {
 	endpointsProvider := &EndpointsProvider{
 		OriginProvider: mainOriginProvider,
 	}
+	redisHandle := appProvider.Redis
+	logger := redis.NewLogger(factory)
 	databaseCredentials := deps.ProvideDatabaseCredentials(secretConfig)
-	appConfig := config.AppConfig
-	appID := appConfig.ID
 	sqlBuilder := db.ProvideSQLBuilder(databaseCredentials, appID)
 	context := deps.ProvideRequestContext(request)
 	sqlExecutor := db.SQLExecutor{
 		Context:  context,
 		Database: handle,
 	}
-	store := &user.Store{
-		SQLBuilder:  sqlBuilder,
-		SQLExecutor: sqlExecutor,
-	}
-	authenticationConfig := appConfig.Authentication
+	clockClock := _wireSystemClockValue
+	grantStore := &redis.GrantStore{
+		Redis:       redisHandle,
+		AppID:       appID,
+		Logger:      logger,
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+		Clock:       clockClock,
+	}
+	clientAuthenticator := &handler.ClientAuthenticator{
+		Request:          request,
+		Config:           oAuthConfig,
+		Secrets:          oAuthClientSecrets,
+		Endpoints:        endpointsProvider,
+		ClientAssertions: grantStore,
+		Clock:            clockClock,
+	}
+	urlProvider := &webapp.URLProvider{
+		Endpoints: endpointsProvider,
+	}
+	scopesValidator := _wireScopesValidatorValue
+	tokenGenerator := _wireTokenGeneratorValue
+	deviceAuthorizationHandler := &handler.DeviceAuthorizationHandler{
+		AppID:               appID,
+		Logger:              handlerDeviceAuthorizationHandlerLogger,
+		ClientAuthenticator: clientAuthenticator,
+		DeviceGrants:        grantStore,
+		DeviceURLs:          urlProvider,
+		ValidateScopes:      scopesValidator,
+		GenerateToken:       tokenGenerator,
+		Clock:               clockClock,
+	}
+	oauthDeviceAuthorizationHandler := &oauth.DeviceAuthorizationHandler{
+		Logger:                     deviceAuthorizationHandlerLogger,
+		Database:                   handle,
+		DeviceAuthorizationHandler: deviceAuthorizationHandler,
+	}
+	return oauthDeviceAuthorizationHandler
+}
+
+func newOAuthMetadataHandler(p *deps.RequestProvider) http.Handler {
+	request := p.Request
+	appProvider := p.AppProvider
+	rootProvider := appProvider.RootProvider
+	environmentConfig := rootProvider.EnvironmentConfig
+	trustProxy := environmentConfig.TrustProxy
+	mainOriginProvider := &MainOriginProvider{
+		Request:    request,
+		TrustProxy: trustProxy,
+	}
+	endpointsProvider := &EndpointsProvider{
+		OriginProvider: mainOriginProvider,
+	}
+	metadataProvider := &oauth2.MetadataProvider{
+		Endpoints: endpointsProvider,
+	}
+	oidcMetadataProvider := &oidc.MetadataProvider{
+		Endpoints: endpointsProvider,
+	}
+	v := ProvideOAuthMetadataProviders(metadataProvider, oidcMetadataProvider)
+	metadataHandler := &oauth.MetadataHandler{
+		Providers: v,
+	}
+	return metadataHandler
+}
+
+func newOAuthJWKSHandler(p *deps.RequestProvider) http.Handler {
+	appProvider := p.AppProvider
+	factory := appProvider.LoggerFactory
+	jwksHandlerLogger := oauth.NewJWKSHandlerLogger(factory)
+	config := appProvider.Config
+	secretConfig := config.SecretConfig
+	oidcKeyMaterials := deps.ProvideOIDCKeyMaterials(secretConfig)
+	request := p.Request
+	rootProvider := appProvider.RootProvider
+	environmentConfig := rootProvider.EnvironmentConfig
+	trustProxy := environmentConfig.TrustProxy
+	mainOriginProvider := &MainOriginProvider{
+		Request:    request,
+		TrustProxy: trustProxy,
+	}
+	endpointsProvider := &EndpointsProvider{
+		OriginProvider: mainOriginProvider,
+	}
+	databaseCredentials := deps.ProvideDatabaseCredentials(secretConfig)
+	appConfig := config.AppConfig
+	appID := appConfig.ID
+	sqlBuilder := db.ProvideSQLBuilder(databaseCredentials, appID)
+	context := deps.ProvideRequestContext(request)
+	handle := appProvider.Database
+	sqlExecutor := db.SQLExecutor{
+		Context:  context,
+		Database: handle,
+	}
+	store := &user.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	authenticationConfig := appConfig.Authentication
 	identityConfig := appConfig.Identity
 	serviceStore := &service.Store{
 		SQLBuilder:  sqlBuilder,
@@ -1253,6 +1592,7 @@ func newOAuthUserInfoHandler(p *deps.RequestProvider) http.Handler {
 		PasswordHistory: historyStore,
 		PasswordChecker: passwordChecker,
 		TaskQueue:       queue,
+		Database:        handle,
 	}
 	totpStore := &totp.Store{
 		SQLBuilder:  sqlBuilder,
@@ -1274,15 +1614,33 @@ func newOAuthUserInfoHandler(p *deps.RequestProvider) http.Handler {
 		Store:  oobStore,
 		Clock:  clockClock,
 	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	redisHandle := appProvider.Redis
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
 	service3 := &service2.Service{
 		Store:    store2,
 		Password: passwordProvider,
 		TOTP:     totpProvider,
 		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
 	}
 	verificationLogger := verification.NewLogger(factory)
 	verificationConfig := appConfig.Verification
-	redisHandle := appProvider.Redis
 	storeRedis := &verification.StoreRedis{
 		Redis: redisHandle,
 		AppID: appID,
@@ -1312,29 +1670,33 @@ func newOAuthUserInfoHandler(p *deps.RequestProvider) http.Handler {
 		Store:        store,
 		Identities:   identityFacade,
 		Verification: verificationService,
+		Clock:        clockClock,
 	}
+	userProfileConfig := appConfig.UserProfile
 	idTokenIssuer := &oidc.IDTokenIssuer{
-		Secrets:   oidcKeyMaterials,
-		Endpoints: endpointsProvider,
-		Users:     queries,
-		Clock:     clockClock,
+		Secrets:           oidcKeyMaterials,
+		Endpoints:         endpointsProvider,
+		Users:             queries,
+		Identities:        serviceService,
+		Verification:      verificationService,
+		UserProfileConfig: userProfileConfig,
+		Clock:             clockClock,
 	}
-	userInfoHandler := &oauth.UserInfoHandler{
-		Logger:           userInfoHandlerLogger,
-		Database:         handle,
-		UserInfoProvider: idTokenIssuer,
+	jwksHandler := &oauth.JWKSHandler{
+		Logger: jwksHandlerLogger,
+		JWKS:   idTokenIssuer,
 	}
-	return userInfoHandler
+	return jwksHandler
 }
 
-func newOAuthEndSessionHandler(p *deps.RequestProvider) http.Handler {
+func newOAuthUserInfoHandler(p *deps.RequestProvider) http.Handler {
 	appProvider := p.AppProvider
 	factory := appProvider.LoggerFactory
-	endSessionHandlerLogger := oauth.NewEndSessionHandlerLogger(factory)
+	userInfoHandlerLogger := oauth.NewUserInfoHandlerLogger(factory)
 	handle := appProvider.Database
 	config := appProvider.Config
-	appConfig := config.AppConfig
-	oAuthConfig := appConfig.OAuth
+	secretConfig := config.SecretConfig
+	oidcKeyMaterials := deps.ProvideOIDCKeyMaterials(secretConfig)
 	request := p.Request
 	rootProvider := appProvider.RootProvider
 	environmentConfig := rootProvider.EnvironmentConfig
@@ -1346,106 +1708,22 @@ func newOAuthEndSessionHandler(p *deps.RequestProvider) http.Handler {
 	endpointsProvider := &EndpointsProvider{
 		OriginProvider: mainOriginProvider,
 	}
-	urlProvider := &webapp.URLProvider{
-		Endpoints: endpointsProvider,
-	}
-	endSessionHandler := &handler2.EndSessionHandler{
-		Config:    oAuthConfig,
-		Endpoints: endpointsProvider,
-		URLs:      urlProvider,
-	}
-	oauthEndSessionHandler := &oauth.EndSessionHandler{
-		Logger:            endSessionHandlerLogger,
-		Database:          handle,
-		EndSessionHandler: endSessionHandler,
-	}
-	return oauthEndSessionHandler
-}
-
-func newOAuthChallengeHandler(p *deps.RequestProvider) http.Handler {
-	appProvider := p.AppProvider
-	handle := appProvider.Redis
-	config := appProvider.Config
+	databaseCredentials := deps.ProvideDatabaseCredentials(secretConfig)
 	appConfig := config.AppConfig
 	appID := appConfig.ID
-	clockClock := _wireSystemClockValue
-	provider := &challenge.Provider{
-		Redis: handle,
-		AppID: appID,
-		Clock: clockClock,
-	}
-	factory := appProvider.LoggerFactory
-	jsonResponseWriterLogger := httputil.NewJSONResponseWriterLogger(factory)
-	jsonResponseWriter := &httputil.JSONResponseWriter{
-		Logger: jsonResponseWriterLogger,
-	}
-	challengeHandler := &oauth.ChallengeHandler{
-		Challenges: provider,
-		JSON:       jsonResponseWriter,
-	}
-	return challengeHandler
-}
-
-func newWebAppRootHandler(p *deps.RequestProvider) http.Handler {
-	rootHandler := &webapp2.RootHandler{}
-	return rootHandler
-}
-
-func newWebAppLoginHandler(p *deps.RequestProvider) http.Handler {
-	appProvider := p.AppProvider
-	rootProvider := appProvider.RootProvider
-	environmentConfig := rootProvider.EnvironmentConfig
-	trustProxy := environmentConfig.TrustProxy
-	handle := appProvider.Database
-	staticAssetURLPrefix := environmentConfig.StaticAssetURLPrefix
-	config := appProvider.Config
-	appConfig := config.AppConfig
-	uiConfig := appConfig.UI
-	request := p.Request
+	sqlBuilder := db.ProvideSQLBuilder(databaseCredentials, appID)
 	context := deps.ProvideRequestContext(request)
-	engine := appProvider.TemplateEngine
-	translationService := &translation.Service{
-		Context:           context,
-		EnvironmentConfig: environmentConfig,
-		TemplateEngine:    engine,
-	}
-	forgotPasswordConfig := appConfig.ForgotPassword
-	baseViewModeler := &viewmodels.BaseViewModeler{
-		StaticAssetURLPrefix: staticAssetURLPrefix,
-		AuthUI:               uiConfig,
-		Translation:          translationService,
-		ForgotPassword:       forgotPasswordConfig,
-	}
-	identityConfig := appConfig.Identity
-	loginIDConfig := identityConfig.LoginID
-	formPrefiller := &webapp2.FormPrefiller{
-		LoginID: loginIDConfig,
-		UI:      uiConfig,
-	}
-	factory := appProvider.LoggerFactory
-	responseRendererLogger := webapp2.NewResponseRendererLogger(factory)
-	responseRenderer := &webapp2.ResponseRenderer{
-		TemplateEngine: engine,
-		Logger:         responseRendererLogger,
-	}
-	serviceLogger := webapp.NewServiceLogger(factory)
-	appID := appConfig.ID
-	redisHandle := appProvider.Redis
-	redisStore := &webapp.RedisStore{
-		AppID: appID,
-		Redis: redisHandle,
-	}
-	logger := interaction.NewLogger(factory)
 	sqlExecutor := db.SQLExecutor{
 		Context:  context,
 		Database: handle,
 	}
-	clockClock := _wireSystemClockValue
+	store := &user.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
 	authenticationConfig := appConfig.Authentication
-	secretConfig := config.SecretConfig
-	databaseCredentials := deps.ProvideDatabaseCredentials(secretConfig)
-	sqlBuilder := db.ProvideSQLBuilder(databaseCredentials, appID)
-	store := &service.Store{
+	identityConfig := appConfig.Identity
+	serviceStore := &service.Store{
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
@@ -1453,6 +1731,7 @@ func newWebAppLoginHandler(p *deps.RequestProvider) http.Handler {
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
+	loginIDConfig := identityConfig.LoginID
 	reservedNameChecker := rootProvider.ReservedNameChecker
 	typeCheckerFactory := &loginid.TypeCheckerFactory{
 		Config:              loginIDConfig,
@@ -1465,6 +1744,7 @@ func newWebAppLoginHandler(p *deps.RequestProvider) http.Handler {
 	normalizerFactory := &loginid.NormalizerFactory{
 		Config: loginIDConfig,
 	}
+	clockClock := _wireSystemClockValue
 	provider := &loginid.Provider{
 		Store:             loginidStore,
 		Config:            loginIDConfig,
@@ -1491,12 +1771,12 @@ func newWebAppLoginHandler(p *deps.RequestProvider) http.Handler {
 	serviceService := &service.Service{
 		Authentication: authenticationConfig,
 		Identity:       identityConfig,
-		Store:          store,
+		Store:          serviceStore,
 		LoginID:        provider,
 		OAuth:          oauthProvider,
 		Anonymous:      anonymousProvider,
 	}
-	serviceStore := &service2.Store{
+	store2 := &service2.Store{
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
@@ -1506,7 +1786,7 @@ func newWebAppLoginHandler(p *deps.RequestProvider) http.Handler {
 	}
 	authenticatorConfig := appConfig.Authenticator
 	authenticatorPasswordConfig := authenticatorConfig.Password
-	passwordLogger := password.NewLogger(factory)
+	logger := password.NewLogger(factory)
 	historyStore := &password.HistoryStore{
 		Clock:       clockClock,
 		SQLBuilder:  sqlBuilder,
@@ -1518,10 +1798,11 @@ func newWebAppLoginHandler(p *deps.RequestProvider) http.Handler {
 		Store:           passwordStore,
 		Config:          authenticatorPasswordConfig,
 		Clock:           clockClock,
-		Logger:          passwordLogger,
+		Logger:          logger,
 		PasswordHistory: historyStore,
 		PasswordChecker: passwordChecker,
 		TaskQueue:       queue,
+		Database:        handle,
 	}
 	totpStore := &totp.Store{
 		SQLBuilder:  sqlBuilder,
@@ -1543,11 +1824,30 @@ func newWebAppLoginHandler(p *deps.RequestProvider) http.Handler {
 		Store:  oobStore,
 		Clock:  clockClock,
 	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	redisHandle := appProvider.Redis
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
 	service3 := &service2.Service{
-		Store:    serviceStore,
+		Store:    store2,
 		Password: passwordProvider,
 		TOTP:     totpProvider,
 		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
 	}
 	verificationLogger := verification.NewLogger(factory)
 	verificationConfig := appConfig.Verification
@@ -1576,23 +1876,2234 @@ func newWebAppLoginHandler(p *deps.RequestProvider) http.Handler {
 	identityFacade := facade.IdentityFacade{
 		Coordinator: coordinator,
 	}
-	authenticatorFacade := facade.AuthenticatorFacade{
-		Coordinator: coordinator,
-	}
-	mainOriginProvider := &MainOriginProvider{
-		Request:    request,
-		TrustProxy: trustProxy,
+	queries := &user.Queries{
+		Store:        store,
+		Identities:   identityFacade,
+		Verification: verificationService,
+		Clock:        clockClock,
 	}
-	endpointsProvider := &EndpointsProvider{
-		OriginProvider: mainOriginProvider,
+	userProfileConfig := appConfig.UserProfile
+	idTokenIssuer := &oidc.IDTokenIssuer{
+		Secrets:           oidcKeyMaterials,
+		Endpoints:         endpointsProvider,
+		Users:             queries,
+		Identities:        serviceService,
+		Verification:      verificationService,
+		UserProfileConfig: userProfileConfig,
+		Clock:             clockClock,
 	}
-	messageSender := &otp.MessageSender{
-		StaticAssetURLPrefix: staticAssetURLPrefix,
-		Translation:          translationService,
-		Endpoints:            endpointsProvider,
-		TaskQueue:            queue,
+	userInfoHandler := &oauth.UserInfoHandler{
+		Logger:           userInfoHandlerLogger,
+		Database:         handle,
+		UserInfoProvider: idTokenIssuer,
 	}
-	codeSender := &oob.CodeSender{
+	return userInfoHandler
+}
+
+func newOAuthEndSessionHandler(p *deps.RequestProvider) http.Handler {
+	appProvider := p.AppProvider
+	factory := appProvider.LoggerFactory
+	endSessionHandlerLogger := oauth.NewEndSessionHandlerLogger(factory)
+	handle := appProvider.Database
+	config := appProvider.Config
+	appConfig := config.AppConfig
+	oAuthConfig := appConfig.OAuth
+	request := p.Request
+	rootProvider := appProvider.RootProvider
+	environmentConfig := rootProvider.EnvironmentConfig
+	trustProxy := environmentConfig.TrustProxy
+	mainOriginProvider := &MainOriginProvider{
+		Request:    request,
+		TrustProxy: trustProxy,
+	}
+	endpointsProvider := &EndpointsProvider{
+		OriginProvider: mainOriginProvider,
+	}
+	urlProvider := &webapp.URLProvider{
+		Endpoints: endpointsProvider,
+	}
+	endSessionHandler := &handler2.EndSessionHandler{
+		Config:    oAuthConfig,
+		Endpoints: endpointsProvider,
+		URLs:      urlProvider,
+	}
+	oauthEndSessionHandler := &oauth.EndSessionHandler{
+		Logger:            endSessionHandlerLogger,
+		Database:          handle,
+		EndSessionHandler: endSessionHandler,
+	}
+	return oauthEndSessionHandler
+}
+
+func newOAuthChallengeHandler(p *deps.RequestProvider) http.Handler {
+	appProvider := p.AppProvider
+	handle := appProvider.Redis
+	config := appProvider.Config
+	appConfig := config.AppConfig
+	appID := appConfig.ID
+	clockClock := _wireSystemClockValue
+	provider := &challenge.Provider{
+		Redis: handle,
+		AppID: appID,
+		Clock: clockClock,
+	}
+	factory := appProvider.LoggerFactory
+	jsonResponseWriterLogger := httputil.NewJSONResponseWriterLogger(factory)
+	jsonResponseWriter := &httputil.JSONResponseWriter{
+		Logger: jsonResponseWriterLogger,
+	}
+	challengeHandler := &oauth.ChallengeHandler{
+		Challenges: provider,
+		JSON:       jsonResponseWriter,
+	}
+	return challengeHandler
+}
+
+func newHeadlessInteractionHandler(p *deps.RequestProvider) http.Handler {
+	appProvider := p.AppProvider
+	factory := appProvider.LoggerFactory
+	interactionHandlerLogger := headless.NewInteractionHandlerLogger(factory)
+	handle := appProvider.Database
+	config := appProvider.Config
+	appConfig := config.AppConfig
+	oAuthConfig := appConfig.OAuth
+	logger := interaction.NewLogger(factory)
+	request := p.Request
+	context := deps.ProvideRequestContext(request)
+	sqlExecutor := db.SQLExecutor{
+		Context:  context,
+		Database: handle,
+	}
+	clockClock := _wireSystemClockValue
+	rootProvider := appProvider.RootProvider
+	environmentConfig := rootProvider.EnvironmentConfig
+	trustProxy := environmentConfig.TrustProxy
+	remoteIP := deps.ProvideRemoteIP(request, trustProxy)
+	authenticationConfig := appConfig.Authentication
+	identityConfig := appConfig.Identity
+	secretConfig := config.SecretConfig
+	databaseCredentials := deps.ProvideDatabaseCredentials(secretConfig)
+	appID := appConfig.ID
+	sqlBuilder := db.ProvideSQLBuilder(databaseCredentials, appID)
+	store := &service.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	loginidStore := &loginid.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	loginIDConfig := identityConfig.LoginID
+	reservedNameChecker := rootProvider.ReservedNameChecker
+	typeCheckerFactory := &loginid.TypeCheckerFactory{
+		Config:              loginIDConfig,
+		ReservedNameChecker: reservedNameChecker,
+	}
+	checker := &loginid.Checker{
+		Config:             loginIDConfig,
+		TypeCheckerFactory: typeCheckerFactory,
+	}
+	normalizerFactory := &loginid.NormalizerFactory{
+		Config: loginIDConfig,
+	}
+	provider := &loginid.Provider{
+		Store:             loginidStore,
+		Config:            loginIDConfig,
+		Checker:           checker,
+		NormalizerFactory: normalizerFactory,
+		Clock:             clockClock,
+	}
+	oauthStore := &oauth3.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	oauthProvider := &oauth3.Provider{
+		Store: oauthStore,
+		Clock: clockClock,
+	}
+	anonymousStore := &anonymous.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	anonymousProvider := &anonymous.Provider{
+		Store: anonymousStore,
+		Clock: clockClock,
+	}
+	serviceService := &service.Service{
+		Authentication: authenticationConfig,
+		Identity:       identityConfig,
+		Store:          store,
+		LoginID:        provider,
+		OAuth:          oauthProvider,
+		Anonymous:      anonymousProvider,
+	}
+	serviceStore := &service2.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	passwordStore := &password.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	authenticatorConfig := appConfig.Authenticator
+	authenticatorPasswordConfig := authenticatorConfig.Password
+	passwordLogger := password.NewLogger(factory)
+	historyStore := &password.HistoryStore{
+		Clock:       clockClock,
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	passwordChecker := password.ProvideChecker(authenticatorPasswordConfig, historyStore)
+	queue := appProvider.TaskQueue
+	passwordProvider := &password.Provider{
+		Store:           passwordStore,
+		Config:          authenticatorPasswordConfig,
+		Clock:           clockClock,
+		Logger:          passwordLogger,
+		PasswordHistory: historyStore,
+		PasswordChecker: passwordChecker,
+		TaskQueue:       queue,
+		Database:        handle,
+	}
+	totpStore := &totp.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	authenticatorTOTPConfig := authenticatorConfig.TOTP
+	totpProvider := &totp.Provider{
+		Store:  totpStore,
+		Config: authenticatorTOTPConfig,
+		Clock:  clockClock,
+	}
+	authenticatorOOBConfig := authenticatorConfig.OOB
+	oobStore := &oob.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	oobProvider := &oob.Provider{
+		Config: authenticatorOOBConfig,
+		Store:  oobStore,
+		Clock:  clockClock,
+	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	redisHandle := appProvider.Redis
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
+	service3 := &service2.Service{
+		Store:    serviceStore,
+		Password: passwordProvider,
+		TOTP:     totpProvider,
+		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
+	}
+	verificationLogger := verification.NewLogger(factory)
+	verificationConfig := appConfig.Verification
+	storeRedis := &verification.StoreRedis{
+		Redis: redisHandle,
+		AppID: appID,
+		Clock: clockClock,
+	}
+	storePQ := &verification.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	verificationService := &verification.Service{
+		Logger:     verificationLogger,
+		Config:     verificationConfig,
+		Clock:      clockClock,
+		CodeStore:  storeRedis,
+		ClaimStore: storePQ,
+	}
+	coordinator := &facade.Coordinator{
+		Identities:     serviceService,
+		Authenticators: service3,
+		Verification:   verificationService,
+		IdentityConfig: identityConfig,
+	}
+	identityFacade := facade.IdentityFacade{
+		Coordinator: coordinator,
+	}
+	authenticatorFacade := facade.AuthenticatorFacade{
+		Coordinator: coordinator,
+	}
+	staticAssetURLPrefix := environmentConfig.StaticAssetURLPrefix
+	rateLimitConfig := appConfig.RateLimit
+	engine := appProvider.TemplateEngine
+	translationService := &translation.Service{
+		Context:           context,
+		EnvironmentConfig: environmentConfig,
+		TemplateEngine:    engine,
+	}
+	mainOriginProvider := &MainOriginProvider{
+		Request:    request,
+		TrustProxy: trustProxy,
+	}
+	endpointsProvider := &EndpointsProvider{
+		OriginProvider: mainOriginProvider,
+	}
+	ratelimitLogger := ratelimit.NewLogger(factory)
+	storageRedis := &ratelimit.StorageRedis{
+		AppID: appID,
+		Redis: redisHandle,
+		Clock: clockClock,
+	}
+	limiter := &ratelimit.Limiter{
+		Logger:  ratelimitLogger,
+		Storage: storageRedis,
+	}
+	messageSender := &otp.MessageSender{
+		StaticAssetURLPrefix: staticAssetURLPrefix,
+		RateLimitConfig:      rateLimitConfig,
+		Translation:          translationService,
+		Endpoints:            endpointsProvider,
+		TaskQueue:            queue,
+		RateLimiter:          limiter,
+	}
+	codeSender := &oob.CodeSender{
+		OTPMessageSender: messageSender,
+	}
+	oAuthClientCredentials := deps.ProvideOAuthClientCredentials(secretConfig)
+	urlProvider := &webapp.URLProvider{
+		Endpoints: endpointsProvider,
+	}
+	userInfoDecoder := sso.UserInfoDecoder{
+		LoginIDNormalizerFactory: normalizerFactory,
+	}
+	oidcCache := rootProvider.OIDCCache
+	oAuthProviderFactory := &sso.OAuthProviderFactory{
+		Endpoints:                endpointsProvider,
+		IdentityConfig:           identityConfig,
+		Credentials:              oAuthClientCredentials,
+		RedirectURL:              urlProvider,
+		Clock:                    clockClock,
+		UserInfoDecoder:          userInfoDecoder,
+		LoginIDNormalizerFactory: normalizerFactory,
+		OIDCCache:                oidcCache,
+	}
+	storeDeviceTokenRedis := &mfa.StoreDeviceTokenRedis{
+		Redis: redisHandle,
+		AppID: appID,
+		Clock: clockClock,
+	}
+	storeRecoveryCodePQ := &mfa.StoreRecoveryCodePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	mfaService := &mfa.Service{
+		DeviceTokens:  storeDeviceTokenRedis,
+		RecoveryCodes: storeRecoveryCodePQ,
+		Clock:         clockClock,
+		Config:        authenticationConfig,
+	}
+	forgotPasswordConfig := appConfig.ForgotPassword
+	forgotpasswordStore := &forgotpassword.Store{
+		Redis: redisHandle,
+	}
+	providerLogger := forgotpassword.NewProviderLogger(factory)
+	forgotpasswordProvider := &forgotpassword.Provider{
+		StaticAssetURLPrefix: staticAssetURLPrefix,
+		Translation:          translationService,
+		Config:               forgotPasswordConfig,
+		RateLimitConfig:      rateLimitConfig,
+		Store:                forgotpasswordStore,
+		Clock:                clockClock,
+		URLs:                 urlProvider,
+		TaskQueue:            queue,
+		RateLimiter:          limiter,
+		Logger:               providerLogger,
+		Identities:           identityFacade,
+		Authenticators:       authenticatorFacade,
+	}
+	verificationCodeSender := &verification.CodeSender{
+		OTPMessageSender: messageSender,
+		WebAppURLs:       urlProvider,
+	}
+	challengeProvider := &challenge.Provider{
+		Redis: redisHandle,
+		AppID: appID,
+		Clock: clockClock,
+	}
+	userStore := &user.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	welcomeMessageConfig := appConfig.WelcomeMessage
+	welcomemessageProvider := &welcomemessage.Provider{
+		Translation:          translationService,
+		WelcomeMessageConfig: welcomeMessageConfig,
+		TaskQueue:            queue,
+	}
+	queries := &user.Queries{
+		Store:        userStore,
+		Identities:   identityFacade,
+		Verification: verificationService,
+		Clock:        clockClock,
+	}
+	rawCommands := &user.RawCommands{
+		Store:                  userStore,
+		Clock:                  clockClock,
+		WelcomeMessageProvider: welcomemessageProvider,
+		Queries:                queries,
+	}
+	hookLogger := hook.NewLogger(factory)
+	rawProvider := &user.RawProvider{
+		RawCommands: rawCommands,
+		Queries:     queries,
+	}
+	hookStore := &hook.Store{
+		Clock:       clockClock,
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	hookConfig := appConfig.Hook
+	webhookKeyMaterials := deps.ProvideWebhookKeyMaterials(secretConfig)
+	syncHTTPClient := hook.NewSyncHTTPClient(hookConfig)
+	asyncHTTPClient := hook.NewAsyncHTTPClient()
+	deliverer := &hook.Deliverer{
+		Config:    hookConfig,
+		Secret:    webhookKeyMaterials,
+		Clock:     clockClock,
+		SyncHTTP:  syncHTTPClient,
+		AsyncHTTP: asyncHTTPClient,
+	}
+	hookProvider := &hook.Provider{
+		Context:   context,
+		Logger:    hookLogger,
+		Database:  handle,
+		Clock:     clockClock,
+		Users:     rawProvider,
+		Store:     hookStore,
+		Deliverer: deliverer,
+		TaskQueue: queue,
+	}
+	userProfileConfig := appConfig.UserProfile
+	commands := &user.Commands{
+		Raw:               rawCommands,
+		Hooks:             hookProvider,
+		Verification:      verificationService,
+		UserProfileConfig: userProfileConfig,
+	}
+	userProvider := &user.Provider{
+		Commands: commands,
+		Queries:  queries,
+	}
+	authenticationLockoutConfig := authenticationConfig.Lockout
+	lockoutStorePQ := &lockout.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	lockoutService := &lockout.Service{
+		Config:   authenticationLockoutConfig,
+		Clock:    clockClock,
+		Store:    lockoutStorePQ,
+		Database: handle,
+		Users:    rawProvider,
+		Hooks:    hookProvider,
+	}
+	auditStorePQ := &audit.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	auditService := &audit.Service{
+		Context:  context,
+		Clock:    clockClock,
+		Store:    auditStorePQ,
+		Database: handle,
+	}
+	cookieFactory := deps.NewCookieFactory(request, trustProxy)
+	storeRedisLogger := idpsession.NewStoreRedisLogger(factory)
+	idpsessionStoreRedis := &idpsession.StoreRedis{
+		Redis:  redisHandle,
+		AppID:  appID,
+		Clock:  clockClock,
+		Logger: storeRedisLogger,
+	}
+	eventStoreRedis := &access.EventStoreRedis{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	eventProvider := &access.EventProvider{
+		Store: eventStoreRedis,
+	}
+	sessionConfig := appConfig.Session
+	idpsessionRand := _wireRandValue
+	idpsessionProvider := &idpsession.Provider{
+		Request:      request,
+		Store:        idpsessionStoreRedis,
+		AccessEvents: eventProvider,
+		TrustProxy:   trustProxy,
+		Config:       sessionConfig,
+		Clock:        clockClock,
+		Random:       idpsessionRand,
+	}
+	cookieDef := idpsession.NewSessionCookieDef(httpConfig, sessionConfig)
+	mfaCookieDef := mfa.NewDeviceTokenCookieDef(httpConfig, authenticationConfig)
+	interactionContext := &interaction.Context{
+		Database:                 sqlExecutor,
+		Clock:                    clockClock,
+		Config:                   appConfig,
+		RemoteIP:                 remoteIP,
+		Identities:               identityFacade,
+		Authenticators:           authenticatorFacade,
+		AnonymousIdentities:      anonymousProvider,
+		OOBAuthenticators:        oobProvider,
+		OOBCodeSender:            codeSender,
+		WebAuthnAuthenticators:   webauthnProvider,
+		OAuthProviderFactory:     oAuthProviderFactory,
+		MFA:                      mfaService,
+		ForgotPassword:           forgotpasswordProvider,
+		ResetPassword:            forgotpasswordProvider,
+		LoginIDNormalizerFactory: normalizerFactory,
+		Verification:             verificationService,
+		VerificationCodeSender:   verificationCodeSender,
+		Challenges:               challengeProvider,
+		Users:                    userProvider,
+		Hooks:                    hookProvider,
+		RateLimiter:              limiter,
+		Lockout:                  lockoutService,
+		Audit:                    auditService,
+		CookieFactory:            cookieFactory,
+		Sessions:                 idpsessionProvider,
+		SessionCookie:            cookieDef,
+		MFADeviceTokenCookie:     mfaCookieDef,
+	}
+	interactionStoreRedis := &interaction.StoreRedis{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	interactionService := &interaction.Service{
+		Logger:  logger,
+		Context: interactionContext,
+		Store:   interactionStoreRedis,
+	}
+	tokenHandlerLogger := handler.NewTokenHandlerLogger(factory)
+	authorizationStore := &pq.AuthorizationStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	redisLogger := redis.NewLogger(factory)
+	grantStore := &redis.GrantStore{
+		Redis:       redisHandle,
+		AppID:       appID,
+		Logger:      redisLogger,
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+		Clock:       clockClock,
+	}
+	oidcKeyMaterials := deps.ProvideOIDCKeyMaterials(secretConfig)
+	idTokenIssuer := &oidc.IDTokenIssuer{
+		Secrets:           oidcKeyMaterials,
+		Endpoints:         endpointsProvider,
+		Users:             queries,
+		Identities:        serviceService,
+		Verification:      verificationService,
+		UserProfileConfig: userProfileConfig,
+		Clock:             clockClock,
+	}
+	tokenGenerator := _wireTokenGeneratorValue
+	accessTokenEncoding := &oidc.AccessTokenEncoding{
+		Secrets:   oidcKeyMaterials,
+		Endpoints: endpointsProvider,
+	}
+	oAuthClientSecrets := deps.ProvideOAuthClientSecrets(secretConfig)
+	clientAuthenticator := &handler.ClientAuthenticator{
+		Request:          request,
+		Config:           oAuthConfig,
+		Secrets:          oAuthClientSecrets,
+		Endpoints:        endpointsProvider,
+		ClientAssertions: grantStore,
+		Clock:            clockClock,
+	}
+	tokenHandler := &handler.TokenHandler{
+		Request:             request,
+		AppID:               appID,
+		Config:              oAuthConfig,
+		RateLimitConfig:     rateLimitConfig,
+		TrustProxy:          trustProxy,
+		Logger:              tokenHandlerLogger,
+		Authorizations:      authorizationStore,
+		CodeGrants:          grantStore,
+		DeviceGrants:        grantStore,
+		OfflineGrants:       grantStore,
+		AccessGrants:        grantStore,
+		AccessEvents:        eventProvider,
+		Sessions:            idpsessionProvider,
+		Users:               queries,
+		Graphs:              interactionService,
+		IDTokenIssuer:       idTokenIssuer,
+		GenerateToken:       tokenGenerator,
+		RateLimiter:         limiter,
+		Clock:               clockClock,
+		AccessTokenEncoding: accessTokenEncoding,
+		ClientAuthenticator: clientAuthenticator,
+	}
+	jsonResponseWriterLogger := httputil.NewJSONResponseWriterLogger(factory)
+	jsonResponseWriter := &httputil.JSONResponseWriter{
+		Logger: jsonResponseWriterLogger,
+	}
+	interactionHandler := &headless.InteractionHandler{
+		Logger:      interactionHandlerLogger,
+		Database:    handle,
+		OAuthConfig: oAuthConfig,
+		Graphs:      interactionService,
+		Tokens:      tokenHandler,
+		JSON:        jsonResponseWriter,
+	}
+	return interactionHandler
+}
+
+func newWebAppRootHandler(p *deps.RequestProvider) http.Handler {
+	rootHandler := &webapp2.RootHandler{}
+	return rootHandler
+}
+
+func newWebAppLoginHandler(p *deps.RequestProvider) http.Handler {
+	appProvider := p.AppProvider
+	rootProvider := appProvider.RootProvider
+	environmentConfig := rootProvider.EnvironmentConfig
+	trustProxy := environmentConfig.TrustProxy
+	handle := appProvider.Database
+	staticAssetURLPrefix := environmentConfig.StaticAssetURLPrefix
+	config := appProvider.Config
+	appConfig := config.AppConfig
+	uiConfig := appConfig.UI
+	request := p.Request
+	context := deps.ProvideRequestContext(request)
+	engine := appProvider.TemplateEngine
+	translationService := &translation.Service{
+		Context:           context,
+		EnvironmentConfig: environmentConfig,
+		TemplateEngine:    engine,
+	}
+	forgotPasswordConfig := appConfig.ForgotPassword
+	baseViewModeler := &viewmodels.BaseViewModeler{
+		StaticAssetURLPrefix: staticAssetURLPrefix,
+		AuthUI:               uiConfig,
+		Translation:          translationService,
+		ForgotPassword:       forgotPasswordConfig,
+	}
+	identityConfig := appConfig.Identity
+	loginIDConfig := identityConfig.LoginID
+	formPrefiller := &webapp2.FormPrefiller{
+		LoginID: loginIDConfig,
+		UI:      uiConfig,
+	}
+	factory := appProvider.LoggerFactory
+	responseRendererLogger := webapp2.NewResponseRendererLogger(factory)
+	responseRenderer := &webapp2.ResponseRenderer{
+		TemplateEngine: engine,
+		Logger:         responseRendererLogger,
+	}
+	serviceLogger := webapp.NewServiceLogger(factory)
+	appID := appConfig.ID
+	redisHandle := appProvider.Redis
+	redisStore := &webapp.RedisStore{
+		AppID: appID,
+		Redis: redisHandle,
+	}
+	logger := interaction.NewLogger(factory)
+	sqlExecutor := db.SQLExecutor{
+		Context:  context,
+		Database: handle,
+	}
+	clockClock := _wireSystemClockValue
+	remoteIP := deps.ProvideRemoteIP(request, trustProxy)
+	authenticationConfig := appConfig.Authentication
+	secretConfig := config.SecretConfig
+	databaseCredentials := deps.ProvideDatabaseCredentials(secretConfig)
+	sqlBuilder := db.ProvideSQLBuilder(databaseCredentials, appID)
+	store := &service.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	loginidStore := &loginid.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	reservedNameChecker := rootProvider.ReservedNameChecker
+	typeCheckerFactory := &loginid.TypeCheckerFactory{
+		Config:              loginIDConfig,
+		ReservedNameChecker: reservedNameChecker,
+	}
+	checker := &loginid.Checker{
+		Config:             loginIDConfig,
+		TypeCheckerFactory: typeCheckerFactory,
+	}
+	normalizerFactory := &loginid.NormalizerFactory{
+		Config: loginIDConfig,
+	}
+	provider := &loginid.Provider{
+		Store:             loginidStore,
+		Config:            loginIDConfig,
+		Checker:           checker,
+		NormalizerFactory: normalizerFactory,
+		Clock:             clockClock,
+	}
+	oauthStore := &oauth3.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	oauthProvider := &oauth3.Provider{
+		Store: oauthStore,
+		Clock: clockClock,
+	}
+	anonymousStore := &anonymous.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	anonymousProvider := &anonymous.Provider{
+		Store: anonymousStore,
+		Clock: clockClock,
+	}
+	serviceService := &service.Service{
+		Authentication: authenticationConfig,
+		Identity:       identityConfig,
+		Store:          store,
+		LoginID:        provider,
+		OAuth:          oauthProvider,
+		Anonymous:      anonymousProvider,
+	}
+	serviceStore := &service2.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	passwordStore := &password.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	authenticatorConfig := appConfig.Authenticator
+	authenticatorPasswordConfig := authenticatorConfig.Password
+	passwordLogger := password.NewLogger(factory)
+	historyStore := &password.HistoryStore{
+		Clock:       clockClock,
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	passwordChecker := password.ProvideChecker(authenticatorPasswordConfig, historyStore)
+	queue := appProvider.TaskQueue
+	passwordProvider := &password.Provider{
+		Store:           passwordStore,
+		Config:          authenticatorPasswordConfig,
+		Clock:           clockClock,
+		Logger:          passwordLogger,
+		PasswordHistory: historyStore,
+		PasswordChecker: passwordChecker,
+		TaskQueue:       queue,
+		Database:        handle,
+	}
+	totpStore := &totp.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	authenticatorTOTPConfig := authenticatorConfig.TOTP
+	totpProvider := &totp.Provider{
+		Store:  totpStore,
+		Config: authenticatorTOTPConfig,
+		Clock:  clockClock,
+	}
+	authenticatorOOBConfig := authenticatorConfig.OOB
+	oobStore := &oob.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	oobProvider := &oob.Provider{
+		Config: authenticatorOOBConfig,
+		Store:  oobStore,
+		Clock:  clockClock,
+	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
+	service3 := &service2.Service{
+		Store:    serviceStore,
+		Password: passwordProvider,
+		TOTP:     totpProvider,
+		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
+	}
+	verificationLogger := verification.NewLogger(factory)
+	verificationConfig := appConfig.Verification
+	storeRedis := &verification.StoreRedis{
+		Redis: redisHandle,
+		AppID: appID,
+		Clock: clockClock,
+	}
+	storePQ := &verification.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	verificationService := &verification.Service{
+		Logger:     verificationLogger,
+		Config:     verificationConfig,
+		Clock:      clockClock,
+		CodeStore:  storeRedis,
+		ClaimStore: storePQ,
+	}
+	coordinator := &facade.Coordinator{
+		Identities:     serviceService,
+		Authenticators: service3,
+		Verification:   verificationService,
+		IdentityConfig: identityConfig,
+	}
+	identityFacade := facade.IdentityFacade{
+		Coordinator: coordinator,
+	}
+	authenticatorFacade := facade.AuthenticatorFacade{
+		Coordinator: coordinator,
+	}
+	rateLimitConfig := appConfig.RateLimit
+	mainOriginProvider := &MainOriginProvider{
+		Request:    request,
+		TrustProxy: trustProxy,
+	}
+	endpointsProvider := &EndpointsProvider{
+		OriginProvider: mainOriginProvider,
+	}
+	ratelimitLogger := ratelimit.NewLogger(factory)
+	storageRedis := &ratelimit.StorageRedis{
+		AppID: appID,
+		Redis: redisHandle,
+		Clock: clockClock,
+	}
+	limiter := &ratelimit.Limiter{
+		Logger:  ratelimitLogger,
+		Storage: storageRedis,
+	}
+	messageSender := &otp.MessageSender{
+		StaticAssetURLPrefix: staticAssetURLPrefix,
+		RateLimitConfig:      rateLimitConfig,
+		Translation:          translationService,
+		Endpoints:            endpointsProvider,
+		TaskQueue:            queue,
+		RateLimiter:          limiter,
+	}
+	codeSender := &oob.CodeSender{
+		OTPMessageSender: messageSender,
+	}
+	oAuthClientCredentials := deps.ProvideOAuthClientCredentials(secretConfig)
+	urlProvider := &webapp.URLProvider{
+		Endpoints: endpointsProvider,
+	}
+	userInfoDecoder := sso.UserInfoDecoder{
+		LoginIDNormalizerFactory: normalizerFactory,
+	}
+	oidcCache := rootProvider.OIDCCache
+	oAuthProviderFactory := &sso.OAuthProviderFactory{
+		Endpoints:                endpointsProvider,
+		IdentityConfig:           identityConfig,
+		Credentials:              oAuthClientCredentials,
+		RedirectURL:              urlProvider,
+		Clock:                    clockClock,
+		UserInfoDecoder:          userInfoDecoder,
+		LoginIDNormalizerFactory: normalizerFactory,
+		OIDCCache:                oidcCache,
+	}
+	storeDeviceTokenRedis := &mfa.StoreDeviceTokenRedis{
+		Redis: redisHandle,
+		AppID: appID,
+		Clock: clockClock,
+	}
+	storeRecoveryCodePQ := &mfa.StoreRecoveryCodePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	mfaService := &mfa.Service{
+		DeviceTokens:  storeDeviceTokenRedis,
+		RecoveryCodes: storeRecoveryCodePQ,
+		Clock:         clockClock,
+		Config:        authenticationConfig,
+	}
+	forgotpasswordStore := &forgotpassword.Store{
+		Redis: redisHandle,
+	}
+	providerLogger := forgotpassword.NewProviderLogger(factory)
+	forgotpasswordProvider := &forgotpassword.Provider{
+		StaticAssetURLPrefix: staticAssetURLPrefix,
+		Translation:          translationService,
+		Config:               forgotPasswordConfig,
+		RateLimitConfig:      rateLimitConfig,
+		Store:                forgotpasswordStore,
+		Clock:                clockClock,
+		URLs:                 urlProvider,
+		TaskQueue:            queue,
+		RateLimiter:          limiter,
+		Logger:               providerLogger,
+		Identities:           identityFacade,
+		Authenticators:       authenticatorFacade,
+	}
+	verificationCodeSender := &verification.CodeSender{
+		OTPMessageSender: messageSender,
+		WebAppURLs:       urlProvider,
+	}
+	challengeProvider := &challenge.Provider{
+		Redis: redisHandle,
+		AppID: appID,
+		Clock: clockClock,
+	}
+	userStore := &user.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	welcomeMessageConfig := appConfig.WelcomeMessage
+	welcomemessageProvider := &welcomemessage.Provider{
+		Translation:          translationService,
+		WelcomeMessageConfig: welcomeMessageConfig,
+		TaskQueue:            queue,
+	}
+	queries := &user.Queries{
+		Store:        userStore,
+		Identities:   identityFacade,
+		Verification: verificationService,
+		Clock:        clockClock,
+	}
+	rawCommands := &user.RawCommands{
+		Store:                  userStore,
+		Clock:                  clockClock,
+		WelcomeMessageProvider: welcomemessageProvider,
+		Queries:                queries,
+	}
+	hookLogger := hook.NewLogger(factory)
+	rawProvider := &user.RawProvider{
+		RawCommands: rawCommands,
+		Queries:     queries,
+	}
+	hookStore := &hook.Store{
+		Clock:       clockClock,
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	hookConfig := appConfig.Hook
+	webhookKeyMaterials := deps.ProvideWebhookKeyMaterials(secretConfig)
+	syncHTTPClient := hook.NewSyncHTTPClient(hookConfig)
+	asyncHTTPClient := hook.NewAsyncHTTPClient()
+	deliverer := &hook.Deliverer{
+		Config:    hookConfig,
+		Secret:    webhookKeyMaterials,
+		Clock:     clockClock,
+		SyncHTTP:  syncHTTPClient,
+		AsyncHTTP: asyncHTTPClient,
+	}
+	hookProvider := &hook.Provider{
+		Context:   context,
+		Logger:    hookLogger,
+		Database:  handle,
+		Clock:     clockClock,
+		Users:     rawProvider,
+		Store:     hookStore,
+		Deliverer: deliverer,
+		TaskQueue: queue,
+	}
+	userProfileConfig := appConfig.UserProfile
+	commands := &user.Commands{
+		Raw:               rawCommands,
+		Hooks:             hookProvider,
+		Verification:      verificationService,
+		UserProfileConfig: userProfileConfig,
+	}
+	userProvider := &user.Provider{
+		Commands: commands,
+		Queries:  queries,
+	}
+	authenticationLockoutConfig := authenticationConfig.Lockout
+	lockoutStorePQ := &lockout.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	lockoutService := &lockout.Service{
+		Config:   authenticationLockoutConfig,
+		Clock:    clockClock,
+		Store:    lockoutStorePQ,
+		Database: handle,
+		Users:    rawProvider,
+		Hooks:    hookProvider,
+	}
+	auditStorePQ := &audit.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	auditService := &audit.Service{
+		Context:  context,
+		Clock:    clockClock,
+		Store:    auditStorePQ,
+		Database: handle,
+	}
+	cookieFactory := deps.NewCookieFactory(request, trustProxy)
+	storeRedisLogger := idpsession.NewStoreRedisLogger(factory)
+	idpsessionStoreRedis := &idpsession.StoreRedis{
+		Redis:  redisHandle,
+		AppID:  appID,
+		Clock:  clockClock,
+		Logger: storeRedisLogger,
+	}
+	eventStoreRedis := &access.EventStoreRedis{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	eventProvider := &access.EventProvider{
+		Store: eventStoreRedis,
+	}
+	sessionConfig := appConfig.Session
+	idpsessionRand := _wireRandValue
+	idpsessionProvider := &idpsession.Provider{
+		Request:      request,
+		Store:        idpsessionStoreRedis,
+		AccessEvents: eventProvider,
+		TrustProxy:   trustProxy,
+		Config:       sessionConfig,
+		Clock:        clockClock,
+		Random:       idpsessionRand,
+	}
+	cookieDef := idpsession.NewSessionCookieDef(httpConfig, sessionConfig)
+	mfaCookieDef := mfa.NewDeviceTokenCookieDef(httpConfig, authenticationConfig)
+	interactionContext := &interaction.Context{
+		Database:                 sqlExecutor,
+		Clock:                    clockClock,
+		Config:                   appConfig,
+		RemoteIP:                 remoteIP,
+		Identities:               identityFacade,
+		Authenticators:           authenticatorFacade,
+		AnonymousIdentities:      anonymousProvider,
+		OOBAuthenticators:        oobProvider,
+		OOBCodeSender:            codeSender,
+		WebAuthnAuthenticators:   webauthnProvider,
+		OAuthProviderFactory:     oAuthProviderFactory,
+		MFA:                      mfaService,
+		ForgotPassword:           forgotpasswordProvider,
+		ResetPassword:            forgotpasswordProvider,
+		LoginIDNormalizerFactory: normalizerFactory,
+		Verification:             verificationService,
+		VerificationCodeSender:   verificationCodeSender,
+		Challenges:               challengeProvider,
+		Users:                    userProvider,
+		Hooks:                    hookProvider,
+		RateLimiter:              limiter,
+		Lockout:                  lockoutService,
+		Audit:                    auditService,
+		CookieFactory:            cookieFactory,
+		Sessions:                 idpsessionProvider,
+		SessionCookie:            cookieDef,
+		MFADeviceTokenCookie:     mfaCookieDef,
+	}
+	interactionStoreRedis := &interaction.StoreRedis{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	interactionService := &interaction.Service{
+		Logger:  logger,
+		Context: interactionContext,
+		Store:   interactionStoreRedis,
+	}
+	webappCookieDef := webapp.NewUATokenCookieDef(httpConfig)
+	webappService := &webapp.Service{
+		Logger:        serviceLogger,
+		Request:       request,
+		Store:         redisStore,
+		Graph:         interactionService,
+		CookieFactory: cookieFactory,
+		UATokenCookie: webappCookieDef,
+	}
+	csrfCookieDef := webapp.NewCSRFCookieDef(httpConfig)
+	loginHandler := &webapp2.LoginHandler{
+		TrustProxy:    trustProxy,
+		Database:      handle,
+		BaseViewModel: baseViewModeler,
+		FormPrefiller: formPrefiller,
+		Renderer:      responseRenderer,
+		WebApp:        webappService,
+		CSRFCookie:    csrfCookieDef,
+	}
+	return loginHandler
+}
+
+func newWebAppSignupHandler(p *deps.RequestProvider) http.Handler {
+	appProvider := p.AppProvider
+	rootProvider := appProvider.RootProvider
+	environmentConfig := rootProvider.EnvironmentConfig
+	trustProxy := environmentConfig.TrustProxy
+	handle := appProvider.Database
+	staticAssetURLPrefix := environmentConfig.StaticAssetURLPrefix
+	config := appProvider.Config
+	appConfig := config.AppConfig
+	uiConfig := appConfig.UI
+	request := p.Request
+	context := deps.ProvideRequestContext(request)
+	engine := appProvider.TemplateEngine
+	translationService := &translation.Service{
+		Context:           context,
+		EnvironmentConfig: environmentConfig,
+		TemplateEngine:    engine,
+	}
+	forgotPasswordConfig := appConfig.ForgotPassword
+	baseViewModeler := &viewmodels.BaseViewModeler{
+		StaticAssetURLPrefix: staticAssetURLPrefix,
+		AuthUI:               uiConfig,
+		Translation:          translationService,
+		ForgotPassword:       forgotPasswordConfig,
+	}
+	identityConfig := appConfig.Identity
+	loginIDConfig := identityConfig.LoginID
+	formPrefiller := &webapp2.FormPrefiller{
+		LoginID: loginIDConfig,
+		UI:      uiConfig,
+	}
+	factory := appProvider.LoggerFactory
+	responseRendererLogger := webapp2.NewResponseRendererLogger(factory)
+	responseRenderer := &webapp2.ResponseRenderer{
+		TemplateEngine: engine,
+		Logger:         responseRendererLogger,
+	}
+	serviceLogger := webapp.NewServiceLogger(factory)
+	appID := appConfig.ID
+	redisHandle := appProvider.Redis
+	redisStore := &webapp.RedisStore{
+		AppID: appID,
+		Redis: redisHandle,
+	}
+	logger := interaction.NewLogger(factory)
+	sqlExecutor := db.SQLExecutor{
+		Context:  context,
+		Database: handle,
+	}
+	clockClock := _wireSystemClockValue
+	remoteIP := deps.ProvideRemoteIP(request, trustProxy)
+	authenticationConfig := appConfig.Authentication
+	secretConfig := config.SecretConfig
+	databaseCredentials := deps.ProvideDatabaseCredentials(secretConfig)
+	sqlBuilder := db.ProvideSQLBuilder(databaseCredentials, appID)
+	store := &service.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	loginidStore := &loginid.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	reservedNameChecker := rootProvider.ReservedNameChecker
+	typeCheckerFactory := &loginid.TypeCheckerFactory{
+		Config:              loginIDConfig,
+		ReservedNameChecker: reservedNameChecker,
+	}
+	checker := &loginid.Checker{
+		Config:             loginIDConfig,
+		TypeCheckerFactory: typeCheckerFactory,
+	}
+	normalizerFactory := &loginid.NormalizerFactory{
+		Config: loginIDConfig,
+	}
+	provider := &loginid.Provider{
+		Store:             loginidStore,
+		Config:            loginIDConfig,
+		Checker:           checker,
+		NormalizerFactory: normalizerFactory,
+		Clock:             clockClock,
+	}
+	oauthStore := &oauth3.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	oauthProvider := &oauth3.Provider{
+		Store: oauthStore,
+		Clock: clockClock,
+	}
+	anonymousStore := &anonymous.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	anonymousProvider := &anonymous.Provider{
+		Store: anonymousStore,
+		Clock: clockClock,
+	}
+	serviceService := &service.Service{
+		Authentication: authenticationConfig,
+		Identity:       identityConfig,
+		Store:          store,
+		LoginID:        provider,
+		OAuth:          oauthProvider,
+		Anonymous:      anonymousProvider,
+	}
+	serviceStore := &service2.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	passwordStore := &password.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	authenticatorConfig := appConfig.Authenticator
+	authenticatorPasswordConfig := authenticatorConfig.Password
+	passwordLogger := password.NewLogger(factory)
+	historyStore := &password.HistoryStore{
+		Clock:       clockClock,
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	passwordChecker := password.ProvideChecker(authenticatorPasswordConfig, historyStore)
+	queue := appProvider.TaskQueue
+	passwordProvider := &password.Provider{
+		Store:           passwordStore,
+		Config:          authenticatorPasswordConfig,
+		Clock:           clockClock,
+		Logger:          passwordLogger,
+		PasswordHistory: historyStore,
+		PasswordChecker: passwordChecker,
+		TaskQueue:       queue,
+		Database:        handle,
+	}
+	totpStore := &totp.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	authenticatorTOTPConfig := authenticatorConfig.TOTP
+	totpProvider := &totp.Provider{
+		Store:  totpStore,
+		Config: authenticatorTOTPConfig,
+		Clock:  clockClock,
+	}
+	authenticatorOOBConfig := authenticatorConfig.OOB
+	oobStore := &oob.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	oobProvider := &oob.Provider{
+		Config: authenticatorOOBConfig,
+		Store:  oobStore,
+		Clock:  clockClock,
+	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
+	service3 := &service2.Service{
+		Store:    serviceStore,
+		Password: passwordProvider,
+		TOTP:     totpProvider,
+		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
+	}
+	verificationLogger := verification.NewLogger(factory)
+	verificationConfig := appConfig.Verification
+	storeRedis := &verification.StoreRedis{
+		Redis: redisHandle,
+		AppID: appID,
+		Clock: clockClock,
+	}
+	storePQ := &verification.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	verificationService := &verification.Service{
+		Logger:     verificationLogger,
+		Config:     verificationConfig,
+		Clock:      clockClock,
+		CodeStore:  storeRedis,
+		ClaimStore: storePQ,
+	}
+	coordinator := &facade.Coordinator{
+		Identities:     serviceService,
+		Authenticators: service3,
+		Verification:   verificationService,
+		IdentityConfig: identityConfig,
+	}
+	identityFacade := facade.IdentityFacade{
+		Coordinator: coordinator,
+	}
+	authenticatorFacade := facade.AuthenticatorFacade{
+		Coordinator: coordinator,
+	}
+	rateLimitConfig := appConfig.RateLimit
+	mainOriginProvider := &MainOriginProvider{
+		Request:    request,
+		TrustProxy: trustProxy,
+	}
+	endpointsProvider := &EndpointsProvider{
+		OriginProvider: mainOriginProvider,
+	}
+	ratelimitLogger := ratelimit.NewLogger(factory)
+	storageRedis := &ratelimit.StorageRedis{
+		AppID: appID,
+		Redis: redisHandle,
+		Clock: clockClock,
+	}
+	limiter := &ratelimit.Limiter{
+		Logger:  ratelimitLogger,
+		Storage: storageRedis,
+	}
+	messageSender := &otp.MessageSender{
+		StaticAssetURLPrefix: staticAssetURLPrefix,
+		RateLimitConfig:      rateLimitConfig,
+		Translation:          translationService,
+		Endpoints:            endpointsProvider,
+		TaskQueue:            queue,
+		RateLimiter:          limiter,
+	}
+	codeSender := &oob.CodeSender{
+		OTPMessageSender: messageSender,
+	}
+	oAuthClientCredentials := deps.ProvideOAuthClientCredentials(secretConfig)
+	urlProvider := &webapp.URLProvider{
+		Endpoints: endpointsProvider,
+	}
+	userInfoDecoder := sso.UserInfoDecoder{
+		LoginIDNormalizerFactory: normalizerFactory,
+	}
+	oidcCache := rootProvider.OIDCCache
+	oAuthProviderFactory := &sso.OAuthProviderFactory{
+		Endpoints:                endpointsProvider,
+		IdentityConfig:           identityConfig,
+		Credentials:              oAuthClientCredentials,
+		RedirectURL:              urlProvider,
+		Clock:                    clockClock,
+		UserInfoDecoder:          userInfoDecoder,
+		LoginIDNormalizerFactory: normalizerFactory,
+		OIDCCache:                oidcCache,
+	}
+	storeDeviceTokenRedis := &mfa.StoreDeviceTokenRedis{
+		Redis: redisHandle,
+		AppID: appID,
+		Clock: clockClock,
+	}
+	storeRecoveryCodePQ := &mfa.StoreRecoveryCodePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	mfaService := &mfa.Service{
+		DeviceTokens:  storeDeviceTokenRedis,
+		RecoveryCodes: storeRecoveryCodePQ,
+		Clock:         clockClock,
+		Config:        authenticationConfig,
+	}
+	forgotpasswordStore := &forgotpassword.Store{
+		Redis: redisHandle,
+	}
+	providerLogger := forgotpassword.NewProviderLogger(factory)
+	forgotpasswordProvider := &forgotpassword.Provider{
+		StaticAssetURLPrefix: staticAssetURLPrefix,
+		Translation:          translationService,
+		Config:               forgotPasswordConfig,
+		RateLimitConfig:      rateLimitConfig,
+		Store:                forgotpasswordStore,
+		Clock:                clockClock,
+		URLs:                 urlProvider,
+		TaskQueue:            queue,
+		RateLimiter:          limiter,
+		Logger:               providerLogger,
+		Identities:           identityFacade,
+		Authenticators:       authenticatorFacade,
+	}
+	verificationCodeSender := &verification.CodeSender{
+		OTPMessageSender: messageSender,
+		WebAppURLs:       urlProvider,
+	}
+	challengeProvider := &challenge.Provider{
+		Redis: redisHandle,
+		AppID: appID,
+		Clock: clockClock,
+	}
+	userStore := &user.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	welcomeMessageConfig := appConfig.WelcomeMessage
+	welcomemessageProvider := &welcomemessage.Provider{
+		Translation:          translationService,
+		WelcomeMessageConfig: welcomeMessageConfig,
+		TaskQueue:            queue,
+	}
+	queries := &user.Queries{
+		Store:        userStore,
+		Identities:   identityFacade,
+		Verification: verificationService,
+		Clock:        clockClock,
+	}
+	rawCommands := &user.RawCommands{
+		Store:                  userStore,
+		Clock:                  clockClock,
+		WelcomeMessageProvider: welcomemessageProvider,
+		Queries:                queries,
+	}
+	hookLogger := hook.NewLogger(factory)
+	rawProvider := &user.RawProvider{
+		RawCommands: rawCommands,
+		Queries:     queries,
+	}
+	hookStore := &hook.Store{
+		Clock:       clockClock,
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	hookConfig := appConfig.Hook
+	webhookKeyMaterials := deps.ProvideWebhookKeyMaterials(secretConfig)
+	syncHTTPClient := hook.NewSyncHTTPClient(hookConfig)
+	asyncHTTPClient := hook.NewAsyncHTTPClient()
+	deliverer := &hook.Deliverer{
+		Config:    hookConfig,
+		Secret:    webhookKeyMaterials,
+		Clock:     clockClock,
+		SyncHTTP:  syncHTTPClient,
+		AsyncHTTP: asyncHTTPClient,
+	}
+	hookProvider := &hook.Provider{
+		Context:   context,
+		Logger:    hookLogger,
+		Database:  handle,
+		Clock:     clockClock,
+		Users:     rawProvider,
+		Store:     hookStore,
+		Deliverer: deliverer,
+		TaskQueue: queue,
+	}
+	userProfileConfig := appConfig.UserProfile
+	commands := &user.Commands{
+		Raw:               rawCommands,
+		Hooks:             hookProvider,
+		Verification:      verificationService,
+		UserProfileConfig: userProfileConfig,
+	}
+	userProvider := &user.Provider{
+		Commands: commands,
+		Queries:  queries,
+	}
+	authenticationLockoutConfig := authenticationConfig.Lockout
+	lockoutStorePQ := &lockout.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	lockoutService := &lockout.Service{
+		Config:   authenticationLockoutConfig,
+		Clock:    clockClock,
+		Store:    lockoutStorePQ,
+		Database: handle,
+		Users:    rawProvider,
+		Hooks:    hookProvider,
+	}
+	auditStorePQ := &audit.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	auditService := &audit.Service{
+		Context:  context,
+		Clock:    clockClock,
+		Store:    auditStorePQ,
+		Database: handle,
+	}
+	cookieFactory := deps.NewCookieFactory(request, trustProxy)
+	storeRedisLogger := idpsession.NewStoreRedisLogger(factory)
+	idpsessionStoreRedis := &idpsession.StoreRedis{
+		Redis:  redisHandle,
+		AppID:  appID,
+		Clock:  clockClock,
+		Logger: storeRedisLogger,
+	}
+	eventStoreRedis := &access.EventStoreRedis{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	eventProvider := &access.EventProvider{
+		Store: eventStoreRedis,
+	}
+	sessionConfig := appConfig.Session
+	idpsessionRand := _wireRandValue
+	idpsessionProvider := &idpsession.Provider{
+		Request:      request,
+		Store:        idpsessionStoreRedis,
+		AccessEvents: eventProvider,
+		TrustProxy:   trustProxy,
+		Config:       sessionConfig,
+		Clock:        clockClock,
+		Random:       idpsessionRand,
+	}
+	cookieDef := idpsession.NewSessionCookieDef(httpConfig, sessionConfig)
+	mfaCookieDef := mfa.NewDeviceTokenCookieDef(httpConfig, authenticationConfig)
+	interactionContext := &interaction.Context{
+		Database:                 sqlExecutor,
+		Clock:                    clockClock,
+		Config:                   appConfig,
+		RemoteIP:                 remoteIP,
+		Identities:               identityFacade,
+		Authenticators:           authenticatorFacade,
+		AnonymousIdentities:      anonymousProvider,
+		OOBAuthenticators:        oobProvider,
+		OOBCodeSender:            codeSender,
+		WebAuthnAuthenticators:   webauthnProvider,
+		OAuthProviderFactory:     oAuthProviderFactory,
+		MFA:                      mfaService,
+		ForgotPassword:           forgotpasswordProvider,
+		ResetPassword:            forgotpasswordProvider,
+		LoginIDNormalizerFactory: normalizerFactory,
+		Verification:             verificationService,
+		VerificationCodeSender:   verificationCodeSender,
+		Challenges:               challengeProvider,
+		Users:                    userProvider,
+		Hooks:                    hookProvider,
+		RateLimiter:              limiter,
+		Lockout:                  lockoutService,
+		Audit:                    auditService,
+		CookieFactory:            cookieFactory,
+		Sessions:                 idpsessionProvider,
+		SessionCookie:            cookieDef,
+		MFADeviceTokenCookie:     mfaCookieDef,
+	}
+	interactionStoreRedis := &interaction.StoreRedis{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	interactionService := &interaction.Service{
+		Logger:  logger,
+		Context: interactionContext,
+		Store:   interactionStoreRedis,
+	}
+	webappCookieDef := webapp.NewUATokenCookieDef(httpConfig)
+	webappService := &webapp.Service{
+		Logger:        serviceLogger,
+		Request:       request,
+		Store:         redisStore,
+		Graph:         interactionService,
+		CookieFactory: cookieFactory,
+		UATokenCookie: webappCookieDef,
+	}
+	csrfCookieDef := webapp.NewCSRFCookieDef(httpConfig)
+	signupHandler := &webapp2.SignupHandler{
+		TrustProxy:    trustProxy,
+		Database:      handle,
+		BaseViewModel: baseViewModeler,
+		FormPrefiller: formPrefiller,
+		Renderer:      responseRenderer,
+		WebApp:        webappService,
+		CSRFCookie:    csrfCookieDef,
+	}
+	return signupHandler
+}
+
+func newWebAppPromoteHandler(p *deps.RequestProvider) http.Handler {
+	appProvider := p.AppProvider
+	handle := appProvider.Database
+	rootProvider := appProvider.RootProvider
+	environmentConfig := rootProvider.EnvironmentConfig
+	staticAssetURLPrefix := environmentConfig.StaticAssetURLPrefix
+	config := appProvider.Config
+	appConfig := config.AppConfig
+	uiConfig := appConfig.UI
+	request := p.Request
+	context := deps.ProvideRequestContext(request)
+	engine := appProvider.TemplateEngine
+	translationService := &translation.Service{
+		Context:           context,
+		EnvironmentConfig: environmentConfig,
+		TemplateEngine:    engine,
+	}
+	forgotPasswordConfig := appConfig.ForgotPassword
+	baseViewModeler := &viewmodels.BaseViewModeler{
+		StaticAssetURLPrefix: staticAssetURLPrefix,
+		AuthUI:               uiConfig,
+		Translation:          translationService,
+		ForgotPassword:       forgotPasswordConfig,
+	}
+	identityConfig := appConfig.Identity
+	loginIDConfig := identityConfig.LoginID
+	formPrefiller := &webapp2.FormPrefiller{
+		LoginID: loginIDConfig,
+		UI:      uiConfig,
+	}
+	factory := appProvider.LoggerFactory
+	responseRendererLogger := webapp2.NewResponseRendererLogger(factory)
+	responseRenderer := &webapp2.ResponseRenderer{
+		TemplateEngine: engine,
+		Logger:         responseRendererLogger,
+	}
+	serviceLogger := webapp.NewServiceLogger(factory)
+	appID := appConfig.ID
+	redisHandle := appProvider.Redis
+	redisStore := &webapp.RedisStore{
+		AppID: appID,
+		Redis: redisHandle,
+	}
+	logger := interaction.NewLogger(factory)
+	sqlExecutor := db.SQLExecutor{
+		Context:  context,
+		Database: handle,
+	}
+	clockClock := _wireSystemClockValue
+	trustProxy := environmentConfig.TrustProxy
+	remoteIP := deps.ProvideRemoteIP(request, trustProxy)
+	authenticationConfig := appConfig.Authentication
+	secretConfig := config.SecretConfig
+	databaseCredentials := deps.ProvideDatabaseCredentials(secretConfig)
+	sqlBuilder := db.ProvideSQLBuilder(databaseCredentials, appID)
+	store := &service.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	loginidStore := &loginid.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	reservedNameChecker := rootProvider.ReservedNameChecker
+	typeCheckerFactory := &loginid.TypeCheckerFactory{
+		Config:              loginIDConfig,
+		ReservedNameChecker: reservedNameChecker,
+	}
+	checker := &loginid.Checker{
+		Config:             loginIDConfig,
+		TypeCheckerFactory: typeCheckerFactory,
+	}
+	normalizerFactory := &loginid.NormalizerFactory{
+		Config: loginIDConfig,
+	}
+	provider := &loginid.Provider{
+		Store:             loginidStore,
+		Config:            loginIDConfig,
+		Checker:           checker,
+		NormalizerFactory: normalizerFactory,
+		Clock:             clockClock,
+	}
+	oauthStore := &oauth3.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	oauthProvider := &oauth3.Provider{
+		Store: oauthStore,
+		Clock: clockClock,
+	}
+	anonymousStore := &anonymous.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	anonymousProvider := &anonymous.Provider{
+		Store: anonymousStore,
+		Clock: clockClock,
+	}
+	serviceService := &service.Service{
+		Authentication: authenticationConfig,
+		Identity:       identityConfig,
+		Store:          store,
+		LoginID:        provider,
+		OAuth:          oauthProvider,
+		Anonymous:      anonymousProvider,
+	}
+	serviceStore := &service2.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	passwordStore := &password.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	authenticatorConfig := appConfig.Authenticator
+	authenticatorPasswordConfig := authenticatorConfig.Password
+	passwordLogger := password.NewLogger(factory)
+	historyStore := &password.HistoryStore{
+		Clock:       clockClock,
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	passwordChecker := password.ProvideChecker(authenticatorPasswordConfig, historyStore)
+	queue := appProvider.TaskQueue
+	passwordProvider := &password.Provider{
+		Store:           passwordStore,
+		Config:          authenticatorPasswordConfig,
+		Clock:           clockClock,
+		Logger:          passwordLogger,
+		PasswordHistory: historyStore,
+		PasswordChecker: passwordChecker,
+		TaskQueue:       queue,
+		Database:        handle,
+	}
+	totpStore := &totp.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	authenticatorTOTPConfig := authenticatorConfig.TOTP
+	totpProvider := &totp.Provider{
+		Store:  totpStore,
+		Config: authenticatorTOTPConfig,
+		Clock:  clockClock,
+	}
+	authenticatorOOBConfig := authenticatorConfig.OOB
+	oobStore := &oob.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	oobProvider := &oob.Provider{
+		Config: authenticatorOOBConfig,
+		Store:  oobStore,
+		Clock:  clockClock,
+	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
+	service3 := &service2.Service{
+		Store:    serviceStore,
+		Password: passwordProvider,
+		TOTP:     totpProvider,
+		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
+	}
+	verificationLogger := verification.NewLogger(factory)
+	verificationConfig := appConfig.Verification
+	storeRedis := &verification.StoreRedis{
+		Redis: redisHandle,
+		AppID: appID,
+		Clock: clockClock,
+	}
+	storePQ := &verification.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	verificationService := &verification.Service{
+		Logger:     verificationLogger,
+		Config:     verificationConfig,
+		Clock:      clockClock,
+		CodeStore:  storeRedis,
+		ClaimStore: storePQ,
+	}
+	coordinator := &facade.Coordinator{
+		Identities:     serviceService,
+		Authenticators: service3,
+		Verification:   verificationService,
+		IdentityConfig: identityConfig,
+	}
+	identityFacade := facade.IdentityFacade{
+		Coordinator: coordinator,
+	}
+	authenticatorFacade := facade.AuthenticatorFacade{
+		Coordinator: coordinator,
+	}
+	rateLimitConfig := appConfig.RateLimit
+	mainOriginProvider := &MainOriginProvider{
+		Request:    request,
+		TrustProxy: trustProxy,
+	}
+	endpointsProvider := &EndpointsProvider{
+		OriginProvider: mainOriginProvider,
+	}
+	ratelimitLogger := ratelimit.NewLogger(factory)
+	storageRedis := &ratelimit.StorageRedis{
+		AppID: appID,
+		Redis: redisHandle,
+		Clock: clockClock,
+	}
+	limiter := &ratelimit.Limiter{
+		Logger:  ratelimitLogger,
+		Storage: storageRedis,
+	}
+	messageSender := &otp.MessageSender{
+		StaticAssetURLPrefix: staticAssetURLPrefix,
+		RateLimitConfig:      rateLimitConfig,
+		Translation:          translationService,
+		Endpoints:            endpointsProvider,
+		TaskQueue:            queue,
+		RateLimiter:          limiter,
+	}
+	codeSender := &oob.CodeSender{
+		OTPMessageSender: messageSender,
+	}
+	oAuthClientCredentials := deps.ProvideOAuthClientCredentials(secretConfig)
+	urlProvider := &webapp.URLProvider{
+		Endpoints: endpointsProvider,
+	}
+	userInfoDecoder := sso.UserInfoDecoder{
+		LoginIDNormalizerFactory: normalizerFactory,
+	}
+	oidcCache := rootProvider.OIDCCache
+	oAuthProviderFactory := &sso.OAuthProviderFactory{
+		Endpoints:                endpointsProvider,
+		IdentityConfig:           identityConfig,
+		Credentials:              oAuthClientCredentials,
+		RedirectURL:              urlProvider,
+		Clock:                    clockClock,
+		UserInfoDecoder:          userInfoDecoder,
+		LoginIDNormalizerFactory: normalizerFactory,
+		OIDCCache:                oidcCache,
+	}
+	storeDeviceTokenRedis := &mfa.StoreDeviceTokenRedis{
+		Redis: redisHandle,
+		AppID: appID,
+		Clock: clockClock,
+	}
+	storeRecoveryCodePQ := &mfa.StoreRecoveryCodePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	mfaService := &mfa.Service{
+		DeviceTokens:  storeDeviceTokenRedis,
+		RecoveryCodes: storeRecoveryCodePQ,
+		Clock:         clockClock,
+		Config:        authenticationConfig,
+	}
+	forgotpasswordStore := &forgotpassword.Store{
+		Redis: redisHandle,
+	}
+	providerLogger := forgotpassword.NewProviderLogger(factory)
+	forgotpasswordProvider := &forgotpassword.Provider{
+		StaticAssetURLPrefix: staticAssetURLPrefix,
+		Translation:          translationService,
+		Config:               forgotPasswordConfig,
+		RateLimitConfig:      rateLimitConfig,
+		Store:                forgotpasswordStore,
+		Clock:                clockClock,
+		URLs:                 urlProvider,
+		TaskQueue:            queue,
+		RateLimiter:          limiter,
+		Logger:               providerLogger,
+		Identities:           identityFacade,
+		Authenticators:       authenticatorFacade,
+	}
+	verificationCodeSender := &verification.CodeSender{
+		OTPMessageSender: messageSender,
+		WebAppURLs:       urlProvider,
+	}
+	challengeProvider := &challenge.Provider{
+		Redis: redisHandle,
+		AppID: appID,
+		Clock: clockClock,
+	}
+	userStore := &user.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	welcomeMessageConfig := appConfig.WelcomeMessage
+	welcomemessageProvider := &welcomemessage.Provider{
+		Translation:          translationService,
+		WelcomeMessageConfig: welcomeMessageConfig,
+		TaskQueue:            queue,
+	}
+	queries := &user.Queries{
+		Store:        userStore,
+		Identities:   identityFacade,
+		Verification: verificationService,
+		Clock:        clockClock,
+	}
+	rawCommands := &user.RawCommands{
+		Store:                  userStore,
+		Clock:                  clockClock,
+		WelcomeMessageProvider: welcomemessageProvider,
+		Queries:                queries,
+	}
+	hookLogger := hook.NewLogger(factory)
+	rawProvider := &user.RawProvider{
+		RawCommands: rawCommands,
+		Queries:     queries,
+	}
+	hookStore := &hook.Store{
+		Clock:       clockClock,
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	hookConfig := appConfig.Hook
+	webhookKeyMaterials := deps.ProvideWebhookKeyMaterials(secretConfig)
+	syncHTTPClient := hook.NewSyncHTTPClient(hookConfig)
+	asyncHTTPClient := hook.NewAsyncHTTPClient()
+	deliverer := &hook.Deliverer{
+		Config:    hookConfig,
+		Secret:    webhookKeyMaterials,
+		Clock:     clockClock,
+		SyncHTTP:  syncHTTPClient,
+		AsyncHTTP: asyncHTTPClient,
+	}
+	hookProvider := &hook.Provider{
+		Context:   context,
+		Logger:    hookLogger,
+		Database:  handle,
+		Clock:     clockClock,
+		Users:     rawProvider,
+		Store:     hookStore,
+		Deliverer: deliverer,
+		TaskQueue: queue,
+	}
+	userProfileConfig := appConfig.UserProfile
+	commands := &user.Commands{
+		Raw:               rawCommands,
+		Hooks:             hookProvider,
+		Verification:      verificationService,
+		UserProfileConfig: userProfileConfig,
+	}
+	userProvider := &user.Provider{
+		Commands: commands,
+		Queries:  queries,
+	}
+	authenticationLockoutConfig := authenticationConfig.Lockout
+	lockoutStorePQ := &lockout.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	lockoutService := &lockout.Service{
+		Config:   authenticationLockoutConfig,
+		Clock:    clockClock,
+		Store:    lockoutStorePQ,
+		Database: handle,
+		Users:    rawProvider,
+		Hooks:    hookProvider,
+	}
+	auditStorePQ := &audit.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	auditService := &audit.Service{
+		Context:  context,
+		Clock:    clockClock,
+		Store:    auditStorePQ,
+		Database: handle,
+	}
+	cookieFactory := deps.NewCookieFactory(request, trustProxy)
+	storeRedisLogger := idpsession.NewStoreRedisLogger(factory)
+	idpsessionStoreRedis := &idpsession.StoreRedis{
+		Redis:  redisHandle,
+		AppID:  appID,
+		Clock:  clockClock,
+		Logger: storeRedisLogger,
+	}
+	eventStoreRedis := &access.EventStoreRedis{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	eventProvider := &access.EventProvider{
+		Store: eventStoreRedis,
+	}
+	sessionConfig := appConfig.Session
+	idpsessionRand := _wireRandValue
+	idpsessionProvider := &idpsession.Provider{
+		Request:      request,
+		Store:        idpsessionStoreRedis,
+		AccessEvents: eventProvider,
+		TrustProxy:   trustProxy,
+		Config:       sessionConfig,
+		Clock:        clockClock,
+		Random:       idpsessionRand,
+	}
+	cookieDef := idpsession.NewSessionCookieDef(httpConfig, sessionConfig)
+	mfaCookieDef := mfa.NewDeviceTokenCookieDef(httpConfig, authenticationConfig)
+	interactionContext := &interaction.Context{
+		Database:                 sqlExecutor,
+		Clock:                    clockClock,
+		Config:                   appConfig,
+		RemoteIP:                 remoteIP,
+		Identities:               identityFacade,
+		Authenticators:           authenticatorFacade,
+		AnonymousIdentities:      anonymousProvider,
+		OOBAuthenticators:        oobProvider,
+		OOBCodeSender:            codeSender,
+		WebAuthnAuthenticators:   webauthnProvider,
+		OAuthProviderFactory:     oAuthProviderFactory,
+		MFA:                      mfaService,
+		ForgotPassword:           forgotpasswordProvider,
+		ResetPassword:            forgotpasswordProvider,
+		LoginIDNormalizerFactory: normalizerFactory,
+		Verification:             verificationService,
+		VerificationCodeSender:   verificationCodeSender,
+		Challenges:               challengeProvider,
+		Users:                    userProvider,
+		Hooks:                    hookProvider,
+		RateLimiter:              limiter,
+		Lockout:                  lockoutService,
+		Audit:                    auditService,
+		CookieFactory:            cookieFactory,
+		Sessions:                 idpsessionProvider,
+		SessionCookie:            cookieDef,
+		MFADeviceTokenCookie:     mfaCookieDef,
+	}
+	interactionStoreRedis := &interaction.StoreRedis{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	interactionService := &interaction.Service{
+		Logger:  logger,
+		Context: interactionContext,
+		Store:   interactionStoreRedis,
+	}
+	webappCookieDef := webapp.NewUATokenCookieDef(httpConfig)
+	webappService := &webapp.Service{
+		Logger:        serviceLogger,
+		Request:       request,
+		Store:         redisStore,
+		Graph:         interactionService,
+		CookieFactory: cookieFactory,
+		UATokenCookie: webappCookieDef,
+	}
+	csrfCookieDef := webapp.NewCSRFCookieDef(httpConfig)
+	promoteHandler := &webapp2.PromoteHandler{
+		Database:      handle,
+		BaseViewModel: baseViewModeler,
+		FormPrefiller: formPrefiller,
+		Renderer:      responseRenderer,
+		WebApp:        webappService,
+		CSRFCookie:    csrfCookieDef,
+	}
+	return promoteHandler
+}
+
+func newWebAppSSOCallbackHandler(p *deps.RequestProvider) http.Handler {
+	appProvider := p.AppProvider
+	handle := appProvider.Database
+	factory := appProvider.LoggerFactory
+	serviceLogger := webapp.NewServiceLogger(factory)
+	request := p.Request
+	config := appProvider.Config
+	appConfig := config.AppConfig
+	appID := appConfig.ID
+	redisHandle := appProvider.Redis
+	redisStore := &webapp.RedisStore{
+		AppID: appID,
+		Redis: redisHandle,
+	}
+	logger := interaction.NewLogger(factory)
+	context := deps.ProvideRequestContext(request)
+	sqlExecutor := db.SQLExecutor{
+		Context:  context,
+		Database: handle,
+	}
+	clockClock := _wireSystemClockValue
+	rootProvider := appProvider.RootProvider
+	environmentConfig := rootProvider.EnvironmentConfig
+	trustProxy := environmentConfig.TrustProxy
+	remoteIP := deps.ProvideRemoteIP(request, trustProxy)
+	authenticationConfig := appConfig.Authentication
+	identityConfig := appConfig.Identity
+	secretConfig := config.SecretConfig
+	databaseCredentials := deps.ProvideDatabaseCredentials(secretConfig)
+	sqlBuilder := db.ProvideSQLBuilder(databaseCredentials, appID)
+	store := &service.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	loginidStore := &loginid.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	loginIDConfig := identityConfig.LoginID
+	reservedNameChecker := rootProvider.ReservedNameChecker
+	typeCheckerFactory := &loginid.TypeCheckerFactory{
+		Config:              loginIDConfig,
+		ReservedNameChecker: reservedNameChecker,
+	}
+	checker := &loginid.Checker{
+		Config:             loginIDConfig,
+		TypeCheckerFactory: typeCheckerFactory,
+	}
+	normalizerFactory := &loginid.NormalizerFactory{
+		Config: loginIDConfig,
+	}
+	provider := &loginid.Provider{
+		Store:             loginidStore,
+		Config:            loginIDConfig,
+		Checker:           checker,
+		NormalizerFactory: normalizerFactory,
+		Clock:             clockClock,
+	}
+	oauthStore := &oauth3.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	oauthProvider := &oauth3.Provider{
+		Store: oauthStore,
+		Clock: clockClock,
+	}
+	anonymousStore := &anonymous.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	anonymousProvider := &anonymous.Provider{
+		Store: anonymousStore,
+		Clock: clockClock,
+	}
+	serviceService := &service.Service{
+		Authentication: authenticationConfig,
+		Identity:       identityConfig,
+		Store:          store,
+		LoginID:        provider,
+		OAuth:          oauthProvider,
+		Anonymous:      anonymousProvider,
+	}
+	serviceStore := &service2.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	passwordStore := &password.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	authenticatorConfig := appConfig.Authenticator
+	authenticatorPasswordConfig := authenticatorConfig.Password
+	passwordLogger := password.NewLogger(factory)
+	historyStore := &password.HistoryStore{
+		Clock:       clockClock,
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	passwordChecker := password.ProvideChecker(authenticatorPasswordConfig, historyStore)
+	queue := appProvider.TaskQueue
+	passwordProvider := &password.Provider{
+		Store:           passwordStore,
+		Config:          authenticatorPasswordConfig,
+		Clock:           clockClock,
+		Logger:          passwordLogger,
+		PasswordHistory: historyStore,
+		PasswordChecker: passwordChecker,
+		TaskQueue:       queue,
+		Database:        handle,
+	}
+	totpStore := &totp.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	authenticatorTOTPConfig := authenticatorConfig.TOTP
+	totpProvider := &totp.Provider{
+		Store:  totpStore,
+		Config: authenticatorTOTPConfig,
+		Clock:  clockClock,
+	}
+	authenticatorOOBConfig := authenticatorConfig.OOB
+	oobStore := &oob.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	oobProvider := &oob.Provider{
+		Config: authenticatorOOBConfig,
+		Store:  oobStore,
+		Clock:  clockClock,
+	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
+	service3 := &service2.Service{
+		Store:    serviceStore,
+		Password: passwordProvider,
+		TOTP:     totpProvider,
+		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
+	}
+	verificationLogger := verification.NewLogger(factory)
+	verificationConfig := appConfig.Verification
+	storeRedis := &verification.StoreRedis{
+		Redis: redisHandle,
+		AppID: appID,
+		Clock: clockClock,
+	}
+	storePQ := &verification.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	verificationService := &verification.Service{
+		Logger:     verificationLogger,
+		Config:     verificationConfig,
+		Clock:      clockClock,
+		CodeStore:  storeRedis,
+		ClaimStore: storePQ,
+	}
+	coordinator := &facade.Coordinator{
+		Identities:     serviceService,
+		Authenticators: service3,
+		Verification:   verificationService,
+		IdentityConfig: identityConfig,
+	}
+	identityFacade := facade.IdentityFacade{
+		Coordinator: coordinator,
+	}
+	authenticatorFacade := facade.AuthenticatorFacade{
+		Coordinator: coordinator,
+	}
+	staticAssetURLPrefix := environmentConfig.StaticAssetURLPrefix
+	rateLimitConfig := appConfig.RateLimit
+	engine := appProvider.TemplateEngine
+	translationService := &translation.Service{
+		Context:           context,
+		EnvironmentConfig: environmentConfig,
+		TemplateEngine:    engine,
+	}
+	mainOriginProvider := &MainOriginProvider{
+		Request:    request,
+		TrustProxy: trustProxy,
+	}
+	endpointsProvider := &EndpointsProvider{
+		OriginProvider: mainOriginProvider,
+	}
+	ratelimitLogger := ratelimit.NewLogger(factory)
+	storageRedis := &ratelimit.StorageRedis{
+		AppID: appID,
+		Redis: redisHandle,
+		Clock: clockClock,
+	}
+	limiter := &ratelimit.Limiter{
+		Logger:  ratelimitLogger,
+		Storage: storageRedis,
+	}
+	messageSender := &otp.MessageSender{
+		StaticAssetURLPrefix: staticAssetURLPrefix,
+		RateLimitConfig:      rateLimitConfig,
+		Translation:          translationService,
+		Endpoints:            endpointsProvider,
+		TaskQueue:            queue,
+		RateLimiter:          limiter,
+	}
+	codeSender := &oob.CodeSender{
 		OTPMessageSender: messageSender,
 	}
 	oAuthClientCredentials := deps.ProvideOAuthClientCredentials(secretConfig)
@@ -1602,6 +4113,7 @@ func newWebAppLoginHandler(p *deps.RequestProvider) http.Handler {
 	userInfoDecoder := sso.UserInfoDecoder{
 		LoginIDNormalizerFactory: normalizerFactory,
 	}
+	oidcCache := rootProvider.OIDCCache
 	oAuthProviderFactory := &sso.OAuthProviderFactory{
 		Endpoints:                endpointsProvider,
 		IdentityConfig:           identityConfig,
@@ -1610,6 +4122,7 @@ func newWebAppLoginHandler(p *deps.RequestProvider) http.Handler {
 		Clock:                    clockClock,
 		UserInfoDecoder:          userInfoDecoder,
 		LoginIDNormalizerFactory: normalizerFactory,
+		OIDCCache:                oidcCache,
 	}
 	storeDeviceTokenRedis := &mfa.StoreDeviceTokenRedis{
 		Redis: redisHandle,
@@ -1626,6 +4139,7 @@ func newWebAppLoginHandler(p *deps.RequestProvider) http.Handler {
 		Clock:         clockClock,
 		Config:        authenticationConfig,
 	}
+	forgotPasswordConfig := appConfig.ForgotPassword
 	forgotpasswordStore := &forgotpassword.Store{
 		Redis: redisHandle,
 	}
@@ -1634,10 +4148,12 @@ func newWebAppLoginHandler(p *deps.RequestProvider) http.Handler {
 		StaticAssetURLPrefix: staticAssetURLPrefix,
 		Translation:          translationService,
 		Config:               forgotPasswordConfig,
+		RateLimitConfig:      rateLimitConfig,
 		Store:                forgotpasswordStore,
 		Clock:                clockClock,
 		URLs:                 urlProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 		Logger:               providerLogger,
 		Identities:           identityFacade,
 		Authenticators:       authenticatorFacade,
@@ -1665,6 +4181,7 @@ func newWebAppLoginHandler(p *deps.RequestProvider) http.Handler {
 		Store:        userStore,
 		Identities:   identityFacade,
 		Verification: verificationService,
+		Clock:        clockClock,
 	}
 	rawCommands := &user.RawCommands{
 		Store:                  userStore,
@@ -1678,6 +4195,7 @@ func newWebAppLoginHandler(p *deps.RequestProvider) http.Handler {
 		Queries:     queries,
 	}
 	hookStore := &hook.Store{
+		Clock:       clockClock,
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
@@ -1700,16 +4218,42 @@ func newWebAppLoginHandler(p *deps.RequestProvider) http.Handler {
 		Users:     rawProvider,
 		Store:     hookStore,
 		Deliverer: deliverer,
+		TaskQueue: queue,
 	}
+	userProfileConfig := appConfig.UserProfile
 	commands := &user.Commands{
-		Raw:          rawCommands,
-		Hooks:        hookProvider,
-		Verification: verificationService,
+		Raw:               rawCommands,
+		Hooks:             hookProvider,
+		Verification:      verificationService,
+		UserProfileConfig: userProfileConfig,
 	}
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
+	authenticationLockoutConfig := authenticationConfig.Lockout
+	lockoutStorePQ := &lockout.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	lockoutService := &lockout.Service{
+		Config:   authenticationLockoutConfig,
+		Clock:    clockClock,
+		Store:    lockoutStorePQ,
+		Database: handle,
+		Users:    rawProvider,
+		Hooks:    hookProvider,
+	}
+	auditStorePQ := &audit.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	auditService := &audit.Service{
+		Context:  context,
+		Clock:    clockClock,
+		Store:    auditStorePQ,
+		Database: handle,
+	}
 	cookieFactory := deps.NewCookieFactory(request, trustProxy)
 	storeRedisLogger := idpsession.NewStoreRedisLogger(factory)
 	idpsessionStoreRedis := &idpsession.StoreRedis{
@@ -1736,18 +4280,19 @@ func newWebAppLoginHandler(p *deps.RequestProvider) http.Handler {
 		Clock:        clockClock,
 		Random:       idpsessionRand,
 	}
-	httpConfig := appConfig.HTTP
 	cookieDef := idpsession.NewSessionCookieDef(httpConfig, sessionConfig)
 	mfaCookieDef := mfa.NewDeviceTokenCookieDef(httpConfig, authenticationConfig)
 	interactionContext := &interaction.Context{
 		Database:                 sqlExecutor,
 		Clock:                    clockClock,
 		Config:                   appConfig,
+		RemoteIP:                 remoteIP,
 		Identities:               identityFacade,
 		Authenticators:           authenticatorFacade,
 		AnonymousIdentities:      anonymousProvider,
 		OOBAuthenticators:        oobProvider,
 		OOBCodeSender:            codeSender,
+		WebAuthnAuthenticators:   webauthnProvider,
 		OAuthProviderFactory:     oAuthProviderFactory,
 		MFA:                      mfaService,
 		ForgotPassword:           forgotpasswordProvider,
@@ -1758,6 +4303,9 @@ func newWebAppLoginHandler(p *deps.RequestProvider) http.Handler {
 		Challenges:               challengeProvider,
 		Users:                    userProvider,
 		Hooks:                    hookProvider,
+		RateLimiter:              limiter,
+		Lockout:                  lockoutService,
+		Audit:                    auditService,
 		CookieFactory:            cookieFactory,
 		Sessions:                 idpsessionProvider,
 		SessionCookie:            cookieDef,
@@ -1782,24 +4330,19 @@ func newWebAppLoginHandler(p *deps.RequestProvider) http.Handler {
 		UATokenCookie: webappCookieDef,
 	}
 	csrfCookieDef := webapp.NewCSRFCookieDef(httpConfig)
-	loginHandler := &webapp2.LoginHandler{
-		TrustProxy:    trustProxy,
-		Database:      handle,
-		BaseViewModel: baseViewModeler,
-		FormPrefiller: formPrefiller,
-		Renderer:      responseRenderer,
-		WebApp:        webappService,
-		CSRFCookie:    csrfCookieDef,
+	ssoCallbackHandler := &webapp2.SSOCallbackHandler{
+		Database:   handle,
+		WebApp:     webappService,
+		CSRFCookie: csrfCookieDef,
 	}
-	return loginHandler
+	return ssoCallbackHandler
 }
 
-func newWebAppSignupHandler(p *deps.RequestProvider) http.Handler {
+func newWebAppEnterLoginIDHandler(p *deps.RequestProvider) http.Handler {
 	appProvider := p.AppProvider
+	handle := appProvider.Database
 	rootProvider := appProvider.RootProvider
 	environmentConfig := rootProvider.EnvironmentConfig
-	trustProxy := environmentConfig.TrustProxy
-	handle := appProvider.Database
 	staticAssetURLPrefix := environmentConfig.StaticAssetURLPrefix
 	config := appProvider.Config
 	appConfig := config.AppConfig
@@ -1819,12 +4362,6 @@ func newWebAppSignupHandler(p *deps.RequestProvider) http.Handler {
 		Translation:          translationService,
 		ForgotPassword:       forgotPasswordConfig,
 	}
-	identityConfig := appConfig.Identity
-	loginIDConfig := identityConfig.LoginID
-	formPrefiller := &webapp2.FormPrefiller{
-		LoginID: loginIDConfig,
-		UI:      uiConfig,
-	}
 	factory := appProvider.LoggerFactory
 	responseRendererLogger := webapp2.NewResponseRendererLogger(factory)
 	responseRenderer := &webapp2.ResponseRenderer{
@@ -1844,7 +4381,10 @@ func newWebAppSignupHandler(p *deps.RequestProvider) http.Handler {
 		Database: handle,
 	}
 	clockClock := _wireSystemClockValue
+	trustProxy := environmentConfig.TrustProxy
+	remoteIP := deps.ProvideRemoteIP(request, trustProxy)
 	authenticationConfig := appConfig.Authentication
+	identityConfig := appConfig.Identity
 	secretConfig := config.SecretConfig
 	databaseCredentials := deps.ProvideDatabaseCredentials(secretConfig)
 	sqlBuilder := db.ProvideSQLBuilder(databaseCredentials, appID)
@@ -1856,6 +4396,7 @@ func newWebAppSignupHandler(p *deps.RequestProvider) http.Handler {
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
+	loginIDConfig := identityConfig.LoginID
 	reservedNameChecker := rootProvider.ReservedNameChecker
 	typeCheckerFactory := &loginid.TypeCheckerFactory{
 		Config:              loginIDConfig,
@@ -1925,6 +4466,7 @@ func newWebAppSignupHandler(p *deps.RequestProvider) http.Handler {
 		PasswordHistory: historyStore,
 		PasswordChecker: passwordChecker,
 		TaskQueue:       queue,
+		Database:        handle,
 	}
 	totpStore := &totp.Store{
 		SQLBuilder:  sqlBuilder,
@@ -1946,11 +4488,29 @@ func newWebAppSignupHandler(p *deps.RequestProvider) http.Handler {
 		Store:  oobStore,
 		Clock:  clockClock,
 	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
 	service3 := &service2.Service{
 		Store:    serviceStore,
 		Password: passwordProvider,
 		TOTP:     totpProvider,
 		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
 	}
 	verificationLogger := verification.NewLogger(factory)
 	verificationConfig := appConfig.Verification
@@ -1982,6 +4542,7 @@ func newWebAppSignupHandler(p *deps.RequestProvider) http.Handler {
 	authenticatorFacade := facade.AuthenticatorFacade{
 		Coordinator: coordinator,
 	}
+	rateLimitConfig := appConfig.RateLimit
 	mainOriginProvider := &MainOriginProvider{
 		Request:    request,
 		TrustProxy: trustProxy,
@@ -1989,11 +4550,23 @@ func newWebAppSignupHandler(p *deps.RequestProvider) http.Handler {
 	endpointsProvider := &EndpointsProvider{
 		OriginProvider: mainOriginProvider,
 	}
+	ratelimitLogger := ratelimit.NewLogger(factory)
+	storageRedis := &ratelimit.StorageRedis{
+		AppID: appID,
+		Redis: redisHandle,
+		Clock: clockClock,
+	}
+	limiter := &ratelimit.Limiter{
+		Logger:  ratelimitLogger,
+		Storage: storageRedis,
+	}
 	messageSender := &otp.MessageSender{
 		StaticAssetURLPrefix: staticAssetURLPrefix,
+		RateLimitConfig:      rateLimitConfig,
 		Translation:          translationService,
 		Endpoints:            endpointsProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 	}
 	codeSender := &oob.CodeSender{
 		OTPMessageSender: messageSender,
@@ -2005,6 +4578,7 @@ func newWebAppSignupHandler(p *deps.RequestProvider) http.Handler {
 	userInfoDecoder := sso.UserInfoDecoder{
 		LoginIDNormalizerFactory: normalizerFactory,
 	}
+	oidcCache := rootProvider.OIDCCache
 	oAuthProviderFactory := &sso.OAuthProviderFactory{
 		Endpoints:                endpointsProvider,
 		IdentityConfig:           identityConfig,
@@ -2013,6 +4587,7 @@ func newWebAppSignupHandler(p *deps.RequestProvider) http.Handler {
 		Clock:                    clockClock,
 		UserInfoDecoder:          userInfoDecoder,
 		LoginIDNormalizerFactory: normalizerFactory,
+		OIDCCache:                oidcCache,
 	}
 	storeDeviceTokenRedis := &mfa.StoreDeviceTokenRedis{
 		Redis: redisHandle,
@@ -2037,10 +4612,12 @@ func newWebAppSignupHandler(p *deps.RequestProvider) http.Handler {
 		StaticAssetURLPrefix: staticAssetURLPrefix,
 		Translation:          translationService,
 		Config:               forgotPasswordConfig,
+		RateLimitConfig:      rateLimitConfig,
 		Store:                forgotpasswordStore,
 		Clock:                clockClock,
 		URLs:                 urlProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 		Logger:               providerLogger,
 		Identities:           identityFacade,
 		Authenticators:       authenticatorFacade,
@@ -2068,6 +4645,7 @@ func newWebAppSignupHandler(p *deps.RequestProvider) http.Handler {
 		Store:        userStore,
 		Identities:   identityFacade,
 		Verification: verificationService,
+		Clock:        clockClock,
 	}
 	rawCommands := &user.RawCommands{
 		Store:                  userStore,
@@ -2081,6 +4659,7 @@ func newWebAppSignupHandler(p *deps.RequestProvider) http.Handler {
 		Queries:     queries,
 	}
 	hookStore := &hook.Store{
+		Clock:       clockClock,
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
@@ -2103,16 +4682,42 @@ func newWebAppSignupHandler(p *deps.RequestProvider) http.Handler {
 		Users:     rawProvider,
 		Store:     hookStore,
 		Deliverer: deliverer,
+		TaskQueue: queue,
 	}
+	userProfileConfig := appConfig.UserProfile
 	commands := &user.Commands{
-		Raw:          rawCommands,
-		Hooks:        hookProvider,
-		Verification: verificationService,
+		Raw:               rawCommands,
+		Hooks:             hookProvider,
+		Verification:      verificationService,
+		UserProfileConfig: userProfileConfig,
 	}
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
+	authenticationLockoutConfig := authenticationConfig.Lockout
+	lockoutStorePQ := &lockout.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	lockoutService := &lockout.Service{
+		Config:   authenticationLockoutConfig,
+		Clock:    clockClock,
+		Store:    lockoutStorePQ,
+		Database: handle,
+		Users:    rawProvider,
+		Hooks:    hookProvider,
+	}
+	auditStorePQ := &audit.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	auditService := &audit.Service{
+		Context:  context,
+		Clock:    clockClock,
+		Store:    auditStorePQ,
+		Database: handle,
+	}
 	cookieFactory := deps.NewCookieFactory(request, trustProxy)
 	storeRedisLogger := idpsession.NewStoreRedisLogger(factory)
 	idpsessionStoreRedis := &idpsession.StoreRedis{
@@ -2139,18 +4744,19 @@ func newWebAppSignupHandler(p *deps.RequestProvider) http.Handler {
 		Clock:        clockClock,
 		Random:       idpsessionRand,
 	}
-	httpConfig := appConfig.HTTP
 	cookieDef := idpsession.NewSessionCookieDef(httpConfig, sessionConfig)
 	mfaCookieDef := mfa.NewDeviceTokenCookieDef(httpConfig, authenticationConfig)
 	interactionContext := &interaction.Context{
 		Database:                 sqlExecutor,
 		Clock:                    clockClock,
 		Config:                   appConfig,
+		RemoteIP:                 remoteIP,
 		Identities:               identityFacade,
 		Authenticators:           authenticatorFacade,
 		AnonymousIdentities:      anonymousProvider,
 		OOBAuthenticators:        oobProvider,
 		OOBCodeSender:            codeSender,
+		WebAuthnAuthenticators:   webauthnProvider,
 		OAuthProviderFactory:     oAuthProviderFactory,
 		MFA:                      mfaService,
 		ForgotPassword:           forgotpasswordProvider,
@@ -2161,6 +4767,9 @@ func newWebAppSignupHandler(p *deps.RequestProvider) http.Handler {
 		Challenges:               challengeProvider,
 		Users:                    userProvider,
 		Hooks:                    hookProvider,
+		RateLimiter:              limiter,
+		Lockout:                  lockoutService,
+		Audit:                    auditService,
 		CookieFactory:            cookieFactory,
 		Sessions:                 idpsessionProvider,
 		SessionCookie:            cookieDef,
@@ -2184,20 +4793,16 @@ func newWebAppSignupHandler(p *deps.RequestProvider) http.Handler {
 		CookieFactory: cookieFactory,
 		UATokenCookie: webappCookieDef,
 	}
-	csrfCookieDef := webapp.NewCSRFCookieDef(httpConfig)
-	signupHandler := &webapp2.SignupHandler{
-		TrustProxy:    trustProxy,
+	enterLoginIDHandler := &webapp2.EnterLoginIDHandler{
 		Database:      handle,
 		BaseViewModel: baseViewModeler,
-		FormPrefiller: formPrefiller,
 		Renderer:      responseRenderer,
 		WebApp:        webappService,
-		CSRFCookie:    csrfCookieDef,
 	}
-	return signupHandler
+	return enterLoginIDHandler
 }
 
-func newWebAppPromoteHandler(p *deps.RequestProvider) http.Handler {
+func newWebAppEnterPasswordHandler(p *deps.RequestProvider) http.Handler {
 	appProvider := p.AppProvider
 	handle := appProvider.Database
 	rootProvider := appProvider.RootProvider
@@ -2221,12 +4826,6 @@ func newWebAppPromoteHandler(p *deps.RequestProvider) http.Handler {
 		Translation:          translationService,
 		ForgotPassword:       forgotPasswordConfig,
 	}
-	identityConfig := appConfig.Identity
-	loginIDConfig := identityConfig.LoginID
-	formPrefiller := &webapp2.FormPrefiller{
-		LoginID: loginIDConfig,
-		UI:      uiConfig,
-	}
 	factory := appProvider.LoggerFactory
 	responseRendererLogger := webapp2.NewResponseRendererLogger(factory)
 	responseRenderer := &webapp2.ResponseRenderer{
@@ -2246,7 +4845,10 @@ func newWebAppPromoteHandler(p *deps.RequestProvider) http.Handler {
 		Database: handle,
 	}
 	clockClock := _wireSystemClockValue
+	trustProxy := environmentConfig.TrustProxy
+	remoteIP := deps.ProvideRemoteIP(request, trustProxy)
 	authenticationConfig := appConfig.Authentication
+	identityConfig := appConfig.Identity
 	secretConfig := config.SecretConfig
 	databaseCredentials := deps.ProvideDatabaseCredentials(secretConfig)
 	sqlBuilder := db.ProvideSQLBuilder(databaseCredentials, appID)
@@ -2258,6 +4860,7 @@ func newWebAppPromoteHandler(p *deps.RequestProvider) http.Handler {
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
+	loginIDConfig := identityConfig.LoginID
 	reservedNameChecker := rootProvider.ReservedNameChecker
 	typeCheckerFactory := &loginid.TypeCheckerFactory{
 		Config:              loginIDConfig,
@@ -2327,6 +4930,7 @@ func newWebAppPromoteHandler(p *deps.RequestProvider) http.Handler {
 		PasswordHistory: historyStore,
 		PasswordChecker: passwordChecker,
 		TaskQueue:       queue,
+		Database:        handle,
 	}
 	totpStore := &totp.Store{
 		SQLBuilder:  sqlBuilder,
@@ -2348,11 +4952,29 @@ func newWebAppPromoteHandler(p *deps.RequestProvider) http.Handler {
 		Store:  oobStore,
 		Clock:  clockClock,
 	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
 	service3 := &service2.Service{
 		Store:    serviceStore,
 		Password: passwordProvider,
 		TOTP:     totpProvider,
 		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
 	}
 	verificationLogger := verification.NewLogger(factory)
 	verificationConfig := appConfig.Verification
@@ -2384,7 +5006,7 @@ func newWebAppPromoteHandler(p *deps.RequestProvider) http.Handler {
 	authenticatorFacade := facade.AuthenticatorFacade{
 		Coordinator: coordinator,
 	}
-	trustProxy := environmentConfig.TrustProxy
+	rateLimitConfig := appConfig.RateLimit
 	mainOriginProvider := &MainOriginProvider{
 		Request:    request,
 		TrustProxy: trustProxy,
@@ -2392,11 +5014,23 @@ func newWebAppPromoteHandler(p *deps.RequestProvider) http.Handler {
 	endpointsProvider := &EndpointsProvider{
 		OriginProvider: mainOriginProvider,
 	}
+	ratelimitLogger := ratelimit.NewLogger(factory)
+	storageRedis := &ratelimit.StorageRedis{
+		AppID: appID,
+		Redis: redisHandle,
+		Clock: clockClock,
+	}
+	limiter := &ratelimit.Limiter{
+		Logger:  ratelimitLogger,
+		Storage: storageRedis,
+	}
 	messageSender := &otp.MessageSender{
 		StaticAssetURLPrefix: staticAssetURLPrefix,
+		RateLimitConfig:      rateLimitConfig,
 		Translation:          translationService,
 		Endpoints:            endpointsProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 	}
 	codeSender := &oob.CodeSender{
 		OTPMessageSender: messageSender,
@@ -2408,6 +5042,7 @@ func newWebAppPromoteHandler(p *deps.RequestProvider) http.Handler {
 	userInfoDecoder := sso.UserInfoDecoder{
 		LoginIDNormalizerFactory: normalizerFactory,
 	}
+	oidcCache := rootProvider.OIDCCache
 	oAuthProviderFactory := &sso.OAuthProviderFactory{
 		Endpoints:                endpointsProvider,
 		IdentityConfig:           identityConfig,
@@ -2416,6 +5051,7 @@ func newWebAppPromoteHandler(p *deps.RequestProvider) http.Handler {
 		Clock:                    clockClock,
 		UserInfoDecoder:          userInfoDecoder,
 		LoginIDNormalizerFactory: normalizerFactory,
+		OIDCCache:                oidcCache,
 	}
 	storeDeviceTokenRedis := &mfa.StoreDeviceTokenRedis{
 		Redis: redisHandle,
@@ -2440,10 +5076,12 @@ func newWebAppPromoteHandler(p *deps.RequestProvider) http.Handler {
 		StaticAssetURLPrefix: staticAssetURLPrefix,
 		Translation:          translationService,
 		Config:               forgotPasswordConfig,
+		RateLimitConfig:      rateLimitConfig,
 		Store:                forgotpasswordStore,
 		Clock:                clockClock,
 		URLs:                 urlProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 		Logger:               providerLogger,
 		Identities:           identityFacade,
 		Authenticators:       authenticatorFacade,
@@ -2471,6 +5109,7 @@ func newWebAppPromoteHandler(p *deps.RequestProvider) http.Handler {
 		Store:        userStore,
 		Identities:   identityFacade,
 		Verification: verificationService,
+		Clock:        clockClock,
 	}
 	rawCommands := &user.RawCommands{
 		Store:                  userStore,
@@ -2484,6 +5123,7 @@ func newWebAppPromoteHandler(p *deps.RequestProvider) http.Handler {
 		Queries:     queries,
 	}
 	hookStore := &hook.Store{
+		Clock:       clockClock,
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
@@ -2506,16 +5146,42 @@ func newWebAppPromoteHandler(p *deps.RequestProvider) http.Handler {
 		Users:     rawProvider,
 		Store:     hookStore,
 		Deliverer: deliverer,
+		TaskQueue: queue,
 	}
+	userProfileConfig := appConfig.UserProfile
 	commands := &user.Commands{
-		Raw:          rawCommands,
-		Hooks:        hookProvider,
-		Verification: verificationService,
+		Raw:               rawCommands,
+		Hooks:             hookProvider,
+		Verification:      verificationService,
+		UserProfileConfig: userProfileConfig,
 	}
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
+	authenticationLockoutConfig := authenticationConfig.Lockout
+	lockoutStorePQ := &lockout.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	lockoutService := &lockout.Service{
+		Config:   authenticationLockoutConfig,
+		Clock:    clockClock,
+		Store:    lockoutStorePQ,
+		Database: handle,
+		Users:    rawProvider,
+		Hooks:    hookProvider,
+	}
+	auditStorePQ := &audit.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	auditService := &audit.Service{
+		Context:  context,
+		Clock:    clockClock,
+		Store:    auditStorePQ,
+		Database: handle,
+	}
 	cookieFactory := deps.NewCookieFactory(request, trustProxy)
 	storeRedisLogger := idpsession.NewStoreRedisLogger(factory)
 	idpsessionStoreRedis := &idpsession.StoreRedis{
@@ -2542,18 +5208,19 @@ func newWebAppPromoteHandler(p *deps.RequestProvider) http.Handler {
 		Clock:        clockClock,
 		Random:       idpsessionRand,
 	}
-	httpConfig := appConfig.HTTP
 	cookieDef := idpsession.NewSessionCookieDef(httpConfig, sessionConfig)
 	mfaCookieDef := mfa.NewDeviceTokenCookieDef(httpConfig, authenticationConfig)
 	interactionContext := &interaction.Context{
 		Database:                 sqlExecutor,
 		Clock:                    clockClock,
 		Config:                   appConfig,
+		RemoteIP:                 remoteIP,
 		Identities:               identityFacade,
 		Authenticators:           authenticatorFacade,
 		AnonymousIdentities:      anonymousProvider,
 		OOBAuthenticators:        oobProvider,
 		OOBCodeSender:            codeSender,
+		WebAuthnAuthenticators:   webauthnProvider,
 		OAuthProviderFactory:     oAuthProviderFactory,
 		MFA:                      mfaService,
 		ForgotPassword:           forgotpasswordProvider,
@@ -2564,6 +5231,9 @@ func newWebAppPromoteHandler(p *deps.RequestProvider) http.Handler {
 		Challenges:               challengeProvider,
 		Users:                    userProvider,
 		Hooks:                    hookProvider,
+		RateLimiter:              limiter,
+		Lockout:                  lockoutService,
+		Audit:                    auditService,
 		CookieFactory:            cookieFactory,
 		Sessions:                 idpsessionProvider,
 		SessionCookie:            cookieDef,
@@ -2587,26 +5257,46 @@ func newWebAppPromoteHandler(p *deps.RequestProvider) http.Handler {
 		CookieFactory: cookieFactory,
 		UATokenCookie: webappCookieDef,
 	}
-	csrfCookieDef := webapp.NewCSRFCookieDef(httpConfig)
-	promoteHandler := &webapp2.PromoteHandler{
+	enterPasswordHandler := &webapp2.EnterPasswordHandler{
 		Database:      handle,
 		BaseViewModel: baseViewModeler,
-		FormPrefiller: formPrefiller,
 		Renderer:      responseRenderer,
 		WebApp:        webappService,
-		CSRFCookie:    csrfCookieDef,
 	}
-	return promoteHandler
+	return enterPasswordHandler
 }
 
-func newWebAppSSOCallbackHandler(p *deps.RequestProvider) http.Handler {
+func newWebAppCreatePasswordHandler(p *deps.RequestProvider) http.Handler {
 	appProvider := p.AppProvider
 	handle := appProvider.Database
+	rootProvider := appProvider.RootProvider
+	environmentConfig := rootProvider.EnvironmentConfig
+	staticAssetURLPrefix := environmentConfig.StaticAssetURLPrefix
+	config := appProvider.Config
+	appConfig := config.AppConfig
+	uiConfig := appConfig.UI
+	request := p.Request
+	context := deps.ProvideRequestContext(request)
+	engine := appProvider.TemplateEngine
+	translationService := &translation.Service{
+		Context:           context,
+		EnvironmentConfig: environmentConfig,
+		TemplateEngine:    engine,
+	}
+	forgotPasswordConfig := appConfig.ForgotPassword
+	baseViewModeler := &viewmodels.BaseViewModeler{
+		StaticAssetURLPrefix: staticAssetURLPrefix,
+		AuthUI:               uiConfig,
+		Translation:          translationService,
+		ForgotPassword:       forgotPasswordConfig,
+	}
 	factory := appProvider.LoggerFactory
+	responseRendererLogger := webapp2.NewResponseRendererLogger(factory)
+	responseRenderer := &webapp2.ResponseRenderer{
+		TemplateEngine: engine,
+		Logger:         responseRendererLogger,
+	}
 	serviceLogger := webapp.NewServiceLogger(factory)
-	request := p.Request
-	config := appProvider.Config
-	appConfig := config.AppConfig
 	appID := appConfig.ID
 	redisHandle := appProvider.Redis
 	redisStore := &webapp.RedisStore{
@@ -2614,12 +5304,13 @@ func newWebAppSSOCallbackHandler(p *deps.RequestProvider) http.Handler {
 		Redis: redisHandle,
 	}
 	logger := interaction.NewLogger(factory)
-	context := deps.ProvideRequestContext(request)
 	sqlExecutor := db.SQLExecutor{
 		Context:  context,
 		Database: handle,
 	}
 	clockClock := _wireSystemClockValue
+	trustProxy := environmentConfig.TrustProxy
+	remoteIP := deps.ProvideRemoteIP(request, trustProxy)
 	authenticationConfig := appConfig.Authentication
 	identityConfig := appConfig.Identity
 	secretConfig := config.SecretConfig
@@ -2634,7 +5325,6 @@ func newWebAppSSOCallbackHandler(p *deps.RequestProvider) http.Handler {
 		SQLExecutor: sqlExecutor,
 	}
 	loginIDConfig := identityConfig.LoginID
-	rootProvider := appProvider.RootProvider
 	reservedNameChecker := rootProvider.ReservedNameChecker
 	typeCheckerFactory := &loginid.TypeCheckerFactory{
 		Config:              loginIDConfig,
@@ -2704,6 +5394,7 @@ func newWebAppSSOCallbackHandler(p *deps.RequestProvider) http.Handler {
 		PasswordHistory: historyStore,
 		PasswordChecker: passwordChecker,
 		TaskQueue:       queue,
+		Database:        handle,
 	}
 	totpStore := &totp.Store{
 		SQLBuilder:  sqlBuilder,
@@ -2725,11 +5416,29 @@ func newWebAppSSOCallbackHandler(p *deps.RequestProvider) http.Handler {
 		Store:  oobStore,
 		Clock:  clockClock,
 	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
 	service3 := &service2.Service{
 		Store:    serviceStore,
 		Password: passwordProvider,
 		TOTP:     totpProvider,
 		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
 	}
 	verificationLogger := verification.NewLogger(factory)
 	verificationConfig := appConfig.Verification
@@ -2761,15 +5470,7 @@ func newWebAppSSOCallbackHandler(p *deps.RequestProvider) http.Handler {
 	authenticatorFacade := facade.AuthenticatorFacade{
 		Coordinator: coordinator,
 	}
-	environmentConfig := rootProvider.EnvironmentConfig
-	staticAssetURLPrefix := environmentConfig.StaticAssetURLPrefix
-	engine := appProvider.TemplateEngine
-	translationService := &translation.Service{
-		Context:           context,
-		EnvironmentConfig: environmentConfig,
-		TemplateEngine:    engine,
-	}
-	trustProxy := environmentConfig.TrustProxy
+	rateLimitConfig := appConfig.RateLimit
 	mainOriginProvider := &MainOriginProvider{
 		Request:    request,
 		TrustProxy: trustProxy,
@@ -2777,11 +5478,23 @@ func newWebAppSSOCallbackHandler(p *deps.RequestProvider) http.Handler {
 	endpointsProvider := &EndpointsProvider{
 		OriginProvider: mainOriginProvider,
 	}
+	ratelimitLogger := ratelimit.NewLogger(factory)
+	storageRedis := &ratelimit.StorageRedis{
+		AppID: appID,
+		Redis: redisHandle,
+		Clock: clockClock,
+	}
+	limiter := &ratelimit.Limiter{
+		Logger:  ratelimitLogger,
+		Storage: storageRedis,
+	}
 	messageSender := &otp.MessageSender{
 		StaticAssetURLPrefix: staticAssetURLPrefix,
+		RateLimitConfig:      rateLimitConfig,
 		Translation:          translationService,
 		Endpoints:            endpointsProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 	}
 	codeSender := &oob.CodeSender{
 		OTPMessageSender: messageSender,
@@ -2793,6 +5506,7 @@ func newWebAppSSOCallbackHandler(p *deps.RequestProvider) http.Handler {
 	userInfoDecoder := sso.UserInfoDecoder{
 		LoginIDNormalizerFactory: normalizerFactory,
 	}
+	oidcCache := rootProvider.OIDCCache
 	oAuthProviderFactory := &sso.OAuthProviderFactory{
 		Endpoints:                endpointsProvider,
 		IdentityConfig:           identityConfig,
@@ -2801,6 +5515,7 @@ func newWebAppSSOCallbackHandler(p *deps.RequestProvider) http.Handler {
 		Clock:                    clockClock,
 		UserInfoDecoder:          userInfoDecoder,
 		LoginIDNormalizerFactory: normalizerFactory,
+		OIDCCache:                oidcCache,
 	}
 	storeDeviceTokenRedis := &mfa.StoreDeviceTokenRedis{
 		Redis: redisHandle,
@@ -2817,7 +5532,6 @@ func newWebAppSSOCallbackHandler(p *deps.RequestProvider) http.Handler {
 		Clock:         clockClock,
 		Config:        authenticationConfig,
 	}
-	forgotPasswordConfig := appConfig.ForgotPassword
 	forgotpasswordStore := &forgotpassword.Store{
 		Redis: redisHandle,
 	}
@@ -2826,10 +5540,12 @@ func newWebAppSSOCallbackHandler(p *deps.RequestProvider) http.Handler {
 		StaticAssetURLPrefix: staticAssetURLPrefix,
 		Translation:          translationService,
 		Config:               forgotPasswordConfig,
+		RateLimitConfig:      rateLimitConfig,
 		Store:                forgotpasswordStore,
 		Clock:                clockClock,
 		URLs:                 urlProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 		Logger:               providerLogger,
 		Identities:           identityFacade,
 		Authenticators:       authenticatorFacade,
@@ -2857,6 +5573,7 @@ func newWebAppSSOCallbackHandler(p *deps.RequestProvider) http.Handler {
 		Store:        userStore,
 		Identities:   identityFacade,
 		Verification: verificationService,
+		Clock:        clockClock,
 	}
 	rawCommands := &user.RawCommands{
 		Store:                  userStore,
@@ -2870,6 +5587,7 @@ func newWebAppSSOCallbackHandler(p *deps.RequestProvider) http.Handler {
 		Queries:     queries,
 	}
 	hookStore := &hook.Store{
+		Clock:       clockClock,
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
@@ -2892,16 +5610,42 @@ func newWebAppSSOCallbackHandler(p *deps.RequestProvider) http.Handler {
 		Users:     rawProvider,
 		Store:     hookStore,
 		Deliverer: deliverer,
+		TaskQueue: queue,
 	}
+	userProfileConfig := appConfig.UserProfile
 	commands := &user.Commands{
-		Raw:          rawCommands,
-		Hooks:        hookProvider,
-		Verification: verificationService,
+		Raw:               rawCommands,
+		Hooks:             hookProvider,
+		Verification:      verificationService,
+		UserProfileConfig: userProfileConfig,
 	}
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
+	authenticationLockoutConfig := authenticationConfig.Lockout
+	lockoutStorePQ := &lockout.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	lockoutService := &lockout.Service{
+		Config:   authenticationLockoutConfig,
+		Clock:    clockClock,
+		Store:    lockoutStorePQ,
+		Database: handle,
+		Users:    rawProvider,
+		Hooks:    hookProvider,
+	}
+	auditStorePQ := &audit.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	auditService := &audit.Service{
+		Context:  context,
+		Clock:    clockClock,
+		Store:    auditStorePQ,
+		Database: handle,
+	}
 	cookieFactory := deps.NewCookieFactory(request, trustProxy)
 	storeRedisLogger := idpsession.NewStoreRedisLogger(factory)
 	idpsessionStoreRedis := &idpsession.StoreRedis{
@@ -2928,18 +5672,19 @@ func newWebAppSSOCallbackHandler(p *deps.RequestProvider) http.Handler {
 		Clock:        clockClock,
 		Random:       idpsessionRand,
 	}
-	httpConfig := appConfig.HTTP
 	cookieDef := idpsession.NewSessionCookieDef(httpConfig, sessionConfig)
 	mfaCookieDef := mfa.NewDeviceTokenCookieDef(httpConfig, authenticationConfig)
 	interactionContext := &interaction.Context{
 		Database:                 sqlExecutor,
 		Clock:                    clockClock,
 		Config:                   appConfig,
+		RemoteIP:                 remoteIP,
 		Identities:               identityFacade,
 		Authenticators:           authenticatorFacade,
 		AnonymousIdentities:      anonymousProvider,
 		OOBAuthenticators:        oobProvider,
 		OOBCodeSender:            codeSender,
+		WebAuthnAuthenticators:   webauthnProvider,
 		OAuthProviderFactory:     oAuthProviderFactory,
 		MFA:                      mfaService,
 		ForgotPassword:           forgotpasswordProvider,
@@ -2950,6 +5695,9 @@ func newWebAppSSOCallbackHandler(p *deps.RequestProvider) http.Handler {
 		Challenges:               challengeProvider,
 		Users:                    userProvider,
 		Hooks:                    hookProvider,
+		RateLimiter:              limiter,
+		Lockout:                  lockoutService,
+		Audit:                    auditService,
 		CookieFactory:            cookieFactory,
 		Sessions:                 idpsessionProvider,
 		SessionCookie:            cookieDef,
@@ -2973,16 +5721,17 @@ func newWebAppSSOCallbackHandler(p *deps.RequestProvider) http.Handler {
 		CookieFactory: cookieFactory,
 		UATokenCookie: webappCookieDef,
 	}
-	csrfCookieDef := webapp.NewCSRFCookieDef(httpConfig)
-	ssoCallbackHandler := &webapp2.SSOCallbackHandler{
-		Database:   handle,
-		WebApp:     webappService,
-		CSRFCookie: csrfCookieDef,
+	createPasswordHandler := &webapp2.CreatePasswordHandler{
+		Database:       handle,
+		BaseViewModel:  baseViewModeler,
+		Renderer:       responseRenderer,
+		WebApp:         webappService,
+		PasswordPolicy: passwordChecker,
 	}
-	return ssoCallbackHandler
+	return createPasswordHandler
 }
 
-func newWebAppEnterLoginIDHandler(p *deps.RequestProvider) http.Handler {
+func newWebAppSetupTOTPHandler(p *deps.RequestProvider) http.Handler {
 	appProvider := p.AppProvider
 	handle := appProvider.Database
 	rootProvider := appProvider.RootProvider
@@ -3025,6 +5774,8 @@ func newWebAppEnterLoginIDHandler(p *deps.RequestProvider) http.Handler {
 		Database: handle,
 	}
 	clockClock := _wireSystemClockValue
+	trustProxy := environmentConfig.TrustProxy
+	remoteIP := deps.ProvideRemoteIP(request, trustProxy)
 	authenticationConfig := appConfig.Authentication
 	identityConfig := appConfig.Identity
 	secretConfig := config.SecretConfig
@@ -3108,6 +5859,7 @@ func newWebAppEnterLoginIDHandler(p *deps.RequestProvider) http.Handler {
 		PasswordHistory: historyStore,
 		PasswordChecker: passwordChecker,
 		TaskQueue:       queue,
+		Database:        handle,
 	}
 	totpStore := &totp.Store{
 		SQLBuilder:  sqlBuilder,
@@ -3129,11 +5881,29 @@ func newWebAppEnterLoginIDHandler(p *deps.RequestProvider) http.Handler {
 		Store:  oobStore,
 		Clock:  clockClock,
 	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
 	service3 := &service2.Service{
 		Store:    serviceStore,
 		Password: passwordProvider,
 		TOTP:     totpProvider,
 		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
 	}
 	verificationLogger := verification.NewLogger(factory)
 	verificationConfig := appConfig.Verification
@@ -3165,7 +5935,7 @@ func newWebAppEnterLoginIDHandler(p *deps.RequestProvider) http.Handler {
 	authenticatorFacade := facade.AuthenticatorFacade{
 		Coordinator: coordinator,
 	}
-	trustProxy := environmentConfig.TrustProxy
+	rateLimitConfig := appConfig.RateLimit
 	mainOriginProvider := &MainOriginProvider{
 		Request:    request,
 		TrustProxy: trustProxy,
@@ -3173,11 +5943,23 @@ func newWebAppEnterLoginIDHandler(p *deps.RequestProvider) http.Handler {
 	endpointsProvider := &EndpointsProvider{
 		OriginProvider: mainOriginProvider,
 	}
+	ratelimitLogger := ratelimit.NewLogger(factory)
+	storageRedis := &ratelimit.StorageRedis{
+		AppID: appID,
+		Redis: redisHandle,
+		Clock: clockClock,
+	}
+	limiter := &ratelimit.Limiter{
+		Logger:  ratelimitLogger,
+		Storage: storageRedis,
+	}
 	messageSender := &otp.MessageSender{
 		StaticAssetURLPrefix: staticAssetURLPrefix,
+		RateLimitConfig:      rateLimitConfig,
 		Translation:          translationService,
 		Endpoints:            endpointsProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 	}
 	codeSender := &oob.CodeSender{
 		OTPMessageSender: messageSender,
@@ -3189,6 +5971,7 @@ func newWebAppEnterLoginIDHandler(p *deps.RequestProvider) http.Handler {
 	userInfoDecoder := sso.UserInfoDecoder{
 		LoginIDNormalizerFactory: normalizerFactory,
 	}
+	oidcCache := rootProvider.OIDCCache
 	oAuthProviderFactory := &sso.OAuthProviderFactory{
 		Endpoints:                endpointsProvider,
 		IdentityConfig:           identityConfig,
@@ -3197,6 +5980,7 @@ func newWebAppEnterLoginIDHandler(p *deps.RequestProvider) http.Handler {
 		Clock:                    clockClock,
 		UserInfoDecoder:          userInfoDecoder,
 		LoginIDNormalizerFactory: normalizerFactory,
+		OIDCCache:                oidcCache,
 	}
 	storeDeviceTokenRedis := &mfa.StoreDeviceTokenRedis{
 		Redis: redisHandle,
@@ -3221,10 +6005,12 @@ func newWebAppEnterLoginIDHandler(p *deps.RequestProvider) http.Handler {
 		StaticAssetURLPrefix: staticAssetURLPrefix,
 		Translation:          translationService,
 		Config:               forgotPasswordConfig,
+		RateLimitConfig:      rateLimitConfig,
 		Store:                forgotpasswordStore,
 		Clock:                clockClock,
 		URLs:                 urlProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 		Logger:               providerLogger,
 		Identities:           identityFacade,
 		Authenticators:       authenticatorFacade,
@@ -3252,6 +6038,7 @@ func newWebAppEnterLoginIDHandler(p *deps.RequestProvider) http.Handler {
 		Store:        userStore,
 		Identities:   identityFacade,
 		Verification: verificationService,
+		Clock:        clockClock,
 	}
 	rawCommands := &user.RawCommands{
 		Store:                  userStore,
@@ -3265,6 +6052,7 @@ func newWebAppEnterLoginIDHandler(p *deps.RequestProvider) http.Handler {
 		Queries:     queries,
 	}
 	hookStore := &hook.Store{
+		Clock:       clockClock,
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
@@ -3287,16 +6075,42 @@ func newWebAppEnterLoginIDHandler(p *deps.RequestProvider) http.Handler {
 		Users:     rawProvider,
 		Store:     hookStore,
 		Deliverer: deliverer,
+		TaskQueue: queue,
 	}
+	userProfileConfig := appConfig.UserProfile
 	commands := &user.Commands{
-		Raw:          rawCommands,
-		Hooks:        hookProvider,
-		Verification: verificationService,
+		Raw:               rawCommands,
+		Hooks:             hookProvider,
+		Verification:      verificationService,
+		UserProfileConfig: userProfileConfig,
 	}
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
+	authenticationLockoutConfig := authenticationConfig.Lockout
+	lockoutStorePQ := &lockout.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	lockoutService := &lockout.Service{
+		Config:   authenticationLockoutConfig,
+		Clock:    clockClock,
+		Store:    lockoutStorePQ,
+		Database: handle,
+		Users:    rawProvider,
+		Hooks:    hookProvider,
+	}
+	auditStorePQ := &audit.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	auditService := &audit.Service{
+		Context:  context,
+		Clock:    clockClock,
+		Store:    auditStorePQ,
+		Database: handle,
+	}
 	cookieFactory := deps.NewCookieFactory(request, trustProxy)
 	storeRedisLogger := idpsession.NewStoreRedisLogger(factory)
 	idpsessionStoreRedis := &idpsession.StoreRedis{
@@ -3323,18 +6137,19 @@ func newWebAppEnterLoginIDHandler(p *deps.RequestProvider) http.Handler {
 		Clock:        clockClock,
 		Random:       idpsessionRand,
 	}
-	httpConfig := appConfig.HTTP
 	cookieDef := idpsession.NewSessionCookieDef(httpConfig, sessionConfig)
 	mfaCookieDef := mfa.NewDeviceTokenCookieDef(httpConfig, authenticationConfig)
 	interactionContext := &interaction.Context{
 		Database:                 sqlExecutor,
 		Clock:                    clockClock,
 		Config:                   appConfig,
+		RemoteIP:                 remoteIP,
 		Identities:               identityFacade,
 		Authenticators:           authenticatorFacade,
 		AnonymousIdentities:      anonymousProvider,
 		OOBAuthenticators:        oobProvider,
 		OOBCodeSender:            codeSender,
+		WebAuthnAuthenticators:   webauthnProvider,
 		OAuthProviderFactory:     oAuthProviderFactory,
 		MFA:                      mfaService,
 		ForgotPassword:           forgotpasswordProvider,
@@ -3345,6 +6160,9 @@ func newWebAppEnterLoginIDHandler(p *deps.RequestProvider) http.Handler {
 		Challenges:               challengeProvider,
 		Users:                    userProvider,
 		Hooks:                    hookProvider,
+		RateLimiter:              limiter,
+		Lockout:                  lockoutService,
+		Audit:                    auditService,
 		CookieFactory:            cookieFactory,
 		Sessions:                 idpsessionProvider,
 		SessionCookie:            cookieDef,
@@ -3368,16 +6186,18 @@ func newWebAppEnterLoginIDHandler(p *deps.RequestProvider) http.Handler {
 		CookieFactory: cookieFactory,
 		UATokenCookie: webappCookieDef,
 	}
-	enterLoginIDHandler := &webapp2.EnterLoginIDHandler{
+	setupTOTPHandler := &webapp2.SetupTOTPHandler{
 		Database:      handle,
 		BaseViewModel: baseViewModeler,
 		Renderer:      responseRenderer,
 		WebApp:        webappService,
+		Clock:         clockClock,
+		Endpoints:     endpointsProvider,
 	}
-	return enterLoginIDHandler
+	return setupTOTPHandler
 }
 
-func newWebAppEnterPasswordHandler(p *deps.RequestProvider) http.Handler {
+func newWebAppEnterTOTPHandler(p *deps.RequestProvider) http.Handler {
 	appProvider := p.AppProvider
 	handle := appProvider.Database
 	rootProvider := appProvider.RootProvider
@@ -3420,6 +6240,8 @@ func newWebAppEnterPasswordHandler(p *deps.RequestProvider) http.Handler {
 		Database: handle,
 	}
 	clockClock := _wireSystemClockValue
+	trustProxy := environmentConfig.TrustProxy
+	remoteIP := deps.ProvideRemoteIP(request, trustProxy)
 	authenticationConfig := appConfig.Authentication
 	identityConfig := appConfig.Identity
 	secretConfig := config.SecretConfig
@@ -3503,6 +6325,7 @@ func newWebAppEnterPasswordHandler(p *deps.RequestProvider) http.Handler {
 		PasswordHistory: historyStore,
 		PasswordChecker: passwordChecker,
 		TaskQueue:       queue,
+		Database:        handle,
 	}
 	totpStore := &totp.Store{
 		SQLBuilder:  sqlBuilder,
@@ -3524,11 +6347,29 @@ func newWebAppEnterPasswordHandler(p *deps.RequestProvider) http.Handler {
 		Store:  oobStore,
 		Clock:  clockClock,
 	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
 	service3 := &service2.Service{
 		Store:    serviceStore,
 		Password: passwordProvider,
 		TOTP:     totpProvider,
 		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
 	}
 	verificationLogger := verification.NewLogger(factory)
 	verificationConfig := appConfig.Verification
@@ -3560,7 +6401,7 @@ func newWebAppEnterPasswordHandler(p *deps.RequestProvider) http.Handler {
 	authenticatorFacade := facade.AuthenticatorFacade{
 		Coordinator: coordinator,
 	}
-	trustProxy := environmentConfig.TrustProxy
+	rateLimitConfig := appConfig.RateLimit
 	mainOriginProvider := &MainOriginProvider{
 		Request:    request,
 		TrustProxy: trustProxy,
@@ -3568,11 +6409,23 @@ func newWebAppEnterPasswordHandler(p *deps.RequestProvider) http.Handler {
 	endpointsProvider := &EndpointsProvider{
 		OriginProvider: mainOriginProvider,
 	}
+	ratelimitLogger := ratelimit.NewLogger(factory)
+	storageRedis := &ratelimit.StorageRedis{
+		AppID: appID,
+		Redis: redisHandle,
+		Clock: clockClock,
+	}
+	limiter := &ratelimit.Limiter{
+		Logger:  ratelimitLogger,
+		Storage: storageRedis,
+	}
 	messageSender := &otp.MessageSender{
 		StaticAssetURLPrefix: staticAssetURLPrefix,
+		RateLimitConfig:      rateLimitConfig,
 		Translation:          translationService,
 		Endpoints:            endpointsProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 	}
 	codeSender := &oob.CodeSender{
 		OTPMessageSender: messageSender,
@@ -3584,6 +6437,7 @@ func newWebAppEnterPasswordHandler(p *deps.RequestProvider) http.Handler {
 	userInfoDecoder := sso.UserInfoDecoder{
 		LoginIDNormalizerFactory: normalizerFactory,
 	}
+	oidcCache := rootProvider.OIDCCache
 	oAuthProviderFactory := &sso.OAuthProviderFactory{
 		Endpoints:                endpointsProvider,
 		IdentityConfig:           identityConfig,
@@ -3592,6 +6446,7 @@ func newWebAppEnterPasswordHandler(p *deps.RequestProvider) http.Handler {
 		Clock:                    clockClock,
 		UserInfoDecoder:          userInfoDecoder,
 		LoginIDNormalizerFactory: normalizerFactory,
+		OIDCCache:                oidcCache,
 	}
 	storeDeviceTokenRedis := &mfa.StoreDeviceTokenRedis{
 		Redis: redisHandle,
@@ -3616,10 +6471,12 @@ func newWebAppEnterPasswordHandler(p *deps.RequestProvider) http.Handler {
 		StaticAssetURLPrefix: staticAssetURLPrefix,
 		Translation:          translationService,
 		Config:               forgotPasswordConfig,
+		RateLimitConfig:      rateLimitConfig,
 		Store:                forgotpasswordStore,
 		Clock:                clockClock,
 		URLs:                 urlProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 		Logger:               providerLogger,
 		Identities:           identityFacade,
 		Authenticators:       authenticatorFacade,
@@ -3647,6 +6504,7 @@ func newWebAppEnterPasswordHandler(p *deps.RequestProvider) http.Handler {
 		Store:        userStore,
 		Identities:   identityFacade,
 		Verification: verificationService,
+		Clock:        clockClock,
 	}
 	rawCommands := &user.RawCommands{
 		Store:                  userStore,
@@ -3660,6 +6518,7 @@ func newWebAppEnterPasswordHandler(p *deps.RequestProvider) http.Handler {
 		Queries:     queries,
 	}
 	hookStore := &hook.Store{
+		Clock:       clockClock,
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
@@ -3682,16 +6541,42 @@ func newWebAppEnterPasswordHandler(p *deps.RequestProvider) http.Handler {
 		Users:     rawProvider,
 		Store:     hookStore,
 		Deliverer: deliverer,
+		TaskQueue: queue,
 	}
+	userProfileConfig := appConfig.UserProfile
 	commands := &user.Commands{
-		Raw:          rawCommands,
-		Hooks:        hookProvider,
-		Verification: verificationService,
+		Raw:               rawCommands,
+		Hooks:             hookProvider,
+		Verification:      verificationService,
+		UserProfileConfig: userProfileConfig,
 	}
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
+	authenticationLockoutConfig := authenticationConfig.Lockout
+	lockoutStorePQ := &lockout.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	lockoutService := &lockout.Service{
+		Config:   authenticationLockoutConfig,
+		Clock:    clockClock,
+		Store:    lockoutStorePQ,
+		Database: handle,
+		Users:    rawProvider,
+		Hooks:    hookProvider,
+	}
+	auditStorePQ := &audit.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	auditService := &audit.Service{
+		Context:  context,
+		Clock:    clockClock,
+		Store:    auditStorePQ,
+		Database: handle,
+	}
 	cookieFactory := deps.NewCookieFactory(request, trustProxy)
 	storeRedisLogger := idpsession.NewStoreRedisLogger(factory)
 	idpsessionStoreRedis := &idpsession.StoreRedis{
@@ -3718,18 +6603,19 @@ func newWebAppEnterPasswordHandler(p *deps.RequestProvider) http.Handler {
 		Clock:        clockClock,
 		Random:       idpsessionRand,
 	}
-	httpConfig := appConfig.HTTP
 	cookieDef := idpsession.NewSessionCookieDef(httpConfig, sessionConfig)
 	mfaCookieDef := mfa.NewDeviceTokenCookieDef(httpConfig, authenticationConfig)
 	interactionContext := &interaction.Context{
 		Database:                 sqlExecutor,
 		Clock:                    clockClock,
 		Config:                   appConfig,
+		RemoteIP:                 remoteIP,
 		Identities:               identityFacade,
 		Authenticators:           authenticatorFacade,
 		AnonymousIdentities:      anonymousProvider,
 		OOBAuthenticators:        oobProvider,
 		OOBCodeSender:            codeSender,
+		WebAuthnAuthenticators:   webauthnProvider,
 		OAuthProviderFactory:     oAuthProviderFactory,
 		MFA:                      mfaService,
 		ForgotPassword:           forgotpasswordProvider,
@@ -3740,6 +6626,9 @@ func newWebAppEnterPasswordHandler(p *deps.RequestProvider) http.Handler {
 		Challenges:               challengeProvider,
 		Users:                    userProvider,
 		Hooks:                    hookProvider,
+		RateLimiter:              limiter,
+		Lockout:                  lockoutService,
+		Audit:                    auditService,
 		CookieFactory:            cookieFactory,
 		Sessions:                 idpsessionProvider,
 		SessionCookie:            cookieDef,
@@ -3763,16 +6652,16 @@ func newWebAppEnterPasswordHandler(p *deps.RequestProvider) http.Handler {
 		CookieFactory: cookieFactory,
 		UATokenCookie: webappCookieDef,
 	}
-	enterPasswordHandler := &webapp2.EnterPasswordHandler{
+	enterTOTPHandler := &webapp2.EnterTOTPHandler{
 		Database:      handle,
 		BaseViewModel: baseViewModeler,
 		Renderer:      responseRenderer,
 		WebApp:        webappService,
 	}
-	return enterPasswordHandler
+	return enterTOTPHandler
 }
 
-func newWebAppCreatePasswordHandler(p *deps.RequestProvider) http.Handler {
+func newWebAppSetupWebAuthnHandler(p *deps.RequestProvider) http.Handler {
 	appProvider := p.AppProvider
 	handle := appProvider.Database
 	rootProvider := appProvider.RootProvider
@@ -3815,6 +6704,8 @@ func newWebAppCreatePasswordHandler(p *deps.RequestProvider) http.Handler {
 		Database: handle,
 	}
 	clockClock := _wireSystemClockValue
+	trustProxy := environmentConfig.TrustProxy
+	remoteIP := deps.ProvideRemoteIP(request, trustProxy)
 	authenticationConfig := appConfig.Authentication
 	identityConfig := appConfig.Identity
 	secretConfig := config.SecretConfig
@@ -3898,6 +6789,7 @@ func newWebAppCreatePasswordHandler(p *deps.RequestProvider) http.Handler {
 		PasswordHistory: historyStore,
 		PasswordChecker: passwordChecker,
 		TaskQueue:       queue,
+		Database:        handle,
 	}
 	totpStore := &totp.Store{
 		SQLBuilder:  sqlBuilder,
@@ -3919,11 +6811,29 @@ func newWebAppCreatePasswordHandler(p *deps.RequestProvider) http.Handler {
 		Store:  oobStore,
 		Clock:  clockClock,
 	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
 	service3 := &service2.Service{
 		Store:    serviceStore,
 		Password: passwordProvider,
 		TOTP:     totpProvider,
 		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
 	}
 	verificationLogger := verification.NewLogger(factory)
 	verificationConfig := appConfig.Verification
@@ -3955,7 +6865,7 @@ func newWebAppCreatePasswordHandler(p *deps.RequestProvider) http.Handler {
 	authenticatorFacade := facade.AuthenticatorFacade{
 		Coordinator: coordinator,
 	}
-	trustProxy := environmentConfig.TrustProxy
+	rateLimitConfig := appConfig.RateLimit
 	mainOriginProvider := &MainOriginProvider{
 		Request:    request,
 		TrustProxy: trustProxy,
@@ -3963,11 +6873,23 @@ func newWebAppCreatePasswordHandler(p *deps.RequestProvider) http.Handler {
 	endpointsProvider := &EndpointsProvider{
 		OriginProvider: mainOriginProvider,
 	}
+	ratelimitLogger := ratelimit.NewLogger(factory)
+	storageRedis := &ratelimit.StorageRedis{
+		AppID: appID,
+		Redis: redisHandle,
+		Clock: clockClock,
+	}
+	limiter := &ratelimit.Limiter{
+		Logger:  ratelimitLogger,
+		Storage: storageRedis,
+	}
 	messageSender := &otp.MessageSender{
 		StaticAssetURLPrefix: staticAssetURLPrefix,
+		RateLimitConfig:      rateLimitConfig,
 		Translation:          translationService,
 		Endpoints:            endpointsProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 	}
 	codeSender := &oob.CodeSender{
 		OTPMessageSender: messageSender,
@@ -3979,6 +6901,7 @@ func newWebAppCreatePasswordHandler(p *deps.RequestProvider) http.Handler {
 	userInfoDecoder := sso.UserInfoDecoder{
 		LoginIDNormalizerFactory: normalizerFactory,
 	}
+	oidcCache := rootProvider.OIDCCache
 	oAuthProviderFactory := &sso.OAuthProviderFactory{
 		Endpoints:                endpointsProvider,
 		IdentityConfig:           identityConfig,
@@ -3987,6 +6910,7 @@ func newWebAppCreatePasswordHandler(p *deps.RequestProvider) http.Handler {
 		Clock:                    clockClock,
 		UserInfoDecoder:          userInfoDecoder,
 		LoginIDNormalizerFactory: normalizerFactory,
+		OIDCCache:                oidcCache,
 	}
 	storeDeviceTokenRedis := &mfa.StoreDeviceTokenRedis{
 		Redis: redisHandle,
@@ -4011,10 +6935,12 @@ func newWebAppCreatePasswordHandler(p *deps.RequestProvider) http.Handler {
 		StaticAssetURLPrefix: staticAssetURLPrefix,
 		Translation:          translationService,
 		Config:               forgotPasswordConfig,
+		RateLimitConfig:      rateLimitConfig,
 		Store:                forgotpasswordStore,
 		Clock:                clockClock,
 		URLs:                 urlProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 		Logger:               providerLogger,
 		Identities:           identityFacade,
 		Authenticators:       authenticatorFacade,
@@ -4042,6 +6968,7 @@ func newWebAppCreatePasswordHandler(p *deps.RequestProvider) http.Handler {
 		Store:        userStore,
 		Identities:   identityFacade,
 		Verification: verificationService,
+		Clock:        clockClock,
 	}
 	rawCommands := &user.RawCommands{
 		Store:                  userStore,
@@ -4055,6 +6982,7 @@ func newWebAppCreatePasswordHandler(p *deps.RequestProvider) http.Handler {
 		Queries:     queries,
 	}
 	hookStore := &hook.Store{
+		Clock:       clockClock,
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
@@ -4077,16 +7005,42 @@ func newWebAppCreatePasswordHandler(p *deps.RequestProvider) http.Handler {
 		Users:     rawProvider,
 		Store:     hookStore,
 		Deliverer: deliverer,
+		TaskQueue: queue,
 	}
+	userProfileConfig := appConfig.UserProfile
 	commands := &user.Commands{
-		Raw:          rawCommands,
-		Hooks:        hookProvider,
-		Verification: verificationService,
+		Raw:               rawCommands,
+		Hooks:             hookProvider,
+		Verification:      verificationService,
+		UserProfileConfig: userProfileConfig,
 	}
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
+	authenticationLockoutConfig := authenticationConfig.Lockout
+	lockoutStorePQ := &lockout.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	lockoutService := &lockout.Service{
+		Config:   authenticationLockoutConfig,
+		Clock:    clockClock,
+		Store:    lockoutStorePQ,
+		Database: handle,
+		Users:    rawProvider,
+		Hooks:    hookProvider,
+	}
+	auditStorePQ := &audit.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	auditService := &audit.Service{
+		Context:  context,
+		Clock:    clockClock,
+		Store:    auditStorePQ,
+		Database: handle,
+	}
 	cookieFactory := deps.NewCookieFactory(request, trustProxy)
 	storeRedisLogger := idpsession.NewStoreRedisLogger(factory)
 	idpsessionStoreRedis := &idpsession.StoreRedis{
@@ -4113,18 +7067,19 @@ func newWebAppCreatePasswordHandler(p *deps.RequestProvider) http.Handler {
 		Clock:        clockClock,
 		Random:       idpsessionRand,
 	}
-	httpConfig := appConfig.HTTP
 	cookieDef := idpsession.NewSessionCookieDef(httpConfig, sessionConfig)
 	mfaCookieDef := mfa.NewDeviceTokenCookieDef(httpConfig, authenticationConfig)
 	interactionContext := &interaction.Context{
 		Database:                 sqlExecutor,
 		Clock:                    clockClock,
 		Config:                   appConfig,
+		RemoteIP:                 remoteIP,
 		Identities:               identityFacade,
 		Authenticators:           authenticatorFacade,
 		AnonymousIdentities:      anonymousProvider,
 		OOBAuthenticators:        oobProvider,
 		OOBCodeSender:            codeSender,
+		WebAuthnAuthenticators:   webauthnProvider,
 		OAuthProviderFactory:     oAuthProviderFactory,
 		MFA:                      mfaService,
 		ForgotPassword:           forgotpasswordProvider,
@@ -4135,6 +7090,9 @@ func newWebAppCreatePasswordHandler(p *deps.RequestProvider) http.Handler {
 		Challenges:               challengeProvider,
 		Users:                    userProvider,
 		Hooks:                    hookProvider,
+		RateLimiter:              limiter,
+		Lockout:                  lockoutService,
+		Audit:                    auditService,
 		CookieFactory:            cookieFactory,
 		Sessions:                 idpsessionProvider,
 		SessionCookie:            cookieDef,
@@ -4158,17 +7116,17 @@ func newWebAppCreatePasswordHandler(p *deps.RequestProvider) http.Handler {
 		CookieFactory: cookieFactory,
 		UATokenCookie: webappCookieDef,
 	}
-	createPasswordHandler := &webapp2.CreatePasswordHandler{
-		Database:       handle,
-		BaseViewModel:  baseViewModeler,
-		Renderer:       responseRenderer,
-		WebApp:         webappService,
-		PasswordPolicy: passwordChecker,
+	setupWebAuthnHandler := &webapp2.SetupWebAuthnHandler{
+		Database:      handle,
+		BaseViewModel: baseViewModeler,
+		Renderer:      responseRenderer,
+		WebApp:        webappService,
+		Clock:         clockClock,
 	}
-	return createPasswordHandler
+	return setupWebAuthnHandler
 }
 
-func newWebAppSetupTOTPHandler(p *deps.RequestProvider) http.Handler {
+func newWebAppEnterWebAuthnHandler(p *deps.RequestProvider) http.Handler {
 	appProvider := p.AppProvider
 	handle := appProvider.Database
 	rootProvider := appProvider.RootProvider
@@ -4211,6 +7169,8 @@ func newWebAppSetupTOTPHandler(p *deps.RequestProvider) http.Handler {
 		Database: handle,
 	}
 	clockClock := _wireSystemClockValue
+	trustProxy := environmentConfig.TrustProxy
+	remoteIP := deps.ProvideRemoteIP(request, trustProxy)
 	authenticationConfig := appConfig.Authentication
 	identityConfig := appConfig.Identity
 	secretConfig := config.SecretConfig
@@ -4294,6 +7254,7 @@ func newWebAppSetupTOTPHandler(p *deps.RequestProvider) http.Handler {
 		PasswordHistory: historyStore,
 		PasswordChecker: passwordChecker,
 		TaskQueue:       queue,
+		Database:        handle,
 	}
 	totpStore := &totp.Store{
 		SQLBuilder:  sqlBuilder,
@@ -4315,11 +7276,29 @@ func newWebAppSetupTOTPHandler(p *deps.RequestProvider) http.Handler {
 		Store:  oobStore,
 		Clock:  clockClock,
 	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
 	service3 := &service2.Service{
 		Store:    serviceStore,
 		Password: passwordProvider,
 		TOTP:     totpProvider,
 		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
 	}
 	verificationLogger := verification.NewLogger(factory)
 	verificationConfig := appConfig.Verification
@@ -4351,7 +7330,7 @@ func newWebAppSetupTOTPHandler(p *deps.RequestProvider) http.Handler {
 	authenticatorFacade := facade.AuthenticatorFacade{
 		Coordinator: coordinator,
 	}
-	trustProxy := environmentConfig.TrustProxy
+	rateLimitConfig := appConfig.RateLimit
 	mainOriginProvider := &MainOriginProvider{
 		Request:    request,
 		TrustProxy: trustProxy,
@@ -4359,11 +7338,23 @@ func newWebAppSetupTOTPHandler(p *deps.RequestProvider) http.Handler {
 	endpointsProvider := &EndpointsProvider{
 		OriginProvider: mainOriginProvider,
 	}
+	ratelimitLogger := ratelimit.NewLogger(factory)
+	storageRedis := &ratelimit.StorageRedis{
+		AppID: appID,
+		Redis: redisHandle,
+		Clock: clockClock,
+	}
+	limiter := &ratelimit.Limiter{
+		Logger:  ratelimitLogger,
+		Storage: storageRedis,
+	}
 	messageSender := &otp.MessageSender{
 		StaticAssetURLPrefix: staticAssetURLPrefix,
+		RateLimitConfig:      rateLimitConfig,
 		Translation:          translationService,
 		Endpoints:            endpointsProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 	}
 	codeSender := &oob.CodeSender{
 		OTPMessageSender: messageSender,
@@ -4375,6 +7366,7 @@ func newWebAppSetupTOTPHandler(p *deps.RequestProvider) http.Handler {
 	userInfoDecoder := sso.UserInfoDecoder{
 		LoginIDNormalizerFactory: normalizerFactory,
 	}
+	oidcCache := rootProvider.OIDCCache
 	oAuthProviderFactory := &sso.OAuthProviderFactory{
 		Endpoints:                endpointsProvider,
 		IdentityConfig:           identityConfig,
@@ -4383,6 +7375,7 @@ func newWebAppSetupTOTPHandler(p *deps.RequestProvider) http.Handler {
 		Clock:                    clockClock,
 		UserInfoDecoder:          userInfoDecoder,
 		LoginIDNormalizerFactory: normalizerFactory,
+		OIDCCache:                oidcCache,
 	}
 	storeDeviceTokenRedis := &mfa.StoreDeviceTokenRedis{
 		Redis: redisHandle,
@@ -4407,10 +7400,12 @@ func newWebAppSetupTOTPHandler(p *deps.RequestProvider) http.Handler {
 		StaticAssetURLPrefix: staticAssetURLPrefix,
 		Translation:          translationService,
 		Config:               forgotPasswordConfig,
+		RateLimitConfig:      rateLimitConfig,
 		Store:                forgotpasswordStore,
 		Clock:                clockClock,
 		URLs:                 urlProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 		Logger:               providerLogger,
 		Identities:           identityFacade,
 		Authenticators:       authenticatorFacade,
@@ -4438,6 +7433,7 @@ func newWebAppSetupTOTPHandler(p *deps.RequestProvider) http.Handler {
 		Store:        userStore,
 		Identities:   identityFacade,
 		Verification: verificationService,
+		Clock:        clockClock,
 	}
 	rawCommands := &user.RawCommands{
 		Store:                  userStore,
@@ -4451,6 +7447,7 @@ func newWebAppSetupTOTPHandler(p *deps.RequestProvider) http.Handler {
 		Queries:     queries,
 	}
 	hookStore := &hook.Store{
+		Clock:       clockClock,
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
@@ -4473,16 +7470,42 @@ func newWebAppSetupTOTPHandler(p *deps.RequestProvider) http.Handler {
 		Users:     rawProvider,
 		Store:     hookStore,
 		Deliverer: deliverer,
+		TaskQueue: queue,
 	}
+	userProfileConfig := appConfig.UserProfile
 	commands := &user.Commands{
-		Raw:          rawCommands,
-		Hooks:        hookProvider,
-		Verification: verificationService,
+		Raw:               rawCommands,
+		Hooks:             hookProvider,
+		Verification:      verificationService,
+		UserProfileConfig: userProfileConfig,
 	}
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
+	authenticationLockoutConfig := authenticationConfig.Lockout
+	lockoutStorePQ := &lockout.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	lockoutService := &lockout.Service{
+		Config:   authenticationLockoutConfig,
+		Clock:    clockClock,
+		Store:    lockoutStorePQ,
+		Database: handle,
+		Users:    rawProvider,
+		Hooks:    hookProvider,
+	}
+	auditStorePQ := &audit.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	auditService := &audit.Service{
+		Context:  context,
+		Clock:    clockClock,
+		Store:    auditStorePQ,
+		Database: handle,
+	}
 	cookieFactory := deps.NewCookieFactory(request, trustProxy)
 	storeRedisLogger := idpsession.NewStoreRedisLogger(factory)
 	idpsessionStoreRedis := &idpsession.StoreRedis{
@@ -4509,18 +7532,19 @@ func newWebAppSetupTOTPHandler(p *deps.RequestProvider) http.Handler {
 		Clock:        clockClock,
 		Random:       idpsessionRand,
 	}
-	httpConfig := appConfig.HTTP
 	cookieDef := idpsession.NewSessionCookieDef(httpConfig, sessionConfig)
 	mfaCookieDef := mfa.NewDeviceTokenCookieDef(httpConfig, authenticationConfig)
 	interactionContext := &interaction.Context{
 		Database:                 sqlExecutor,
 		Clock:                    clockClock,
 		Config:                   appConfig,
+		RemoteIP:                 remoteIP,
 		Identities:               identityFacade,
 		Authenticators:           authenticatorFacade,
 		AnonymousIdentities:      anonymousProvider,
 		OOBAuthenticators:        oobProvider,
 		OOBCodeSender:            codeSender,
+		WebAuthnAuthenticators:   webauthnProvider,
 		OAuthProviderFactory:     oAuthProviderFactory,
 		MFA:                      mfaService,
 		ForgotPassword:           forgotpasswordProvider,
@@ -4531,6 +7555,9 @@ func newWebAppSetupTOTPHandler(p *deps.RequestProvider) http.Handler {
 		Challenges:               challengeProvider,
 		Users:                    userProvider,
 		Hooks:                    hookProvider,
+		RateLimiter:              limiter,
+		Lockout:                  lockoutService,
+		Audit:                    auditService,
 		CookieFactory:            cookieFactory,
 		Sessions:                 idpsessionProvider,
 		SessionCookie:            cookieDef,
@@ -4554,18 +7581,16 @@ func newWebAppSetupTOTPHandler(p *deps.RequestProvider) http.Handler {
 		CookieFactory: cookieFactory,
 		UATokenCookie: webappCookieDef,
 	}
-	setupTOTPHandler := &webapp2.SetupTOTPHandler{
+	enterWebAuthnHandler := &webapp2.EnterWebAuthnHandler{
 		Database:      handle,
 		BaseViewModel: baseViewModeler,
 		Renderer:      responseRenderer,
 		WebApp:        webappService,
-		Clock:         clockClock,
-		Endpoints:     endpointsProvider,
 	}
-	return setupTOTPHandler
+	return enterWebAuthnHandler
 }
 
-func newWebAppEnterTOTPHandler(p *deps.RequestProvider) http.Handler {
+func newWebAppSetupOOBOTPHandler(p *deps.RequestProvider) http.Handler {
 	appProvider := p.AppProvider
 	handle := appProvider.Database
 	rootProvider := appProvider.RootProvider
@@ -4608,6 +7633,8 @@ func newWebAppEnterTOTPHandler(p *deps.RequestProvider) http.Handler {
 		Database: handle,
 	}
 	clockClock := _wireSystemClockValue
+	trustProxy := environmentConfig.TrustProxy
+	remoteIP := deps.ProvideRemoteIP(request, trustProxy)
 	authenticationConfig := appConfig.Authentication
 	identityConfig := appConfig.Identity
 	secretConfig := config.SecretConfig
@@ -4691,6 +7718,7 @@ func newWebAppEnterTOTPHandler(p *deps.RequestProvider) http.Handler {
 		PasswordHistory: historyStore,
 		PasswordChecker: passwordChecker,
 		TaskQueue:       queue,
+		Database:        handle,
 	}
 	totpStore := &totp.Store{
 		SQLBuilder:  sqlBuilder,
@@ -4712,11 +7740,29 @@ func newWebAppEnterTOTPHandler(p *deps.RequestProvider) http.Handler {
 		Store:  oobStore,
 		Clock:  clockClock,
 	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
 	service3 := &service2.Service{
 		Store:    serviceStore,
 		Password: passwordProvider,
 		TOTP:     totpProvider,
 		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
 	}
 	verificationLogger := verification.NewLogger(factory)
 	verificationConfig := appConfig.Verification
@@ -4748,7 +7794,7 @@ func newWebAppEnterTOTPHandler(p *deps.RequestProvider) http.Handler {
 	authenticatorFacade := facade.AuthenticatorFacade{
 		Coordinator: coordinator,
 	}
-	trustProxy := environmentConfig.TrustProxy
+	rateLimitConfig := appConfig.RateLimit
 	mainOriginProvider := &MainOriginProvider{
 		Request:    request,
 		TrustProxy: trustProxy,
@@ -4756,11 +7802,23 @@ func newWebAppEnterTOTPHandler(p *deps.RequestProvider) http.Handler {
 	endpointsProvider := &EndpointsProvider{
 		OriginProvider: mainOriginProvider,
 	}
+	ratelimitLogger := ratelimit.NewLogger(factory)
+	storageRedis := &ratelimit.StorageRedis{
+		AppID: appID,
+		Redis: redisHandle,
+		Clock: clockClock,
+	}
+	limiter := &ratelimit.Limiter{
+		Logger:  ratelimitLogger,
+		Storage: storageRedis,
+	}
 	messageSender := &otp.MessageSender{
 		StaticAssetURLPrefix: staticAssetURLPrefix,
+		RateLimitConfig:      rateLimitConfig,
 		Translation:          translationService,
 		Endpoints:            endpointsProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 	}
 	codeSender := &oob.CodeSender{
 		OTPMessageSender: messageSender,
@@ -4772,6 +7830,7 @@ func newWebAppEnterTOTPHandler(p *deps.RequestProvider) http.Handler {
 	userInfoDecoder := sso.UserInfoDecoder{
 		LoginIDNormalizerFactory: normalizerFactory,
 	}
+	oidcCache := rootProvider.OIDCCache
 	oAuthProviderFactory := &sso.OAuthProviderFactory{
 		Endpoints:                endpointsProvider,
 		IdentityConfig:           identityConfig,
@@ -4780,6 +7839,7 @@ func newWebAppEnterTOTPHandler(p *deps.RequestProvider) http.Handler {
 		Clock:                    clockClock,
 		UserInfoDecoder:          userInfoDecoder,
 		LoginIDNormalizerFactory: normalizerFactory,
+		OIDCCache:                oidcCache,
 	}
 	storeDeviceTokenRedis := &mfa.StoreDeviceTokenRedis{
 		Redis: redisHandle,
@@ -4804,10 +7864,12 @@ func newWebAppEnterTOTPHandler(p *deps.RequestProvider) http.Handler {
 		StaticAssetURLPrefix: staticAssetURLPrefix,
 		Translation:          translationService,
 		Config:               forgotPasswordConfig,
+		RateLimitConfig:      rateLimitConfig,
 		Store:                forgotpasswordStore,
 		Clock:                clockClock,
 		URLs:                 urlProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 		Logger:               providerLogger,
 		Identities:           identityFacade,
 		Authenticators:       authenticatorFacade,
@@ -4835,6 +7897,7 @@ func newWebAppEnterTOTPHandler(p *deps.RequestProvider) http.Handler {
 		Store:        userStore,
 		Identities:   identityFacade,
 		Verification: verificationService,
+		Clock:        clockClock,
 	}
 	rawCommands := &user.RawCommands{
 		Store:                  userStore,
@@ -4848,6 +7911,7 @@ func newWebAppEnterTOTPHandler(p *deps.RequestProvider) http.Handler {
 		Queries:     queries,
 	}
 	hookStore := &hook.Store{
+		Clock:       clockClock,
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
@@ -4870,16 +7934,42 @@ func newWebAppEnterTOTPHandler(p *deps.RequestProvider) http.Handler {
 		Users:     rawProvider,
 		Store:     hookStore,
 		Deliverer: deliverer,
+		TaskQueue: queue,
 	}
+	userProfileConfig := appConfig.UserProfile
 	commands := &user.Commands{
-		Raw:          rawCommands,
-		Hooks:        hookProvider,
-		Verification: verificationService,
+		Raw:               rawCommands,
+		Hooks:             hookProvider,
+		Verification:      verificationService,
+		UserProfileConfig: userProfileConfig,
 	}
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
+	authenticationLockoutConfig := authenticationConfig.Lockout
+	lockoutStorePQ := &lockout.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	lockoutService := &lockout.Service{
+		Config:   authenticationLockoutConfig,
+		Clock:    clockClock,
+		Store:    lockoutStorePQ,
+		Database: handle,
+		Users:    rawProvider,
+		Hooks:    hookProvider,
+	}
+	auditStorePQ := &audit.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	auditService := &audit.Service{
+		Context:  context,
+		Clock:    clockClock,
+		Store:    auditStorePQ,
+		Database: handle,
+	}
 	cookieFactory := deps.NewCookieFactory(request, trustProxy)
 	storeRedisLogger := idpsession.NewStoreRedisLogger(factory)
 	idpsessionStoreRedis := &idpsession.StoreRedis{
@@ -4906,18 +7996,19 @@ func newWebAppEnterTOTPHandler(p *deps.RequestProvider) http.Handler {
 		Clock:        clockClock,
 		Random:       idpsessionRand,
 	}
-	httpConfig := appConfig.HTTP
 	cookieDef := idpsession.NewSessionCookieDef(httpConfig, sessionConfig)
 	mfaCookieDef := mfa.NewDeviceTokenCookieDef(httpConfig, authenticationConfig)
 	interactionContext := &interaction.Context{
 		Database:                 sqlExecutor,
 		Clock:                    clockClock,
 		Config:                   appConfig,
+		RemoteIP:                 remoteIP,
 		Identities:               identityFacade,
 		Authenticators:           authenticatorFacade,
 		AnonymousIdentities:      anonymousProvider,
 		OOBAuthenticators:        oobProvider,
 		OOBCodeSender:            codeSender,
+		WebAuthnAuthenticators:   webauthnProvider,
 		OAuthProviderFactory:     oAuthProviderFactory,
 		MFA:                      mfaService,
 		ForgotPassword:           forgotpasswordProvider,
@@ -4928,6 +8019,9 @@ func newWebAppEnterTOTPHandler(p *deps.RequestProvider) http.Handler {
 		Challenges:               challengeProvider,
 		Users:                    userProvider,
 		Hooks:                    hookProvider,
+		RateLimiter:              limiter,
+		Lockout:                  lockoutService,
+		Audit:                    auditService,
 		CookieFactory:            cookieFactory,
 		Sessions:                 idpsessionProvider,
 		SessionCookie:            cookieDef,
@@ -4951,16 +8045,16 @@ func newWebAppEnterTOTPHandler(p *deps.RequestProvider) http.Handler {
 		CookieFactory: cookieFactory,
 		UATokenCookie: webappCookieDef,
 	}
-	enterTOTPHandler := &webapp2.EnterTOTPHandler{
+	setupOOBOTPHandler := &webapp2.SetupOOBOTPHandler{
 		Database:      handle,
 		BaseViewModel: baseViewModeler,
 		Renderer:      responseRenderer,
 		WebApp:        webappService,
 	}
-	return enterTOTPHandler
+	return setupOOBOTPHandler
 }
 
-func newWebAppSetupOOBOTPHandler(p *deps.RequestProvider) http.Handler {
+func newWebAppEnterOOBOTPHandler(p *deps.RequestProvider) http.Handler {
 	appProvider := p.AppProvider
 	handle := appProvider.Database
 	rootProvider := appProvider.RootProvider
@@ -5003,6 +8097,8 @@ func newWebAppSetupOOBOTPHandler(p *deps.RequestProvider) http.Handler {
 		Database: handle,
 	}
 	clockClock := _wireSystemClockValue
+	trustProxy := environmentConfig.TrustProxy
+	remoteIP := deps.ProvideRemoteIP(request, trustProxy)
 	authenticationConfig := appConfig.Authentication
 	identityConfig := appConfig.Identity
 	secretConfig := config.SecretConfig
@@ -5086,6 +8182,7 @@ func newWebAppSetupOOBOTPHandler(p *deps.RequestProvider) http.Handler {
 		PasswordHistory: historyStore,
 		PasswordChecker: passwordChecker,
 		TaskQueue:       queue,
+		Database:        handle,
 	}
 	totpStore := &totp.Store{
 		SQLBuilder:  sqlBuilder,
@@ -5107,11 +8204,29 @@ func newWebAppSetupOOBOTPHandler(p *deps.RequestProvider) http.Handler {
 		Store:  oobStore,
 		Clock:  clockClock,
 	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
 	service3 := &service2.Service{
 		Store:    serviceStore,
 		Password: passwordProvider,
 		TOTP:     totpProvider,
 		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
 	}
 	verificationLogger := verification.NewLogger(factory)
 	verificationConfig := appConfig.Verification
@@ -5143,7 +8258,7 @@ func newWebAppSetupOOBOTPHandler(p *deps.RequestProvider) http.Handler {
 	authenticatorFacade := facade.AuthenticatorFacade{
 		Coordinator: coordinator,
 	}
-	trustProxy := environmentConfig.TrustProxy
+	rateLimitConfig := appConfig.RateLimit
 	mainOriginProvider := &MainOriginProvider{
 		Request:    request,
 		TrustProxy: trustProxy,
@@ -5151,11 +8266,23 @@ func newWebAppSetupOOBOTPHandler(p *deps.RequestProvider) http.Handler {
 	endpointsProvider := &EndpointsProvider{
 		OriginProvider: mainOriginProvider,
 	}
+	ratelimitLogger := ratelimit.NewLogger(factory)
+	storageRedis := &ratelimit.StorageRedis{
+		AppID: appID,
+		Redis: redisHandle,
+		Clock: clockClock,
+	}
+	limiter := &ratelimit.Limiter{
+		Logger:  ratelimitLogger,
+		Storage: storageRedis,
+	}
 	messageSender := &otp.MessageSender{
 		StaticAssetURLPrefix: staticAssetURLPrefix,
+		RateLimitConfig:      rateLimitConfig,
 		Translation:          translationService,
 		Endpoints:            endpointsProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 	}
 	codeSender := &oob.CodeSender{
 		OTPMessageSender: messageSender,
@@ -5167,6 +8294,7 @@ func newWebAppSetupOOBOTPHandler(p *deps.RequestProvider) http.Handler {
 	userInfoDecoder := sso.UserInfoDecoder{
 		LoginIDNormalizerFactory: normalizerFactory,
 	}
+	oidcCache := rootProvider.OIDCCache
 	oAuthProviderFactory := &sso.OAuthProviderFactory{
 		Endpoints:                endpointsProvider,
 		IdentityConfig:           identityConfig,
@@ -5175,6 +8303,7 @@ func newWebAppSetupOOBOTPHandler(p *deps.RequestProvider) http.Handler {
 		Clock:                    clockClock,
 		UserInfoDecoder:          userInfoDecoder,
 		LoginIDNormalizerFactory: normalizerFactory,
+		OIDCCache:                oidcCache,
 	}
 	storeDeviceTokenRedis := &mfa.StoreDeviceTokenRedis{
 		Redis: redisHandle,
@@ -5199,10 +8328,12 @@ func newWebAppSetupOOBOTPHandler(p *deps.RequestProvider) http.Handler {
 		StaticAssetURLPrefix: staticAssetURLPrefix,
 		Translation:          translationService,
 		Config:               forgotPasswordConfig,
+		RateLimitConfig:      rateLimitConfig,
 		Store:                forgotpasswordStore,
 		Clock:                clockClock,
 		URLs:                 urlProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 		Logger:               providerLogger,
 		Identities:           identityFacade,
 		Authenticators:       authenticatorFacade,
@@ -5230,6 +8361,7 @@ func newWebAppSetupOOBOTPHandler(p *deps.RequestProvider) http.Handler {
 		Store:        userStore,
 		Identities:   identityFacade,
 		Verification: verificationService,
+		Clock:        clockClock,
 	}
 	rawCommands := &user.RawCommands{
 		Store:                  userStore,
@@ -5243,6 +8375,7 @@ func newWebAppSetupOOBOTPHandler(p *deps.RequestProvider) http.Handler {
 		Queries:     queries,
 	}
 	hookStore := &hook.Store{
+		Clock:       clockClock,
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
@@ -5265,16 +8398,42 @@ func newWebAppSetupOOBOTPHandler(p *deps.RequestProvider) http.Handler {
 		Users:     rawProvider,
 		Store:     hookStore,
 		Deliverer: deliverer,
+		TaskQueue: queue,
 	}
+	userProfileConfig := appConfig.UserProfile
 	commands := &user.Commands{
-		Raw:          rawCommands,
-		Hooks:        hookProvider,
-		Verification: verificationService,
+		Raw:               rawCommands,
+		Hooks:             hookProvider,
+		Verification:      verificationService,
+		UserProfileConfig: userProfileConfig,
 	}
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
+	authenticationLockoutConfig := authenticationConfig.Lockout
+	lockoutStorePQ := &lockout.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	lockoutService := &lockout.Service{
+		Config:   authenticationLockoutConfig,
+		Clock:    clockClock,
+		Store:    lockoutStorePQ,
+		Database: handle,
+		Users:    rawProvider,
+		Hooks:    hookProvider,
+	}
+	auditStorePQ := &audit.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	auditService := &audit.Service{
+		Context:  context,
+		Clock:    clockClock,
+		Store:    auditStorePQ,
+		Database: handle,
+	}
 	cookieFactory := deps.NewCookieFactory(request, trustProxy)
 	storeRedisLogger := idpsession.NewStoreRedisLogger(factory)
 	idpsessionStoreRedis := &idpsession.StoreRedis{
@@ -5301,18 +8460,19 @@ func newWebAppSetupOOBOTPHandler(p *deps.RequestProvider) http.Handler {
 		Clock:        clockClock,
 		Random:       idpsessionRand,
 	}
-	httpConfig := appConfig.HTTP
 	cookieDef := idpsession.NewSessionCookieDef(httpConfig, sessionConfig)
 	mfaCookieDef := mfa.NewDeviceTokenCookieDef(httpConfig, authenticationConfig)
 	interactionContext := &interaction.Context{
 		Database:                 sqlExecutor,
 		Clock:                    clockClock,
 		Config:                   appConfig,
+		RemoteIP:                 remoteIP,
 		Identities:               identityFacade,
 		Authenticators:           authenticatorFacade,
 		AnonymousIdentities:      anonymousProvider,
 		OOBAuthenticators:        oobProvider,
 		OOBCodeSender:            codeSender,
+		WebAuthnAuthenticators:   webauthnProvider,
 		OAuthProviderFactory:     oAuthProviderFactory,
 		MFA:                      mfaService,
 		ForgotPassword:           forgotpasswordProvider,
@@ -5323,6 +8483,9 @@ func newWebAppSetupOOBOTPHandler(p *deps.RequestProvider) http.Handler {
 		Challenges:               challengeProvider,
 		Users:                    userProvider,
 		Hooks:                    hookProvider,
+		RateLimiter:              limiter,
+		Lockout:                  lockoutService,
+		Audit:                    auditService,
 		CookieFactory:            cookieFactory,
 		Sessions:                 idpsessionProvider,
 		SessionCookie:            cookieDef,
@@ -5346,16 +8509,16 @@ func newWebAppSetupOOBOTPHandler(p *deps.RequestProvider) http.Handler {
 		CookieFactory: cookieFactory,
 		UATokenCookie: webappCookieDef,
 	}
-	setupOOBOTPHandler := &webapp2.SetupOOBOTPHandler{
+	enterOOBOTPHandler := &webapp2.EnterOOBOTPHandler{
 		Database:      handle,
 		BaseViewModel: baseViewModeler,
 		Renderer:      responseRenderer,
 		WebApp:        webappService,
 	}
-	return setupOOBOTPHandler
+	return enterOOBOTPHandler
 }
 
-func newWebAppEnterOOBOTPHandler(p *deps.RequestProvider) http.Handler {
+func newWebAppEnterRecoveryCodeHandler(p *deps.RequestProvider) http.Handler {
 	appProvider := p.AppProvider
 	handle := appProvider.Database
 	rootProvider := appProvider.RootProvider
@@ -5398,6 +8561,8 @@ func newWebAppEnterOOBOTPHandler(p *deps.RequestProvider) http.Handler {
 		Database: handle,
 	}
 	clockClock := _wireSystemClockValue
+	trustProxy := environmentConfig.TrustProxy
+	remoteIP := deps.ProvideRemoteIP(request, trustProxy)
 	authenticationConfig := appConfig.Authentication
 	identityConfig := appConfig.Identity
 	secretConfig := config.SecretConfig
@@ -5481,6 +8646,7 @@ func newWebAppEnterOOBOTPHandler(p *deps.RequestProvider) http.Handler {
 		PasswordHistory: historyStore,
 		PasswordChecker: passwordChecker,
 		TaskQueue:       queue,
+		Database:        handle,
 	}
 	totpStore := &totp.Store{
 		SQLBuilder:  sqlBuilder,
@@ -5502,11 +8668,29 @@ func newWebAppEnterOOBOTPHandler(p *deps.RequestProvider) http.Handler {
 		Store:  oobStore,
 		Clock:  clockClock,
 	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
 	service3 := &service2.Service{
 		Store:    serviceStore,
 		Password: passwordProvider,
 		TOTP:     totpProvider,
 		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
 	}
 	verificationLogger := verification.NewLogger(factory)
 	verificationConfig := appConfig.Verification
@@ -5538,7 +8722,7 @@ func newWebAppEnterOOBOTPHandler(p *deps.RequestProvider) http.Handler {
 	authenticatorFacade := facade.AuthenticatorFacade{
 		Coordinator: coordinator,
 	}
-	trustProxy := environmentConfig.TrustProxy
+	rateLimitConfig := appConfig.RateLimit
 	mainOriginProvider := &MainOriginProvider{
 		Request:    request,
 		TrustProxy: trustProxy,
@@ -5546,11 +8730,23 @@ func newWebAppEnterOOBOTPHandler(p *deps.RequestProvider) http.Handler {
 	endpointsProvider := &EndpointsProvider{
 		OriginProvider: mainOriginProvider,
 	}
+	ratelimitLogger := ratelimit.NewLogger(factory)
+	storageRedis := &ratelimit.StorageRedis{
+		AppID: appID,
+		Redis: redisHandle,
+		Clock: clockClock,
+	}
+	limiter := &ratelimit.Limiter{
+		Logger:  ratelimitLogger,
+		Storage: storageRedis,
+	}
 	messageSender := &otp.MessageSender{
 		StaticAssetURLPrefix: staticAssetURLPrefix,
+		RateLimitConfig:      rateLimitConfig,
 		Translation:          translationService,
 		Endpoints:            endpointsProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 	}
 	codeSender := &oob.CodeSender{
 		OTPMessageSender: messageSender,
@@ -5562,6 +8758,7 @@ func newWebAppEnterOOBOTPHandler(p *deps.RequestProvider) http.Handler {
 	userInfoDecoder := sso.UserInfoDecoder{
 		LoginIDNormalizerFactory: normalizerFactory,
 	}
+	oidcCache := rootProvider.OIDCCache
 	oAuthProviderFactory := &sso.OAuthProviderFactory{
 		Endpoints:                endpointsProvider,
 		IdentityConfig:           identityConfig,
@@ -5570,6 +8767,7 @@ func newWebAppEnterOOBOTPHandler(p *deps.RequestProvider) http.Handler {
 		Clock:                    clockClock,
 		UserInfoDecoder:          userInfoDecoder,
 		LoginIDNormalizerFactory: normalizerFactory,
+		OIDCCache:                oidcCache,
 	}
 	storeDeviceTokenRedis := &mfa.StoreDeviceTokenRedis{
 		Redis: redisHandle,
@@ -5594,10 +8792,12 @@ func newWebAppEnterOOBOTPHandler(p *deps.RequestProvider) http.Handler {
 		StaticAssetURLPrefix: staticAssetURLPrefix,
 		Translation:          translationService,
 		Config:               forgotPasswordConfig,
+		RateLimitConfig:      rateLimitConfig,
 		Store:                forgotpasswordStore,
 		Clock:                clockClock,
 		URLs:                 urlProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 		Logger:               providerLogger,
 		Identities:           identityFacade,
 		Authenticators:       authenticatorFacade,
@@ -5625,6 +8825,7 @@ func newWebAppEnterOOBOTPHandler(p *deps.RequestProvider) http.Handler {
 		Store:        userStore,
 		Identities:   identityFacade,
 		Verification: verificationService,
+		Clock:        clockClock,
 	}
 	rawCommands := &user.RawCommands{
 		Store:                  userStore,
@@ -5638,6 +8839,7 @@ func newWebAppEnterOOBOTPHandler(p *deps.RequestProvider) http.Handler {
 		Queries:     queries,
 	}
 	hookStore := &hook.Store{
+		Clock:       clockClock,
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
@@ -5660,16 +8862,42 @@ func newWebAppEnterOOBOTPHandler(p *deps.RequestProvider) http.Handler {
 		Users:     rawProvider,
 		Store:     hookStore,
 		Deliverer: deliverer,
+		TaskQueue: queue,
 	}
+	userProfileConfig := appConfig.UserProfile
 	commands := &user.Commands{
-		Raw:          rawCommands,
-		Hooks:        hookProvider,
-		Verification: verificationService,
+		Raw:               rawCommands,
+		Hooks:             hookProvider,
+		Verification:      verificationService,
+		UserProfileConfig: userProfileConfig,
 	}
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
+	authenticationLockoutConfig := authenticationConfig.Lockout
+	lockoutStorePQ := &lockout.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	lockoutService := &lockout.Service{
+		Config:   authenticationLockoutConfig,
+		Clock:    clockClock,
+		Store:    lockoutStorePQ,
+		Database: handle,
+		Users:    rawProvider,
+		Hooks:    hookProvider,
+	}
+	auditStorePQ := &audit.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	auditService := &audit.Service{
+		Context:  context,
+		Clock:    clockClock,
+		Store:    auditStorePQ,
+		Database: handle,
+	}
 	cookieFactory := deps.NewCookieFactory(request, trustProxy)
 	storeRedisLogger := idpsession.NewStoreRedisLogger(factory)
 	idpsessionStoreRedis := &idpsession.StoreRedis{
@@ -5696,18 +8924,19 @@ func newWebAppEnterOOBOTPHandler(p *deps.RequestProvider) http.Handler {
 		Clock:        clockClock,
 		Random:       idpsessionRand,
 	}
-	httpConfig := appConfig.HTTP
 	cookieDef := idpsession.NewSessionCookieDef(httpConfig, sessionConfig)
 	mfaCookieDef := mfa.NewDeviceTokenCookieDef(httpConfig, authenticationConfig)
 	interactionContext := &interaction.Context{
 		Database:                 sqlExecutor,
 		Clock:                    clockClock,
 		Config:                   appConfig,
+		RemoteIP:                 remoteIP,
 		Identities:               identityFacade,
 		Authenticators:           authenticatorFacade,
 		AnonymousIdentities:      anonymousProvider,
 		OOBAuthenticators:        oobProvider,
 		OOBCodeSender:            codeSender,
+		WebAuthnAuthenticators:   webauthnProvider,
 		OAuthProviderFactory:     oAuthProviderFactory,
 		MFA:                      mfaService,
 		ForgotPassword:           forgotpasswordProvider,
@@ -5718,6 +8947,9 @@ func newWebAppEnterOOBOTPHandler(p *deps.RequestProvider) http.Handler {
 		Challenges:               challengeProvider,
 		Users:                    userProvider,
 		Hooks:                    hookProvider,
+		RateLimiter:              limiter,
+		Lockout:                  lockoutService,
+		Audit:                    auditService,
 		CookieFactory:            cookieFactory,
 		Sessions:                 idpsessionProvider,
 		SessionCookie:            cookieDef,
@@ -5741,16 +8973,16 @@ func newWebAppEnterOOBOTPHandler(p *deps.RequestProvider) http.Handler {
 		CookieFactory: cookieFactory,
 		UATokenCookie: webappCookieDef,
 	}
-	enterOOBOTPHandler := &webapp2.EnterOOBOTPHandler{
+	enterRecoveryCodeHandler := &webapp2.EnterRecoveryCodeHandler{
 		Database:      handle,
 		BaseViewModel: baseViewModeler,
 		Renderer:      responseRenderer,
 		WebApp:        webappService,
 	}
-	return enterOOBOTPHandler
+	return enterRecoveryCodeHandler
 }
 
-func newWebAppEnterRecoveryCodeHandler(p *deps.RequestProvider) http.Handler {
+func newWebAppSetupRecoveryCodeHandler(p *deps.RequestProvider) http.Handler {
 	appProvider := p.AppProvider
 	handle := appProvider.Database
 	rootProvider := appProvider.RootProvider
@@ -5793,6 +9025,8 @@ func newWebAppEnterRecoveryCodeHandler(p *deps.RequestProvider) http.Handler {
 		Database: handle,
 	}
 	clockClock := _wireSystemClockValue
+	trustProxy := environmentConfig.TrustProxy
+	remoteIP := deps.ProvideRemoteIP(request, trustProxy)
 	authenticationConfig := appConfig.Authentication
 	identityConfig := appConfig.Identity
 	secretConfig := config.SecretConfig
@@ -5876,6 +9110,7 @@ func newWebAppEnterRecoveryCodeHandler(p *deps.RequestProvider) http.Handler {
 		PasswordHistory: historyStore,
 		PasswordChecker: passwordChecker,
 		TaskQueue:       queue,
+		Database:        handle,
 	}
 	totpStore := &totp.Store{
 		SQLBuilder:  sqlBuilder,
@@ -5897,11 +9132,29 @@ func newWebAppEnterRecoveryCodeHandler(p *deps.RequestProvider) http.Handler {
 		Store:  oobStore,
 		Clock:  clockClock,
 	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
 	service3 := &service2.Service{
 		Store:    serviceStore,
 		Password: passwordProvider,
 		TOTP:     totpProvider,
 		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
 	}
 	verificationLogger := verification.NewLogger(factory)
 	verificationConfig := appConfig.Verification
@@ -5933,7 +9186,7 @@ func newWebAppEnterRecoveryCodeHandler(p *deps.RequestProvider) http.Handler {
 	authenticatorFacade := facade.AuthenticatorFacade{
 		Coordinator: coordinator,
 	}
-	trustProxy := environmentConfig.TrustProxy
+	rateLimitConfig := appConfig.RateLimit
 	mainOriginProvider := &MainOriginProvider{
 		Request:    request,
 		TrustProxy: trustProxy,
@@ -5941,11 +9194,23 @@ func newWebAppEnterRecoveryCodeHandler(p *deps.RequestProvider) http.Handler {
 	endpointsProvider := &EndpointsProvider{
 		OriginProvider: mainOriginProvider,
 	}
+	ratelimitLogger := ratelimit.NewLogger(factory)
+	storageRedis := &ratelimit.StorageRedis{
+		AppID: appID,
+		Redis: redisHandle,
+		Clock: clockClock,
+	}
+	limiter := &ratelimit.Limiter{
+		Logger:  ratelimitLogger,
+		Storage: storageRedis,
+	}
 	messageSender := &otp.MessageSender{
 		StaticAssetURLPrefix: staticAssetURLPrefix,
+		RateLimitConfig:      rateLimitConfig,
 		Translation:          translationService,
 		Endpoints:            endpointsProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 	}
 	codeSender := &oob.CodeSender{
 		OTPMessageSender: messageSender,
@@ -5957,6 +9222,7 @@ func newWebAppEnterRecoveryCodeHandler(p *deps.RequestProvider) http.Handler {
 	userInfoDecoder := sso.UserInfoDecoder{
 		LoginIDNormalizerFactory: normalizerFactory,
 	}
+	oidcCache := rootProvider.OIDCCache
 	oAuthProviderFactory := &sso.OAuthProviderFactory{
 		Endpoints:                endpointsProvider,
 		IdentityConfig:           identityConfig,
@@ -5965,6 +9231,7 @@ func newWebAppEnterRecoveryCodeHandler(p *deps.RequestProvider) http.Handler {
 		Clock:                    clockClock,
 		UserInfoDecoder:          userInfoDecoder,
 		LoginIDNormalizerFactory: normalizerFactory,
+		OIDCCache:                oidcCache,
 	}
 	storeDeviceTokenRedis := &mfa.StoreDeviceTokenRedis{
 		Redis: redisHandle,
@@ -5989,10 +9256,12 @@ func newWebAppEnterRecoveryCodeHandler(p *deps.RequestProvider) http.Handler {
 		StaticAssetURLPrefix: staticAssetURLPrefix,
 		Translation:          translationService,
 		Config:               forgotPasswordConfig,
+		RateLimitConfig:      rateLimitConfig,
 		Store:                forgotpasswordStore,
 		Clock:                clockClock,
 		URLs:                 urlProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 		Logger:               providerLogger,
 		Identities:           identityFacade,
 		Authenticators:       authenticatorFacade,
@@ -6020,6 +9289,7 @@ func newWebAppEnterRecoveryCodeHandler(p *deps.RequestProvider) http.Handler {
 		Store:        userStore,
 		Identities:   identityFacade,
 		Verification: verificationService,
+		Clock:        clockClock,
 	}
 	rawCommands := &user.RawCommands{
 		Store:                  userStore,
@@ -6033,6 +9303,7 @@ func newWebAppEnterRecoveryCodeHandler(p *deps.RequestProvider) http.Handler {
 		Queries:     queries,
 	}
 	hookStore := &hook.Store{
+		Clock:       clockClock,
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
@@ -6055,16 +9326,42 @@ func newWebAppEnterRecoveryCodeHandler(p *deps.RequestProvider) http.Handler {
 		Users:     rawProvider,
 		Store:     hookStore,
 		Deliverer: deliverer,
+		TaskQueue: queue,
 	}
+	userProfileConfig := appConfig.UserProfile
 	commands := &user.Commands{
-		Raw:          rawCommands,
-		Hooks:        hookProvider,
-		Verification: verificationService,
+		Raw:               rawCommands,
+		Hooks:             hookProvider,
+		Verification:      verificationService,
+		UserProfileConfig: userProfileConfig,
 	}
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
+	authenticationLockoutConfig := authenticationConfig.Lockout
+	lockoutStorePQ := &lockout.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	lockoutService := &lockout.Service{
+		Config:   authenticationLockoutConfig,
+		Clock:    clockClock,
+		Store:    lockoutStorePQ,
+		Database: handle,
+		Users:    rawProvider,
+		Hooks:    hookProvider,
+	}
+	auditStorePQ := &audit.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	auditService := &audit.Service{
+		Context:  context,
+		Clock:    clockClock,
+		Store:    auditStorePQ,
+		Database: handle,
+	}
 	cookieFactory := deps.NewCookieFactory(request, trustProxy)
 	storeRedisLogger := idpsession.NewStoreRedisLogger(factory)
 	idpsessionStoreRedis := &idpsession.StoreRedis{
@@ -6091,18 +9388,19 @@ func newWebAppEnterRecoveryCodeHandler(p *deps.RequestProvider) http.Handler {
 		Clock:        clockClock,
 		Random:       idpsessionRand,
 	}
-	httpConfig := appConfig.HTTP
 	cookieDef := idpsession.NewSessionCookieDef(httpConfig, sessionConfig)
 	mfaCookieDef := mfa.NewDeviceTokenCookieDef(httpConfig, authenticationConfig)
 	interactionContext := &interaction.Context{
 		Database:                 sqlExecutor,
 		Clock:                    clockClock,
 		Config:                   appConfig,
+		RemoteIP:                 remoteIP,
 		Identities:               identityFacade,
 		Authenticators:           authenticatorFacade,
 		AnonymousIdentities:      anonymousProvider,
 		OOBAuthenticators:        oobProvider,
 		OOBCodeSender:            codeSender,
+		WebAuthnAuthenticators:   webauthnProvider,
 		OAuthProviderFactory:     oAuthProviderFactory,
 		MFA:                      mfaService,
 		ForgotPassword:           forgotpasswordProvider,
@@ -6113,6 +9411,9 @@ func newWebAppEnterRecoveryCodeHandler(p *deps.RequestProvider) http.Handler {
 		Challenges:               challengeProvider,
 		Users:                    userProvider,
 		Hooks:                    hookProvider,
+		RateLimiter:              limiter,
+		Lockout:                  lockoutService,
+		Audit:                    auditService,
 		CookieFactory:            cookieFactory,
 		Sessions:                 idpsessionProvider,
 		SessionCookie:            cookieDef,
@@ -6136,16 +9437,16 @@ func newWebAppEnterRecoveryCodeHandler(p *deps.RequestProvider) http.Handler {
 		CookieFactory: cookieFactory,
 		UATokenCookie: webappCookieDef,
 	}
-	enterRecoveryCodeHandler := &webapp2.EnterRecoveryCodeHandler{
+	setupRecoveryCodeHandler := &webapp2.SetupRecoveryCodeHandler{
 		Database:      handle,
 		BaseViewModel: baseViewModeler,
 		Renderer:      responseRenderer,
 		WebApp:        webappService,
 	}
-	return enterRecoveryCodeHandler
+	return setupRecoveryCodeHandler
 }
 
-func newWebAppSetupRecoveryCodeHandler(p *deps.RequestProvider) http.Handler {
+func newWebAppVerifyIdentityHandler(p *deps.RequestProvider) http.Handler {
 	appProvider := p.AppProvider
 	handle := appProvider.Database
 	rootProvider := appProvider.RootProvider
@@ -6188,6 +9489,8 @@ func newWebAppSetupRecoveryCodeHandler(p *deps.RequestProvider) http.Handler {
 		Database: handle,
 	}
 	clockClock := _wireSystemClockValue
+	trustProxy := environmentConfig.TrustProxy
+	remoteIP := deps.ProvideRemoteIP(request, trustProxy)
 	authenticationConfig := appConfig.Authentication
 	identityConfig := appConfig.Identity
 	secretConfig := config.SecretConfig
@@ -6271,6 +9574,7 @@ func newWebAppSetupRecoveryCodeHandler(p *deps.RequestProvider) http.Handler {
 		PasswordHistory: historyStore,
 		PasswordChecker: passwordChecker,
 		TaskQueue:       queue,
+		Database:        handle,
 	}
 	totpStore := &totp.Store{
 		SQLBuilder:  sqlBuilder,
@@ -6292,11 +9596,29 @@ func newWebAppSetupRecoveryCodeHandler(p *deps.RequestProvider) http.Handler {
 		Store:  oobStore,
 		Clock:  clockClock,
 	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
 	service3 := &service2.Service{
 		Store:    serviceStore,
 		Password: passwordProvider,
 		TOTP:     totpProvider,
 		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
 	}
 	verificationLogger := verification.NewLogger(factory)
 	verificationConfig := appConfig.Verification
@@ -6328,7 +9650,7 @@ func newWebAppSetupRecoveryCodeHandler(p *deps.RequestProvider) http.Handler {
 	authenticatorFacade := facade.AuthenticatorFacade{
 		Coordinator: coordinator,
 	}
-	trustProxy := environmentConfig.TrustProxy
+	rateLimitConfig := appConfig.RateLimit
 	mainOriginProvider := &MainOriginProvider{
 		Request:    request,
 		TrustProxy: trustProxy,
@@ -6336,11 +9658,23 @@ func newWebAppSetupRecoveryCodeHandler(p *deps.RequestProvider) http.Handler {
 	endpointsProvider := &EndpointsProvider{
 		OriginProvider: mainOriginProvider,
 	}
+	ratelimitLogger := ratelimit.NewLogger(factory)
+	storageRedis := &ratelimit.StorageRedis{
+		AppID: appID,
+		Redis: redisHandle,
+		Clock: clockClock,
+	}
+	limiter := &ratelimit.Limiter{
+		Logger:  ratelimitLogger,
+		Storage: storageRedis,
+	}
 	messageSender := &otp.MessageSender{
 		StaticAssetURLPrefix: staticAssetURLPrefix,
+		RateLimitConfig:      rateLimitConfig,
 		Translation:          translationService,
 		Endpoints:            endpointsProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 	}
 	codeSender := &oob.CodeSender{
 		OTPMessageSender: messageSender,
@@ -6352,6 +9686,7 @@ func newWebAppSetupRecoveryCodeHandler(p *deps.RequestProvider) http.Handler {
 	userInfoDecoder := sso.UserInfoDecoder{
 		LoginIDNormalizerFactory: normalizerFactory,
 	}
+	oidcCache := rootProvider.OIDCCache
 	oAuthProviderFactory := &sso.OAuthProviderFactory{
 		Endpoints:                endpointsProvider,
 		IdentityConfig:           identityConfig,
@@ -6360,6 +9695,7 @@ func newWebAppSetupRecoveryCodeHandler(p *deps.RequestProvider) http.Handler {
 		Clock:                    clockClock,
 		UserInfoDecoder:          userInfoDecoder,
 		LoginIDNormalizerFactory: normalizerFactory,
+		OIDCCache:                oidcCache,
 	}
 	storeDeviceTokenRedis := &mfa.StoreDeviceTokenRedis{
 		Redis: redisHandle,
@@ -6384,10 +9720,12 @@ func newWebAppSetupRecoveryCodeHandler(p *deps.RequestProvider) http.Handler {
 		StaticAssetURLPrefix: staticAssetURLPrefix,
 		Translation:          translationService,
 		Config:               forgotPasswordConfig,
+		RateLimitConfig:      rateLimitConfig,
 		Store:                forgotpasswordStore,
 		Clock:                clockClock,
 		URLs:                 urlProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 		Logger:               providerLogger,
 		Identities:           identityFacade,
 		Authenticators:       authenticatorFacade,
@@ -6415,6 +9753,7 @@ func newWebAppSetupRecoveryCodeHandler(p *deps.RequestProvider) http.Handler {
 		Store:        userStore,
 		Identities:   identityFacade,
 		Verification: verificationService,
+		Clock:        clockClock,
 	}
 	rawCommands := &user.RawCommands{
 		Store:                  userStore,
@@ -6428,6 +9767,7 @@ func newWebAppSetupRecoveryCodeHandler(p *deps.RequestProvider) http.Handler {
 		Queries:     queries,
 	}
 	hookStore := &hook.Store{
+		Clock:       clockClock,
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
@@ -6450,16 +9790,42 @@ func newWebAppSetupRecoveryCodeHandler(p *deps.RequestProvider) http.Handler {
 		Users:     rawProvider,
 		Store:     hookStore,
 		Deliverer: deliverer,
+		TaskQueue: queue,
 	}
+	userProfileConfig := appConfig.UserProfile
 	commands := &user.Commands{
-		Raw:          rawCommands,
-		Hooks:        hookProvider,
-		Verification: verificationService,
+		Raw:               rawCommands,
+		Hooks:             hookProvider,
+		Verification:      verificationService,
+		UserProfileConfig: userProfileConfig,
 	}
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
+	authenticationLockoutConfig := authenticationConfig.Lockout
+	lockoutStorePQ := &lockout.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	lockoutService := &lockout.Service{
+		Config:   authenticationLockoutConfig,
+		Clock:    clockClock,
+		Store:    lockoutStorePQ,
+		Database: handle,
+		Users:    rawProvider,
+		Hooks:    hookProvider,
+	}
+	auditStorePQ := &audit.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	auditService := &audit.Service{
+		Context:  context,
+		Clock:    clockClock,
+		Store:    auditStorePQ,
+		Database: handle,
+	}
 	cookieFactory := deps.NewCookieFactory(request, trustProxy)
 	storeRedisLogger := idpsession.NewStoreRedisLogger(factory)
 	idpsessionStoreRedis := &idpsession.StoreRedis{
@@ -6486,18 +9852,19 @@ func newWebAppSetupRecoveryCodeHandler(p *deps.RequestProvider) http.Handler {
 		Clock:        clockClock,
 		Random:       idpsessionRand,
 	}
-	httpConfig := appConfig.HTTP
 	cookieDef := idpsession.NewSessionCookieDef(httpConfig, sessionConfig)
 	mfaCookieDef := mfa.NewDeviceTokenCookieDef(httpConfig, authenticationConfig)
 	interactionContext := &interaction.Context{
 		Database:                 sqlExecutor,
 		Clock:                    clockClock,
 		Config:                   appConfig,
+		RemoteIP:                 remoteIP,
 		Identities:               identityFacade,
 		Authenticators:           authenticatorFacade,
 		AnonymousIdentities:      anonymousProvider,
 		OOBAuthenticators:        oobProvider,
 		OOBCodeSender:            codeSender,
+		WebAuthnAuthenticators:   webauthnProvider,
 		OAuthProviderFactory:     oAuthProviderFactory,
 		MFA:                      mfaService,
 		ForgotPassword:           forgotpasswordProvider,
@@ -6508,6 +9875,9 @@ func newWebAppSetupRecoveryCodeHandler(p *deps.RequestProvider) http.Handler {
 		Challenges:               challengeProvider,
 		Users:                    userProvider,
 		Hooks:                    hookProvider,
+		RateLimiter:              limiter,
+		Lockout:                  lockoutService,
+		Audit:                    auditService,
 		CookieFactory:            cookieFactory,
 		Sessions:                 idpsessionProvider,
 		SessionCookie:            cookieDef,
@@ -6531,16 +9901,16 @@ func newWebAppSetupRecoveryCodeHandler(p *deps.RequestProvider) http.Handler {
 		CookieFactory: cookieFactory,
 		UATokenCookie: webappCookieDef,
 	}
-	setupRecoveryCodeHandler := &webapp2.SetupRecoveryCodeHandler{
+	verifyIdentityHandler := &webapp2.VerifyIdentityHandler{
 		Database:      handle,
 		BaseViewModel: baseViewModeler,
 		Renderer:      responseRenderer,
 		WebApp:        webappService,
 	}
-	return setupRecoveryCodeHandler
+	return verifyIdentityHandler
 }
 
-func newWebAppVerifyIdentityHandler(p *deps.RequestProvider) http.Handler {
+func newWebAppVerifyIdentitySuccessHandler(p *deps.RequestProvider) http.Handler {
 	appProvider := p.AppProvider
 	handle := appProvider.Database
 	rootProvider := appProvider.RootProvider
@@ -6583,6 +9953,8 @@ func newWebAppVerifyIdentityHandler(p *deps.RequestProvider) http.Handler {
 		Database: handle,
 	}
 	clockClock := _wireSystemClockValue
+	trustProxy := environmentConfig.TrustProxy
+	remoteIP := deps.ProvideRemoteIP(request, trustProxy)
 	authenticationConfig := appConfig.Authentication
 	identityConfig := appConfig.Identity
 	secretConfig := config.SecretConfig
@@ -6666,6 +10038,7 @@ func newWebAppVerifyIdentityHandler(p *deps.RequestProvider) http.Handler {
 		PasswordHistory: historyStore,
 		PasswordChecker: passwordChecker,
 		TaskQueue:       queue,
+		Database:        handle,
 	}
 	totpStore := &totp.Store{
 		SQLBuilder:  sqlBuilder,
@@ -6687,11 +10060,29 @@ func newWebAppVerifyIdentityHandler(p *deps.RequestProvider) http.Handler {
 		Store:  oobStore,
 		Clock:  clockClock,
 	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
 	service3 := &service2.Service{
 		Store:    serviceStore,
 		Password: passwordProvider,
 		TOTP:     totpProvider,
 		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
 	}
 	verificationLogger := verification.NewLogger(factory)
 	verificationConfig := appConfig.Verification
@@ -6723,7 +10114,7 @@ func newWebAppVerifyIdentityHandler(p *deps.RequestProvider) http.Handler {
 	authenticatorFacade := facade.AuthenticatorFacade{
 		Coordinator: coordinator,
 	}
-	trustProxy := environmentConfig.TrustProxy
+	rateLimitConfig := appConfig.RateLimit
 	mainOriginProvider := &MainOriginProvider{
 		Request:    request,
 		TrustProxy: trustProxy,
@@ -6731,11 +10122,23 @@ func newWebAppVerifyIdentityHandler(p *deps.RequestProvider) http.Handler {
 	endpointsProvider := &EndpointsProvider{
 		OriginProvider: mainOriginProvider,
 	}
+	ratelimitLogger := ratelimit.NewLogger(factory)
+	storageRedis := &ratelimit.StorageRedis{
+		AppID: appID,
+		Redis: redisHandle,
+		Clock: clockClock,
+	}
+	limiter := &ratelimit.Limiter{
+		Logger:  ratelimitLogger,
+		Storage: storageRedis,
+	}
 	messageSender := &otp.MessageSender{
 		StaticAssetURLPrefix: staticAssetURLPrefix,
+		RateLimitConfig:      rateLimitConfig,
 		Translation:          translationService,
 		Endpoints:            endpointsProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 	}
 	codeSender := &oob.CodeSender{
 		OTPMessageSender: messageSender,
@@ -6747,6 +10150,7 @@ func newWebAppVerifyIdentityHandler(p *deps.RequestProvider) http.Handler {
 	userInfoDecoder := sso.UserInfoDecoder{
 		LoginIDNormalizerFactory: normalizerFactory,
 	}
+	oidcCache := rootProvider.OIDCCache
 	oAuthProviderFactory := &sso.OAuthProviderFactory{
 		Endpoints:                endpointsProvider,
 		IdentityConfig:           identityConfig,
@@ -6755,6 +10159,7 @@ func newWebAppVerifyIdentityHandler(p *deps.RequestProvider) http.Handler {
 		Clock:                    clockClock,
 		UserInfoDecoder:          userInfoDecoder,
 		LoginIDNormalizerFactory: normalizerFactory,
+		OIDCCache:                oidcCache,
 	}
 	storeDeviceTokenRedis := &mfa.StoreDeviceTokenRedis{
 		Redis: redisHandle,
@@ -6779,10 +10184,12 @@ func newWebAppVerifyIdentityHandler(p *deps.RequestProvider) http.Handler {
 		StaticAssetURLPrefix: staticAssetURLPrefix,
 		Translation:          translationService,
 		Config:               forgotPasswordConfig,
+		RateLimitConfig:      rateLimitConfig,
 		Store:                forgotpasswordStore,
 		Clock:                clockClock,
 		URLs:                 urlProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 		Logger:               providerLogger,
 		Identities:           identityFacade,
 		Authenticators:       authenticatorFacade,
@@ -6810,6 +10217,7 @@ func newWebAppVerifyIdentityHandler(p *deps.RequestProvider) http.Handler {
 		Store:        userStore,
 		Identities:   identityFacade,
 		Verification: verificationService,
+		Clock:        clockClock,
 	}
 	rawCommands := &user.RawCommands{
 		Store:                  userStore,
@@ -6823,6 +10231,7 @@ func newWebAppVerifyIdentityHandler(p *deps.RequestProvider) http.Handler {
 		Queries:     queries,
 	}
 	hookStore := &hook.Store{
+		Clock:       clockClock,
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
@@ -6845,16 +10254,42 @@ func newWebAppVerifyIdentityHandler(p *deps.RequestProvider) http.Handler {
 		Users:     rawProvider,
 		Store:     hookStore,
 		Deliverer: deliverer,
+		TaskQueue: queue,
 	}
+	userProfileConfig := appConfig.UserProfile
 	commands := &user.Commands{
-		Raw:          rawCommands,
-		Hooks:        hookProvider,
-		Verification: verificationService,
+		Raw:               rawCommands,
+		Hooks:             hookProvider,
+		Verification:      verificationService,
+		UserProfileConfig: userProfileConfig,
 	}
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
+	authenticationLockoutConfig := authenticationConfig.Lockout
+	lockoutStorePQ := &lockout.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	lockoutService := &lockout.Service{
+		Config:   authenticationLockoutConfig,
+		Clock:    clockClock,
+		Store:    lockoutStorePQ,
+		Database: handle,
+		Users:    rawProvider,
+		Hooks:    hookProvider,
+	}
+	auditStorePQ := &audit.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	auditService := &audit.Service{
+		Context:  context,
+		Clock:    clockClock,
+		Store:    auditStorePQ,
+		Database: handle,
+	}
 	cookieFactory := deps.NewCookieFactory(request, trustProxy)
 	storeRedisLogger := idpsession.NewStoreRedisLogger(factory)
 	idpsessionStoreRedis := &idpsession.StoreRedis{
@@ -6881,18 +10316,19 @@ func newWebAppVerifyIdentityHandler(p *deps.RequestProvider) http.Handler {
 		Clock:        clockClock,
 		Random:       idpsessionRand,
 	}
-	httpConfig := appConfig.HTTP
 	cookieDef := idpsession.NewSessionCookieDef(httpConfig, sessionConfig)
 	mfaCookieDef := mfa.NewDeviceTokenCookieDef(httpConfig, authenticationConfig)
 	interactionContext := &interaction.Context{
 		Database:                 sqlExecutor,
 		Clock:                    clockClock,
 		Config:                   appConfig,
+		RemoteIP:                 remoteIP,
 		Identities:               identityFacade,
 		Authenticators:           authenticatorFacade,
 		AnonymousIdentities:      anonymousProvider,
 		OOBAuthenticators:        oobProvider,
 		OOBCodeSender:            codeSender,
+		WebAuthnAuthenticators:   webauthnProvider,
 		OAuthProviderFactory:     oAuthProviderFactory,
 		MFA:                      mfaService,
 		ForgotPassword:           forgotpasswordProvider,
@@ -6903,6 +10339,9 @@ func newWebAppVerifyIdentityHandler(p *deps.RequestProvider) http.Handler {
 		Challenges:               challengeProvider,
 		Users:                    userProvider,
 		Hooks:                    hookProvider,
+		RateLimiter:              limiter,
+		Lockout:                  lockoutService,
+		Audit:                    auditService,
 		CookieFactory:            cookieFactory,
 		Sessions:                 idpsessionProvider,
 		SessionCookie:            cookieDef,
@@ -6926,16 +10365,16 @@ func newWebAppVerifyIdentityHandler(p *deps.RequestProvider) http.Handler {
 		CookieFactory: cookieFactory,
 		UATokenCookie: webappCookieDef,
 	}
-	verifyIdentityHandler := &webapp2.VerifyIdentityHandler{
+	verifyIdentitySuccessHandler := &webapp2.VerifyIdentitySuccessHandler{
 		Database:      handle,
 		BaseViewModel: baseViewModeler,
 		Renderer:      responseRenderer,
 		WebApp:        webappService,
 	}
-	return verifyIdentityHandler
+	return verifyIdentitySuccessHandler
 }
 
-func newWebAppVerifyIdentitySuccessHandler(p *deps.RequestProvider) http.Handler {
+func newWebAppForgotPasswordHandler(p *deps.RequestProvider) http.Handler {
 	appProvider := p.AppProvider
 	handle := appProvider.Database
 	rootProvider := appProvider.RootProvider
@@ -6959,6 +10398,12 @@ func newWebAppVerifyIdentitySuccessHandler(p *deps.RequestProvider) http.Handler
 		Translation:          translationService,
 		ForgotPassword:       forgotPasswordConfig,
 	}
+	identityConfig := appConfig.Identity
+	loginIDConfig := identityConfig.LoginID
+	formPrefiller := &webapp2.FormPrefiller{
+		LoginID: loginIDConfig,
+		UI:      uiConfig,
+	}
 	factory := appProvider.LoggerFactory
 	responseRendererLogger := webapp2.NewResponseRendererLogger(factory)
 	responseRenderer := &webapp2.ResponseRenderer{
@@ -6978,8 +10423,9 @@ func newWebAppVerifyIdentitySuccessHandler(p *deps.RequestProvider) http.Handler
 		Database: handle,
 	}
 	clockClock := _wireSystemClockValue
+	trustProxy := environmentConfig.TrustProxy
+	remoteIP := deps.ProvideRemoteIP(request, trustProxy)
 	authenticationConfig := appConfig.Authentication
-	identityConfig := appConfig.Identity
 	secretConfig := config.SecretConfig
 	databaseCredentials := deps.ProvideDatabaseCredentials(secretConfig)
 	sqlBuilder := db.ProvideSQLBuilder(databaseCredentials, appID)
@@ -6991,7 +10437,6 @@ func newWebAppVerifyIdentitySuccessHandler(p *deps.RequestProvider) http.Handler
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
-	loginIDConfig := identityConfig.LoginID
 	reservedNameChecker := rootProvider.ReservedNameChecker
 	typeCheckerFactory := &loginid.TypeCheckerFactory{
 		Config:              loginIDConfig,
@@ -7061,6 +10506,7 @@ func newWebAppVerifyIdentitySuccessHandler(p *deps.RequestProvider) http.Handler
 		PasswordHistory: historyStore,
 		PasswordChecker: passwordChecker,
 		TaskQueue:       queue,
+		Database:        handle,
 	}
 	totpStore := &totp.Store{
 		SQLBuilder:  sqlBuilder,
@@ -7082,11 +10528,29 @@ func newWebAppVerifyIdentitySuccessHandler(p *deps.RequestProvider) http.Handler
 		Store:  oobStore,
 		Clock:  clockClock,
 	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
 	service3 := &service2.Service{
 		Store:    serviceStore,
 		Password: passwordProvider,
 		TOTP:     totpProvider,
 		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
 	}
 	verificationLogger := verification.NewLogger(factory)
 	verificationConfig := appConfig.Verification
@@ -7118,7 +10582,7 @@ func newWebAppVerifyIdentitySuccessHandler(p *deps.RequestProvider) http.Handler
 	authenticatorFacade := facade.AuthenticatorFacade{
 		Coordinator: coordinator,
 	}
-	trustProxy := environmentConfig.TrustProxy
+	rateLimitConfig := appConfig.RateLimit
 	mainOriginProvider := &MainOriginProvider{
 		Request:    request,
 		TrustProxy: trustProxy,
@@ -7126,11 +10590,23 @@ func newWebAppVerifyIdentitySuccessHandler(p *deps.RequestProvider) http.Handler
 	endpointsProvider := &EndpointsProvider{
 		OriginProvider: mainOriginProvider,
 	}
+	ratelimitLogger := ratelimit.NewLogger(factory)
+	storageRedis := &ratelimit.StorageRedis{
+		AppID: appID,
+		Redis: redisHandle,
+		Clock: clockClock,
+	}
+	limiter := &ratelimit.Limiter{
+		Logger:  ratelimitLogger,
+		Storage: storageRedis,
+	}
 	messageSender := &otp.MessageSender{
 		StaticAssetURLPrefix: staticAssetURLPrefix,
+		RateLimitConfig:      rateLimitConfig,
 		Translation:          translationService,
 		Endpoints:            endpointsProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 	}
 	codeSender := &oob.CodeSender{
 		OTPMessageSender: messageSender,
@@ -7142,6 +10618,7 @@ func newWebAppVerifyIdentitySuccessHandler(p *deps.RequestProvider) http.Handler
 	userInfoDecoder := sso.UserInfoDecoder{
 		LoginIDNormalizerFactory: normalizerFactory,
 	}
+	oidcCache := rootProvider.OIDCCache
 	oAuthProviderFactory := &sso.OAuthProviderFactory{
 		Endpoints:                endpointsProvider,
 		IdentityConfig:           identityConfig,
@@ -7150,6 +10627,7 @@ func newWebAppVerifyIdentitySuccessHandler(p *deps.RequestProvider) http.Handler
 		Clock:                    clockClock,
 		UserInfoDecoder:          userInfoDecoder,
 		LoginIDNormalizerFactory: normalizerFactory,
+		OIDCCache:                oidcCache,
 	}
 	storeDeviceTokenRedis := &mfa.StoreDeviceTokenRedis{
 		Redis: redisHandle,
@@ -7174,10 +10652,12 @@ func newWebAppVerifyIdentitySuccessHandler(p *deps.RequestProvider) http.Handler
 		StaticAssetURLPrefix: staticAssetURLPrefix,
 		Translation:          translationService,
 		Config:               forgotPasswordConfig,
+		RateLimitConfig:      rateLimitConfig,
 		Store:                forgotpasswordStore,
 		Clock:                clockClock,
 		URLs:                 urlProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 		Logger:               providerLogger,
 		Identities:           identityFacade,
 		Authenticators:       authenticatorFacade,
@@ -7205,6 +10685,7 @@ func newWebAppVerifyIdentitySuccessHandler(p *deps.RequestProvider) http.Handler
 		Store:        userStore,
 		Identities:   identityFacade,
 		Verification: verificationService,
+		Clock:        clockClock,
 	}
 	rawCommands := &user.RawCommands{
 		Store:                  userStore,
@@ -7218,6 +10699,7 @@ func newWebAppVerifyIdentitySuccessHandler(p *deps.RequestProvider) http.Handler
 		Queries:     queries,
 	}
 	hookStore := &hook.Store{
+		Clock:       clockClock,
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
@@ -7240,16 +10722,42 @@ func newWebAppVerifyIdentitySuccessHandler(p *deps.RequestProvider) http.Handler
 		Users:     rawProvider,
 		Store:     hookStore,
 		Deliverer: deliverer,
+		TaskQueue: queue,
 	}
+	userProfileConfig := appConfig.UserProfile
 	commands := &user.Commands{
-		Raw:          rawCommands,
-		Hooks:        hookProvider,
-		Verification: verificationService,
+		Raw:               rawCommands,
+		Hooks:             hookProvider,
+		Verification:      verificationService,
+		UserProfileConfig: userProfileConfig,
 	}
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
+	authenticationLockoutConfig := authenticationConfig.Lockout
+	lockoutStorePQ := &lockout.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	lockoutService := &lockout.Service{
+		Config:   authenticationLockoutConfig,
+		Clock:    clockClock,
+		Store:    lockoutStorePQ,
+		Database: handle,
+		Users:    rawProvider,
+		Hooks:    hookProvider,
+	}
+	auditStorePQ := &audit.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	auditService := &audit.Service{
+		Context:  context,
+		Clock:    clockClock,
+		Store:    auditStorePQ,
+		Database: handle,
+	}
 	cookieFactory := deps.NewCookieFactory(request, trustProxy)
 	storeRedisLogger := idpsession.NewStoreRedisLogger(factory)
 	idpsessionStoreRedis := &idpsession.StoreRedis{
@@ -7276,18 +10784,19 @@ func newWebAppVerifyIdentitySuccessHandler(p *deps.RequestProvider) http.Handler
 		Clock:        clockClock,
 		Random:       idpsessionRand,
 	}
-	httpConfig := appConfig.HTTP
 	cookieDef := idpsession.NewSessionCookieDef(httpConfig, sessionConfig)
 	mfaCookieDef := mfa.NewDeviceTokenCookieDef(httpConfig, authenticationConfig)
 	interactionContext := &interaction.Context{
 		Database:                 sqlExecutor,
 		Clock:                    clockClock,
 		Config:                   appConfig,
+		RemoteIP:                 remoteIP,
 		Identities:               identityFacade,
 		Authenticators:           authenticatorFacade,
 		AnonymousIdentities:      anonymousProvider,
 		OOBAuthenticators:        oobProvider,
 		OOBCodeSender:            codeSender,
+		WebAuthnAuthenticators:   webauthnProvider,
 		OAuthProviderFactory:     oAuthProviderFactory,
 		MFA:                      mfaService,
 		ForgotPassword:           forgotpasswordProvider,
@@ -7298,6 +10807,9 @@ func newWebAppVerifyIdentitySuccessHandler(p *deps.RequestProvider) http.Handler
 		Challenges:               challengeProvider,
 		Users:                    userProvider,
 		Hooks:                    hookProvider,
+		RateLimiter:              limiter,
+		Lockout:                  lockoutService,
+		Audit:                    auditService,
 		CookieFactory:            cookieFactory,
 		Sessions:                 idpsessionProvider,
 		SessionCookie:            cookieDef,
@@ -7321,16 +10833,17 @@ func newWebAppVerifyIdentitySuccessHandler(p *deps.RequestProvider) http.Handler
 		CookieFactory: cookieFactory,
 		UATokenCookie: webappCookieDef,
 	}
-	verifyIdentitySuccessHandler := &webapp2.VerifyIdentitySuccessHandler{
+	forgotPasswordHandler := &webapp2.ForgotPasswordHandler{
 		Database:      handle,
 		BaseViewModel: baseViewModeler,
+		FormPrefiller: formPrefiller,
 		Renderer:      responseRenderer,
 		WebApp:        webappService,
 	}
-	return verifyIdentitySuccessHandler
+	return forgotPasswordHandler
 }
 
-func newWebAppForgotPasswordHandler(p *deps.RequestProvider) http.Handler {
+func newWebAppForgotPasswordSuccessHandler(p *deps.RequestProvider) http.Handler {
 	appProvider := p.AppProvider
 	handle := appProvider.Database
 	rootProvider := appProvider.RootProvider
@@ -7354,12 +10867,6 @@ func newWebAppForgotPasswordHandler(p *deps.RequestProvider) http.Handler {
 		Translation:          translationService,
 		ForgotPassword:       forgotPasswordConfig,
 	}
-	identityConfig := appConfig.Identity
-	loginIDConfig := identityConfig.LoginID
-	formPrefiller := &webapp2.FormPrefiller{
-		LoginID: loginIDConfig,
-		UI:      uiConfig,
-	}
 	factory := appProvider.LoggerFactory
 	responseRendererLogger := webapp2.NewResponseRendererLogger(factory)
 	responseRenderer := &webapp2.ResponseRenderer{
@@ -7379,7 +10886,10 @@ func newWebAppForgotPasswordHandler(p *deps.RequestProvider) http.Handler {
 		Database: handle,
 	}
 	clockClock := _wireSystemClockValue
+	trustProxy := environmentConfig.TrustProxy
+	remoteIP := deps.ProvideRemoteIP(request, trustProxy)
 	authenticationConfig := appConfig.Authentication
+	identityConfig := appConfig.Identity
 	secretConfig := config.SecretConfig
 	databaseCredentials := deps.ProvideDatabaseCredentials(secretConfig)
 	sqlBuilder := db.ProvideSQLBuilder(databaseCredentials, appID)
@@ -7391,6 +10901,7 @@ func newWebAppForgotPasswordHandler(p *deps.RequestProvider) http.Handler {
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
+	loginIDConfig := identityConfig.LoginID
 	reservedNameChecker := rootProvider.ReservedNameChecker
 	typeCheckerFactory := &loginid.TypeCheckerFactory{
 		Config:              loginIDConfig,
@@ -7460,6 +10971,7 @@ func newWebAppForgotPasswordHandler(p *deps.RequestProvider) http.Handler {
 		PasswordHistory: historyStore,
 		PasswordChecker: passwordChecker,
 		TaskQueue:       queue,
+		Database:        handle,
 	}
 	totpStore := &totp.Store{
 		SQLBuilder:  sqlBuilder,
@@ -7481,11 +10993,29 @@ func newWebAppForgotPasswordHandler(p *deps.RequestProvider) http.Handler {
 		Store:  oobStore,
 		Clock:  clockClock,
 	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
 	service3 := &service2.Service{
 		Store:    serviceStore,
 		Password: passwordProvider,
 		TOTP:     totpProvider,
 		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
 	}
 	verificationLogger := verification.NewLogger(factory)
 	verificationConfig := appConfig.Verification
@@ -7517,7 +11047,7 @@ func newWebAppForgotPasswordHandler(p *deps.RequestProvider) http.Handler {
 	authenticatorFacade := facade.AuthenticatorFacade{
 		Coordinator: coordinator,
 	}
-	trustProxy := environmentConfig.TrustProxy
+	rateLimitConfig := appConfig.RateLimit
 	mainOriginProvider := &MainOriginProvider{
 		Request:    request,
 		TrustProxy: trustProxy,
@@ -7525,11 +11055,23 @@ func newWebAppForgotPasswordHandler(p *deps.RequestProvider) http.Handler {
 	endpointsProvider := &EndpointsProvider{
 		OriginProvider: mainOriginProvider,
 	}
+	ratelimitLogger := ratelimit.NewLogger(factory)
+	storageRedis := &ratelimit.StorageRedis{
+		AppID: appID,
+		Redis: redisHandle,
+		Clock: clockClock,
+	}
+	limiter := &ratelimit.Limiter{
+		Logger:  ratelimitLogger,
+		Storage: storageRedis,
+	}
 	messageSender := &otp.MessageSender{
 		StaticAssetURLPrefix: staticAssetURLPrefix,
+		RateLimitConfig:      rateLimitConfig,
 		Translation:          translationService,
 		Endpoints:            endpointsProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 	}
 	codeSender := &oob.CodeSender{
 		OTPMessageSender: messageSender,
@@ -7541,6 +11083,7 @@ func newWebAppForgotPasswordHandler(p *deps.RequestProvider) http.Handler {
 	userInfoDecoder := sso.UserInfoDecoder{
 		LoginIDNormalizerFactory: normalizerFactory,
 	}
+	oidcCache := rootProvider.OIDCCache
 	oAuthProviderFactory := &sso.OAuthProviderFactory{
 		Endpoints:                endpointsProvider,
 		IdentityConfig:           identityConfig,
@@ -7549,6 +11092,7 @@ func newWebAppForgotPasswordHandler(p *deps.RequestProvider) http.Handler {
 		Clock:                    clockClock,
 		UserInfoDecoder:          userInfoDecoder,
 		LoginIDNormalizerFactory: normalizerFactory,
+		OIDCCache:                oidcCache,
 	}
 	storeDeviceTokenRedis := &mfa.StoreDeviceTokenRedis{
 		Redis: redisHandle,
@@ -7573,10 +11117,12 @@ func newWebAppForgotPasswordHandler(p *deps.RequestProvider) http.Handler {
 		StaticAssetURLPrefix: staticAssetURLPrefix,
 		Translation:          translationService,
 		Config:               forgotPasswordConfig,
+		RateLimitConfig:      rateLimitConfig,
 		Store:                forgotpasswordStore,
 		Clock:                clockClock,
 		URLs:                 urlProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 		Logger:               providerLogger,
 		Identities:           identityFacade,
 		Authenticators:       authenticatorFacade,
@@ -7604,6 +11150,7 @@ func newWebAppForgotPasswordHandler(p *deps.RequestProvider) http.Handler {
 		Store:        userStore,
 		Identities:   identityFacade,
 		Verification: verificationService,
+		Clock:        clockClock,
 	}
 	rawCommands := &user.RawCommands{
 		Store:                  userStore,
@@ -7617,6 +11164,7 @@ func newWebAppForgotPasswordHandler(p *deps.RequestProvider) http.Handler {
 		Queries:     queries,
 	}
 	hookStore := &hook.Store{
+		Clock:       clockClock,
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
@@ -7639,16 +11187,42 @@ func newWebAppForgotPasswordHandler(p *deps.RequestProvider) http.Handler {
 		Users:     rawProvider,
 		Store:     hookStore,
 		Deliverer: deliverer,
+		TaskQueue: queue,
 	}
+	userProfileConfig := appConfig.UserProfile
 	commands := &user.Commands{
-		Raw:          rawCommands,
-		Hooks:        hookProvider,
-		Verification: verificationService,
+		Raw:               rawCommands,
+		Hooks:             hookProvider,
+		Verification:      verificationService,
+		UserProfileConfig: userProfileConfig,
 	}
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
+	authenticationLockoutConfig := authenticationConfig.Lockout
+	lockoutStorePQ := &lockout.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	lockoutService := &lockout.Service{
+		Config:   authenticationLockoutConfig,
+		Clock:    clockClock,
+		Store:    lockoutStorePQ,
+		Database: handle,
+		Users:    rawProvider,
+		Hooks:    hookProvider,
+	}
+	auditStorePQ := &audit.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	auditService := &audit.Service{
+		Context:  context,
+		Clock:    clockClock,
+		Store:    auditStorePQ,
+		Database: handle,
+	}
 	cookieFactory := deps.NewCookieFactory(request, trustProxy)
 	storeRedisLogger := idpsession.NewStoreRedisLogger(factory)
 	idpsessionStoreRedis := &idpsession.StoreRedis{
@@ -7675,18 +11249,19 @@ func newWebAppForgotPasswordHandler(p *deps.RequestProvider) http.Handler {
 		Clock:        clockClock,
 		Random:       idpsessionRand,
 	}
-	httpConfig := appConfig.HTTP
 	cookieDef := idpsession.NewSessionCookieDef(httpConfig, sessionConfig)
 	mfaCookieDef := mfa.NewDeviceTokenCookieDef(httpConfig, authenticationConfig)
 	interactionContext := &interaction.Context{
 		Database:                 sqlExecutor,
 		Clock:                    clockClock,
 		Config:                   appConfig,
+		RemoteIP:                 remoteIP,
 		Identities:               identityFacade,
 		Authenticators:           authenticatorFacade,
 		AnonymousIdentities:      anonymousProvider,
 		OOBAuthenticators:        oobProvider,
 		OOBCodeSender:            codeSender,
+		WebAuthnAuthenticators:   webauthnProvider,
 		OAuthProviderFactory:     oAuthProviderFactory,
 		MFA:                      mfaService,
 		ForgotPassword:           forgotpasswordProvider,
@@ -7697,6 +11272,9 @@ func newWebAppForgotPasswordHandler(p *deps.RequestProvider) http.Handler {
 		Challenges:               challengeProvider,
 		Users:                    userProvider,
 		Hooks:                    hookProvider,
+		RateLimiter:              limiter,
+		Lockout:                  lockoutService,
+		Audit:                    auditService,
 		CookieFactory:            cookieFactory,
 		Sessions:                 idpsessionProvider,
 		SessionCookie:            cookieDef,
@@ -7720,17 +11298,16 @@ func newWebAppForgotPasswordHandler(p *deps.RequestProvider) http.Handler {
 		CookieFactory: cookieFactory,
 		UATokenCookie: webappCookieDef,
 	}
-	forgotPasswordHandler := &webapp2.ForgotPasswordHandler{
+	forgotPasswordSuccessHandler := &webapp2.ForgotPasswordSuccessHandler{
 		Database:      handle,
 		BaseViewModel: baseViewModeler,
-		FormPrefiller: formPrefiller,
 		Renderer:      responseRenderer,
 		WebApp:        webappService,
 	}
-	return forgotPasswordHandler
+	return forgotPasswordSuccessHandler
 }
 
-func newWebAppForgotPasswordSuccessHandler(p *deps.RequestProvider) http.Handler {
+func newWebAppResetPasswordHandler(p *deps.RequestProvider) http.Handler {
 	appProvider := p.AppProvider
 	handle := appProvider.Database
 	rootProvider := appProvider.RootProvider
@@ -7773,6 +11350,8 @@ func newWebAppForgotPasswordSuccessHandler(p *deps.RequestProvider) http.Handler
 		Database: handle,
 	}
 	clockClock := _wireSystemClockValue
+	trustProxy := environmentConfig.TrustProxy
+	remoteIP := deps.ProvideRemoteIP(request, trustProxy)
 	authenticationConfig := appConfig.Authentication
 	identityConfig := appConfig.Identity
 	secretConfig := config.SecretConfig
@@ -7856,6 +11435,7 @@ func newWebAppForgotPasswordSuccessHandler(p *deps.RequestProvider) http.Handler
 		PasswordHistory: historyStore,
 		PasswordChecker: passwordChecker,
 		TaskQueue:       queue,
+		Database:        handle,
 	}
 	totpStore := &totp.Store{
 		SQLBuilder:  sqlBuilder,
@@ -7877,11 +11457,29 @@ func newWebAppForgotPasswordSuccessHandler(p *deps.RequestProvider) http.Handler
 		Store:  oobStore,
 		Clock:  clockClock,
 	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
 	service3 := &service2.Service{
 		Store:    serviceStore,
 		Password: passwordProvider,
 		TOTP:     totpProvider,
 		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
 	}
 	verificationLogger := verification.NewLogger(factory)
 	verificationConfig := appConfig.Verification
@@ -7913,7 +11511,7 @@ func newWebAppForgotPasswordSuccessHandler(p *deps.RequestProvider) http.Handler
 	authenticatorFacade := facade.AuthenticatorFacade{
 		Coordinator: coordinator,
 	}
-	trustProxy := environmentConfig.TrustProxy
+	rateLimitConfig := appConfig.RateLimit
 	mainOriginProvider := &MainOriginProvider{
 		Request:    request,
 		TrustProxy: trustProxy,
@@ -7921,11 +11519,23 @@ func newWebAppForgotPasswordSuccessHandler(p *deps.RequestProvider) http.Handler
 	endpointsProvider := &EndpointsProvider{
 		OriginProvider: mainOriginProvider,
 	}
+	ratelimitLogger := ratelimit.NewLogger(factory)
+	storageRedis := &ratelimit.StorageRedis{
+		AppID: appID,
+		Redis: redisHandle,
+		Clock: clockClock,
+	}
+	limiter := &ratelimit.Limiter{
+		Logger:  ratelimitLogger,
+		Storage: storageRedis,
+	}
 	messageSender := &otp.MessageSender{
 		StaticAssetURLPrefix: staticAssetURLPrefix,
+		RateLimitConfig:      rateLimitConfig,
 		Translation:          translationService,
 		Endpoints:            endpointsProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 	}
 	codeSender := &oob.CodeSender{
 		OTPMessageSender: messageSender,
@@ -7937,6 +11547,7 @@ func newWebAppForgotPasswordSuccessHandler(p *deps.RequestProvider) http.Handler
 	userInfoDecoder := sso.UserInfoDecoder{
 		LoginIDNormalizerFactory: normalizerFactory,
 	}
+	oidcCache := rootProvider.OIDCCache
 	oAuthProviderFactory := &sso.OAuthProviderFactory{
 		Endpoints:                endpointsProvider,
 		IdentityConfig:           identityConfig,
@@ -7945,6 +11556,7 @@ func newWebAppForgotPasswordSuccessHandler(p *deps.RequestProvider) http.Handler
 		Clock:                    clockClock,
 		UserInfoDecoder:          userInfoDecoder,
 		LoginIDNormalizerFactory: normalizerFactory,
+		OIDCCache:                oidcCache,
 	}
 	storeDeviceTokenRedis := &mfa.StoreDeviceTokenRedis{
 		Redis: redisHandle,
@@ -7969,10 +11581,12 @@ func newWebAppForgotPasswordSuccessHandler(p *deps.RequestProvider) http.Handler
 		StaticAssetURLPrefix: staticAssetURLPrefix,
 		Translation:          translationService,
 		Config:               forgotPasswordConfig,
+		RateLimitConfig:      rateLimitConfig,
 		Store:                forgotpasswordStore,
 		Clock:                clockClock,
 		URLs:                 urlProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 		Logger:               providerLogger,
 		Identities:           identityFacade,
 		Authenticators:       authenticatorFacade,
@@ -8000,6 +11614,7 @@ func newWebAppForgotPasswordSuccessHandler(p *deps.RequestProvider) http.Handler
 		Store:        userStore,
 		Identities:   identityFacade,
 		Verification: verificationService,
+		Clock:        clockClock,
 	}
 	rawCommands := &user.RawCommands{
 		Store:                  userStore,
@@ -8013,6 +11628,7 @@ func newWebAppForgotPasswordSuccessHandler(p *deps.RequestProvider) http.Handler
 		Queries:     queries,
 	}
 	hookStore := &hook.Store{
+		Clock:       clockClock,
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
@@ -8035,16 +11651,42 @@ func newWebAppForgotPasswordSuccessHandler(p *deps.RequestProvider) http.Handler
 		Users:     rawProvider,
 		Store:     hookStore,
 		Deliverer: deliverer,
+		TaskQueue: queue,
 	}
+	userProfileConfig := appConfig.UserProfile
 	commands := &user.Commands{
-		Raw:          rawCommands,
-		Hooks:        hookProvider,
-		Verification: verificationService,
+		Raw:               rawCommands,
+		Hooks:             hookProvider,
+		Verification:      verificationService,
+		UserProfileConfig: userProfileConfig,
 	}
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
+	authenticationLockoutConfig := authenticationConfig.Lockout
+	lockoutStorePQ := &lockout.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	lockoutService := &lockout.Service{
+		Config:   authenticationLockoutConfig,
+		Clock:    clockClock,
+		Store:    lockoutStorePQ,
+		Database: handle,
+		Users:    rawProvider,
+		Hooks:    hookProvider,
+	}
+	auditStorePQ := &audit.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	auditService := &audit.Service{
+		Context:  context,
+		Clock:    clockClock,
+		Store:    auditStorePQ,
+		Database: handle,
+	}
 	cookieFactory := deps.NewCookieFactory(request, trustProxy)
 	storeRedisLogger := idpsession.NewStoreRedisLogger(factory)
 	idpsessionStoreRedis := &idpsession.StoreRedis{
@@ -8071,18 +11713,19 @@ func newWebAppForgotPasswordSuccessHandler(p *deps.RequestProvider) http.Handler
 		Clock:        clockClock,
 		Random:       idpsessionRand,
 	}
-	httpConfig := appConfig.HTTP
 	cookieDef := idpsession.NewSessionCookieDef(httpConfig, sessionConfig)
 	mfaCookieDef := mfa.NewDeviceTokenCookieDef(httpConfig, authenticationConfig)
 	interactionContext := &interaction.Context{
 		Database:                 sqlExecutor,
 		Clock:                    clockClock,
 		Config:                   appConfig,
+		RemoteIP:                 remoteIP,
 		Identities:               identityFacade,
 		Authenticators:           authenticatorFacade,
 		AnonymousIdentities:      anonymousProvider,
 		OOBAuthenticators:        oobProvider,
 		OOBCodeSender:            codeSender,
+		WebAuthnAuthenticators:   webauthnProvider,
 		OAuthProviderFactory:     oAuthProviderFactory,
 		MFA:                      mfaService,
 		ForgotPassword:           forgotpasswordProvider,
@@ -8093,6 +11736,9 @@ func newWebAppForgotPasswordSuccessHandler(p *deps.RequestProvider) http.Handler
 		Challenges:               challengeProvider,
 		Users:                    userProvider,
 		Hooks:                    hookProvider,
+		RateLimiter:              limiter,
+		Lockout:                  lockoutService,
+		Audit:                    auditService,
 		CookieFactory:            cookieFactory,
 		Sessions:                 idpsessionProvider,
 		SessionCookie:            cookieDef,
@@ -8116,16 +11762,17 @@ func newWebAppForgotPasswordSuccessHandler(p *deps.RequestProvider) http.Handler
 		CookieFactory: cookieFactory,
 		UATokenCookie: webappCookieDef,
 	}
-	forgotPasswordSuccessHandler := &webapp2.ForgotPasswordSuccessHandler{
-		Database:      handle,
-		BaseViewModel: baseViewModeler,
-		Renderer:      responseRenderer,
-		WebApp:        webappService,
+	resetPasswordHandler := &webapp2.ResetPasswordHandler{
+		Database:       handle,
+		BaseViewModel:  baseViewModeler,
+		Renderer:       responseRenderer,
+		WebApp:         webappService,
+		PasswordPolicy: passwordChecker,
 	}
-	return forgotPasswordSuccessHandler
+	return resetPasswordHandler
 }
 
-func newWebAppResetPasswordHandler(p *deps.RequestProvider) http.Handler {
+func newWebAppResetPasswordSuccessHandler(p *deps.RequestProvider) http.Handler {
 	appProvider := p.AppProvider
 	handle := appProvider.Database
 	rootProvider := appProvider.RootProvider
@@ -8168,6 +11815,8 @@ func newWebAppResetPasswordHandler(p *deps.RequestProvider) http.Handler {
 		Database: handle,
 	}
 	clockClock := _wireSystemClockValue
+	trustProxy := environmentConfig.TrustProxy
+	remoteIP := deps.ProvideRemoteIP(request, trustProxy)
 	authenticationConfig := appConfig.Authentication
 	identityConfig := appConfig.Identity
 	secretConfig := config.SecretConfig
@@ -8251,6 +11900,7 @@ func newWebAppResetPasswordHandler(p *deps.RequestProvider) http.Handler {
 		PasswordHistory: historyStore,
 		PasswordChecker: passwordChecker,
 		TaskQueue:       queue,
+		Database:        handle,
 	}
 	totpStore := &totp.Store{
 		SQLBuilder:  sqlBuilder,
@@ -8272,11 +11922,29 @@ func newWebAppResetPasswordHandler(p *deps.RequestProvider) http.Handler {
 		Store:  oobStore,
 		Clock:  clockClock,
 	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
 	service3 := &service2.Service{
 		Store:    serviceStore,
 		Password: passwordProvider,
 		TOTP:     totpProvider,
 		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
 	}
 	verificationLogger := verification.NewLogger(factory)
 	verificationConfig := appConfig.Verification
@@ -8308,7 +11976,7 @@ func newWebAppResetPasswordHandler(p *deps.RequestProvider) http.Handler {
 	authenticatorFacade := facade.AuthenticatorFacade{
 		Coordinator: coordinator,
 	}
-	trustProxy := environmentConfig.TrustProxy
+	rateLimitConfig := appConfig.RateLimit
 	mainOriginProvider := &MainOriginProvider{
 		Request:    request,
 		TrustProxy: trustProxy,
@@ -8316,11 +11984,23 @@ func newWebAppResetPasswordHandler(p *deps.RequestProvider) http.Handler {
 	endpointsProvider := &EndpointsProvider{
 		OriginProvider: mainOriginProvider,
 	}
+	ratelimitLogger := ratelimit.NewLogger(factory)
+	storageRedis := &ratelimit.StorageRedis{
+		AppID: appID,
+		Redis: redisHandle,
+		Clock: clockClock,
+	}
+	limiter := &ratelimit.Limiter{
+		Logger:  ratelimitLogger,
+		Storage: storageRedis,
+	}
 	messageSender := &otp.MessageSender{
 		StaticAssetURLPrefix: staticAssetURLPrefix,
+		RateLimitConfig:      rateLimitConfig,
 		Translation:          translationService,
 		Endpoints:            endpointsProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 	}
 	codeSender := &oob.CodeSender{
 		OTPMessageSender: messageSender,
@@ -8332,6 +12012,7 @@ func newWebAppResetPasswordHandler(p *deps.RequestProvider) http.Handler {
 	userInfoDecoder := sso.UserInfoDecoder{
 		LoginIDNormalizerFactory: normalizerFactory,
 	}
+	oidcCache := rootProvider.OIDCCache
 	oAuthProviderFactory := &sso.OAuthProviderFactory{
 		Endpoints:                endpointsProvider,
 		IdentityConfig:           identityConfig,
@@ -8340,6 +12021,7 @@ func newWebAppResetPasswordHandler(p *deps.RequestProvider) http.Handler {
 		Clock:                    clockClock,
 		UserInfoDecoder:          userInfoDecoder,
 		LoginIDNormalizerFactory: normalizerFactory,
+		OIDCCache:                oidcCache,
 	}
 	storeDeviceTokenRedis := &mfa.StoreDeviceTokenRedis{
 		Redis: redisHandle,
@@ -8364,10 +12046,12 @@ func newWebAppResetPasswordHandler(p *deps.RequestProvider) http.Handler {
 		StaticAssetURLPrefix: staticAssetURLPrefix,
 		Translation:          translationService,
 		Config:               forgotPasswordConfig,
+		RateLimitConfig:      rateLimitConfig,
 		Store:                forgotpasswordStore,
 		Clock:                clockClock,
 		URLs:                 urlProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 		Logger:               providerLogger,
 		Identities:           identityFacade,
 		Authenticators:       authenticatorFacade,
@@ -8395,6 +12079,7 @@ func newWebAppResetPasswordHandler(p *deps.RequestProvider) http.Handler {
 		Store:        userStore,
 		Identities:   identityFacade,
 		Verification: verificationService,
+		Clock:        clockClock,
 	}
 	rawCommands := &user.RawCommands{
 		Store:                  userStore,
@@ -8408,6 +12093,7 @@ func newWebAppResetPasswordHandler(p *deps.RequestProvider) http.Handler {
 		Queries:     queries,
 	}
 	hookStore := &hook.Store{
+		Clock:       clockClock,
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
@@ -8430,16 +12116,42 @@ func newWebAppResetPasswordHandler(p *deps.RequestProvider) http.Handler {
 		Users:     rawProvider,
 		Store:     hookStore,
 		Deliverer: deliverer,
+		TaskQueue: queue,
 	}
+	userProfileConfig := appConfig.UserProfile
 	commands := &user.Commands{
-		Raw:          rawCommands,
-		Hooks:        hookProvider,
-		Verification: verificationService,
+		Raw:               rawCommands,
+		Hooks:             hookProvider,
+		Verification:      verificationService,
+		UserProfileConfig: userProfileConfig,
 	}
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
+	authenticationLockoutConfig := authenticationConfig.Lockout
+	lockoutStorePQ := &lockout.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	lockoutService := &lockout.Service{
+		Config:   authenticationLockoutConfig,
+		Clock:    clockClock,
+		Store:    lockoutStorePQ,
+		Database: handle,
+		Users:    rawProvider,
+		Hooks:    hookProvider,
+	}
+	auditStorePQ := &audit.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	auditService := &audit.Service{
+		Context:  context,
+		Clock:    clockClock,
+		Store:    auditStorePQ,
+		Database: handle,
+	}
 	cookieFactory := deps.NewCookieFactory(request, trustProxy)
 	storeRedisLogger := idpsession.NewStoreRedisLogger(factory)
 	idpsessionStoreRedis := &idpsession.StoreRedis{
@@ -8466,18 +12178,19 @@ func newWebAppResetPasswordHandler(p *deps.RequestProvider) http.Handler {
 		Clock:        clockClock,
 		Random:       idpsessionRand,
 	}
-	httpConfig := appConfig.HTTP
 	cookieDef := idpsession.NewSessionCookieDef(httpConfig, sessionConfig)
 	mfaCookieDef := mfa.NewDeviceTokenCookieDef(httpConfig, authenticationConfig)
 	interactionContext := &interaction.Context{
 		Database:                 sqlExecutor,
 		Clock:                    clockClock,
 		Config:                   appConfig,
+		RemoteIP:                 remoteIP,
 		Identities:               identityFacade,
 		Authenticators:           authenticatorFacade,
 		AnonymousIdentities:      anonymousProvider,
 		OOBAuthenticators:        oobProvider,
 		OOBCodeSender:            codeSender,
+		WebAuthnAuthenticators:   webauthnProvider,
 		OAuthProviderFactory:     oAuthProviderFactory,
 		MFA:                      mfaService,
 		ForgotPassword:           forgotpasswordProvider,
@@ -8488,6 +12201,9 @@ func newWebAppResetPasswordHandler(p *deps.RequestProvider) http.Handler {
 		Challenges:               challengeProvider,
 		Users:                    userProvider,
 		Hooks:                    hookProvider,
+		RateLimiter:              limiter,
+		Lockout:                  lockoutService,
+		Audit:                    auditService,
 		CookieFactory:            cookieFactory,
 		Sessions:                 idpsessionProvider,
 		SessionCookie:            cookieDef,
@@ -8511,17 +12227,156 @@ func newWebAppResetPasswordHandler(p *deps.RequestProvider) http.Handler {
 		CookieFactory: cookieFactory,
 		UATokenCookie: webappCookieDef,
 	}
-	resetPasswordHandler := &webapp2.ResetPasswordHandler{
-		Database:       handle,
+	resetPasswordSuccessHandler := &webapp2.ResetPasswordSuccessHandler{
+		Database:      handle,
+		BaseViewModel: baseViewModeler,
+		Renderer:      responseRenderer,
+		WebApp:        webappService,
+	}
+	return resetPasswordSuccessHandler
+}
+
+func newWebAppSettingsHandler(p *deps.RequestProvider) http.Handler {
+	appProvider := p.AppProvider
+	rootProvider := appProvider.RootProvider
+	environmentConfig := rootProvider.EnvironmentConfig
+	staticAssetURLPrefix := environmentConfig.StaticAssetURLPrefix
+	config := appProvider.Config
+	appConfig := config.AppConfig
+	uiConfig := appConfig.UI
+	request := p.Request
+	context := deps.ProvideRequestContext(request)
+	engine := appProvider.TemplateEngine
+	translationService := &translation.Service{
+		Context:           context,
+		EnvironmentConfig: environmentConfig,
+		TemplateEngine:    engine,
+	}
+	forgotPasswordConfig := appConfig.ForgotPassword
+	baseViewModeler := &viewmodels.BaseViewModeler{
+		StaticAssetURLPrefix: staticAssetURLPrefix,
+		AuthUI:               uiConfig,
+		Translation:          translationService,
+		ForgotPassword:       forgotPasswordConfig,
+	}
+	factory := appProvider.LoggerFactory
+	responseRendererLogger := webapp2.NewResponseRendererLogger(factory)
+	responseRenderer := &webapp2.ResponseRenderer{
+		TemplateEngine: engine,
+		Logger:         responseRendererLogger,
+	}
+	authenticationConfig := appConfig.Authentication
+	userProfileConfig := appConfig.UserProfile
+	secretConfig := config.SecretConfig
+	databaseCredentials := deps.ProvideDatabaseCredentials(secretConfig)
+	appID := appConfig.ID
+	sqlBuilder := db.ProvideSQLBuilder(databaseCredentials, appID)
+	handle := appProvider.Database
+	sqlExecutor := db.SQLExecutor{
+		Context:  context,
+		Database: handle,
+	}
+	store := &service2.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	passwordStore := &password.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	authenticatorConfig := appConfig.Authenticator
+	authenticatorPasswordConfig := authenticatorConfig.Password
+	clockClock := _wireSystemClockValue
+	logger := password.NewLogger(factory)
+	historyStore := &password.HistoryStore{
+		Clock:       clockClock,
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	checker := password.ProvideChecker(authenticatorPasswordConfig, historyStore)
+	queue := appProvider.TaskQueue
+	provider := &password.Provider{
+		Store:           passwordStore,
+		Config:          authenticatorPasswordConfig,
+		Clock:           clockClock,
+		Logger:          logger,
+		PasswordHistory: historyStore,
+		PasswordChecker: checker,
+		TaskQueue:       queue,
+		Database:        handle,
+	}
+	totpStore := &totp.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	authenticatorTOTPConfig := authenticatorConfig.TOTP
+	totpProvider := &totp.Provider{
+		Store:  totpStore,
+		Config: authenticatorTOTPConfig,
+		Clock:  clockClock,
+	}
+	authenticatorOOBConfig := authenticatorConfig.OOB
+	oobStore := &oob.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	oobProvider := &oob.Provider{
+		Config: authenticatorOOBConfig,
+		Store:  oobStore,
+		Clock:  clockClock,
+	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	redisHandle := appProvider.Redis
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
+	serviceService := &service2.Service{
+		Store:    store,
+		Password: provider,
+		TOTP:     totpProvider,
+		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
+	}
+	storeDeviceTokenRedis := &mfa.StoreDeviceTokenRedis{
+		Redis: redisHandle,
+		AppID: appID,
+		Clock: clockClock,
+	}
+	storeRecoveryCodePQ := &mfa.StoreRecoveryCodePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	mfaService := &mfa.Service{
+		DeviceTokens:  storeDeviceTokenRedis,
+		RecoveryCodes: storeRecoveryCodePQ,
+		Clock:         clockClock,
+		Config:        authenticationConfig,
+	}
+	settingsHandler := &webapp2.SettingsHandler{
 		BaseViewModel:  baseViewModeler,
 		Renderer:       responseRenderer,
-		WebApp:         webappService,
-		PasswordPolicy: passwordChecker,
+		Authentication: authenticationConfig,
+		UserProfile:    userProfileConfig,
+		Authenticators: serviceService,
+		MFA:            mfaService,
 	}
-	return resetPasswordHandler
+	return settingsHandler
 }
 
-func newWebAppResetPasswordSuccessHandler(p *deps.RequestProvider) http.Handler {
+func newWebAppSettingsIdentityHandler(p *deps.RequestProvider) http.Handler {
 	appProvider := p.AppProvider
 	handle := appProvider.Database
 	rootProvider := appProvider.RootProvider
@@ -8564,6 +12419,8 @@ func newWebAppResetPasswordSuccessHandler(p *deps.RequestProvider) http.Handler
 		Database: handle,
 	}
 	clockClock := _wireSystemClockValue
+	trustProxy := environmentConfig.TrustProxy
+	remoteIP := deps.ProvideRemoteIP(request, trustProxy)
 	authenticationConfig := appConfig.Authentication
 	identityConfig := appConfig.Identity
 	secretConfig := config.SecretConfig
@@ -8647,6 +12504,7 @@ func newWebAppResetPasswordSuccessHandler(p *deps.RequestProvider) http.Handler
 		PasswordHistory: historyStore,
 		PasswordChecker: passwordChecker,
 		TaskQueue:       queue,
+		Database:        handle,
 	}
 	totpStore := &totp.Store{
 		SQLBuilder:  sqlBuilder,
@@ -8668,11 +12526,29 @@ func newWebAppResetPasswordSuccessHandler(p *deps.RequestProvider) http.Handler
 		Store:  oobStore,
 		Clock:  clockClock,
 	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
 	service3 := &service2.Service{
 		Store:    serviceStore,
 		Password: passwordProvider,
 		TOTP:     totpProvider,
 		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
 	}
 	verificationLogger := verification.NewLogger(factory)
 	verificationConfig := appConfig.Verification
@@ -8704,7 +12580,7 @@ func newWebAppResetPasswordSuccessHandler(p *deps.RequestProvider) http.Handler
 	authenticatorFacade := facade.AuthenticatorFacade{
 		Coordinator: coordinator,
 	}
-	trustProxy := environmentConfig.TrustProxy
+	rateLimitConfig := appConfig.RateLimit
 	mainOriginProvider := &MainOriginProvider{
 		Request:    request,
 		TrustProxy: trustProxy,
@@ -8712,11 +12588,23 @@ func newWebAppResetPasswordSuccessHandler(p *deps.RequestProvider) http.Handler
 	endpointsProvider := &EndpointsProvider{
 		OriginProvider: mainOriginProvider,
 	}
+	ratelimitLogger := ratelimit.NewLogger(factory)
+	storageRedis := &ratelimit.StorageRedis{
+		AppID: appID,
+		Redis: redisHandle,
+		Clock: clockClock,
+	}
+	limiter := &ratelimit.Limiter{
+		Logger:  ratelimitLogger,
+		Storage: storageRedis,
+	}
 	messageSender := &otp.MessageSender{
 		StaticAssetURLPrefix: staticAssetURLPrefix,
+		RateLimitConfig:      rateLimitConfig,
 		Translation:          translationService,
 		Endpoints:            endpointsProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 	}
 	codeSender := &oob.CodeSender{
 		OTPMessageSender: messageSender,
@@ -8728,6 +12616,7 @@ func newWebAppResetPasswordSuccessHandler(p *deps.RequestProvider) http.Handler
 	userInfoDecoder := sso.UserInfoDecoder{
 		LoginIDNormalizerFactory: normalizerFactory,
 	}
+	oidcCache := rootProvider.OIDCCache
 	oAuthProviderFactory := &sso.OAuthProviderFactory{
 		Endpoints:                endpointsProvider,
 		IdentityConfig:           identityConfig,
@@ -8736,6 +12625,7 @@ func newWebAppResetPasswordSuccessHandler(p *deps.RequestProvider) http.Handler
 		Clock:                    clockClock,
 		UserInfoDecoder:          userInfoDecoder,
 		LoginIDNormalizerFactory: normalizerFactory,
+		OIDCCache:                oidcCache,
 	}
 	storeDeviceTokenRedis := &mfa.StoreDeviceTokenRedis{
 		Redis: redisHandle,
@@ -8760,10 +12650,12 @@ func newWebAppResetPasswordSuccessHandler(p *deps.RequestProvider) http.Handler
 		StaticAssetURLPrefix: staticAssetURLPrefix,
 		Translation:          translationService,
 		Config:               forgotPasswordConfig,
+		RateLimitConfig:      rateLimitConfig,
 		Store:                forgotpasswordStore,
 		Clock:                clockClock,
 		URLs:                 urlProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 		Logger:               providerLogger,
 		Identities:           identityFacade,
 		Authenticators:       authenticatorFacade,
@@ -8791,6 +12683,7 @@ func newWebAppResetPasswordSuccessHandler(p *deps.RequestProvider) http.Handler
 		Store:        userStore,
 		Identities:   identityFacade,
 		Verification: verificationService,
+		Clock:        clockClock,
 	}
 	rawCommands := &user.RawCommands{
 		Store:                  userStore,
@@ -8804,6 +12697,7 @@ func newWebAppResetPasswordSuccessHandler(p *deps.RequestProvider) http.Handler
 		Queries:     queries,
 	}
 	hookStore := &hook.Store{
+		Clock:       clockClock,
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
@@ -8826,16 +12720,42 @@ func newWebAppResetPasswordSuccessHandler(p *deps.RequestProvider) http.Handler
 		Users:     rawProvider,
 		Store:     hookStore,
 		Deliverer: deliverer,
+		TaskQueue: queue,
 	}
+	userProfileConfig := appConfig.UserProfile
 	commands := &user.Commands{
-		Raw:          rawCommands,
-		Hooks:        hookProvider,
-		Verification: verificationService,
+		Raw:               rawCommands,
+		Hooks:             hookProvider,
+		Verification:      verificationService,
+		UserProfileConfig: userProfileConfig,
 	}
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
+	authenticationLockoutConfig := authenticationConfig.Lockout
+	lockoutStorePQ := &lockout.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	lockoutService := &lockout.Service{
+		Config:   authenticationLockoutConfig,
+		Clock:    clockClock,
+		Store:    lockoutStorePQ,
+		Database: handle,
+		Users:    rawProvider,
+		Hooks:    hookProvider,
+	}
+	auditStorePQ := &audit.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	auditService := &audit.Service{
+		Context:  context,
+		Clock:    clockClock,
+		Store:    auditStorePQ,
+		Database: handle,
+	}
 	cookieFactory := deps.NewCookieFactory(request, trustProxy)
 	storeRedisLogger := idpsession.NewStoreRedisLogger(factory)
 	idpsessionStoreRedis := &idpsession.StoreRedis{
@@ -8862,18 +12782,19 @@ func newWebAppResetPasswordSuccessHandler(p *deps.RequestProvider) http.Handler
 		Clock:        clockClock,
 		Random:       idpsessionRand,
 	}
-	httpConfig := appConfig.HTTP
 	cookieDef := idpsession.NewSessionCookieDef(httpConfig, sessionConfig)
 	mfaCookieDef := mfa.NewDeviceTokenCookieDef(httpConfig, authenticationConfig)
 	interactionContext := &interaction.Context{
 		Database:                 sqlExecutor,
 		Clock:                    clockClock,
 		Config:                   appConfig,
+		RemoteIP:                 remoteIP,
 		Identities:               identityFacade,
 		Authenticators:           authenticatorFacade,
 		AnonymousIdentities:      anonymousProvider,
 		OOBAuthenticators:        oobProvider,
 		OOBCodeSender:            codeSender,
+		WebAuthnAuthenticators:   webauthnProvider,
 		OAuthProviderFactory:     oAuthProviderFactory,
 		MFA:                      mfaService,
 		ForgotPassword:           forgotpasswordProvider,
@@ -8884,6 +12805,9 @@ func newWebAppResetPasswordSuccessHandler(p *deps.RequestProvider) http.Handler
 		Challenges:               challengeProvider,
 		Users:                    userProvider,
 		Hooks:                    hookProvider,
+		RateLimiter:              limiter,
+		Lockout:                  lockoutService,
+		Audit:                    auditService,
 		CookieFactory:            cookieFactory,
 		Sessions:                 idpsessionProvider,
 		SessionCookie:            cookieDef,
@@ -8907,17 +12831,22 @@ func newWebAppResetPasswordSuccessHandler(p *deps.RequestProvider) http.Handler
 		CookieFactory: cookieFactory,
 		UATokenCookie: webappCookieDef,
 	}
-	resetPasswordSuccessHandler := &webapp2.ResetPasswordSuccessHandler{
+	csrfCookieDef := webapp.NewCSRFCookieDef(httpConfig)
+	settingsIdentityHandler := &webapp2.SettingsIdentityHandler{
 		Database:      handle,
 		BaseViewModel: baseViewModeler,
 		Renderer:      responseRenderer,
 		WebApp:        webappService,
+		Identities:    serviceService,
+		Verification:  verificationService,
+		CSRFCookie:    csrfCookieDef,
 	}
-	return resetPasswordSuccessHandler
+	return settingsIdentityHandler
 }
 
-func newWebAppSettingsHandler(p *deps.RequestProvider) http.Handler {
+func newWebAppSettingsAuthorizedAppsHandler(p *deps.RequestProvider) http.Handler {
 	appProvider := p.AppProvider
+	handle := appProvider.Database
 	rootProvider := appProvider.RootProvider
 	environmentConfig := rootProvider.EnvironmentConfig
 	staticAssetURLPrefix := environmentConfig.StaticAssetURLPrefix
@@ -8945,17 +12874,136 @@ func newWebAppSettingsHandler(p *deps.RequestProvider) http.Handler {
 		TemplateEngine: engine,
 		Logger:         responseRendererLogger,
 	}
-	authenticationConfig := appConfig.Authentication
+	appID := appConfig.ID
+	oAuthConfig := appConfig.OAuth
 	secretConfig := config.SecretConfig
 	databaseCredentials := deps.ProvideDatabaseCredentials(secretConfig)
-	appID := appConfig.ID
 	sqlBuilder := db.ProvideSQLBuilder(databaseCredentials, appID)
+	sqlExecutor := db.SQLExecutor{
+		Context:  context,
+		Database: handle,
+	}
+	authorizationStore := &pq.AuthorizationStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	scopesValidator := _wireScopesValidatorValue
+	clockClock := _wireSystemClockValue
+	consentService := &handler.ConsentService{
+		AppID:          appID,
+		Config:         oAuthConfig,
+		Authorizations: authorizationStore,
+		ValidateScopes: scopesValidator,
+		Clock:          clockClock,
+	}
+	settingsAuthorizedAppsHandler := &webapp2.SettingsAuthorizedAppsHandler{
+		Database:      handle,
+		BaseViewModel: baseViewModeler,
+		Renderer:      responseRenderer,
+		Consents:      consentService,
+	}
+	return settingsAuthorizedAppsHandler
+}
+
+func newWebAppSettingsSessionsHandler(p *deps.RequestProvider) http.Handler {
+	appProvider := p.AppProvider
 	handle := appProvider.Database
+	rootProvider := appProvider.RootProvider
+	environmentConfig := rootProvider.EnvironmentConfig
+	staticAssetURLPrefix := environmentConfig.StaticAssetURLPrefix
+	config := appProvider.Config
+	appConfig := config.AppConfig
+	uiConfig := appConfig.UI
+	request := p.Request
+	context := deps.ProvideRequestContext(request)
+	engine := appProvider.TemplateEngine
+	translationService := &translation.Service{
+		Context:           context,
+		EnvironmentConfig: environmentConfig,
+		TemplateEngine:    engine,
+	}
+	forgotPasswordConfig := appConfig.ForgotPassword
+	baseViewModeler := &viewmodels.BaseViewModeler{
+		StaticAssetURLPrefix: staticAssetURLPrefix,
+		AuthUI:               uiConfig,
+		Translation:          translationService,
+		ForgotPassword:       forgotPasswordConfig,
+	}
+	factory := appProvider.LoggerFactory
+	responseRendererLogger := webapp2.NewResponseRendererLogger(factory)
+	responseRenderer := &webapp2.ResponseRenderer{
+		TemplateEngine: engine,
+		Logger:         responseRendererLogger,
+	}
+	oAuthConfig := appConfig.OAuth
+	secretConfig := config.SecretConfig
+	databaseCredentials := deps.ProvideDatabaseCredentials(secretConfig)
+	appID := appConfig.ID
+	sqlBuilder := db.ProvideSQLBuilder(databaseCredentials, appID)
 	sqlExecutor := db.SQLExecutor{
 		Context:  context,
 		Database: handle,
 	}
-	store := &service2.Store{
+	store := &user.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	authenticationConfig := appConfig.Authentication
+	identityConfig := appConfig.Identity
+	serviceStore := &service.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	loginidStore := &loginid.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	loginIDConfig := identityConfig.LoginID
+	reservedNameChecker := rootProvider.ReservedNameChecker
+	typeCheckerFactory := &loginid.TypeCheckerFactory{
+		Config:              loginIDConfig,
+		ReservedNameChecker: reservedNameChecker,
+	}
+	checker := &loginid.Checker{
+		Config:             loginIDConfig,
+		TypeCheckerFactory: typeCheckerFactory,
+	}
+	normalizerFactory := &loginid.NormalizerFactory{
+		Config: loginIDConfig,
+	}
+	clockClock := _wireSystemClockValue
+	provider := &loginid.Provider{
+		Store:             loginidStore,
+		Config:            loginIDConfig,
+		Checker:           checker,
+		NormalizerFactory: normalizerFactory,
+		Clock:             clockClock,
+	}
+	oauthStore := &oauth3.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	oauthProvider := &oauth3.Provider{
+		Store: oauthStore,
+		Clock: clockClock,
+	}
+	anonymousStore := &anonymous.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	anonymousProvider := &anonymous.Provider{
+		Store: anonymousStore,
+		Clock: clockClock,
+	}
+	serviceService := &service.Service{
+		Authentication: authenticationConfig,
+		Identity:       identityConfig,
+		Store:          serviceStore,
+		LoginID:        provider,
+		OAuth:          oauthProvider,
+		Anonymous:      anonymousProvider,
+	}
+	store2 := &service2.Store{
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
@@ -8965,23 +13013,23 @@ func newWebAppSettingsHandler(p *deps.RequestProvider) http.Handler {
 	}
 	authenticatorConfig := appConfig.Authenticator
 	authenticatorPasswordConfig := authenticatorConfig.Password
-	clockClock := _wireSystemClockValue
 	logger := password.NewLogger(factory)
 	historyStore := &password.HistoryStore{
 		Clock:       clockClock,
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
-	checker := password.ProvideChecker(authenticatorPasswordConfig, historyStore)
+	passwordChecker := password.ProvideChecker(authenticatorPasswordConfig, historyStore)
 	queue := appProvider.TaskQueue
-	provider := &password.Provider{
+	passwordProvider := &password.Provider{
 		Store:           passwordStore,
 		Config:          authenticatorPasswordConfig,
 		Clock:           clockClock,
 		Logger:          logger,
 		PasswordHistory: historyStore,
-		PasswordChecker: checker,
+		PasswordChecker: passwordChecker,
 		TaskQueue:       queue,
+		Database:        handle,
 	}
 	totpStore := &totp.Store{
 		SQLBuilder:  sqlBuilder,
@@ -9003,39 +13051,155 @@ func newWebAppSettingsHandler(p *deps.RequestProvider) http.Handler {
 		Store:  oobStore,
 		Clock:  clockClock,
 	}
-	serviceService := &service2.Service{
-		Store:    store,
-		Password: provider,
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	redisHandle := appProvider.Redis
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
+	service3 := &service2.Service{
+		Store:    store2,
+		Password: passwordProvider,
 		TOTP:     totpProvider,
 		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
 	}
-	redisHandle := appProvider.Redis
-	storeDeviceTokenRedis := &mfa.StoreDeviceTokenRedis{
+	verificationLogger := verification.NewLogger(factory)
+	verificationConfig := appConfig.Verification
+	storeRedis := &verification.StoreRedis{
 		Redis: redisHandle,
 		AppID: appID,
 		Clock: clockClock,
 	}
-	storeRecoveryCodePQ := &mfa.StoreRecoveryCodePQ{
+	storePQ := &verification.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	verificationService := &verification.Service{
+		Logger:     verificationLogger,
+		Config:     verificationConfig,
+		Clock:      clockClock,
+		CodeStore:  storeRedis,
+		ClaimStore: storePQ,
+	}
+	coordinator := &facade.Coordinator{
+		Identities:     serviceService,
+		Authenticators: service3,
+		Verification:   verificationService,
+		IdentityConfig: identityConfig,
+	}
+	identityFacade := facade.IdentityFacade{
+		Coordinator: coordinator,
+	}
+	queries := &user.Queries{
+		Store:        store,
+		Identities:   identityFacade,
+		Verification: verificationService,
+		Clock:        clockClock,
+	}
+	hookLogger := hook.NewLogger(factory)
+	welcomeMessageConfig := appConfig.WelcomeMessage
+	welcomemessageProvider := &welcomemessage.Provider{
+		Translation:          translationService,
+		WelcomeMessageConfig: welcomeMessageConfig,
+		TaskQueue:            queue,
+	}
+	rawCommands := &user.RawCommands{
+		Store:                  store,
+		Clock:                  clockClock,
+		WelcomeMessageProvider: welcomemessageProvider,
+		Queries:                queries,
+	}
+	rawProvider := &user.RawProvider{
+		RawCommands: rawCommands,
+		Queries:     queries,
+	}
+	hookStore := &hook.Store{
+		Clock:       clockClock,
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	hookConfig := appConfig.Hook
+	webhookKeyMaterials := deps.ProvideWebhookKeyMaterials(secretConfig)
+	syncHTTPClient := hook.NewSyncHTTPClient(hookConfig)
+	asyncHTTPClient := hook.NewAsyncHTTPClient()
+	deliverer := &hook.Deliverer{
+		Config:    hookConfig,
+		Secret:    webhookKeyMaterials,
+		Clock:     clockClock,
+		SyncHTTP:  syncHTTPClient,
+		AsyncHTTP: asyncHTTPClient,
+	}
+	hookProvider := &hook.Provider{
+		Context:   context,
+		Logger:    hookLogger,
+		Database:  handle,
+		Clock:     clockClock,
+		Users:     rawProvider,
+		Store:     hookStore,
+		Deliverer: deliverer,
+		TaskQueue: queue,
+	}
+	storeRedisLogger := idpsession.NewStoreRedisLogger(factory)
+	idpsessionStoreRedis := &idpsession.StoreRedis{
+		Redis:  redisHandle,
+		AppID:  appID,
+		Clock:  clockClock,
+		Logger: storeRedisLogger,
+	}
+	sessionConfig := appConfig.Session
+	trustProxy := environmentConfig.TrustProxy
+	cookieFactory := deps.NewCookieFactory(request, trustProxy)
+	cookieDef := idpsession.NewSessionCookieDef(httpConfig, sessionConfig)
+	manager := &idpsession.Manager{
+		Store:         idpsessionStoreRedis,
+		Clock:         clockClock,
+		Config:        sessionConfig,
+		CookieFactory: cookieFactory,
+		CookieDef:     cookieDef,
+	}
+	redisLogger := redis.NewLogger(factory)
+	grantStore := &redis.GrantStore{
+		Redis:       redisHandle,
+		AppID:       appID,
+		Logger:      redisLogger,
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
+		Clock:       clockClock,
 	}
-	mfaService := &mfa.Service{
-		DeviceTokens:  storeDeviceTokenRedis,
-		RecoveryCodes: storeRecoveryCodePQ,
-		Clock:         clockClock,
-		Config:        authenticationConfig,
+	sessionManager := &oauth2.SessionManager{
+		Store: grantStore,
+		Clock: clockClock,
 	}
-	settingsHandler := &webapp2.SettingsHandler{
-		BaseViewModel:  baseViewModeler,
-		Renderer:       responseRenderer,
-		Authentication: authenticationConfig,
-		Authenticators: serviceService,
-		MFA:            mfaService,
+	manager2 := &session.Manager{
+		Users:               queries,
+		Hooks:               hookProvider,
+		IDPSessions:         manager,
+		AccessTokenSessions: sessionManager,
 	}
-	return settingsHandler
+	settingsSessionsHandler := &webapp2.SettingsSessionsHandler{
+		Database:      handle,
+		BaseViewModel: baseViewModeler,
+		Renderer:      responseRenderer,
+		OAuth:         oAuthConfig,
+		Sessions:      manager2,
+	}
+	return settingsSessionsHandler
 }
 
-func newWebAppSettingsIdentityHandler(p *deps.RequestProvider) http.Handler {
+func newWebAppSettingsProfileHandler(p *deps.RequestProvider) http.Handler {
 	appProvider := p.AppProvider
 	handle := appProvider.Database
 	rootProvider := appProvider.RootProvider
@@ -9065,25 +13229,30 @@ func newWebAppSettingsIdentityHandler(p *deps.RequestProvider) http.Handler {
 		TemplateEngine: engine,
 		Logger:         responseRendererLogger,
 	}
-	serviceLogger := webapp.NewServiceLogger(factory)
+	userProfileConfig := appConfig.UserProfile
+	secretConfig := config.SecretConfig
+	databaseCredentials := deps.ProvideDatabaseCredentials(secretConfig)
 	appID := appConfig.ID
-	redisHandle := appProvider.Redis
-	redisStore := &webapp.RedisStore{
-		AppID: appID,
-		Redis: redisHandle,
-	}
-	logger := interaction.NewLogger(factory)
+	sqlBuilder := db.ProvideSQLBuilder(databaseCredentials, appID)
 	sqlExecutor := db.SQLExecutor{
 		Context:  context,
 		Database: handle,
 	}
+	store := &user.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
 	clockClock := _wireSystemClockValue
+	welcomeMessageConfig := appConfig.WelcomeMessage
+	queue := appProvider.TaskQueue
+	provider := &welcomemessage.Provider{
+		Translation:          translationService,
+		WelcomeMessageConfig: welcomeMessageConfig,
+		TaskQueue:            queue,
+	}
 	authenticationConfig := appConfig.Authentication
 	identityConfig := appConfig.Identity
-	secretConfig := config.SecretConfig
-	databaseCredentials := deps.ProvideDatabaseCredentials(secretConfig)
-	sqlBuilder := db.ProvideSQLBuilder(databaseCredentials, appID)
-	store := &service.Store{
+	serviceStore := &service.Store{
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
@@ -9104,7 +13273,7 @@ func newWebAppSettingsIdentityHandler(p *deps.RequestProvider) http.Handler {
 	normalizerFactory := &loginid.NormalizerFactory{
 		Config: loginIDConfig,
 	}
-	provider := &loginid.Provider{
+	loginidProvider := &loginid.Provider{
 		Store:             loginidStore,
 		Config:            loginIDConfig,
 		Checker:           checker,
@@ -9130,12 +13299,12 @@ func newWebAppSettingsIdentityHandler(p *deps.RequestProvider) http.Handler {
 	serviceService := &service.Service{
 		Authentication: authenticationConfig,
 		Identity:       identityConfig,
-		Store:          store,
-		LoginID:        provider,
+		Store:          serviceStore,
+		LoginID:        loginidProvider,
 		OAuth:          oauthProvider,
 		Anonymous:      anonymousProvider,
 	}
-	serviceStore := &service2.Store{
+	store2 := &service2.Store{
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
@@ -9145,22 +13314,22 @@ func newWebAppSettingsIdentityHandler(p *deps.RequestProvider) http.Handler {
 	}
 	authenticatorConfig := appConfig.Authenticator
 	authenticatorPasswordConfig := authenticatorConfig.Password
-	passwordLogger := password.NewLogger(factory)
+	logger := password.NewLogger(factory)
 	historyStore := &password.HistoryStore{
 		Clock:       clockClock,
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
 	passwordChecker := password.ProvideChecker(authenticatorPasswordConfig, historyStore)
-	queue := appProvider.TaskQueue
 	passwordProvider := &password.Provider{
 		Store:           passwordStore,
 		Config:          authenticatorPasswordConfig,
 		Clock:           clockClock,
-		Logger:          passwordLogger,
+		Logger:          logger,
 		PasswordHistory: historyStore,
 		PasswordChecker: passwordChecker,
 		TaskQueue:       queue,
+		Database:        handle,
 	}
 	totpStore := &totp.Store{
 		SQLBuilder:  sqlBuilder,
@@ -9182,11 +13351,30 @@ func newWebAppSettingsIdentityHandler(p *deps.RequestProvider) http.Handler {
 		Store:  oobStore,
 		Clock:  clockClock,
 	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	redisHandle := appProvider.Redis
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
 	service3 := &service2.Service{
-		Store:    serviceStore,
+		Store:    store2,
 		Password: passwordProvider,
 		TOTP:     totpProvider,
 		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
 	}
 	verificationLogger := verification.NewLogger(factory)
 	verificationConfig := appConfig.Verification
@@ -9215,101 +13403,16 @@ func newWebAppSettingsIdentityHandler(p *deps.RequestProvider) http.Handler {
 	identityFacade := facade.IdentityFacade{
 		Coordinator: coordinator,
 	}
-	authenticatorFacade := facade.AuthenticatorFacade{
-		Coordinator: coordinator,
-	}
-	trustProxy := environmentConfig.TrustProxy
-	mainOriginProvider := &MainOriginProvider{
-		Request:    request,
-		TrustProxy: trustProxy,
-	}
-	endpointsProvider := &EndpointsProvider{
-		OriginProvider: mainOriginProvider,
-	}
-	messageSender := &otp.MessageSender{
-		StaticAssetURLPrefix: staticAssetURLPrefix,
-		Translation:          translationService,
-		Endpoints:            endpointsProvider,
-		TaskQueue:            queue,
-	}
-	codeSender := &oob.CodeSender{
-		OTPMessageSender: messageSender,
-	}
-	oAuthClientCredentials := deps.ProvideOAuthClientCredentials(secretConfig)
-	urlProvider := &webapp.URLProvider{
-		Endpoints: endpointsProvider,
-	}
-	userInfoDecoder := sso.UserInfoDecoder{
-		LoginIDNormalizerFactory: normalizerFactory,
-	}
-	oAuthProviderFactory := &sso.OAuthProviderFactory{
-		Endpoints:                endpointsProvider,
-		IdentityConfig:           identityConfig,
-		Credentials:              oAuthClientCredentials,
-		RedirectURL:              urlProvider,
-		Clock:                    clockClock,
-		UserInfoDecoder:          userInfoDecoder,
-		LoginIDNormalizerFactory: normalizerFactory,
-	}
-	storeDeviceTokenRedis := &mfa.StoreDeviceTokenRedis{
-		Redis: redisHandle,
-		AppID: appID,
-		Clock: clockClock,
-	}
-	storeRecoveryCodePQ := &mfa.StoreRecoveryCodePQ{
-		SQLBuilder:  sqlBuilder,
-		SQLExecutor: sqlExecutor,
-	}
-	mfaService := &mfa.Service{
-		DeviceTokens:  storeDeviceTokenRedis,
-		RecoveryCodes: storeRecoveryCodePQ,
-		Clock:         clockClock,
-		Config:        authenticationConfig,
-	}
-	forgotpasswordStore := &forgotpassword.Store{
-		Redis: redisHandle,
-	}
-	providerLogger := forgotpassword.NewProviderLogger(factory)
-	forgotpasswordProvider := &forgotpassword.Provider{
-		StaticAssetURLPrefix: staticAssetURLPrefix,
-		Translation:          translationService,
-		Config:               forgotPasswordConfig,
-		Store:                forgotpasswordStore,
-		Clock:                clockClock,
-		URLs:                 urlProvider,
-		TaskQueue:            queue,
-		Logger:               providerLogger,
-		Identities:           identityFacade,
-		Authenticators:       authenticatorFacade,
-	}
-	verificationCodeSender := &verification.CodeSender{
-		OTPMessageSender: messageSender,
-		WebAppURLs:       urlProvider,
-	}
-	challengeProvider := &challenge.Provider{
-		Redis: redisHandle,
-		AppID: appID,
-		Clock: clockClock,
-	}
-	userStore := &user.Store{
-		SQLBuilder:  sqlBuilder,
-		SQLExecutor: sqlExecutor,
-	}
-	welcomeMessageConfig := appConfig.WelcomeMessage
-	welcomemessageProvider := &welcomemessage.Provider{
-		Translation:          translationService,
-		WelcomeMessageConfig: welcomeMessageConfig,
-		TaskQueue:            queue,
-	}
 	queries := &user.Queries{
-		Store:        userStore,
+		Store:        store,
 		Identities:   identityFacade,
 		Verification: verificationService,
+		Clock:        clockClock,
 	}
 	rawCommands := &user.RawCommands{
-		Store:                  userStore,
+		Store:                  store,
 		Clock:                  clockClock,
-		WelcomeMessageProvider: welcomemessageProvider,
+		WelcomeMessageProvider: provider,
 		Queries:                queries,
 	}
 	hookLogger := hook.NewLogger(factory)
@@ -9318,6 +13421,7 @@ func newWebAppSettingsIdentityHandler(p *deps.RequestProvider) http.Handler {
 		Queries:     queries,
 	}
 	hookStore := &hook.Store{
+		Clock:       clockClock,
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
@@ -9340,98 +13444,174 @@ func newWebAppSettingsIdentityHandler(p *deps.RequestProvider) http.Handler {
 		Users:     rawProvider,
 		Store:     hookStore,
 		Deliverer: deliverer,
+		TaskQueue: queue,
 	}
 	commands := &user.Commands{
-		Raw:          rawCommands,
-		Hooks:        hookProvider,
-		Verification: verificationService,
+		Raw:               rawCommands,
+		Hooks:             hookProvider,
+		Verification:      verificationService,
+		UserProfileConfig: userProfileConfig,
 	}
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
-	cookieFactory := deps.NewCookieFactory(request, trustProxy)
-	storeRedisLogger := idpsession.NewStoreRedisLogger(factory)
-	idpsessionStoreRedis := &idpsession.StoreRedis{
-		Redis:  redisHandle,
-		AppID:  appID,
-		Clock:  clockClock,
-		Logger: storeRedisLogger,
+	settingsProfileHandler := &webapp2.SettingsProfileHandler{
+		Database:          handle,
+		BaseViewModel:     baseViewModeler,
+		Renderer:          responseRenderer,
+		UserProfileConfig: userProfileConfig,
+		Users:             userProvider,
+	}
+	return settingsProfileHandler
+}
+
+func newWebAppConsentHandler(p *deps.RequestProvider) http.Handler {
+	appProvider := p.AppProvider
+	handle := appProvider.Database
+	rootProvider := appProvider.RootProvider
+	environmentConfig := rootProvider.EnvironmentConfig
+	trustProxy := environmentConfig.TrustProxy
+	staticAssetURLPrefix := environmentConfig.StaticAssetURLPrefix
+	config := appProvider.Config
+	appConfig := config.AppConfig
+	uiConfig := appConfig.UI
+	request := p.Request
+	context := deps.ProvideRequestContext(request)
+	engine := appProvider.TemplateEngine
+	translationService := &translation.Service{
+		Context:           context,
+		EnvironmentConfig: environmentConfig,
+		TemplateEngine:    engine,
+	}
+	forgotPasswordConfig := appConfig.ForgotPassword
+	baseViewModeler := &viewmodels.BaseViewModeler{
+		StaticAssetURLPrefix: staticAssetURLPrefix,
+		AuthUI:               uiConfig,
+		Translation:          translationService,
+		ForgotPassword:       forgotPasswordConfig,
+	}
+	factory := appProvider.LoggerFactory
+	responseRendererLogger := webapp2.NewResponseRendererLogger(factory)
+	responseRenderer := &webapp2.ResponseRenderer{
+		TemplateEngine: engine,
+		Logger:         responseRendererLogger,
+	}
+	appID := appConfig.ID
+	oAuthConfig := appConfig.OAuth
+	secretConfig := config.SecretConfig
+	databaseCredentials := deps.ProvideDatabaseCredentials(secretConfig)
+	sqlBuilder := db.ProvideSQLBuilder(databaseCredentials, appID)
+	sqlExecutor := db.SQLExecutor{
+		Context:  context,
+		Database: handle,
+	}
+	authorizationStore := &pq.AuthorizationStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	scopesValidator := _wireScopesValidatorValue
+	clockClock := _wireSystemClockValue
+	consentService := &handler.ConsentService{
+		AppID:          appID,
+		Config:         oAuthConfig,
+		Authorizations: authorizationStore,
+		ValidateScopes: scopesValidator,
+		Clock:          clockClock,
+	}
+	consentHandler := &webapp2.ConsentHandler{
+		Database:      handle,
+		TrustProxy:    trustProxy,
+		BaseViewModel: baseViewModeler,
+		Renderer:      responseRenderer,
+		Consents:      consentService,
+	}
+	return consentHandler
+}
+
+func newWebAppDeviceHandler(p *deps.RequestProvider) http.Handler {
+	appProvider := p.AppProvider
+	handle := appProvider.Database
+	rootProvider := appProvider.RootProvider
+	environmentConfig := rootProvider.EnvironmentConfig
+	staticAssetURLPrefix := environmentConfig.StaticAssetURLPrefix
+	config := appProvider.Config
+	appConfig := config.AppConfig
+	uiConfig := appConfig.UI
+	request := p.Request
+	context := deps.ProvideRequestContext(request)
+	engine := appProvider.TemplateEngine
+	translationService := &translation.Service{
+		Context:           context,
+		EnvironmentConfig: environmentConfig,
+		TemplateEngine:    engine,
+	}
+	forgotPasswordConfig := appConfig.ForgotPassword
+	baseViewModeler := &viewmodels.BaseViewModeler{
+		StaticAssetURLPrefix: staticAssetURLPrefix,
+		AuthUI:               uiConfig,
+		Translation:          translationService,
+		ForgotPassword:       forgotPasswordConfig,
+	}
+	factory := appProvider.LoggerFactory
+	responseRendererLogger := webapp2.NewResponseRendererLogger(factory)
+	responseRenderer := &webapp2.ResponseRenderer{
+		TemplateEngine: engine,
+		Logger:         responseRendererLogger,
 	}
-	eventStoreRedis := &access.EventStoreRedis{
-		Redis: redisHandle,
-		AppID: appID,
+	appID := appConfig.ID
+	oAuthConfig := appConfig.OAuth
+	redisHandle := appProvider.Redis
+	logger := redis.NewLogger(factory)
+	secretConfig := config.SecretConfig
+	databaseCredentials := deps.ProvideDatabaseCredentials(secretConfig)
+	sqlBuilder := db.ProvideSQLBuilder(databaseCredentials, appID)
+	sqlExecutor := db.SQLExecutor{
+		Context:  context,
+		Database: handle,
 	}
-	eventProvider := &access.EventProvider{
-		Store: eventStoreRedis,
+	clockClock := _wireSystemClockValue
+	grantStore := &redis.GrantStore{
+		Redis:       redisHandle,
+		AppID:       appID,
+		Logger:      logger,
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+		Clock:       clockClock,
 	}
-	sessionConfig := appConfig.Session
-	idpsessionRand := _wireRandValue
-	idpsessionProvider := &idpsession.Provider{
-		Request:      request,
-		Store:        idpsessionStoreRedis,
-		AccessEvents: eventProvider,
-		TrustProxy:   trustProxy,
-		Config:       sessionConfig,
-		Clock:        clockClock,
-		Random:       idpsessionRand,
+	authorizationStore := &pq.AuthorizationStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
 	}
-	httpConfig := appConfig.HTTP
-	cookieDef := idpsession.NewSessionCookieDef(httpConfig, sessionConfig)
-	mfaCookieDef := mfa.NewDeviceTokenCookieDef(httpConfig, authenticationConfig)
-	interactionContext := &interaction.Context{
-		Database:                 sqlExecutor,
-		Clock:                    clockClock,
-		Config:                   appConfig,
-		Identities:               identityFacade,
-		Authenticators:           authenticatorFacade,
-		AnonymousIdentities:      anonymousProvider,
-		OOBAuthenticators:        oobProvider,
-		OOBCodeSender:            codeSender,
-		OAuthProviderFactory:     oAuthProviderFactory,
-		MFA:                      mfaService,
-		ForgotPassword:           forgotpasswordProvider,
-		ResetPassword:            forgotpasswordProvider,
-		LoginIDNormalizerFactory: normalizerFactory,
-		Verification:             verificationService,
-		VerificationCodeSender:   verificationCodeSender,
-		Challenges:               challengeProvider,
-		Users:                    userProvider,
-		Hooks:                    hookProvider,
-		CookieFactory:            cookieFactory,
-		Sessions:                 idpsessionProvider,
-		SessionCookie:            cookieDef,
-		MFADeviceTokenCookie:     mfaCookieDef,
+	deviceService := &handler.DeviceService{
+		AppID:          appID,
+		Config:         oAuthConfig,
+		DeviceGrants:   grantStore,
+		Authorizations: authorizationStore,
+		Clock:          clockClock,
 	}
-	interactionStoreRedis := &interaction.StoreRedis{
-		Redis: redisHandle,
+	trustProxy := environmentConfig.TrustProxy
+	ratelimitLogger := ratelimit.NewLogger(factory)
+	storageRedis := &ratelimit.StorageRedis{
 		AppID: appID,
+		Redis: redisHandle,
+		Clock: clockClock,
 	}
-	interactionService := &interaction.Service{
-		Logger:  logger,
-		Context: interactionContext,
-		Store:   interactionStoreRedis,
-	}
-	webappCookieDef := webapp.NewUATokenCookieDef(httpConfig)
-	webappService := &webapp.Service{
-		Logger:        serviceLogger,
-		Request:       request,
-		Store:         redisStore,
-		Graph:         interactionService,
-		CookieFactory: cookieFactory,
-		UATokenCookie: webappCookieDef,
+	limiter := &ratelimit.Limiter{
+		Logger:  ratelimitLogger,
+		Storage: storageRedis,
 	}
-	csrfCookieDef := webapp.NewCSRFCookieDef(httpConfig)
-	settingsIdentityHandler := &webapp2.SettingsIdentityHandler{
-		Database:      handle,
-		BaseViewModel: baseViewModeler,
-		Renderer:      responseRenderer,
-		WebApp:        webappService,
-		Identities:    serviceService,
-		Verification:  verificationService,
-		CSRFCookie:    csrfCookieDef,
+	rateLimitConfig := appConfig.RateLimit
+	deviceHandler := &webapp2.DeviceHandler{
+		Database:        handle,
+		BaseViewModel:   baseViewModeler,
+		Renderer:        responseRenderer,
+		Devices:         deviceService,
+		TrustProxy:      trustProxy,
+		RateLimiter:     limiter,
+		RateLimitConfig: rateLimitConfig,
 	}
-	return settingsIdentityHandler
+	return deviceHandler
 }
 
 func newWebAppChangePasswordHandler(p *deps.RequestProvider) http.Handler {
@@ -9477,6 +13657,8 @@ func newWebAppChangePasswordHandler(p *deps.RequestProvider) http.Handler {
 		Database: handle,
 	}
 	clockClock := _wireSystemClockValue
+	trustProxy := environmentConfig.TrustProxy
+	remoteIP := deps.ProvideRemoteIP(request, trustProxy)
 	authenticationConfig := appConfig.Authentication
 	identityConfig := appConfig.Identity
 	secretConfig := config.SecretConfig
@@ -9560,6 +13742,7 @@ func newWebAppChangePasswordHandler(p *deps.RequestProvider) http.Handler {
 		PasswordHistory: historyStore,
 		PasswordChecker: passwordChecker,
 		TaskQueue:       queue,
+		Database:        handle,
 	}
 	totpStore := &totp.Store{
 		SQLBuilder:  sqlBuilder,
@@ -9581,11 +13764,29 @@ func newWebAppChangePasswordHandler(p *deps.RequestProvider) http.Handler {
 		Store:  oobStore,
 		Clock:  clockClock,
 	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
 	service3 := &service2.Service{
 		Store:    serviceStore,
 		Password: passwordProvider,
 		TOTP:     totpProvider,
 		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
 	}
 	verificationLogger := verification.NewLogger(factory)
 	verificationConfig := appConfig.Verification
@@ -9617,7 +13818,7 @@ func newWebAppChangePasswordHandler(p *deps.RequestProvider) http.Handler {
 	authenticatorFacade := facade.AuthenticatorFacade{
 		Coordinator: coordinator,
 	}
-	trustProxy := environmentConfig.TrustProxy
+	rateLimitConfig := appConfig.RateLimit
 	mainOriginProvider := &MainOriginProvider{
 		Request:    request,
 		TrustProxy: trustProxy,
@@ -9625,11 +13826,23 @@ func newWebAppChangePasswordHandler(p *deps.RequestProvider) http.Handler {
 	endpointsProvider := &EndpointsProvider{
 		OriginProvider: mainOriginProvider,
 	}
+	ratelimitLogger := ratelimit.NewLogger(factory)
+	storageRedis := &ratelimit.StorageRedis{
+		AppID: appID,
+		Redis: redisHandle,
+		Clock: clockClock,
+	}
+	limiter := &ratelimit.Limiter{
+		Logger:  ratelimitLogger,
+		Storage: storageRedis,
+	}
 	messageSender := &otp.MessageSender{
 		StaticAssetURLPrefix: staticAssetURLPrefix,
+		RateLimitConfig:      rateLimitConfig,
 		Translation:          translationService,
 		Endpoints:            endpointsProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 	}
 	codeSender := &oob.CodeSender{
 		OTPMessageSender: messageSender,
@@ -9641,6 +13854,7 @@ func newWebAppChangePasswordHandler(p *deps.RequestProvider) http.Handler {
 	userInfoDecoder := sso.UserInfoDecoder{
 		LoginIDNormalizerFactory: normalizerFactory,
 	}
+	oidcCache := rootProvider.OIDCCache
 	oAuthProviderFactory := &sso.OAuthProviderFactory{
 		Endpoints:                endpointsProvider,
 		IdentityConfig:           identityConfig,
@@ -9649,6 +13863,7 @@ func newWebAppChangePasswordHandler(p *deps.RequestProvider) http.Handler {
 		Clock:                    clockClock,
 		UserInfoDecoder:          userInfoDecoder,
 		LoginIDNormalizerFactory: normalizerFactory,
+		OIDCCache:                oidcCache,
 	}
 	storeDeviceTokenRedis := &mfa.StoreDeviceTokenRedis{
 		Redis: redisHandle,
@@ -9673,10 +13888,12 @@ func newWebAppChangePasswordHandler(p *deps.RequestProvider) http.Handler {
 		StaticAssetURLPrefix: staticAssetURLPrefix,
 		Translation:          translationService,
 		Config:               forgotPasswordConfig,
+		RateLimitConfig:      rateLimitConfig,
 		Store:                forgotpasswordStore,
 		Clock:                clockClock,
 		URLs:                 urlProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 		Logger:               providerLogger,
 		Identities:           identityFacade,
 		Authenticators:       authenticatorFacade,
@@ -9704,6 +13921,7 @@ func newWebAppChangePasswordHandler(p *deps.RequestProvider) http.Handler {
 		Store:        userStore,
 		Identities:   identityFacade,
 		Verification: verificationService,
+		Clock:        clockClock,
 	}
 	rawCommands := &user.RawCommands{
 		Store:                  userStore,
@@ -9717,6 +13935,7 @@ func newWebAppChangePasswordHandler(p *deps.RequestProvider) http.Handler {
 		Queries:     queries,
 	}
 	hookStore := &hook.Store{
+		Clock:       clockClock,
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
@@ -9739,16 +13958,42 @@ func newWebAppChangePasswordHandler(p *deps.RequestProvider) http.Handler {
 		Users:     rawProvider,
 		Store:     hookStore,
 		Deliverer: deliverer,
+		TaskQueue: queue,
 	}
+	userProfileConfig := appConfig.UserProfile
 	commands := &user.Commands{
-		Raw:          rawCommands,
-		Hooks:        hookProvider,
-		Verification: verificationService,
+		Raw:               rawCommands,
+		Hooks:             hookProvider,
+		Verification:      verificationService,
+		UserProfileConfig: userProfileConfig,
 	}
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
+	authenticationLockoutConfig := authenticationConfig.Lockout
+	lockoutStorePQ := &lockout.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	lockoutService := &lockout.Service{
+		Config:   authenticationLockoutConfig,
+		Clock:    clockClock,
+		Store:    lockoutStorePQ,
+		Database: handle,
+		Users:    rawProvider,
+		Hooks:    hookProvider,
+	}
+	auditStorePQ := &audit.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	auditService := &audit.Service{
+		Context:  context,
+		Clock:    clockClock,
+		Store:    auditStorePQ,
+		Database: handle,
+	}
 	cookieFactory := deps.NewCookieFactory(request, trustProxy)
 	storeRedisLogger := idpsession.NewStoreRedisLogger(factory)
 	idpsessionStoreRedis := &idpsession.StoreRedis{
@@ -9775,18 +14020,19 @@ func newWebAppChangePasswordHandler(p *deps.RequestProvider) http.Handler {
 		Clock:        clockClock,
 		Random:       idpsessionRand,
 	}
-	httpConfig := appConfig.HTTP
 	cookieDef := idpsession.NewSessionCookieDef(httpConfig, sessionConfig)
 	mfaCookieDef := mfa.NewDeviceTokenCookieDef(httpConfig, authenticationConfig)
 	interactionContext := &interaction.Context{
 		Database:                 sqlExecutor,
 		Clock:                    clockClock,
 		Config:                   appConfig,
+		RemoteIP:                 remoteIP,
 		Identities:               identityFacade,
 		Authenticators:           authenticatorFacade,
 		AnonymousIdentities:      anonymousProvider,
 		OOBAuthenticators:        oobProvider,
 		OOBCodeSender:            codeSender,
+		WebAuthnAuthenticators:   webauthnProvider,
 		OAuthProviderFactory:     oAuthProviderFactory,
 		MFA:                      mfaService,
 		ForgotPassword:           forgotpasswordProvider,
@@ -9797,6 +14043,9 @@ func newWebAppChangePasswordHandler(p *deps.RequestProvider) http.Handler {
 		Challenges:               challengeProvider,
 		Users:                    userProvider,
 		Hooks:                    hookProvider,
+		RateLimiter:              limiter,
+		Lockout:                  lockoutService,
+		Audit:                    auditService,
 		CookieFactory:            cookieFactory,
 		Sessions:                 idpsessionProvider,
 		SessionCookie:            cookieDef,
@@ -9934,6 +14183,7 @@ func newWebAppLogoutHandler(p *deps.RequestProvider) http.Handler {
 		PasswordHistory: historyStore,
 		PasswordChecker: passwordChecker,
 		TaskQueue:       queue,
+		Database:        handle,
 	}
 	totpStore := &totp.Store{
 		SQLBuilder:  sqlBuilder,
@@ -9955,15 +14205,33 @@ func newWebAppLogoutHandler(p *deps.RequestProvider) http.Handler {
 		Store:  oobStore,
 		Clock:  clockClock,
 	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	redisHandle := appProvider.Redis
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
 	service3 := &service2.Service{
 		Store:    store2,
 		Password: passwordProvider,
 		TOTP:     totpProvider,
 		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
 	}
 	verificationLogger := verification.NewLogger(factory)
 	verificationConfig := appConfig.Verification
-	redisHandle := appProvider.Redis
 	storeRedis := &verification.StoreRedis{
 		Redis: redisHandle,
 		AppID: appID,
@@ -9993,6 +14261,7 @@ func newWebAppLogoutHandler(p *deps.RequestProvider) http.Handler {
 		Store:        store,
 		Identities:   identityFacade,
 		Verification: verificationService,
+		Clock:        clockClock,
 	}
 	hookLogger := hook.NewLogger(factory)
 	engine := appProvider.TemplateEngine
@@ -10018,6 +14287,7 @@ func newWebAppLogoutHandler(p *deps.RequestProvider) http.Handler {
 		Queries:     queries,
 	}
 	hookStore := &hook.Store{
+		Clock:       clockClock,
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
@@ -10040,6 +14310,7 @@ func newWebAppLogoutHandler(p *deps.RequestProvider) http.Handler {
 		Users:     rawProvider,
 		Store:     hookStore,
 		Deliverer: deliverer,
+		TaskQueue: queue,
 	}
 	storeRedisLogger := idpsession.NewStoreRedisLogger(factory)
 	idpsessionStoreRedis := &idpsession.StoreRedis{
@@ -10050,7 +14321,6 @@ func newWebAppLogoutHandler(p *deps.RequestProvider) http.Handler {
 	}
 	sessionConfig := appConfig.Session
 	cookieFactory := deps.NewCookieFactory(request, trustProxy)
-	httpConfig := appConfig.HTTP
 	cookieDef := idpsession.NewSessionCookieDef(httpConfig, sessionConfig)
 	manager := &idpsession.Manager{
 		Store:         idpsessionStoreRedis,
@@ -10123,6 +14393,10 @@ func newWebAppAuthenticationBeginHandler(p *deps.RequestProvider) http.Handler {
 		Database: handle,
 	}
 	clockClock := _wireSystemClockValue
+	rootProvider := appProvider.RootProvider
+	environmentConfig := rootProvider.EnvironmentConfig
+	trustProxy := environmentConfig.TrustProxy
+	remoteIP := deps.ProvideRemoteIP(request, trustProxy)
 	authenticationConfig := appConfig.Authentication
 	identityConfig := appConfig.Identity
 	secretConfig := config.SecretConfig
@@ -10137,7 +14411,6 @@ func newWebAppAuthenticationBeginHandler(p *deps.RequestProvider) http.Handler {
 		SQLExecutor: sqlExecutor,
 	}
 	loginIDConfig := identityConfig.LoginID
-	rootProvider := appProvider.RootProvider
 	reservedNameChecker := rootProvider.ReservedNameChecker
 	typeCheckerFactory := &loginid.TypeCheckerFactory{
 		Config:              loginIDConfig,
@@ -10207,6 +14480,7 @@ func newWebAppAuthenticationBeginHandler(p *deps.RequestProvider) http.Handler {
 		PasswordHistory: historyStore,
 		PasswordChecker: passwordChecker,
 		TaskQueue:       queue,
+		Database:        handle,
 	}
 	totpStore := &totp.Store{
 		SQLBuilder:  sqlBuilder,
@@ -10228,11 +14502,29 @@ func newWebAppAuthenticationBeginHandler(p *deps.RequestProvider) http.Handler {
 		Store:  oobStore,
 		Clock:  clockClock,
 	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
 	service3 := &service2.Service{
 		Store:    serviceStore,
 		Password: passwordProvider,
 		TOTP:     totpProvider,
 		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
 	}
 	verificationLogger := verification.NewLogger(factory)
 	verificationConfig := appConfig.Verification
@@ -10264,15 +14556,14 @@ func newWebAppAuthenticationBeginHandler(p *deps.RequestProvider) http.Handler {
 	authenticatorFacade := facade.AuthenticatorFacade{
 		Coordinator: coordinator,
 	}
-	environmentConfig := rootProvider.EnvironmentConfig
 	staticAssetURLPrefix := environmentConfig.StaticAssetURLPrefix
+	rateLimitConfig := appConfig.RateLimit
 	engine := appProvider.TemplateEngine
 	translationService := &translation.Service{
 		Context:           context,
 		EnvironmentConfig: environmentConfig,
 		TemplateEngine:    engine,
 	}
-	trustProxy := environmentConfig.TrustProxy
 	mainOriginProvider := &MainOriginProvider{
 		Request:    request,
 		TrustProxy: trustProxy,
@@ -10280,11 +14571,23 @@ func newWebAppAuthenticationBeginHandler(p *deps.RequestProvider) http.Handler {
 	endpointsProvider := &EndpointsProvider{
 		OriginProvider: mainOriginProvider,
 	}
+	ratelimitLogger := ratelimit.NewLogger(factory)
+	storageRedis := &ratelimit.StorageRedis{
+		AppID: appID,
+		Redis: redisHandle,
+		Clock: clockClock,
+	}
+	limiter := &ratelimit.Limiter{
+		Logger:  ratelimitLogger,
+		Storage: storageRedis,
+	}
 	messageSender := &otp.MessageSender{
 		StaticAssetURLPrefix: staticAssetURLPrefix,
+		RateLimitConfig:      rateLimitConfig,
 		Translation:          translationService,
 		Endpoints:            endpointsProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 	}
 	codeSender := &oob.CodeSender{
 		OTPMessageSender: messageSender,
@@ -10296,6 +14599,7 @@ func newWebAppAuthenticationBeginHandler(p *deps.RequestProvider) http.Handler {
 	userInfoDecoder := sso.UserInfoDecoder{
 		LoginIDNormalizerFactory: normalizerFactory,
 	}
+	oidcCache := rootProvider.OIDCCache
 	oAuthProviderFactory := &sso.OAuthProviderFactory{
 		Endpoints:                endpointsProvider,
 		IdentityConfig:           identityConfig,
@@ -10304,6 +14608,7 @@ func newWebAppAuthenticationBeginHandler(p *deps.RequestProvider) http.Handler {
 		Clock:                    clockClock,
 		UserInfoDecoder:          userInfoDecoder,
 		LoginIDNormalizerFactory: normalizerFactory,
+		OIDCCache:                oidcCache,
 	}
 	storeDeviceTokenRedis := &mfa.StoreDeviceTokenRedis{
 		Redis: redisHandle,
@@ -10329,10 +14634,12 @@ func newWebAppAuthenticationBeginHandler(p *deps.RequestProvider) http.Handler {
 		StaticAssetURLPrefix: staticAssetURLPrefix,
 		Translation:          translationService,
 		Config:               forgotPasswordConfig,
+		RateLimitConfig:      rateLimitConfig,
 		Store:                forgotpasswordStore,
 		Clock:                clockClock,
 		URLs:                 urlProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 		Logger:               providerLogger,
 		Identities:           identityFacade,
 		Authenticators:       authenticatorFacade,
@@ -10360,6 +14667,7 @@ func newWebAppAuthenticationBeginHandler(p *deps.RequestProvider) http.Handler {
 		Store:        userStore,
 		Identities:   identityFacade,
 		Verification: verificationService,
+		Clock:        clockClock,
 	}
 	rawCommands := &user.RawCommands{
 		Store:                  userStore,
@@ -10373,6 +14681,7 @@ func newWebAppAuthenticationBeginHandler(p *deps.RequestProvider) http.Handler {
 		Queries:     queries,
 	}
 	hookStore := &hook.Store{
+		Clock:       clockClock,
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
@@ -10395,16 +14704,42 @@ func newWebAppAuthenticationBeginHandler(p *deps.RequestProvider) http.Handler {
 		Users:     rawProvider,
 		Store:     hookStore,
 		Deliverer: deliverer,
+		TaskQueue: queue,
 	}
+	userProfileConfig := appConfig.UserProfile
 	commands := &user.Commands{
-		Raw:          rawCommands,
-		Hooks:        hookProvider,
-		Verification: verificationService,
+		Raw:               rawCommands,
+		Hooks:             hookProvider,
+		Verification:      verificationService,
+		UserProfileConfig: userProfileConfig,
 	}
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
+	authenticationLockoutConfig := authenticationConfig.Lockout
+	lockoutStorePQ := &lockout.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	lockoutService := &lockout.Service{
+		Config:   authenticationLockoutConfig,
+		Clock:    clockClock,
+		Store:    lockoutStorePQ,
+		Database: handle,
+		Users:    rawProvider,
+		Hooks:    hookProvider,
+	}
+	auditStorePQ := &audit.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	auditService := &audit.Service{
+		Context:  context,
+		Clock:    clockClock,
+		Store:    auditStorePQ,
+		Database: handle,
+	}
 	cookieFactory := deps.NewCookieFactory(request, trustProxy)
 	storeRedisLogger := idpsession.NewStoreRedisLogger(factory)
 	idpsessionStoreRedis := &idpsession.StoreRedis{
@@ -10431,18 +14766,19 @@ func newWebAppAuthenticationBeginHandler(p *deps.RequestProvider) http.Handler {
 		Clock:        clockClock,
 		Random:       idpsessionRand,
 	}
-	httpConfig := appConfig.HTTP
 	cookieDef := idpsession.NewSessionCookieDef(httpConfig, sessionConfig)
 	mfaCookieDef := mfa.NewDeviceTokenCookieDef(httpConfig, authenticationConfig)
 	interactionContext := &interaction.Context{
 		Database:                 sqlExecutor,
 		Clock:                    clockClock,
 		Config:                   appConfig,
+		RemoteIP:                 remoteIP,
 		Identities:               identityFacade,
 		Authenticators:           authenticatorFacade,
 		AnonymousIdentities:      anonymousProvider,
 		OOBAuthenticators:        oobProvider,
 		OOBCodeSender:            codeSender,
+		WebAuthnAuthenticators:   webauthnProvider,
 		OAuthProviderFactory:     oAuthProviderFactory,
 		MFA:                      mfaService,
 		ForgotPassword:           forgotpasswordProvider,
@@ -10453,6 +14789,9 @@ func newWebAppAuthenticationBeginHandler(p *deps.RequestProvider) http.Handler {
 		Challenges:               challengeProvider,
 		Users:                    userProvider,
 		Hooks:                    hookProvider,
+		RateLimiter:              limiter,
+		Lockout:                  lockoutService,
+		Audit:                    auditService,
 		CookieFactory:            cookieFactory,
 		Sessions:                 idpsessionProvider,
 		SessionCookie:            cookieDef,
@@ -10505,6 +14844,10 @@ func newWebAppCreateAuthenticatorBeginHandler(p *deps.RequestProvider) http.Hand
 		Database: handle,
 	}
 	clockClock := _wireSystemClockValue
+	rootProvider := appProvider.RootProvider
+	environmentConfig := rootProvider.EnvironmentConfig
+	trustProxy := environmentConfig.TrustProxy
+	remoteIP := deps.ProvideRemoteIP(request, trustProxy)
 	authenticationConfig := appConfig.Authentication
 	identityConfig := appConfig.Identity
 	secretConfig := config.SecretConfig
@@ -10519,7 +14862,6 @@ func newWebAppCreateAuthenticatorBeginHandler(p *deps.RequestProvider) http.Hand
 		SQLExecutor: sqlExecutor,
 	}
 	loginIDConfig := identityConfig.LoginID
-	rootProvider := appProvider.RootProvider
 	reservedNameChecker := rootProvider.ReservedNameChecker
 	typeCheckerFactory := &loginid.TypeCheckerFactory{
 		Config:              loginIDConfig,
@@ -10589,6 +14931,7 @@ func newWebAppCreateAuthenticatorBeginHandler(p *deps.RequestProvider) http.Hand
 		PasswordHistory: historyStore,
 		PasswordChecker: passwordChecker,
 		TaskQueue:       queue,
+		Database:        handle,
 	}
 	totpStore := &totp.Store{
 		SQLBuilder:  sqlBuilder,
@@ -10610,11 +14953,29 @@ func newWebAppCreateAuthenticatorBeginHandler(p *deps.RequestProvider) http.Hand
 		Store:  oobStore,
 		Clock:  clockClock,
 	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	sessionStore := &webauthn.SessionStore{
+		Redis: redisHandle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	httpConfig := appConfig.HTTP
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
 	service3 := &service2.Service{
 		Store:    serviceStore,
 		Password: passwordProvider,
 		TOTP:     totpProvider,
 		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
 	}
 	verificationLogger := verification.NewLogger(factory)
 	verificationConfig := appConfig.Verification
@@ -10646,15 +15007,14 @@ func newWebAppCreateAuthenticatorBeginHandler(p *deps.RequestProvider) http.Hand
 	authenticatorFacade := facade.AuthenticatorFacade{
 		Coordinator: coordinator,
 	}
-	environmentConfig := rootProvider.EnvironmentConfig
 	staticAssetURLPrefix := environmentConfig.StaticAssetURLPrefix
+	rateLimitConfig := appConfig.RateLimit
 	engine := appProvider.TemplateEngine
 	translationService := &translation.Service{
 		Context:           context,
 		EnvironmentConfig: environmentConfig,
 		TemplateEngine:    engine,
 	}
-	trustProxy := environmentConfig.TrustProxy
 	mainOriginProvider := &MainOriginProvider{
 		Request:    request,
 		TrustProxy: trustProxy,
@@ -10662,11 +15022,23 @@ func newWebAppCreateAuthenticatorBeginHandler(p *deps.RequestProvider) http.Hand
 	endpointsProvider := &EndpointsProvider{
 		OriginProvider: mainOriginProvider,
 	}
+	ratelimitLogger := ratelimit.NewLogger(factory)
+	storageRedis := &ratelimit.StorageRedis{
+		AppID: appID,
+		Redis: redisHandle,
+		Clock: clockClock,
+	}
+	limiter := &ratelimit.Limiter{
+		Logger:  ratelimitLogger,
+		Storage: storageRedis,
+	}
 	messageSender := &otp.MessageSender{
 		StaticAssetURLPrefix: staticAssetURLPrefix,
+		RateLimitConfig:      rateLimitConfig,
 		Translation:          translationService,
 		Endpoints:            endpointsProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 	}
 	codeSender := &oob.CodeSender{
 		OTPMessageSender: messageSender,
@@ -10678,6 +15050,7 @@ func newWebAppCreateAuthenticatorBeginHandler(p *deps.RequestProvider) http.Hand
 	userInfoDecoder := sso.UserInfoDecoder{
 		LoginIDNormalizerFactory: normalizerFactory,
 	}
+	oidcCache := rootProvider.OIDCCache
 	oAuthProviderFactory := &sso.OAuthProviderFactory{
 		Endpoints:                endpointsProvider,
 		IdentityConfig:           identityConfig,
@@ -10686,6 +15059,7 @@ func newWebAppCreateAuthenticatorBeginHandler(p *deps.RequestProvider) http.Hand
 		Clock:                    clockClock,
 		UserInfoDecoder:          userInfoDecoder,
 		LoginIDNormalizerFactory: normalizerFactory,
+		OIDCCache:                oidcCache,
 	}
 	storeDeviceTokenRedis := &mfa.StoreDeviceTokenRedis{
 		Redis: redisHandle,
@@ -10711,10 +15085,12 @@ func newWebAppCreateAuthenticatorBeginHandler(p *deps.RequestProvider) http.Hand
 		StaticAssetURLPrefix: staticAssetURLPrefix,
 		Translation:          translationService,
 		Config:               forgotPasswordConfig,
+		RateLimitConfig:      rateLimitConfig,
 		Store:                forgotpasswordStore,
 		Clock:                clockClock,
 		URLs:                 urlProvider,
 		TaskQueue:            queue,
+		RateLimiter:          limiter,
 		Logger:               providerLogger,
 		Identities:           identityFacade,
 		Authenticators:       authenticatorFacade,
@@ -10742,6 +15118,7 @@ func newWebAppCreateAuthenticatorBeginHandler(p *deps.RequestProvider) http.Hand
 		Store:        userStore,
 		Identities:   identityFacade,
 		Verification: verificationService,
+		Clock:        clockClock,
 	}
 	rawCommands := &user.RawCommands{
 		Store:                  userStore,
@@ -10755,6 +15132,7 @@ func newWebAppCreateAuthenticatorBeginHandler(p *deps.RequestProvider) http.Hand
 		Queries:     queries,
 	}
 	hookStore := &hook.Store{
+		Clock:       clockClock,
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
@@ -10777,16 +15155,42 @@ func newWebAppCreateAuthenticatorBeginHandler(p *deps.RequestProvider) http.Hand
 		Users:     rawProvider,
 		Store:     hookStore,
 		Deliverer: deliverer,
+		TaskQueue: queue,
 	}
+	userProfileConfig := appConfig.UserProfile
 	commands := &user.Commands{
-		Raw:          rawCommands,
-		Hooks:        hookProvider,
-		Verification: verificationService,
+		Raw:               rawCommands,
+		Hooks:             hookProvider,
+		Verification:      verificationService,
+		UserProfileConfig: userProfileConfig,
 	}
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
+	authenticationLockoutConfig := authenticationConfig.Lockout
+	lockoutStorePQ := &lockout.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	lockoutService := &lockout.Service{
+		Config:   authenticationLockoutConfig,
+		Clock:    clockClock,
+		Store:    lockoutStorePQ,
+		Database: handle,
+		Users:    rawProvider,
+		Hooks:    hookProvider,
+	}
+	auditStorePQ := &audit.StorePQ{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	auditService := &audit.Service{
+		Context:  context,
+		Clock:    clockClock,
+		Store:    auditStorePQ,
+		Database: handle,
+	}
 	cookieFactory := deps.NewCookieFactory(request, trustProxy)
 	storeRedisLogger := idpsession.NewStoreRedisLogger(factory)
 	idpsessionStoreRedis := &idpsession.StoreRedis{
@@ -10813,18 +15217,19 @@ func newWebAppCreateAuthenticatorBeginHandler(p *deps.RequestProvider) http.Hand
 		Clock:        clockClock,
 		Random:       idpsessionRand,
 	}
-	httpConfig := appConfig.HTTP
 	cookieDef := idpsession.NewSessionCookieDef(httpConfig, sessionConfig)
 	mfaCookieDef := mfa.NewDeviceTokenCookieDef(httpConfig, authenticationConfig)
 	interactionContext := &interaction.Context{
 		Database:                 sqlExecutor,
 		Clock:                    clockClock,
 		Config:                   appConfig,
+		RemoteIP:                 remoteIP,
 		Identities:               identityFacade,
 		Authenticators:           authenticatorFacade,
 		AnonymousIdentities:      anonymousProvider,
 		OOBAuthenticators:        oobProvider,
 		OOBCodeSender:            codeSender,
+		WebAuthnAuthenticators:   webauthnProvider,
 		OAuthProviderFactory:     oAuthProviderFactory,
 		MFA:                      mfaService,
 		ForgotPassword:           forgotpasswordProvider,
@@ -10835,6 +15240,9 @@ func newWebAppCreateAuthenticatorBeginHandler(p *deps.RequestProvider) http.Hand
 		Challenges:               challengeProvider,
 		Users:                    userProvider,
 		Hooks:                    hookProvider,
+		RateLimiter:              limiter,
+		Lockout:                  lockoutService,
+		Audit:                    auditService,
 		CookieFactory:            cookieFactory,
 		Sessions:                 idpsessionProvider,
 		SessionCookie:            cookieDef,
@@ -11043,6 +15451,7 @@ func newSessionMiddleware(p *deps.RequestProvider) httproute.Middleware {
 		TrustProxy:    trustProxy,
 		Clock:         clockClock,
 	}
+	oAuthConfig := appConfig.OAuth
 	secretConfig := config.SecretConfig
 	databaseCredentials := deps.ProvideDatabaseCredentials(secretConfig)
 	sqlBuilder := db.ProvideSQLBuilder(databaseCredentials, appID)
@@ -11067,6 +15476,7 @@ func newSessionMiddleware(p *deps.RequestProvider) httproute.Middleware {
 	}
 	oauthResolver := &oauth2.Resolver{
 		TrustProxy:     trustProxy,
+		OAuthConfig:    oAuthConfig,
 		Authorizations: authorizationStore,
 		AccessGrants:   grantStore,
 		OfflineGrants:  grantStore,
@@ -11157,6 +15567,7 @@ func newSessionMiddleware(p *deps.RequestProvider) httproute.Middleware {
 		PasswordHistory: historyStore,
 		PasswordChecker: passwordChecker,
 		TaskQueue:       queue,
+		Database:        dbHandle,
 	}
 	totpStore := &totp.Store{
 		SQLBuilder:  sqlBuilder,
@@ -11178,11 +15589,28 @@ func newSessionMiddleware(p *deps.RequestProvider) httproute.Middleware {
 		Store:  oobStore,
 		Clock:  clockClock,
 	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	sessionStore := &webauthn.SessionStore{
+		Redis: handle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clockClock,
+	}
 	service3 := &service2.Service{
 		Store:    store2,
 		Password: passwordProvider,
 		TOTP:     totpProvider,
 		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
 	}
 	verificationLogger := verification.NewLogger(factory)
 	verificationConfig := appConfig.Verification
@@ -11215,17 +15643,44 @@ func newSessionMiddleware(p *deps.RequestProvider) httproute.Middleware {
 		Store:        store,
 		Identities:   identityFacade,
 		Verification: verificationService,
+		Clock:        clockClock,
+	}
+	manager := &idpsession.Manager{
+		Store:         storeRedis,
+		Clock:         clockClock,
+		Config:        sessionConfig,
+		CookieFactory: cookieFactory,
+		CookieDef:     cookieDef,
+	}
+	sessionManager := &oauth2.SessionManager{
+		Store: grantStore,
+		Clock: clockClock,
 	}
 	sessionMiddleware := &session.Middleware{
 		IDPSessionResolver:         resolver,
 		AccessTokenSessionResolver: oauthResolver,
 		AccessEvents:               eventProvider,
 		Users:                      queries,
+		IDPSessions:                manager,
+		AccessTokenSessions:        sessionManager,
 		Database:                   dbHandle,
 	}
 	return sessionMiddleware
 }
 
+func newAuditMiddleware(p *deps.RequestProvider) httproute.Middleware {
+	appProvider := p.AppProvider
+	rootProvider := appProvider.RootProvider
+	environmentConfig := rootProvider.EnvironmentConfig
+	trustProxy := environmentConfig.TrustProxy
+	clockClock := _wireSystemClockValue
+	auditMiddleware := &audit.Middleware{
+		TrustProxy: trustProxy,
+		Clock:      clockClock,
+	}
+	return auditMiddleware
+}
+
 func newWebAppStateMiddleware(p *deps.RequestProvider) httproute.Middleware {
 	appProvider := p.AppProvider
 	config := appProvider.Config
@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/wire"
 
+	handlerheadless "github.com/authgear/authgear-server/pkg/auth/handler/headless"
 	handleroauth "github.com/authgear/authgear-server/pkg/auth/handler/oauth"
 	handlerwebapp "github.com/authgear/authgear-server/pkg/auth/handler/webapp"
 	"github.com/authgear/authgear-server/pkg/lib/deps"
@@ -33,6 +34,20 @@ func newOAuthRevokeHandler(p *deps.RequestProvider) http.Handler {
 	))
 }
 
+func newOAuthIntrospectHandler(p *deps.RequestProvider) http.Handler {
+	panic(wire.Build(
+		DependencySet,
+		wire.Bind(new(http.Handler), new(*handleroauth.IntrospectHandler)),
+	))
+}
+
+func newOAuthDeviceAuthorizationHandler(p *deps.RequestProvider) http.Handler {
+	panic(wire.Build(
+		DependencySet,
+		wire.Bind(new(http.Handler), new(*handleroauth.DeviceAuthorizationHandler)),
+	))
+}
+
 func newOAuthMetadataHandler(p *deps.RequestProvider) http.Handler {
 	panic(wire.Build(
 		DependencySet,
@@ -68,6 +83,13 @@ func newOAuthChallengeHandler(p *deps.RequestProvider) http.Handler {
 	))
 }
 
+func newHeadlessInteractionHandler(p *deps.RequestProvider) http.Handler {
+	panic(wire.Build(
+		DependencySet,
+		wire.Bind(new(http.Handler), new(*handlerheadless.InteractionHandler)),
+	))
+}
+
 func newWebAppRootHandler(p *deps.RequestProvider) http.Handler {
 	panic(wire.Build(
 		DependencySet,
@@ -138,6 +160,20 @@ func newWebAppEnterTOTPHandler(p *deps.RequestProvider) http.Handler {
 	))
 }
 
+func newWebAppSetupWebAuthnHandler(p *deps.RequestProvider) http.Handler {
+	panic(wire.Build(
+		DependencySet,
+		wire.Bind(new(http.Handler), new(*handlerwebapp.SetupWebAuthnHandler)),
+	))
+}
+
+func newWebAppEnterWebAuthnHandler(p *deps.RequestProvider) http.Handler {
+	panic(wire.Build(
+		DependencySet,
+		wire.Bind(new(http.Handler), new(*handlerwebapp.EnterWebAuthnHandler)),
+	))
+}
+
 func newWebAppSetupOOBOTPHandler(p *deps.RequestProvider) http.Handler {
 	panic(wire.Build(
 		DependencySet,
@@ -222,6 +258,41 @@ func newWebAppSettingsIdentityHandler(p *deps.RequestProvider) http.Handler {
 	))
 }
 
+func newWebAppSettingsAuthorizedAppsHandler(p *deps.RequestProvider) http.Handler {
+	panic(wire.Build(
+		DependencySet,
+		wire.Bind(new(http.Handler), new(*handlerwebapp.SettingsAuthorizedAppsHandler)),
+	))
+}
+
+func newWebAppSettingsSessionsHandler(p *deps.RequestProvider) http.Handler {
+	panic(wire.Build(
+		DependencySet,
+		wire.Bind(new(http.Handler), new(*handlerwebapp.SettingsSessionsHandler)),
+	))
+}
+
+func newWebAppSettingsProfileHandler(p *deps.RequestProvider) http.Handler {
+	panic(wire.Build(
+		DependencySet,
+		wire.Bind(new(http.Handler), new(*handlerwebapp.SettingsProfileHandler)),
+	))
+}
+
+func newWebAppConsentHandler(p *deps.RequestProvider) http.Handler {
+	panic(wire.Build(
+		DependencySet,
+		wire.Bind(new(http.Handler), new(*handlerwebapp.ConsentHandler)),
+	))
+}
+
+func newWebAppDeviceHandler(p *deps.RequestProvider) http.Handler {
+	panic(wire.Build(
+		DependencySet,
+		wire.Bind(new(http.Handler), new(*handlerwebapp.DeviceHandler)),
+	))
+}
+
 func newWebAppChangePasswordHandler(p *deps.RequestProvider) http.Handler {
 	panic(wire.Build(
 		DependencySet,
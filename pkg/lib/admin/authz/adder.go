@@ -17,7 +17,9 @@ type Adder struct {
 	Clock clock.Clock
 }
 
-func (a *Adder) AddAuthz(auth config.AdminAPIAuth, appID config.AppID, authKey *config.AdminAPIAuthKey, hdr http.Header) (err error) {
+// AddAuthz adds the authorization header for the Admin API. subject is the
+// ID of the party calling the Admin API, which is recorded in the audit log.
+func (a *Adder) AddAuthz(auth config.AdminAPIAuth, appID config.AppID, authKey *config.AdminAPIAuthKey, subject string, hdr http.Header) (err error) {
 	switch auth {
 	case config.AdminAPIAuthNone:
 		break
@@ -29,6 +31,9 @@ func (a *Adder) AddAuthz(auth config.AdminAPIAuth, appID config.AppID, authKey *
 		now := a.Clock.NowUTC()
 		payload := jwt.New()
 		_ = payload.Set(jwt.AudienceKey, string(appID))
+		if subject != "" {
+			_ = payload.Set(jwt.SubjectKey, subject)
+		}
 		_ = payload.Set(jwt.IssuedAtKey, now.Unix())
 		_ = payload.Set(jwt.ExpirationKey, now.Add(5*time.Minute).Unix())
 
@@ -8,6 +8,7 @@ import (
 
 	"github.com/lestrrat-go/jwx/jwt"
 
+	"github.com/authgear/authgear-server/pkg/lib/audit"
 	"github.com/authgear/authgear-server/pkg/lib/config"
 	"github.com/authgear/authgear-server/pkg/util/clock"
 	"github.com/authgear/authgear-server/pkg/util/jwkutil"
@@ -41,6 +42,7 @@ type Middleware struct {
 func (m *Middleware) Handle(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		authorized := false
+		actor := audit.Actor{Type: audit.ActorTypeAdmin}
 		switch m.Auth {
 		case config.AdminAPIAuthNone:
 			authorized = true
@@ -81,6 +83,7 @@ func (m *Middleware) Handle(next http.Handler) http.Handler {
 			}
 
 			authorized = true
+			actor.ID = token.Subject()
 		}
 
 		if !authorized {
@@ -88,6 +91,7 @@ func (m *Middleware) Handle(next http.Handler) http.Handler {
 			return
 		}
 
+		r = r.WithContext(audit.WithActor(r.Context(), actor))
 		next.ServeHTTP(w, r)
 	})
 }
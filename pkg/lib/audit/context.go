@@ -0,0 +1,34 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/authgear/authgear-server/pkg/lib/session/access"
+)
+
+type actorContextKeyType struct{}
+
+var actorContextKey = actorContextKeyType{}
+
+type accessEventContextKeyType struct{}
+
+var accessEventContextKey = accessEventContextKeyType{}
+
+// WithActor overrides the actor of the audit logs recorded in ctx.
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorContextKey, &actor)
+}
+
+func getActor(ctx context.Context) *Actor {
+	actor, _ := ctx.Value(actorContextKey).(*Actor)
+	return actor
+}
+
+func withAccessEvent(ctx context.Context, e access.Event) context.Context {
+	return context.WithValue(ctx, accessEventContextKey, &e)
+}
+
+func getAccessEvent(ctx context.Context) *access.Event {
+	e, _ := ctx.Value(accessEventContextKey).(*access.Event)
+	return e
+}
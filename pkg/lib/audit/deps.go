@@ -0,0 +1,12 @@
+package audit
+
+import "github.com/google/wire"
+
+var DependencySet = wire.NewSet(
+	wire.Struct(new(StorePQ), "*"),
+	wire.Bind(new(Store), new(*StorePQ)),
+	wire.Struct(new(Service), "*"),
+	wire.Struct(new(Queries), "*"),
+	wire.Struct(new(Pruner), "*"),
+	wire.Struct(new(Middleware), "*"),
+)
@@ -0,0 +1,78 @@
+package audit
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrLogNotFound = errors.New("audit log not found")
+
+type Activity string
+
+const (
+	ActivityAuthenticationSucceeded Activity = "authentication.succeeded"
+	ActivityAuthenticationFailed    Activity = "authentication.failed"
+
+	ActivityIdentityCreated Activity = "identity.created"
+	ActivityIdentityUpdated Activity = "identity.updated"
+	ActivityIdentityDeleted Activity = "identity.deleted"
+
+	ActivityAuthenticatorCreated Activity = "authenticator.created"
+	ActivityAuthenticatorUpdated Activity = "authenticator.updated"
+	ActivityAuthenticatorDeleted Activity = "authenticator.deleted"
+
+	ActivityPasswordReset Activity = "password.reset"
+
+	ActivityClaimVerified Activity = "verification.claim_verified"
+
+	ActivityAdminMutation Activity = "admin.mutation"
+
+	ActivityUserImported Activity = "user.imported"
+
+	ActivityConfigUpdated Activity = "config.updated"
+)
+
+type ActorType string
+
+const (
+	// ActorTypeAnonymous is an unauthenticated end-user,
+	// e.g. a user attempting to log in.
+	ActorTypeAnonymous ActorType = "anonymous"
+	// ActorTypeUser is an authenticated end-user.
+	ActorTypeUser ActorType = "user"
+	// ActorTypeAdmin is a caller of the Admin API.
+	ActorTypeAdmin ActorType = "admin"
+	// ActorTypePortal is a developer using the portal.
+	ActorTypePortal ActorType = "portal"
+)
+
+// Actor is the party performing the audited activity.
+type Actor struct {
+	Type ActorType
+	// ID is the ID of the actor. It is empty if the actor cannot be
+	// identified, e.g. anonymous actors and Admin API callers without subject.
+	ID string
+}
+
+// Log is a record of "who changed what".
+type Log struct {
+	ID        string
+	CreatedAt time.Time
+	Activity  Activity
+	Actor     Actor
+	// UserID is the ID of the user the activity is about.
+	UserID    string
+	IPAddress string
+	UserAgent string
+	Data      map[string]interface{}
+}
+
+// Filter filters the audit logs in query.
+type Filter struct {
+	Activity      Activity
+	UserID        string
+	ActorType     ActorType
+	ActorID       string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+}
@@ -0,0 +1,24 @@
+package audit
+
+import (
+	"net/http"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/session/access"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+)
+
+// Middleware captures the IP address and user agent of the request,
+// which are recorded in the audit logs.
+type Middleware struct {
+	TrustProxy config.TrustProxy
+	Clock      clock.Clock
+}
+
+func (m *Middleware) Handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		e := access.NewEvent(m.Clock.NowUTC(), r, bool(m.TrustProxy))
+		r = r.WithContext(withAccessEvent(r.Context(), e))
+		next.ServeHTTP(rw, r)
+	})
+}
@@ -0,0 +1,19 @@
+package audit
+
+import (
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+)
+
+// Pruner deletes the audit logs older than the retention period.
+type Pruner struct {
+	Config *config.AuditLogConfig
+	Clock  clock.Clock
+	Store  *StorePQ
+}
+
+// Prune returns the number of deleted logs.
+func (p *Pruner) Prune() (int64, error) {
+	cutoff := p.Clock.NowUTC().Add(-p.Config.RetentionDays.Duration())
+	return p.Store.DeleteBefore(cutoff)
+}
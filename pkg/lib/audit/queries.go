@@ -0,0 +1,38 @@
+package audit
+
+import (
+	"github.com/authgear/authgear-server/pkg/api/model"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+)
+
+// Queries provides read access to the audit logs.
+type Queries struct {
+	Store *StorePQ
+}
+
+func (q *Queries) Get(id string) (*Log, error) {
+	return q.Store.Get(id)
+}
+
+func (q *Queries) Count(filter Filter) (uint64, error) {
+	return q.Store.Count(filter)
+}
+
+func (q *Queries) QueryPage(filter Filter, after, before model.PageCursor, first, last *uint64) ([]model.PageItem, error) {
+	logs, offset, err := q.Store.QueryPage(filter, after, before, first, last)
+	if err != nil {
+		return nil, err
+	}
+
+	var models = make([]model.PageItem, len(logs))
+	for i, l := range logs {
+		pageKey := db.PageKey{Offset: offset + uint64(i)}
+		cursor, err := pageKey.ToPageCursor()
+		if err != nil {
+			return nil, err
+		}
+
+		models[i] = model.PageItem{Value: l, Cursor: cursor}
+	}
+	return models, nil
+}
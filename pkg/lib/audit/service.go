@@ -0,0 +1,81 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	"github.com/authgear/authgear-server/pkg/lib/session"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+	"github.com/authgear/authgear-server/pkg/util/uuid"
+)
+
+//go:generate mockgen -source=service.go -destination=service_mock_test.go -package audit
+
+type Store interface {
+	Create(logs []*Log) error
+}
+
+type DatabaseHandle interface {
+	UseHook(hook db.TransactionHook)
+}
+
+// Service records audit logs. Logs are written when the transaction commits;
+// see interaction.Service.DryRun.
+type Service struct {
+	Context  context.Context
+	Clock    clock.Clock
+	Store    Store
+	Database DatabaseHandle
+
+	pending  []*Log `wire:"-"`
+	dbHooked bool   `wire:"-"`
+}
+
+// Log records the activity performed about the user. The actor is the one
+// set by WithActor, or the user of the current session.
+func (s *Service) Log(activity Activity, userID string, data map[string]interface{}) {
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+
+	l := &Log{
+		ID:        uuid.New(),
+		CreatedAt: s.Clock.NowUTC(),
+		Activity:  activity,
+		Actor:     s.actor(),
+		UserID:    userID,
+		Data:      data,
+	}
+	if e := getAccessEvent(s.Context); e != nil {
+		l.IPAddress = e.RemoteIP
+		l.UserAgent = e.UserAgent
+	}
+
+	s.pending = append(s.pending, l)
+
+	if !s.dbHooked {
+		s.Database.UseHook(s)
+		s.dbHooked = true
+	}
+}
+
+func (s *Service) WillCommitTx() error {
+	err := s.Store.Create(s.pending)
+	if err != nil {
+		return err
+	}
+	s.pending = nil
+	return nil
+}
+
+func (s *Service) DidCommitTx() {}
+
+func (s *Service) actor() Actor {
+	if actor := getActor(s.Context); actor != nil {
+		return *actor
+	}
+	if userID := session.GetUserID(s.Context); userID != nil {
+		return Actor{Type: ActorTypeUser, ID: *userID}
+	}
+	return Actor{Type: ActorTypeAnonymous}
+}
@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package audit is a generated GoMock package.
+package audit
+
+import (
+	db "github.com/authgear/authgear-server/pkg/lib/infra/db"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockStore is a mock of Store interface
+type MockStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreMockRecorder
+}
+
+// MockStoreMockRecorder is the mock recorder for MockStore
+type MockStoreMockRecorder struct {
+	mock *MockStore
+}
+
+// NewMockStore creates a new mock instance
+func NewMockStore(ctrl *gomock.Controller) *MockStore {
+	mock := &MockStore{ctrl: ctrl}
+	mock.recorder = &MockStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockStore) EXPECT() *MockStoreMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method
+func (m *MockStore) Create(logs []*Log) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", logs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create
+func (mr *MockStoreMockRecorder) Create(logs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockStore)(nil).Create), logs)
+}
+
+// MockDatabaseHandle is a mock of DatabaseHandle interface
+type MockDatabaseHandle struct {
+	ctrl     *gomock.Controller
+	recorder *MockDatabaseHandleMockRecorder
+}
+
+// MockDatabaseHandleMockRecorder is the mock recorder for MockDatabaseHandle
+type MockDatabaseHandleMockRecorder struct {
+	mock *MockDatabaseHandle
+}
+
+// NewMockDatabaseHandle creates a new mock instance
+func NewMockDatabaseHandle(ctrl *gomock.Controller) *MockDatabaseHandle {
+	mock := &MockDatabaseHandle{ctrl: ctrl}
+	mock.recorder = &MockDatabaseHandleMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockDatabaseHandle) EXPECT() *MockDatabaseHandleMockRecorder {
+	return m.recorder
+}
+
+// UseHook mocks base method
+func (m *MockDatabaseHandle) UseHook(hook db.TransactionHook) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UseHook", hook)
+}
+
+// UseHook indicates an expected call of UseHook
+func (mr *MockDatabaseHandleMockRecorder) UseHook(hook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseHook", reflect.TypeOf((*MockDatabaseHandle)(nil).UseHook), hook)
+}
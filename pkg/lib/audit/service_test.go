@@ -0,0 +1,75 @@
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/lib/session/access"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+)
+
+func TestService(t *testing.T) {
+	Convey("Service", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := NewMockStore(ctrl)
+		database := NewMockDatabaseHandle(ctrl)
+		clk := clock.NewMockClockAt("2020-02-01T00:00:00Z")
+		now := clk.NowUTC()
+
+		ctx := withAccessEvent(context.Background(), access.Event{
+			Timestamp: now,
+			RemoteIP:  "192.0.2.1",
+			UserAgent: "UA",
+		})
+		s := &Service{
+			Context:  ctx,
+			Clock:    clk,
+			Store:    store,
+			Database: database,
+		}
+
+		Convey("should buffer logs until commit", func() {
+			database.EXPECT().UseHook(s).Times(1)
+
+			s.Log(ActivityIdentityCreated, "user-id", map[string]interface{}{"identity_id": "identity-id"})
+			s.Log(ActivityAuthenticationFailed, "user-id", nil)
+
+			store.EXPECT().Create(gomock.Any()).DoAndReturn(func(logs []*Log) error {
+				So(logs, ShouldHaveLength, 2)
+				So(logs[0].ID, ShouldNotBeEmpty)
+				So(logs[0].CreatedAt, ShouldEqual, now)
+				So(logs[0].Activity, ShouldEqual, ActivityIdentityCreated)
+				So(logs[0].Actor, ShouldResemble, Actor{Type: ActorTypeAnonymous})
+				So(logs[0].UserID, ShouldEqual, "user-id")
+				So(logs[0].IPAddress, ShouldEqual, "192.0.2.1")
+				So(logs[0].UserAgent, ShouldEqual, "UA")
+				So(logs[0].Data, ShouldResemble, map[string]interface{}{"identity_id": "identity-id"})
+				So(logs[1].Data, ShouldResemble, map[string]interface{}{})
+				return nil
+			})
+			So(s.WillCommitTx(), ShouldBeNil)
+
+			store.EXPECT().Create(gomock.Len(0)).Return(nil)
+			So(s.WillCommitTx(), ShouldBeNil)
+		})
+
+		Convey("should record actor in context", func() {
+			s.Context = WithActor(ctx, Actor{Type: ActorTypeAdmin, ID: "portal-user-id"})
+			database.EXPECT().UseHook(s)
+
+			s.Log(ActivityAdminMutation, "", nil)
+
+			store.EXPECT().Create(gomock.Any()).DoAndReturn(func(logs []*Log) error {
+				So(logs, ShouldHaveLength, 1)
+				So(logs[0].Actor, ShouldResemble, Actor{Type: ActorTypeAdmin, ID: "portal-user-id"})
+				return nil
+			})
+			So(s.WillCommitTx(), ShouldBeNil)
+		})
+	})
+}
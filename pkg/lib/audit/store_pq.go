@@ -0,0 +1,227 @@
+package audit
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/api/model"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+)
+
+// queryLogsPage lists the latest logs first.
+var queryLogsPage = db.QueryPage(db.QueryPageConfig{
+	KeyColumn: "l.created_at DESC",
+	IDColumn:  "l.id DESC",
+})
+
+type StorePQ struct {
+	SQLBuilder  db.SQLBuilder
+	SQLExecutor db.SQLExecutor
+}
+
+func (s *StorePQ) Create(logs []*Log) error {
+	if len(logs) == 0 {
+		return nil
+	}
+
+	q := s.SQLBuilder.Tenant().
+		Insert(s.SQLBuilder.FullTableName("audit_log")).
+		Columns(
+			"id",
+			"created_at",
+			"activity",
+			"actor_type",
+			"actor_id",
+			"user_id",
+			"ip_address",
+			"user_agent",
+			"data",
+		)
+
+	for _, l := range logs {
+		data, err := json.Marshal(l.Data)
+		if err != nil {
+			return err
+		}
+		q = q.Values(
+			l.ID,
+			l.CreatedAt,
+			string(l.Activity),
+			string(l.Actor.Type),
+			nullString(l.Actor.ID),
+			nullString(l.UserID),
+			nullString(l.IPAddress),
+			nullString(l.UserAgent),
+			data,
+		)
+	}
+
+	_, err := s.SQLExecutor.ExecWith(q)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *StorePQ) Get(id string) (*Log, error) {
+	builder := s.selectQuery().Where("l.id = ?", id)
+
+	row, err := s.SQLExecutor.QueryRowWith(builder)
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := s.scan(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrLogNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+func (s *StorePQ) Count(filter Filter) (uint64, error) {
+	builder := s.SQLBuilder.Tenant().
+		Select("count(*)").
+		From(s.SQLBuilder.FullTableName("audit_log"), "l")
+	builder = applyFilter(builder, filter)
+
+	scanner, err := s.SQLExecutor.QueryRowWith(builder)
+	if err != nil {
+		return 0, err
+	}
+
+	var count uint64
+	if err = scanner.Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (s *StorePQ) QueryPage(filter Filter, after, before model.PageCursor, first, last *uint64) ([]*Log, uint64, error) {
+	afterKey, err := db.NewFromPageCursor(after)
+	if err != nil {
+		return nil, 0, err
+	}
+	beforeKey, err := db.NewFromPageCursor(before)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	selectQuery := applyFilter(s.selectQuery(), filter)
+
+	query, offset, err := queryLogsPage(selectQuery, afterKey, beforeKey, first, last)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := s.SQLExecutor.QueryWith(query)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var logs []*Log
+	for rows.Next() {
+		l, err := s.scan(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		logs = append(logs, l)
+	}
+
+	return logs, offset, nil
+}
+
+// DeleteBefore deletes the logs created before t.
+func (s *StorePQ) DeleteBefore(t time.Time) (int64, error) {
+	q := s.SQLBuilder.Tenant().
+		Delete(s.SQLBuilder.FullTableName("audit_log")).
+		Where("created_at < ?", t)
+
+	result, err := s.SQLExecutor.ExecWith(q)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (s *StorePQ) selectQuery() db.SelectBuilder {
+	return s.SQLBuilder.Tenant().
+		Select(
+			"l.id",
+			"l.created_at",
+			"l.activity",
+			"l.actor_type",
+			"l.actor_id",
+			"l.user_id",
+			"l.ip_address",
+			"l.user_agent",
+			"l.data",
+		).
+		From(s.SQLBuilder.FullTableName("audit_log"), "l")
+}
+
+func (s *StorePQ) scan(scn db.Scanner) (*Log, error) {
+	l := &Log{}
+	var activity, actorType string
+	var actorID, userID, ipAddress, userAgent sql.NullString
+	var data []byte
+	err := scn.Scan(
+		&l.ID,
+		&l.CreatedAt,
+		&activity,
+		&actorType,
+		&actorID,
+		&userID,
+		&ipAddress,
+		&userAgent,
+		&data,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &l.Data); err != nil {
+		return nil, err
+	}
+
+	l.Activity = Activity(activity)
+	l.Actor = Actor{Type: ActorType(actorType), ID: actorID.String}
+	l.UserID = userID.String
+	l.IPAddress = ipAddress.String
+	l.UserAgent = userAgent.String
+	return l, nil
+}
+
+func applyFilter(builder db.SelectBuilder, filter Filter) db.SelectBuilder {
+	if filter.Activity != "" {
+		builder = builder.Where("l.activity = ?", string(filter.Activity))
+	}
+	if filter.UserID != "" {
+		builder = builder.Where("l.user_id = ?", filter.UserID)
+	}
+	if filter.ActorType != "" {
+		builder = builder.Where("l.actor_type = ?", string(filter.ActorType))
+	}
+	if filter.ActorID != "" {
+		builder = builder.Where("l.actor_id = ?", filter.ActorID)
+	}
+	if filter.CreatedAfter != nil {
+		builder = builder.Where("l.created_at >= ?", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		builder = builder.Where("l.created_at < ?", *filter.CreatedBefore)
+	}
+	return builder
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -9,4 +9,6 @@ const (
 	AMRSMS string = "sms"
 	// AMRMFA is from https://tools.ietf.org/html/rfc8176#section-2
 	AMRMFA string = "mfa"
+	// AMRHWK is from https://tools.ietf.org/html/rfc8176#section-2
+	AMRHWK string = "hwk"
 )
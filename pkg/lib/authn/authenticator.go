@@ -6,6 +6,7 @@ const (
 	AuthenticatorTypePassword AuthenticatorType = "password"
 	AuthenticatorTypeTOTP     AuthenticatorType = "totp"
 	AuthenticatorTypeOOB      AuthenticatorType = "oob_otp"
+	AuthenticatorTypeWebAuthn AuthenticatorType = "webauthn"
 )
 
 type AuthenticatorOOBChannel string
@@ -70,6 +70,8 @@ func (i *Info) AMR() []string {
 			panic("authenticator: unknown OOB channel: " + channel)
 		}
 		return out
+	case authn.AuthenticatorTypeWebAuthn:
+		return []string{authn.AMRHWK}
 	default:
 		panic("authenticator: unknown authenticator type: " + i.Type)
 	}
@@ -120,6 +122,15 @@ func (i *Info) Equal(that *Info) bool {
 		default:
 			panic("authenticator: unknown OOB channel: " + iChannel)
 		}
+	case authn.AuthenticatorTypeWebAuthn:
+		// If they are WebAuthn, they have the same credential ID, and primary/secondary tag.
+		if i.Kind != that.Kind {
+			return false
+		}
+
+		iCredentialID := i.Claims[AuthenticatorClaimWebAuthnCredentialID].(string)
+		thatCredentialID := that.Claims[AuthenticatorClaimWebAuthnCredentialID].(string)
+		return iCredentialID == thatCredentialID
 	default:
 		panic("authenticator: unknown authenticator type: " + i.Type)
 	}
@@ -132,6 +143,8 @@ func (i *Info) StandardClaims() map[authn.ClaimName]string {
 		break
 	case authn.AuthenticatorTypeTOTP:
 		break
+	case authn.AuthenticatorTypeWebAuthn:
+		break
 	case authn.AuthenticatorTypeOOB:
 		channel := i.Claims[AuthenticatorClaimOOBOTPChannelType].(string)
 		switch authn.AuthenticatorOOBChannel(i.Claims[AuthenticatorClaimOOBOTPChannelType].(string)) {
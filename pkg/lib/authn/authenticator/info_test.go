@@ -146,6 +146,24 @@ func TestAuthenticatorEqualTrue(t *testing.T) {
 					},
 				},
 			},
+
+			// WebAuthn with the same credential ID.
+			{
+				&Info{
+					Type: authn.AuthenticatorTypeWebAuthn,
+					Kind: KindPrimary,
+					Claims: map[string]interface{}{
+						AuthenticatorClaimWebAuthnCredentialID: "credential-id",
+					},
+				},
+				&Info{
+					Type: authn.AuthenticatorTypeWebAuthn,
+					Kind: KindPrimary,
+					Claims: map[string]interface{}{
+						AuthenticatorClaimWebAuthnCredentialID: "credential-id",
+					},
+				},
+			},
 		}
 
 		for _, c := range cases {
@@ -217,6 +235,24 @@ func TestAuthenticatorEqualFalse(t *testing.T) {
 					},
 				},
 			},
+
+			// WebAuthn with different credential ID.
+			{
+				&Info{
+					Type: authn.AuthenticatorTypeWebAuthn,
+					Kind: KindSecondary,
+					Claims: map[string]interface{}{
+						AuthenticatorClaimWebAuthnCredentialID: "credential-id-1",
+					},
+				},
+				&Info{
+					Type: authn.AuthenticatorTypeWebAuthn,
+					Kind: KindSecondary,
+					Claims: map[string]interface{}{
+						AuthenticatorClaimWebAuthnCredentialID: "credential-id-2",
+					},
+				},
+			},
 		}
 
 		for _, c := range cases {
@@ -14,6 +14,19 @@ const (
 	AuthenticatorClaimOOBOTPPhone string = "https://authgear.com/claims/oob_otp/phone"
 )
 
+const (
+	// AuthenticatorClaimWebAuthnCredentialID is a claim with string value for WebAuthn credential ID.
+	AuthenticatorClaimWebAuthnCredentialID string = "https://authgear.com/claims/webauthn/credential_id"
+	// AuthenticatorClaimWebAuthnDisplayName is a claim with string value for WebAuthn display name.
+	AuthenticatorClaimWebAuthnDisplayName string = "https://authgear.com/claims/webauthn/display_name"
+	// AuthenticatorClaimWebAuthnAAGUID is a claim with string value for WebAuthn authenticator AAGUID.
+	AuthenticatorClaimWebAuthnAAGUID string = "https://authgear.com/claims/webauthn/aaguid"
+	// AuthenticatorClaimWebAuthnAttestationType is a claim with string value for WebAuthn attestation type.
+	AuthenticatorClaimWebAuthnAttestationType string = "https://authgear.com/claims/webauthn/attestation_type"
+	// AuthenticatorClaimWebAuthnSignCount is a claim with number value for WebAuthn signature counter.
+	AuthenticatorClaimWebAuthnSignCount string = "https://authgear.com/claims/webauthn/sign_count"
+)
+
 const (
 	// AuthenticatorStateOOBOTPCode is a claim with string value for OOB OTP code secret of current interaction.
 	// nolint:gosec
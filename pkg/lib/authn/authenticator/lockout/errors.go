@@ -0,0 +1,18 @@
+package lockout
+
+import (
+	"errors"
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+)
+
+var ErrLockoutNotFound = errors.New("lockout not found")
+
+var AccountLocked = apierrors.Forbidden.WithReason("AccountLocked")
+
+func ErrAccountLocked(until time.Time) error {
+	return AccountLocked.NewWithInfo("account is locked", apierrors.Details{
+		"until": until,
+	})
+}
@@ -0,0 +1,19 @@
+package lockout
+
+import (
+	"time"
+)
+
+// Lockout is the failed authentication history of a user.
+type Lockout struct {
+	UserID         string
+	FailedAttempts int
+	FirstFailedAt  *time.Time
+	LockoutCount   int
+	LockedUntil    *time.Time
+	UpdatedAt      time.Time
+}
+
+func (l *Lockout) IsLocked(now time.Time) bool {
+	return l.LockedUntil != nil && now.Before(*l.LockedUntil)
+}
@@ -0,0 +1,216 @@
+package lockout
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/api/event"
+	"github.com/authgear/authgear-server/pkg/api/model"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+)
+
+//go:generate mockgen -source=service.go -destination=service_mock_test.go -package lockout
+
+type Store interface {
+	Get(userID string) (*Lockout, error)
+	Upsert(l *Lockout) error
+	Delete(userID string) error
+}
+
+type UserProvider interface {
+	Get(id string) (*model.User, error)
+}
+
+type HookProvider interface {
+	DispatchEvent(payload event.Payload) error
+}
+
+type DatabaseHandle interface {
+	UseHook(hook db.TransactionHook)
+}
+
+// Service tracks failed authentication attempts and locks out users.
+// Changes are written when the transaction commits; see
+// interaction.Service.DryRun.
+type Service struct {
+	Config   *config.AuthenticationLockoutConfig
+	Clock    clock.Clock
+	Store    Store
+	Database DatabaseHandle
+	Users    UserProvider
+	Hooks    HookProvider
+
+	pending  map[string]*Lockout `wire:"-"`
+	dbHooked bool                `wire:"-"`
+}
+
+// Check returns AccountLocked error if the user is currently locked out.
+func (s *Service) Check(userID string) error {
+	if !s.Config.Enabled {
+		return nil
+	}
+
+	l, err := s.get(userID)
+	if err != nil {
+		return err
+	}
+
+	if l.IsLocked(s.Clock.NowUTC()) {
+		return ErrAccountLocked(*l.LockedUntil)
+	}
+	return nil
+}
+
+// RecordFailure records a failed authentication attempt of the user.
+// It returns AccountLocked error if the user becomes locked out.
+func (s *Service) RecordFailure(userID string) error {
+	if !s.Config.Enabled {
+		return nil
+	}
+
+	l, err := s.get(userID)
+	if err != nil {
+		return err
+	}
+
+	now := s.Clock.NowUTC()
+	historyStart := now.Add(-s.Config.HistoryDuration.Duration())
+	if l.FirstFailedAt == nil || l.FirstFailedAt.Before(historyStart) {
+		l.FailedAttempts = 0
+		l.FirstFailedAt = &now
+	}
+	l.FailedAttempts++
+	l.UpdatedAt = now
+
+	locked := false
+	if l.FailedAttempts >= s.Config.MaxAttempts {
+		lockedUntil := now.Add(s.lockoutDuration(l.LockoutCount))
+		l.LockoutCount++
+		l.LockedUntil = &lockedUntil
+		l.FailedAttempts = 0
+		l.FirstFailedAt = nil
+		locked = true
+	}
+
+	s.setPending(userID, l)
+
+	if locked {
+		user, err := s.Users.Get(userID)
+		if err != nil {
+			return err
+		}
+
+		err = s.Hooks.DispatchEvent(&event.UserLockEvent{
+			User:        *user,
+			LockedUntil: *l.LockedUntil,
+		})
+		if err != nil {
+			return err
+		}
+
+		return ErrAccountLocked(*l.LockedUntil)
+	}
+
+	return nil
+}
+
+// RecordSuccess clears the failed authentication history of the user.
+func (s *Service) RecordSuccess(userID string) error {
+	if !s.Config.Enabled {
+		return nil
+	}
+
+	l, err := s.get(userID)
+	if err != nil {
+		return err
+	}
+
+	if l.FailedAttempts == 0 && l.LockoutCount == 0 && l.LockedUntil == nil {
+		return nil
+	}
+
+	s.setPending(userID, nil)
+	return nil
+}
+
+// GetLockedUntil returns the end of the current lockout of the user,
+// or nil if the user is not locked out.
+func (s *Service) GetLockedUntil(userID string) (*time.Time, error) {
+	l, err := s.get(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !l.IsLocked(s.Clock.NowUTC()) {
+		return nil, nil
+	}
+	return l.LockedUntil, nil
+}
+
+// Unlock removes the lockout and failed authentication history of the user.
+func (s *Service) Unlock(userID string) error {
+	delete(s.pending, userID)
+	return s.Store.Delete(userID)
+}
+
+func (s *Service) WillCommitTx() error {
+	for userID, l := range s.pending {
+		var err error
+		if l == nil {
+			err = s.Store.Delete(userID)
+		} else {
+			err = s.Store.Upsert(l)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	s.pending = nil
+	return nil
+}
+
+func (s *Service) DidCommitTx() {}
+
+func (s *Service) get(userID string) (*Lockout, error) {
+	if l, ok := s.pending[userID]; ok {
+		if l == nil {
+			return &Lockout{UserID: userID}, nil
+		}
+		ll := *l
+		return &ll, nil
+	}
+
+	l, err := s.Store.Get(userID)
+	if errors.Is(err, ErrLockoutNotFound) {
+		return &Lockout{UserID: userID}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (s *Service) setPending(userID string, l *Lockout) {
+	if s.pending == nil {
+		s.pending = map[string]*Lockout{}
+	}
+	s.pending[userID] = l
+
+	if !s.dbHooked {
+		s.Database.UseHook(s)
+		s.dbHooked = true
+	}
+}
+
+func (s *Service) lockoutDuration(previousLockouts int) time.Duration {
+	minimum := float64(s.Config.MinimumDuration.Duration())
+	maximum := float64(s.Config.MaximumDuration.Duration())
+
+	d := minimum * math.Pow(s.Config.BackoffFactor, float64(previousLockouts))
+	if d > maximum {
+		d = maximum
+	}
+	return time.Duration(d)
+}
@@ -0,0 +1,189 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package lockout is a generated GoMock package.
+package lockout
+
+import (
+	event "github.com/authgear/authgear-server/pkg/api/event"
+	model "github.com/authgear/authgear-server/pkg/api/model"
+	db "github.com/authgear/authgear-server/pkg/lib/infra/db"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockStore is a mock of Store interface
+type MockStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreMockRecorder
+}
+
+// MockStoreMockRecorder is the mock recorder for MockStore
+type MockStoreMockRecorder struct {
+	mock *MockStore
+}
+
+// NewMockStore creates a new mock instance
+func NewMockStore(ctrl *gomock.Controller) *MockStore {
+	mock := &MockStore{ctrl: ctrl}
+	mock.recorder = &MockStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockStore) EXPECT() *MockStoreMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method
+func (m *MockStore) Get(userID string) (*Lockout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", userID)
+	ret0, _ := ret[0].(*Lockout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockStoreMockRecorder) Get(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockStore)(nil).Get), userID)
+}
+
+// Upsert mocks base method
+func (m *MockStore) Upsert(l *Lockout) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", l)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert
+func (mr *MockStoreMockRecorder) Upsert(l interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockStore)(nil).Upsert), l)
+}
+
+// Delete mocks base method
+func (m *MockStore) Delete(userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete
+func (mr *MockStoreMockRecorder) Delete(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockStore)(nil).Delete), userID)
+}
+
+// MockUserProvider is a mock of UserProvider interface
+type MockUserProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserProviderMockRecorder
+}
+
+// MockUserProviderMockRecorder is the mock recorder for MockUserProvider
+type MockUserProviderMockRecorder struct {
+	mock *MockUserProvider
+}
+
+// NewMockUserProvider creates a new mock instance
+func NewMockUserProvider(ctrl *gomock.Controller) *MockUserProvider {
+	mock := &MockUserProvider{ctrl: ctrl}
+	mock.recorder = &MockUserProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockUserProvider) EXPECT() *MockUserProviderMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method
+func (m *MockUserProvider) Get(id string) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", id)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockUserProviderMockRecorder) Get(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockUserProvider)(nil).Get), id)
+}
+
+// MockHookProvider is a mock of HookProvider interface
+type MockHookProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockHookProviderMockRecorder
+}
+
+// MockHookProviderMockRecorder is the mock recorder for MockHookProvider
+type MockHookProviderMockRecorder struct {
+	mock *MockHookProvider
+}
+
+// NewMockHookProvider creates a new mock instance
+func NewMockHookProvider(ctrl *gomock.Controller) *MockHookProvider {
+	mock := &MockHookProvider{ctrl: ctrl}
+	mock.recorder = &MockHookProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockHookProvider) EXPECT() *MockHookProviderMockRecorder {
+	return m.recorder
+}
+
+// DispatchEvent mocks base method
+func (m *MockHookProvider) DispatchEvent(payload event.Payload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DispatchEvent", payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DispatchEvent indicates an expected call of DispatchEvent
+func (mr *MockHookProviderMockRecorder) DispatchEvent(payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DispatchEvent", reflect.TypeOf((*MockHookProvider)(nil).DispatchEvent), payload)
+}
+
+// MockDatabaseHandle is a mock of DatabaseHandle interface
+type MockDatabaseHandle struct {
+	ctrl     *gomock.Controller
+	recorder *MockDatabaseHandleMockRecorder
+}
+
+// MockDatabaseHandleMockRecorder is the mock recorder for MockDatabaseHandle
+type MockDatabaseHandleMockRecorder struct {
+	mock *MockDatabaseHandle
+}
+
+// NewMockDatabaseHandle creates a new mock instance
+func NewMockDatabaseHandle(ctrl *gomock.Controller) *MockDatabaseHandle {
+	mock := &MockDatabaseHandle{ctrl: ctrl}
+	mock.recorder = &MockDatabaseHandleMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockDatabaseHandle) EXPECT() *MockDatabaseHandleMockRecorder {
+	return m.recorder
+}
+
+// UseHook mocks base method
+func (m *MockDatabaseHandle) UseHook(hook db.TransactionHook) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UseHook", hook)
+}
+
+// UseHook indicates an expected call of UseHook
+func (mr *MockDatabaseHandleMockRecorder) UseHook(hook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseHook", reflect.TypeOf((*MockDatabaseHandle)(nil).UseHook), hook)
+}
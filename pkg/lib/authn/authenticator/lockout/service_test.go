@@ -0,0 +1,123 @@
+package lockout
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+	"github.com/authgear/authgear-server/pkg/api/event"
+	"github.com/authgear/authgear-server/pkg/api/model"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+)
+
+func TestService(t *testing.T) {
+	Convey("Service", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := NewMockStore(ctrl)
+		database := NewMockDatabaseHandle(ctrl)
+		users := NewMockUserProvider(ctrl)
+		hooks := NewMockHookProvider(ctrl)
+		clk := clock.NewMockClockAt("2020-02-01T00:00:00Z")
+		now := clk.NowUTC()
+
+		cfg := &config.AuthenticationLockoutConfig{
+			Enabled:         true,
+			MaxAttempts:     3,
+			HistoryDuration: 3600,
+			MinimumDuration: 60,
+			MaximumDuration: 300,
+			BackoffFactor:   2,
+		}
+		s := &Service{
+			Config:   cfg,
+			Clock:    clk,
+			Store:    store,
+			Database: database,
+			Users:    users,
+			Hooks:    hooks,
+		}
+
+		Convey("should do nothing if disabled", func() {
+			cfg.Enabled = false
+			So(s.Check("user-id"), ShouldBeNil)
+			So(s.RecordFailure("user-id"), ShouldBeNil)
+		})
+
+		Convey("should reject locked user", func() {
+			lockedUntil := now.Add(time.Minute)
+			store.EXPECT().Get("user-id").Return(&Lockout{
+				UserID:      "user-id",
+				LockedUntil: &lockedUntil,
+			}, nil)
+
+			err := s.Check("user-id")
+			So(apierrors.IsKind(err, AccountLocked), ShouldBeTrue)
+		})
+
+		Convey("should allow user after lockout expired", func() {
+			lockedUntil := now.Add(-time.Second)
+			store.EXPECT().Get("user-id").Return(&Lockout{
+				UserID:      "user-id",
+				LockedUntil: &lockedUntil,
+			}, nil)
+
+			So(s.Check("user-id"), ShouldBeNil)
+		})
+
+		Convey("should lock user after max attempts", func() {
+			store.EXPECT().Get("user-id").Return(nil, ErrLockoutNotFound)
+			database.EXPECT().UseHook(s)
+			users.EXPECT().Get("user-id").Return(&model.User{
+				Meta: model.Meta{ID: "user-id"},
+			}, nil)
+
+			lockedUntil := now.Add(2 * time.Minute)
+			hooks.EXPECT().DispatchEvent(&event.UserLockEvent{
+				User:        model.User{Meta: model.Meta{ID: "user-id"}},
+				LockedUntil: lockedUntil,
+			}).Return(nil)
+
+			So(s.RecordFailure("user-id"), ShouldBeNil)
+			So(s.RecordFailure("user-id"), ShouldBeNil)
+			s.pending["user-id"].LockoutCount = 1
+
+			err := s.RecordFailure("user-id")
+			So(apierrors.IsKind(err, AccountLocked), ShouldBeTrue)
+
+			store.EXPECT().Upsert(&Lockout{
+				UserID:       "user-id",
+				LockoutCount: 2,
+				LockedUntil:  &lockedUntil,
+				UpdatedAt:    now,
+			}).Return(nil)
+			So(s.WillCommitTx(), ShouldBeNil)
+		})
+
+		Convey("should cap lockout duration", func() {
+			So(s.lockoutDuration(0), ShouldEqual, time.Minute)
+			So(s.lockoutDuration(1), ShouldEqual, 2*time.Minute)
+			So(s.lockoutDuration(10), ShouldEqual, 5*time.Minute)
+		})
+
+		Convey("should clear history on success", func() {
+			firstFailedAt := now.Add(-time.Minute)
+			store.EXPECT().Get("user-id").Return(&Lockout{
+				UserID:         "user-id",
+				FailedAttempts: 2,
+				FirstFailedAt:  &firstFailedAt,
+			}, nil)
+			database.EXPECT().UseHook(s)
+
+			So(s.RecordSuccess("user-id"), ShouldBeNil)
+
+			store.EXPECT().Delete("user-id").Return(nil)
+			So(s.WillCommitTx(), ShouldBeNil)
+		})
+	})
+}
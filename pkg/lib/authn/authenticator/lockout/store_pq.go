@@ -0,0 +1,96 @@
+package lockout
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+)
+
+type StorePQ struct {
+	SQLBuilder  db.SQLBuilder
+	SQLExecutor db.SQLExecutor
+}
+
+func (s *StorePQ) Get(userID string) (*Lockout, error) {
+	builder := s.SQLBuilder.Tenant().
+		Select(
+			"l.user_id",
+			"l.failed_attempts",
+			"l.first_failed_at",
+			"l.lockout_count",
+			"l.locked_until",
+			"l.updated_at",
+		).
+		From(s.SQLBuilder.FullTableName("user_lockout"), "l").
+		Where("l.user_id = ?", userID)
+
+	row, err := s.SQLExecutor.QueryRowWith(builder)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &Lockout{}
+	err = row.Scan(
+		&l.UserID,
+		&l.FailedAttempts,
+		&l.FirstFailedAt,
+		&l.LockoutCount,
+		&l.LockedUntil,
+		&l.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrLockoutNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+func (s *StorePQ) Upsert(l *Lockout) error {
+	q := s.SQLBuilder.Tenant().
+		Insert(s.SQLBuilder.FullTableName("user_lockout")).
+		Columns(
+			"user_id",
+			"failed_attempts",
+			"first_failed_at",
+			"lockout_count",
+			"locked_until",
+			"updated_at",
+		).
+		Values(
+			l.UserID,
+			l.FailedAttempts,
+			l.FirstFailedAt,
+			l.LockoutCount,
+			l.LockedUntil,
+			l.UpdatedAt,
+		).
+		Suffix(`ON CONFLICT (user_id) DO UPDATE SET
+			failed_attempts = excluded.failed_attempts,
+			first_failed_at = excluded.first_failed_at,
+			lockout_count = excluded.lockout_count,
+			locked_until = excluded.locked_until,
+			updated_at = excluded.updated_at`)
+
+	_, err := s.SQLExecutor.ExecWith(q)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *StorePQ) Delete(userID string) error {
+	q := s.SQLBuilder.Tenant().
+		Delete(s.SQLBuilder.FullTableName("user_lockout")).
+		Where("user_id = ?", userID)
+
+	_, err := s.SQLExecutor.ExecWith(q)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"sort"
 
 	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
 	"github.com/authgear/authgear-server/pkg/lib/infra/task"
 	"github.com/authgear/authgear-server/pkg/lib/tasks"
 	"github.com/authgear/authgear-server/pkg/util/clock"
@@ -17,6 +18,12 @@ type Logger struct{ *log.Logger }
 
 func NewLogger(lf *log.Factory) Logger { return Logger{lf.New("password")} }
 
+type DatabaseHandle interface {
+	UseHook(hook db.TransactionHook)
+}
+
+// Provider manages password authenticators. Migrated password hashes are
+// written when the transaction commits; see interaction.Service.DryRun.
 type Provider struct {
 	Store           *Store
 	Config          *config.AuthenticatorPasswordConfig
@@ -25,6 +32,10 @@ type Provider struct {
 	PasswordHistory *HistoryStore
 	PasswordChecker *Checker
 	TaskQueue       task.Queue
+	Database        DatabaseHandle
+
+	migrated map[string]*Authenticator `wire:"-"`
+	dbHooked bool                      `wire:"-"`
 }
 
 func (p *Provider) Get(userID string, id string) (*Authenticator, error) {
@@ -131,17 +142,36 @@ func (p *Provider) Authenticate(a *Authenticator, password string) error {
 	}
 
 	if migrated {
-		err = p.Store.UpdatePasswordHash(a)
+		if p.migrated == nil {
+			p.migrated = map[string]*Authenticator{}
+		}
+		aa := *a
+		p.migrated[a.ID] = &aa
+
+		if !p.dbHooked {
+			p.Database.UseHook(p)
+			p.dbHooked = true
+		}
+	}
+
+	return nil
+}
+
+func (p *Provider) WillCommitTx() error {
+	for _, a := range p.migrated {
+		err := p.Store.UpdatePasswordHash(a)
 		if err != nil {
+			// Failure to save migrated password should not fail the authentication.
 			p.Logger.WithError(err).WithField("authenticator_id", a.ID).
 				Warn("Failed to save migrated password")
-			return nil
 		}
 	}
-
+	p.migrated = nil
 	return nil
 }
 
+func (p *Provider) DidCommitTx() {}
+
 func (p *Provider) isPasswordAllowed(userID string, password string) error {
 	return p.PasswordChecker.ValidatePassword(ValidatePayload{
 		AuthID:        userID,
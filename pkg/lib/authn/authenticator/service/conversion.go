@@ -1,11 +1,15 @@
 package service
 
 import (
+	"encoding/base64"
+	"fmt"
+
 	"github.com/authgear/authgear-server/pkg/lib/authn"
 	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator"
 	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator/oob"
 	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator/password"
 	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator/totp"
+	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator/webauthn"
 )
 
 func passwordToAuthenticatorInfo(p *password.Authenticator) *authenticator.Info {
@@ -107,3 +111,57 @@ func oobotpFromAuthenticatorInfo(a *authenticator.Info) *oob.Authenticator {
 		Kind:      string(a.Kind),
 	}
 }
+
+func webauthnToAuthenticatorInfo(w *webauthn.Authenticator) *authenticator.Info {
+	return &authenticator.Info{
+		Type:      authn.AuthenticatorTypeWebAuthn,
+		Labels:    w.Labels,
+		ID:        w.ID,
+		UserID:    w.UserID,
+		CreatedAt: w.CreatedAt,
+		UpdatedAt: w.UpdatedAt,
+		Secret:    base64.StdEncoding.EncodeToString(w.PublicKey),
+		Claims: map[string]interface{}{
+			authenticator.AuthenticatorClaimWebAuthnCredentialID:    w.CredentialID,
+			authenticator.AuthenticatorClaimWebAuthnDisplayName:     w.DisplayName,
+			authenticator.AuthenticatorClaimWebAuthnAAGUID:          w.AAGUID,
+			authenticator.AuthenticatorClaimWebAuthnAttestationType: w.AttestationType,
+			authenticator.AuthenticatorClaimWebAuthnSignCount:       w.SignCount,
+		},
+		IsDefault: w.IsDefault,
+		Kind:      authenticator.Kind(w.Kind),
+	}
+}
+
+func webauthnFromAuthenticatorInfo(a *authenticator.Info) *webauthn.Authenticator {
+	publicKey, err := base64.StdEncoding.DecodeString(a.Secret)
+	if err != nil {
+		panic(fmt.Errorf("authenticator: invalid webauthn public key: %w", err))
+	}
+
+	// The sign count becomes float64 after the info is serialized in
+	// interaction.
+	var signCount int64
+	switch v := a.Claims[authenticator.AuthenticatorClaimWebAuthnSignCount].(type) {
+	case int64:
+		signCount = v
+	case float64:
+		signCount = int64(v)
+	}
+
+	return &webauthn.Authenticator{
+		ID:              a.ID,
+		Labels:          a.Labels,
+		UserID:          a.UserID,
+		CreatedAt:       a.CreatedAt,
+		UpdatedAt:       a.UpdatedAt,
+		CredentialID:    a.Claims[authenticator.AuthenticatorClaimWebAuthnCredentialID].(string),
+		PublicKey:       publicKey,
+		AAGUID:          a.Claims[authenticator.AuthenticatorClaimWebAuthnAAGUID].(string),
+		AttestationType: a.Claims[authenticator.AuthenticatorClaimWebAuthnAttestationType].(string),
+		SignCount:       signCount,
+		DisplayName:     a.Claims[authenticator.AuthenticatorClaimWebAuthnDisplayName].(string),
+		IsDefault:       a.IsDefault,
+		Kind:            string(a.Kind),
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator/oob"
 	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator/password"
 	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator/totp"
+	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator/webauthn"
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity"
 )
 
@@ -43,11 +44,23 @@ type OOBOTPAuthenticatorProvider interface {
 	Authenticate(secret string, channel authn.AuthenticatorOOBChannel, code string) error
 }
 
+type WebAuthnAuthenticatorProvider interface {
+	Get(userID, id string) (*webauthn.Authenticator, error)
+	GetMany(ids []string) ([]*webauthn.Authenticator, error)
+	List(userID string) ([]*webauthn.Authenticator, error)
+	New(userID string, displayName string, response string, isDefault bool, kind string) (*webauthn.Authenticator, error)
+	Create(*webauthn.Authenticator) error
+	Delete(*webauthn.Authenticator) error
+	Authenticate(a *webauthn.Authenticator, response string) error
+	UpdateSignCount(a *webauthn.Authenticator) error
+}
+
 type Service struct {
 	Store    *Store
 	Password PasswordAuthenticatorProvider
 	TOTP     TOTPAuthenticatorProvider
 	OOBOTP   OOBOTPAuthenticatorProvider
+	WebAuthn WebAuthnAuthenticatorProvider
 }
 
 func (s *Service) Get(userID string, typ authn.AuthenticatorType, id string) (*authenticator.Info, error) {
@@ -72,13 +85,20 @@ func (s *Service) Get(userID string, typ authn.AuthenticatorType, id string) (*a
 			return nil, err
 		}
 		return oobotpToAuthenticatorInfo(o), nil
+
+	case authn.AuthenticatorTypeWebAuthn:
+		w, err := s.WebAuthn.Get(userID, id)
+		if err != nil {
+			return nil, err
+		}
+		return webauthnToAuthenticatorInfo(w), nil
 	}
 
 	panic("authenticator: unknown authenticator type " + typ)
 }
 
 func (s *Service) GetMany(refs []*authenticator.Ref) ([]*authenticator.Info, error) {
-	var passwordIDs, totpIDs, oobIDs []string
+	var passwordIDs, totpIDs, oobIDs, webauthnIDs []string
 	for _, ref := range refs {
 		switch ref.Type {
 		case authn.AuthenticatorTypePassword:
@@ -87,6 +107,8 @@ func (s *Service) GetMany(refs []*authenticator.Ref) ([]*authenticator.Info, err
 			totpIDs = append(totpIDs, ref.ID)
 		case authn.AuthenticatorTypeOOB:
 			oobIDs = append(oobIDs, ref.ID)
+		case authn.AuthenticatorTypeWebAuthn:
+			webauthnIDs = append(webauthnIDs, ref.ID)
 		default:
 			panic("authenticator: unknown authenticator type " + ref.Type)
 		}
@@ -118,6 +140,14 @@ func (s *Service) GetMany(refs []*authenticator.Ref) ([]*authenticator.Info, err
 		infos = append(infos, oobotpToAuthenticatorInfo(a))
 	}
 
+	w, err := s.WebAuthn.GetMany(webauthnIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range w {
+		infos = append(infos, webauthnToAuthenticatorInfo(a))
+	}
+
 	return infos, nil
 }
 
@@ -150,6 +180,15 @@ func (s *Service) List(userID string, filters ...authenticator.Filter) ([]*authe
 			ais = append(ais, oobotpToAuthenticatorInfo(a))
 		}
 	}
+	{
+		as, err := s.WebAuthn.List(userID)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range as {
+			ais = append(ais, webauthnToAuthenticatorInfo(a))
+		}
+	}
 
 	var filtered []*authenticator.Info
 	for _, a := range ais {
@@ -201,6 +240,15 @@ func (s *Service) New(spec *authenticator.Spec, secret string) (*authenticator.I
 		}
 		o := s.OOBOTP.New(spec.UserID, authn.AuthenticatorOOBChannel(channel), phone, email, spec.IsDefault, string(spec.Kind))
 		return oobotpToAuthenticatorInfo(o), nil
+
+	case authn.AuthenticatorTypeWebAuthn:
+		// The secret is the attestation response of the registration ceremony.
+		displayName := spec.Claims[authenticator.AuthenticatorClaimWebAuthnDisplayName].(string)
+		w, err := s.WebAuthn.New(spec.UserID, displayName, secret, spec.IsDefault, string(spec.Kind))
+		if err != nil {
+			return nil, err
+		}
+		return webauthnToAuthenticatorInfo(w), nil
 	}
 
 	panic("authenticator: unknown authenticator type " + spec.Type)
@@ -254,6 +302,12 @@ func (s *Service) Create(info *authenticator.Info) error {
 			return err
 		}
 
+	case authn.AuthenticatorTypeWebAuthn:
+		a := webauthnFromAuthenticatorInfo(info)
+		if err := s.WebAuthn.Create(a); err != nil {
+			return err
+		}
+
 	default:
 		panic("authenticator: unknown authenticator type " + info.Type)
 	}
@@ -268,6 +322,11 @@ func (s *Service) Update(info *authenticator.Info) error {
 		if err := s.Password.UpdatePassword(a); err != nil {
 			return err
 		}
+	case authn.AuthenticatorTypeWebAuthn:
+		a := webauthnFromAuthenticatorInfo(info)
+		if err := s.WebAuthn.UpdateSignCount(a); err != nil {
+			return err
+		}
 	default:
 		panic("authenticator: unknown authenticator type for update" + info.Type)
 	}
@@ -294,6 +353,12 @@ func (s *Service) Delete(info *authenticator.Info) error {
 		if err := s.OOBOTP.Delete(a); err != nil {
 			return err
 		}
+
+	case authn.AuthenticatorTypeWebAuthn:
+		a := webauthnFromAuthenticatorInfo(info)
+		if err := s.WebAuthn.Delete(a); err != nil {
+			return err
+		}
 	default:
 		panic("authenticator: delete authenticator is not supported yet for type " + info.Type)
 	}
@@ -324,6 +389,15 @@ func (s *Service) VerifySecret(info *authenticator.Info, state map[string]string
 			return authenticator.ErrInvalidCredentials
 		}
 		return nil
+
+	case authn.AuthenticatorTypeWebAuthn:
+		// The secret is the assertion response of the assertion ceremony.
+		a := webauthnFromAuthenticatorInfo(info)
+		if err := s.WebAuthn.Authenticate(a, secret); err != nil {
+			return err
+		}
+		info.Claims[authenticator.AuthenticatorClaimWebAuthnSignCount] = a.SignCount
+		return nil
 	}
 
 	panic("authenticator: unhandled authenticator type " + info.Type)
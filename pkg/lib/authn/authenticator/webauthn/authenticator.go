@@ -0,0 +1,21 @@
+package webauthn
+
+import (
+	"time"
+)
+
+type Authenticator struct {
+	ID              string
+	Labels          map[string]interface{}
+	IsDefault       bool
+	Kind            string
+	UserID          string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	CredentialID    string
+	PublicKey       []byte
+	AAGUID          string
+	AttestationType string
+	SignCount       int64
+	DisplayName     string
+}
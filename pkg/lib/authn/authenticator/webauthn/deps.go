@@ -0,0 +1,11 @@
+package webauthn
+
+import (
+	"github.com/google/wire"
+)
+
+var DependencySet = wire.NewSet(
+	wire.Struct(new(Store), "*"),
+	wire.Struct(new(SessionStore), "*"),
+	wire.Struct(new(Provider), "*"),
+)
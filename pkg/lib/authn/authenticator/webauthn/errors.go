@@ -0,0 +1,7 @@
+package webauthn
+
+import (
+	"errors"
+)
+
+var ErrSessionNotFound = errors.New("webauthn session not found")
@@ -0,0 +1,341 @@
+package webauthn
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/duo-labs/webauthn/protocol"
+	"github.com/duo-labs/webauthn/webauthn"
+
+	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+	"github.com/authgear/authgear-server/pkg/util/uuid"
+)
+
+// SessionTimeout is the time allowed to complete a ceremony.
+const SessionTimeout = 5 * time.Minute
+
+type Provider struct {
+	Store      *Store
+	Sessions   *SessionStore
+	Config     *config.AuthenticatorWebAuthnConfig
+	HTTPConfig *config.HTTPConfig
+	Clock      clock.Clock
+}
+
+func (p *Provider) Get(userID string, id string) (*Authenticator, error) {
+	return p.Store.Get(userID, id)
+}
+
+func (p *Provider) GetMany(ids []string) ([]*Authenticator, error) {
+	return p.Store.GetMany(ids)
+}
+
+func (p *Provider) Delete(a *Authenticator) error {
+	return p.Store.Delete(a.ID)
+}
+
+func (p *Provider) List(userID string) ([]*Authenticator, error) {
+	authenticators, err := p.Store.List(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sortAuthenticators(authenticators)
+	return authenticators, nil
+}
+
+func (p *Provider) Create(a *Authenticator) error {
+	now := p.Clock.NowUTC()
+	a.CreatedAt = now
+	a.UpdatedAt = now
+	return p.Store.Create(a)
+}
+
+// BeginRegistration starts a registration ceremony, and returns the options
+// to be passed to navigator.credentials.create.
+func (p *Provider) BeginRegistration(userID string, userName string, excludeCredentialIDs []string) (json.RawMessage, error) {
+	rp, err := p.relyingParty()
+	if err != nil {
+		return nil, err
+	}
+
+	excludeList, err := credentialDescriptors(excludeCredentialIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	requireResidentKey := false
+	options, data, err := rp.BeginRegistration(
+		&user{ID: userID, Name: userName},
+		webauthn.WithAuthenticatorSelection(protocol.AuthenticatorSelection{
+			RequireResidentKey: &requireResidentKey,
+			UserVerification:   protocol.UserVerificationRequirement(p.Config.UserVerification),
+		}),
+		webauthn.WithExclusions(excludeList),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.createSession(data.Challenge, CeremonyRegistration, userID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(options)
+}
+
+// New completes a registration ceremony with the attestation response
+// returned by navigator.credentials.create.
+func (p *Provider) New(userID string, displayName string, response string, isDefault bool, kind string) (*Authenticator, error) {
+	rp, err := p.relyingParty()
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := protocol.ParseCredentialCreationResponseBody(strings.NewReader(response))
+	if err != nil {
+		return nil, authenticator.ErrInvalidCredentials
+	}
+
+	session, err := p.consumeSession(parsed.Response.CollectedClientData.Challenge, CeremonyRegistration, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	credential, err := rp.CreateCredential(&user{ID: userID}, session, parsed)
+	if err != nil {
+		return nil, authenticator.ErrInvalidCredentials
+	}
+
+	a := &Authenticator{
+		ID:              uuid.New(),
+		Labels:          make(map[string]interface{}),
+		UserID:          userID,
+		IsDefault:       isDefault,
+		Kind:            kind,
+		CredentialID:    encodeCredentialID(credential.ID),
+		PublicKey:       credential.PublicKey,
+		AAGUID:          formatAAGUID(credential.Authenticator.AAGUID),
+		AttestationType: credential.AttestationType,
+		SignCount:       int64(credential.Authenticator.SignCount),
+		DisplayName:     displayName,
+	}
+	return a, nil
+}
+
+// BeginAssertion starts an assertion ceremony, and returns the options
+// to be passed to navigator.credentials.get.
+func (p *Provider) BeginAssertion(userID string, credentialIDs []string) (json.RawMessage, error) {
+	rp, err := p.relyingParty()
+	if err != nil {
+		return nil, err
+	}
+
+	u := &user{ID: userID}
+	for _, id := range credentialIDs {
+		rawID, err := decodeCredentialID(id)
+		if err != nil {
+			return nil, err
+		}
+		u.Credentials = append(u.Credentials, webauthn.Credential{ID: rawID})
+	}
+
+	options, data, err := rp.BeginLogin(u)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.createSession(data.Challenge, CeremonyAssertion, userID, credentialIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(options)
+}
+
+// Authenticate completes an assertion ceremony with the assertion response
+// returned by navigator.credentials.get.
+//
+// The response is rejected if the signature counter does not increase,
+// which indicates the authenticator may be cloned. The new signature counter
+// is set to a, and must be saved with UpdateSignCount.
+func (p *Provider) Authenticate(a *Authenticator, response string) error {
+	rp, err := p.relyingParty()
+	if err != nil {
+		return err
+	}
+
+	parsed, err := protocol.ParseCredentialRequestResponseBody(strings.NewReader(response))
+	if err != nil {
+		return authenticator.ErrInvalidCredentials
+	}
+
+	// The response is for another authenticator; keep the session for it.
+	if encodeCredentialID(parsed.RawID) != a.CredentialID {
+		return authenticator.ErrInvalidCredentials
+	}
+
+	session, err := p.consumeSession(parsed.Response.CollectedClientData.Challenge, CeremonyAssertion, a.UserID)
+	if err != nil {
+		return err
+	}
+
+	// Use the latest signature counter instead of the one in interaction.
+	stored, err := p.Store.Get(a.UserID, a.ID)
+	if err != nil {
+		return err
+	}
+
+	credential, err := rp.ValidateLogin(&user{
+		ID: a.UserID,
+		Credentials: []webauthn.Credential{{
+			ID:        parsed.RawID,
+			PublicKey: stored.PublicKey,
+			Authenticator: webauthn.Authenticator{
+				SignCount: uint32(stored.SignCount),
+			},
+		}},
+	}, session, parsed)
+	if err != nil {
+		return authenticator.ErrInvalidCredentials
+	}
+	if credential.Authenticator.CloneWarning {
+		return authenticator.ErrInvalidCredentials
+	}
+
+	a.SignCount = int64(credential.Authenticator.SignCount)
+	return nil
+}
+
+func (p *Provider) UpdateSignCount(a *Authenticator) error {
+	return p.Store.UpdateSignCount(a, a.SignCount, p.Clock.NowUTC())
+}
+
+func (p *Provider) relyingParty() (*webauthn.WebAuthn, error) {
+	origin, err := url.Parse(p.HTTPConfig.PublicOrigin)
+	if err != nil {
+		return nil, fmt.Errorf("webauthn: invalid public origin: %w", err)
+	}
+
+	rpID := p.Config.RPID
+	if rpID == "" {
+		rpID = origin.Hostname()
+	}
+	rpDisplayName := p.Config.RPDisplayName
+	if rpDisplayName == "" {
+		rpDisplayName = rpID
+	}
+
+	return webauthn.New(&webauthn.Config{
+		RPID:                  rpID,
+		RPDisplayName:         rpDisplayName,
+		RPOrigin:              p.HTTPConfig.PublicOrigin,
+		AttestationPreference: protocol.ConveyancePreference(p.Config.Attestation),
+		AuthenticatorSelection: protocol.AuthenticatorSelection{
+			UserVerification: protocol.UserVerificationRequirement(p.Config.UserVerification),
+		},
+		Timeout: int(SessionTimeout / time.Millisecond),
+	})
+}
+
+func (p *Provider) createSession(challenge string, ceremony Ceremony, userID string, credentialIDs []string) error {
+	now := p.Clock.NowUTC()
+	return p.Sessions.Create(&Session{
+		Challenge:            challenge,
+		Ceremony:             ceremony,
+		UserID:               userID,
+		AllowedCredentialIDs: credentialIDs,
+		UserVerification:     p.Config.UserVerification,
+		CreatedAt:            now,
+		ExpireAt:             now.Add(SessionTimeout),
+	})
+}
+
+func (p *Provider) consumeSession(challenge string, ceremony Ceremony, userID string) (webauthn.SessionData, error) {
+	session, err := p.Sessions.Consume(challenge)
+	if errors.Is(err, ErrSessionNotFound) {
+		return webauthn.SessionData{}, authenticator.ErrInvalidCredentials
+	} else if err != nil {
+		return webauthn.SessionData{}, err
+	}
+
+	if session.Ceremony != ceremony || session.UserID != userID {
+		return webauthn.SessionData{}, authenticator.ErrInvalidCredentials
+	}
+
+	var allowedCredentialIDs [][]byte
+	for _, id := range session.AllowedCredentialIDs {
+		rawID, err := decodeCredentialID(id)
+		if err != nil {
+			return webauthn.SessionData{}, err
+		}
+		allowedCredentialIDs = append(allowedCredentialIDs, rawID)
+	}
+
+	return webauthn.SessionData{
+		Challenge:            session.Challenge,
+		UserID:               []byte(session.UserID),
+		AllowedCredentialIDs: allowedCredentialIDs,
+		UserVerification:     protocol.UserVerificationRequirement(session.UserVerification),
+	}, nil
+}
+
+type user struct {
+	ID          string
+	Name        string
+	Credentials []webauthn.Credential
+}
+
+var _ webauthn.User = &user{}
+
+func (u *user) WebAuthnID() []byte                         { return []byte(u.ID) }
+func (u *user) WebAuthnName() string                       { return u.Name }
+func (u *user) WebAuthnDisplayName() string                { return u.Name }
+func (u *user) WebAuthnIcon() string                       { return "" }
+func (u *user) WebAuthnCredentials() []webauthn.Credential { return u.Credentials }
+
+func credentialDescriptors(ids []string) ([]protocol.CredentialDescriptor, error) {
+	var out []protocol.CredentialDescriptor
+	for _, id := range ids {
+		rawID, err := decodeCredentialID(id)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, protocol.CredentialDescriptor{
+			Type:         protocol.PublicKeyCredentialType,
+			CredentialID: rawID,
+		})
+	}
+	return out, nil
+}
+
+func encodeCredentialID(rawID []byte) string {
+	return base64.RawURLEncoding.EncodeToString(rawID)
+}
+
+func decodeCredentialID(id string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(id)
+}
+
+func formatAAGUID(aaguid []byte) string {
+	if len(aaguid) != 16 {
+		return hex.EncodeToString(aaguid)
+	}
+	return fmt.Sprintf("%x-%x-%x-%x-%x", aaguid[0:4], aaguid[4:6], aaguid[6:8], aaguid[8:10], aaguid[10:16])
+}
+
+func sortAuthenticators(as []*Authenticator) {
+	sort.Slice(as, func(i, j int) bool {
+		return as[i].CreatedAt.Before(as[j].CreatedAt)
+	})
+}
@@ -0,0 +1,91 @@
+package webauthn
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	goredis "github.com/gomodule/redigo/redis"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/infra/redis"
+)
+
+type Ceremony string
+
+const (
+	CeremonyRegistration Ceremony = "registration"
+	CeremonyAssertion    Ceremony = "assertion"
+)
+
+// Session is the state of an ongoing ceremony. It is keyed by its challenge
+// and can be consumed only once.
+type Session struct {
+	Challenge            string                          `json:"challenge"`
+	Ceremony             Ceremony                        `json:"ceremony"`
+	UserID               string                          `json:"user_id"`
+	AllowedCredentialIDs []string                        `json:"allowed_credential_ids,omitempty"`
+	UserVerification     config.WebAuthnUserVerification `json:"user_verification"`
+	CreatedAt            time.Time                       `json:"created_at"`
+	ExpireAt             time.Time                       `json:"expire_at"`
+}
+
+type SessionStore struct {
+	Redis *redis.Handle
+	AppID config.AppID
+}
+
+func (s *SessionStore) Create(session *Session) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	ttl := session.ExpireAt.Sub(session.CreatedAt)
+	key := redisSessionKey(s.AppID, session.Challenge)
+
+	return s.Redis.WithConn(func(conn redis.Conn) error {
+		_, err := goredis.String(conn.Do("SET", key, data, "PX", toMilliseconds(ttl), "NX"))
+		if errors.Is(err, goredis.ErrNil) {
+			return errors.New("duplicated webauthn challenge")
+		} else if err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
+func (s *SessionStore) Consume(challenge string) (*Session, error) {
+	key := redisSessionKey(s.AppID, challenge)
+
+	session := &Session{}
+	err := s.Redis.WithConn(func(conn redis.Conn) error {
+		data, err := goredis.Bytes(conn.Do("GET", key))
+		if errors.Is(err, goredis.ErrNil) {
+			return ErrSessionNotFound
+		} else if err != nil {
+			return err
+		}
+
+		if err := json.Unmarshal(data, session); err != nil {
+			return err
+		}
+
+		_, err = conn.Do("DEL", key)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func redisSessionKey(appID config.AppID, challenge string) string {
+	return fmt.Sprintf("app:%s:webauthn-session:%s", appID, challenge)
+}
+
+func toMilliseconds(d time.Duration) int64 {
+	return int64(d / time.Millisecond)
+}
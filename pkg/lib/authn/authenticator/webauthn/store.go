@@ -0,0 +1,234 @@
+package webauthn
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/authgear/authgear-server/pkg/lib/authn"
+	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+)
+
+type Store struct {
+	SQLBuilder  db.SQLBuilder
+	SQLExecutor db.SQLExecutor
+}
+
+func (s *Store) selectQuery() db.SelectBuilder {
+	return s.SQLBuilder.Tenant().
+		Select(
+			"a.id",
+			"a.labels",
+			"a.user_id",
+			"a.created_at",
+			"a.updated_at",
+			"a.is_default",
+			"a.kind",
+			"aw.credential_id",
+			"aw.public_key",
+			"aw.aaguid",
+			"aw.attestation_type",
+			"aw.sign_count",
+			"aw.display_name",
+		).
+		From(s.SQLBuilder.FullTableName("authenticator"), "a").
+		Join(
+			s.SQLBuilder.FullTableName("authenticator_webauthn"),
+			"aw",
+			"a.id = aw.id",
+		)
+}
+
+func (s *Store) scan(scn db.Scanner) (*Authenticator, error) {
+	a := &Authenticator{}
+	var labels []byte
+
+	err := scn.Scan(
+		&a.ID,
+		&labels,
+		&a.UserID,
+		&a.CreatedAt,
+		&a.UpdatedAt,
+		&a.IsDefault,
+		&a.Kind,
+		&a.CredentialID,
+		&a.PublicKey,
+		&a.AAGUID,
+		&a.AttestationType,
+		&a.SignCount,
+		&a.DisplayName,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, authenticator.ErrAuthenticatorNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(labels, &a.Labels); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+func (s *Store) GetMany(ids []string) ([]*Authenticator, error) {
+	builder := s.selectQuery().Where("a.id = ANY (?)", pq.Array(ids))
+
+	rows, err := s.SQLExecutor.QueryWith(builder)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var as []*Authenticator
+	for rows.Next() {
+		a, err := s.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		as = append(as, a)
+	}
+
+	return as, nil
+}
+
+func (s *Store) Get(userID string, id string) (*Authenticator, error) {
+	q := s.selectQuery().Where("a.user_id = ? AND a.id = ?", userID, id)
+
+	row, err := s.SQLExecutor.QueryRowWith(q)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.scan(row)
+}
+
+func (s *Store) List(userID string) ([]*Authenticator, error) {
+	q := s.selectQuery().Where("a.user_id = ?", userID)
+
+	rows, err := s.SQLExecutor.QueryWith(q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var authenticators []*Authenticator
+	for rows.Next() {
+		a, err := s.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, a)
+	}
+
+	return authenticators, nil
+}
+
+func (s *Store) UpdateSignCount(a *Authenticator, signCount int64, updatedAt time.Time) error {
+	q := s.SQLBuilder.Tenant().
+		Update(s.SQLBuilder.FullTableName("authenticator_webauthn")).
+		Set("sign_count", signCount).
+		Where("id = ?", a.ID)
+	_, err := s.SQLExecutor.ExecWith(q)
+	if err != nil {
+		return err
+	}
+
+	q = s.SQLBuilder.Tenant().
+		Update(s.SQLBuilder.FullTableName("authenticator")).
+		Set("updated_at", updatedAt).
+		Where("id = ?", a.ID)
+	_, err = s.SQLExecutor.ExecWith(q)
+	if err != nil {
+		return err
+	}
+
+	a.SignCount = signCount
+	a.UpdatedAt = updatedAt
+	return nil
+}
+
+func (s *Store) Delete(id string) error {
+	q := s.SQLBuilder.Tenant().
+		Delete(s.SQLBuilder.FullTableName("authenticator_webauthn")).
+		Where("id = ?", id)
+	_, err := s.SQLExecutor.ExecWith(q)
+	if err != nil {
+		return err
+	}
+
+	q = s.SQLBuilder.Tenant().
+		Delete(s.SQLBuilder.FullTableName("authenticator")).
+		Where("id = ?", id)
+	_, err = s.SQLExecutor.ExecWith(q)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Store) Create(a *Authenticator) error {
+	labels, err := json.Marshal(a.Labels)
+	if err != nil {
+		return err
+	}
+
+	q := s.SQLBuilder.Tenant().
+		Insert(s.SQLBuilder.FullTableName("authenticator")).
+		Columns(
+			"id",
+			"labels",
+			"type",
+			"user_id",
+			"created_at",
+			"updated_at",
+			"is_default",
+			"kind",
+		).
+		Values(
+			a.ID,
+			labels,
+			authn.AuthenticatorTypeWebAuthn,
+			a.UserID,
+			a.CreatedAt,
+			a.UpdatedAt,
+			a.IsDefault,
+			a.Kind,
+		)
+	_, err = s.SQLExecutor.ExecWith(q)
+	if err != nil {
+		return err
+	}
+
+	q = s.SQLBuilder.Tenant().
+		Insert(s.SQLBuilder.FullTableName("authenticator_webauthn")).
+		Columns(
+			"id",
+			"credential_id",
+			"public_key",
+			"aaguid",
+			"attestation_type",
+			"sign_count",
+			"display_name",
+		).
+		Values(
+			a.ID,
+			a.CredentialID,
+			a.PublicKey,
+			a.AAGUID,
+			a.AttestationType,
+			a.SignCount,
+			a.DisplayName,
+		)
+	_, err = s.SQLExecutor.ExecWith(q)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
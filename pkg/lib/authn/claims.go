@@ -4,12 +4,17 @@ type ClaimName string
 
 // ref: https://www.iana.org/assignments/jwt/jwt.xhtml
 const (
-	ClaimACR               ClaimName = "acr"
-	ClaimAMR               ClaimName = "amr"
-	ClaimEmail             ClaimName = "email"
-	ClaimPhoneNumber       ClaimName = "phone_number"
-	ClaimPreferredUsername ClaimName = "preferred_username"
-	ClaimKeyID             ClaimName = "https://authgear.com/claims/user/key_id"
-	ClaimUserIsAnonymous   ClaimName = "https://authgear.com/claims/user/is_anonymous"
-	ClaimUserIsVerified    ClaimName = "https://authgear.com/claims/user/is_verified"
+	ClaimACR                 ClaimName = "acr"
+	ClaimAMR                 ClaimName = "amr"
+	ClaimAuthTime            ClaimName = "auth_time"
+	ClaimEmail               ClaimName = "email"
+	ClaimEmailVerified       ClaimName = "email_verified"
+	ClaimPhoneNumber         ClaimName = "phone_number"
+	ClaimPhoneNumberVerified ClaimName = "phone_number_verified"
+	ClaimPreferredUsername   ClaimName = "preferred_username"
+	ClaimUpdatedAt           ClaimName = "updated_at"
+	ClaimKeyID               ClaimName = "https://authgear.com/claims/user/key_id"
+	ClaimUserIsAnonymous     ClaimName = "https://authgear.com/claims/user/is_anonymous"
+	ClaimUserIsVerified      ClaimName = "https://authgear.com/claims/user/is_verified"
+	ClaimUserProfile         ClaimName = "https://authgear.com/claims/user/profile"
 )
@@ -39,4 +39,5 @@ const (
 	StandardClaimEmail             string = "email"
 	StandardClaimPhoneNumber       string = "phone_number"
 	StandardClaimPreferredUsername string = "preferred_username"
+	StandardClaimName              string = "name"
 )
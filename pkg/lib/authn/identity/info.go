@@ -80,7 +80,8 @@ func (i *Info) ToModel() model.Identity {
 
 // DisplayID returns a string that is suitable for the owner to identify the identity.
 // If it is a Login ID identity, the original login ID value is returned.
-// If it is a OAuth identity, the email claim is returned.
+// If it is a OAuth identity, the email claim is returned, or the name claim
+// if the provider does not tell the email.
 // If it is a anonymous identity, the kid is returned.
 func (i *Info) DisplayID() string {
 	switch i.Type {
@@ -88,7 +89,10 @@ func (i *Info) DisplayID() string {
 		displayID, _ := i.Claims[IdentityClaimLoginIDOriginalValue].(string)
 		return displayID
 	case authn.IdentityTypeOAuth:
-		displayID, _ := i.Claims[StandardClaimEmail].(string)
+		if displayID, ok := i.Claims[StandardClaimEmail].(string); ok {
+			return displayID
+		}
+		displayID, _ := i.Claims[StandardClaimName].(string)
 		return displayID
 	case authn.IdentityTypeAnonymous:
 		displayID, _ := i.Claims[IdentityClaimAnonymousKeyID].(string)
@@ -160,11 +164,13 @@ func (i *Info) PrimaryAuthenticatorTypes() []authn.AuthenticatorType {
 		case config.LoginIDKeyTypeUsername:
 			return []authn.AuthenticatorType{
 				authn.AuthenticatorTypePassword,
+				authn.AuthenticatorTypeWebAuthn,
 			}
 		case config.LoginIDKeyTypeEmail, config.LoginIDKeyTypePhone:
 			return []authn.AuthenticatorType{
 				authn.AuthenticatorTypePassword,
 				authn.AuthenticatorTypeOOB,
+				authn.AuthenticatorTypeWebAuthn,
 			}
 		default:
 			panic(fmt.Sprintf("identity: unexpected login ID type: %s", i.Claims[IdentityClaimLoginIDType]))
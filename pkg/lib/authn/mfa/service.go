@@ -55,6 +55,10 @@ func (s *Service) InvalidateAllDeviceTokens(userID string) error {
 	return s.DeviceTokens.DeleteAll(userID)
 }
 
+func (s *Service) InvalidateAllRecoveryCodes(userID string) error {
+	return s.RecoveryCodes.DeleteAll(userID)
+}
+
 func (s *Service) GenerateRecoveryCodes() []string {
 	codes := make([]string, s.Config.RecoveryCode.Count)
 	for i := range codes {
@@ -7,6 +7,7 @@ import (
 	"github.com/authgear/authgear-server/pkg/lib/infra/mail"
 	"github.com/authgear/authgear-server/pkg/lib/infra/sms"
 	"github.com/authgear/authgear-server/pkg/lib/infra/task"
+	"github.com/authgear/authgear-server/pkg/lib/ratelimit"
 	"github.com/authgear/authgear-server/pkg/lib/tasks"
 	"github.com/authgear/authgear-server/pkg/lib/translation"
 )
@@ -21,11 +22,17 @@ type TranslationService interface {
 	SMSMessageData(msg *translation.MessageSpec, args interface{}) (*translation.SMSMessageData, error)
 }
 
+type RateLimiter interface {
+	TakeToken(bucket ratelimit.Bucket) error
+}
+
 type MessageSender struct {
 	StaticAssetURLPrefix config.StaticAssetURLPrefix
+	RateLimitConfig      *config.RateLimitConfig
 	Translation          TranslationService
 	Endpoints            EndpointsProvider
 	TaskQueue            task.Queue
+	RateLimiter          RateLimiter
 }
 
 type SendOptions struct {
@@ -34,6 +41,12 @@ type SendOptions struct {
 	MessageType MessageType
 }
 
+func (s *MessageSender) takeSendToken(target string) error {
+	return s.RateLimiter.TakeToken(ratelimit.NewBucket(
+		"MessageSendPerTarget", target, s.RateLimitConfig.MessageSendPerTarget,
+	))
+}
+
 func (s *MessageSender) makeData(opts SendOptions) (*MessageTemplateContext, error) {
 	appMeta, err := s.Translation.AppMetadata()
 	if err != nil {
@@ -55,6 +68,11 @@ func (s *MessageSender) makeData(opts SendOptions) (*MessageTemplateContext, err
 }
 
 func (s *MessageSender) SendEmail(email string, opts SendOptions) error {
+	err := s.takeSendToken(email)
+	if err != nil {
+		return err
+	}
+
 	data, err := s.makeData(opts)
 	if err != nil {
 		return err
@@ -99,6 +117,11 @@ func (s *MessageSender) SendEmail(email string, opts SendOptions) error {
 }
 
 func (s *MessageSender) SendSMS(phone string, opts SendOptions) (err error) {
+	err = s.takeSendToken(phone)
+	if err != nil {
+		return err
+	}
+
 	data, err := s.makeData(opts)
 	if err != nil {
 		return err
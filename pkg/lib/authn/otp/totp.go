@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pquerna/otp"
@@ -56,6 +57,23 @@ func GenerateTOTPSecret() (string, error) {
 	return secret, nil
 }
 
+// NormalizeTOTPSecret normalizes the TOTP secret generated elsewhere to
+// Base32 without padding. Whitespaces and lowercase letters are tolerated.
+func NormalizeTOTPSecret(secret string) (string, error) {
+	secret = strings.ToUpper(strings.Join(strings.Fields(secret), ""))
+	secret = strings.TrimRight(secret, "=")
+
+	secretBytes, err := b32NoPadding.DecodeString(secret)
+	if err != nil {
+		return "", err
+	}
+	if len(secretBytes) == 0 {
+		return "", fmt.Errorf("otp: empty TOTP secret")
+	}
+
+	return b32NoPadding.EncodeToString(secretBytes), nil
+}
+
 // ValidateTOTP validates the TOTP code against the secret at the given time t.
 func ValidateTOTP(secret string, code string, t time.Time, opts ValidateOpts) bool {
 	ok, err := totp.ValidateCustom(code, secret, t, opts)
@@ -93,8 +93,15 @@ func fetchAccessTokenResp(
 	v.Add("client_id", clientID)
 	v.Add("client_secret", clientSecret)
 
-	// nolint: gosec
-	resp, err := http.PostForm(accessTokenURL, v)
+	req, err := http.NewRequest(http.MethodPost, accessTokenURL, strings.NewReader(v.Encode()))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	// Some providers, e.g. GitHub, respond with form encoding by default.
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
 	if resp != nil {
 		defer resp.Body.Close()
 	}
@@ -17,6 +17,7 @@ type ProviderUserInfo struct {
 	ID string
 	// Email is normalized.
 	Email string
+	Name  string
 }
 
 func (i ProviderUserInfo) ClaimsValue() map[string]interface{} {
@@ -24,6 +25,9 @@ func (i ProviderUserInfo) ClaimsValue() map[string]interface{} {
 	if i.Email != "" {
 		claimsValue[identity.StandardClaimEmail] = i.Email
 	}
+	if i.Name != "" {
+		claimsValue[identity.StandardClaimName] = i.Name
+	}
 	return claimsValue
 }
 
@@ -75,7 +79,7 @@ func (h getAuthInfoRequest) getAuthInfoByAccessTokenResp(accessTokenResp AccessT
 		return
 	}
 	authInfo.ProviderRawProfile = userProfile
-	providerUserInfo, err := h.userInfoDecoder.DecodeUserInfo(h.providerConfig.Type, userProfile)
+	providerUserInfo, err := h.userInfoDecoder.DecodeUserInfo(h.providerConfig, userProfile)
 	if err != nil {
 		return
 	}
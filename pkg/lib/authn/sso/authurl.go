@@ -25,7 +25,9 @@ func authURL(params authURLParams) (string, error) {
 	v.Add("response_type", "code")
 	v.Add("client_id", params.clientID)
 	v.Add("redirect_uri", params.redirectURI)
-	v.Add("scope", params.scope)
+	if params.scope != "" {
+		v.Add("scope", params.scope)
+	}
 	v.Add("state", params.state)
 	return params.baseURL + "?" + v.Encode(), nil
 }
@@ -30,7 +30,7 @@ func (f *FacebookImpl) GetAuthURL(param GetAuthURLParam) (string, error) {
 	p := authURLParams{
 		redirectURI: f.RedirectURL.SSOCallbackURL(f.ProviderConfig).String(),
 		clientID:    f.ProviderConfig.ClientID,
-		scope:       f.ProviderConfig.Type.Scope(),
+		scope:       f.ProviderConfig.Scope(),
 		state:       param.State,
 		baseURL:     facebookAuthorizationURL,
 	}
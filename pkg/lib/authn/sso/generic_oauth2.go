@@ -0,0 +1,54 @@
+package sso
+
+import (
+	"github.com/authgear/authgear-server/pkg/lib/config"
+)
+
+// GenericOAuth2Impl is an OAuth 2.0 provider configured with its endpoints,
+// e.g. GitHub, GitLab and Discord.
+type GenericOAuth2Impl struct {
+	RedirectURL     RedirectURLProvider
+	ProviderConfig  config.OAuthSSOProviderConfig
+	Credentials     config.OAuthClientCredentialsItem
+	UserInfoDecoder UserInfoDecoder
+}
+
+func (*GenericOAuth2Impl) Type() config.OAuthSSOProviderType {
+	return config.OAuthSSOProviderTypeOAuth2
+}
+
+func (f *GenericOAuth2Impl) Config() config.OAuthSSOProviderConfig {
+	return f.ProviderConfig
+}
+
+func (f *GenericOAuth2Impl) GetAuthURL(param GetAuthURLParam) (string, error) {
+	p := authURLParams{
+		redirectURI: f.RedirectURL.SSOCallbackURL(f.ProviderConfig).String(),
+		clientID:    f.ProviderConfig.ClientID,
+		scope:       f.ProviderConfig.Scope(),
+		state:       param.State,
+		baseURL:     f.ProviderConfig.AuthorizationEndpoint,
+	}
+	return authURL(p)
+}
+
+func (f *GenericOAuth2Impl) GetAuthInfo(r OAuthAuthorizationResponse, param GetAuthInfoParam) (authInfo AuthInfo, err error) {
+	return f.NonOpenIDConnectGetAuthInfo(r, param)
+}
+
+func (f *GenericOAuth2Impl) NonOpenIDConnectGetAuthInfo(r OAuthAuthorizationResponse, _ GetAuthInfoParam) (authInfo AuthInfo, err error) {
+	h := getAuthInfoRequest{
+		redirectURL:     f.RedirectURL.SSOCallbackURL(f.ProviderConfig).String(),
+		providerConfig:  f.ProviderConfig,
+		clientSecret:    f.Credentials.ClientSecret,
+		accessTokenURL:  f.ProviderConfig.TokenEndpoint,
+		userProfileURL:  f.ProviderConfig.UserInfoEndpoint,
+		userInfoDecoder: f.UserInfoDecoder,
+	}
+	return h.getAuthInfo(r)
+}
+
+var (
+	_ OAuthProvider            = &GenericOAuth2Impl{}
+	_ NonOpenIDConnectProvider = &GenericOAuth2Impl{}
+)
@@ -0,0 +1,113 @@
+package sso
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+)
+
+func TestGenericOAuth2(t *testing.T) {
+	Convey("GenericOAuth2Impl", t, func() {
+		userInfo := map[string]interface{}{
+			"id":    float64(1234567),
+			"login": "octocat",
+			"emails": []interface{}{
+				map[string]interface{}{"email": "octocat@example.com", "verified": true},
+			},
+		}
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/token", func(rw http.ResponseWriter, r *http.Request) {
+			_ = r.ParseForm()
+			if r.Header.Get("Accept") != "application/json" || r.PostForm.Get("code") != "code" {
+				rw.WriteHeader(http.StatusBadRequest)
+				_, _ = rw.Write([]byte(`{"error":"invalid_request"}`))
+				return
+			}
+			_ = json.NewEncoder(rw).Encode(map[string]interface{}{
+				"access_token": "access-token",
+				"token_type":   "bearer",
+			})
+		})
+		mux.HandleFunc("/user", func(rw http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer access-token" {
+				rw.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_ = json.NewEncoder(rw).Encode(userInfo)
+		})
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		impl := &GenericOAuth2Impl{
+			RedirectURL: mockRedirectURLProvider{},
+			ProviderConfig: config.OAuthSSOProviderConfig{
+				Alias:                 "github",
+				Type:                  config.OAuthSSOProviderTypeOAuth2,
+				ClientID:              "client-id",
+				AuthorizationEndpoint: server.URL + "/authorize",
+				TokenEndpoint:         server.URL + "/token",
+				UserInfoEndpoint:      server.URL + "/user",
+				UserInfoMapping: &config.OAuthSSOUserInfoMappingConfig{
+					Email:         "/emails/0/email",
+					EmailVerified: "/emails/0/verified",
+					Name:          "/login",
+				},
+			},
+			Credentials: config.OAuthClientCredentialsItem{ClientSecret: "client-secret"},
+			UserInfoDecoder: UserInfoDecoder{
+				LoginIDNormalizerFactory: mockLoginIDNormalizerFactory{},
+			},
+		}
+
+		Convey("should build authorization URL", func() {
+			u, err := impl.GetAuthURL(GetAuthURLParam{State: "state"})
+			So(err, ShouldBeNil)
+
+			parsed, err := url.Parse(u)
+			So(err, ShouldBeNil)
+			So(parsed.Path, ShouldEqual, "/authorize")
+			q := parsed.Query()
+			So(q.Get("client_id"), ShouldEqual, "client-id")
+			So(q.Get("state"), ShouldEqual, "state")
+			So(q["scope"], ShouldBeNil)
+
+			impl.ProviderConfig.Scopes = []string{"read:user", "user:email"}
+			u, err = impl.GetAuthURL(GetAuthURLParam{State: "state"})
+			So(err, ShouldBeNil)
+			parsed, err = url.Parse(u)
+			So(err, ShouldBeNil)
+			So(parsed.Query().Get("scope"), ShouldEqual, "read:user user:email")
+		})
+
+		Convey("should map userinfo", func() {
+			authInfo, err := impl.GetAuthInfo(OAuthAuthorizationResponse{Code: "code"}, GetAuthInfoParam{})
+			So(err, ShouldBeNil)
+			So(authInfo.ProviderUserInfo, ShouldResemble, ProviderUserInfo{
+				ID:    "1234567",
+				Email: "octocat@example.com",
+				Name:  "octocat",
+			})
+			So(authInfo.ProviderRawProfile, ShouldResemble, userInfo)
+		})
+
+		Convey("should discard unverified email", func() {
+			userInfo["emails"].([]interface{})[0].(map[string]interface{})["verified"] = false
+			authInfo, err := impl.GetAuthInfo(OAuthAuthorizationResponse{Code: "code"}, GetAuthInfoParam{})
+			So(err, ShouldBeNil)
+			So(authInfo.ProviderUserInfo.Email, ShouldEqual, "")
+		})
+
+		Convey("should reject userinfo without subject", func() {
+			impl.ProviderConfig.UserInfoMapping.Subject = "/uid"
+			_, err := impl.GetAuthInfo(OAuthAuthorizationResponse{Code: "code"}, GetAuthInfoParam{})
+			So(err, ShouldBeError, "no subject in userinfo")
+		})
+	})
+}
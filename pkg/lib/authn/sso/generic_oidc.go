@@ -0,0 +1,172 @@
+package sso
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+)
+
+// GenericOIDCImpl is an OpenID Connect provider configured with its issuer,
+// e.g. Okta, Keycloak and Auth0.
+type GenericOIDCImpl struct {
+	Clock                    clock.Clock
+	RedirectURL              RedirectURLProvider
+	ProviderConfig           config.OAuthSSOProviderConfig
+	Credentials              config.OAuthClientCredentialsItem
+	LoginIDNormalizerFactory LoginIDNormalizerFactory
+	Cache                    *OIDCCache
+}
+
+func (f *GenericOIDCImpl) discoveryURL() string {
+	if f.ProviderConfig.DiscoveryURL != "" {
+		return f.ProviderConfig.DiscoveryURL
+	}
+	return strings.TrimSuffix(f.ProviderConfig.Issuer, "/") + "/.well-known/openid-configuration"
+}
+
+func (f *GenericOIDCImpl) getOpenIDConfiguration() (*OIDCDiscoveryDocument, error) {
+	c := f.ProviderConfig
+	d := &OIDCDiscoveryDocument{
+		AuthorizationEndpoint: c.AuthorizationEndpoint,
+		TokenEndpoint:         c.TokenEndpoint,
+		JWKSUri:               c.JWKSURI,
+	}
+	if d.AuthorizationEndpoint != "" && d.TokenEndpoint != "" && d.JWKSUri != "" {
+		return d, nil
+	}
+
+	discovered, err := f.Cache.FetchDiscoveryDocument(http.DefaultClient, f.Clock.NowUTC(), f.discoveryURL())
+	if err != nil {
+		return nil, err
+	}
+
+	// Explicit endpoints take precedence over the discovered ones.
+	if d.AuthorizationEndpoint == "" {
+		d.AuthorizationEndpoint = discovered.AuthorizationEndpoint
+	}
+	if d.TokenEndpoint == "" {
+		d.TokenEndpoint = discovered.TokenEndpoint
+	}
+	if d.JWKSUri == "" {
+		d.JWKSUri = discovered.JWKSUri
+	}
+	return d, nil
+}
+
+func (*GenericOIDCImpl) Type() config.OAuthSSOProviderType {
+	return config.OAuthSSOProviderTypeOIDC
+}
+
+func (f *GenericOIDCImpl) Config() config.OAuthSSOProviderConfig {
+	return f.ProviderConfig
+}
+
+func (f *GenericOIDCImpl) GetAuthURL(param GetAuthURLParam) (string, error) {
+	d, err := f.getOpenIDConfiguration()
+	if err != nil {
+		return "", err
+	}
+	return d.MakeOAuthURL(OIDCAuthParams{
+		ProviderConfig: f.ProviderConfig,
+		RedirectURI:    f.RedirectURL.SSOCallbackURL(f.ProviderConfig).String(),
+		Nonce:          param.Nonce,
+		State:          param.State,
+	}), nil
+}
+
+func (f *GenericOIDCImpl) GetAuthInfo(r OAuthAuthorizationResponse, param GetAuthInfoParam) (authInfo AuthInfo, err error) {
+	return f.OpenIDConnectGetAuthInfo(r, param)
+}
+
+func (f *GenericOIDCImpl) OpenIDConnectGetAuthInfo(r OAuthAuthorizationResponse, param GetAuthInfoParam) (authInfo AuthInfo, err error) {
+	d, err := f.getOpenIDConfiguration()
+	if err != nil {
+		err = NewSSOFailed(NetworkFailed, "failed to get OIDC discovery document")
+		return
+	}
+	keySet, err := f.Cache.FetchJWKs(http.DefaultClient, f.Clock.NowUTC(), d)
+	if err != nil {
+		err = NewSSOFailed(NetworkFailed, "failed to get OIDC JWKs")
+		return
+	}
+
+	var tokenResp AccessTokenResp
+	jwtToken, err := d.ExchangeCode(
+		http.DefaultClient,
+		f.Clock,
+		r.Code,
+		keySet,
+		f.ProviderConfig.ClientID,
+		f.Credentials.ClientSecret,
+		f.RedirectURL.SSOCallbackURL(f.ProviderConfig).String(),
+		param.Nonce,
+		&tokenResp,
+	)
+	if err != nil {
+		return
+	}
+
+	claims, err := jwtToken.AsMap(context.TODO())
+	if err != nil {
+		return
+	}
+
+	// Verify the issuer
+	// https://openid.net/specs/openid-connect-core-1_0.html#IDTokenValidation
+	iss, ok := claims["iss"].(string)
+	if !ok || strings.TrimSuffix(iss, "/") != strings.TrimSuffix(f.ProviderConfig.Issuer, "/") {
+		err = NewSSOFailed(SSOUnauthorized, "invalid iss")
+		return
+	}
+
+	mapping := f.ProviderConfig.ClaimMapping
+	sub, ok := claims[mapping.SubjectClaim()].(string)
+	if !ok || sub == "" {
+		err = NewSSOFailed(SSOUnauthorized, "no sub")
+		return
+	}
+
+	// The email is discarded unless the provider tells it is verified.
+	email, _ := claims[mapping.EmailClaim()].(string)
+	if !isClaimTrue(claims[mapping.EmailVerifiedClaim()]) {
+		email = ""
+	}
+	if email != "" {
+		normalizer := f.LoginIDNormalizerFactory.NormalizerWithLoginIDType(config.LoginIDKeyTypeEmail)
+		email, err = normalizer.Normalize(email)
+		if err != nil {
+			return
+		}
+	}
+
+	authInfo.ProviderConfig = f.ProviderConfig
+	authInfo.ProviderRawProfile = claims
+	authInfo.ProviderAccessTokenResp = tokenResp
+	authInfo.ProviderUserInfo = ProviderUserInfo{
+		ID:    sub,
+		Email: email,
+	}
+
+	return
+}
+
+// isClaimTrue reports whether the boolean claim is true. Some providers
+// encode boolean claims as strings.
+func isClaimTrue(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	default:
+		return false
+	}
+}
+
+var (
+	_ OAuthProvider         = &GenericOIDCImpl{}
+	_ OpenIDConnectProvider = &GenericOIDCImpl{}
+)
@@ -0,0 +1,226 @@
+package sso
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jwk"
+	"github.com/lestrrat-go/jwx/jwt"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/lib/authn/identity/loginid"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+	"github.com/authgear/authgear-server/pkg/util/jwtutil"
+)
+
+type mockRedirectURLProvider struct{}
+
+func (mockRedirectURLProvider) SSOCallbackURL(providerConfig config.OAuthSSOProviderConfig) *url.URL {
+	return &url.URL{Scheme: "https", Host: "auth.example.com", Path: "/sso/oauth2/callback/" + providerConfig.Alias}
+}
+
+type mockLoginIDNormalizerFactory struct{}
+
+func (mockLoginIDNormalizerFactory) NormalizerWithLoginIDType(loginIDKeyType config.LoginIDKeyType) loginid.Normalizer {
+	return &loginid.NullNormalizer{}
+}
+
+// stubIdP is a minimal OpenID Connect provider issuing ID tokens with the
+// given claims.
+type stubIdP struct {
+	Server          *httptest.Server
+	Key             jwk.Key
+	Claims          map[string]interface{}
+	DiscoveryHits   int
+	JWKSHits        int
+	TokenRequestURL url.Values
+}
+
+func newStubIdP() *stubIdP {
+	// nolint: gosec
+	privKey, err := rsa.GenerateKey(rand.Reader, 512)
+	if err != nil {
+		panic(err)
+	}
+	key, _ := jwk.New(privKey)
+	_ = key.Set(jwk.KeyIDKey, "mykey")
+	pubKey, _ := jwk.New(&privKey.PublicKey)
+	_ = pubKey.Set(jwk.KeyIDKey, "mykey")
+	_ = pubKey.Set(jwk.AlgorithmKey, jwa.RS256)
+
+	idp := &stubIdP{Key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(rw http.ResponseWriter, r *http.Request) {
+		idp.DiscoveryHits++
+		_ = json.NewEncoder(rw).Encode(map[string]interface{}{
+			"issuer":                 idp.Server.URL,
+			"authorization_endpoint": idp.Server.URL + "/authorize",
+			"token_endpoint":         idp.Server.URL + "/token",
+			"jwks_uri":               idp.Server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(rw http.ResponseWriter, r *http.Request) {
+		idp.JWKSHits++
+		_ = json.NewEncoder(rw).Encode(jwk.Set{Keys: []jwk.Key{pubKey}})
+	})
+	mux.HandleFunc("/token", func(rw http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		idp.TokenRequestURL = r.PostForm
+
+		token := jwt.New()
+		for k, v := range idp.Claims {
+			_ = token.Set(k, v)
+		}
+		signed, err := jwtutil.Sign(token, jwa.RS256, idp.Key)
+		if err != nil {
+			panic(err)
+		}
+		_ = json.NewEncoder(rw).Encode(map[string]interface{}{
+			"access_token": "access-token",
+			"token_type":   "Bearer",
+			"id_token":     string(signed),
+		})
+	})
+	idp.Server = httptest.NewServer(mux)
+	return idp
+}
+
+func TestGenericOIDC(t *testing.T) {
+	Convey("GenericOIDCImpl", t, func() {
+		idp := newStubIdP()
+		defer idp.Server.Close()
+
+		now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		clk := &clock.MockClock{Time: now}
+		impl := &GenericOIDCImpl{
+			Clock:       clk,
+			RedirectURL: mockRedirectURLProvider{},
+			ProviderConfig: config.OAuthSSOProviderConfig{
+				Alias:    "okta",
+				Type:     config.OAuthSSOProviderTypeOIDC,
+				ClientID: "client-id",
+				Issuer:   idp.Server.URL,
+				Scopes:   []string{"openid", "groups"},
+			},
+			Credentials:              config.OAuthClientCredentialsItem{ClientSecret: "client-secret"},
+			LoginIDNormalizerFactory: mockLoginIDNormalizerFactory{},
+			Cache:                    NewOIDCCache(),
+		}
+
+		idp.Claims = map[string]interface{}{
+			"iss":            idp.Server.URL,
+			"aud":            "client-id",
+			"sub":            "user-1",
+			"email":          "user@example.com",
+			"email_verified": true,
+			"mail":           "mail@example.com",
+			"uid":            "uid-1",
+			"nonce":          "nonce",
+			"iat":            now.Unix(),
+			"exp":            now.Add(5 * time.Minute).Unix(),
+		}
+
+		Convey("should build authorization URL from discovery document", func() {
+			u, err := impl.GetAuthURL(GetAuthURLParam{Nonce: "nonce", State: "state"})
+			So(err, ShouldBeNil)
+
+			parsed, err := url.Parse(u)
+			So(err, ShouldBeNil)
+			So(parsed.Path, ShouldEqual, "/authorize")
+			q := parsed.Query()
+			So(q.Get("client_id"), ShouldEqual, "client-id")
+			So(q.Get("scope"), ShouldEqual, "openid groups")
+			So(q.Get("nonce"), ShouldEqual, "nonce")
+			So(q.Get("state"), ShouldEqual, "state")
+			So(q.Get("redirect_uri"), ShouldEqual, "https://auth.example.com/sso/oauth2/callback/okta")
+		})
+
+		Convey("should use explicit endpoints without discovery", func() {
+			impl.ProviderConfig.AuthorizationEndpoint = "https://idp.example.com/oauth2/authorize"
+			impl.ProviderConfig.TokenEndpoint = idp.Server.URL + "/token"
+			impl.ProviderConfig.JWKSURI = idp.Server.URL + "/jwks"
+
+			u, err := impl.GetAuthURL(GetAuthURLParam{Nonce: "nonce", State: "state"})
+			So(err, ShouldBeNil)
+			So(u, ShouldStartWith, "https://idp.example.com/oauth2/authorize?")
+			So(idp.DiscoveryHits, ShouldEqual, 0)
+		})
+
+		Convey("should verify ID token and extract user info", func() {
+			authInfo, err := impl.GetAuthInfo(OAuthAuthorizationResponse{Code: "code"}, GetAuthInfoParam{Nonce: "nonce"})
+			So(err, ShouldBeNil)
+			So(authInfo.ProviderUserInfo, ShouldResemble, ProviderUserInfo{
+				ID:    "user-1",
+				Email: "user@example.com",
+			})
+			So(idp.TokenRequestURL.Get("code"), ShouldEqual, "code")
+			So(idp.TokenRequestURL.Get("client_secret"), ShouldEqual, "client-secret")
+		})
+
+		Convey("should discard unverified email", func() {
+			idp.Claims["email_verified"] = false
+			authInfo, err := impl.GetAuthInfo(OAuthAuthorizationResponse{Code: "code"}, GetAuthInfoParam{Nonce: "nonce"})
+			So(err, ShouldBeNil)
+			So(authInfo.ProviderUserInfo, ShouldResemble, ProviderUserInfo{ID: "user-1"})
+
+			delete(idp.Claims, "email_verified")
+			authInfo, err = impl.GetAuthInfo(OAuthAuthorizationResponse{Code: "code"}, GetAuthInfoParam{Nonce: "nonce"})
+			So(err, ShouldBeNil)
+			So(authInfo.ProviderUserInfo, ShouldResemble, ProviderUserInfo{ID: "user-1"})
+
+			idp.Claims["email_verified"] = "true"
+			authInfo, err = impl.GetAuthInfo(OAuthAuthorizationResponse{Code: "code"}, GetAuthInfoParam{Nonce: "nonce"})
+			So(err, ShouldBeNil)
+			So(authInfo.ProviderUserInfo.Email, ShouldEqual, "user@example.com")
+		})
+
+		Convey("should apply claim mapping", func() {
+			impl.ProviderConfig.ClaimMapping = &config.OAuthSSOClaimMappingConfig{
+				Subject: "uid",
+				Email:   "mail",
+			}
+			authInfo, err := impl.GetAuthInfo(OAuthAuthorizationResponse{Code: "code"}, GetAuthInfoParam{Nonce: "nonce"})
+			So(err, ShouldBeNil)
+			So(authInfo.ProviderUserInfo, ShouldResemble, ProviderUserInfo{
+				ID:    "uid-1",
+				Email: "mail@example.com",
+			})
+		})
+
+		Convey("should cache discovery document and JWKs", func() {
+			_, err := impl.GetAuthInfo(OAuthAuthorizationResponse{Code: "code"}, GetAuthInfoParam{Nonce: "nonce"})
+			So(err, ShouldBeNil)
+			_, err = impl.GetAuthInfo(OAuthAuthorizationResponse{Code: "code"}, GetAuthInfoParam{Nonce: "nonce"})
+			So(err, ShouldBeNil)
+			So(idp.DiscoveryHits, ShouldEqual, 1)
+			So(idp.JWKSHits, ShouldEqual, 1)
+
+			clk.Time = now.Add(OIDCCacheTTL)
+			idp.Claims["iat"] = clk.Time.Unix()
+			idp.Claims["exp"] = clk.Time.Add(5 * time.Minute).Unix()
+			_, err = impl.GetAuthInfo(OAuthAuthorizationResponse{Code: "code"}, GetAuthInfoParam{Nonce: "nonce"})
+			So(err, ShouldBeNil)
+			So(idp.DiscoveryHits, ShouldEqual, 2)
+			So(idp.JWKSHits, ShouldEqual, 2)
+		})
+
+		Convey("should reject mismatched nonce", func() {
+			_, err := impl.GetAuthInfo(OAuthAuthorizationResponse{Code: "code"}, GetAuthInfoParam{Nonce: "other"})
+			So(err, ShouldBeError, "invalid nonce")
+		})
+
+		Convey("should reject mismatched issuer", func() {
+			idp.Claims["iss"] = "https://evil.example.com"
+			_, err := impl.GetAuthInfo(OAuthAuthorizationResponse{Code: "code"}, GetAuthInfoParam{Nonce: "nonce"})
+			So(err, ShouldBeError, "invalid iss")
+		})
+	})
+}
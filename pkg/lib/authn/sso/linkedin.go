@@ -31,7 +31,7 @@ func (f *LinkedInImpl) GetAuthURL(param GetAuthURLParam) (string, error) {
 	p := authURLParams{
 		redirectURI: f.RedirectURL.SSOCallbackURL(f.ProviderConfig).String(),
 		clientID:    f.ProviderConfig.ClientID,
-		scope:       f.ProviderConfig.Type.Scope(),
+		scope:       f.ProviderConfig.Scope(),
 		state:       param.State,
 		baseURL:     linkedinAuthorizationURL,
 	}
@@ -69,7 +69,7 @@ func (f *LinkedInImpl) NonOpenIDConnectGetAuthInfo(r OAuthAuthorizationResponse,
 		"primary_contact": contactResponse,
 	}
 
-	providerUserInfo, err := f.UserInfoDecoder.DecodeUserInfo(f.ProviderConfig.Type, combinedResponse)
+	providerUserInfo, err := f.UserInfoDecoder.DecodeUserInfo(f.ProviderConfig, combinedResponse)
 	if err != nil {
 		return
 	}
@@ -29,6 +29,7 @@ type OAuthProvider interface {
 // They are
 // "facebook"
 // "linkedin"
+// "oauth2"
 type NonOpenIDConnectProvider interface {
 	NonOpenIDConnectGetAuthInfo(r OAuthAuthorizationResponse, param GetAuthInfoParam) (authInfo AuthInfo, err error)
 }
@@ -38,6 +39,7 @@ type NonOpenIDConnectProvider interface {
 // "google"
 // "apple"
 // "azureadv2"
+// "oidc"
 type OpenIDConnectProvider interface {
 	OpenIDConnectGetAuthInfo(r OAuthAuthorizationResponse, param GetAuthInfoParam) (authInfo AuthInfo, err error)
 }
@@ -54,6 +56,7 @@ type OAuthProviderFactory struct {
 	Clock                    clock.Clock
 	UserInfoDecoder          UserInfoDecoder
 	LoginIDNormalizerFactory LoginIDNormalizerFactory
+	OIDCCache                *OIDCCache
 }
 
 func (p *OAuthProviderFactory) NewOAuthProvider(alias string) OAuthProvider {
@@ -105,6 +108,22 @@ func (p *OAuthProviderFactory) NewOAuthProvider(alias string) OAuthProvider {
 			Credentials:              *credentials,
 			LoginIDNormalizerFactory: p.LoginIDNormalizerFactory,
 		}
+	case config.OAuthSSOProviderTypeOIDC:
+		return &GenericOIDCImpl{
+			Clock:                    p.Clock,
+			RedirectURL:              p.RedirectURL,
+			ProviderConfig:           *providerConfig,
+			Credentials:              *credentials,
+			LoginIDNormalizerFactory: p.LoginIDNormalizerFactory,
+			Cache:                    p.OIDCCache,
+		}
+	case config.OAuthSSOProviderTypeOAuth2:
+		return &GenericOAuth2Impl{
+			RedirectURL:     p.RedirectURL,
+			ProviderConfig:  *providerConfig,
+			Credentials:     *credentials,
+			UserInfoDecoder: p.UserInfoDecoder,
+		}
 	}
 	return nil
 }
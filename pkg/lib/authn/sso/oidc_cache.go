@@ -0,0 +1,74 @@
+package sso
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/lestrrat-go/jwx/jwk"
+)
+
+// OIDCCacheTTL is the duration for which discovery documents and JWKs are
+// cached. Providers are expected to publish new signing keys before using
+// them, so that they are picked up within the TTL.
+const OIDCCacheTTL = 1 * time.Hour
+
+type oidcCacheItem struct {
+	Value    interface{}
+	ExpireAt time.Time
+}
+
+// OIDCCache caches discovery documents and JWKs of OpenID Connect providers.
+// It is shared by all apps, so that the documents are not fetched in every
+// authentication.
+type OIDCCache struct {
+	mutex sync.Mutex
+	items map[string]oidcCacheItem
+}
+
+func NewOIDCCache() *OIDCCache {
+	return &OIDCCache{
+		items: make(map[string]oidcCacheItem),
+	}
+}
+
+func (c *OIDCCache) FetchDiscoveryDocument(client *http.Client, now time.Time, endpoint string) (*OIDCDiscoveryDocument, error) {
+	value, err := c.get("discovery:"+endpoint, now, func() (interface{}, error) {
+		return FetchOIDCDiscoveryDocument(client, endpoint)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*OIDCDiscoveryDocument), nil
+}
+
+func (c *OIDCCache) FetchJWKs(client *http.Client, now time.Time, d *OIDCDiscoveryDocument) (*jwk.Set, error) {
+	value, err := c.get("jwks:"+d.JWKSUri, now, func() (interface{}, error) {
+		return d.FetchJWKs(client)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*jwk.Set), nil
+}
+
+func (c *OIDCCache) get(key string, now time.Time, fetch func() (interface{}, error)) (interface{}, error) {
+	c.mutex.Lock()
+	item, ok := c.items[key]
+	c.mutex.Unlock()
+	if ok && now.Before(item.ExpireAt) {
+		return item.Value, nil
+	}
+
+	// Fetch without holding the lock; concurrent fetches are harmless.
+	value, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	c.items[key] = oidcCacheItem{Value: value, ExpireAt: now.Add(OIDCCacheTTL)}
+	c.mutex.Unlock()
+
+	return value, nil
+}
@@ -2,6 +2,9 @@ package sso
 
 import (
 	"fmt"
+	"strconv"
+
+	"github.com/iawaknahc/jsonschema/pkg/jsonpointer"
 
 	"github.com/authgear/authgear-server/pkg/lib/config"
 )
@@ -10,8 +13,8 @@ type UserInfoDecoder struct {
 	LoginIDNormalizerFactory LoginIDNormalizerFactory
 }
 
-func (d *UserInfoDecoder) DecodeUserInfo(providerType config.OAuthSSOProviderType, userInfo map[string]interface{}) (providerUserInfo *ProviderUserInfo, err error) {
-	switch providerType {
+func (d *UserInfoDecoder) DecodeUserInfo(providerConfig config.OAuthSSOProviderConfig, userInfo map[string]interface{}) (providerUserInfo *ProviderUserInfo, err error) {
+	switch providerType := providerConfig.Type; providerType {
 	case config.OAuthSSOProviderTypeGoogle:
 		providerUserInfo = DecodeDefault(userInfo)
 	case config.OAuthSSOProviderTypeFacebook:
@@ -22,6 +25,11 @@ func (d *UserInfoDecoder) DecodeUserInfo(providerType config.OAuthSSOProviderTyp
 		providerUserInfo = DecodeAzureADv2(userInfo)
 	case config.OAuthSSOProviderTypeApple:
 		providerUserInfo = DecodeApple(userInfo)
+	case config.OAuthSSOProviderTypeOAuth2:
+		providerUserInfo, err = DecodeWithMapping(providerConfig.UserInfoMapping, userInfo)
+		if err != nil {
+			return
+		}
 	default:
 		panic(fmt.Sprintf("sso: unknown provider type: %v", providerType))
 	}
@@ -96,3 +104,51 @@ func DecodeLinkedIn(userInfo map[string]interface{}) *ProviderUserInfo {
 		Email: email,
 	}
 }
+
+// DecodeWithMapping decodes userInfo with the JSON pointers in mapping.
+// The email is discarded if the provider tells it is not verified.
+func DecodeWithMapping(mapping *config.OAuthSSOUserInfoMappingConfig, userInfo map[string]interface{}) (*ProviderUserInfo, error) {
+	id := stringAt(mapping.SubjectPointer(), userInfo)
+	if id == "" {
+		return nil, NewSSOFailed(SSOUnauthorized, "no subject in userinfo")
+	}
+
+	email := stringAt(mapping.EmailPointer(), userInfo)
+	if ptr := mapping.EmailVerifiedPointer(); ptr != "" {
+		verified, _ := valueAt(ptr, userInfo).(bool)
+		if !verified {
+			email = ""
+		}
+	}
+
+	return &ProviderUserInfo{
+		ID:    id,
+		Email: email,
+		Name:  stringAt(mapping.NamePointer(), userInfo),
+	}, nil
+}
+
+func valueAt(pointer string, userInfo map[string]interface{}) interface{} {
+	ptr, err := jsonpointer.Parse(pointer)
+	if err != nil {
+		return nil
+	}
+	value, err := ptr.Traverse(userInfo)
+	if err != nil {
+		return nil
+	}
+	return value
+}
+
+// stringAt returns the string at pointer. Numbers are formatted since
+// some providers, e.g. GitHub, use numeric user IDs.
+func stringAt(pointer string, userInfo map[string]interface{}) string {
+	switch v := valueAt(pointer, userInfo).(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return ""
+	}
+}
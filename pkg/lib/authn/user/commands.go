@@ -6,6 +6,7 @@ import (
 	"github.com/authgear/authgear-server/pkg/api/event"
 	"github.com/authgear/authgear-server/pkg/api/model"
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity"
+	"github.com/authgear/authgear-server/pkg/lib/config"
 )
 
 type HookProvider interface {
@@ -13,9 +14,10 @@ type HookProvider interface {
 }
 
 type Commands struct {
-	Raw          *RawCommands
-	Hooks        HookProvider
-	Verification VerificationService
+	Raw               *RawCommands
+	Hooks             HookProvider
+	Verification      VerificationService
+	UserProfileConfig *config.UserProfileConfig
 }
 
 func (c *Commands) Create(userID string) (*User, error) {
@@ -31,7 +33,7 @@ func (c *Commands) AfterCreate(
 		return err
 	}
 
-	userModel := newUserModel(user, identities, isVerified)
+	userModel := newUserModel(user, identities, isVerified, c.Raw.Clock.NowUTC())
 	var identityModels []model.Identity
 	for _, i := range identities {
 		identityModels = append(identityModels, i.ToModel())
@@ -55,3 +57,69 @@ func (c *Commands) AfterCreate(
 func (c *Commands) UpdateLoginTime(user *model.User, loginAt gotime.Time) error {
 	return c.Raw.UpdateLoginTime(user, loginAt)
 }
+
+// Disable disables the user until the given time, or indefinitely if until
+// is nil. Sessions of disabled users are invalidated when they are used.
+func (c *Commands) Disable(userID string, reason *string, until *gotime.Time) error {
+	err := c.Raw.UpdateDisabledStatus(userID, true, reason, until)
+	if err != nil {
+		return err
+	}
+
+	user, err := c.Raw.Queries.Get(userID)
+	if err != nil {
+		return err
+	}
+
+	return c.Hooks.DispatchEvent(&event.UserDisabledEvent{
+		User:          *user,
+		Reason:        reason,
+		DisabledUntil: until,
+	})
+}
+
+// Reenable re-enables the disabled user.
+func (c *Commands) Reenable(userID string) error {
+	err := c.Raw.UpdateDisabledStatus(userID, false, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	user, err := c.Raw.Queries.Get(userID)
+	if err != nil {
+		return err
+	}
+
+	return c.Hooks.DispatchEvent(&event.UserReenabledEvent{
+		User: *user,
+	})
+}
+
+// UpdateProfile replaces the profile of the user. The profile must conform to
+// the user profile schema of the app.
+func (c *Commands) UpdateProfile(userID string, profile map[string]interface{}, reason event.UserUpdateReason) error {
+	if profile == nil {
+		profile = make(map[string]interface{})
+	}
+
+	err := c.UserProfileConfig.Validator().ValidateValueWithMessage(profile, "invalid user profile")
+	if err != nil {
+		return err
+	}
+
+	user, err := c.Raw.Queries.Get(userID)
+	if err != nil {
+		return err
+	}
+
+	err = c.Hooks.DispatchEvent(&event.UserUpdateEvent{
+		Reason:  reason,
+		Profile: profile,
+		User:    *user,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Raw.UpdateProfile(userID, profile)
+}
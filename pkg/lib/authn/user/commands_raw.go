@@ -24,6 +24,7 @@ func (c *RawCommands) New(userID string) *User {
 	user := &User{
 		ID:          userID,
 		Labels:      make(map[string]interface{}),
+		Profile:     make(map[string]interface{}),
 		CreatedAt:   now,
 		UpdatedAt:   now,
 		LastLoginAt: nil,
@@ -51,6 +52,22 @@ func (c *RawCommands) AfterCreate(userModel *model.User, identities []*identity.
 	return nil
 }
 
+func (c *RawCommands) UpdateDisabledStatus(userID string, isDisabled bool, reason *string, until *time.Time) error {
+	return c.Store.UpdateDisabledStatus(userID, isDisabled, reason, until)
+}
+
+func (c *RawCommands) UpdateProfile(userID string, profile map[string]interface{}) error {
+	return c.Store.UpdateProfile(userID, profile, c.Clock.NowUTC())
+}
+
+func (c *RawCommands) UpdateDeleteAt(userID string, deleteAt *time.Time) error {
+	return c.Store.UpdateDeleteAt(userID, deleteAt)
+}
+
+func (c *RawCommands) Delete(userID string) error {
+	return c.Store.Delete(userID)
+}
+
 func (c *RawCommands) UpdateLoginTime(user *model.User, loginAt time.Time) error {
 	err := c.Store.UpdateLoginTime(user.ID, loginAt)
 	if err != nil {
@@ -1,5 +1,23 @@
 package user
 
-import "errors"
+import (
+	"errors"
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+)
 
 var ErrUserNotFound = errors.New("user not found")
+
+var UserDisabled = apierrors.Forbidden.WithReason("UserDisabled")
+
+func ErrDisabled(reason *string, until *time.Time) error {
+	details := apierrors.Details{}
+	if reason != nil {
+		details["reason"] = *reason
+	}
+	if until != nil {
+		details["until"] = *until
+	}
+	return UserDisabled.NewWithInfo("user is disabled", details)
+}
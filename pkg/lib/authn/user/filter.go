@@ -0,0 +1,138 @@
+package user
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/lib/authn"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+)
+
+// Filter filters the users in query.
+type Filter struct {
+	// SearchKeyword matches login ID by prefix, OAuth subject exactly,
+	// and user ID by prefix.
+	SearchKeyword   string
+	CreatedAfter    *time.Time
+	CreatedBefore   *time.Time
+	LastLoginAfter  *time.Time
+	LastLoginBefore *time.Time
+	// HasVerifiedClaim matches users having any verified claim record,
+	// regardless of the verification criteria and of whether the claim
+	// still belongs to an identity of the user. It therefore differs from
+	// the is_verified status of the user.
+	HasVerifiedClaim *bool
+	IsAnonymous      *bool
+	IsDisabled       *bool
+	Labels           map[string]interface{}
+}
+
+type SortBy string
+
+const (
+	SortByCreatedAt   SortBy = "created_at"
+	SortByLastLoginAt SortBy = "last_login_at"
+)
+
+type SortDirection string
+
+const (
+	SortDirectionAsc  SortDirection = "asc"
+	SortDirectionDesc SortDirection = "desc"
+)
+
+// Sort is the sort order of the users in query. The zero value sorts by
+// creation time in ascending order.
+type Sort struct {
+	By        SortBy
+	Direction SortDirection
+}
+
+func (s Sort) pageConfig() db.QueryPageConfig {
+	by := s.By
+	if by == "" {
+		by = SortByCreatedAt
+	}
+
+	keyColumn := "u." + string(by)
+	idColumn := "u.id"
+	if s.Direction == SortDirectionDesc {
+		keyColumn += " DESC NULLS LAST"
+		idColumn += " DESC"
+	} else {
+		keyColumn += " ASC NULLS LAST"
+	}
+
+	return db.QueryPageConfig{
+		KeyColumn: keyColumn,
+		IDColumn:  idColumn,
+	}
+}
+
+// escapeLike escapes the wildcards of LIKE patterns.
+func escapeLike(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `%`, `\%`)
+	s = strings.ReplaceAll(s, `_`, `\_`)
+	return s
+}
+
+func (s *Store) applyFilter(builder db.SelectBuilder, filter Filter) (db.SelectBuilder, error) {
+	if keyword := strings.TrimSpace(filter.SearchKeyword); keyword != "" {
+		prefix := escapeLike(keyword) + "%"
+		builder = builder.Where(
+			"(u.id LIKE ? OR EXISTS ("+
+				"SELECT 1 FROM "+s.SQLBuilder.FullTableName("identity")+" AS i "+
+				"JOIN "+s.SQLBuilder.FullTableName("identity_login_id")+" AS li ON li.id = i.id "+
+				"WHERE i.user_id = u.id AND li.app_id = u.app_id AND lower(li.login_id) LIKE ?"+
+				") OR EXISTS ("+
+				"SELECT 1 FROM "+s.SQLBuilder.FullTableName("identity")+" AS i "+
+				"JOIN "+s.SQLBuilder.FullTableName("identity_oauth")+" AS o ON o.id = i.id "+
+				"WHERE i.user_id = u.id AND o.app_id = u.app_id AND o.provider_user_id = ?"+
+				"))",
+			prefix,
+			strings.ToLower(prefix),
+			keyword,
+		)
+	}
+	if filter.CreatedAfter != nil {
+		builder = builder.Where("u.created_at >= ?", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		builder = builder.Where("u.created_at < ?", *filter.CreatedBefore)
+	}
+	if filter.LastLoginAfter != nil {
+		builder = builder.Where("u.last_login_at >= ?", *filter.LastLoginAfter)
+	}
+	if filter.LastLoginBefore != nil {
+		builder = builder.Where("u.last_login_at < ?", *filter.LastLoginBefore)
+	}
+	if filter.HasVerifiedClaim != nil {
+		pred := "EXISTS (SELECT 1 FROM " + s.SQLBuilder.FullTableName("verified_claim") + " AS vc " +
+			"WHERE vc.user_id = u.id AND vc.app_id = u.app_id)"
+		if !*filter.HasVerifiedClaim {
+			pred = "NOT " + pred
+		}
+		builder = builder.Where(pred)
+	}
+	if filter.IsAnonymous != nil {
+		pred := "EXISTS (SELECT 1 FROM " + s.SQLBuilder.FullTableName("identity") + " AS i " +
+			"WHERE i.user_id = u.id AND i.app_id = u.app_id AND i.type = ?)"
+		if !*filter.IsAnonymous {
+			pred = "NOT " + pred
+		}
+		builder = builder.Where(pred, string(authn.IdentityTypeAnonymous))
+	}
+	if filter.IsDisabled != nil {
+		builder = builder.Where("u.is_disabled = ?", *filter.IsDisabled)
+	}
+	if len(filter.Labels) > 0 {
+		labels, err := json.Marshal(filter.Labels)
+		if err != nil {
+			return builder, err
+		}
+		builder = builder.Where("u.labels @> ?::jsonb", string(labels))
+	}
+	return builder, nil
+}
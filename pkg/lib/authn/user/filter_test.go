@@ -0,0 +1,76 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+)
+
+func TestUserFilter(t *testing.T) {
+	Convey("User filter", t, func() {
+		s := &Store{SQLBuilder: db.NewSQLBuilder("auth", "app", "my-app")}
+		query := func(filter Filter) (string, []interface{}) {
+			builder := s.SQLBuilder.Tenant().Select("u.id").From(s.SQLBuilder.FullTableName("user"), "u")
+			builder, err := s.applyFilter(builder, filter)
+			So(err, ShouldBeNil)
+			sql, args, err := builder.ToSql()
+			So(err, ShouldBeNil)
+			return sql, args
+		}
+
+		Convey("should not filter by default", func() {
+			sql, args := query(Filter{})
+			So(sql, ShouldEqual, `SELECT u.id FROM "app"."_auth_user" AS u WHERE u.app_id = $1`)
+			So(args, ShouldResemble, []interface{}{"my-app"})
+		})
+
+		Convey("should search by keyword", func() {
+			sql, args := query(Filter{SearchKeyword: " User_1@Example.com "})
+			So(sql, ShouldEqual, `SELECT u.id FROM "app"."_auth_user" AS u WHERE u.app_id = $1 AND `+
+				`(u.id LIKE $2 OR EXISTS (SELECT 1 FROM "app"."_auth_identity" AS i `+
+				`JOIN "app"."_auth_identity_login_id" AS li ON li.id = i.id `+
+				`WHERE i.user_id = u.id AND li.app_id = u.app_id AND lower(li.login_id) LIKE $3) `+
+				`OR EXISTS (SELECT 1 FROM "app"."_auth_identity" AS i `+
+				`JOIN "app"."_auth_identity_oauth" AS o ON o.id = i.id `+
+				`WHERE i.user_id = u.id AND o.app_id = u.app_id AND o.provider_user_id = $4))`)
+			So(args, ShouldResemble, []interface{}{
+				"my-app",
+				`User\_1@Example.com%`,
+				`user\_1@example.com%`,
+				"User_1@Example.com",
+			})
+		})
+
+		Convey("should filter by state and time range", func() {
+			after := time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC)
+			hasVerifiedClaim := false
+			isDisabled := true
+			sql, args := query(Filter{
+				LastLoginAfter:   &after,
+				HasVerifiedClaim: &hasVerifiedClaim,
+				IsDisabled:       &isDisabled,
+				Labels:           map[string]interface{}{"tier": "gold"},
+			})
+			So(sql, ShouldEqual, `SELECT u.id FROM "app"."_auth_user" AS u WHERE u.app_id = $1 AND `+
+				`u.last_login_at >= $2 AND `+
+				`NOT EXISTS (SELECT 1 FROM "app"."_auth_verified_claim" AS vc WHERE vc.user_id = u.id AND vc.app_id = u.app_id) AND `+
+				`u.is_disabled = $3 AND `+
+				`u.labels @> $4::jsonb`)
+			So(args, ShouldResemble, []interface{}{"my-app", after, true, `{"tier":"gold"}`})
+		})
+
+		Convey("should sort by column", func() {
+			So(Sort{}.pageConfig(), ShouldResemble, db.QueryPageConfig{
+				KeyColumn: "u.created_at ASC NULLS LAST",
+				IDColumn:  "u.id",
+			})
+			So(Sort{By: SortByLastLoginAt, Direction: SortDirectionDesc}.pageConfig(), ShouldResemble, db.QueryPageConfig{
+				KeyColumn: "u.last_login_at DESC NULLS LAST",
+				IDColumn:  "u.id DESC",
+			})
+		})
+	})
+}
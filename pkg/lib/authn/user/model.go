@@ -11,9 +11,34 @@ import (
 type User struct {
 	ID          string
 	Labels      map[string]interface{}
+	Profile     map[string]interface{}
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 	LastLoginAt *time.Time
+
+	IsDisabled    bool
+	DisableReason *string
+	DisabledUntil *time.Time
+
+	// DeleteAt is the time when the user is scheduled to be deleted.
+	DeleteAt *time.Time
+}
+
+// IsDisabledAt reports whether the user is disabled at now.
+// A disabled user is re-enabled automatically after DisabledUntil.
+func (u *User) IsDisabledAt(now time.Time) bool {
+	if !u.IsDisabled {
+		return false
+	}
+	return u.DisabledUntil == nil || now.Before(*u.DisabledUntil)
+}
+
+// CheckStatus returns UserDisabled error if the user is disabled at now.
+func (u *User) CheckStatus(now time.Time) error {
+	if u.IsDisabledAt(now) {
+		return ErrDisabled(u.DisableReason, u.DisabledUntil)
+	}
+	return nil
 }
 
 func (u *User) GetMeta() model.Meta {
@@ -28,6 +53,7 @@ func newUserModel(
 	user *User,
 	identities []*identity.Info,
 	isVerified bool,
+	now time.Time,
 ) *model.User {
 	isAnonymous := false
 	for _, i := range identities {
@@ -37,7 +63,7 @@ func newUserModel(
 		}
 	}
 
-	return &model.User{
+	m := &model.User{
 		Meta: model.Meta{
 			ID:        user.ID,
 			CreatedAt: user.CreatedAt,
@@ -46,5 +72,12 @@ func newUserModel(
 		LastLoginAt: user.LastLoginAt,
 		IsAnonymous: isAnonymous,
 		IsVerified:  isVerified,
+		Profile:     user.Profile,
+	}
+	if user.IsDisabledAt(now) {
+		m.IsDisabled = true
+		m.DisableReason = user.DisableReason
+		m.DisabledUntil = user.DisabledUntil
 	}
+	return m
 }
@@ -0,0 +1,51 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUserDisabledStatus(t *testing.T) {
+	Convey("User disabled status", t, func() {
+		now := time.Date(2020, 9, 25, 12, 0, 0, 0, time.UTC)
+		reason := "Violation of terms of service"
+
+		Convey("should not be disabled by default", func() {
+			u := &User{ID: "user-id"}
+			So(u.IsDisabledAt(now), ShouldBeFalse)
+			So(u.CheckStatus(now), ShouldBeNil)
+		})
+
+		Convey("should be disabled indefinitely", func() {
+			u := &User{ID: "user-id", IsDisabled: true, DisableReason: &reason}
+			So(u.IsDisabledAt(now), ShouldBeTrue)
+			So(u.CheckStatus(now), ShouldBeError, "user is disabled")
+		})
+
+		Convey("should be re-enabled after disabled until", func() {
+			until := now.Add(time.Hour)
+			u := &User{ID: "user-id", IsDisabled: true, DisabledUntil: &until}
+			So(u.IsDisabledAt(now), ShouldBeTrue)
+			So(u.IsDisabledAt(until.Add(-time.Second)), ShouldBeTrue)
+			So(u.IsDisabledAt(until), ShouldBeFalse)
+			So(u.CheckStatus(until.Add(time.Minute)), ShouldBeNil)
+		})
+
+		Convey("should expose effective status in model", func() {
+			until := now.Add(time.Hour)
+			u := &User{ID: "user-id", IsDisabled: true, DisableReason: &reason, DisabledUntil: &until}
+
+			m := newUserModel(u, nil, false, now)
+			So(m.IsDisabled, ShouldBeTrue)
+			So(*m.DisableReason, ShouldEqual, reason)
+			So(*m.DisabledUntil, ShouldEqual, until)
+
+			m = newUserModel(u, nil, false, until)
+			So(m.IsDisabled, ShouldBeFalse)
+			So(m.DisableReason, ShouldBeNil)
+			So(m.DisabledUntil, ShouldBeNil)
+		})
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/authgear/authgear-server/pkg/api/model"
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity"
 	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	"github.com/authgear/authgear-server/pkg/util/clock"
 )
 
 type IdentityService interface {
@@ -18,6 +19,7 @@ type Queries struct {
 	Store        store
 	Identities   IdentityService
 	Verification VerificationService
+	Clock        clock.Clock
 }
 
 func (p *Queries) Get(id string) (*model.User, error) {
@@ -36,7 +38,7 @@ func (p *Queries) Get(id string) (*model.User, error) {
 		return nil, err
 	}
 
-	return newUserModel(user, identities, isVerified), nil
+	return newUserModel(user, identities, isVerified, p.Clock.NowUTC()), nil
 }
 
 func (p *Queries) GetRaw(id string) (*User, error) {
@@ -47,12 +49,20 @@ func (p *Queries) GetManyRaw(ids []string) ([]*User, error) {
 	return p.Store.GetByIDs(ids)
 }
 
-func (p *Queries) Count() (uint64, error) {
-	return p.Store.Count()
+func (p *Queries) ListIDsToDelete() ([]string, error) {
+	return p.Store.ListIDsToDelete(p.Clock.NowUTC())
 }
 
-func (p *Queries) QueryPage(after, before model.PageCursor, first, last *uint64) ([]model.PageItem, error) {
-	users, offset, err := p.Store.QueryPage(after, before, first, last)
+func (p *Queries) ListRawAfter(afterID string, limit uint64) ([]*User, error) {
+	return p.Store.ListAfter(afterID, limit)
+}
+
+func (p *Queries) Count(filter Filter) (uint64, error) {
+	return p.Store.Count(filter)
+}
+
+func (p *Queries) QueryPage(filter Filter, sort Sort, after, before model.PageCursor, first, last *uint64) ([]model.PageItem, error) {
+	users, offset, err := p.Store.QueryPage(filter, sort, after, before, first, last)
 	if err != nil {
 		return nil, err
 	}
@@ -17,16 +17,17 @@ type store interface {
 	Create(u *User) error
 	Get(userID string) (*User, error)
 	GetByIDs(userIDs []string) ([]*User, error)
-	Count() (uint64, error)
-	QueryPage(after, before model.PageCursor, first, last *uint64) ([]*User, uint64, error)
+	Count(filter Filter) (uint64, error)
+	QueryPage(filter Filter, sort Sort, after, before model.PageCursor, first, last *uint64) ([]*User, uint64, error)
 	UpdateLoginTime(userID string, loginAt time.Time) error
+	UpdateDisabledStatus(userID string, isDisabled bool, reason *string, until *time.Time) error
+	UpdateDeleteAt(userID string, deleteAt *time.Time) error
+	UpdateProfile(userID string, profile map[string]interface{}, updatedAt time.Time) error
+	ListIDsToDelete(now time.Time) ([]string, error)
+	ListAfter(afterID string, limit uint64) ([]*User, error)
+	Delete(userID string) error
 }
 
-var queryPage = db.QueryPage(db.QueryPageConfig{
-	KeyColumn: "created_at",
-	IDColumn:  "id",
-})
-
 type Store struct {
 	SQLBuilder  db.SQLBuilder
 	SQLExecutor db.SQLExecutor
@@ -38,11 +39,17 @@ func (s *Store) Create(u *User) error {
 		return err
 	}
 
+	profile, err := json.Marshal(u.Profile)
+	if err != nil {
+		return err
+	}
+
 	builder := s.SQLBuilder.Tenant().
 		Insert(s.SQLBuilder.FullTableName("user")).
 		Columns(
 			"id",
 			"labels",
+			"profile",
 			"created_at",
 			"updated_at",
 			"last_login_at",
@@ -50,6 +57,7 @@ func (s *Store) Create(u *User) error {
 		Values(
 			u.ID,
 			labels,
+			profile,
 			u.CreatedAt,
 			u.UpdatedAt,
 			u.LastLoginAt,
@@ -68,24 +76,35 @@ func (s *Store) selectQuery() db.SelectBuilder {
 		Select(
 			"id",
 			"labels",
+			"profile",
 			"created_at",
 			"updated_at",
 			"last_login_at",
+			"is_disabled",
+			"disable_reason",
+			"disabled_until",
+			"delete_at",
 		).
-		From(s.SQLBuilder.FullTableName("user"))
+		From(s.SQLBuilder.FullTableName("user"), "u")
 }
 
 func (s *Store) scan(scn db.Scanner) (*User, error) {
 	u := &User{}
 
 	var labels []byte
+	var profile []byte
 
 	if err := scn.Scan(
 		&u.ID,
 		&labels,
+		&profile,
 		&u.CreatedAt,
 		&u.UpdatedAt,
 		&u.LastLoginAt,
+		&u.IsDisabled,
+		&u.DisableReason,
+		&u.DisabledUntil,
+		&u.DeleteAt,
 	); err != nil {
 		return nil, err
 	}
@@ -94,6 +113,10 @@ func (s *Store) scan(scn db.Scanner) (*User, error) {
 		return nil, err
 	}
 
+	if err := json.Unmarshal(profile, &u.Profile); err != nil {
+		return nil, err
+	}
+
 	return u, nil
 }
 
@@ -135,10 +158,15 @@ func (s *Store) GetByIDs(userIDs []string) ([]*User, error) {
 	return users, nil
 }
 
-func (s *Store) Count() (uint64, error) {
+func (s *Store) Count(filter Filter) (uint64, error) {
 	builder := s.SQLBuilder.Tenant().
 		Select("count(*)").
-		From(s.SQLBuilder.FullTableName("user"))
+		From(s.SQLBuilder.FullTableName("user"), "u")
+	builder, err := s.applyFilter(builder, filter)
+	if err != nil {
+		return 0, err
+	}
+
 	scanner, err := s.SQLExecutor.QueryRowWith(builder)
 	if err != nil {
 		return 0, err
@@ -152,7 +180,7 @@ func (s *Store) Count() (uint64, error) {
 	return count, nil
 }
 
-func (s *Store) QueryPage(after, before model.PageCursor, first, last *uint64) ([]*User, uint64, error) {
+func (s *Store) QueryPage(filter Filter, sort Sort, after, before model.PageCursor, first, last *uint64) ([]*User, uint64, error) {
 	afterKey, err := db.NewFromPageCursor(after)
 	if err != nil {
 		return nil, 0, err
@@ -162,8 +190,12 @@ func (s *Store) QueryPage(after, before model.PageCursor, first, last *uint64) (
 		return nil, 0, err
 	}
 
-	selectQuery := s.selectQuery()
+	selectQuery, err := s.applyFilter(s.selectQuery(), filter)
+	if err != nil {
+		return nil, 0, err
+	}
 
+	queryPage := db.QueryPage(sort.pageConfig())
 	query, offset, err := queryPage(selectQuery, afterKey, beforeKey, first, last)
 	if err != nil {
 		return nil, 0, err
@@ -201,3 +233,120 @@ func (s *Store) UpdateLoginTime(userID string, loginAt time.Time) error {
 
 	return nil
 }
+
+func (s *Store) UpdateDisabledStatus(userID string, isDisabled bool, reason *string, until *time.Time) error {
+	builder := s.SQLBuilder.Tenant().
+		Update(s.SQLBuilder.FullTableName("user")).
+		Set("is_disabled", isDisabled).
+		Set("disable_reason", reason).
+		Set("disabled_until", until).
+		Where("id = ?", userID)
+
+	_, err := s.SQLExecutor.ExecWith(builder)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Store) UpdateDeleteAt(userID string, deleteAt *time.Time) error {
+	builder := s.SQLBuilder.Tenant().
+		Update(s.SQLBuilder.FullTableName("user")).
+		Set("delete_at", deleteAt).
+		Where("id = ?", userID)
+
+	_, err := s.SQLExecutor.ExecWith(builder)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Store) UpdateProfile(userID string, profile map[string]interface{}, updatedAt time.Time) error {
+	profileBytes, err := json.Marshal(profile)
+	if err != nil {
+		return err
+	}
+
+	builder := s.SQLBuilder.Tenant().
+		Update(s.SQLBuilder.FullTableName("user")).
+		Set("profile", profileBytes).
+		Set("updated_at", updatedAt).
+		Where("id = ?", userID)
+
+	_, err = s.SQLExecutor.ExecWith(builder)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListIDsToDelete returns the IDs of users scheduled to be deleted at or
+// before now.
+func (s *Store) ListIDsToDelete(now time.Time) ([]string, error) {
+	builder := s.SQLBuilder.Tenant().
+		Select("id").
+		From(s.SQLBuilder.FullTableName("user")).
+		Where("delete_at <= ?", now).
+		OrderBy("delete_at")
+
+	rows, err := s.SQLExecutor.QueryWith(builder)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// ListAfter lists at most limit users with ID greater than afterID, ordered
+// by ID. It is used to iterate all users without being affected by users
+// created or deleted during the iteration.
+func (s *Store) ListAfter(afterID string, limit uint64) ([]*User, error) {
+	builder := s.selectQuery().
+		Where("u.id > ?", afterID).
+		OrderBy("u.id").
+		Limit(limit)
+
+	rows, err := s.SQLExecutor.QueryWith(builder)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*User
+	for rows.Next() {
+		u, err := s.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+
+	return users, nil
+}
+
+func (s *Store) Delete(userID string) error {
+	builder := s.SQLBuilder.Tenant().
+		Delete(s.SQLBuilder.FullTableName("user")).
+		Where("id = ?", userID)
+
+	_, err := s.SQLExecutor.ExecWith(builder)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
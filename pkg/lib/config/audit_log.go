@@ -0,0 +1,21 @@
+package config
+
+var _ = Schema.Add("AuditLogConfig", `
+{
+	"type": "object",
+	"additionalProperties": false,
+	"properties": {
+		"retention_days": { "$ref": "#/$defs/DurationDays" }
+	}
+}
+`)
+
+type AuditLogConfig struct {
+	RetentionDays DurationDays `json:"retention_days,omitempty"`
+}
+
+func (c *AuditLogConfig) SetDefaults() {
+	if c.RetentionDays == 0 {
+		c.RetentionDays = DurationDays(90)
+	}
+}
@@ -26,7 +26,8 @@ var _ = Schema.Add("AuthenticationConfig", `
 		},
 		"secondary_authentication_mode": { "$ref": "#/$defs/SecondaryAuthenticationMode" },
 		"device_token": { "$ref": "#/$defs/DeviceTokenConfig" },
-		"recovery_code": { "$ref": "#/$defs/RecoveryCodeConfig" }
+		"recovery_code": { "$ref": "#/$defs/RecoveryCodeConfig" },
+		"lockout": { "$ref": "#/$defs/AuthenticationLockoutConfig" }
 	}
 }
 `)
@@ -41,24 +42,25 @@ var _ = Schema.Add("IdentityType", `
 var _ = Schema.Add("PrimaryAuthenticatorType", `
 {
 	"type": "string",
-	"enum": ["password", "oob_otp"]
+	"enum": ["password", "oob_otp", "webauthn"]
 }
 `)
 
 var _ = Schema.Add("SecondaryAuthenticatorType", `
 {
 	"type": "string",
-	"enum": ["password", "oob_otp", "totp"]
+	"enum": ["password", "oob_otp", "totp", "webauthn"]
 }
 `)
 
 type AuthenticationConfig struct {
-	Identities                  []authn.IdentityType        `json:"identities,omitempty"`
-	PrimaryAuthenticators       []authn.AuthenticatorType   `json:"primary_authenticators,omitempty"`
-	SecondaryAuthenticators     []authn.AuthenticatorType   `json:"secondary_authenticators,omitempty"`
-	SecondaryAuthenticationMode SecondaryAuthenticationMode `json:"secondary_authentication_mode,omitempty"`
-	DeviceToken                 *DeviceTokenConfig          `json:"device_token,omitempty"`
-	RecoveryCode                *RecoveryCodeConfig         `json:"recovery_code,omitempty"`
+	Identities                  []authn.IdentityType         `json:"identities,omitempty"`
+	PrimaryAuthenticators       []authn.AuthenticatorType    `json:"primary_authenticators,omitempty"`
+	SecondaryAuthenticators     []authn.AuthenticatorType    `json:"secondary_authenticators,omitempty"`
+	SecondaryAuthenticationMode SecondaryAuthenticationMode  `json:"secondary_authentication_mode,omitempty"`
+	DeviceToken                 *DeviceTokenConfig           `json:"device_token,omitempty"`
+	RecoveryCode                *RecoveryCodeConfig          `json:"recovery_code,omitempty"`
+	Lockout                     *AuthenticationLockoutConfig `json:"lockout,omitempty"`
 }
 
 func (c *AuthenticationConfig) SetDefaults() {
@@ -142,3 +144,50 @@ func (c *RecoveryCodeConfig) SetDefaults() {
 		c.Count = 16
 	}
 }
+
+var _ = Schema.Add("AuthenticationLockoutConfig", `
+{
+	"type": "object",
+	"additionalProperties": false,
+	"properties": {
+		"enabled": { "type": "boolean" },
+		"max_attempts": { "type": "integer", "minimum": 1 },
+		"history_duration_seconds": { "$ref": "#/$defs/DurationSeconds" },
+		"minimum_duration_seconds": { "$ref": "#/$defs/DurationSeconds" },
+		"maximum_duration_seconds": { "$ref": "#/$defs/DurationSeconds" },
+		"backoff_factor": { "type": "number", "minimum": 1 }
+	}
+}
+`)
+
+// AuthenticationLockoutConfig configures locking out a user after
+// repeated failed authentication attempts.
+//
+// The n-th consecutive lockout lasts for
+// minimum_duration * backoff_factor^(n-1), capped by maximum_duration.
+type AuthenticationLockoutConfig struct {
+	Enabled         bool            `json:"enabled,omitempty"`
+	MaxAttempts     int             `json:"max_attempts,omitempty"`
+	HistoryDuration DurationSeconds `json:"history_duration_seconds,omitempty"`
+	MinimumDuration DurationSeconds `json:"minimum_duration_seconds,omitempty"`
+	MaximumDuration DurationSeconds `json:"maximum_duration_seconds,omitempty"`
+	BackoffFactor   float64         `json:"backoff_factor,omitempty"`
+}
+
+func (c *AuthenticationLockoutConfig) SetDefaults() {
+	if c.MaxAttempts == 0 {
+		c.MaxAttempts = 10
+	}
+	if c.HistoryDuration == 0 {
+		c.HistoryDuration = DurationSeconds(3600)
+	}
+	if c.MinimumDuration == 0 {
+		c.MinimumDuration = DurationSeconds(300)
+	}
+	if c.MaximumDuration == 0 {
+		c.MaximumDuration = DurationSeconds(86400)
+	}
+	if c.BackoffFactor == 0 {
+		c.BackoffFactor = 1
+	}
+}
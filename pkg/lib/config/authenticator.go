@@ -7,7 +7,8 @@ var _ = Schema.Add("AuthenticatorConfig", `
 	"properties": {
 		"password": { "$ref": "#/$defs/AuthenticatorPasswordConfig" },
 		"totp": { "$ref": "#/$defs/AuthenticatorTOTPConfig" },
-		"oob_otp": { "$ref": "#/$defs/AuthenticatorOOBConfig" }
+		"oob_otp": { "$ref": "#/$defs/AuthenticatorOOBConfig" },
+		"webauthn": { "$ref": "#/$defs/AuthenticatorWebAuthnConfig" }
 	}
 }
 `)
@@ -16,6 +17,7 @@ type AuthenticatorConfig struct {
 	Password *AuthenticatorPasswordConfig `json:"password,omitempty"`
 	TOTP     *AuthenticatorTOTPConfig     `json:"totp,omitempty"`
 	OOB      *AuthenticatorOOBConfig      `json:"oob_otp,omitempty"`
+	WebAuthn *AuthenticatorWebAuthnConfig `json:"webauthn,omitempty"`
 }
 
 var _ = Schema.Add("AuthenticatorPasswordConfig", `
@@ -151,3 +153,70 @@ func (c *AuthenticatorOOBEmailConfig) SetDefaults() {
 		c.CodeDigits = 6
 	}
 }
+
+var _ = Schema.Add("AuthenticatorWebAuthnConfig", `
+{
+	"type": "object",
+	"additionalProperties": false,
+	"properties": {
+		"maximum": { "type": "integer" },
+		"rp_id": { "type": "string", "minLength": 1 },
+		"rp_display_name": { "type": "string", "minLength": 1 },
+		"attestation": { "$ref": "#/$defs/WebAuthnAttestationPreference" },
+		"user_verification": { "$ref": "#/$defs/WebAuthnUserVerification" }
+	}
+}
+`)
+
+// AuthenticatorWebAuthnConfig configures WebAuthn authenticators.
+//
+// RPID defaults to the host of the public origin if it is not specified.
+type AuthenticatorWebAuthnConfig struct {
+	Maximum          *int                          `json:"maximum,omitempty"`
+	RPID             string                        `json:"rp_id,omitempty"`
+	RPDisplayName    string                        `json:"rp_display_name,omitempty"`
+	Attestation      WebAuthnAttestationPreference `json:"attestation,omitempty"`
+	UserVerification WebAuthnUserVerification      `json:"user_verification,omitempty"`
+}
+
+func (c *AuthenticatorWebAuthnConfig) SetDefaults() {
+	if c.Maximum == nil {
+		c.Maximum = newInt(99)
+	}
+	if c.Attestation == "" {
+		c.Attestation = WebAuthnAttestationPreferenceNone
+	}
+	if c.UserVerification == "" {
+		c.UserVerification = WebAuthnUserVerificationPreferred
+	}
+}
+
+var _ = Schema.Add("WebAuthnAttestationPreference", `
+{
+	"type": "string",
+	"enum": ["none", "indirect", "direct"]
+}
+`)
+
+type WebAuthnAttestationPreference string
+
+const (
+	WebAuthnAttestationPreferenceNone     WebAuthnAttestationPreference = "none"
+	WebAuthnAttestationPreferenceIndirect WebAuthnAttestationPreference = "indirect"
+	WebAuthnAttestationPreferenceDirect   WebAuthnAttestationPreference = "direct"
+)
+
+var _ = Schema.Add("WebAuthnUserVerification", `
+{
+	"type": "string",
+	"enum": ["required", "preferred", "discouraged"]
+}
+`)
+
+type WebAuthnUserVerification string
+
+const (
+	WebAuthnUserVerificationRequired    WebAuthnUserVerification = "required"
+	WebAuthnUserVerificationPreferred   WebAuthnUserVerification = "preferred"
+	WebAuthnUserVerificationDiscouraged WebAuthnUserVerification = "discouraged"
+)
@@ -39,7 +39,10 @@ var _ = Schema.Add("AppConfig", `
 		"authenticator": { "$ref": "#/$defs/AuthenticatorConfig" },
 		"forgot_password": { "$ref": "#/$defs/ForgotPasswordConfig" },
 		"welcome_message": { "$ref": "#/$defs/WelcomeMessageConfig" },
-		"verification": { "$ref": "#/$defs/VerificationConfig" }
+		"verification": { "$ref": "#/$defs/VerificationConfig" },
+		"rate_limit": { "$ref": "#/$defs/RateLimitConfig" },
+		"user_profile": { "$ref": "#/$defs/UserProfileConfig" },
+		"audit_log": { "$ref": "#/$defs/AuditLogConfig" }
 	},
 	"required": ["id"]
 }
@@ -67,6 +70,12 @@ type AppConfig struct {
 	ForgotPassword *ForgotPasswordConfig `json:"forgot_password,omitempty"`
 	WelcomeMessage *WelcomeMessageConfig `json:"welcome_message,omitempty"`
 	Verification   *VerificationConfig   `json:"verification,omitempty"`
+
+	RateLimit *RateLimitConfig `json:"rate_limit,omitempty"`
+
+	UserProfile *UserProfileConfig `json:"user_profile,omitempty"`
+
+	AuditLog *AuditLogConfig `json:"audit_log,omitempty"`
 }
 
 func (c *AppConfig) Validate(ctx *validation.Context) {
@@ -124,14 +133,15 @@ func (c *AppConfig) Validate(ctx *validation.Context) {
 		case authn.IdentityTypeLoginID:
 			_, hasPassword := authenticatorTypes[authn.AuthenticatorTypePassword]
 			_, hasOOB := authenticatorTypes[authn.AuthenticatorTypeOOB]
+			_, hasWebAuthn := authenticatorTypes[authn.AuthenticatorTypeWebAuthn]
 			for _, k := range c.Identity.LoginID.Keys {
 				switch k.Type {
 				case LoginIDKeyTypeEmail, LoginIDKeyTypePhone:
-					if !hasPassword && !hasOOB {
+					if !hasPassword && !hasOOB && !hasWebAuthn {
 						hasPrimaryAuth = false
 					}
 				case LoginIDKeyTypeUsername:
-					if !hasPassword {
+					if !hasPassword && !hasWebAuthn {
 						hasPrimaryAuth = false
 					}
 				}
@@ -157,6 +167,8 @@ func (c *AppConfig) Validate(ctx *validation.Context) {
 		ctx.Child("ui", "country_calling_code", "default").
 			EmitErrorMessage("default country calling code is unlisted")
 	}
+
+	c.UserProfile.validate(ctx.Child("user_profile"))
 }
 
 func Parse(inputYAML []byte) (*AppConfig, error) {
@@ -7,15 +7,17 @@ var _ = Schema.Add("HookConfig", `
 	"properties": {
 		"sync_hook_timeout_seconds": { "$ref": "#/$defs/DurationSeconds" },
 		"sync_hook_total_timeout_seconds": { "$ref": "#/$defs/DurationSeconds" },
+		"async_hook_retry_horizon_seconds": { "$ref": "#/$defs/DurationSeconds" },
 		"handlers": { "type": "array", "items": { "$ref": "#/$defs/HookHandlerConfig" } }
 	}
 }
 `)
 
 type HookConfig struct {
-	SyncTimeout      DurationSeconds     `json:"sync_hook_timeout_seconds,omitempty"`
-	SyncTotalTimeout DurationSeconds     `json:"sync_hook_total_timeout_seconds,omitempty"`
-	Handlers         []HookHandlerConfig `json:"handlers,omitempty"`
+	SyncTimeout       DurationSeconds     `json:"sync_hook_timeout_seconds,omitempty"`
+	SyncTotalTimeout  DurationSeconds     `json:"sync_hook_total_timeout_seconds,omitempty"`
+	AsyncRetryHorizon DurationSeconds     `json:"async_hook_retry_horizon_seconds,omitempty"`
+	Handlers          []HookHandlerConfig `json:"handlers,omitempty"`
 }
 
 func (c *HookConfig) SetDefaults() {
@@ -25,6 +27,9 @@ func (c *HookConfig) SetDefaults() {
 	if c.SyncTotalTimeout == 0 {
 		c.SyncTotalTimeout = DurationSeconds(10)
 	}
+	if c.AsyncRetryHorizon == 0 {
+		c.AsyncRetryHorizon = DurationSeconds(3 * 24 * 60 * 60)
+	}
 }
 
 var _ = Schema.Add("HookHandlerConfig", `
@@ -2,6 +2,8 @@ package config
 
 import (
 	"fmt"
+	"net/url"
+	"strings"
 )
 
 var _ = Schema.Add("IdentityConfig", `
@@ -228,7 +230,9 @@ var _ = Schema.Add("OAuthSSOProviderType", `
 		"facebook",
 		"linkedin",
 		"azureadv2",
-		"apple"
+		"apple",
+		"oidc",
+		"oauth2"
 	]
 }
 `)
@@ -253,6 +257,12 @@ func (t OAuthSSOProviderType) Scope() string {
 		return "openid profile email"
 	case OAuthSSOProviderTypeApple:
 		return "email"
+	case OAuthSSOProviderTypeOIDC:
+		// https://openid.net/specs/openid-connect-core-1_0.html#ScopeClaims
+		return "openid profile email"
+	case OAuthSSOProviderTypeOAuth2:
+		// The scopes are provider-specific.
+		return ""
 	}
 
 	panic(fmt.Sprintf("oauth: unknown provider type %s", string(t)))
@@ -264,6 +274,8 @@ const (
 	OAuthSSOProviderTypeLinkedIn  OAuthSSOProviderType = "linkedin"
 	OAuthSSOProviderTypeAzureADv2 OAuthSSOProviderType = "azureadv2"
 	OAuthSSOProviderTypeApple     OAuthSSOProviderType = "apple"
+	OAuthSSOProviderTypeOIDC      OAuthSSOProviderType = "oidc"
+	OAuthSSOProviderTypeOAuth2    OAuthSSOProviderType = "oauth2"
 )
 
 var _ = Schema.Add("OAuthSSOProviderConfig", `
@@ -277,7 +289,16 @@ var _ = Schema.Add("OAuthSSOProviderConfig", `
 		"claims": { "$ref": "#/$defs/VerificationOAuthClaimsConfig" },
 		"tenant": { "type": "string" },
 		"key_id": { "type": "string" },
-		"team_id": { "type": "string" }
+		"team_id": { "type": "string" },
+		"issuer": { "type": "string", "format": "uri" },
+		"discovery_url": { "type": "string", "format": "uri" },
+		"authorization_endpoint": { "type": "string", "format": "uri" },
+		"token_endpoint": { "type": "string", "format": "uri" },
+		"jwks_uri": { "type": "string", "format": "uri" },
+		"userinfo_endpoint": { "type": "string", "format": "uri" },
+		"scopes": { "type": "array", "items": { "type": "string", "minLength": 1 } },
+		"claim_mapping": { "$ref": "#/$defs/OAuthSSOClaimMappingConfig" },
+		"userinfo_mapping": { "$ref": "#/$defs/OAuthSSOUserInfoMappingConfig" }
 	},
 	"required": ["alias", "type", "client_id"],
 	"allOf": [
@@ -292,6 +313,18 @@ var _ = Schema.Add("OAuthSSOProviderConfig", `
 			"then": {
 				"required": ["tenant"]
 			}
+		},
+		{
+			"if": { "properties": { "type": { "const": "oidc" } } },
+			"then": {
+				"required": ["issuer"]
+			}
+		},
+		{
+			"if": { "properties": { "type": { "const": "oauth2" } } },
+			"then": {
+				"required": ["authorization_endpoint", "token_endpoint", "userinfo_endpoint"]
+			}
 		}
 	]
 }
@@ -309,6 +342,131 @@ type OAuthSSOProviderConfig struct {
 	// KeyID and TeamID are specific to apple
 	KeyID  string `json:"key_id,omitempty"`
 	TeamID string `json:"team_id,omitempty"`
+
+	// Issuer, DiscoveryURL and JWKSURI are specific to oidc.
+	// The endpoints are discovered from the issuer unless all of them are
+	// given explicitly.
+	Issuer                string `json:"issuer,omitempty"`
+	DiscoveryURL          string `json:"discovery_url,omitempty"`
+	AuthorizationEndpoint string `json:"authorization_endpoint,omitempty"`
+	TokenEndpoint         string `json:"token_endpoint,omitempty"`
+	JWKSURI               string `json:"jwks_uri,omitempty"`
+
+	// UserInfoEndpoint is specific to oauth2.
+	// AuthorizationEndpoint and TokenEndpoint are also required.
+	UserInfoEndpoint string `json:"userinfo_endpoint,omitempty"`
+
+	// Scopes overrides the default scopes of the provider type.
+	Scopes []string `json:"scopes,omitempty"`
+	// ClaimMapping maps provider claims to user info.
+	ClaimMapping *OAuthSSOClaimMappingConfig `json:"claim_mapping,omitempty"`
+	// UserInfoMapping maps the userinfo response of oauth2 to user info.
+	UserInfoMapping *OAuthSSOUserInfoMappingConfig `json:"userinfo_mapping,omitempty"`
+}
+
+func (c *OAuthSSOProviderConfig) Scope() string {
+	if len(c.Scopes) > 0 {
+		return strings.Join(c.Scopes, " ")
+	}
+	return c.Type.Scope()
+}
+
+var _ = Schema.Add("OAuthSSOClaimMappingConfig", `
+{
+	"type": "object",
+	"additionalProperties": false,
+	"properties": {
+		"subject": { "type": "string", "minLength": 1 },
+		"email": { "type": "string", "minLength": 1 },
+		"email_verified": { "type": "string", "minLength": 1 }
+	}
+}
+`)
+
+type OAuthSSOClaimMappingConfig struct {
+	Subject       string `json:"subject,omitempty"`
+	Email         string `json:"email,omitempty"`
+	EmailVerified string `json:"email_verified,omitempty"`
+}
+
+// SubjectClaim returns the claim of the user ID, which defaults to sub.
+func (c *OAuthSSOClaimMappingConfig) SubjectClaim() string {
+	if c == nil || c.Subject == "" {
+		return "sub"
+	}
+	return c.Subject
+}
+
+// EmailClaim returns the claim of the email address, which defaults to email.
+func (c *OAuthSSOClaimMappingConfig) EmailClaim() string {
+	if c == nil || c.Email == "" {
+		return "email"
+	}
+	return c.Email
+}
+
+// EmailVerifiedClaim returns the claim of whether the email address is
+// verified, which defaults to email_verified.
+func (c *OAuthSSOClaimMappingConfig) EmailVerifiedClaim() string {
+	if c == nil || c.EmailVerified == "" {
+		return "email_verified"
+	}
+	return c.EmailVerified
+}
+
+var _ = Schema.Add("OAuthSSOUserInfoMappingConfig", `
+{
+	"type": "object",
+	"additionalProperties": false,
+	"properties": {
+		"subject": { "type": "string", "format": "json-pointer" },
+		"email": { "type": "string", "format": "json-pointer" },
+		"email_verified": { "type": "string", "format": "json-pointer" },
+		"name": { "type": "string", "format": "json-pointer" }
+	}
+}
+`)
+
+// OAuthSSOUserInfoMappingConfig contains JSON pointers to the values in the
+// userinfo response.
+type OAuthSSOUserInfoMappingConfig struct {
+	Subject       string `json:"subject,omitempty"`
+	Email         string `json:"email,omitempty"`
+	EmailVerified string `json:"email_verified,omitempty"`
+	Name          string `json:"name,omitempty"`
+}
+
+// SubjectPointer returns the pointer to the user ID, which defaults to /id.
+func (c *OAuthSSOUserInfoMappingConfig) SubjectPointer() string {
+	if c == nil || c.Subject == "" {
+		return "/id"
+	}
+	return c.Subject
+}
+
+// EmailPointer returns the pointer to the email address, which defaults to /email.
+func (c *OAuthSSOUserInfoMappingConfig) EmailPointer() string {
+	if c == nil || c.Email == "" {
+		return "/email"
+	}
+	return c.Email
+}
+
+// EmailVerifiedPointer returns the pointer to whether the email address is
+// verified. It is empty if the provider does not tell.
+func (c *OAuthSSOUserInfoMappingConfig) EmailVerifiedPointer() string {
+	if c == nil {
+		return ""
+	}
+	return c.EmailVerified
+}
+
+// NamePointer returns the pointer to the display name, which defaults to /name.
+func (c *OAuthSSOUserInfoMappingConfig) NamePointer() string {
+	if c == nil || c.Name == "" {
+		return "/name"
+	}
+	return c.Name
 }
 
 func (c *OAuthSSOProviderConfig) ProviderID() ProviderID {
@@ -353,6 +511,22 @@ func (c *OAuthSSOProviderConfig) ProviderID() ProviderID {
 		// Since Apple has private relay to hide the real email,
 		// the user may not be associate their account.
 		keys["team_id"] = c.TeamID
+	case OAuthSSOProviderTypeOIDC:
+		// sub is unique within the issuer.
+		// Therefore, ProviderID is Type + issuer.
+		//
+		// Rotating the OAuth application is OK.
+		// But changing the issuer is problematic.
+		keys["issuer"] = c.Issuer
+	case OAuthSSOProviderTypeOAuth2:
+		// The user ID of most OAuth 2.0 providers, e.g. GitHub, GitLab and
+		// Discord, is not scoped to client_id.
+		// Therefore, ProviderID is Type + host of the authorization endpoint.
+		//
+		// Rotating the OAuth application is OK.
+		// But moving the provider to another host is problematic.
+		// But if email remains unchanged, the user can associate their account.
+		keys["host"] = oauth2ProviderHost(c.AuthorizationEndpoint)
 	}
 
 	return ProviderID{
@@ -361,6 +535,14 @@ func (c *OAuthSSOProviderConfig) ProviderID() ProviderID {
 	}
 }
 
+func oauth2ProviderHost(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return endpoint
+	}
+	return u.Host
+}
+
 // ProviderID combining with a subject ID identifies an user from an external system.
 type ProviderID struct {
 	Type string
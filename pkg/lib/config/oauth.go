@@ -1,5 +1,12 @@
 package config
 
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/lestrrat-go/jwx/jwk"
+)
+
 var _ = Schema.Add("OAuthConfig", `
 {
 	"type": "object",
@@ -38,14 +45,75 @@ var _ = Schema.Add("OAuthClientConfig", `
 		},
 		"grant_types": { "type": "array", "items": { "type": "string" } },
 		"response_types": { "type": "array", "items": { "type": "string" } },
+		"scope": { "type": "string" },
 		"post_logout_redirect_uris": { "type": "array", "items": { "type": "string", "format": "uri" } },
 		"access_token_lifetime_seconds": { "$ref": "#/$defs/DurationSeconds" },
-		"refresh_token_lifetime_seconds": { "$ref": "#/$defs/DurationSeconds" }
+		"refresh_token_lifetime_seconds": { "$ref": "#/$defs/DurationSeconds" },
+		"is_first_party": { "type": "boolean" },
+		"skip_consent": { "type": "boolean" },
+		"token_endpoint_auth_method": { "$ref": "#/$defs/OAuthClientAuthMethod" },
+		"default_acr_values": { "type": "array", "items": { "type": "string" } },
+		"default_max_age": { "type": "integer", "minimum": 1 },
+		"access_token_format": { "$ref": "#/$defs/OAuthAccessTokenFormat" },
+		"jwks": {
+			"type": "object",
+			"properties": {
+				"keys": {
+					"type": "array",
+					"items": { "type": "object" },
+					"minItems": 1
+				}
+			},
+			"required": ["keys"]
+		}
+	},
+	"required": ["name", "client_id", "redirect_uris"],
+	"if": {
+		"properties": {
+			"token_endpoint_auth_method": { "const": "private_key_jwt" }
+		},
+		"required": ["token_endpoint_auth_method"]
 	},
-	"required": ["name", "client_id", "redirect_uris"]
+	"then": {
+		"required": ["jwks"]
+	}
+}
+`)
+
+var _ = Schema.Add("OAuthClientAuthMethod", `
+{
+	"type": "string",
+	"enum": ["none", "client_secret_basic", "client_secret_post", "private_key_jwt"]
 }
 `)
 
+type OAuthClientAuthMethod string
+
+const (
+	OAuthClientAuthMethodNone              OAuthClientAuthMethod = "none"
+	OAuthClientAuthMethodClientSecretBasic OAuthClientAuthMethod = "client_secret_basic"
+	OAuthClientAuthMethodClientSecretPost  OAuthClientAuthMethod = "client_secret_post"
+	OAuthClientAuthMethodPrivateKeyJWT     OAuthClientAuthMethod = "private_key_jwt"
+)
+
+func (m OAuthClientAuthMethod) UsesClientSecret() bool {
+	return m == OAuthClientAuthMethodClientSecretBasic || m == OAuthClientAuthMethodClientSecretPost
+}
+
+var _ = Schema.Add("OAuthAccessTokenFormat", `
+{
+	"type": "string",
+	"enum": ["opaque", "jwt"]
+}
+`)
+
+type OAuthAccessTokenFormat string
+
+const (
+	OAuthAccessTokenFormatOpaque OAuthAccessTokenFormat = "opaque"
+	OAuthAccessTokenFormatJWT    OAuthAccessTokenFormat = "jwt"
+)
+
 type OAuthClientConfig map[string]interface{}
 
 func (c OAuthClientConfig) SetDefaults() {
@@ -86,6 +154,13 @@ func (c OAuthClientConfig) RedirectURIs() (out []string) {
 	return
 }
 
+func (c OAuthClientConfig) Name() string {
+	if s, ok := c["name"].(string); ok {
+		return s
+	}
+	return ""
+}
+
 func (c OAuthClientConfig) GrantTypes() (out []string) {
 	if arr, ok := c["grant_types"].([]interface{}); ok {
 		for _, item := range arr {
@@ -97,6 +172,15 @@ func (c OAuthClientConfig) GrantTypes() (out []string) {
 	return out
 }
 
+// Scopes returns the API scopes the client may request with the
+// client_credentials grant.
+func (c OAuthClientConfig) Scopes() []string {
+	if s, ok := c["scope"].(string); ok {
+		return strings.Fields(s)
+	}
+	return nil
+}
+
 func (c OAuthClientConfig) ResponseTypes() (out []string) {
 	if arr, ok := c["response_types"].([]interface{}); ok {
 		for _, item := range arr {
@@ -118,6 +202,29 @@ func (c OAuthClientConfig) PostLogoutRedirectURIs() (out []string) {
 	}
 	return out
 }
+
+// DefaultACRValues returns the ACR values requested when acr_values is absent
+// in authorization requests.
+func (c OAuthClientConfig) DefaultACRValues() (out []string) {
+	if arr, ok := c["default_acr_values"].([]interface{}); ok {
+		for _, item := range arr {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+	}
+	return out
+}
+
+// DefaultMaxAge returns the max age requested when max_age is absent in
+// authorization requests.
+func (c OAuthClientConfig) DefaultMaxAge() (DurationSeconds, bool) {
+	if f64, ok := c["default_max_age"].(float64); ok {
+		return DurationSeconds(f64), true
+	}
+	return 0, false
+}
+
 func (c OAuthClientConfig) AccessTokenLifetime() DurationSeconds {
 	if f64, ok := c["access_token_lifetime_seconds"].(float64); ok {
 		return DurationSeconds(f64)
@@ -129,6 +236,15 @@ func (c OAuthClientConfig) SetAccessTokenLifetime(t DurationSeconds) {
 	c["access_token_lifetime_seconds"] = float64(t)
 }
 
+// AccessTokenFormat returns the format of access tokens issued to the
+// client. Access tokens are opaque unless configured otherwise.
+func (c OAuthClientConfig) AccessTokenFormat() OAuthAccessTokenFormat {
+	if s, ok := c["access_token_format"].(string); ok {
+		return OAuthAccessTokenFormat(s)
+	}
+	return OAuthAccessTokenFormatOpaque
+}
+
 func (c OAuthClientConfig) RefreshTokenLifetime() DurationSeconds {
 	if f64, ok := c["refresh_token_lifetime_seconds"].(float64); ok {
 		return DurationSeconds(f64)
@@ -139,3 +255,51 @@ func (c OAuthClientConfig) RefreshTokenLifetime() DurationSeconds {
 func (c OAuthClientConfig) SetRefreshTokenLifetime(t DurationSeconds) {
 	c["refresh_token_lifetime_seconds"] = float64(t)
 }
+
+// IsFirstParty reports whether the client is operated by the app itself.
+// Clients are first-party unless explicitly configured otherwise.
+func (c OAuthClientConfig) IsFirstParty() bool {
+	if b, ok := c["is_first_party"].(bool); ok {
+		return b
+	}
+	return true
+}
+
+func (c OAuthClientConfig) SkipConsent() bool {
+	if b, ok := c["skip_consent"].(bool); ok {
+		return b
+	}
+	return false
+}
+
+// ConsentRequired reports whether the user must explicitly consent to the
+// scopes requested by the client.
+func (c OAuthClientConfig) ConsentRequired() bool {
+	return !c.IsFirstParty() && !c.SkipConsent()
+}
+
+// TokenEndpointAuthMethod returns the client authentication method at the
+// token endpoint. Clients are public unless configured otherwise.
+func (c OAuthClientConfig) TokenEndpointAuthMethod() OAuthClientAuthMethod {
+	if s, ok := c["token_endpoint_auth_method"].(string); ok {
+		return OAuthClientAuthMethod(s)
+	}
+	return OAuthClientAuthMethodNone
+}
+
+func (c OAuthClientConfig) IsConfidential() bool {
+	return c.TokenEndpointAuthMethod() != OAuthClientAuthMethodNone
+}
+
+// JWKS returns the public keys used by the client to sign client assertions.
+func (c OAuthClientConfig) JWKS() (*jwk.Set, error) {
+	v, ok := c["jwks"]
+	if !ok {
+		return nil, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return jwk.ParseBytes(b)
+}
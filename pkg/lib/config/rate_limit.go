@@ -0,0 +1,64 @@
+package config
+
+var _ = Schema.Add("RateLimitConfig", `
+{
+	"type": "object",
+	"additionalProperties": false,
+	"properties": {
+		"authentication_per_user": { "$ref": "#/$defs/RateLimitBucketConfig" },
+		"authentication_per_ip": { "$ref": "#/$defs/RateLimitBucketConfig" },
+		"message_send_per_target": { "$ref": "#/$defs/RateLimitBucketConfig" },
+		"forgot_password_per_ip": { "$ref": "#/$defs/RateLimitBucketConfig" },
+		"anonymous_request_per_ip": { "$ref": "#/$defs/RateLimitBucketConfig" },
+		"device_user_code_per_ip": { "$ref": "#/$defs/RateLimitBucketConfig" }
+	}
+}
+`)
+
+type RateLimitConfig struct {
+	AuthenticationPerUser *RateLimitBucketConfig `json:"authentication_per_user,omitempty"`
+	AuthenticationPerIP   *RateLimitBucketConfig `json:"authentication_per_ip,omitempty"`
+	MessageSendPerTarget  *RateLimitBucketConfig `json:"message_send_per_target,omitempty"`
+	ForgotPasswordPerIP   *RateLimitBucketConfig `json:"forgot_password_per_ip,omitempty"`
+	AnonymousRequestPerIP *RateLimitBucketConfig `json:"anonymous_request_per_ip,omitempty"`
+	DeviceUserCodePerIP   *RateLimitBucketConfig `json:"device_user_code_per_ip,omitempty"`
+}
+
+func (c *RateLimitConfig) SetDefaults() {
+	c.AuthenticationPerUser.setDefaults(10, 60)
+	c.AuthenticationPerIP.setDefaults(60, 60)
+	c.MessageSendPerTarget.setDefaults(5, 300)
+	c.ForgotPasswordPerIP.setDefaults(10, 3600)
+	c.AnonymousRequestPerIP.setDefaults(60, 3600)
+	c.DeviceUserCodePerIP.setDefaults(10, 300)
+}
+
+var _ = Schema.Add("RateLimitBucketConfig", `
+{
+	"type": "object",
+	"additionalProperties": false,
+	"properties": {
+		"enabled": { "type": "boolean" },
+		"size": { "type": "integer", "minimum": 1 },
+		"reset_period_seconds": { "$ref": "#/$defs/DurationSeconds" }
+	}
+}
+`)
+
+type RateLimitBucketConfig struct {
+	Enabled     *bool           `json:"enabled,omitempty"`
+	Size        int             `json:"size,omitempty"`
+	ResetPeriod DurationSeconds `json:"reset_period_seconds,omitempty"`
+}
+
+func (c *RateLimitBucketConfig) setDefaults(size int, resetPeriod DurationSeconds) {
+	if c.Enabled == nil {
+		c.Enabled = newBool(true)
+	}
+	if c.Size == 0 {
+		c.Size = size
+	}
+	if c.ResetPeriod == 0 {
+		c.ResetPeriod = resetPeriod
+	}
+}
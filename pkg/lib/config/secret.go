@@ -116,6 +116,19 @@ func (c *SecretConfig) Validate(appConfig *AppConfig) error {
 		require(WebhookKeyMaterialsKey, "web-hook signing key materials")
 	}
 
+	for _, client := range appConfig.OAuth.Clients {
+		if !client.TokenEndpointAuthMethod().UsesClientSecret() {
+			continue
+		}
+		require(OAuthClientSecretsKey, "OAuth client secrets")
+		secrets, ok := c.LookupData(OAuthClientSecretsKey).(*OAuthClientSecrets)
+		if ok {
+			if _, found := secrets.Lookup(client.ClientID()); !found {
+				ctx.EmitErrorMessage(fmt.Sprintf("OAuth client secret for '%s' is required", client.ClientID()))
+			}
+		}
+	}
+
 	return ctx.Error("invalid secrets")
 }
 
@@ -132,6 +145,7 @@ const (
 	OIDCKeyMaterialsKey       SecretKey = "oidc"
 	CSRFKeyMaterialsKey       SecretKey = "csrf"
 	WebhookKeyMaterialsKey    SecretKey = "webhook"
+	OAuthClientSecretsKey     SecretKey = "oauth.client_secrets"
 )
 
 type SecretItemData interface {
@@ -154,6 +168,7 @@ var secretItemKeys = map[SecretKey]secretKeyDef{
 	OIDCKeyMaterialsKey:       {"OIDCKeyMaterials", func() SecretItemData { return &OIDCKeyMaterials{} }},
 	CSRFKeyMaterialsKey:       {"CSRFKeyMaterials", func() SecretItemData { return &CSRFKeyMaterials{} }},
 	WebhookKeyMaterialsKey:    {"WebhookKeyMaterials", func() SecretItemData { return &WebhookKeyMaterials{} }},
+	OAuthClientSecretsKey:     {"OAuthClientSecrets", func() SecretItemData { return &OAuthClientSecrets{} }},
 }
 
 var _ = SecretConfigSchema.AddJSON("SecretKey", map[string]interface{}{
@@ -114,6 +114,64 @@ func (c *OAuthClientCredentialsItem) SensitiveStrings() []string {
 	return []string{c.ClientSecret}
 }
 
+var _ = SecretConfigSchema.Add("OAuthClientSecrets", `
+{
+	"type": "object",
+	"additionalProperties": false,
+	"properties": {
+		"items": {
+			"type": "array",
+			"items": {
+				"type": "object",
+				"additionalProperties": false,
+				"properties": {
+					"client_id": {
+						"type": "string"
+					},
+					"client_secret": {
+						"type": "string",
+						"minLength": 32
+					}
+				},
+				"required": ["client_id", "client_secret"]
+			}
+		}
+	},
+	"required": ["items"]
+}
+`)
+
+type OAuthClientSecrets struct {
+	Items []OAuthClientSecretItem `json:"items,omitempty"`
+}
+
+func (c *OAuthClientSecrets) Lookup(clientID string) (*OAuthClientSecretItem, bool) {
+	for _, item := range c.Items {
+		if item.ClientID == clientID {
+			ii := item
+			return &ii, true
+		}
+	}
+	return nil, false
+}
+
+func (c *OAuthClientSecrets) SensitiveStrings() []string {
+	var out []string
+	for _, item := range c.Items {
+		out = append(out, item.SensitiveStrings()...)
+	}
+	return out
+}
+
+type OAuthClientSecretItem struct {
+	ClientID     string `json:"client_id,omitempty"`
+	ClientSecret string `json:"client_secret,omitempty"`
+}
+
+func (c *OAuthClientSecretItem) SensitiveStrings() []string {
+	return []string{c.ClientSecret}
+}
+
 var _ = SecretConfigSchema.Add("SMTPMode", `
 {
 	"type": "string",
@@ -0,0 +1,153 @@
+package config
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/authgear/authgear-server/pkg/util/validation"
+)
+
+var _ = Schema.Add("UserProfileConfig", `
+{
+	"type": "object",
+	"additionalProperties": false,
+	"properties": {
+		"schema": { "$ref": "#/$defs/UserProfileSchema" },
+		"id_token_claims": {
+			"type": "array",
+			"items": { "type": "string", "minLength": 1 }
+		}
+	}
+}
+`)
+
+var _ = Schema.Add("UserProfileSchema", `
+{
+	"type": "object",
+	"additionalProperties": false,
+	"properties": {
+		"type": { "type": "string", "enum": ["object"] },
+		"properties": {
+			"type": "object",
+			"additionalProperties": { "$ref": "#/$defs/UserProfileAttributeSchema" }
+		},
+		"required": {
+			"type": "array",
+			"items": { "type": "string" }
+		}
+	},
+	"required": ["type"]
+}
+`)
+
+var _ = Schema.Add("UserProfileAttributeSchema", `
+{
+	"type": "object",
+	"additionalProperties": false,
+	"properties": {
+		"type": { "type": "string", "enum": ["string", "number", "integer", "boolean"] },
+		"title": { "type": "string" },
+		"description": { "type": "string" },
+		"enum": { "type": "array", "minItems": 1 },
+		"format": { "type": "string", "enum": ["email", "phone", "uri"] },
+		"pattern": { "type": "string" },
+		"minLength": { "type": "integer", "minimum": 0 },
+		"maxLength": { "type": "integer", "minimum": 0 },
+		"minimum": { "type": "number" },
+		"maximum": { "type": "number" }
+	},
+	"required": ["type"]
+}
+`)
+
+type UserProfileConfig struct {
+	Schema        *UserProfileSchema `json:"schema,omitempty"`
+	IDTokenClaims []string           `json:"id_token_claims,omitempty"`
+
+	validatorOnce sync.Once
+	validator     *validation.SchemaValidator
+}
+
+// Validator returns the validator of user profile. Attributes not defined in
+// the schema are rejected. The validator is built once per config.
+func (c *UserProfileConfig) Validator() *validation.SchemaValidator {
+	c.validatorOnce.Do(func() {
+		c.validator = c.buildValidator()
+	})
+	return c.validator
+}
+
+func (c *UserProfileConfig) buildValidator() *validation.SchemaValidator {
+	properties := map[string]UserProfileAttributeSchema{}
+	for name, attr := range c.Schema.Properties {
+		properties[name] = attr
+	}
+
+	schema := map[string]interface{}{
+		"type":                 "object",
+		"additionalProperties": false,
+		"properties":           properties,
+	}
+	if len(c.Schema.Required) > 0 {
+		schema["required"] = c.Schema.Required
+	}
+
+	schemaJSON, err := json.Marshal(schema)
+	if err != nil {
+		panic("config: cannot marshal user profile schema: " + err.Error())
+	}
+	return validation.NewSimpleSchema(string(schemaJSON)).Validator()
+}
+
+func (c *UserProfileConfig) validate(ctx *validation.Context) {
+	var names []string
+	for name := range c.Schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if attr := c.Schema.Properties[name]; attr.Pattern != "" {
+			if _, err := regexp.Compile(attr.Pattern); err != nil {
+				ctx.Child("schema", "properties", name, "pattern").EmitErrorMessage("invalid pattern: " + err.Error())
+			}
+		}
+	}
+	for i, name := range c.Schema.Required {
+		if _, ok := c.Schema.Properties[name]; !ok {
+			ctx.Child("schema", "required", strconv.Itoa(i)).EmitErrorMessage("undefined attribute")
+		}
+	}
+	for i, name := range c.IDTokenClaims {
+		if _, ok := c.Schema.Properties[name]; !ok {
+			ctx.Child("id_token_claims", strconv.Itoa(i)).EmitErrorMessage("undefined attribute")
+		}
+	}
+}
+
+type UserProfileSchema struct {
+	Type       string                                `json:"type,omitempty"`
+	Properties map[string]UserProfileAttributeSchema `json:"properties,omitempty"`
+	Required   []string                              `json:"required,omitempty"`
+}
+
+func (s *UserProfileSchema) SetDefaults() {
+	if s.Type == "" {
+		s.Type = "object"
+	}
+}
+
+type UserProfileAttributeSchema struct {
+	Type        string        `json:"type,omitempty"`
+	Title       string        `json:"title,omitempty"`
+	Description string        `json:"description,omitempty"`
+	Enum        []interface{} `json:"enum,omitempty"`
+	Format      string        `json:"format,omitempty"`
+	Pattern     string        `json:"pattern,omitempty"`
+	MinLength   *int          `json:"minLength,omitempty"`
+	MaxLength   *int          `json:"maxLength,omitempty"`
+	Minimum     *float64      `json:"minimum,omitempty"`
+	Maximum     *float64      `json:"maximum,omitempty"`
+}
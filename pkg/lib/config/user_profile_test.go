@@ -0,0 +1,66 @@
+package config_test
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+)
+
+func TestUserProfileConfig(t *testing.T) {
+	Convey("UserProfileConfig.Validator", t, func() {
+		maxLength := 5
+		minimum := 0.0
+		cfg := &config.UserProfileConfig{
+			Schema: &config.UserProfileSchema{
+				Type: "object",
+				Properties: map[string]config.UserProfileAttributeSchema{
+					"nickname": {Type: "string", MaxLength: &maxLength},
+					"age":      {Type: "integer", Minimum: &minimum},
+				},
+				Required: []string{"nickname"},
+			},
+		}
+		validator := cfg.Validator()
+
+		Convey("should accept valid profile", func() {
+			err := validator.ValidateValue(map[string]interface{}{
+				"nickname": "John",
+				"age":      30,
+			})
+			So(err, ShouldBeNil)
+		})
+
+		Convey("should reject invalid profile", func() {
+			err := validator.ValidateValue(map[string]interface{}{
+				"nickname": "Johnny",
+				"age":      "thirty",
+				"unknown":  true,
+			})
+			So(err, ShouldBeError, `invalid value:
+/age: type
+  map[actual:[string] expected:[integer]]
+/nickname: maxLength
+  map[actual:6 expected:5]
+/unknown: additionalProperties`)
+		})
+
+		Convey("should reject profile without required attributes", func() {
+			err := validator.ValidateValue(map[string]interface{}{})
+			So(err, ShouldBeError, `invalid value:
+<root>: required
+  map[actual:<nil> expected:[nickname] missing:[nickname]]`)
+		})
+
+		Convey("should build validator once", func() {
+			So(cfg.Validator(), ShouldEqual, validator)
+		})
+
+		Convey("should reject any attributes without schema", func() {
+			cfg := &config.UserProfileConfig{Schema: &config.UserProfileSchema{Type: "object"}}
+			So(cfg.Validator().ValidateValue(map[string]interface{}{}), ShouldBeNil)
+			So(cfg.Validator().ValidateValue(map[string]interface{}{"nickname": "John"}), ShouldNotBeNil)
+		})
+	})
+}
@@ -3,10 +3,13 @@ package deps
 import (
 	"github.com/google/wire"
 
+	"github.com/authgear/authgear-server/pkg/lib/audit"
+	authenticatorlockout "github.com/authgear/authgear-server/pkg/lib/authn/authenticator/lockout"
 	authenticatoroob "github.com/authgear/authgear-server/pkg/lib/authn/authenticator/oob"
 	authenticatorpassword "github.com/authgear/authgear-server/pkg/lib/authn/authenticator/password"
 	authenticatorservice "github.com/authgear/authgear-server/pkg/lib/authn/authenticator/service"
 	authenticatortotp "github.com/authgear/authgear-server/pkg/lib/authn/authenticator/totp"
+	authenticatorwebauthn "github.com/authgear/authgear-server/pkg/lib/authn/authenticator/webauthn"
 	"github.com/authgear/authgear-server/pkg/lib/authn/challenge"
 	identityanonymous "github.com/authgear/authgear-server/pkg/lib/authn/identity/anonymous"
 	identityloginid "github.com/authgear/authgear-server/pkg/lib/authn/identity/loginid"
@@ -18,6 +21,9 @@ import (
 	"github.com/authgear/authgear-server/pkg/lib/authn/user"
 	"github.com/authgear/authgear-server/pkg/lib/facade"
 	"github.com/authgear/authgear-server/pkg/lib/feature/forgotpassword"
+	"github.com/authgear/authgear-server/pkg/lib/feature/userdeletion"
+	"github.com/authgear/authgear-server/pkg/lib/feature/userexport"
+	"github.com/authgear/authgear-server/pkg/lib/feature/userimport"
 	"github.com/authgear/authgear-server/pkg/lib/feature/verification"
 	"github.com/authgear/authgear-server/pkg/lib/feature/welcomemessage"
 	"github.com/authgear/authgear-server/pkg/lib/hook"
@@ -29,6 +35,7 @@ import (
 	oidchandler "github.com/authgear/authgear-server/pkg/lib/oauth/oidc/handler"
 	oauthpq "github.com/authgear/authgear-server/pkg/lib/oauth/pq"
 	oauthredis "github.com/authgear/authgear-server/pkg/lib/oauth/redis"
+	"github.com/authgear/authgear-server/pkg/lib/ratelimit"
 	"github.com/authgear/authgear-server/pkg/lib/session"
 	"github.com/authgear/authgear-server/pkg/lib/session/access"
 	"github.com/authgear/authgear-server/pkg/lib/session/idpsession"
@@ -50,7 +57,9 @@ var CommonDependencySet = wire.NewSet(
 		hook.DependencySet,
 		wire.Bind(new(interaction.HookProvider), new(*hook.Provider)),
 		wire.Bind(new(user.HookProvider), new(*hook.Provider)),
+		wire.Bind(new(authenticatorlockout.HookProvider), new(*hook.Provider)),
 		wire.Bind(new(session.HookProvider), new(*hook.Provider)),
+		wire.Bind(new(userdeletion.HookProvider), new(*hook.Provider)),
 	),
 
 	wire.NewSet(
@@ -61,6 +70,7 @@ var CommonDependencySet = wire.NewSet(
 		wire.Bind(new(oauth.ResolverSessionProvider), new(*idpsession.Provider)),
 		wire.Bind(new(oauthhandler.SessionProvider), new(*idpsession.Provider)),
 		wire.Bind(new(interaction.SessionProvider), new(*idpsession.Provider)),
+		wire.Bind(new(userdeletion.IDPSessionStore), new(*idpsession.StoreRedis)),
 	),
 
 	wire.NewSet(
@@ -75,19 +85,37 @@ var CommonDependencySet = wire.NewSet(
 		wire.Bind(new(interaction.OOBAuthenticatorProvider), new(*authenticatoroob.Provider)),
 		wire.Bind(new(interaction.OOBCodeSender), new(*authenticatoroob.CodeSender)),
 		authenticatortotp.DependencySet,
+		authenticatorwebauthn.DependencySet,
+		wire.Bind(new(interaction.WebAuthnAuthenticatorProvider), new(*authenticatorwebauthn.Provider)),
 
 		authenticatorservice.DependencySet,
 		wire.Bind(new(authenticatorservice.PasswordAuthenticatorProvider), new(*authenticatorpassword.Provider)),
 		wire.Bind(new(authenticatorservice.OOBOTPAuthenticatorProvider), new(*authenticatoroob.Provider)),
 		wire.Bind(new(authenticatorservice.TOTPAuthenticatorProvider), new(*authenticatortotp.Provider)),
+		wire.Bind(new(authenticatorservice.WebAuthnAuthenticatorProvider), new(*authenticatorwebauthn.Provider)),
 
 		wire.Bind(new(facade.AuthenticatorService), new(*authenticatorservice.Service)),
+		wire.Bind(new(userdeletion.AuthenticatorService), new(*authenticatorservice.Service)),
+		wire.Bind(new(userdeletion.PasswordHistoryStore), new(*authenticatorpassword.HistoryStore)),
+		wire.Bind(new(userimport.AuthenticatorService), new(*authenticatorservice.Service)),
+		wire.Bind(new(userexport.AuthenticatorService), new(*authenticatorservice.Service)),
+
+		authenticatorlockout.DependencySet,
+		wire.Bind(new(interaction.LockoutService), new(*authenticatorlockout.Service)),
+		wire.Bind(new(userdeletion.LockoutService), new(*authenticatorlockout.Service)),
+	),
+
+	wire.NewSet(
+		audit.DependencySet,
+		wire.Bind(new(interaction.AuditLogger), new(*audit.Service)),
+		wire.Bind(new(userimport.AuditLogger), new(*audit.Service)),
 	),
 
 	wire.NewSet(
 		mfa.DependencySet,
 
 		wire.Bind(new(interaction.MFAService), new(*mfa.Service)),
+		wire.Bind(new(userdeletion.MFAService), new(*mfa.Service)),
 	),
 
 	wire.NewSet(
@@ -104,6 +132,10 @@ var CommonDependencySet = wire.NewSet(
 		wire.Bind(new(identityservice.AnonymousIdentityProvider), new(*identityanonymous.Provider)),
 
 		wire.Bind(new(facade.IdentityService), new(*identityservice.Service)),
+		wire.Bind(new(oidc.IdentityService), new(*identityservice.Service)),
+		wire.Bind(new(userdeletion.IdentityService), new(*identityservice.Service)),
+		wire.Bind(new(userimport.IdentityService), new(*identityservice.Service)),
+		wire.Bind(new(userexport.IdentityService), new(*identityservice.Service)),
 	),
 
 	wire.NewSet(
@@ -121,7 +153,26 @@ var CommonDependencySet = wire.NewSet(
 		wire.Bind(new(session.UserProvider), new(*user.Queries)),
 		wire.Bind(new(interaction.UserService), new(*user.Provider)),
 		wire.Bind(new(oidc.UserProvider), new(*user.Queries)),
+		wire.Bind(new(oauthhandler.UserProvider), new(*user.Queries)),
+		wire.Bind(new(oauthhandler.IntrospectionUserProvider), new(*user.Queries)),
 		wire.Bind(new(hook.UserProvider), new(*user.RawProvider)),
+		wire.Bind(new(authenticatorlockout.UserProvider), new(*user.RawProvider)),
+		wire.Bind(new(userdeletion.UserQueries), new(*user.Queries)),
+		wire.Bind(new(userdeletion.UserCommands), new(*user.RawCommands)),
+		wire.Bind(new(userimport.UserCommands), new(*user.RawCommands)),
+		wire.Bind(new(userexport.UserQueries), new(*user.Queries)),
+	),
+
+	wire.NewSet(
+		userdeletion.DependencySet,
+	),
+
+	wire.NewSet(
+		userimport.DependencySet,
+	),
+
+	wire.NewSet(
+		userexport.DependencySet,
 	),
 
 	wire.NewSet(
@@ -143,11 +194,15 @@ var CommonDependencySet = wire.NewSet(
 	wire.NewSet(
 		oauthpq.DependencySet,
 		wire.Bind(new(oauth.AuthorizationStore), new(*oauthpq.AuthorizationStore)),
+		wire.Bind(new(userdeletion.AuthorizationStore), new(*oauthpq.AuthorizationStore)),
 
 		oauthredis.DependencySet,
 		wire.Bind(new(oauth.AccessGrantStore), new(*oauthredis.GrantStore)),
 		wire.Bind(new(oauth.CodeGrantStore), new(*oauthredis.GrantStore)),
+		wire.Bind(new(oauth.DeviceGrantStore), new(*oauthredis.GrantStore)),
+		wire.Bind(new(oauth.ClientAssertionStore), new(*oauthredis.GrantStore)),
 		wire.Bind(new(oauth.OfflineGrantStore), new(*oauthredis.GrantStore)),
+		wire.Bind(new(userdeletion.OfflineGrantStore), new(*oauthredis.GrantStore)),
 
 		oauth.DependencySet,
 		wire.Bind(new(session.AccessTokenSessionResolver), new(*oauth.Resolver)),
@@ -162,6 +217,7 @@ var CommonDependencySet = wire.NewSet(
 		oidc.DependencySet,
 		wire.Value(oauthhandler.ScopesValidator(oidc.ValidateScopes)),
 		wire.Bind(new(oauthhandler.IDTokenIssuer), new(*oidc.IDTokenIssuer)),
+		wire.Bind(new(oauthhandler.AccessTokenEncoding), new(*oidc.AccessTokenEncoding)),
 
 		oidchandler.DependencySet,
 	),
@@ -176,6 +232,10 @@ var CommonDependencySet = wire.NewSet(
 		wire.Bind(new(user.VerificationService), new(*verification.Service)),
 		wire.Bind(new(facade.VerificationService), new(*verification.Service)),
 		wire.Bind(new(interaction.VerificationService), new(*verification.Service)),
+		wire.Bind(new(oidc.VerificationService), new(*verification.Service)),
+		wire.Bind(new(userdeletion.VerificationService), new(*verification.Service)),
+		wire.Bind(new(userimport.VerificationService), new(*verification.Service)),
+		wire.Bind(new(userexport.VerificationService), new(*verification.Service)),
 		wire.Bind(new(interaction.VerificationCodeSender), new(*verification.CodeSender)),
 	),
 
@@ -185,6 +245,14 @@ var CommonDependencySet = wire.NewSet(
 		wire.Bind(new(verification.OTPMessageSender), new(*otp.MessageSender)),
 	),
 
+	wire.NewSet(
+		ratelimit.DependencySet,
+		wire.Bind(new(interaction.RateLimiter), new(*ratelimit.Limiter)),
+		wire.Bind(new(otp.RateLimiter), new(*ratelimit.Limiter)),
+		wire.Bind(new(forgotpassword.RateLimiter), new(*ratelimit.Limiter)),
+		wire.Bind(new(oauthhandler.RateLimiter), new(*ratelimit.Limiter)),
+	),
+
 	wire.NewSet(
 		translation.DependencySet,
 		wire.Bind(new(otp.TranslationService), new(*translation.Service)),
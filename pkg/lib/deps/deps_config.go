@@ -24,6 +24,12 @@ var configDeps = wire.NewSet(
 		"ForgotPassword",
 		"WelcomeMessage",
 		"Verification",
+		"RateLimit",
+		"UserProfile",
+		"AuditLog",
+	),
+	wire.FieldsOf(new(*config.AuthenticationConfig),
+		"Lockout",
 	),
 	wire.FieldsOf(new(*config.IdentityConfig),
 		"LoginID",
@@ -34,6 +40,7 @@ var configDeps = wire.NewSet(
 		"Password",
 		"TOTP",
 		"OOB",
+		"WebAuthn",
 	),
 	secretDeps,
 )
@@ -49,6 +56,7 @@ var secretDeps = wire.NewSet(
 	ProvideOIDCKeyMaterials,
 	ProvideCSRFKeyMaterials,
 	ProvideWebhookKeyMaterials,
+	ProvideOAuthClientSecrets,
 )
 
 func ProvideDatabaseCredentials(c *config.SecretConfig) *config.DatabaseCredentials {
@@ -100,3 +108,8 @@ func ProvideWebhookKeyMaterials(c *config.SecretConfig) *config.WebhookKeyMateri
 	s, _ := c.LookupData(config.WebhookKeyMaterialsKey).(*config.WebhookKeyMaterials)
 	return s
 }
+
+func ProvideOAuthClientSecrets(c *config.SecretConfig) *config.OAuthClientSecrets {
+	s, _ := c.LookupData(config.OAuthClientSecretsKey).(*config.OAuthClientSecrets)
+	return s
+}
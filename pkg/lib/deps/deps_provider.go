@@ -6,6 +6,9 @@ import (
 
 	"github.com/google/wire"
 
+	"github.com/authgear/authgear-server/pkg/lib/audit"
+	authenticatorlockout "github.com/authgear/authgear-server/pkg/lib/authn/authenticator/lockout"
+	authenticatorpassword "github.com/authgear/authgear-server/pkg/lib/authn/authenticator/password"
 	"github.com/authgear/authgear-server/pkg/lib/config"
 	"github.com/authgear/authgear-server/pkg/lib/config/configsource"
 	"github.com/authgear/authgear-server/pkg/lib/hook"
@@ -18,6 +21,7 @@ var rootDeps = wire.NewSet(
 		"EnvironmentConfig",
 		"ConfigSourceConfig",
 		"ReservedNameChecker",
+		"OIDCCache",
 	),
 	wire.FieldsOf(new(*config.EnvironmentConfig),
 		"TrustProxy",
@@ -46,6 +50,9 @@ var appRootDeps = wire.NewSet(
 	),
 
 	wire.Bind(new(hook.DatabaseHandle), new(*db.Handle)),
+	wire.Bind(new(authenticatorlockout.DatabaseHandle), new(*db.Handle)),
+	wire.Bind(new(audit.DatabaseHandle), new(*db.Handle)),
+	wire.Bind(new(authenticatorpassword.DatabaseHandle), new(*db.Handle)),
 )
 
 var RootDependencySet = wire.NewSet(
@@ -7,6 +7,7 @@ import (
 
 	"github.com/authgear/authgear-server/pkg/lib/config"
 	"github.com/authgear/authgear-server/pkg/lib/interaction"
+	"github.com/authgear/authgear-server/pkg/lib/session/access"
 	"github.com/authgear/authgear-server/pkg/lib/session/idpsession"
 	"github.com/authgear/authgear-server/pkg/util/httputil"
 )
@@ -17,6 +18,7 @@ var utilsDeps = wire.NewSet(
 		NewCookieFactory,
 		wire.Bind(new(idpsession.CookieFactory), new(*httputil.CookieFactory)),
 		wire.Bind(new(interaction.CookieFactory), new(*httputil.CookieFactory)),
+		ProvideRemoteIP,
 	),
 )
 
@@ -26,3 +28,7 @@ func NewCookieFactory(r *http.Request, trustProxy config.TrustProxy) *httputil.C
 		TrustProxy: bool(trustProxy),
 	}
 }
+
+func ProvideRemoteIP(r *http.Request, trustProxy config.TrustProxy) httputil.RemoteIP {
+	return httputil.RemoteIP(access.RemoteIP(r, bool(trustProxy)))
+}
@@ -7,6 +7,7 @@ import (
 	getsentry "github.com/getsentry/sentry-go"
 
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity/loginid"
+	"github.com/authgear/authgear-server/pkg/lib/authn/sso"
 	"github.com/authgear/authgear-server/pkg/lib/config"
 	"github.com/authgear/authgear-server/pkg/lib/config/configsource"
 	"github.com/authgear/authgear-server/pkg/lib/infra/db"
@@ -27,6 +28,7 @@ type RootProvider struct {
 	RedisPool                *redis.Pool
 	TaskQueueFactory         TaskQueueFactory
 	ReservedNameChecker      *loginid.ReservedNameChecker
+	OIDCCache                *sso.OIDCCache
 	DefaultTemplateDirectory string
 }
 
@@ -71,6 +73,7 @@ func NewRootProvider(
 		RedisPool:                redisPool,
 		TaskQueueFactory:         taskQueueFactory,
 		ReservedNameChecker:      reservedNameChecker,
+		OIDCCache:                sso.NewOIDCCache(),
 		DefaultTemplateDirectory: defaultTemplateDirectory,
 	}
 	return &p, nil
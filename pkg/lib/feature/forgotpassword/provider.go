@@ -11,6 +11,7 @@ import (
 	"github.com/authgear/authgear-server/pkg/lib/infra/mail"
 	"github.com/authgear/authgear-server/pkg/lib/infra/sms"
 	"github.com/authgear/authgear-server/pkg/lib/infra/task"
+	"github.com/authgear/authgear-server/pkg/lib/ratelimit"
 	"github.com/authgear/authgear-server/pkg/lib/tasks"
 	"github.com/authgear/authgear-server/pkg/lib/translation"
 	"github.com/authgear/authgear-server/pkg/util/clock"
@@ -37,6 +38,10 @@ type TranslationService interface {
 	SMSMessageData(msg *translation.MessageSpec, args interface{}) (*translation.SMSMessageData, error)
 }
 
+type RateLimiter interface {
+	TakeToken(bucket ratelimit.Bucket) error
+}
+
 type ProviderLogger struct{ *log.Logger }
 
 func NewProviderLogger(lf *log.Factory) ProviderLogger {
@@ -47,11 +52,13 @@ type Provider struct {
 	StaticAssetURLPrefix config.StaticAssetURLPrefix
 	Translation          TranslationService
 	Config               *config.ForgotPasswordConfig
+	RateLimitConfig      *config.RateLimitConfig
 
-	Store     *Store
-	Clock     clock.Clock
-	URLs      URLProvider
-	TaskQueue task.Queue
+	Store       *Store
+	Clock       clock.Clock
+	URLs        URLProvider
+	TaskQueue   task.Queue
+	RateLimiter RateLimiter
 
 	Logger ProviderLogger
 
@@ -139,6 +146,11 @@ func (p *Provider) newCode(userID string) (code *Code, codeStr string) {
 }
 
 func (p *Provider) sendEmail(email string, code string) error {
+	err := p.takeMessageSendToken(email)
+	if err != nil {
+		return err
+	}
+
 	u := p.URLs.ResetPasswordURL(code)
 
 	appMeta, err := p.Translation.AppMetadata()
@@ -176,6 +188,11 @@ func (p *Provider) sendEmail(email string, code string) error {
 }
 
 func (p *Provider) sendSMS(phone string, code string) (err error) {
+	err = p.takeMessageSendToken(phone)
+	if err != nil {
+		return err
+	}
+
 	u := p.URLs.ResetPasswordURL(code)
 
 	appMeta, err := p.Translation.AppMetadata()
@@ -291,3 +308,9 @@ func (p *Provider) AfterResetPasswordByCode(codeHash string) (err error) {
 
 	return
 }
+
+func (p *Provider) takeMessageSendToken(target string) error {
+	return p.RateLimiter.TakeToken(ratelimit.NewBucket(
+		"MessageSendPerTarget", target, p.RateLimitConfig.MessageSendPerTarget,
+	))
+}
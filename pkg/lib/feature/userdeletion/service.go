@@ -0,0 +1,249 @@
+package userdeletion
+
+import (
+	"errors"
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+	"github.com/authgear/authgear-server/pkg/api/event"
+	"github.com/authgear/authgear-server/pkg/api/model"
+	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator"
+	"github.com/authgear/authgear-server/pkg/lib/authn/identity"
+	"github.com/authgear/authgear-server/pkg/lib/authn/user"
+	"github.com/authgear/authgear-server/pkg/lib/feature/verification"
+	"github.com/authgear/authgear-server/pkg/lib/hook"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/lib/session/idpsession"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+)
+
+//go:generate mockgen -source=service.go -destination=service_mock_test.go -package userdeletion
+
+// scheduledDeletionRetryInterval is the interval of retrying failed
+// scheduled deletions.
+const scheduledDeletionRetryInterval = 1 * time.Hour
+
+type UserQueries interface {
+	Get(id string) (*model.User, error)
+	GetRaw(id string) (*user.User, error)
+	ListIDsToDelete() ([]string, error)
+}
+
+type UserCommands interface {
+	UpdateDeleteAt(userID string, deleteAt *time.Time) error
+	Delete(userID string) error
+}
+
+type IdentityService interface {
+	ListByUser(userID string) ([]*identity.Info, error)
+	Delete(info *identity.Info) error
+}
+
+type AuthenticatorService interface {
+	List(userID string, filters ...authenticator.Filter) ([]*authenticator.Info, error)
+	Delete(info *authenticator.Info) error
+}
+
+type VerificationService interface {
+	GetClaims(userID string) ([]*verification.Claim, error)
+	DeleteClaim(claimID string) error
+}
+
+type MFAService interface {
+	InvalidateAllDeviceTokens(userID string) error
+	InvalidateAllRecoveryCodes(userID string) error
+}
+
+type PasswordHistoryStore interface {
+	ResetPasswordHistory(userID string) error
+}
+
+type LockoutService interface {
+	Unlock(userID string) error
+}
+
+type AuthorizationStore interface {
+	ListByUserID(userID string) ([]*oauth.Authorization, error)
+	Delete(authz *oauth.Authorization) error
+}
+
+type IDPSessionStore interface {
+	List(userID string) ([]*idpsession.IDPSession, error)
+	Delete(s *idpsession.IDPSession) error
+}
+
+type OfflineGrantStore interface {
+	ListOfflineGrants(userID string) ([]*oauth.OfflineGrant, error)
+	DeleteOfflineGrant(g *oauth.OfflineGrant) error
+}
+
+type HookProvider interface {
+	DispatchEvent(payload event.Payload) error
+}
+
+// Service deletes users with all data belonging to them.
+type Service struct {
+	Clock           clock.Clock
+	Users           UserQueries
+	UserCommands    UserCommands
+	Identities      IdentityService
+	Authenticators  AuthenticatorService
+	Verification    VerificationService
+	MFA             MFAService
+	PasswordHistory PasswordHistoryStore
+	Lockout         LockoutService
+	Authorizations  AuthorizationStore
+	IDPSessions     IDPSessionStore
+	OfflineGrants   OfflineGrantStore
+	Hooks           HookProvider
+}
+
+// ScheduleDeletion schedules the user to be deleted by the worker at
+// deleteAt.
+func (s *Service) ScheduleDeletion(userID string, deleteAt time.Time) error {
+	return s.UserCommands.UpdateDeleteAt(userID, &deleteAt)
+}
+
+// UnscheduleDeletion cancels the scheduled deletion of the user.
+func (s *Service) UnscheduleDeletion(userID string) error {
+	return s.UserCommands.UpdateDeleteAt(userID, nil)
+}
+
+// ListScheduled returns the IDs of users due to be deleted.
+func (s *Service) ListScheduled() ([]string, error) {
+	return s.Users.ListIDsToDelete()
+}
+
+// DeleteScheduled deletes the user if the scheduled deletion is due.
+// Users deleted already or no longer scheduled are skipped.
+// If the deletion is vetoed by before_user_delete hook handlers, the
+// scheduled deletion is cancelled.
+func (s *Service) DeleteScheduled(userID string) error {
+	u, err := s.Users.GetRaw(userID)
+	if errors.Is(err, user.ErrUserNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if u.DeleteAt == nil || u.DeleteAt.After(s.Clock.NowUTC()) {
+		return nil
+	}
+
+	err = s.Delete(userID)
+	if apierrors.IsKind(err, hook.WebHookDisallowed) {
+		return s.UserCommands.UpdateDeleteAt(userID, nil)
+	}
+	return err
+}
+
+// PostponeScheduled postpones the scheduled deletion of the user, so that
+// the failed deletion is retried later instead of on every worker run.
+func (s *Service) PostponeScheduled(userID string) error {
+	deleteAt := s.Clock.NowUTC().Add(scheduledDeletionRetryInterval)
+	return s.UserCommands.UpdateDeleteAt(userID, &deleteAt)
+}
+
+// Delete deletes the user permanently. The deletion can be vetoed by
+// before_user_delete hook handlers.
+//
+// Data in database are deleted before those in Redis, so that the user is
+// intact if the transaction is rolled back due to database errors.
+func (s *Service) Delete(userID string) error {
+	userModel, err := s.Users.Get(userID)
+	if err != nil {
+		return err
+	}
+
+	identities, err := s.Identities.ListByUser(userID)
+	if err != nil {
+		return err
+	}
+
+	var identityModels []model.Identity
+	for _, i := range identities {
+		identityModels = append(identityModels, i.ToModel())
+	}
+	err = s.Hooks.DispatchEvent(&event.UserDeleteEvent{
+		User:       *userModel,
+		Identities: identityModels,
+	})
+	if err != nil {
+		return err
+	}
+
+	authzs, err := s.Authorizations.ListByUserID(userID)
+	if err != nil {
+		return err
+	}
+	for _, authz := range authzs {
+		if err := s.Authorizations.Delete(authz); err != nil {
+			return err
+		}
+	}
+
+	for _, i := range identities {
+		if err := s.Identities.Delete(i); err != nil {
+			return err
+		}
+	}
+
+	authenticators, err := s.Authenticators.List(userID)
+	if err != nil {
+		return err
+	}
+	for _, a := range authenticators {
+		if err := s.Authenticators.Delete(a); err != nil {
+			return err
+		}
+	}
+
+	claims, err := s.Verification.GetClaims(userID)
+	if err != nil {
+		return err
+	}
+	for _, c := range claims {
+		if err := s.Verification.DeleteClaim(c.ID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.MFA.InvalidateAllRecoveryCodes(userID); err != nil {
+		return err
+	}
+	if err := s.PasswordHistory.ResetPasswordHistory(userID); err != nil {
+		return err
+	}
+	if err := s.Lockout.Unlock(userID); err != nil {
+		return err
+	}
+	if err := s.UserCommands.Delete(userID); err != nil {
+		return err
+	}
+
+	if err := s.MFA.InvalidateAllDeviceTokens(userID); err != nil {
+		return err
+	}
+
+	idpSessions, err := s.IDPSessions.List(userID)
+	if err != nil {
+		return err
+	}
+	for _, session := range idpSessions {
+		if err := s.IDPSessions.Delete(session); err != nil {
+			return err
+		}
+	}
+
+	offlineGrants, err := s.OfflineGrants.ListOfflineGrants(userID)
+	if err != nil {
+		return err
+	}
+	for _, grant := range offlineGrants {
+		if err := s.OfflineGrants.DeleteOfflineGrant(grant); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,617 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package userdeletion is a generated GoMock package.
+package userdeletion
+
+import (
+	event "github.com/authgear/authgear-server/pkg/api/event"
+	model "github.com/authgear/authgear-server/pkg/api/model"
+	authenticator "github.com/authgear/authgear-server/pkg/lib/authn/authenticator"
+	identity "github.com/authgear/authgear-server/pkg/lib/authn/identity"
+	user "github.com/authgear/authgear-server/pkg/lib/authn/user"
+	verification "github.com/authgear/authgear-server/pkg/lib/feature/verification"
+	oauth "github.com/authgear/authgear-server/pkg/lib/oauth"
+	idpsession "github.com/authgear/authgear-server/pkg/lib/session/idpsession"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+	time "time"
+)
+
+// MockUserQueries is a mock of UserQueries interface
+type MockUserQueries struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserQueriesMockRecorder
+}
+
+// MockUserQueriesMockRecorder is the mock recorder for MockUserQueries
+type MockUserQueriesMockRecorder struct {
+	mock *MockUserQueries
+}
+
+// NewMockUserQueries creates a new mock instance
+func NewMockUserQueries(ctrl *gomock.Controller) *MockUserQueries {
+	mock := &MockUserQueries{ctrl: ctrl}
+	mock.recorder = &MockUserQueriesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockUserQueries) EXPECT() *MockUserQueriesMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method
+func (m *MockUserQueries) Get(id string) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", id)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockUserQueriesMockRecorder) Get(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockUserQueries)(nil).Get), id)
+}
+
+// GetRaw mocks base method
+func (m *MockUserQueries) GetRaw(id string) (*user.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRaw", id)
+	ret0, _ := ret[0].(*user.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRaw indicates an expected call of GetRaw
+func (mr *MockUserQueriesMockRecorder) GetRaw(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRaw", reflect.TypeOf((*MockUserQueries)(nil).GetRaw), id)
+}
+
+// ListIDsToDelete mocks base method
+func (m *MockUserQueries) ListIDsToDelete() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIDsToDelete")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIDsToDelete indicates an expected call of ListIDsToDelete
+func (mr *MockUserQueriesMockRecorder) ListIDsToDelete() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIDsToDelete", reflect.TypeOf((*MockUserQueries)(nil).ListIDsToDelete))
+}
+
+// MockUserCommands is a mock of UserCommands interface
+type MockUserCommands struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserCommandsMockRecorder
+}
+
+// MockUserCommandsMockRecorder is the mock recorder for MockUserCommands
+type MockUserCommandsMockRecorder struct {
+	mock *MockUserCommands
+}
+
+// NewMockUserCommands creates a new mock instance
+func NewMockUserCommands(ctrl *gomock.Controller) *MockUserCommands {
+	mock := &MockUserCommands{ctrl: ctrl}
+	mock.recorder = &MockUserCommandsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockUserCommands) EXPECT() *MockUserCommandsMockRecorder {
+	return m.recorder
+}
+
+// UpdateDeleteAt mocks base method
+func (m *MockUserCommands) UpdateDeleteAt(userID string, deleteAt *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDeleteAt", userID, deleteAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDeleteAt indicates an expected call of UpdateDeleteAt
+func (mr *MockUserCommandsMockRecorder) UpdateDeleteAt(userID, deleteAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDeleteAt", reflect.TypeOf((*MockUserCommands)(nil).UpdateDeleteAt), userID, deleteAt)
+}
+
+// Delete mocks base method
+func (m *MockUserCommands) Delete(userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete
+func (mr *MockUserCommandsMockRecorder) Delete(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserCommands)(nil).Delete), userID)
+}
+
+// MockIdentityService is a mock of IdentityService interface
+type MockIdentityService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdentityServiceMockRecorder
+}
+
+// MockIdentityServiceMockRecorder is the mock recorder for MockIdentityService
+type MockIdentityServiceMockRecorder struct {
+	mock *MockIdentityService
+}
+
+// NewMockIdentityService creates a new mock instance
+func NewMockIdentityService(ctrl *gomock.Controller) *MockIdentityService {
+	mock := &MockIdentityService{ctrl: ctrl}
+	mock.recorder = &MockIdentityServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockIdentityService) EXPECT() *MockIdentityServiceMockRecorder {
+	return m.recorder
+}
+
+// ListByUser mocks base method
+func (m *MockIdentityService) ListByUser(userID string) ([]*identity.Info, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", userID)
+	ret0, _ := ret[0].([]*identity.Info)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser
+func (mr *MockIdentityServiceMockRecorder) ListByUser(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockIdentityService)(nil).ListByUser), userID)
+}
+
+// Delete mocks base method
+func (m *MockIdentityService) Delete(info *identity.Info) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", info)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete
+func (mr *MockIdentityServiceMockRecorder) Delete(info interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIdentityService)(nil).Delete), info)
+}
+
+// MockAuthenticatorService is a mock of AuthenticatorService interface
+type MockAuthenticatorService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthenticatorServiceMockRecorder
+}
+
+// MockAuthenticatorServiceMockRecorder is the mock recorder for MockAuthenticatorService
+type MockAuthenticatorServiceMockRecorder struct {
+	mock *MockAuthenticatorService
+}
+
+// NewMockAuthenticatorService creates a new mock instance
+func NewMockAuthenticatorService(ctrl *gomock.Controller) *MockAuthenticatorService {
+	mock := &MockAuthenticatorService{ctrl: ctrl}
+	mock.recorder = &MockAuthenticatorServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockAuthenticatorService) EXPECT() *MockAuthenticatorServiceMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method
+func (m *MockAuthenticatorService) List(userID string, filters ...authenticator.Filter) ([]*authenticator.Info, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{userID}
+	for _, a := range filters {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "List", varargs...)
+	ret0, _ := ret[0].([]*authenticator.Info)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List
+func (mr *MockAuthenticatorServiceMockRecorder) List(userID interface{}, filters ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{userID}, filters...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAuthenticatorService)(nil).List), varargs...)
+}
+
+// Delete mocks base method
+func (m *MockAuthenticatorService) Delete(info *authenticator.Info) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", info)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete
+func (mr *MockAuthenticatorServiceMockRecorder) Delete(info interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAuthenticatorService)(nil).Delete), info)
+}
+
+// MockVerificationService is a mock of VerificationService interface
+type MockVerificationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockVerificationServiceMockRecorder
+}
+
+// MockVerificationServiceMockRecorder is the mock recorder for MockVerificationService
+type MockVerificationServiceMockRecorder struct {
+	mock *MockVerificationService
+}
+
+// NewMockVerificationService creates a new mock instance
+func NewMockVerificationService(ctrl *gomock.Controller) *MockVerificationService {
+	mock := &MockVerificationService{ctrl: ctrl}
+	mock.recorder = &MockVerificationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockVerificationService) EXPECT() *MockVerificationServiceMockRecorder {
+	return m.recorder
+}
+
+// GetClaims mocks base method
+func (m *MockVerificationService) GetClaims(userID string) ([]*verification.Claim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClaims", userID)
+	ret0, _ := ret[0].([]*verification.Claim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClaims indicates an expected call of GetClaims
+func (mr *MockVerificationServiceMockRecorder) GetClaims(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClaims", reflect.TypeOf((*MockVerificationService)(nil).GetClaims), userID)
+}
+
+// DeleteClaim mocks base method
+func (m *MockVerificationService) DeleteClaim(claimID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteClaim", claimID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteClaim indicates an expected call of DeleteClaim
+func (mr *MockVerificationServiceMockRecorder) DeleteClaim(claimID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClaim", reflect.TypeOf((*MockVerificationService)(nil).DeleteClaim), claimID)
+}
+
+// MockMFAService is a mock of MFAService interface
+type MockMFAService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMFAServiceMockRecorder
+}
+
+// MockMFAServiceMockRecorder is the mock recorder for MockMFAService
+type MockMFAServiceMockRecorder struct {
+	mock *MockMFAService
+}
+
+// NewMockMFAService creates a new mock instance
+func NewMockMFAService(ctrl *gomock.Controller) *MockMFAService {
+	mock := &MockMFAService{ctrl: ctrl}
+	mock.recorder = &MockMFAServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockMFAService) EXPECT() *MockMFAServiceMockRecorder {
+	return m.recorder
+}
+
+// InvalidateAllDeviceTokens mocks base method
+func (m *MockMFAService) InvalidateAllDeviceTokens(userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateAllDeviceTokens", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateAllDeviceTokens indicates an expected call of InvalidateAllDeviceTokens
+func (mr *MockMFAServiceMockRecorder) InvalidateAllDeviceTokens(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateAllDeviceTokens", reflect.TypeOf((*MockMFAService)(nil).InvalidateAllDeviceTokens), userID)
+}
+
+// InvalidateAllRecoveryCodes mocks base method
+func (m *MockMFAService) InvalidateAllRecoveryCodes(userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateAllRecoveryCodes", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateAllRecoveryCodes indicates an expected call of InvalidateAllRecoveryCodes
+func (mr *MockMFAServiceMockRecorder) InvalidateAllRecoveryCodes(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateAllRecoveryCodes", reflect.TypeOf((*MockMFAService)(nil).InvalidateAllRecoveryCodes), userID)
+}
+
+// MockPasswordHistoryStore is a mock of PasswordHistoryStore interface
+type MockPasswordHistoryStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordHistoryStoreMockRecorder
+}
+
+// MockPasswordHistoryStoreMockRecorder is the mock recorder for MockPasswordHistoryStore
+type MockPasswordHistoryStoreMockRecorder struct {
+	mock *MockPasswordHistoryStore
+}
+
+// NewMockPasswordHistoryStore creates a new mock instance
+func NewMockPasswordHistoryStore(ctrl *gomock.Controller) *MockPasswordHistoryStore {
+	mock := &MockPasswordHistoryStore{ctrl: ctrl}
+	mock.recorder = &MockPasswordHistoryStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockPasswordHistoryStore) EXPECT() *MockPasswordHistoryStoreMockRecorder {
+	return m.recorder
+}
+
+// ResetPasswordHistory mocks base method
+func (m *MockPasswordHistoryStore) ResetPasswordHistory(userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPasswordHistory", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPasswordHistory indicates an expected call of ResetPasswordHistory
+func (mr *MockPasswordHistoryStoreMockRecorder) ResetPasswordHistory(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPasswordHistory", reflect.TypeOf((*MockPasswordHistoryStore)(nil).ResetPasswordHistory), userID)
+}
+
+// MockLockoutService is a mock of LockoutService interface
+type MockLockoutService struct {
+	ctrl     *gomock.Controller
+	recorder *MockLockoutServiceMockRecorder
+}
+
+// MockLockoutServiceMockRecorder is the mock recorder for MockLockoutService
+type MockLockoutServiceMockRecorder struct {
+	mock *MockLockoutService
+}
+
+// NewMockLockoutService creates a new mock instance
+func NewMockLockoutService(ctrl *gomock.Controller) *MockLockoutService {
+	mock := &MockLockoutService{ctrl: ctrl}
+	mock.recorder = &MockLockoutServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockLockoutService) EXPECT() *MockLockoutServiceMockRecorder {
+	return m.recorder
+}
+
+// Unlock mocks base method
+func (m *MockLockoutService) Unlock(userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unlock", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unlock indicates an expected call of Unlock
+func (mr *MockLockoutServiceMockRecorder) Unlock(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlock", reflect.TypeOf((*MockLockoutService)(nil).Unlock), userID)
+}
+
+// MockAuthorizationStore is a mock of AuthorizationStore interface
+type MockAuthorizationStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthorizationStoreMockRecorder
+}
+
+// MockAuthorizationStoreMockRecorder is the mock recorder for MockAuthorizationStore
+type MockAuthorizationStoreMockRecorder struct {
+	mock *MockAuthorizationStore
+}
+
+// NewMockAuthorizationStore creates a new mock instance
+func NewMockAuthorizationStore(ctrl *gomock.Controller) *MockAuthorizationStore {
+	mock := &MockAuthorizationStore{ctrl: ctrl}
+	mock.recorder = &MockAuthorizationStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockAuthorizationStore) EXPECT() *MockAuthorizationStoreMockRecorder {
+	return m.recorder
+}
+
+// ListByUserID mocks base method
+func (m *MockAuthorizationStore) ListByUserID(userID string) ([]*oauth.Authorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUserID", userID)
+	ret0, _ := ret[0].([]*oauth.Authorization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUserID indicates an expected call of ListByUserID
+func (mr *MockAuthorizationStoreMockRecorder) ListByUserID(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUserID", reflect.TypeOf((*MockAuthorizationStore)(nil).ListByUserID), userID)
+}
+
+// Delete mocks base method
+func (m *MockAuthorizationStore) Delete(authz *oauth.Authorization) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", authz)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete
+func (mr *MockAuthorizationStoreMockRecorder) Delete(authz interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAuthorizationStore)(nil).Delete), authz)
+}
+
+// MockIDPSessionStore is a mock of IDPSessionStore interface
+type MockIDPSessionStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockIDPSessionStoreMockRecorder
+}
+
+// MockIDPSessionStoreMockRecorder is the mock recorder for MockIDPSessionStore
+type MockIDPSessionStoreMockRecorder struct {
+	mock *MockIDPSessionStore
+}
+
+// NewMockIDPSessionStore creates a new mock instance
+func NewMockIDPSessionStore(ctrl *gomock.Controller) *MockIDPSessionStore {
+	mock := &MockIDPSessionStore{ctrl: ctrl}
+	mock.recorder = &MockIDPSessionStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockIDPSessionStore) EXPECT() *MockIDPSessionStoreMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method
+func (m *MockIDPSessionStore) List(userID string) ([]*idpsession.IDPSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", userID)
+	ret0, _ := ret[0].([]*idpsession.IDPSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List
+func (mr *MockIDPSessionStoreMockRecorder) List(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockIDPSessionStore)(nil).List), userID)
+}
+
+// Delete mocks base method
+func (m *MockIDPSessionStore) Delete(s *idpsession.IDPSession) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", s)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete
+func (mr *MockIDPSessionStoreMockRecorder) Delete(s interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIDPSessionStore)(nil).Delete), s)
+}
+
+// MockOfflineGrantStore is a mock of OfflineGrantStore interface
+type MockOfflineGrantStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockOfflineGrantStoreMockRecorder
+}
+
+// MockOfflineGrantStoreMockRecorder is the mock recorder for MockOfflineGrantStore
+type MockOfflineGrantStoreMockRecorder struct {
+	mock *MockOfflineGrantStore
+}
+
+// NewMockOfflineGrantStore creates a new mock instance
+func NewMockOfflineGrantStore(ctrl *gomock.Controller) *MockOfflineGrantStore {
+	mock := &MockOfflineGrantStore{ctrl: ctrl}
+	mock.recorder = &MockOfflineGrantStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockOfflineGrantStore) EXPECT() *MockOfflineGrantStoreMockRecorder {
+	return m.recorder
+}
+
+// ListOfflineGrants mocks base method
+func (m *MockOfflineGrantStore) ListOfflineGrants(userID string) ([]*oauth.OfflineGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOfflineGrants", userID)
+	ret0, _ := ret[0].([]*oauth.OfflineGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOfflineGrants indicates an expected call of ListOfflineGrants
+func (mr *MockOfflineGrantStoreMockRecorder) ListOfflineGrants(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOfflineGrants", reflect.TypeOf((*MockOfflineGrantStore)(nil).ListOfflineGrants), userID)
+}
+
+// DeleteOfflineGrant mocks base method
+func (m *MockOfflineGrantStore) DeleteOfflineGrant(g *oauth.OfflineGrant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOfflineGrant", g)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOfflineGrant indicates an expected call of DeleteOfflineGrant
+func (mr *MockOfflineGrantStoreMockRecorder) DeleteOfflineGrant(g interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOfflineGrant", reflect.TypeOf((*MockOfflineGrantStore)(nil).DeleteOfflineGrant), g)
+}
+
+// MockHookProvider is a mock of HookProvider interface
+type MockHookProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockHookProviderMockRecorder
+}
+
+// MockHookProviderMockRecorder is the mock recorder for MockHookProvider
+type MockHookProviderMockRecorder struct {
+	mock *MockHookProvider
+}
+
+// NewMockHookProvider creates a new mock instance
+func NewMockHookProvider(ctrl *gomock.Controller) *MockHookProvider {
+	mock := &MockHookProvider{ctrl: ctrl}
+	mock.recorder = &MockHookProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockHookProvider) EXPECT() *MockHookProviderMockRecorder {
+	return m.recorder
+}
+
+// DispatchEvent mocks base method
+func (m *MockHookProvider) DispatchEvent(payload event.Payload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DispatchEvent", payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DispatchEvent indicates an expected call of DispatchEvent
+func (mr *MockHookProviderMockRecorder) DispatchEvent(payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DispatchEvent", reflect.TypeOf((*MockHookProvider)(nil).DispatchEvent), payload)
+}
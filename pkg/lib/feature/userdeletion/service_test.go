@@ -0,0 +1,153 @@
+package userdeletion
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/api/event"
+	"github.com/authgear/authgear-server/pkg/api/model"
+	"github.com/authgear/authgear-server/pkg/lib/authn"
+	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator"
+	"github.com/authgear/authgear-server/pkg/lib/authn/identity"
+	"github.com/authgear/authgear-server/pkg/lib/authn/user"
+	"github.com/authgear/authgear-server/pkg/lib/feature/verification"
+	"github.com/authgear/authgear-server/pkg/lib/hook"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/lib/session/idpsession"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+)
+
+func TestService(t *testing.T) {
+	Convey("Service", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		users := NewMockUserQueries(ctrl)
+		userCommands := NewMockUserCommands(ctrl)
+		identities := NewMockIdentityService(ctrl)
+		authenticators := NewMockAuthenticatorService(ctrl)
+		verificationService := NewMockVerificationService(ctrl)
+		mfa := NewMockMFAService(ctrl)
+		passwordHistory := NewMockPasswordHistoryStore(ctrl)
+		lockout := NewMockLockoutService(ctrl)
+		authorizations := NewMockAuthorizationStore(ctrl)
+		idpSessions := NewMockIDPSessionStore(ctrl)
+		offlineGrants := NewMockOfflineGrantStore(ctrl)
+		hooks := NewMockHookProvider(ctrl)
+		clk := clock.NewMockClockAt("2020-09-26T00:00:00Z")
+		now := clk.NowUTC()
+
+		s := &Service{
+			Clock:           clk,
+			Users:           users,
+			UserCommands:    userCommands,
+			Identities:      identities,
+			Authenticators:  authenticators,
+			Verification:    verificationService,
+			MFA:             mfa,
+			PasswordHistory: passwordHistory,
+			Lockout:         lockout,
+			Authorizations:  authorizations,
+			IDPSessions:     idpSessions,
+			OfflineGrants:   offlineGrants,
+			Hooks:           hooks,
+		}
+
+		userModel := &model.User{Meta: model.Meta{ID: "user-id"}}
+		identityInfo := &identity.Info{
+			ID:     "identity-id",
+			UserID: "user-id",
+			Type:   authn.IdentityTypeLoginID,
+			Claims: map[string]interface{}{},
+		}
+		authenticatorInfo := &authenticator.Info{
+			ID:     "authenticator-id",
+			UserID: "user-id",
+			Type:   authn.AuthenticatorTypePassword,
+		}
+		authz := &oauth.Authorization{ID: "authz-id", UserID: "user-id"}
+		claim := &verification.Claim{ID: "claim-id", UserID: "user-id"}
+		idpSession := &idpsession.IDPSession{ID: "session-id"}
+		offlineGrant := &oauth.OfflineGrant{ID: "grant-id"}
+
+		Convey("should delete user with all data", func() {
+			users.EXPECT().Get("user-id").Return(userModel, nil)
+			identities.EXPECT().ListByUser("user-id").Return([]*identity.Info{identityInfo}, nil)
+			gomock.InOrder(
+				hooks.EXPECT().DispatchEvent(&event.UserDeleteEvent{
+					User:       *userModel,
+					Identities: []model.Identity{identityInfo.ToModel()},
+				}).Return(nil),
+				authorizations.EXPECT().ListByUserID("user-id").Return([]*oauth.Authorization{authz}, nil),
+				authorizations.EXPECT().Delete(authz).Return(nil),
+				identities.EXPECT().Delete(identityInfo).Return(nil),
+				authenticators.EXPECT().List("user-id").Return([]*authenticator.Info{authenticatorInfo}, nil),
+				authenticators.EXPECT().Delete(authenticatorInfo).Return(nil),
+				verificationService.EXPECT().GetClaims("user-id").Return([]*verification.Claim{claim}, nil),
+				verificationService.EXPECT().DeleteClaim("claim-id").Return(nil),
+				mfa.EXPECT().InvalidateAllRecoveryCodes("user-id").Return(nil),
+				passwordHistory.EXPECT().ResetPasswordHistory("user-id").Return(nil),
+				lockout.EXPECT().Unlock("user-id").Return(nil),
+				userCommands.EXPECT().Delete("user-id").Return(nil),
+				mfa.EXPECT().InvalidateAllDeviceTokens("user-id").Return(nil),
+				idpSessions.EXPECT().List("user-id").Return([]*idpsession.IDPSession{idpSession}, nil),
+				idpSessions.EXPECT().Delete(idpSession).Return(nil),
+				offlineGrants.EXPECT().ListOfflineGrants("user-id").Return([]*oauth.OfflineGrant{offlineGrant}, nil),
+				offlineGrants.EXPECT().DeleteOfflineGrant(offlineGrant).Return(nil),
+			)
+
+			So(s.Delete("user-id"), ShouldBeNil)
+		})
+
+		Convey("should not delete anything if vetoed by hook", func() {
+			vetoErr := errors.New("disallowed")
+			users.EXPECT().Get("user-id").Return(userModel, nil)
+			identities.EXPECT().ListByUser("user-id").Return(nil, nil)
+			hooks.EXPECT().DispatchEvent(gomock.Any()).Return(vetoErr)
+
+			So(s.Delete("user-id"), ShouldEqual, vetoErr)
+		})
+
+		Convey("should skip users not due for deletion", func() {
+			deleteAt := now.Add(time.Hour)
+			users.EXPECT().GetRaw("user-id").Return(&user.User{ID: "user-id", DeleteAt: &deleteAt}, nil)
+			So(s.DeleteScheduled("user-id"), ShouldBeNil)
+
+			users.EXPECT().GetRaw("user-id").Return(&user.User{ID: "user-id"}, nil)
+			So(s.DeleteScheduled("user-id"), ShouldBeNil)
+
+			users.EXPECT().GetRaw("user-id").Return(nil, user.ErrUserNotFound)
+			So(s.DeleteScheduled("user-id"), ShouldBeNil)
+		})
+
+		Convey("should delete users due for deletion", func() {
+			deleteAt := now
+			users.EXPECT().GetRaw("user-id").Return(&user.User{ID: "user-id", DeleteAt: &deleteAt}, nil)
+			users.EXPECT().Get("user-id").Return(nil, user.ErrUserNotFound)
+
+			So(s.DeleteScheduled("user-id"), ShouldEqual, user.ErrUserNotFound)
+		})
+
+		Convey("should cancel scheduled deletion if vetoed by hook", func() {
+			deleteAt := now
+			users.EXPECT().GetRaw("user-id").Return(&user.User{ID: "user-id", DeleteAt: &deleteAt}, nil)
+			users.EXPECT().Get("user-id").Return(userModel, nil)
+			identities.EXPECT().ListByUser("user-id").Return(nil, nil)
+			hooks.EXPECT().DispatchEvent(gomock.Any()).Return(hook.WebHookDisallowed.New("disallowed"))
+			userCommands.EXPECT().UpdateDeleteAt("user-id", nil).Return(nil)
+
+			So(s.DeleteScheduled("user-id"), ShouldBeNil)
+		})
+
+		Convey("should postpone failed scheduled deletion", func() {
+			deleteAt := now.Add(scheduledDeletionRetryInterval)
+			userCommands.EXPECT().UpdateDeleteAt("user-id", &deleteAt).Return(nil)
+
+			So(s.PostponeScheduled("user-id"), ShouldBeNil)
+		})
+	})
+}
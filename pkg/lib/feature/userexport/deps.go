@@ -0,0 +1,12 @@
+package userexport
+
+import (
+	"github.com/google/wire"
+)
+
+var DependencySet = wire.NewSet(
+	wire.Struct(new(StorePQ), "*"),
+	wire.Bind(new(Store), new(*StorePQ)),
+	wire.Struct(new(Service), "*"),
+	wire.Struct(new(Pruner), "*"),
+)
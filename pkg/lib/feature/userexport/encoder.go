@@ -0,0 +1,85 @@
+package userexport
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"time"
+)
+
+var csvHeader = []string{
+	"id",
+	"created_at",
+	"updated_at",
+	"last_login_at",
+	"is_disabled",
+	"labels",
+	"profile",
+	"identities",
+	"authenticators",
+	"verified_claims",
+}
+
+// encodeRecords encodes the records in format. The CSV header is written
+// before the records if withHeader is true.
+func encodeRecords(format Format, records []*Record, withHeader bool) ([]byte, error) {
+	var buf bytes.Buffer
+	switch format {
+	case FormatCSV:
+		w := csv.NewWriter(&buf)
+		if withHeader {
+			if err := w.Write(csvHeader); err != nil {
+				return nil, err
+			}
+		}
+		for _, r := range records {
+			row, err := csvRow(r)
+			if err != nil {
+				return nil, err
+			}
+			if err := w.Write(row); err != nil {
+				return nil, err
+			}
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return nil, err
+		}
+	default:
+		encoder := json.NewEncoder(&buf)
+		for _, r := range records {
+			if err := encoder.Encode(r); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// csvRow flattens the record. Nested values are encoded as JSON.
+func csvRow(r *Record) ([]string, error) {
+	lastLoginAt := ""
+	if r.LastLoginAt != nil {
+		lastLoginAt = r.LastLoginAt.Format(time.RFC3339)
+	}
+	isDisabled := "false"
+	if r.IsDisabled {
+		isDisabled = "true"
+	}
+
+	row := []string{
+		r.ID,
+		r.CreatedAt.Format(time.RFC3339),
+		r.UpdatedAt.Format(time.RFC3339),
+		lastLoginAt,
+		isDisabled,
+	}
+	for _, v := range []interface{}{r.Labels, r.Profile, r.Identities, r.Authenticators, r.VerifiedClaims} {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		row = append(row, string(b))
+	}
+	return row, nil
+}
@@ -0,0 +1,44 @@
+package userexport
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrJobNotFound = errors.New("user export not found")
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+)
+
+type Format string
+
+const (
+	FormatNDJSON Format = "ndjson"
+	FormatCSV    Format = "csv"
+)
+
+func (f Format) IsValid() bool {
+	return f == FormatNDJSON || f == FormatCSV
+}
+
+// Job is an export of all users of the app. The output is stored in chunks,
+// which are written as the users are exported.
+type Job struct {
+	ID             string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	CompletedAt    *time.Time
+	ExpireAt       time.Time
+	Status         Status
+	Format         Format
+	IncludeSecrets bool
+	TotalUsers     int
+	ExportedUsers  int
+	Error          *string
+}
@@ -0,0 +1,16 @@
+package userexport
+
+import (
+	"github.com/authgear/authgear-server/pkg/util/clock"
+)
+
+// Pruner deletes the expired exports.
+type Pruner struct {
+	Clock clock.Clock
+	Store *StorePQ
+}
+
+// Prune returns the number of deleted exports.
+func (p *Pruner) Prune() (int64, error) {
+	return p.Store.DeleteExpired(p.Clock.NowUTC())
+}
@@ -0,0 +1,46 @@
+package userexport
+
+import (
+	"time"
+)
+
+// Record is an exported user.
+type Record struct {
+	ID             string                 `json:"id"`
+	CreatedAt      time.Time              `json:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+	LastLoginAt    *time.Time             `json:"last_login_at,omitempty"`
+	IsDisabled     bool                   `json:"is_disabled"`
+	Labels         map[string]interface{} `json:"labels"`
+	Profile        map[string]interface{} `json:"profile"`
+	Identities     []IdentityRecord       `json:"identities"`
+	Authenticators []AuthenticatorRecord  `json:"authenticators"`
+	VerifiedClaims []VerifiedClaimRecord  `json:"verified_claims"`
+}
+
+type IdentityRecord struct {
+	ID        string                 `json:"id"`
+	Type      string                 `json:"type"`
+	Claims    map[string]interface{} `json:"claims"`
+	CreatedAt time.Time              `json:"created_at"`
+	UpdatedAt time.Time              `json:"updated_at"`
+}
+
+// AuthenticatorRecord is an exported authenticator. Secret is included only
+// if the export is requested to include secrets.
+type AuthenticatorRecord struct {
+	ID        string                 `json:"id"`
+	Type      string                 `json:"type"`
+	Kind      string                 `json:"kind"`
+	IsDefault bool                   `json:"is_default"`
+	Claims    map[string]interface{} `json:"claims"`
+	Secret    string                 `json:"secret,omitempty"`
+	CreatedAt time.Time              `json:"created_at"`
+	UpdatedAt time.Time              `json:"updated_at"`
+}
+
+type VerifiedClaimRecord struct {
+	Name      string    `json:"name"`
+	Value     string    `json:"value"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,307 @@
+package userexport
+
+import (
+	"errors"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator"
+	"github.com/authgear/authgear-server/pkg/lib/authn/identity"
+	"github.com/authgear/authgear-server/pkg/lib/authn/user"
+	"github.com/authgear/authgear-server/pkg/lib/feature/verification"
+	"github.com/authgear/authgear-server/pkg/lib/infra/task"
+	"github.com/authgear/authgear-server/pkg/lib/tasks"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+	"github.com/authgear/authgear-server/pkg/util/uuid"
+)
+
+//go:generate mockgen -source=service.go -destination=service_mock_test.go -package userexport
+
+// batchSize is the number of users exported in a transaction.
+const batchSize = 100
+
+// jobExpiry is the duration that an export can be downloaded after it is
+// created. The output may include secrets, so it is not kept for long.
+const jobExpiry = 24 * time.Hour
+
+type Store interface {
+	Create(job *Job) error
+	Get(id string) (*Job, error)
+	Update(job *Job) error
+	CreateChunk(jobID string, seq int, data []byte) error
+	GetChunk(jobID string, seq int) ([]byte, error)
+}
+
+type UserQueries interface {
+	Count(filter user.Filter) (uint64, error)
+	ListRawAfter(afterID string, limit uint64) ([]*user.User, error)
+}
+
+type IdentityService interface {
+	ListRefsByUsers(userIDs []string) ([]*identity.Ref, error)
+	GetMany(refs []*identity.Ref) ([]*identity.Info, error)
+}
+
+type AuthenticatorService interface {
+	ListRefsByUsers(userIDs []string) ([]*authenticator.Ref, error)
+	GetMany(refs []*authenticator.Ref) ([]*authenticator.Info, error)
+}
+
+type VerificationService interface {
+	GetClaims(userID string) ([]*verification.Claim, error)
+}
+
+// Cursor tracks the progress of a running export.
+type Cursor struct {
+	AfterUserID string
+	Seq         int
+}
+
+// Service exports all users of the app.
+//
+// Exports are run by the worker in batches, each in its own transaction,
+// so that exporting large number of users does not time out requests.
+type Service struct {
+	Clock          clock.Clock
+	Store          Store
+	Users          UserQueries
+	Identities     IdentityService
+	Authenticators AuthenticatorService
+	Verification   VerificationService
+	TaskQueue      task.Queue
+}
+
+// CreateJob creates an export and enqueues it to be run by the worker.
+func (s *Service) CreateJob(format Format, includeSecrets bool) (*Job, error) {
+	if !format.IsValid() {
+		return nil, apierrors.NewInvalid("invalid export format")
+	}
+
+	now := s.Clock.NowUTC()
+	job := &Job{
+		ID:             uuid.New(),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		ExpireAt:       now.Add(jobExpiry),
+		Status:         StatusPending,
+		Format:         format,
+		IncludeSecrets: includeSecrets,
+	}
+	if err := s.Store.Create(job); err != nil {
+		return nil, err
+	}
+
+	s.TaskQueue.Enqueue(&tasks.ExportUsersParam{JobID: job.ID})
+	return job, nil
+}
+
+// GetJob returns the export. Expired exports are not found even if they
+// are not pruned yet.
+func (s *Service) GetJob(id string) (*Job, error) {
+	job, err := s.Store.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if !job.ExpireAt.After(s.Clock.NowUTC()) {
+		return nil, ErrJobNotFound
+	}
+	return job, nil
+}
+
+// ReadChunk reads the output of the completed export by chunks, starting
+// from seq 0. io.EOF is returned after the last chunk.
+func (s *Service) ReadChunk(jobID string, seq int) ([]byte, error) {
+	data, err := s.Store.GetChunk(jobID, seq)
+	if errors.Is(err, errChunkNotFound) {
+		return nil, io.EOF
+	} else if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Start marks the pending export as running, and writes the CSV header if
+// needed.
+func (s *Service) Start(jobID string) (*Job, *Cursor, error) {
+	job, err := s.Store.Get(jobID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if job.Status != StatusPending {
+		return nil, nil, errors.New("userexport: export is not pending")
+	}
+
+	total, err := s.Users.Count(user.Filter{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cursor := &Cursor{}
+	if job.Format == FormatCSV {
+		data, err := encodeRecords(job.Format, nil, true)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := s.Store.CreateChunk(job.ID, cursor.Seq, data); err != nil {
+			return nil, nil, err
+		}
+		cursor.Seq++
+	}
+
+	job.Status = StatusRunning
+	job.TotalUsers = int(total)
+	job.UpdatedAt = s.Clock.NowUTC()
+	if err := s.Store.Update(job); err != nil {
+		return nil, nil, err
+	}
+
+	return job, cursor, nil
+}
+
+// ExportBatch exports the next batch of users and advances the cursor.
+// done is true if all users have been exported.
+func (s *Service) ExportBatch(job *Job, cursor *Cursor) (done bool, err error) {
+	users, err := s.Users.ListRawAfter(cursor.AfterUserID, batchSize)
+	if err != nil {
+		return false, err
+	}
+	if len(users) == 0 {
+		return true, nil
+	}
+
+	records, err := s.buildRecords(users, job.IncludeSecrets)
+	if err != nil {
+		return false, err
+	}
+
+	data, err := encodeRecords(job.Format, records, false)
+	if err != nil {
+		return false, err
+	}
+	if err := s.Store.CreateChunk(job.ID, cursor.Seq, data); err != nil {
+		return false, err
+	}
+
+	job.ExportedUsers += len(users)
+	job.UpdatedAt = s.Clock.NowUTC()
+	if err := s.Store.Update(job); err != nil {
+		return false, err
+	}
+
+	cursor.AfterUserID = users[len(users)-1].ID
+	cursor.Seq++
+	return false, nil
+}
+
+func (s *Service) Complete(job *Job) error {
+	now := s.Clock.NowUTC()
+	job.Status = StatusCompleted
+	job.UpdatedAt = now
+	job.CompletedAt = &now
+	return s.Store.Update(job)
+}
+
+func (s *Service) Fail(jobID string, cause error) error {
+	job, err := s.Store.Get(jobID)
+	if err != nil {
+		return err
+	}
+
+	now := s.Clock.NowUTC()
+	msg := cause.Error()
+	job.Status = StatusFailed
+	job.UpdatedAt = now
+	job.CompletedAt = &now
+	job.Error = &msg
+	return s.Store.Update(job)
+}
+
+func (s *Service) buildRecords(users []*user.User, includeSecrets bool) ([]*Record, error) {
+	userIDs := make([]string, len(users))
+	records := make([]*Record, len(users))
+	recordsByUserID := map[string]*Record{}
+	for i, u := range users {
+		userIDs[i] = u.ID
+		records[i] = &Record{
+			ID:             u.ID,
+			CreatedAt:      u.CreatedAt,
+			UpdatedAt:      u.UpdatedAt,
+			LastLoginAt:    u.LastLoginAt,
+			IsDisabled:     u.IsDisabled,
+			Labels:         u.Labels,
+			Profile:        u.Profile,
+			Identities:     []IdentityRecord{},
+			Authenticators: []AuthenticatorRecord{},
+			VerifiedClaims: []VerifiedClaimRecord{},
+		}
+		recordsByUserID[u.ID] = records[i]
+	}
+
+	identityRefs, err := s.Identities.ListRefsByUsers(userIDs)
+	if err != nil {
+		return nil, err
+	}
+	identities, err := s.Identities.GetMany(identityRefs)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(identities, func(i, j int) bool {
+		return identities[i].CreatedAt.Before(identities[j].CreatedAt)
+	})
+	for _, i := range identities {
+		r := recordsByUserID[i.UserID]
+		r.Identities = append(r.Identities, IdentityRecord{
+			ID:        i.ID,
+			Type:      string(i.Type),
+			Claims:    i.Claims,
+			CreatedAt: i.CreatedAt,
+			UpdatedAt: i.UpdatedAt,
+		})
+	}
+
+	authenticatorRefs, err := s.Authenticators.ListRefsByUsers(userIDs)
+	if err != nil {
+		return nil, err
+	}
+	authenticators, err := s.Authenticators.GetMany(authenticatorRefs)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(authenticators, func(i, j int) bool {
+		return authenticators[i].CreatedAt.Before(authenticators[j].CreatedAt)
+	})
+	for _, a := range authenticators {
+		r := recordsByUserID[a.UserID]
+		ar := AuthenticatorRecord{
+			ID:        a.ID,
+			Type:      string(a.Type),
+			Kind:      string(a.Kind),
+			IsDefault: a.IsDefault,
+			Claims:    a.Claims,
+			CreatedAt: a.CreatedAt,
+			UpdatedAt: a.UpdatedAt,
+		}
+		if includeSecrets {
+			ar.Secret = a.Secret
+		}
+		r.Authenticators = append(r.Authenticators, ar)
+	}
+
+	for _, r := range records {
+		claims, err := s.Verification.GetClaims(r.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range claims {
+			r.VerifiedClaims = append(r.VerifiedClaims, VerifiedClaimRecord{
+				Name:      c.Name,
+				Value:     c.Value,
+				CreatedAt: c.CreatedAt,
+			})
+		}
+	}
+
+	return records, nil
+}
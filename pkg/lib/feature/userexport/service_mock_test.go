@@ -0,0 +1,306 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package userexport is a generated GoMock package.
+package userexport
+
+import (
+	authenticator "github.com/authgear/authgear-server/pkg/lib/authn/authenticator"
+	identity "github.com/authgear/authgear-server/pkg/lib/authn/identity"
+	user "github.com/authgear/authgear-server/pkg/lib/authn/user"
+	verification "github.com/authgear/authgear-server/pkg/lib/feature/verification"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockStore is a mock of Store interface
+type MockStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreMockRecorder
+}
+
+// MockStoreMockRecorder is the mock recorder for MockStore
+type MockStoreMockRecorder struct {
+	mock *MockStore
+}
+
+// NewMockStore creates a new mock instance
+func NewMockStore(ctrl *gomock.Controller) *MockStore {
+	mock := &MockStore{ctrl: ctrl}
+	mock.recorder = &MockStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockStore) EXPECT() *MockStoreMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method
+func (m *MockStore) Create(job *Job) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create
+func (mr *MockStoreMockRecorder) Create(job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockStore)(nil).Create), job)
+}
+
+// Get mocks base method
+func (m *MockStore) Get(id string) (*Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", id)
+	ret0, _ := ret[0].(*Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockStoreMockRecorder) Get(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockStore)(nil).Get), id)
+}
+
+// Update mocks base method
+func (m *MockStore) Update(job *Job) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update
+func (mr *MockStoreMockRecorder) Update(job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockStore)(nil).Update), job)
+}
+
+// CreateChunk mocks base method
+func (m *MockStore) CreateChunk(jobID string, seq int, data []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateChunk", jobID, seq, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateChunk indicates an expected call of CreateChunk
+func (mr *MockStoreMockRecorder) CreateChunk(jobID, seq, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChunk", reflect.TypeOf((*MockStore)(nil).CreateChunk), jobID, seq, data)
+}
+
+// GetChunk mocks base method
+func (m *MockStore) GetChunk(jobID string, seq int) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChunk", jobID, seq)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChunk indicates an expected call of GetChunk
+func (mr *MockStoreMockRecorder) GetChunk(jobID, seq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChunk", reflect.TypeOf((*MockStore)(nil).GetChunk), jobID, seq)
+}
+
+// MockUserQueries is a mock of UserQueries interface
+type MockUserQueries struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserQueriesMockRecorder
+}
+
+// MockUserQueriesMockRecorder is the mock recorder for MockUserQueries
+type MockUserQueriesMockRecorder struct {
+	mock *MockUserQueries
+}
+
+// NewMockUserQueries creates a new mock instance
+func NewMockUserQueries(ctrl *gomock.Controller) *MockUserQueries {
+	mock := &MockUserQueries{ctrl: ctrl}
+	mock.recorder = &MockUserQueriesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockUserQueries) EXPECT() *MockUserQueriesMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method
+func (m *MockUserQueries) Count(filter user.Filter) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", filter)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count
+func (mr *MockUserQueriesMockRecorder) Count(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockUserQueries)(nil).Count), filter)
+}
+
+// ListRawAfter mocks base method
+func (m *MockUserQueries) ListRawAfter(afterID string, limit uint64) ([]*user.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRawAfter", afterID, limit)
+	ret0, _ := ret[0].([]*user.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRawAfter indicates an expected call of ListRawAfter
+func (mr *MockUserQueriesMockRecorder) ListRawAfter(afterID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRawAfter", reflect.TypeOf((*MockUserQueries)(nil).ListRawAfter), afterID, limit)
+}
+
+// MockIdentityService is a mock of IdentityService interface
+type MockIdentityService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdentityServiceMockRecorder
+}
+
+// MockIdentityServiceMockRecorder is the mock recorder for MockIdentityService
+type MockIdentityServiceMockRecorder struct {
+	mock *MockIdentityService
+}
+
+// NewMockIdentityService creates a new mock instance
+func NewMockIdentityService(ctrl *gomock.Controller) *MockIdentityService {
+	mock := &MockIdentityService{ctrl: ctrl}
+	mock.recorder = &MockIdentityServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockIdentityService) EXPECT() *MockIdentityServiceMockRecorder {
+	return m.recorder
+}
+
+// ListRefsByUsers mocks base method
+func (m *MockIdentityService) ListRefsByUsers(userIDs []string) ([]*identity.Ref, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRefsByUsers", userIDs)
+	ret0, _ := ret[0].([]*identity.Ref)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRefsByUsers indicates an expected call of ListRefsByUsers
+func (mr *MockIdentityServiceMockRecorder) ListRefsByUsers(userIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRefsByUsers", reflect.TypeOf((*MockIdentityService)(nil).ListRefsByUsers), userIDs)
+}
+
+// GetMany mocks base method
+func (m *MockIdentityService) GetMany(refs []*identity.Ref) ([]*identity.Info, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMany", refs)
+	ret0, _ := ret[0].([]*identity.Info)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMany indicates an expected call of GetMany
+func (mr *MockIdentityServiceMockRecorder) GetMany(refs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMany", reflect.TypeOf((*MockIdentityService)(nil).GetMany), refs)
+}
+
+// MockAuthenticatorService is a mock of AuthenticatorService interface
+type MockAuthenticatorService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthenticatorServiceMockRecorder
+}
+
+// MockAuthenticatorServiceMockRecorder is the mock recorder for MockAuthenticatorService
+type MockAuthenticatorServiceMockRecorder struct {
+	mock *MockAuthenticatorService
+}
+
+// NewMockAuthenticatorService creates a new mock instance
+func NewMockAuthenticatorService(ctrl *gomock.Controller) *MockAuthenticatorService {
+	mock := &MockAuthenticatorService{ctrl: ctrl}
+	mock.recorder = &MockAuthenticatorServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockAuthenticatorService) EXPECT() *MockAuthenticatorServiceMockRecorder {
+	return m.recorder
+}
+
+// ListRefsByUsers mocks base method
+func (m *MockAuthenticatorService) ListRefsByUsers(userIDs []string) ([]*authenticator.Ref, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRefsByUsers", userIDs)
+	ret0, _ := ret[0].([]*authenticator.Ref)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRefsByUsers indicates an expected call of ListRefsByUsers
+func (mr *MockAuthenticatorServiceMockRecorder) ListRefsByUsers(userIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRefsByUsers", reflect.TypeOf((*MockAuthenticatorService)(nil).ListRefsByUsers), userIDs)
+}
+
+// GetMany mocks base method
+func (m *MockAuthenticatorService) GetMany(refs []*authenticator.Ref) ([]*authenticator.Info, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMany", refs)
+	ret0, _ := ret[0].([]*authenticator.Info)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMany indicates an expected call of GetMany
+func (mr *MockAuthenticatorServiceMockRecorder) GetMany(refs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMany", reflect.TypeOf((*MockAuthenticatorService)(nil).GetMany), refs)
+}
+
+// MockVerificationService is a mock of VerificationService interface
+type MockVerificationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockVerificationServiceMockRecorder
+}
+
+// MockVerificationServiceMockRecorder is the mock recorder for MockVerificationService
+type MockVerificationServiceMockRecorder struct {
+	mock *MockVerificationService
+}
+
+// NewMockVerificationService creates a new mock instance
+func NewMockVerificationService(ctrl *gomock.Controller) *MockVerificationService {
+	mock := &MockVerificationService{ctrl: ctrl}
+	mock.recorder = &MockVerificationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockVerificationService) EXPECT() *MockVerificationServiceMockRecorder {
+	return m.recorder
+}
+
+// GetClaims mocks base method
+func (m *MockVerificationService) GetClaims(userID string) ([]*verification.Claim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClaims", userID)
+	ret0, _ := ret[0].([]*verification.Claim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClaims indicates an expected call of GetClaims
+func (mr *MockVerificationServiceMockRecorder) GetClaims(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClaims", reflect.TypeOf((*MockVerificationService)(nil).GetClaims), userID)
+}
@@ -0,0 +1,177 @@
+package userexport
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/lib/authn"
+	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator"
+	"github.com/authgear/authgear-server/pkg/lib/authn/identity"
+	"github.com/authgear/authgear-server/pkg/lib/authn/user"
+	"github.com/authgear/authgear-server/pkg/lib/feature/verification"
+	"github.com/authgear/authgear-server/pkg/lib/infra/task"
+	"github.com/authgear/authgear-server/pkg/lib/tasks"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+)
+
+type testQueue struct {
+	params []task.Param
+}
+
+func (q *testQueue) Enqueue(param task.Param) {
+	q.params = append(q.params, param)
+}
+
+func TestService(t *testing.T) {
+	Convey("Service", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := NewMockStore(ctrl)
+		users := NewMockUserQueries(ctrl)
+		identities := NewMockIdentityService(ctrl)
+		authenticators := NewMockAuthenticatorService(ctrl)
+		verificationService := NewMockVerificationService(ctrl)
+		queue := &testQueue{}
+		clk := clock.NewMockClockAt("2020-10-05T00:00:00Z")
+
+		s := &Service{
+			Clock:          clk,
+			Store:          store,
+			Users:          users,
+			Identities:     identities,
+			Authenticators: authenticators,
+			Verification:   verificationService,
+			TaskQueue:      queue,
+		}
+
+		Convey("should create job and enqueue task", func() {
+			store.EXPECT().Create(gomock.Any()).Return(nil)
+
+			job, err := s.CreateJob(FormatNDJSON, false)
+			So(err, ShouldBeNil)
+			So(job.Status, ShouldEqual, StatusPending)
+			So(job.ExpireAt, ShouldEqual, clk.NowUTC().Add(jobExpiry))
+			So(queue.params, ShouldResemble, []task.Param{
+				&tasks.ExportUsersParam{JobID: job.ID},
+			})
+		})
+
+		Convey("should not get expired job", func() {
+			now := clk.NowUTC()
+			job := &Job{ID: "job", ExpireAt: now.Add(time.Minute)}
+			store.EXPECT().Get("job").Return(job, nil)
+			result, err := s.GetJob("job")
+			So(err, ShouldBeNil)
+			So(result, ShouldEqual, job)
+
+			store.EXPECT().Get("expired").Return(&Job{ID: "expired", ExpireAt: now}, nil)
+			_, err = s.GetJob("expired")
+			So(err, ShouldEqual, ErrJobNotFound)
+		})
+
+		Convey("should reject invalid format", func() {
+			_, err := s.CreateJob(Format("xml"), false)
+			So(err, ShouldBeError, "invalid export format")
+			So(queue.params, ShouldBeEmpty)
+		})
+
+		Convey("should write CSV header when started", func() {
+			store.EXPECT().Get("job").Return(&Job{ID: "job", Status: StatusPending, Format: FormatCSV}, nil)
+			users.EXPECT().Count(user.Filter{}).Return(uint64(3), nil)
+			store.EXPECT().CreateChunk("job", 0, gomock.Any()).DoAndReturn(func(_ string, _ int, data []byte) error {
+				So(string(data), ShouldStartWith, "id,created_at,")
+				return nil
+			})
+			store.EXPECT().Update(gomock.Any()).Return(nil)
+
+			job, cursor, err := s.Start("job")
+			So(err, ShouldBeNil)
+			So(job.Status, ShouldEqual, StatusRunning)
+			So(job.TotalUsers, ShouldEqual, 3)
+			So(cursor, ShouldResemble, &Cursor{Seq: 1})
+		})
+
+		Convey("should not start non-pending job", func() {
+			store.EXPECT().Get("job").Return(&Job{ID: "job", Status: StatusCompleted}, nil)
+
+			_, _, err := s.Start("job")
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("should export batch", func() {
+			now := clk.NowUTC()
+			job := &Job{ID: "job", Status: StatusRunning, Format: FormatNDJSON, TotalUsers: 1}
+			cursor := &Cursor{}
+
+			users.EXPECT().ListRawAfter("", uint64(batchSize)).Return([]*user.User{
+				{ID: "user-a", CreatedAt: now, UpdatedAt: now},
+			}, nil)
+			identities.EXPECT().ListRefsByUsers([]string{"user-a"}).Return(nil, nil)
+			identities.EXPECT().GetMany(gomock.Any()).Return([]*identity.Info{
+				{ID: "identity-a", UserID: "user-a", Type: authn.IdentityTypeLoginID, Claims: map[string]interface{}{"email": "user@example.com"}},
+			}, nil)
+			authenticators.EXPECT().ListRefsByUsers([]string{"user-a"}).Return(nil, nil)
+			authenticators.EXPECT().GetMany(gomock.Any()).Return([]*authenticator.Info{
+				{ID: "authenticator-a", UserID: "user-a", Type: authn.AuthenticatorTypePassword, Kind: authenticator.KindPrimary, Secret: "$2a$10$hash"},
+			}, nil)
+			verificationService.EXPECT().GetClaims("user-a").Return([]*verification.Claim{
+				{Name: "email", Value: "user@example.com", CreatedAt: now},
+			}, nil)
+
+			var chunk string
+			store.EXPECT().CreateChunk("job", 0, gomock.Any()).DoAndReturn(func(_ string, _ int, data []byte) error {
+				chunk = string(data)
+				return nil
+			})
+			store.EXPECT().Update(job).Return(nil)
+
+			Convey("without secrets", func() {
+				done, err := s.ExportBatch(job, cursor)
+				So(err, ShouldBeNil)
+				So(done, ShouldBeFalse)
+				So(job.ExportedUsers, ShouldEqual, 1)
+				So(cursor, ShouldResemble, &Cursor{AfterUserID: "user-a", Seq: 1})
+				So(strings.Count(chunk, "\n"), ShouldEqual, 1)
+				So(chunk, ShouldContainSubstring, `"email":"user@example.com"`)
+				So(chunk, ShouldContainSubstring, `"verified_claims":[{"name":"email"`)
+				So(chunk, ShouldNotContainSubstring, "$2a$10$hash")
+			})
+
+			Convey("with secrets", func() {
+				job.IncludeSecrets = true
+				_, err := s.ExportBatch(job, cursor)
+				So(err, ShouldBeNil)
+				So(chunk, ShouldContainSubstring, `"secret":"$2a$10$hash"`)
+			})
+		})
+
+		Convey("should be done when no more users", func() {
+			job := &Job{ID: "job", Status: StatusRunning, Format: FormatNDJSON}
+			cursor := &Cursor{AfterUserID: "user-a", Seq: 1}
+			users.EXPECT().ListRawAfter("user-a", uint64(batchSize)).Return(nil, nil)
+
+			done, err := s.ExportBatch(job, cursor)
+			So(err, ShouldBeNil)
+			So(done, ShouldBeTrue)
+		})
+
+		Convey("should record failure", func() {
+			store.EXPECT().Get("job").Return(&Job{ID: "job", Status: StatusRunning}, nil)
+			store.EXPECT().Update(gomock.Any()).DoAndReturn(func(job *Job) error {
+				So(job.Status, ShouldEqual, StatusFailed)
+				So(*job.Error, ShouldEqual, "boom")
+				So(*job.CompletedAt, ShouldEqual, clk.NowUTC())
+				return nil
+			})
+
+			err := s.Fail("job", errors.New("boom"))
+			So(err, ShouldBeNil)
+		})
+	})
+}
@@ -0,0 +1,188 @@
+package userexport
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+)
+
+var errChunkNotFound = errors.New("user export chunk not found")
+
+type StorePQ struct {
+	SQLBuilder  db.SQLBuilder
+	SQLExecutor db.SQLExecutor
+}
+
+func (s *StorePQ) Create(job *Job) error {
+	builder := s.SQLBuilder.Tenant().
+		Insert(s.SQLBuilder.FullTableName("user_export")).
+		Columns(
+			"id",
+			"created_at",
+			"updated_at",
+			"completed_at",
+			"expire_at",
+			"status",
+			"format",
+			"include_secrets",
+			"total_users",
+			"exported_users",
+			"error",
+		).
+		Values(
+			job.ID,
+			job.CreatedAt,
+			job.UpdatedAt,
+			job.CompletedAt,
+			job.ExpireAt,
+			string(job.Status),
+			string(job.Format),
+			job.IncludeSecrets,
+			job.TotalUsers,
+			job.ExportedUsers,
+			job.Error,
+		)
+
+	_, err := s.SQLExecutor.ExecWith(builder)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *StorePQ) Get(id string) (*Job, error) {
+	builder := s.SQLBuilder.Tenant().
+		Select(
+			"id",
+			"created_at",
+			"updated_at",
+			"completed_at",
+			"expire_at",
+			"status",
+			"format",
+			"include_secrets",
+			"total_users",
+			"exported_users",
+			"error",
+		).
+		From(s.SQLBuilder.FullTableName("user_export")).
+		Where("id = ?", id)
+
+	row, err := s.SQLExecutor.QueryRowWith(builder)
+	if err != nil {
+		return nil, err
+	}
+
+	job := &Job{}
+	var status, format string
+	err = row.Scan(
+		&job.ID,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+		&job.CompletedAt,
+		&job.ExpireAt,
+		&status,
+		&format,
+		&job.IncludeSecrets,
+		&job.TotalUsers,
+		&job.ExportedUsers,
+		&job.Error,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrJobNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	job.Status = Status(status)
+	job.Format = Format(format)
+	return job, nil
+}
+
+func (s *StorePQ) Update(job *Job) error {
+	builder := s.SQLBuilder.Tenant().
+		Update(s.SQLBuilder.FullTableName("user_export")).
+		Set("updated_at", job.UpdatedAt).
+		Set("completed_at", job.CompletedAt).
+		Set("status", string(job.Status)).
+		Set("total_users", job.TotalUsers).
+		Set("exported_users", job.ExportedUsers).
+		Set("error", job.Error).
+		Where("id = ?", job.ID)
+
+	result, err := s.SQLExecutor.ExecWith(builder)
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrJobNotFound
+	}
+
+	return nil
+}
+
+func (s *StorePQ) CreateChunk(jobID string, seq int, data []byte) error {
+	builder := s.SQLBuilder.Tenant().
+		Insert(s.SQLBuilder.FullTableName("user_export_chunk")).
+		Columns(
+			"export_id",
+			"seq",
+			"data",
+		).
+		Values(
+			jobID,
+			seq,
+			data,
+		)
+
+	_, err := s.SQLExecutor.ExecWith(builder)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *StorePQ) GetChunk(jobID string, seq int) ([]byte, error) {
+	builder := s.SQLBuilder.Tenant().
+		Select("data").
+		From(s.SQLBuilder.FullTableName("user_export_chunk")).
+		Where("export_id = ? AND seq = ?", jobID, seq)
+
+	row, err := s.SQLExecutor.QueryRowWith(builder)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	err = row.Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errChunkNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// DeleteExpired deletes the exports expired at t, with their chunks.
+func (s *StorePQ) DeleteExpired(t time.Time) (int64, error) {
+	q := s.SQLBuilder.Tenant().
+		Delete(s.SQLBuilder.FullTableName("user_export")).
+		Where("expire_at <= ?", t)
+
+	result, err := s.SQLExecutor.ExecWith(q)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
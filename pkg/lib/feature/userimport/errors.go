@@ -0,0 +1,7 @@
+package userimport
+
+import (
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+)
+
+var InvalidRecord = apierrors.Invalid.WithReason("InvalidImportRecord")
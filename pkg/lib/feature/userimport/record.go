@@ -0,0 +1,54 @@
+package userimport
+
+import (
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+)
+
+// Record is a user to be imported. Records are given as NDJSON, one record
+// per line.
+type Record struct {
+	LoginIDs     []LoginIDRecord `json:"login_ids,omitempty"`
+	OAuth        []OAuthRecord   `json:"oauth,omitempty"`
+	PasswordHash string          `json:"password_hash,omitempty"`
+	TOTPSecret   string          `json:"totp_secret,omitempty"`
+}
+
+type LoginIDRecord struct {
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+	Verified bool   `json:"verified,omitempty"`
+}
+
+type OAuthRecord struct {
+	ProviderAlias string `json:"provider_alias"`
+	Subject       string `json:"subject"`
+}
+
+type Outcome string
+
+const (
+	// OutcomeCreated indicates the user is created.
+	OutcomeCreated Outcome = "created"
+	// OutcomeSkipped indicates an identity of the record exists already,
+	// so the user is not created again.
+	OutcomeSkipped Outcome = "skipped"
+	// OutcomeFailed indicates the record cannot be imported.
+	OutcomeFailed Outcome = "failed"
+)
+
+// Result is the result of importing a record. Index is the zero-based line
+// number of the record.
+type Result struct {
+	Index   int                 `json:"index"`
+	Outcome Outcome             `json:"outcome"`
+	UserID  string              `json:"user_id,omitempty"`
+	Error   *apierrors.APIError `json:"error,omitempty"`
+}
+
+func NewFailedResult(index int, err error) *Result {
+	return &Result{
+		Index:   index,
+		Outcome: OutcomeFailed,
+		Error:   apierrors.AsAPIError(err),
+	}
+}
@@ -0,0 +1,263 @@
+package userimport
+
+import (
+	"github.com/authgear/authgear-server/pkg/lib/audit"
+	"github.com/authgear/authgear-server/pkg/lib/authn"
+	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator"
+	"github.com/authgear/authgear-server/pkg/lib/authn/identity"
+	"github.com/authgear/authgear-server/pkg/lib/authn/otp"
+	"github.com/authgear/authgear-server/pkg/lib/authn/user"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/feature/verification"
+	"github.com/authgear/authgear-server/pkg/util/password"
+	"github.com/authgear/authgear-server/pkg/util/uuid"
+)
+
+//go:generate mockgen -source=service.go -destination=service_mock_test.go -package userimport
+
+type UserCommands interface {
+	Create(userID string) (*user.User, error)
+}
+
+type IdentityService interface {
+	New(userID string, spec *identity.Spec) (*identity.Info, error)
+	Create(info *identity.Info) error
+	CheckDuplicated(info *identity.Info) (*identity.Info, error)
+}
+
+type AuthenticatorService interface {
+	New(spec *authenticator.Spec, secret string) (*authenticator.Info, error)
+	Create(info *authenticator.Info) error
+}
+
+type VerificationService interface {
+	IsClaimVerifiable(claimName string) bool
+	NewVerifiedClaim(userID string, claimName string, claimValue string) *verification.Claim
+	MarkClaimVerified(claim *verification.Claim) error
+}
+
+type AuditLogger interface {
+	Log(activity audit.Activity, userID string, data map[string]interface{})
+}
+
+// Service imports users from other systems.
+//
+// Imported users are created directly without going through the signup
+// interaction, so no hooks are triggered and no welcome messages are sent.
+// The caller is expected to import each record in its own transaction.
+type Service struct {
+	IdentityConfig       *config.IdentityConfig
+	AuthenticationConfig *config.AuthenticationConfig
+	UserCommands         UserCommands
+	Identities           IdentityService
+	Authenticators       AuthenticatorService
+	Verification         VerificationService
+	AuditLogger          AuditLogger
+}
+
+// Import imports the record. If any identity of the record exists already,
+// the record is skipped, so that importing the same records again is
+// harmless.
+func (s *Service) Import(index int, record *Record) (*Result, error) {
+	if len(record.LoginIDs) == 0 && len(record.OAuth) == 0 {
+		return nil, InvalidRecord.New("record must have at least one login ID or OAuth identity")
+	}
+
+	userID := uuid.New()
+
+	var identities []*identity.Info
+	var verifiedIdentities []*identity.Info
+	for _, l := range record.LoginIDs {
+		spec, err := s.loginIDSpec(l)
+		if err != nil {
+			return nil, err
+		}
+		info, err := s.Identities.New(userID, spec)
+		if err != nil {
+			return nil, err
+		}
+		identities = append(identities, info)
+		if l.Verified {
+			verifiedIdentities = append(verifiedIdentities, info)
+		}
+	}
+	for _, o := range record.OAuth {
+		spec, err := s.oauthSpec(o)
+		if err != nil {
+			return nil, err
+		}
+		info, err := s.Identities.New(userID, spec)
+		if err != nil {
+			return nil, err
+		}
+		identities = append(identities, info)
+	}
+
+	for _, info := range identities {
+		dupe, err := s.Identities.CheckDuplicated(info)
+		if err != nil {
+			return nil, err
+		}
+		if dupe != nil {
+			return &Result{
+				Index:   index,
+				Outcome: OutcomeSkipped,
+				UserID:  dupe.UserID,
+			}, nil
+		}
+	}
+
+	var authenticators []*authenticator.Info
+	if record.PasswordHash != "" {
+		info, err := s.newPasswordAuthenticator(userID, record.PasswordHash)
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, info)
+	}
+	if record.TOTPSecret != "" {
+		info, err := s.newTOTPAuthenticator(userID, record.TOTPSecret)
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, info)
+	}
+
+	if _, err := s.UserCommands.Create(userID); err != nil {
+		return nil, err
+	}
+	for _, info := range identities {
+		if err := s.Identities.Create(info); err != nil {
+			return nil, err
+		}
+	}
+	for _, info := range authenticators {
+		if err := s.Authenticators.Create(info); err != nil {
+			return nil, err
+		}
+	}
+	for _, info := range verifiedIdentities {
+		for name, value := range info.Claims {
+			value, ok := value.(string)
+			if !ok || !s.Verification.IsClaimVerifiable(name) {
+				continue
+			}
+			claim := s.Verification.NewVerifiedClaim(userID, name, value)
+			if err := s.Verification.MarkClaimVerified(claim); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var identityIDs []string
+	for _, info := range identities {
+		identityIDs = append(identityIDs, info.ID)
+	}
+	var authenticatorTypes []string
+	for _, info := range authenticators {
+		authenticatorTypes = append(authenticatorTypes, string(info.Type))
+	}
+	s.AuditLogger.Log(audit.ActivityUserImported, userID, map[string]interface{}{
+		"identity_ids":        identityIDs,
+		"authenticator_types": authenticatorTypes,
+	})
+
+	return &Result{
+		Index:   index,
+		Outcome: OutcomeCreated,
+		UserID:  userID,
+	}, nil
+}
+
+func (s *Service) loginIDSpec(l LoginIDRecord) (*identity.Spec, error) {
+	var typ config.LoginIDKeyType
+	for _, cfg := range s.IdentityConfig.LoginID.Keys {
+		if cfg.Key == l.Key {
+			typ = cfg.Type
+		}
+	}
+	if typ == "" {
+		return nil, InvalidRecord.Errorf("invalid login ID key: %s", l.Key)
+	}
+
+	return &identity.Spec{
+		Type: authn.IdentityTypeLoginID,
+		Claims: map[string]interface{}{
+			identity.IdentityClaimLoginIDKey:   l.Key,
+			identity.IdentityClaimLoginIDType:  string(typ),
+			identity.IdentityClaimLoginIDValue: l.Value,
+		},
+	}, nil
+}
+
+func (s *Service) oauthSpec(o OAuthRecord) (*identity.Spec, error) {
+	providerConfig, ok := s.IdentityConfig.OAuth.GetProviderConfig(o.ProviderAlias)
+	if !ok {
+		return nil, InvalidRecord.Errorf("invalid OAuth provider alias: %s", o.ProviderAlias)
+	}
+	if o.Subject == "" {
+		return nil, InvalidRecord.New("OAuth subject is required")
+	}
+
+	return &identity.Spec{
+		Type: authn.IdentityTypeOAuth,
+		Claims: map[string]interface{}{
+			identity.IdentityClaimOAuthProviderKeys: providerConfig.ProviderID().Claims(),
+			identity.IdentityClaimOAuthSubjectID:    o.Subject,
+		},
+	}, nil
+}
+
+func (s *Service) newPasswordAuthenticator(userID string, passwordHash string) (*authenticator.Info, error) {
+	if err := password.CheckHash([]byte(passwordHash)); err != nil {
+		return nil, InvalidRecord.New("invalid password hash")
+	}
+
+	// The password hash is imported as is, and is migrated to the latest
+	// format when the user logs in next time.
+	info, err := s.Authenticators.New(&authenticator.Spec{
+		UserID:    userID,
+		IsDefault: true,
+		Kind:      authenticator.KindPrimary,
+		Type:      authn.AuthenticatorTypePassword,
+		Claims:    map[string]interface{}{},
+	}, "")
+	if err != nil {
+		return nil, err
+	}
+	info.Secret = passwordHash
+	return info, nil
+}
+
+func (s *Service) newTOTPAuthenticator(userID string, secret string) (*authenticator.Info, error) {
+	enabled := false
+	for _, typ := range s.AuthenticationConfig.SecondaryAuthenticators {
+		if typ == authn.AuthenticatorTypeTOTP {
+			enabled = true
+			break
+		}
+	}
+	if !enabled {
+		return nil, InvalidRecord.New("TOTP authenticator is not enabled")
+	}
+
+	secret, err := otp.NormalizeTOTPSecret(secret)
+	if err != nil {
+		return nil, InvalidRecord.New("invalid TOTP secret")
+	}
+
+	info, err := s.Authenticators.New(&authenticator.Spec{
+		UserID:    userID,
+		IsDefault: true,
+		Kind:      authenticator.KindSecondary,
+		Type:      authn.AuthenticatorTypeTOTP,
+		Claims: map[string]interface{}{
+			authenticator.AuthenticatorClaimTOTPDisplayName: "",
+		},
+	}, "")
+	if err != nil {
+		return nil, err
+	}
+	info.Secret = secret
+	return info, nil
+}
@@ -0,0 +1,272 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package userimport is a generated GoMock package.
+package userimport
+
+import (
+	audit "github.com/authgear/authgear-server/pkg/lib/audit"
+	authenticator "github.com/authgear/authgear-server/pkg/lib/authn/authenticator"
+	identity "github.com/authgear/authgear-server/pkg/lib/authn/identity"
+	user "github.com/authgear/authgear-server/pkg/lib/authn/user"
+	verification "github.com/authgear/authgear-server/pkg/lib/feature/verification"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockUserCommands is a mock of UserCommands interface
+type MockUserCommands struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserCommandsMockRecorder
+}
+
+// MockUserCommandsMockRecorder is the mock recorder for MockUserCommands
+type MockUserCommandsMockRecorder struct {
+	mock *MockUserCommands
+}
+
+// NewMockUserCommands creates a new mock instance
+func NewMockUserCommands(ctrl *gomock.Controller) *MockUserCommands {
+	mock := &MockUserCommands{ctrl: ctrl}
+	mock.recorder = &MockUserCommandsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockUserCommands) EXPECT() *MockUserCommandsMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method
+func (m *MockUserCommands) Create(userID string) (*user.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", userID)
+	ret0, _ := ret[0].(*user.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create
+func (mr *MockUserCommandsMockRecorder) Create(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserCommands)(nil).Create), userID)
+}
+
+// MockIdentityService is a mock of IdentityService interface
+type MockIdentityService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdentityServiceMockRecorder
+}
+
+// MockIdentityServiceMockRecorder is the mock recorder for MockIdentityService
+type MockIdentityServiceMockRecorder struct {
+	mock *MockIdentityService
+}
+
+// NewMockIdentityService creates a new mock instance
+func NewMockIdentityService(ctrl *gomock.Controller) *MockIdentityService {
+	mock := &MockIdentityService{ctrl: ctrl}
+	mock.recorder = &MockIdentityServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockIdentityService) EXPECT() *MockIdentityServiceMockRecorder {
+	return m.recorder
+}
+
+// New mocks base method
+func (m *MockIdentityService) New(userID string, spec *identity.Spec) (*identity.Info, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "New", userID, spec)
+	ret0, _ := ret[0].(*identity.Info)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// New indicates an expected call of New
+func (mr *MockIdentityServiceMockRecorder) New(userID, spec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "New", reflect.TypeOf((*MockIdentityService)(nil).New), userID, spec)
+}
+
+// Create mocks base method
+func (m *MockIdentityService) Create(info *identity.Info) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", info)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create
+func (mr *MockIdentityServiceMockRecorder) Create(info interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIdentityService)(nil).Create), info)
+}
+
+// CheckDuplicated mocks base method
+func (m *MockIdentityService) CheckDuplicated(info *identity.Info) (*identity.Info, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckDuplicated", info)
+	ret0, _ := ret[0].(*identity.Info)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckDuplicated indicates an expected call of CheckDuplicated
+func (mr *MockIdentityServiceMockRecorder) CheckDuplicated(info interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckDuplicated", reflect.TypeOf((*MockIdentityService)(nil).CheckDuplicated), info)
+}
+
+// MockAuthenticatorService is a mock of AuthenticatorService interface
+type MockAuthenticatorService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthenticatorServiceMockRecorder
+}
+
+// MockAuthenticatorServiceMockRecorder is the mock recorder for MockAuthenticatorService
+type MockAuthenticatorServiceMockRecorder struct {
+	mock *MockAuthenticatorService
+}
+
+// NewMockAuthenticatorService creates a new mock instance
+func NewMockAuthenticatorService(ctrl *gomock.Controller) *MockAuthenticatorService {
+	mock := &MockAuthenticatorService{ctrl: ctrl}
+	mock.recorder = &MockAuthenticatorServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockAuthenticatorService) EXPECT() *MockAuthenticatorServiceMockRecorder {
+	return m.recorder
+}
+
+// New mocks base method
+func (m *MockAuthenticatorService) New(spec *authenticator.Spec, secret string) (*authenticator.Info, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "New", spec, secret)
+	ret0, _ := ret[0].(*authenticator.Info)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// New indicates an expected call of New
+func (mr *MockAuthenticatorServiceMockRecorder) New(spec, secret interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "New", reflect.TypeOf((*MockAuthenticatorService)(nil).New), spec, secret)
+}
+
+// Create mocks base method
+func (m *MockAuthenticatorService) Create(info *authenticator.Info) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", info)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create
+func (mr *MockAuthenticatorServiceMockRecorder) Create(info interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAuthenticatorService)(nil).Create), info)
+}
+
+// MockVerificationService is a mock of VerificationService interface
+type MockVerificationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockVerificationServiceMockRecorder
+}
+
+// MockVerificationServiceMockRecorder is the mock recorder for MockVerificationService
+type MockVerificationServiceMockRecorder struct {
+	mock *MockVerificationService
+}
+
+// NewMockVerificationService creates a new mock instance
+func NewMockVerificationService(ctrl *gomock.Controller) *MockVerificationService {
+	mock := &MockVerificationService{ctrl: ctrl}
+	mock.recorder = &MockVerificationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockVerificationService) EXPECT() *MockVerificationServiceMockRecorder {
+	return m.recorder
+}
+
+// IsClaimVerifiable mocks base method
+func (m *MockVerificationService) IsClaimVerifiable(claimName string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsClaimVerifiable", claimName)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsClaimVerifiable indicates an expected call of IsClaimVerifiable
+func (mr *MockVerificationServiceMockRecorder) IsClaimVerifiable(claimName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsClaimVerifiable", reflect.TypeOf((*MockVerificationService)(nil).IsClaimVerifiable), claimName)
+}
+
+// NewVerifiedClaim mocks base method
+func (m *MockVerificationService) NewVerifiedClaim(userID, claimName, claimValue string) *verification.Claim {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewVerifiedClaim", userID, claimName, claimValue)
+	ret0, _ := ret[0].(*verification.Claim)
+	return ret0
+}
+
+// NewVerifiedClaim indicates an expected call of NewVerifiedClaim
+func (mr *MockVerificationServiceMockRecorder) NewVerifiedClaim(userID, claimName, claimValue interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewVerifiedClaim", reflect.TypeOf((*MockVerificationService)(nil).NewVerifiedClaim), userID, claimName, claimValue)
+}
+
+// MarkClaimVerified mocks base method
+func (m *MockVerificationService) MarkClaimVerified(claim *verification.Claim) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkClaimVerified", claim)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkClaimVerified indicates an expected call of MarkClaimVerified
+func (mr *MockVerificationServiceMockRecorder) MarkClaimVerified(claim interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkClaimVerified", reflect.TypeOf((*MockVerificationService)(nil).MarkClaimVerified), claim)
+}
+
+// MockAuditLogger is a mock of AuditLogger interface
+type MockAuditLogger struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditLoggerMockRecorder
+}
+
+// MockAuditLoggerMockRecorder is the mock recorder for MockAuditLogger
+type MockAuditLoggerMockRecorder struct {
+	mock *MockAuditLogger
+}
+
+// NewMockAuditLogger creates a new mock instance
+func NewMockAuditLogger(ctrl *gomock.Controller) *MockAuditLogger {
+	mock := &MockAuditLogger{ctrl: ctrl}
+	mock.recorder = &MockAuditLoggerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockAuditLogger) EXPECT() *MockAuditLoggerMockRecorder {
+	return m.recorder
+}
+
+// Log mocks base method
+func (m *MockAuditLogger) Log(activity audit.Activity, userID string, data map[string]interface{}) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Log", activity, userID, data)
+}
+
+// Log indicates an expected call of Log
+func (mr *MockAuditLoggerMockRecorder) Log(activity, userID, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Log", reflect.TypeOf((*MockAuditLogger)(nil).Log), activity, userID, data)
+}
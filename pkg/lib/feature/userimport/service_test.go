@@ -0,0 +1,170 @@
+package userimport
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+	"github.com/authgear/authgear-server/pkg/lib/audit"
+	"github.com/authgear/authgear-server/pkg/lib/authn"
+	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator"
+	"github.com/authgear/authgear-server/pkg/lib/authn/identity"
+	"github.com/authgear/authgear-server/pkg/lib/authn/user"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/feature/verification"
+)
+
+func TestService(t *testing.T) {
+	Convey("Service", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userCommands := NewMockUserCommands(ctrl)
+		identities := NewMockIdentityService(ctrl)
+		authenticators := NewMockAuthenticatorService(ctrl)
+		verificationService := NewMockVerificationService(ctrl)
+		auditLogger := NewMockAuditLogger(ctrl)
+
+		s := &Service{
+			IdentityConfig: &config.IdentityConfig{
+				LoginID: &config.LoginIDConfig{
+					Keys: []config.LoginIDKeyConfig{
+						{Key: "email", Type: config.LoginIDKeyTypeEmail},
+					},
+				},
+				OAuth: &config.OAuthSSOConfig{
+					Providers: []config.OAuthSSOProviderConfig{
+						{Alias: "google", Type: config.OAuthSSOProviderTypeGoogle},
+					},
+				},
+			},
+			AuthenticationConfig: &config.AuthenticationConfig{
+				SecondaryAuthenticators: []authn.AuthenticatorType{authn.AuthenticatorTypeTOTP},
+			},
+			UserCommands:   userCommands,
+			Identities:     identities,
+			Authenticators: authenticators,
+			Verification:   verificationService,
+			AuditLogger:    auditLogger,
+		}
+
+		newIdentity := func(userID string, spec *identity.Spec) (*identity.Info, error) {
+			info := &identity.Info{
+				ID:     "identity-" + string(spec.Type),
+				UserID: userID,
+				Type:   spec.Type,
+				Claims: map[string]interface{}{},
+			}
+			if spec.Type == authn.IdentityTypeLoginID {
+				info.Claims["email"] = spec.Claims[identity.IdentityClaimLoginIDValue]
+			}
+			return info, nil
+		}
+		newAuthenticator := func(spec *authenticator.Spec, secret string) (*authenticator.Info, error) {
+			return &authenticator.Info{
+				ID:     "authenticator-" + string(spec.Type),
+				UserID: spec.UserID,
+				Type:   spec.Type,
+				Kind:   spec.Kind,
+				Secret: "generated",
+			}, nil
+		}
+
+		Convey("should create user", func() {
+			var userID string
+			identities.EXPECT().New(gomock.Any(), gomock.Any()).DoAndReturn(newIdentity).Times(2)
+			identities.EXPECT().CheckDuplicated(gomock.Any()).Return(nil, nil).Times(2)
+			authenticators.EXPECT().New(gomock.Any(), "").DoAndReturn(newAuthenticator).Times(2)
+			userCommands.EXPECT().Create(gomock.Any()).DoAndReturn(func(id string) (*user.User, error) {
+				userID = id
+				return &user.User{ID: id}, nil
+			})
+			identities.EXPECT().Create(gomock.Any()).Return(nil).Times(2)
+			authenticators.EXPECT().Create(gomock.Any()).DoAndReturn(func(info *authenticator.Info) error {
+				switch info.Type {
+				case authn.AuthenticatorTypePassword:
+					So(info.Secret, ShouldEqual, "$2a$10$qQybX3kAJNT2YqYRVmbfjO5EhgWm6vV4cWmXo2ZATAuBmCyJM2fKu")
+					So(info.Kind, ShouldEqual, authenticator.KindPrimary)
+				case authn.AuthenticatorTypeTOTP:
+					So(info.Secret, ShouldEqual, "JBSWY3DPEHPK3PXP")
+					So(info.Kind, ShouldEqual, authenticator.KindSecondary)
+				}
+				return nil
+			}).Times(2)
+			verificationService.EXPECT().IsClaimVerifiable("email").Return(true)
+			verificationService.EXPECT().NewVerifiedClaim(gomock.Any(), "email", "user@example.com").
+				DoAndReturn(func(userID string, name string, value string) *verification.Claim {
+					return &verification.Claim{UserID: userID, Name: name, Value: value}
+				})
+			verificationService.EXPECT().MarkClaimVerified(gomock.Any()).Return(nil)
+			auditLogger.EXPECT().Log(audit.ActivityUserImported, gomock.Any(), gomock.Any())
+
+			result, err := s.Import(3, &Record{
+				LoginIDs:     []LoginIDRecord{{Key: "email", Value: "user@example.com", Verified: true}},
+				OAuth:        []OAuthRecord{{ProviderAlias: "google", Subject: "google-user"}},
+				PasswordHash: "$2a$10$qQybX3kAJNT2YqYRVmbfjO5EhgWm6vV4cWmXo2ZATAuBmCyJM2fKu",
+				TOTPSecret:   "jbsw y3dp ehpk 3pxp",
+			})
+			So(err, ShouldBeNil)
+			So(result, ShouldResemble, &Result{Index: 3, Outcome: OutcomeCreated, UserID: userID})
+		})
+
+		Convey("should skip existing user", func() {
+			identities.EXPECT().New(gomock.Any(), gomock.Any()).DoAndReturn(newIdentity)
+			identities.EXPECT().CheckDuplicated(gomock.Any()).Return(&identity.Info{UserID: "existing-user"}, nil)
+
+			result, err := s.Import(0, &Record{
+				LoginIDs: []LoginIDRecord{{Key: "email", Value: "user@example.com"}},
+			})
+			So(err, ShouldBeNil)
+			So(result, ShouldResemble, &Result{Index: 0, Outcome: OutcomeSkipped, UserID: "existing-user"})
+		})
+
+		Convey("should reject invalid records", func() {
+			_, err := s.Import(0, &Record{})
+			So(apierrors.IsKind(err, InvalidRecord), ShouldBeTrue)
+
+			_, err = s.Import(0, &Record{
+				LoginIDs: []LoginIDRecord{{Key: "username", Value: "user"}},
+			})
+			So(apierrors.IsKind(err, InvalidRecord), ShouldBeTrue)
+
+			_, err = s.Import(0, &Record{
+				OAuth: []OAuthRecord{{ProviderAlias: "facebook", Subject: "user"}},
+			})
+			So(apierrors.IsKind(err, InvalidRecord), ShouldBeTrue)
+
+			identities.EXPECT().New(gomock.Any(), gomock.Any()).DoAndReturn(newIdentity)
+			identities.EXPECT().CheckDuplicated(gomock.Any()).Return(nil, nil)
+			_, err = s.Import(0, &Record{
+				LoginIDs:     []LoginIDRecord{{Key: "email", Value: "user@example.com"}},
+				PasswordHash: "plaintext",
+			})
+			So(apierrors.IsKind(err, InvalidRecord), ShouldBeTrue)
+		})
+
+		Convey("should reject TOTP secret if TOTP is not enabled", func() {
+			s.AuthenticationConfig.SecondaryAuthenticators = []authn.AuthenticatorType{authn.AuthenticatorTypeOOB}
+
+			identities.EXPECT().New(gomock.Any(), gomock.Any()).DoAndReturn(newIdentity)
+			identities.EXPECT().CheckDuplicated(gomock.Any()).Return(nil, nil)
+			_, err := s.Import(0, &Record{
+				LoginIDs:   []LoginIDRecord{{Key: "email", Value: "user@example.com"}},
+				TOTPSecret: "JBSWY3DPEHPK3PXP",
+			})
+			So(apierrors.IsKind(err, InvalidRecord), ShouldBeTrue)
+		})
+
+		Convey("should propagate errors", func() {
+			identities.EXPECT().New(gomock.Any(), gomock.Any()).Return(nil, errors.New("invalid login ID"))
+
+			_, err := s.Import(0, &Record{
+				LoginIDs: []LoginIDRecord{{Key: "email", Value: "invalid"}},
+			})
+			So(err, ShouldBeError, "invalid login ID")
+		})
+	})
+}
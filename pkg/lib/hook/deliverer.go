@@ -44,7 +44,12 @@ func (deliverer *Deliverer) DeliverBeforeEvent(e *event.Event) error {
 			return errDeliveryTimeout
 		}
 
-		request, err := deliverer.prepareRequest(hook, e)
+		body, err := json.Marshal(e)
+		if err != nil {
+			return newErrorDeliveryFailed(err)
+		}
+
+		request, err := deliverer.prepareRequest(hook, body)
 		if err != nil {
 			return err
 		}
@@ -67,33 +72,74 @@ func (deliverer *Deliverer) DeliverBeforeEvent(e *event.Event) error {
 	return nil
 }
 
-func (deliverer *Deliverer) DeliverNonBeforeEvent(e *event.Event) error {
+// DeliverNonBeforeEvent delivers the persisted event to all its handlers
+// that have not received it yet, and records the handlers that received it
+// in e.DeliveredHandlers.
+// If any handler fails, the first error is returned after trying the other
+// handlers. If a handler responds with non-2xx status code, the error is
+// *InvalidStatusCodeError.
+func (deliverer *Deliverer) DeliverNonBeforeEvent(e *StoredEvent) error {
+	delivered := map[string]struct{}{}
+	for _, url := range e.DeliveredHandlers {
+		delivered[url] = struct{}{}
+	}
+
+	var firstErr error
 	for _, hook := range deliverer.Config.Handlers {
 		if hook.Event != string(e.Type) {
 			continue
 		}
-
-		request, err := deliverer.prepareRequest(hook, e)
-		if err != nil {
-			return err
+		if _, ok := delivered[hook.URL]; ok {
+			continue
 		}
 
-		_, err = performRequest(deliverer.AsyncHTTP.Client, request, false)
+		err := deliverer.deliverNonBeforeEvent(hook, e)
 		if err != nil {
-			return err
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
 		}
+
+		delivered[hook.URL] = struct{}{}
+		e.DeliveredHandlers = append(e.DeliveredHandlers, hook.URL)
 	}
 
-	return nil
+	return firstErr
 }
 
-func (deliverer *Deliverer) prepareRequest(hook config.HookHandlerConfig, event *event.Event) (*http.Request, error) {
-	hookURL, err := url.Parse(hook.URL)
+func (deliverer *Deliverer) deliverNonBeforeEvent(hook config.HookHandlerConfig, e *StoredEvent) error {
+	request, err := deliverer.prepareRequest(hook, e.Data)
 	if err != nil {
-		return nil, newErrorDeliveryFailed(err)
+		return err
 	}
 
-	body, err := json.Marshal(event)
+	_, err = performRequest(deliverer.AsyncHTTP.Client, request, false)
+	return err
+}
+
+// DeliverTestEvent delivers the event to the handler URL,
+// regardless of the configured handlers.
+func (deliverer *Deliverer) DeliverTestEvent(url string, e *event.Event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return newErrorDeliveryFailed(err)
+	}
+
+	request, err := deliverer.prepareRequest(config.HookHandlerConfig{
+		Event: string(e.Type),
+		URL:   url,
+	}, body)
+	if err != nil {
+		return err
+	}
+
+	_, err = performRequest(deliverer.AsyncHTTP.Client, request, false)
+	return err
+}
+
+func (deliverer *Deliverer) prepareRequest(hook config.HookHandlerConfig, body []byte) (*http.Request, error) {
+	hookURL, err := url.Parse(hook.URL)
 	if err != nil {
 		return nil, newErrorDeliveryFailed(err)
 	}
@@ -133,7 +179,10 @@ func performRequest(client *http.Client, request *http.Request, withResponse boo
 	}()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		err = errDeliveryInvalidStatusCode
+		err = &InvalidStatusCodeError{
+			StatusCode: resp.StatusCode,
+			RetryAfter: resp.Header.Get("Retry-After"),
+		}
 		return
 	}
 
@@ -1,6 +1,7 @@
 package hook
 
 import (
+	"encoding/json"
 	"net/http"
 	"testing"
 
@@ -204,6 +205,13 @@ func TestDeliverer(t *testing.T) {
 				ID:   "event-id",
 				Type: event.UserSync,
 			}
+			data, err := json.Marshal(e)
+			So(err, ShouldBeNil)
+			storedEvent := &StoredEvent{
+				ID:   e.ID,
+				Type: e.Type,
+				Data: data,
+			}
 
 			Convey("should be successful", func() {
 				cfg.Handlers = []config.HookHandlerConfig{
@@ -224,29 +232,90 @@ func TestDeliverer(t *testing.T) {
 					BodyString("test")
 				defer func() { gock.Flush() }()
 
-				err := deliverer.DeliverNonBeforeEvent(&e)
+				err := deliverer.DeliverNonBeforeEvent(storedEvent)
 
 				So(err, ShouldBeNil)
 				So(gock.IsDone(), ShouldBeTrue)
+				So(storedEvent.DeliveredHandlers, ShouldResemble, []string{"https://example.com/a"})
 			})
 
-			Convey("should reject invalid status code", func() {
+			Convey("should deliver to other handlers if a handler failed", func() {
 				cfg.Handlers = []config.HookHandlerConfig{
 					{
 						Event: string(event.UserSync),
 						URL:   "https://example.com/a",
 					},
+					{
+						Event: string(event.UserSync),
+						URL:   "https://example.com/b",
+					},
 				}
 
 				gock.New("https://example.com").
 					Post("/a").
 					JSON(e).
 					Reply(500)
+				gock.New("https://example.com").
+					Post("/b").
+					JSON(e).
+					Reply(200)
+				defer func() { gock.Flush() }()
+
+				err := deliverer.DeliverNonBeforeEvent(storedEvent)
+
+				So(err, ShouldResemble, &InvalidStatusCodeError{StatusCode: 500})
+				So(gock.IsDone(), ShouldBeTrue)
+				So(storedEvent.DeliveredHandlers, ShouldResemble, []string{"https://example.com/b"})
+			})
+
+			Convey("should skip handlers that have received the event", func() {
+				cfg.Handlers = []config.HookHandlerConfig{
+					{
+						Event: string(event.UserSync),
+						URL:   "https://example.com/a",
+					},
+					{
+						Event: string(event.UserSync),
+						URL:   "https://example.com/b",
+					},
+				}
+				storedEvent.DeliveredHandlers = []string{"https://example.com/b"}
+
+				gock.New("https://example.com").
+					Post("/a").
+					JSON(e).
+					Reply(200)
+				defer func() { gock.Flush() }()
+
+				err := deliverer.DeliverNonBeforeEvent(storedEvent)
+
+				So(err, ShouldBeNil)
+				So(gock.IsDone(), ShouldBeTrue)
+				So(storedEvent.DeliveredHandlers, ShouldResemble, []string{"https://example.com/b", "https://example.com/a"})
+			})
+
+			Convey("should reject invalid status code", func() {
+				cfg.Handlers = []config.HookHandlerConfig{
+					{
+						Event: string(event.UserSync),
+						URL:   "https://example.com/a",
+					},
+				}
+
+				gock.New("https://example.com").
+					Post("/a").
+					JSON(e).
+					Reply(503).
+					SetHeader("Retry-After", "120")
 				defer func() { gock.Flush() }()
 
-				err := deliverer.DeliverNonBeforeEvent(&e)
+				err := deliverer.DeliverNonBeforeEvent(storedEvent)
 
 				So(err, ShouldBeError, "invalid status code")
+				So(err, ShouldResemble, &InvalidStatusCodeError{
+					StatusCode: 503,
+					RetryAfter: "120",
+				})
 				So(gock.IsDone(), ShouldBeTrue)
 			})
 		})
@@ -0,0 +1,191 @@
+package hook
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/authgear/authgear-server/pkg/api/event"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/infra/task"
+	"github.com/authgear/authgear-server/pkg/lib/tasks"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+	"github.com/authgear/authgear-server/pkg/util/log"
+)
+
+//go:generate mockgen -source=delivery.go -destination=delivery_mock_test.go -mock_names=deliveryStore=MockDeliveryStore,nonBeforeDeliverer=MockNonBeforeDeliverer -package hook
+
+const (
+	deliveryBatchSize  = 10
+	deliveryMinBackoff = 10 * time.Second
+	deliveryMaxBackoff = 1 * time.Hour
+	// deliveryClaimTimeout is the duration that claimed events are not
+	// claimed again, in case the worker is stopped during the delivery.
+	deliveryClaimTimeout = 1 * time.Hour
+)
+
+type deliveryStore interface {
+	GetEvent(id string) (*StoredEvent, error)
+	ClaimEventsForDelivery(now time.Time, until time.Time, limit uint64) ([]*StoredEvent, error)
+	UpdateDelivery(e *StoredEvent) error
+}
+
+type nonBeforeDeliverer interface {
+	DeliverNonBeforeEvent(e *StoredEvent) error
+	DeliverTestEvent(url string, e *event.Event) error
+}
+
+type DeliveryLogger struct{ *log.Logger }
+
+func NewDeliveryLogger(lf *log.Factory) DeliveryLogger {
+	return DeliveryLogger{lf.New("hook-delivery")}
+}
+
+// DeliveryService delivers persisted non-BEFORE events, retrying failed
+// deliveries with exponential back-off until the retry horizon is reached.
+type DeliveryService struct {
+	Config    *config.HookConfig
+	Logger    DeliveryLogger
+	Clock     clock.Clock
+	Store     deliveryStore
+	Deliverer nonBeforeDeliverer
+	TaskQueue task.Queue
+}
+
+// ClaimPendingEvents claims a batch of events that are due for delivery.
+// The claimed events are not claimed again until deliveryClaimTimeout has
+// elapsed, so they can be delivered outside of the transaction.
+func (s *DeliveryService) ClaimPendingEvents() ([]*StoredEvent, error) {
+	now := s.Clock.NowUTC()
+	return s.Store.ClaimEventsForDelivery(now, now.Add(deliveryClaimTimeout), deliveryBatchSize)
+}
+
+// UpdateDelivery saves the delivery status of the event.
+func (s *DeliveryService) UpdateDelivery(e *StoredEvent) error {
+	return s.Store.UpdateDelivery(e)
+}
+
+// Redeliver schedules the event to be delivered immediately, bypassing the
+// retry interval. The event is delivered by the worker after the transaction
+// is committed, so that web-hook handlers are not called within it.
+func (s *DeliveryService) Redeliver(id string) (*StoredEvent, error) {
+	e, err := s.Store.GetEvent(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// The event is re-delivered to all handlers, including the handlers that
+	// have received it.
+	now := s.Clock.NowUTC()
+	e.DeliveredHandlers = nil
+	e.Status = DeliveryStatusPending
+	e.NextAttemptAt = &now
+
+	err = s.Store.UpdateDelivery(e)
+	if err != nil {
+		return nil, err
+	}
+
+	s.TaskQueue.Enqueue(&tasks.DeliverEventsParam{})
+	return e, nil
+}
+
+// SendTestEvent delivers a synthetic test event to the handler URL. Only
+// URLs of the configured handlers are allowed, so that signed payloads are
+// not sent to arbitrary URLs.
+func (s *DeliveryService) SendTestEvent(url string) error {
+	configured := false
+	for _, h := range s.Config.Handlers {
+		if h.URL == url {
+			configured = true
+			break
+		}
+	}
+	if !configured {
+		return UnknownWebHookHandler.New("url is not a configured web-hook handler")
+	}
+
+	e := event.NewEvent(0, &event.TestEvent{}, event.Context{
+		Timestamp: s.Clock.NowUTC().Unix(),
+	})
+	return s.Deliverer.DeliverTestEvent(url, e)
+}
+
+// DeliverEvent delivers the event to its handlers, and updates its delivery
+// status accordingly. The delivery status is not saved; use UpdateDelivery
+// to save it.
+func (s *DeliveryService) DeliverEvent(e *StoredEvent) {
+	err := s.Deliverer.DeliverNonBeforeEvent(e)
+
+	now := s.Clock.NowUTC()
+	e.Attempts++
+	e.LastAttemptAt = &now
+	e.LastStatusCode = nil
+	e.LastError = nil
+
+	if err == nil {
+		e.Status = DeliveryStatusSuccess
+		e.NextAttemptAt = nil
+		return
+	}
+
+	errMsg := err.Error()
+	e.LastError = &errMsg
+
+	nextAttemptAt := now.Add(backoff(e.Attempts))
+	var statusCodeErr *InvalidStatusCodeError
+	if errors.As(err, &statusCodeErr) {
+		statusCode := statusCodeErr.StatusCode
+		e.LastStatusCode = &statusCode
+		if retryAfter, ok := parseRetryAfter(statusCodeErr.RetryAfter, now); ok && retryAfter.After(nextAttemptAt) {
+			nextAttemptAt = retryAfter
+		}
+	}
+
+	horizon := e.CreatedAt.Add(s.Config.AsyncRetryHorizon.Duration())
+	if nextAttemptAt.After(horizon) {
+		e.Status = DeliveryStatusFailed
+		e.NextAttemptAt = nil
+		s.Logger.WithError(err).WithFields(logrus.Fields{
+			"event_id":   e.ID,
+			"event_type": e.Type,
+			"attempts":   e.Attempts,
+		}).Error("web-hook event delivery permanently failed")
+	} else {
+		e.Status = DeliveryStatusPending
+		e.NextAttemptAt = &nextAttemptAt
+		s.Logger.WithError(err).WithFields(logrus.Fields{
+			"event_id":        e.ID,
+			"event_type":      e.Type,
+			"attempts":        e.Attempts,
+			"next_attempt_at": nextAttemptAt,
+		}).Warn("web-hook event delivery failed")
+	}
+}
+
+func backoff(attempts int) time.Duration {
+	d := deliveryMinBackoff
+	for i := 1; i < attempts; i++ {
+		d *= 2
+		if d >= deliveryMaxBackoff {
+			return deliveryMaxBackoff
+		}
+	}
+	return d
+}
+
+func parseRetryAfter(value string, now time.Time) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return now.Add(time.Duration(seconds) * time.Second), true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return t.UTC(), true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: delivery.go
+
+// Package hook is a generated GoMock package.
+package hook
+
+import (
+	event "github.com/authgear/authgear-server/pkg/api/event"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+	time "time"
+)
+
+// MockDeliveryStore is a mock of deliveryStore interface
+type MockDeliveryStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeliveryStoreMockRecorder
+}
+
+// MockDeliveryStoreMockRecorder is the mock recorder for MockDeliveryStore
+type MockDeliveryStoreMockRecorder struct {
+	mock *MockDeliveryStore
+}
+
+// NewMockDeliveryStore creates a new mock instance
+func NewMockDeliveryStore(ctrl *gomock.Controller) *MockDeliveryStore {
+	mock := &MockDeliveryStore{ctrl: ctrl}
+	mock.recorder = &MockDeliveryStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockDeliveryStore) EXPECT() *MockDeliveryStoreMockRecorder {
+	return m.recorder
+}
+
+// GetEvent mocks base method
+func (m *MockDeliveryStore) GetEvent(id string) (*StoredEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEvent", id)
+	ret0, _ := ret[0].(*StoredEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEvent indicates an expected call of GetEvent
+func (mr *MockDeliveryStoreMockRecorder) GetEvent(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvent", reflect.TypeOf((*MockDeliveryStore)(nil).GetEvent), id)
+}
+
+// ClaimEventsForDelivery mocks base method
+func (m *MockDeliveryStore) ClaimEventsForDelivery(now, until time.Time, limit uint64) ([]*StoredEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimEventsForDelivery", now, until, limit)
+	ret0, _ := ret[0].([]*StoredEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimEventsForDelivery indicates an expected call of ClaimEventsForDelivery
+func (mr *MockDeliveryStoreMockRecorder) ClaimEventsForDelivery(now, until, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimEventsForDelivery", reflect.TypeOf((*MockDeliveryStore)(nil).ClaimEventsForDelivery), now, until, limit)
+}
+
+// UpdateDelivery mocks base method
+func (m *MockDeliveryStore) UpdateDelivery(e *StoredEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDelivery", e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDelivery indicates an expected call of UpdateDelivery
+func (mr *MockDeliveryStoreMockRecorder) UpdateDelivery(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDelivery", reflect.TypeOf((*MockDeliveryStore)(nil).UpdateDelivery), e)
+}
+
+// MockNonBeforeDeliverer is a mock of nonBeforeDeliverer interface
+type MockNonBeforeDeliverer struct {
+	ctrl     *gomock.Controller
+	recorder *MockNonBeforeDelivererMockRecorder
+}
+
+// MockNonBeforeDelivererMockRecorder is the mock recorder for MockNonBeforeDeliverer
+type MockNonBeforeDelivererMockRecorder struct {
+	mock *MockNonBeforeDeliverer
+}
+
+// NewMockNonBeforeDeliverer creates a new mock instance
+func NewMockNonBeforeDeliverer(ctrl *gomock.Controller) *MockNonBeforeDeliverer {
+	mock := &MockNonBeforeDeliverer{ctrl: ctrl}
+	mock.recorder = &MockNonBeforeDelivererMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockNonBeforeDeliverer) EXPECT() *MockNonBeforeDelivererMockRecorder {
+	return m.recorder
+}
+
+// DeliverNonBeforeEvent mocks base method
+func (m *MockNonBeforeDeliverer) DeliverNonBeforeEvent(e *StoredEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliverNonBeforeEvent", e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeliverNonBeforeEvent indicates an expected call of DeliverNonBeforeEvent
+func (mr *MockNonBeforeDelivererMockRecorder) DeliverNonBeforeEvent(e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliverNonBeforeEvent", reflect.TypeOf((*MockNonBeforeDeliverer)(nil).DeliverNonBeforeEvent), e)
+}
+
+// DeliverTestEvent mocks base method
+func (m *MockNonBeforeDeliverer) DeliverTestEvent(url string, e *event.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliverTestEvent", url, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeliverTestEvent indicates an expected call of DeliverTestEvent
+func (mr *MockNonBeforeDelivererMockRecorder) DeliverTestEvent(url, e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliverTestEvent", reflect.TypeOf((*MockNonBeforeDeliverer)(nil).DeliverTestEvent), url, e)
+}
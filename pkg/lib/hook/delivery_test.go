@@ -0,0 +1,150 @@
+package hook
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+	"github.com/authgear/authgear-server/pkg/api/event"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/infra/task"
+	"github.com/authgear/authgear-server/pkg/lib/tasks"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+	"github.com/authgear/authgear-server/pkg/util/log"
+)
+
+type testQueue struct {
+	params []task.Param
+}
+
+func (q *testQueue) Enqueue(param task.Param) {
+	q.params = append(q.params, param)
+}
+
+func TestDeliveryService(t *testing.T) {
+	Convey("Delivery Service", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		clk := clock.NewMockClockAt("2006-01-02T15:04:05Z")
+		now := clk.NowUTC()
+		store := NewMockDeliveryStore(ctrl)
+		deliverer := NewMockNonBeforeDeliverer(ctrl)
+		queue := &testQueue{}
+
+		s := &DeliveryService{
+			Config: &config.HookConfig{
+				AsyncRetryHorizon: 3600,
+				Handlers: []config.HookHandlerConfig{
+					{Event: "after.user.created", URL: "https://example.com/hook"},
+				},
+			},
+			Logger:    DeliveryLogger{log.Null},
+			Clock:     clk,
+			Store:     store,
+			Deliverer: deliverer,
+			TaskQueue: queue,
+		}
+
+		newEvent := func() *StoredEvent {
+			return &StoredEvent{
+				ID:        "event-id",
+				Type:      event.UserSync,
+				CreatedAt: now.Add(-time.Minute),
+				Status:    DeliveryStatusPending,
+			}
+		}
+
+		Convey("should claim pending events", func() {
+			e := newEvent()
+			store.EXPECT().ClaimEventsForDelivery(now, now.Add(deliveryClaimTimeout), uint64(deliveryBatchSize)).Return([]*StoredEvent{e}, nil)
+
+			events, err := s.ClaimPendingEvents()
+			So(err, ShouldBeNil)
+			So(events, ShouldResemble, []*StoredEvent{e})
+		})
+
+		Convey("should mark delivered events as success", func() {
+			e := newEvent()
+			deliverer.EXPECT().DeliverNonBeforeEvent(e).Return(nil)
+
+			s.DeliverEvent(e)
+			So(e.Status, ShouldEqual, DeliveryStatusSuccess)
+			So(e.Attempts, ShouldEqual, 1)
+			So(e.NextAttemptAt, ShouldBeNil)
+		})
+
+		Convey("should schedule retry with back-off", func() {
+			e := newEvent()
+			e.Attempts = 2
+			deliverer.EXPECT().DeliverNonBeforeEvent(e).Return(&InvalidStatusCodeError{StatusCode: 500})
+
+			s.DeliverEvent(e)
+			So(e.Status, ShouldEqual, DeliveryStatusPending)
+			So(e.Attempts, ShouldEqual, 3)
+			So(*e.LastStatusCode, ShouldEqual, 500)
+			So(*e.NextAttemptAt, ShouldEqual, now.Add(40*time.Second))
+		})
+
+		Convey("should respect Retry-After", func() {
+			e := newEvent()
+			deliverer.EXPECT().DeliverNonBeforeEvent(e).Return(&InvalidStatusCodeError{
+				StatusCode: 503,
+				RetryAfter: "600",
+			})
+
+			s.DeliverEvent(e)
+			So(*e.NextAttemptAt, ShouldEqual, now.Add(10*time.Minute))
+		})
+
+		Convey("should give up after retry horizon", func() {
+			e := newEvent()
+			e.CreatedAt = now.Add(-time.Hour)
+			deliverer.EXPECT().DeliverNonBeforeEvent(e).Return(errors.New("connection refused"))
+
+			s.DeliverEvent(e)
+			So(e.Status, ShouldEqual, DeliveryStatusFailed)
+			So(e.NextAttemptAt, ShouldBeNil)
+			So(*e.LastError, ShouldEqual, "connection refused")
+		})
+
+		Convey("should schedule failed events for immediate redelivery", func() {
+			e := newEvent()
+			e.Status = DeliveryStatusFailed
+			e.Attempts = 5
+			e.DeliveredHandlers = []string{"https://example.com/a"}
+			store.EXPECT().GetEvent("event-id").Return(e, nil)
+			store.EXPECT().UpdateDelivery(e).Return(nil)
+
+			result, err := s.Redeliver("event-id")
+			So(err, ShouldBeNil)
+			So(result, ShouldEqual, e)
+			So(e.Status, ShouldEqual, DeliveryStatusPending)
+			So(*e.NextAttemptAt, ShouldEqual, now)
+			So(e.Attempts, ShouldEqual, 5)
+			So(e.DeliveredHandlers, ShouldBeNil)
+			So(queue.params, ShouldResemble, []task.Param{&tasks.DeliverEventsParam{}})
+		})
+
+		Convey("should send test event", func() {
+			deliverer.EXPECT().DeliverTestEvent("https://example.com/hook", gomock.Any()).
+				DoAndReturn(func(url string, e *event.Event) error {
+					So(e.Type, ShouldEqual, event.Test)
+					So(e.Context.Timestamp, ShouldEqual, now.Unix())
+					return nil
+				})
+
+			err := s.SendTestEvent("https://example.com/hook")
+			So(err, ShouldBeNil)
+		})
+
+		Convey("should not send test event to unknown URL", func() {
+			err := s.SendTestEvent("https://attacker.example.com/hook")
+			So(apierrors.IsKind(err, UnknownWebHookHandler), ShouldBeTrue)
+		})
+	})
+}
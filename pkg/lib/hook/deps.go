@@ -13,4 +13,9 @@ var DependencySet = wire.NewSet(
 	wire.Struct(new(Store), "*"),
 	wire.Bind(new(store), new(*Store)),
 	wire.Struct(new(Provider), "*"),
+	NewDeliveryLogger,
+	wire.Bind(new(deliveryStore), new(*Store)),
+	wire.Bind(new(nonBeforeDeliverer), new(*Deliverer)),
+	wire.Struct(new(DeliveryService), "*"),
+	wire.Struct(new(EventQueries), "*"),
 )
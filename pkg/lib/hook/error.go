@@ -8,9 +8,23 @@ import (
 )
 
 var WebHookDisallowed = apierrors.Forbidden.WithReason("WebHookDisallowed")
+var UnknownWebHookHandler = apierrors.Invalid.WithReason("UnknownWebHookHandler")
+
+var ErrEventNotFound = errors.New("event not found")
 
 var errDeliveryTimeout = errors.New("web-hook event delivery timed out")
-var errDeliveryInvalidStatusCode = errors.New("invalid status code")
+
+// InvalidStatusCodeError is returned when a web-hook handler responds with
+// a status code outside the 2xx range.
+type InvalidStatusCodeError struct {
+	StatusCode int
+	// RetryAfter is the value of Retry-After header in the response.
+	RetryAfter string
+}
+
+func (e *InvalidStatusCodeError) Error() string {
+	return "invalid status code"
+}
 
 func newErrorDeliveryFailed(inner error) error {
 	return fmt.Errorf("web-hook event delivery failed: %w", inner)
@@ -8,7 +8,9 @@ import (
 	"github.com/authgear/authgear-server/pkg/api/event"
 	"github.com/authgear/authgear-server/pkg/api/model"
 	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	"github.com/authgear/authgear-server/pkg/lib/infra/task"
 	"github.com/authgear/authgear-server/pkg/lib/session"
+	"github.com/authgear/authgear-server/pkg/lib/tasks"
 	"github.com/authgear/authgear-server/pkg/util/clock"
 	"github.com/authgear/authgear-server/pkg/util/errorutil"
 	"github.com/authgear/authgear-server/pkg/util/log"
@@ -23,13 +25,11 @@ type UserProvider interface {
 type deliverer interface {
 	WillDeliver(eventType event.Type) bool
 	DeliverBeforeEvent(event *event.Event) error
-	DeliverNonBeforeEvent(event *event.Event) error
 }
 
 type store interface {
 	NextSequenceNumber() (int64, error)
 	AddEvents(events []*event.Event) error
-	GetEventsForDelivery() ([]*event.Event, error)
 }
 
 type DatabaseHandle interface {
@@ -48,8 +48,10 @@ type Provider struct {
 	Users     UserProvider
 	Store     store
 	Deliverer deliverer
+	TaskQueue task.Queue
 
 	persistentEventPayloads []event.Payload `wire:"-"`
+	hasPendingEvents        bool            `wire:"-"`
 	dbHooked                bool            `wire:"-"`
 }
 
@@ -140,32 +142,43 @@ func (provider *Provider) WillCommitTx() error {
 		return err
 	}
 	provider.persistentEventPayloads = nil
+	if len(events) > 0 {
+		provider.hasPendingEvents = true
+	}
 
 	return nil
 }
 
 func (provider *Provider) DidCommitTx() {
-	// TODO(webhook): deliver persisted events
-	events, _ := provider.Store.GetEventsForDelivery()
-	for _, event := range events {
-		err := provider.Deliverer.DeliverNonBeforeEvent(event)
-		if err != nil {
-			provider.Logger.WithError(err).Debug("Failed to dispatch event")
-		}
+	// Deliver the persisted events as soon as possible;
+	// failed deliveries are retried by the worker.
+	if provider.hasPendingEvents {
+		provider.TaskQueue.Enqueue(&tasks.DeliverEventsParam{})
+		provider.hasPendingEvents = false
 	}
 }
 
 func (provider *Provider) dispatchSyncUserEventIfNeeded() error {
 	userIDToSync := []string{}
+	deletedUserIDs := map[string]struct{}{}
 
 	for _, payload := range provider.persistentEventPayloads {
 		if _, isOperation := payload.(event.OperationPayload); !isOperation {
 			continue
 		}
+		if _, isDelete := payload.(*event.UserDeleteEvent); isDelete {
+			deletedUserIDs[payload.UserID()] = struct{}{}
+			continue
+		}
 		userIDToSync = append(userIDToSync, payload.UserID())
 	}
 
 	for _, userID := range userIDToSync {
+		// Deleted users have nothing to sync.
+		if _, deleted := deletedUserIDs[userID]; deleted {
+			continue
+		}
+
 		user, err := provider.Users.Get(userID)
 		if err != nil {
 			return err
@@ -101,20 +101,6 @@ func (mr *MockDelivererMockRecorder) DeliverBeforeEvent(event interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliverBeforeEvent", reflect.TypeOf((*MockDeliverer)(nil).DeliverBeforeEvent), event)
 }
 
-// DeliverNonBeforeEvent mocks base method
-func (m *MockDeliverer) DeliverNonBeforeEvent(event *event.Event) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeliverNonBeforeEvent", event)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// DeliverNonBeforeEvent indicates an expected call of DeliverNonBeforeEvent
-func (mr *MockDelivererMockRecorder) DeliverNonBeforeEvent(event interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliverNonBeforeEvent", reflect.TypeOf((*MockDeliverer)(nil).DeliverNonBeforeEvent), event)
-}
-
 // MockStore is a mock of store interface
 type MockStore struct {
 	ctrl     *gomock.Controller
@@ -167,21 +153,6 @@ func (mr *MockStoreMockRecorder) AddEvents(events interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEvents", reflect.TypeOf((*MockStore)(nil).AddEvents), events)
 }
 
-// GetEventsForDelivery mocks base method
-func (m *MockStore) GetEventsForDelivery() ([]*event.Event, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetEventsForDelivery")
-	ret0, _ := ret[0].([]*event.Event)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// GetEventsForDelivery indicates an expected call of GetEventsForDelivery
-func (mr *MockStoreMockRecorder) GetEventsForDelivery() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEventsForDelivery", reflect.TypeOf((*MockStore)(nil).GetEventsForDelivery))
-}
-
 // MockDatabaseHandle is a mock of DatabaseHandle interface
 type MockDatabaseHandle struct {
 	ctrl     *gomock.Controller
@@ -268,6 +268,38 @@ func TestDispatchEvent(t *testing.T) {
 				So(err, ShouldBeNil)
 				So(provider.persistentEventPayloads, ShouldBeNil)
 			})
+
+			Convey("should not sync deleted users", func() {
+				provider.persistentEventPayloads = []event.Payload{
+					&event.UserDeleteEvent{
+						User: model.User{
+							Meta: model.Meta{ID: "user-id"},
+						},
+					},
+				}
+				deliverer.EXPECT().WillDeliver(event.AfterUserDelete).Return(true)
+				store.EXPECT().AddEvents([]*event.Event{
+					{
+						ID:   "0000000000000001",
+						Type: event.AfterUserDelete,
+						Seq:  1,
+						Payload: &event.UserDeleteEvent{
+							User: model.User{
+								Meta: model.Meta{ID: "user-id"},
+							},
+						},
+						Context: event.Context{
+							Timestamp: 1136214245,
+							UserID:    nil,
+						},
+					},
+				})
+
+				err := provider.WillCommitTx()
+
+				So(err, ShouldBeNil)
+				So(provider.persistentEventPayloads, ShouldBeNil)
+			})
 		})
 	})
 }
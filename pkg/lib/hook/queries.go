@@ -0,0 +1,38 @@
+package hook
+
+import (
+	"github.com/authgear/authgear-server/pkg/api/model"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+)
+
+// EventQueries provides read access to the persisted events.
+type EventQueries struct {
+	Store *Store
+}
+
+func (q *EventQueries) Get(id string) (*StoredEvent, error) {
+	return q.Store.GetEvent(id)
+}
+
+func (q *EventQueries) Count(filter EventFilter) (uint64, error) {
+	return q.Store.CountEvents(filter)
+}
+
+func (q *EventQueries) QueryPage(filter EventFilter, after, before model.PageCursor, first, last *uint64) ([]model.PageItem, error) {
+	events, offset, err := q.Store.QueryEventsPage(filter, after, before, first, last)
+	if err != nil {
+		return nil, err
+	}
+
+	var models = make([]model.PageItem, len(events))
+	for i, e := range events {
+		pageKey := db.PageKey{Offset: offset + uint64(i)}
+		cursor, err := pageKey.ToPageCursor()
+		if err != nil {
+			return nil, err
+		}
+
+		models[i] = model.PageItem{Value: e, Cursor: cursor}
+	}
+	return models, nil
+}
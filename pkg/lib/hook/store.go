@@ -1,17 +1,29 @@
 package hook
 
 import (
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
+
+	"github.com/lib/pq"
 
 	"github.com/authgear/authgear-server/pkg/api/event"
+	"github.com/authgear/authgear-server/pkg/api/model"
 	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	"github.com/authgear/authgear-server/pkg/util/clock"
 )
 
+var queryEventsPage = db.QueryPage(db.QueryPageConfig{
+	KeyColumn: "e.seq",
+	IDColumn:  "e.id",
+})
+
 type Store struct {
+	Clock       clock.Clock
 	SQLBuilder  db.SQLBuilder
 	SQLExecutor db.SQLExecutor
-
-	events []*event.Event `wire:"-"`
 }
 
 func (store *Store) NextSequenceNumber() (seq int64, err error) {
@@ -26,12 +38,268 @@ func (store *Store) NextSequenceNumber() (seq int64, err error) {
 }
 
 func (store *Store) AddEvents(events []*event.Event) error {
-	// TODO(webhook): persist events
-	store.events = append(store.events, events...)
+	if len(events) == 0 {
+		return nil
+	}
+
+	now := store.Clock.NowUTC()
+	q := store.SQLBuilder.Tenant().
+		Insert(store.SQLBuilder.FullTableName("event")).
+		Columns(
+			"id",
+			"seq",
+			"type",
+			"user_id",
+			"data",
+			"created_at",
+			"status",
+			"attempts",
+			"next_attempt_at",
+		)
+
+	for _, e := range events {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		q = q.Values(
+			e.ID,
+			e.Seq,
+			string(e.Type),
+			e.Payload.UserID(),
+			data,
+			now,
+			string(DeliveryStatusPending),
+			0,
+			now,
+		)
+	}
+
+	_, err := store.SQLExecutor.ExecWith(q)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
-func (store *Store) GetEventsForDelivery() ([]*event.Event, error) {
-	// TODO(webhook): get events
-	return store.events, nil
+// ClaimEventsForDelivery returns pending events due for delivery, and
+// postpones their next attempt to until, so that the events are not
+// delivered by other workers concurrently.
+func (store *Store) ClaimEventsForDelivery(now time.Time, until time.Time, limit uint64) ([]*StoredEvent, error) {
+	builder := store.selectQuery().
+		Where("e.status = ? AND e.next_attempt_at <= ?", string(DeliveryStatusPending), now).
+		OrderBy("e.seq").
+		Limit(limit).
+		Suffix("FOR UPDATE SKIP LOCKED")
+
+	rows, err := store.SQLExecutor.QueryWith(builder)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*StoredEvent
+	var ids []string
+	for rows.Next() {
+		e, err := store.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+		ids = append(ids, e.ID)
+	}
+
+	if len(events) == 0 {
+		return nil, nil
+	}
+
+	q := store.SQLBuilder.Tenant().
+		Update(store.SQLBuilder.FullTableName("event")).
+		Set("next_attempt_at", until).
+		Where("id = ANY (?)", pq.Array(ids))
+
+	_, err = store.SQLExecutor.ExecWith(q)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range events {
+		nextAttemptAt := until
+		e.NextAttemptAt = &nextAttemptAt
+	}
+
+	return events, nil
+}
+
+func (store *Store) GetEvent(id string) (*StoredEvent, error) {
+	builder := store.selectQuery().Where("e.id = ?", id)
+
+	row, err := store.SQLExecutor.QueryRowWith(builder)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := store.scan(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrEventNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+func (store *Store) CountEvents(filter EventFilter) (uint64, error) {
+	builder := store.SQLBuilder.Tenant().
+		Select("count(*)").
+		From(store.SQLBuilder.FullTableName("event"), "e")
+	builder = applyEventFilter(builder, filter)
+
+	scanner, err := store.SQLExecutor.QueryRowWith(builder)
+	if err != nil {
+		return 0, err
+	}
+
+	var count uint64
+	if err = scanner.Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (store *Store) QueryEventsPage(filter EventFilter, after, before model.PageCursor, first, last *uint64) ([]*StoredEvent, uint64, error) {
+	afterKey, err := db.NewFromPageCursor(after)
+	if err != nil {
+		return nil, 0, err
+	}
+	beforeKey, err := db.NewFromPageCursor(before)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	selectQuery := applyEventFilter(store.selectQuery(), filter)
+
+	query, offset, err := queryEventsPage(selectQuery, afterKey, beforeKey, first, last)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := store.SQLExecutor.QueryWith(query)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var events []*StoredEvent
+	for rows.Next() {
+		e, err := store.scan(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		events = append(events, e)
+	}
+
+	return events, offset, nil
+}
+
+func (store *Store) UpdateDelivery(e *StoredEvent) error {
+	deliveredHandlers := e.DeliveredHandlers
+	if deliveredHandlers == nil {
+		deliveredHandlers = []string{}
+	}
+	deliveredHandlersBytes, err := json.Marshal(deliveredHandlers)
+	if err != nil {
+		return err
+	}
+
+	q := store.SQLBuilder.Tenant().
+		Update(store.SQLBuilder.FullTableName("event")).
+		Set("status", string(e.Status)).
+		Set("attempts", e.Attempts).
+		Set("next_attempt_at", e.NextAttemptAt).
+		Set("last_attempt_at", e.LastAttemptAt).
+		Set("last_status_code", e.LastStatusCode).
+		Set("last_error", e.LastError).
+		Set("delivered_handlers", deliveredHandlersBytes).
+		Where("id = ?", e.ID)
+
+	_, err = store.SQLExecutor.ExecWith(q)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (store *Store) selectQuery() db.SelectBuilder {
+	return store.SQLBuilder.Tenant().
+		Select(
+			"e.id",
+			"e.seq",
+			"e.type",
+			"e.user_id",
+			"e.data",
+			"e.created_at",
+			"e.status",
+			"e.attempts",
+			"e.next_attempt_at",
+			"e.last_attempt_at",
+			"e.last_status_code",
+			"e.last_error",
+			"e.delivered_handlers",
+		).
+		From(store.SQLBuilder.FullTableName("event"), "e")
+}
+
+func (store *Store) scan(scn db.Scanner) (*StoredEvent, error) {
+	e := &StoredEvent{}
+	var typ, status string
+	var userID sql.NullString
+	var deliveredHandlers []byte
+	err := scn.Scan(
+		&e.ID,
+		&e.Seq,
+		&typ,
+		&userID,
+		&e.Data,
+		&e.CreatedAt,
+		&status,
+		&e.Attempts,
+		&e.NextAttemptAt,
+		&e.LastAttemptAt,
+		&e.LastStatusCode,
+		&e.LastError,
+		&deliveredHandlers,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(deliveredHandlers, &e.DeliveredHandlers); err != nil {
+		return nil, err
+	}
+	e.Type = event.Type(typ)
+	e.UserID = userID.String
+	e.Status = DeliveryStatus(status)
+	return e, nil
+}
+
+func applyEventFilter(builder db.SelectBuilder, filter EventFilter) db.SelectBuilder {
+	if filter.Type != "" {
+		builder = builder.Where("e.type = ?", string(filter.Type))
+	}
+	if filter.UserID != "" {
+		builder = builder.Where("e.user_id = ?", filter.UserID)
+	}
+	if filter.Status != "" {
+		builder = builder.Where("e.status = ?", string(filter.Status))
+	}
+	if filter.CreatedAfter != nil {
+		builder = builder.Where("e.created_at >= ?", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		builder = builder.Where("e.created_at < ?", *filter.CreatedBefore)
+	}
+	return builder
 }
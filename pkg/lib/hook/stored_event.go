@@ -0,0 +1,47 @@
+package hook
+
+import (
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/api/event"
+)
+
+type DeliveryStatus string
+
+const (
+	// DeliveryStatusPending means the event is waiting to be delivered.
+	DeliveryStatusPending DeliveryStatus = "pending"
+	// DeliveryStatusSuccess means the event is delivered to all handlers.
+	DeliveryStatusSuccess DeliveryStatus = "success"
+	// DeliveryStatusFailed means the event delivery is permanently failed.
+	DeliveryStatusFailed DeliveryStatus = "failed"
+)
+
+// StoredEvent is a persisted non-BEFORE event with its delivery status.
+type StoredEvent struct {
+	ID        string
+	Seq       int64
+	Type      event.Type
+	UserID    string
+	Data      []byte
+	CreatedAt time.Time
+
+	Status         DeliveryStatus
+	Attempts       int
+	NextAttemptAt  *time.Time
+	LastAttemptAt  *time.Time
+	LastStatusCode *int
+	LastError      *string
+	// DeliveredHandlers is the URLs of the handlers that have received
+	// the event, so that they are skipped in the retried deliveries.
+	DeliveredHandlers []string
+}
+
+// EventFilter filters the stored events.
+type EventFilter struct {
+	Type          event.Type
+	UserID        string
+	Status        DeliveryStatus
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+}
@@ -115,6 +115,11 @@ func (b InsertBuilder) Values(values ...interface{}) InsertBuilder {
 	return b
 }
 
+func (b InsertBuilder) Suffix(sql string, args ...interface{}) InsertBuilder {
+	b.builder = b.builder.Suffix(sql, args...)
+	return b
+}
+
 type SelectBuilder struct {
 	builder   sq.SelectBuilder
 	forTenant bool
@@ -165,3 +170,8 @@ func (b SelectBuilder) Limit(limit uint64) SelectBuilder {
 	b.builder = b.builder.Limit(limit)
 	return b
 }
+
+func (b SelectBuilder) Suffix(sql string, args ...interface{}) SelectBuilder {
+	b.builder = b.builder.Suffix(sql, args...)
+	return b
+}
@@ -1,11 +1,13 @@
 package interaction
 
 import (
+	"encoding/json"
 	"net/http"
 	"time"
 
 	"github.com/authgear/authgear-server/pkg/api/event"
 	"github.com/authgear/authgear-server/pkg/api/model"
+	"github.com/authgear/authgear-server/pkg/lib/audit"
 	"github.com/authgear/authgear-server/pkg/lib/authn"
 	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator"
 	"github.com/authgear/authgear-server/pkg/lib/authn/challenge"
@@ -19,6 +21,7 @@ import (
 	"github.com/authgear/authgear-server/pkg/lib/config"
 	"github.com/authgear/authgear-server/pkg/lib/feature/verification"
 	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	"github.com/authgear/authgear-server/pkg/lib/ratelimit"
 	"github.com/authgear/authgear-server/pkg/lib/session"
 	"github.com/authgear/authgear-server/pkg/lib/session/idpsession"
 	"github.com/authgear/authgear-server/pkg/util/clock"
@@ -52,6 +55,11 @@ type OOBAuthenticatorProvider interface {
 	GenerateCode(secret string, channel authn.AuthenticatorOOBChannel) string
 }
 
+type WebAuthnAuthenticatorProvider interface {
+	BeginRegistration(userID string, userName string, excludeCredentialIDs []string) (json.RawMessage, error)
+	BeginAssertion(userID string, credentialIDs []string) (json.RawMessage, error)
+}
+
 type OOBCodeSender interface {
 	SendCode(
 		channel authn.AuthenticatorOOBChannel,
@@ -99,6 +107,8 @@ type HookProvider interface {
 type SessionProvider interface {
 	MakeSession(*session.Attrs) (*idpsession.IDPSession, string)
 	Create(*idpsession.IDPSession) error
+	Get(id string) (*idpsession.IDPSession, error)
+	Update(*idpsession.IDPSession) error
 }
 
 type OAuthProviderFactory interface {
@@ -133,6 +143,20 @@ type VerificationCodeSender interface {
 	SendCode(code *verification.Code, webStateID string) (*otp.CodeSendResult, error)
 }
 
+type LockoutService interface {
+	Check(userID string) error
+	RecordFailure(userID string) error
+	RecordSuccess(userID string) error
+}
+
+type AuditLogger interface {
+	Log(activity audit.Activity, userID string, data map[string]interface{})
+}
+
+type RateLimiter interface {
+	TakeToken(bucket ratelimit.Bucket) error
+}
+
 type CookieFactory interface {
 	ValueCookie(def *httputil.CookieDef, value string) *http.Cookie
 	ClearCookie(def *httputil.CookieDef) *http.Cookie
@@ -146,12 +170,14 @@ type Context struct {
 	Database db.SQLExecutor
 	Clock    clock.Clock
 	Config   *config.AppConfig
+	RemoteIP httputil.RemoteIP
 
 	Identities               IdentityService
 	Authenticators           AuthenticatorService
 	AnonymousIdentities      AnonymousIdentityProvider
 	OOBAuthenticators        OOBAuthenticatorProvider
 	OOBCodeSender            OOBCodeSender
+	WebAuthnAuthenticators   WebAuthnAuthenticatorProvider
 	OAuthProviderFactory     OAuthProviderFactory
 	MFA                      MFAService
 	ForgotPassword           ForgotPasswordService
@@ -163,6 +189,9 @@ type Context struct {
 	Challenges           ChallengeProvider
 	Users                UserService
 	Hooks                HookProvider
+	RateLimiter          RateLimiter
+	Lockout              LockoutService
+	Audit                AuditLogger
 	CookieFactory        CookieFactory
 	Sessions             SessionProvider
 	SessionCookie        idpsession.CookieDef
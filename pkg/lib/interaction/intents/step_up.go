@@ -0,0 +1,75 @@
+package intents
+
+import (
+	"fmt"
+
+	"github.com/authgear/authgear-server/pkg/lib/interaction"
+	"github.com/authgear/authgear-server/pkg/lib/interaction/nodes"
+)
+
+func init() {
+	interaction.RegisterIntent(&IntentStepUp{})
+}
+
+// IntentStepUp performs secondary authentication for the user of an existing
+// IDP session, and upgrades the session ACR on success.
+type IntentStepUp struct {
+	UserID    string `json:"user_id"`
+	SessionID string `json:"session_id"`
+}
+
+func NewIntentStepUp(userID string, sessionID string) *IntentStepUp {
+	return &IntentStepUp{
+		UserID:    userID,
+		SessionID: sessionID,
+	}
+}
+
+func (i *IntentStepUp) InstantiateRootNode(ctx *interaction.Context, graph *interaction.Graph) (interaction.Node, error) {
+	edge := nodes.EdgeDoUseUser{UseUserID: i.UserID}
+	return edge.Instantiate(ctx, graph, i)
+}
+
+func (i *IntentStepUp) DeriveEdgesForNode(graph *interaction.Graph, node interaction.Node) ([]interaction.Edge, error) {
+	switch node := node.(type) {
+	case *nodes.NodeDoUseUser:
+		return []interaction.Edge{
+			// Remembered device does not re-verify the user, and recovery
+			// code is not a secondary authenticator, so neither of them
+			// satisfies MFA.
+			&nodes.EdgeAuthenticationBegin{
+				Stage:                  interaction.AuthenticationStageSecondary,
+				DeviceTokenDisallowed:  true,
+				RecoveryCodeDisallowed: true,
+			},
+		}, nil
+
+	case *nodes.NodeAuthenticationEnd:
+		if node.Result == nodes.AuthenticationResultOptional {
+			// User has no secondary authenticator to step up with.
+			return nil, interaction.NewInvariantViolated(
+				"MissingAuthenticator",
+				"missing secondary authenticator for step-up authentication",
+				nil,
+			)
+		}
+		return []interaction.Edge{
+			&nodes.EdgeDoUseAuthenticator{
+				Stage:         interaction.AuthenticationStageSecondary,
+				Authenticator: node.VerifiedAuthenticator,
+			},
+		}, nil
+
+	case *nodes.NodeDoUseAuthenticator:
+		return []interaction.Edge{
+			&nodes.EdgeDoStepUpSession{SessionID: i.SessionID},
+		}, nil
+
+	case *nodes.NodeDoStepUpSession:
+		// Intent is finished
+		return nil, nil
+
+	default:
+		panic(fmt.Errorf("interaction: unexpected node: %T", node))
+	}
+}
@@ -0,0 +1,58 @@
+package intents
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/lib/authn"
+	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/interaction"
+	"github.com/authgear/authgear-server/pkg/lib/interaction/nodes"
+)
+
+func TestIntentStepUp(t *testing.T) {
+	Convey("IntentStepUp", t, func() {
+		intent := NewIntentStepUp("user-id", "session-id")
+
+		beginAuthentication := func() []interaction.Edge {
+			edges, err := intent.DeriveEdgesForNode(nil, &nodes.NodeDoUseUser{UseUserID: "user-id"})
+			So(err, ShouldBeNil)
+			So(edges, ShouldHaveLength, 1)
+
+			node, err := edges[0].Instantiate(nil, nil, nil)
+			So(err, ShouldBeNil)
+
+			begin := node.(*nodes.NodeAuthenticationBegin)
+			begin.AuthenticationConfig = &config.AuthenticationConfig{
+				SecondaryAuthenticators:     []authn.AuthenticatorType{authn.AuthenticatorTypeTOTP},
+				SecondaryAuthenticationMode: config.SecondaryAuthenticationModeIfExists,
+				DeviceToken:                 &config.DeviceTokenConfig{},
+			}
+			begin.Authenticators = []*authenticator.Info{{
+				ID:     "totp-id",
+				UserID: "user-id",
+				Type:   authn.AuthenticatorTypeTOTP,
+				Kind:   authenticator.KindSecondary,
+			}}
+
+			edges, err = begin.GetAuthenticationEdges()
+			So(err, ShouldBeNil)
+			return edges
+		}
+
+		Convey("should require secondary authenticator", func() {
+			edges := beginAuthentication()
+			So(edges, ShouldHaveLength, 1)
+			So(edges[0], ShouldHaveSameTypeAs, &nodes.EdgeAuthenticationTOTP{})
+		})
+
+		Convey("should not allow recovery code nor device token", func() {
+			for _, edge := range beginAuthentication() {
+				So(edge, ShouldNotHaveSameTypeAs, &nodes.EdgeConsumeRecoveryCode{})
+				So(edge, ShouldNotHaveSameTypeAs, &nodes.EdgeUseDeviceToken{})
+			}
+		})
+	})
+}
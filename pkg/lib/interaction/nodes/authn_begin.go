@@ -16,19 +16,29 @@ func init() {
 
 type EdgeAuthenticationBegin struct {
 	Stage interaction.AuthenticationStage
+	// DeviceTokenDisallowed requires the user to authenticate even if the
+	// device is remembered.
+	DeviceTokenDisallowed bool
+	// RecoveryCodeDisallowed requires the user to authenticate with a
+	// secondary authenticator instead of a recovery code.
+	RecoveryCodeDisallowed bool
 }
 
 func (e *EdgeAuthenticationBegin) Instantiate(ctx *interaction.Context, graph *interaction.Graph, input interface{}) (interaction.Node, error) {
 	return &NodeAuthenticationBegin{
-		Stage: e.Stage,
+		Stage:                  e.Stage,
+		DeviceTokenDisallowed:  e.DeviceTokenDisallowed,
+		RecoveryCodeDisallowed: e.RecoveryCodeDisallowed,
 	}, nil
 }
 
 type NodeAuthenticationBegin struct {
-	Stage                interaction.AuthenticationStage `json:"stage"`
-	Identity             *identity.Info                  `json:"-"`
-	AuthenticationConfig *config.AuthenticationConfig    `json:"-"`
-	Authenticators       []*authenticator.Info           `json:"-"`
+	Stage                  interaction.AuthenticationStage `json:"stage"`
+	DeviceTokenDisallowed  bool                            `json:"device_token_disallowed,omitempty"`
+	RecoveryCodeDisallowed bool                            `json:"recovery_code_disallowed,omitempty"`
+	Identity               *identity.Info                  `json:"-"`
+	AuthenticationConfig   *config.AuthenticationConfig    `json:"-"`
+	Authenticators         []*authenticator.Info           `json:"-"`
 }
 
 func (n *NodeAuthenticationBegin) Prepare(ctx *interaction.Context, graph *interaction.Graph) error {
@@ -37,7 +47,12 @@ func (n *NodeAuthenticationBegin) Prepare(ctx *interaction.Context, graph *inter
 		return err
 	}
 
-	n.Identity = graph.MustGetUserLastIdentity()
+	// Secondary authentication does not depend on identity, so that it can
+	// be performed without selecting an identity, e.g. in step-up
+	// authentication.
+	if n.Stage == interaction.AuthenticationStagePrimary {
+		n.Identity = graph.MustGetUserLastIdentity()
+	}
 	n.AuthenticationConfig = ctx.Config.Authentication
 	n.Authenticators = ais
 	return nil
@@ -136,6 +151,19 @@ func (n *NodeAuthenticationBegin) GetAuthenticationEdges() ([]interaction.Edge,
 		},
 	)
 
+	webauthns := filterAuthenticators(
+		availableAuthenticators,
+		authenticator.KeepType(authn.AuthenticatorTypeWebAuthn),
+	)
+	interaction.SortAuthenticators(
+		nil,
+		webauthns,
+		func(i int) interaction.SortableAuthenticator {
+			a := interaction.SortableAuthenticatorInfo(*webauthns[i])
+			return &a
+		},
+	)
+
 	if len(passwords) > 0 {
 		edges = append(edges, &EdgeAuthenticationPassword{
 			Stage:          n.Stage,
@@ -157,6 +185,13 @@ func (n *NodeAuthenticationBegin) GetAuthenticationEdges() ([]interaction.Edge,
 		})
 	}
 
+	if len(webauthns) > 0 {
+		edges = append(edges, &EdgeAuthenticationWebAuthnTrigger{
+			Stage:          n.Stage,
+			Authenticators: webauthns,
+		})
+	}
+
 	// No authenticators found, skip the authentication stage if not required.
 	// If identity requires authentication, the identity cannot be authenticated.
 	if len(edges) == 0 {
@@ -194,10 +229,12 @@ func (n *NodeAuthenticationBegin) GetAuthenticationEdges() ([]interaction.Edge,
 		// so we have to allow the use of recovery code.
 		// We have to add after the sorting because
 		// recovery code is not an authenticator.
-		edges = append(edges, &EdgeConsumeRecoveryCode{})
+		if !n.RecoveryCodeDisallowed {
+			edges = append(edges, &EdgeConsumeRecoveryCode{})
+		}
 
 		// Allow the use of device token.
-		if !n.AuthenticationConfig.DeviceToken.Disabled {
+		if !n.AuthenticationConfig.DeviceToken.Disabled && !n.DeviceTokenDisallowed {
 			edges = append(edges, &EdgeUseDeviceToken{})
 		}
 	}
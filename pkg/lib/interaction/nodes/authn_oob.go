@@ -1,6 +1,7 @@
 package nodes
 
 import (
+	"github.com/authgear/authgear-server/pkg/lib/authn"
 	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator"
 	"github.com/authgear/authgear-server/pkg/lib/interaction"
 )
@@ -26,10 +27,20 @@ func (e *EdgeAuthenticationOOB) Instantiate(ctx *interaction.Context, graph *int
 	}
 
 	info := e.Authenticator
+	if err := takeAuthenticationToken(ctx, info.UserID); err != nil {
+		return nil, err
+	}
+	if err := ctx.Lockout.Check(info.UserID); err != nil {
+		return nil, err
+	}
+
 	err := ctx.Authenticators.VerifySecret(info, map[string]string{
 		authenticator.AuthenticatorStateOOBOTPSecret: e.Secret,
 	}, input.GetOOBOTP())
 	if err != nil {
+		if err := recordAuthenticationFailure(ctx, info.UserID, string(authn.AuthenticatorTypeOOB)); err != nil {
+			return nil, err
+		}
 		info = nil
 	}
 
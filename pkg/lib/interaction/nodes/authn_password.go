@@ -38,6 +38,14 @@ func (e *EdgeAuthenticationPassword) Instantiate(ctx *interaction.Context, graph
 
 	inputPassword := input.GetPassword()
 
+	userID := graph.MustGetUserID()
+	if err := takeAuthenticationToken(ctx, userID); err != nil {
+		return nil, err
+	}
+	if err := ctx.Lockout.Check(userID); err != nil {
+		return nil, err
+	}
+
 	var info *authenticator.Info
 	for _, a := range e.Authenticators {
 		err := ctx.Authenticators.VerifySecret(a, nil, inputPassword)
@@ -51,6 +59,12 @@ func (e *EdgeAuthenticationPassword) Instantiate(ctx *interaction.Context, graph
 		}
 	}
 
+	if info == nil {
+		if err := recordAuthenticationFailure(ctx, userID, string(authn.AuthenticatorTypePassword)); err != nil {
+			return nil, err
+		}
+	}
+
 	return &NodeAuthenticationPassword{Stage: e.Stage, Authenticator: info}, nil
 }
 
@@ -38,6 +38,14 @@ func (e *EdgeAuthenticationTOTP) Instantiate(ctx *interaction.Context, graph *in
 
 	inputTOTP := input.GetTOTP()
 
+	userID := graph.MustGetUserID()
+	if err := takeAuthenticationToken(ctx, userID); err != nil {
+		return nil, err
+	}
+	if err := ctx.Lockout.Check(userID); err != nil {
+		return nil, err
+	}
+
 	var info *authenticator.Info
 	for _, a := range e.Authenticators {
 		err := ctx.Authenticators.VerifySecret(a, nil, inputTOTP)
@@ -51,6 +59,12 @@ func (e *EdgeAuthenticationTOTP) Instantiate(ctx *interaction.Context, graph *in
 		}
 	}
 
+	if info == nil {
+		if err := recordAuthenticationFailure(ctx, userID, string(authn.AuthenticatorTypeTOTP)); err != nil {
+			return nil, err
+		}
+	}
+
 	return &NodeAuthenticationTOTP{Stage: e.Stage, Authenticator: info}, nil
 }
 
@@ -0,0 +1,93 @@
+package nodes
+
+import (
+	"errors"
+
+	"github.com/authgear/authgear-server/pkg/lib/authn"
+	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator"
+	"github.com/authgear/authgear-server/pkg/lib/interaction"
+)
+
+func init() {
+	interaction.RegisterNode(&NodeAuthenticationWebAuthn{})
+}
+
+type InputAuthenticationWebAuthn interface {
+	GetWebAuthnAssertionResponse() string
+}
+
+type EdgeAuthenticationWebAuthn struct {
+	Stage          interaction.AuthenticationStage
+	Authenticators []*authenticator.Info
+}
+
+func (e *EdgeAuthenticationWebAuthn) Instantiate(ctx *interaction.Context, graph *interaction.Graph, rawInput interface{}) (interaction.Node, error) {
+	var input InputAuthenticationWebAuthn
+	if !interaction.Input(rawInput, &input) {
+		return nil, interaction.ErrIncompatibleInput
+	}
+
+	response := input.GetWebAuthnAssertionResponse()
+
+	userID := graph.MustGetUserID()
+	if err := takeAuthenticationToken(ctx, userID); err != nil {
+		return nil, err
+	}
+	if err := ctx.Lockout.Check(userID); err != nil {
+		return nil, err
+	}
+
+	// The response is signed by only one of the authenticators, and the
+	// others reject it without consuming the ceremony.
+	var info *authenticator.Info
+	for _, a := range e.Authenticators {
+		err := ctx.Authenticators.VerifySecret(a, nil, response)
+		if errors.Is(err, authenticator.ErrInvalidCredentials) {
+			continue
+		} else if err != nil {
+			return nil, err
+		} else {
+			aa := a
+			info = aa
+			break
+		}
+	}
+
+	if info == nil {
+		if err := recordAuthenticationFailure(ctx, userID, string(authn.AuthenticatorTypeWebAuthn)); err != nil {
+			return nil, err
+		}
+	}
+
+	return &NodeAuthenticationWebAuthn{Stage: e.Stage, Authenticator: info}, nil
+}
+
+type NodeAuthenticationWebAuthn struct {
+	Stage         interaction.AuthenticationStage `json:"stage"`
+	Authenticator *authenticator.Info             `json:"authenticator"`
+}
+
+func (n *NodeAuthenticationWebAuthn) Prepare(ctx *interaction.Context, graph *interaction.Graph) error {
+	return nil
+}
+
+func (n *NodeAuthenticationWebAuthn) Apply(perform func(eff interaction.Effect) error, graph *interaction.Graph) error {
+	if n.Authenticator == nil {
+		return nil
+	}
+
+	// Save the signature counter only if the interaction is committed, so
+	// that a cloned authenticator can be detected next time.
+	return perform(interaction.EffectOnCommit(func(ctx *interaction.Context) error {
+		return ctx.Authenticators.Update(n.Authenticator)
+	}))
+}
+
+func (n *NodeAuthenticationWebAuthn) DeriveEdges(graph *interaction.Graph) ([]interaction.Edge, error) {
+	return []interaction.Edge{
+		&EdgeAuthenticationEnd{
+			Stage:                 n.Stage,
+			VerifiedAuthenticator: n.Authenticator,
+		},
+	}, nil
+}
@@ -0,0 +1,79 @@
+package nodes
+
+import (
+	"encoding/json"
+
+	"github.com/authgear/authgear-server/pkg/lib/authn"
+	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator"
+	"github.com/authgear/authgear-server/pkg/lib/interaction"
+)
+
+func init() {
+	interaction.RegisterNode(&NodeAuthenticationWebAuthnTrigger{})
+}
+
+type InputAuthenticationWebAuthnTrigger interface {
+	TriggerWebAuthn()
+}
+
+type EdgeAuthenticationWebAuthnTrigger struct {
+	Stage          interaction.AuthenticationStage
+	Authenticators []*authenticator.Info
+}
+
+func (e *EdgeAuthenticationWebAuthnTrigger) AuthenticatorType() authn.AuthenticatorType {
+	return authn.AuthenticatorTypeWebAuthn
+}
+
+func (e *EdgeAuthenticationWebAuthnTrigger) IsDefaultAuthenticator() bool {
+	filtered := filterAuthenticators(e.Authenticators, authenticator.KeepDefault)
+	return len(filtered) > 0
+}
+
+func (e *EdgeAuthenticationWebAuthnTrigger) Instantiate(ctx *interaction.Context, graph *interaction.Graph, rawInput interface{}) (interaction.Node, error) {
+	var input InputAuthenticationWebAuthnTrigger
+	if !interaction.Input(rawInput, &input) {
+		return nil, interaction.ErrIncompatibleInput
+	}
+
+	var credentialIDs []string
+	for _, a := range e.Authenticators {
+		credentialIDs = append(credentialIDs, a.Claims[authenticator.AuthenticatorClaimWebAuthnCredentialID].(string))
+	}
+
+	options, err := ctx.WebAuthnAuthenticators.BeginAssertion(graph.MustGetUserID(), credentialIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NodeAuthenticationWebAuthnTrigger{
+		Stage:          e.Stage,
+		Authenticators: e.Authenticators,
+		RequestOptions: options,
+	}, nil
+}
+
+type NodeAuthenticationWebAuthnTrigger struct {
+	Stage          interaction.AuthenticationStage `json:"stage"`
+	Authenticators []*authenticator.Info           `json:"authenticators"`
+	RequestOptions json.RawMessage                 `json:"request_options"`
+}
+
+func (n *NodeAuthenticationWebAuthnTrigger) Prepare(ctx *interaction.Context, graph *interaction.Graph) error {
+	return nil
+}
+
+func (n *NodeAuthenticationWebAuthnTrigger) Apply(perform func(eff interaction.Effect) error, graph *interaction.Graph) error {
+	return nil
+}
+
+func (n *NodeAuthenticationWebAuthnTrigger) DeriveEdges(graph *interaction.Graph) ([]interaction.Edge, error) {
+	return []interaction.Edge{
+		&EdgeAuthenticationWebAuthn{Stage: n.Stage, Authenticators: n.Authenticators},
+	}, nil
+}
+
+// GetWebAuthnRequestOptions implements EnterWebAuthnNode.
+func (n *NodeAuthenticationWebAuthnTrigger) GetWebAuthnRequestOptions() json.RawMessage {
+	return n.RequestOptions
+}
@@ -177,6 +177,12 @@ func (n *NodeCreateAuthenticatorBegin) derivePrimary() ([]interaction.Edge, erro
 					Target:    target,
 				})
 			}
+
+		case authn.AuthenticatorTypeWebAuthn:
+			edges = append(edges, &EdgeCreateAuthenticatorWebAuthnSetup{
+				Stage:     n.Stage,
+				IsDefault: isDefault,
+			})
 		default:
 			panic(fmt.Sprintf("interaction: unknown authenticator type: %s", t))
 		}
@@ -232,6 +238,7 @@ func (n *NodeCreateAuthenticatorBegin) deriveSecondary() (edges []interaction.Ed
 	totpCount := 0
 	oobSMSCount := 0
 	oobEmailCount := 0
+	webauthnCount := 0
 	for _, a := range ais {
 		switch a.Type {
 		case authn.AuthenticatorTypePassword:
@@ -248,6 +255,8 @@ func (n *NodeCreateAuthenticatorBegin) deriveSecondary() (edges []interaction.Ed
 			default:
 				panic("interaction: unknown OOB channel: " + channel)
 			}
+		case authn.AuthenticatorTypeWebAuthn:
+			webauthnCount++
 		default:
 			panic("interaction: unknown authenticator type: " + a.Type)
 		}
@@ -287,6 +296,14 @@ func (n *NodeCreateAuthenticatorBegin) deriveSecondary() (edges []interaction.Ed
 					AllowedChannels: allowedChannels,
 				})
 			}
+		case authn.AuthenticatorTypeWebAuthn:
+			// Condition B and C.
+			if webauthnCount < *n.AuthenticatorConfig.WebAuthn.Maximum {
+				edges = append(edges, &EdgeCreateAuthenticatorWebAuthnSetup{
+					Stage:     n.Stage,
+					IsDefault: isDefault,
+				})
+			}
 		default:
 			panic("interaction: unknown authenticator type: " + typ)
 		}
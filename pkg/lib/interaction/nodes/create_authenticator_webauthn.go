@@ -0,0 +1,71 @@
+package nodes
+
+import (
+	"errors"
+
+	"github.com/authgear/authgear-server/pkg/lib/authn"
+	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator"
+	"github.com/authgear/authgear-server/pkg/lib/interaction"
+)
+
+func init() {
+	interaction.RegisterNode(&NodeCreateAuthenticatorWebAuthn{})
+}
+
+type InputCreateAuthenticatorWebAuthn interface {
+	GetWebAuthnAttestationResponse() string
+	GetWebAuthnDisplayName() string
+}
+
+type EdgeCreateAuthenticatorWebAuthn struct {
+	Stage     interaction.AuthenticationStage
+	IsDefault bool
+}
+
+func (e *EdgeCreateAuthenticatorWebAuthn) Instantiate(ctx *interaction.Context, graph *interaction.Graph, rawInput interface{}) (interaction.Node, error) {
+	var input InputCreateAuthenticatorWebAuthn
+	if !interaction.Input(rawInput, &input) {
+		return nil, interaction.ErrIncompatibleInput
+	}
+
+	spec := &authenticator.Spec{
+		UserID:    graph.MustGetUserID(),
+		IsDefault: e.IsDefault,
+		Kind:      stageToAuthenticatorKind(e.Stage),
+		Type:      authn.AuthenticatorTypeWebAuthn,
+		Claims: map[string]interface{}{
+			authenticator.AuthenticatorClaimWebAuthnDisplayName: input.GetWebAuthnDisplayName(),
+		},
+	}
+
+	info, err := ctx.Authenticators.New(spec, input.GetWebAuthnAttestationResponse())
+	if errors.Is(err, authenticator.ErrInvalidCredentials) {
+		return nil, interaction.ErrInvalidCredentials
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &NodeCreateAuthenticatorWebAuthn{Stage: e.Stage, Authenticator: info}, nil
+}
+
+type NodeCreateAuthenticatorWebAuthn struct {
+	Stage         interaction.AuthenticationStage `json:"stage"`
+	Authenticator *authenticator.Info             `json:"authenticator"`
+}
+
+func (n *NodeCreateAuthenticatorWebAuthn) Prepare(ctx *interaction.Context, graph *interaction.Graph) error {
+	return nil
+}
+
+func (n *NodeCreateAuthenticatorWebAuthn) Apply(perform func(eff interaction.Effect) error, graph *interaction.Graph) error {
+	return nil
+}
+
+func (n *NodeCreateAuthenticatorWebAuthn) DeriveEdges(graph *interaction.Graph) ([]interaction.Edge, error) {
+	return []interaction.Edge{
+		&EdgeCreateAuthenticatorEnd{
+			Stage:          n.Stage,
+			Authenticators: []*authenticator.Info{n.Authenticator},
+		},
+	}, nil
+}
@@ -0,0 +1,98 @@
+package nodes
+
+import (
+	"encoding/json"
+
+	"github.com/authgear/authgear-server/pkg/lib/authn"
+	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator"
+	"github.com/authgear/authgear-server/pkg/lib/authn/identity"
+	"github.com/authgear/authgear-server/pkg/lib/interaction"
+)
+
+func init() {
+	interaction.RegisterNode(&NodeCreateAuthenticatorWebAuthnSetup{})
+}
+
+type InputCreateAuthenticatorWebAuthnSetup interface {
+	SetupWebAuthn()
+}
+
+type EdgeCreateAuthenticatorWebAuthnSetup struct {
+	Stage     interaction.AuthenticationStage
+	IsDefault bool
+}
+
+func (e *EdgeCreateAuthenticatorWebAuthnSetup) AuthenticatorType() authn.AuthenticatorType {
+	return authn.AuthenticatorTypeWebAuthn
+}
+
+func (e *EdgeCreateAuthenticatorWebAuthnSetup) IsDefaultAuthenticator() bool {
+	return false
+}
+
+func (e *EdgeCreateAuthenticatorWebAuthnSetup) Instantiate(ctx *interaction.Context, graph *interaction.Graph, rawInput interface{}) (interaction.Node, error) {
+	var input InputCreateAuthenticatorWebAuthnSetup
+	if !interaction.Input(rawInput, &input) {
+		return nil, interaction.ErrIncompatibleInput
+	}
+
+	userID := graph.MustGetUserID()
+
+	// Exclude registered credentials, so that the same security key is not
+	// registered twice.
+	ais, err := ctx.Authenticators.List(userID, authenticator.KeepType(authn.AuthenticatorTypeWebAuthn))
+	if err != nil {
+		return nil, err
+	}
+	var excludeCredentialIDs []string
+	for _, a := range ais {
+		excludeCredentialIDs = append(excludeCredentialIDs, a.Claims[authenticator.AuthenticatorClaimWebAuthnCredentialID].(string))
+	}
+
+	userName := userID
+	var identityNode interface{ UserIdentity() *identity.Info }
+	if graph.FindLastNode(&identityNode) {
+		if displayID := identityNode.UserIdentity().DisplayID(); displayID != "" {
+			userName = displayID
+		}
+	}
+
+	options, err := ctx.WebAuthnAuthenticators.BeginRegistration(userID, userName, excludeCredentialIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NodeCreateAuthenticatorWebAuthnSetup{
+		Stage:           e.Stage,
+		IsDefault:       e.IsDefault,
+		CreationOptions: options,
+	}, nil
+}
+
+type NodeCreateAuthenticatorWebAuthnSetup struct {
+	Stage           interaction.AuthenticationStage `json:"stage"`
+	IsDefault       bool                            `json:"is_default"`
+	CreationOptions json.RawMessage                 `json:"creation_options"`
+}
+
+func (n *NodeCreateAuthenticatorWebAuthnSetup) Prepare(ctx *interaction.Context, graph *interaction.Graph) error {
+	return nil
+}
+
+func (n *NodeCreateAuthenticatorWebAuthnSetup) Apply(perform func(eff interaction.Effect) error, graph *interaction.Graph) error {
+	return nil
+}
+
+func (n *NodeCreateAuthenticatorWebAuthnSetup) DeriveEdges(graph *interaction.Graph) ([]interaction.Edge, error) {
+	return []interaction.Edge{
+		&EdgeCreateAuthenticatorWebAuthn{
+			Stage:     n.Stage,
+			IsDefault: n.IsDefault,
+		},
+	}, nil
+}
+
+// GetWebAuthnCreationOptions implements SetupWebAuthnNode.
+func (n *NodeCreateAuthenticatorWebAuthnSetup) GetWebAuthnCreationOptions() json.RawMessage {
+	return n.CreationOptions
+}
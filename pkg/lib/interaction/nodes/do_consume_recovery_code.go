@@ -26,13 +26,18 @@ func (e *EdgeConsumeRecoveryCode) Instantiate(ctx *interaction.Context, graph *i
 	userID := graph.MustGetUserID()
 	recoveryCode := input.GetRecoveryCode()
 
+	if err := takeAuthenticationToken(ctx, userID); err != nil {
+		return nil, err
+	}
+	if err := ctx.Lockout.Check(userID); err != nil {
+		return nil, err
+	}
+
 	rc, err := ctx.MFA.GetRecoveryCode(userID, recoveryCode)
-	if errors.Is(err, mfa.ErrRecoveryCodeNotFound) {
-		return &NodeAuthenticationEnd{
-			Stage:  interaction.AuthenticationStageSecondary,
-			Result: AuthenticationResultRecoveryCode,
-		}, nil
-	} else if errors.Is(err, mfa.ErrRecoveryCodeConsumed) {
+	if errors.Is(err, mfa.ErrRecoveryCodeNotFound) || errors.Is(err, mfa.ErrRecoveryCodeConsumed) {
+		if err := recordAuthenticationFailure(ctx, userID, string(AuthenticationResultRecoveryCode)); err != nil {
+			return nil, err
+		}
 		return &NodeAuthenticationEnd{
 			Stage:  interaction.AuthenticationStageSecondary,
 			Result: AuthenticationResultRecoveryCode,
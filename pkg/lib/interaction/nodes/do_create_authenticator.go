@@ -1,6 +1,7 @@
 package nodes
 
 import (
+	"github.com/authgear/authgear-server/pkg/lib/audit"
 	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator"
 	"github.com/authgear/authgear-server/pkg/lib/interaction"
 )
@@ -44,6 +45,17 @@ func (n *NodeDoCreateAuthenticator) Apply(perform func(eff interaction.Effect) e
 		return err
 	}
 
+	err = perform(interaction.EffectOnCommit(func(ctx *interaction.Context) error {
+		for _, a := range n.Authenticators {
+			ctx.Audit.Log(audit.ActivityAuthenticatorCreated, a.UserID, authenticatorAuditData(a))
+		}
+
+		return nil
+	}))
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/authgear/authgear-server/pkg/api/event"
+	"github.com/authgear/authgear-server/pkg/lib/audit"
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity"
 	"github.com/authgear/authgear-server/pkg/lib/interaction"
 )
@@ -49,6 +50,8 @@ func (n *NodeDoCreateIdentity) Apply(perform func(eff interaction.Effect) error,
 	}
 
 	err = perform(interaction.EffectOnCommit(func(ctx *interaction.Context) error {
+		ctx.Audit.Log(audit.ActivityIdentityCreated, n.Identity.UserID, identityAuditData(n.Identity))
+
 		if _, creating := graph.GetNewUserID(); creating {
 			return nil
 		}
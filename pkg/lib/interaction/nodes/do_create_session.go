@@ -5,6 +5,7 @@ import (
 
 	"github.com/authgear/authgear-server/pkg/api/event"
 	"github.com/authgear/authgear-server/pkg/api/model"
+	"github.com/authgear/authgear-server/pkg/lib/audit"
 	"github.com/authgear/authgear-server/pkg/lib/authn"
 	"github.com/authgear/authgear-server/pkg/lib/interaction"
 	"github.com/authgear/authgear-server/pkg/lib/session"
@@ -23,9 +24,20 @@ func (e *EdgeDoCreateSession) Instantiate(ctx *interaction.Context, graph *inter
 	amr := graph.GetAMR()
 	acr := graph.GetACR(amr)
 	userIdentity := graph.MustGetUserLastIdentity()
+	userID := graph.MustGetUserID()
+
+	// Disabled users cannot log in. This is checked after authentication
+	// so that the status of the user is not disclosed to others.
+	user, err := ctx.Users.GetRaw(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := user.CheckStatus(ctx.Clock.NowUTC()); err != nil {
+		return nil, err
+	}
 
 	attrs := &session.Attrs{
-		UserID: graph.MustGetUserID(),
+		UserID: userID,
 		Claims: map[authn.ClaimName]interface{}{},
 	}
 	attrs.SetAMR(amr)
@@ -35,6 +47,7 @@ func (e *EdgeDoCreateSession) Instantiate(ctx *interaction.Context, graph *inter
 	}
 
 	sess, token := ctx.Sessions.MakeSession(attrs)
+	sess.Attrs.SetAuthTime(sess.CreatedAt)
 	cookie := ctx.CookieFactory.ValueCookie(ctx.SessionCookie.Def, token)
 
 	return &NodeDoCreateSession{
@@ -114,6 +127,18 @@ func (n *NodeDoCreateSession) Apply(perform func(eff interaction.Effect) error,
 			return err
 		}
 
+		err = ctx.Lockout.RecordSuccess(user.ID)
+		if err != nil {
+			return err
+		}
+
+		amr, _ := n.Session.Attrs.GetAMR()
+		ctx.Audit.Log(audit.ActivityAuthenticationSucceeded, user.ID, map[string]interface{}{
+			"session_id": n.Session.ID,
+			"reason":     string(n.Reason),
+			"amr":        amr,
+		})
+
 		err = ctx.Hooks.DispatchEvent(&event.SessionCreateEvent{
 			Reason:  string(n.Reason),
 			User:    *user,
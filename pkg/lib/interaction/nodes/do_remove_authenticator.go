@@ -1,6 +1,7 @@
 package nodes
 
 import (
+	"github.com/authgear/authgear-server/pkg/lib/audit"
 	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator"
 	"github.com/authgear/authgear-server/pkg/lib/config"
 	"github.com/authgear/authgear-server/pkg/lib/interaction"
@@ -87,6 +88,14 @@ func (n *NodeDoRemoveAuthenticator) Apply(perform func(eff interaction.Effect) e
 		return err
 	}
 
+	err = perform(interaction.EffectOnCommit(func(ctx *interaction.Context) error {
+		ctx.Audit.Log(audit.ActivityAuthenticatorDeleted, n.Authenticator.UserID, authenticatorAuditData(n.Authenticator))
+		return nil
+	}))
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
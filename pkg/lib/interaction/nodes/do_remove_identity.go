@@ -2,6 +2,7 @@ package nodes
 
 import (
 	"github.com/authgear/authgear-server/pkg/api/event"
+	"github.com/authgear/authgear-server/pkg/lib/audit"
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity"
 	"github.com/authgear/authgear-server/pkg/lib/interaction"
 )
@@ -56,6 +57,8 @@ func (n *NodeDoRemoveIdentity) Apply(perform func(eff interaction.Effect) error,
 	}
 
 	err = perform(interaction.EffectOnCommit(func(ctx *interaction.Context) error {
+		ctx.Audit.Log(audit.ActivityIdentityDeleted, n.Identity.UserID, identityAuditData(n.Identity))
+
 		user, err := ctx.Users.Get(n.Identity.UserID)
 		if err != nil {
 			return err
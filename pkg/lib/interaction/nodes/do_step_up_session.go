@@ -0,0 +1,68 @@
+package nodes
+
+import (
+	"sort"
+
+	"github.com/authgear/authgear-server/pkg/lib/interaction"
+)
+
+func init() {
+	interaction.RegisterNode(&NodeDoStepUpSession{})
+}
+
+type EdgeDoStepUpSession struct {
+	SessionID string
+}
+
+func (e *EdgeDoStepUpSession) Instantiate(ctx *interaction.Context, graph *interaction.Graph, input interface{}) (interaction.Node, error) {
+	return &NodeDoStepUpSession{
+		SessionID: e.SessionID,
+		AMR:       graph.GetAMR(),
+	}, nil
+}
+
+// NodeDoStepUpSession records the secondary authentication performed in
+// step-up authentication to the existing IDP session.
+type NodeDoStepUpSession struct {
+	SessionID string   `json:"session_id"`
+	AMR       []string `json:"amr"`
+}
+
+func (n *NodeDoStepUpSession) Prepare(ctx *interaction.Context, graph *interaction.Graph) error {
+	return nil
+}
+
+func (n *NodeDoStepUpSession) Apply(perform func(eff interaction.Effect) error, graph *interaction.Graph) error {
+	return perform(interaction.EffectOnCommit(func(ctx *interaction.Context) error {
+		s, err := ctx.Sessions.Get(n.SessionID)
+		if err != nil {
+			return err
+		}
+
+		if s.Attrs.UserID != graph.MustGetUserID() {
+			panic("interaction: step-up session user mismatch")
+		}
+
+		seen := map[string]struct{}{}
+		var amr []string
+		existing, _ := s.Attrs.GetAMR()
+		values := append(existing, n.AMR...)
+		for _, v := range values {
+			if _, ok := seen[v]; ok {
+				continue
+			}
+			seen[v] = struct{}{}
+			amr = append(amr, v)
+		}
+		sort.Strings(amr)
+
+		s.Attrs.SetAMR(amr)
+		s.Attrs.SetACR(graph.GetACR(amr))
+		s.Attrs.SetAuthTime(ctx.Clock.NowUTC())
+		return ctx.Sessions.Update(s)
+	}))
+}
+
+func (n *NodeDoStepUpSession) DeriveEdges(graph *interaction.Graph) ([]interaction.Edge, error) {
+	return graph.Intent.DeriveEdgesForNode(graph, n)
+}
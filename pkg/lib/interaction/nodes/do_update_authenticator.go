@@ -1,6 +1,7 @@
 package nodes
 
 import (
+	"github.com/authgear/authgear-server/pkg/lib/audit"
 	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator"
 	"github.com/authgear/authgear-server/pkg/lib/interaction"
 )
@@ -40,6 +41,15 @@ func (n *NodeDoUpdateAuthenticator) Apply(perform func(eff interaction.Effect) e
 		return err
 	}
 
+	err = perform(interaction.EffectOnCommit(func(ctx *interaction.Context) error {
+		a := n.AuthenticatorAfterUpdate
+		ctx.Audit.Log(audit.ActivityAuthenticatorUpdated, a.UserID, authenticatorAuditData(a))
+		return nil
+	}))
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/authgear/authgear-server/pkg/api/event"
+	"github.com/authgear/authgear-server/pkg/lib/audit"
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity"
 	"github.com/authgear/authgear-server/pkg/lib/interaction"
 )
@@ -53,6 +54,8 @@ func (n *NodeDoUpdateIdentity) Apply(perform func(eff interaction.Effect) error,
 	}
 
 	err = perform(interaction.EffectOnCommit(func(ctx *interaction.Context) error {
+		ctx.Audit.Log(audit.ActivityIdentityUpdated, n.IdentityAfterUpdate.UserID, identityAuditData(n.IdentityAfterUpdate))
+
 		user, err := ctx.Users.Get(n.IdentityAfterUpdate.UserID)
 		if err != nil {
 			return err
@@ -1,6 +1,7 @@
 package nodes
 
 import (
+	"github.com/authgear/authgear-server/pkg/lib/audit"
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity"
 	"github.com/authgear/authgear-server/pkg/lib/feature/verification"
 	"github.com/authgear/authgear-server/pkg/lib/interaction"
@@ -45,6 +46,20 @@ func (n *NodeDoVerifyIdentity) Apply(perform func(eff interaction.Effect) error,
 		return err
 	}
 
+	err = perform(interaction.EffectOnCommit(func(ctx *interaction.Context) error {
+		if n.NewVerifiedClaim != nil {
+			ctx.Audit.Log(audit.ActivityClaimVerified, n.NewVerifiedClaim.UserID, map[string]interface{}{
+				"identity_id": n.Identity.ID,
+				"claim_name":  n.NewVerifiedClaim.Name,
+			})
+		}
+
+		return nil
+	}))
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
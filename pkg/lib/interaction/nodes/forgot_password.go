@@ -4,6 +4,7 @@ import (
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity"
 	"github.com/authgear/authgear-server/pkg/lib/config"
 	"github.com/authgear/authgear-server/pkg/lib/interaction"
+	"github.com/authgear/authgear-server/pkg/lib/ratelimit"
 )
 
 func init() {
@@ -62,7 +63,14 @@ func (e *EdgeForgotPasswordSelectLoginID) Instantiate(ctx *interaction.Context,
 
 	loginID := input.GetLoginID()
 
-	err := ctx.ForgotPassword.SendCode(loginID)
+	err := ctx.RateLimiter.TakeToken(ratelimit.NewBucket(
+		"ForgotPasswordPerIP", string(ctx.RemoteIP), ctx.Config.RateLimit.ForgotPasswordPerIP,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	err = ctx.ForgotPassword.SendCode(loginID)
 	if err != nil {
 		return nil, err
 	}
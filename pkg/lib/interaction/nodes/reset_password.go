@@ -1,6 +1,7 @@
 package nodes
 
 import (
+	"github.com/authgear/authgear-server/pkg/lib/audit"
 	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator"
 	"github.com/authgear/authgear-server/pkg/lib/interaction"
 )
@@ -84,6 +85,18 @@ type NodeResetPasswordEnd struct {
 }
 
 func (n *NodeResetPasswordEnd) Apply(perform func(eff interaction.Effect) error, graph *interaction.Graph) error {
+	err := perform(interaction.EffectOnCommit(func(ctx *interaction.Context) error {
+		// The password is unchanged if the new password is the same as the old one.
+		if n.NewAuthenticator != nil {
+			ctx.Audit.Log(audit.ActivityPasswordReset, n.NewAuthenticator.UserID, authenticatorAuditData(n.NewAuthenticator))
+		}
+
+		return nil
+	}))
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -0,0 +1,33 @@
+package nodes
+
+import (
+	"github.com/authgear/authgear-server/pkg/lib/audit"
+	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator"
+	"github.com/authgear/authgear-server/pkg/lib/authn/identity"
+	"github.com/authgear/authgear-server/pkg/lib/interaction"
+)
+
+func identityAuditData(info *identity.Info) map[string]interface{} {
+	return map[string]interface{}{
+		"identity_id":   info.ID,
+		"identity_type": string(info.Type),
+	}
+}
+
+func authenticatorAuditData(info *authenticator.Info) map[string]interface{} {
+	return map[string]interface{}{
+		"authenticator_id":   info.ID,
+		"authenticator_type": string(info.Type),
+		"authenticator_kind": string(info.Kind),
+	}
+}
+
+// recordAuthenticationFailure records a failed authentication attempt of
+// the user in the audit log and the lockout history. method is the
+// authenticator type, or "recovery_code".
+func recordAuthenticationFailure(ctx *interaction.Context, userID string, method string) error {
+	ctx.Audit.Log(audit.ActivityAuthenticationFailed, userID, map[string]interface{}{
+		"method": method,
+	})
+	return ctx.Lockout.RecordFailure(userID)
+}
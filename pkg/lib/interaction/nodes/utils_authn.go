@@ -5,6 +5,7 @@ import (
 	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator"
 	"github.com/authgear/authgear-server/pkg/lib/authn/otp"
 	"github.com/authgear/authgear-server/pkg/lib/interaction"
+	"github.com/authgear/authgear-server/pkg/lib/ratelimit"
 )
 
 func cloneAuthenticator(info *authenticator.Info) *authenticator.Info {
@@ -39,8 +40,6 @@ func sendOOBCode(
 	authenticatorInfo *authenticator.Info,
 	secret string,
 ) (*otp.CodeSendResult, error) {
-	// TODO(interaction): handle rate limits
-
 	channel := authn.AuthenticatorOOBChannel(authenticatorInfo.Claims[authenticator.AuthenticatorClaimOOBOTPChannelType].(string))
 
 	var messageType otp.MessageType
@@ -83,3 +82,21 @@ func stageToAuthenticatorKind(stage interaction.AuthenticationStage) authenticat
 		panic("interaction: unknown stage: " + stage)
 	}
 }
+
+// takeAuthenticationToken enforces the authentication rate limits
+// for the user and for the client IP.
+// It must be called before verifying a secret of the user.
+func takeAuthenticationToken(ctx *interaction.Context, userID string) error {
+	cfg := ctx.Config.RateLimit
+
+	err := ctx.RateLimiter.TakeToken(ratelimit.NewBucket(
+		"AuthenticationPerIP", string(ctx.RemoteIP), cfg.AuthenticationPerIP,
+	))
+	if err != nil {
+		return err
+	}
+
+	return ctx.RateLimiter.TakeToken(ratelimit.NewBucket(
+		"AuthenticationPerUser", userID, cfg.AuthenticationPerUser,
+	))
+}
@@ -63,6 +63,11 @@ func (s *Service) Get(instanceID string) (*Graph, error) {
 	return s.Store.GetGraphInstance(instanceID)
 }
 
+// DryRun instantiates the graph with fn inside a savepoint, which is always
+// rolled back. Changes that must persist even if the interaction does not
+// proceed, such as failed authentication attempts, are lost in the rollback.
+// Services making such changes buffer them and write them in the
+// db.TransactionHook when the transaction commits.
 func (s *Service) DryRun(webStateID string, fn func(*Context) (*Graph, error)) (err error) {
 	ctx, err := s.Context.initialize()
 	if err != nil {
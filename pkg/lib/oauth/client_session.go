@@ -0,0 +1,44 @@
+package oauth
+
+import (
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/api/model"
+	"github.com/authgear/authgear-server/pkg/lib/session"
+	"github.com/authgear/authgear-server/pkg/lib/session/access"
+)
+
+// ClientSession is the session of an access token issued to a client with
+// the client_credentials grant. It is not associated with any user.
+type ClientSession struct {
+	ClientID   string
+	Scopes     []string
+	CreatedAt  time.Time
+	AccessInfo access.Info
+}
+
+var _ session.Session = &ClientSession{}
+
+func (s *ClientSession) SessionID() string            { return s.ClientID }
+func (s *ClientSession) SessionType() session.Type    { return session.TypeClientCredentials }
+func (s *ClientSession) SessionAttrs() *session.Attrs { return &session.Attrs{} }
+
+func (s *ClientSession) GetCreatedAt() time.Time     { return s.CreatedAt }
+func (s *ClientSession) GetClientID() string         { return s.ClientID }
+func (s *ClientSession) GetAccessInfo() *access.Info { return &s.AccessInfo }
+
+func (s *ClientSession) ToAPIModel() *model.Session {
+	ua := model.ParseUserAgent(s.AccessInfo.LastAccess.UserAgent)
+	return &model.Session{
+		Meta: model.Meta{
+			ID:        s.ClientID,
+			CreatedAt: s.CreatedAt,
+			UpdatedAt: s.AccessInfo.LastAccess.Timestamp,
+		},
+
+		LastAccessedAt:   s.AccessInfo.LastAccess.Timestamp,
+		CreatedByIP:      s.AccessInfo.InitialAccess.RemoteIP,
+		LastAccessedByIP: s.AccessInfo.LastAccess.RemoteIP,
+		UserAgent:        ua,
+	}
+}
@@ -6,4 +6,6 @@ type EndpointsProvider interface {
 	AuthorizeEndpointURL() *url.URL
 	TokenEndpointURL() *url.URL
 	RevokeEndpointURL() *url.URL
+	IntrospectEndpointURL() *url.URL
+	DeviceAuthorizationEndpointURL() *url.URL
 }
@@ -4,3 +4,4 @@ import "errors"
 
 var ErrAuthorizationNotFound = errors.New("oauth authorization not found")
 var ErrGrantNotFound = errors.New("oauth grant not found")
+var ErrClientAssertionReplayed = errors.New("oauth client assertion is replayed")
@@ -5,6 +5,9 @@ type GrantSessionKind string
 const (
 	GrantSessionKindOffline GrantSessionKind = "offline_grant"
 	GrantSessionKindSession GrantSessionKind = "idp_session"
+	// GrantSessionKindClient is the kind of access grants issued to a client
+	// itself with the client_credentials grant; the session ID is the client ID.
+	GrantSessionKindClient GrantSessionKind = "client"
 )
 
 type Grant interface {
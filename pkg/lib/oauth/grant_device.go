@@ -0,0 +1,38 @@
+package oauth
+
+import "time"
+
+type DeviceGrantStatus string
+
+const (
+	DeviceGrantStatusPending  DeviceGrantStatus = "pending"
+	DeviceGrantStatusApproved DeviceGrantStatus = "approved"
+	DeviceGrantStatusDenied   DeviceGrantStatus = "denied"
+)
+
+// DeviceGrant is the grant of the device authorization grant (RFC 8628).
+// It is approved by the user through the webapp, and then exchanged by the
+// device for tokens at the token endpoint.
+type DeviceGrant struct {
+	AppID          string `json:"app_id"`
+	ClientID       string `json:"client_id"`
+	DeviceCodeHash string `json:"device_code_hash"`
+	UserCode       string `json:"user_code"`
+
+	CreatedAt    time.Time         `json:"created_at"`
+	ExpireAt     time.Time         `json:"expire_at"`
+	Scopes       []string          `json:"scopes"`
+	Interval     int               `json:"interval"`
+	LastPolledAt time.Time         `json:"last_polled_at"`
+	Status       DeviceGrantStatus `json:"status"`
+
+	// AuthorizationID and SessionID are set when the grant is approved.
+	AuthorizationID string `json:"authz_id,omitempty"`
+	SessionID       string `json:"session_id,omitempty"`
+}
+
+var _ Grant = &DeviceGrant{}
+
+func (g *DeviceGrant) Session() (kind GrantSessionKind, id string) {
+	return GrantSessionKindSession, g.SessionID
+}
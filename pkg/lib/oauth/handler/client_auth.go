@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jws"
+	"github.com/lestrrat-go/jwx/jwt"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+	"github.com/authgear/authgear-server/pkg/util/jwtutil"
+)
+
+const ClientAssertionTypeJWTBearer = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+// SupportedClientAssertionAlgorithms are the signing algorithms accepted in
+// private_key_jwt client assertions.
+var SupportedClientAssertionAlgorithms = []jwa.SignatureAlgorithm{
+	jwa.RS256,
+	jwa.ES256,
+}
+
+var errInvalidClient = protocol.NewError("invalid_client", "invalid client credentials")
+
+type clientAuthRequest interface {
+	ClientID() string
+	ClientSecret() string
+	ClientAssertionType() string
+	ClientAssertion() string
+}
+
+// ClientAuthenticator identifies the client of a token endpoint request, and
+// authenticates it with its registered authentication method.
+type ClientAuthenticator struct {
+	Request          *http.Request
+	Config           *config.OAuthConfig
+	Secrets          *config.OAuthClientSecrets
+	Endpoints        oauth.EndpointsProvider
+	ClientAssertions oauth.ClientAssertionStore
+	Clock            clock.Clock
+}
+
+func (a *ClientAuthenticator) Authenticate(r clientAuthRequest) (config.OAuthClientConfig, error) {
+	basicID, basicSecret, hasBasic := a.basicAuth()
+
+	methods := 0
+	if hasBasic {
+		methods++
+	}
+	if r.ClientSecret() != "" {
+		methods++
+	}
+	if r.ClientAssertion() != "" {
+		methods++
+	}
+	if methods > 1 {
+		return nil, protocol.NewError("invalid_request", "multiple client authentication methods are used")
+	}
+
+	clientID := r.ClientID()
+	var assertionHeader jws.Headers
+	var assertion jwt.Token
+	switch {
+	case hasBasic:
+		if clientID != "" && clientID != basicID {
+			return nil, protocol.NewError("invalid_request", "client ID mismatch")
+		}
+		clientID = basicID
+	case r.ClientAssertion() != "":
+		if r.ClientAssertionType() != ClientAssertionTypeJWTBearer {
+			return nil, protocol.NewError("invalid_request", "unsupported client assertion type")
+		}
+		hdr, token, err := jwtutil.SplitWithoutVerify([]byte(r.ClientAssertion()))
+		if err != nil {
+			return nil, errInvalidClient
+		}
+		if clientID == "" {
+			clientID = token.Issuer()
+		}
+		assertionHeader = hdr
+		assertion = token
+	}
+
+	client, ok := a.Config.GetClient(clientID)
+	if !ok {
+		return nil, protocol.NewError("invalid_client", "invalid client ID")
+	}
+
+	method := client.TokenEndpointAuthMethod()
+	switch method {
+	case config.OAuthClientAuthMethodNone:
+		if methods != 0 {
+			return nil, errInvalidClient
+		}
+	case config.OAuthClientAuthMethodClientSecretBasic:
+		if !hasBasic || !a.verifySecret(clientID, basicSecret) {
+			return nil, errInvalidClient
+		}
+	case config.OAuthClientAuthMethodClientSecretPost:
+		if r.ClientSecret() == "" || !a.verifySecret(clientID, r.ClientSecret()) {
+			return nil, errInvalidClient
+		}
+	case config.OAuthClientAuthMethodPrivateKeyJWT:
+		if assertion == nil || !a.verifyAssertion(client, r.ClientAssertion(), assertionHeader, assertion) {
+			return nil, errInvalidClient
+		}
+		// Client assertions can be used once, as required by OIDC core
+		// section 9.
+		err := a.ClientAssertions.UseClientAssertion(clientID, assertion.JwtID(), assertion.Expiration())
+		if errors.Is(err, oauth.ErrClientAssertionReplayed) {
+			return nil, errInvalidClient
+		} else if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errInvalidClient
+	}
+
+	return client, nil
+}
+
+// basicAuth extracts client credentials from HTTP Basic authentication.
+// The credentials are form-urlencoded as required by RFC 6749 section 2.3.1.
+func (a *ClientAuthenticator) basicAuth() (clientID string, clientSecret string, ok bool) {
+	if a.Request == nil {
+		return "", "", false
+	}
+	rawID, rawSecret, ok := a.Request.BasicAuth()
+	if !ok {
+		return "", "", false
+	}
+	clientID, err := url.QueryUnescape(rawID)
+	if err != nil {
+		return "", "", false
+	}
+	clientSecret, err = url.QueryUnescape(rawSecret)
+	if err != nil {
+		return "", "", false
+	}
+	return clientID, clientSecret, true
+}
+
+func (a *ClientAuthenticator) verifySecret(clientID string, secret string) bool {
+	if a.Secrets == nil {
+		return false
+	}
+	item, ok := a.Secrets.Lookup(clientID)
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(item.ClientSecret), []byte(secret)) == 1
+}
+
+// verifyAssertion verifies a client assertion as specified in RFC 7523.
+func (a *ClientAuthenticator) verifyAssertion(client config.OAuthClientConfig, compact string, hdr jws.Headers, assertion jwt.Token) bool {
+	keySet, err := client.JWKS()
+	if err != nil || keySet == nil {
+		return false
+	}
+
+	supported := false
+	for _, alg := range SupportedClientAssertionAlgorithms {
+		if hdr.Algorithm() == alg {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return false
+	}
+
+	verified := false
+	for _, key := range keySet.Keys {
+		if hdr.KeyID() != "" && key.KeyID() != hdr.KeyID() {
+			continue
+		}
+		var rawKey interface{}
+		if err := key.Raw(&rawKey); err != nil {
+			continue
+		}
+		if _, err := jws.Verify([]byte(compact), hdr.Algorithm(), rawKey); err == nil {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return false
+	}
+
+	clientID := client.ClientID()
+	if assertion.Issuer() != clientID || assertion.Subject() != clientID {
+		return false
+	}
+	if assertion.Expiration().IsZero() || assertion.JwtID() == "" {
+		return false
+	}
+
+	err = jwt.Verify(assertion,
+		jwt.WithClock(jwt.ClockFunc(func() time.Time { return a.Clock.NowUTC() })),
+		jwt.WithAudience(a.Endpoints.TokenEndpointURL().String()),
+	)
+	return err == nil
+}
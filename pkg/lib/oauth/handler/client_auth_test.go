@@ -0,0 +1,225 @@
+package handler_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jwk"
+	"github.com/lestrrat-go/jwx/jwt"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/handler"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+	"github.com/authgear/authgear-server/pkg/util/jwtutil"
+)
+
+type mockEndpointsProvider struct{}
+
+func (mockEndpointsProvider) AuthorizeEndpointURL() *url.URL {
+	u, _ := url.Parse("https://auth/oauth2/authorize")
+	return u
+}
+
+func (mockEndpointsProvider) TokenEndpointURL() *url.URL {
+	u, _ := url.Parse("https://auth/oauth2/token")
+	return u
+}
+
+func (mockEndpointsProvider) RevokeEndpointURL() *url.URL {
+	u, _ := url.Parse("https://auth/oauth2/revoke")
+	return u
+}
+
+func (mockEndpointsProvider) DeviceAuthorizationEndpointURL() *url.URL {
+	u, _ := url.Parse("https://auth/oauth2/device_authorization")
+	return u
+}
+
+func (mockEndpointsProvider) IntrospectEndpointURL() *url.URL {
+	u, _ := url.Parse("https://auth/oauth2/introspect")
+	return u
+}
+
+func TestClientAuthenticator(t *testing.T) {
+	Convey("ClientAuthenticator", t, func() {
+		clk := clock.NewMockClockAt("2020-02-01T00:00:00Z")
+
+		// nolint: gosec
+		privKey, err := rsa.GenerateKey(rand.Reader, 1024)
+		So(err, ShouldBeNil)
+		privJWK, err := jwk.New(privKey)
+		So(err, ShouldBeNil)
+		_ = privJWK.Set(jwk.KeyIDKey, "key-1")
+		pubJWK, err := jwk.New(&privKey.PublicKey)
+		So(err, ShouldBeNil)
+		_ = pubJWK.Set(jwk.KeyIDKey, "key-1")
+		_ = pubJWK.Set(jwk.AlgorithmKey, "RS256")
+		pubJWKJSON, err := json.Marshal(pubJWK)
+		So(err, ShouldBeNil)
+		var pubJWKMap map[string]interface{}
+		err = json.Unmarshal(pubJWKJSON, &pubJWKMap)
+		So(err, ShouldBeNil)
+
+		a := &handler.ClientAuthenticator{
+			Request: &http.Request{Header: http.Header{}},
+			Config: &config.OAuthConfig{
+				Clients: []config.OAuthClientConfig{
+					{"client_id": "public"},
+					{"client_id": "basic", "token_endpoint_auth_method": "client_secret_basic"},
+					{"client_id": "post", "token_endpoint_auth_method": "client_secret_post"},
+					{
+						"client_id":                  "jwt",
+						"token_endpoint_auth_method": "private_key_jwt",
+						"jwks": map[string]interface{}{
+							"keys": []interface{}{pubJWKMap},
+						},
+					},
+				},
+			},
+			Secrets: &config.OAuthClientSecrets{
+				Items: []config.OAuthClientSecretItem{
+					{ClientID: "basic", ClientSecret: "basic-secret"},
+					{ClientID: "post", ClientSecret: "post-secret"},
+				},
+			},
+			Endpoints:        mockEndpointsProvider{},
+			ClientAssertions: &mockClientAssertionStore{},
+			Clock:            clk,
+		}
+
+		jtis := 0
+		makeAssertion := func(iss string, aud string, exp time.Time) string {
+			jtis++
+			token := jwt.New()
+			_ = token.Set(jwt.JwtIDKey, fmt.Sprintf("jti-%d", jtis))
+			_ = token.Set(jwt.IssuerKey, iss)
+			_ = token.Set(jwt.SubjectKey, iss)
+			_ = token.Set(jwt.AudienceKey, aud)
+			_ = token.Set(jwt.ExpirationKey, exp.Unix())
+			signed, err := jwtutil.Sign(token, jwa.RS256, privJWK)
+			So(err, ShouldBeNil)
+			return string(signed)
+		}
+
+		Convey("should authenticate public clients without credentials", func() {
+			client, err := a.Authenticate(protocol.TokenRequest{"client_id": "public"})
+			So(err, ShouldBeNil)
+			So(client.ClientID(), ShouldEqual, "public")
+
+			_, err = a.Authenticate(protocol.TokenRequest{
+				"client_id":     "public",
+				"client_secret": "secret",
+			})
+			So(err, ShouldBeError, "invalid client credentials")
+		})
+
+		Convey("should authenticate with client_secret_basic", func() {
+			a.Request.SetBasicAuth("basic", "basic-secret")
+			client, err := a.Authenticate(protocol.TokenRequest{})
+			So(err, ShouldBeNil)
+			So(client.ClientID(), ShouldEqual, "basic")
+
+			a.Request.SetBasicAuth("basic", "wrong-secret")
+			_, err = a.Authenticate(protocol.TokenRequest{})
+			So(err, ShouldBeError, "invalid client credentials")
+		})
+
+		Convey("should authenticate with client_secret_post", func() {
+			client, err := a.Authenticate(protocol.TokenRequest{
+				"client_id":     "post",
+				"client_secret": "post-secret",
+			})
+			So(err, ShouldBeNil)
+			So(client.ClientID(), ShouldEqual, "post")
+
+			_, err = a.Authenticate(protocol.TokenRequest{"client_id": "post"})
+			So(err, ShouldBeError, "invalid client credentials")
+
+			a.Request.SetBasicAuth("post", "post-secret")
+			_, err = a.Authenticate(protocol.TokenRequest{})
+			So(err, ShouldBeError, "invalid client credentials")
+		})
+
+		Convey("should reject multiple authentication methods", func() {
+			a.Request.SetBasicAuth("post", "post-secret")
+			_, err := a.Authenticate(protocol.TokenRequest{
+				"client_id":     "post",
+				"client_secret": "post-secret",
+			})
+			So(err, ShouldBeError, "multiple client authentication methods are used")
+		})
+
+		Convey("should authenticate with private_key_jwt", func() {
+			assertion := makeAssertion("jwt", "https://auth/oauth2/token", clk.NowUTC().Add(time.Minute))
+			client, err := a.Authenticate(protocol.TokenRequest{
+				"client_assertion_type": handler.ClientAssertionTypeJWTBearer,
+				"client_assertion":      assertion,
+			})
+			So(err, ShouldBeNil)
+			So(client.ClientID(), ShouldEqual, "jwt")
+		})
+
+		Convey("should reject replayed client assertions", func() {
+			assertion := makeAssertion("jwt", "https://auth/oauth2/token", clk.NowUTC().Add(time.Minute))
+			_, err := a.Authenticate(protocol.TokenRequest{
+				"client_assertion_type": handler.ClientAssertionTypeJWTBearer,
+				"client_assertion":      assertion,
+			})
+			So(err, ShouldBeNil)
+
+			_, err = a.Authenticate(protocol.TokenRequest{
+				"client_assertion_type": handler.ClientAssertionTypeJWTBearer,
+				"client_assertion":      assertion,
+			})
+			So(err, ShouldBeError, "invalid client credentials")
+		})
+
+		Convey("should reject client assertions without jti", func() {
+			token := jwt.New()
+			_ = token.Set(jwt.IssuerKey, "jwt")
+			_ = token.Set(jwt.SubjectKey, "jwt")
+			_ = token.Set(jwt.AudienceKey, "https://auth/oauth2/token")
+			_ = token.Set(jwt.ExpirationKey, clk.NowUTC().Add(time.Minute).Unix())
+			signed, err := jwtutil.Sign(token, jwa.RS256, privJWK)
+			So(err, ShouldBeNil)
+
+			_, err = a.Authenticate(protocol.TokenRequest{
+				"client_assertion_type": handler.ClientAssertionTypeJWTBearer,
+				"client_assertion":      string(signed),
+			})
+			So(err, ShouldBeError, "invalid client credentials")
+		})
+
+		Convey("should reject invalid client assertions", func() {
+			assertion := makeAssertion("jwt", "https://other/oauth2/token", clk.NowUTC().Add(time.Minute))
+			_, err := a.Authenticate(protocol.TokenRequest{
+				"client_assertion_type": handler.ClientAssertionTypeJWTBearer,
+				"client_assertion":      assertion,
+			})
+			So(err, ShouldBeError, "invalid client credentials")
+
+			assertion = makeAssertion("jwt", "https://auth/oauth2/token", clk.NowUTC().Add(-time.Minute))
+			_, err = a.Authenticate(protocol.TokenRequest{
+				"client_assertion_type": handler.ClientAssertionTypeJWTBearer,
+				"client_assertion":      assertion,
+			})
+			So(err, ShouldBeError, "invalid client credentials")
+
+			assertion = makeAssertion("jwt", "https://auth/oauth2/token", clk.NowUTC().Add(time.Minute))
+			_, err = a.Authenticate(protocol.TokenRequest{
+				"client_assertion_type": "unknown",
+				"client_assertion":      assertion,
+			})
+			So(err, ShouldBeError, "unsupported client assertion type")
+		})
+	})
+}
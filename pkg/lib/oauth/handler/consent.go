@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+)
+
+var ErrConsentClientNotFound = errors.New("oauth client not found")
+
+// Consent is an authorization granted by user to a client.
+type Consent struct {
+	Authorization *oauth.Authorization
+	Client        config.OAuthClientConfig
+}
+
+// ConsentService manages authorizations granted by users through the
+// consent page.
+type ConsentService struct {
+	AppID          config.AppID
+	Config         *config.OAuthConfig
+	Authorizations oauth.AuthorizationStore
+	ValidateScopes ScopesValidator
+	Clock          clock.Clock
+}
+
+func (s *ConsentService) GetClient(clientID string) (config.OAuthClientConfig, error) {
+	client, ok := s.Config.GetClient(clientID)
+	if !ok {
+		return nil, ErrConsentClientNotFound
+	}
+	return client, nil
+}
+
+func (s *ConsentService) Grant(userID string, clientID string, scopes []string) error {
+	client, err := s.GetClient(clientID)
+	if err != nil {
+		return err
+	}
+
+	err = s.ValidateScopes(client, scopes)
+	if err != nil {
+		return err
+	}
+
+	_, err = checkAuthorization(
+		s.Authorizations,
+		s.Clock.NowUTC(),
+		s.AppID,
+		clientID,
+		userID,
+		scopes,
+	)
+	return err
+}
+
+// List returns authorizations granted by the user to third-party clients.
+func (s *ConsentService) List(userID string) ([]*Consent, error) {
+	authzs, err := s.Authorizations.ListByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var consents []*Consent
+	for _, authz := range authzs {
+		client, ok := s.Config.GetClient(authz.ClientID)
+		if !ok || !client.ConsentRequired() {
+			continue
+		}
+		consents = append(consents, &Consent{
+			Authorization: authz,
+			Client:        client,
+		})
+	}
+
+	sort.Slice(consents, func(i, j int) bool {
+		return consents[i].Authorization.CreatedAt.Before(consents[j].Authorization.CreatedAt)
+	})
+
+	return consents, nil
+}
+
+// Revoke deletes the authorization; tokens issued under it are invalidated.
+func (s *ConsentService) Revoke(userID string, authorizationID string) error {
+	authz, err := s.Authorizations.GetByID(authorizationID)
+	if err != nil {
+		return err
+	}
+
+	if authz.UserID != userID {
+		return oauth.ErrAuthorizationNotFound
+	}
+
+	return s.Authorizations.Delete(authz)
+}
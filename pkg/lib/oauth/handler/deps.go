@@ -10,4 +10,11 @@ var DependencySet = wire.NewSet(
 	NewTokenHandlerLogger,
 	wire.Struct(new(TokenHandler), "*"),
 	wire.Struct(new(RevokeHandler), "*"),
+	NewIntrospectionHandlerLogger,
+	wire.Struct(new(IntrospectionHandler), "*"),
+	NewDeviceAuthorizationHandlerLogger,
+	wire.Struct(new(DeviceAuthorizationHandler), "*"),
+	wire.Struct(new(DeviceService), "*"),
+	wire.Struct(new(ClientAuthenticator), "*"),
+	wire.Struct(new(ConsentService), "*"),
 )
@@ -9,6 +9,7 @@ import (
 
 	"github.com/authgear/authgear-server/pkg/api/apierrors"
 	"github.com/authgear/authgear-server/pkg/auth/webapp"
+	"github.com/authgear/authgear-server/pkg/lib/authn"
 	"github.com/authgear/authgear-server/pkg/lib/config"
 	"github.com/authgear/authgear-server/pkg/lib/interaction"
 	"github.com/authgear/authgear-server/pkg/lib/oauth"
@@ -28,6 +29,11 @@ type OAuthURLProvider interface {
 
 type WebAppAuthenticateURLProvider interface {
 	AuthenticateURL(options webapp.AuthenticateURLOptions) (httputil.Result, error)
+	StepUpURL(options webapp.StepUpURLOptions) (httputil.Result, error)
+}
+
+type WebAppConsentURLProvider interface {
+	ConsentURL(options webapp.ConsentURLOptions) *url.URL
 }
 
 type AuthorizationHandlerLogger struct{ *log.Logger }
@@ -46,6 +52,7 @@ type AuthorizationHandler struct {
 	CodeGrants     oauth.CodeGrantStore
 	OAuthURLs      OAuthURLProvider
 	WebAppURLs     WebAppAuthenticateURLProvider
+	ConsentURLs    WebAppConsentURLProvider
 	ValidateScopes ScopesValidator
 	CodeGenerator  TokenGenerator
 	Clock          clock.Clock
@@ -122,6 +129,22 @@ func (h *AuthorizationHandler) doHandle(
 		// Treat as not authenticated
 		s = nil
 	}
+	if s != nil && s.SessionType() == session.TypeIdentityProvider && !h.isAuthTimeSatisfied(client, r, s) {
+		// Authentication is older than max age => force re-authentication and retry
+		if slice.ContainsString(r.Prompt(), "none") {
+			return nil, protocol.NewError("login_required", "re-authentication is required")
+		}
+		r2 := protocol.AuthorizationRequest{}
+		for k, v := range r {
+			r2[k] = v
+		}
+		delete(r2, "max_age")
+		authnOptions.Prompt = "login"
+
+		r = r2
+		// Treat as not authenticated
+		s = nil
+	}
 	if s == nil || s.SessionType() != session.TypeIdentityProvider {
 		// Not authenticated as IdP session => request authentication and retry
 		authnOptions.ClientID = r.ClientID()
@@ -141,6 +164,29 @@ func (h *AuthorizationHandler) doHandle(
 		return resp, nil
 	}
 
+	if !isACRSatisfied(client, r, s) {
+		// Requested ACR is not satisfied => request step-up authentication and retry
+		if slice.ContainsString(r.Prompt(), "none") {
+			return nil, protocol.NewError("interaction_required", "step-up authentication is required")
+		}
+		authorizeURI := h.OAuthURLs.AuthorizeURL(r)
+		return h.WebAppURLs.StepUpURL(webapp.StepUpURLOptions{
+			RedirectURI: authorizeURI.String(),
+			UILocales:   strings.Join(r.UILocales(), " "),
+			UserID:      s.SessionAttrs().UserID,
+			SessionID:   s.SessionID(),
+		})
+	}
+
+	if client.ConsentRequired() {
+		resp, err := h.requestConsent(client, r, s.SessionAttrs().UserID, scopes)
+		if err != nil {
+			return nil, err
+		} else if resp != nil {
+			return resp, nil
+		}
+	}
+
 	authz, err := checkAuthorization(
 		h.Authorizations,
 		h.Clock.NowUTC(),
@@ -180,6 +226,95 @@ func (h *AuthorizationHandler) doHandle(
 	}, nil
 }
 
+// isAuthTimeSatisfied checks the authentication time of session against the
+// requested max age.
+func (h *AuthorizationHandler) isAuthTimeSatisfied(
+	client config.OAuthClientConfig,
+	r protocol.AuthorizationRequest,
+	s session.Session,
+) bool {
+	maxAge, ok := r.MaxAge()
+	if !ok {
+		var defaultMaxAge config.DurationSeconds
+		defaultMaxAge, ok = client.DefaultMaxAge()
+		maxAge = int(defaultMaxAge)
+	}
+	if !ok {
+		return true
+	}
+
+	authTime, ok := s.SessionAttrs().GetAuthTime()
+	if !ok {
+		authTime = s.GetCreatedAt()
+	}
+	return !h.Clock.NowUTC().After(authTime.Add(time.Duration(maxAge) * time.Second))
+}
+
+// isACRSatisfied checks the ACR of session against the requested ACR values.
+// Only MFA ACR is supported; other values are ignored.
+func isACRSatisfied(
+	client config.OAuthClientConfig,
+	r protocol.AuthorizationRequest,
+	s session.Session,
+) bool {
+	acrValues := r.ACRValues()
+	if len(acrValues) == 0 {
+		acrValues = client.DefaultACRValues()
+	}
+	if !slice.ContainsString(acrValues, authn.ACRMFA) {
+		return true
+	}
+
+	acr, _ := s.SessionAttrs().GetACR()
+	return acr == authn.ACRMFA
+}
+
+// requestConsent returns a redirect to the consent page if the user has
+// not yet consented to the requested scopes, or consent is requested
+// explicitly with prompt=consent.
+func (h *AuthorizationHandler) requestConsent(
+	client config.OAuthClientConfig,
+	r protocol.AuthorizationRequest,
+	userID string,
+	scopes []string,
+) (httputil.Result, error) {
+	if r[webapp.ConsentDeniedQuery] == "true" {
+		return nil, protocol.NewError("access_denied", "user denied the consent request")
+	}
+
+	granted := false
+	authz, err := h.Authorizations.Get(userID, client.ClientID())
+	if err == nil {
+		granted = authz.IsAuthorized(scopes)
+	} else if !errors.Is(err, oauth.ErrAuthorizationNotFound) {
+		return nil, err
+	}
+
+	prompt := r.Prompt()
+	if granted && !slice.ContainsString(prompt, "consent") {
+		return nil, nil
+	}
+
+	if slice.ContainsString(prompt, "none") {
+		return nil, protocol.NewError("consent_required", "consent is required")
+	}
+
+	// Retry the request after consent is given, without prompting again.
+	r2 := protocol.AuthorizationRequest{}
+	for k, v := range r {
+		r2[k] = v
+	}
+	r2.SetPrompt(slice.ExceptStrings(prompt, []string{"consent"}))
+	authorizeURI := h.OAuthURLs.AuthorizeURL(r2)
+
+	consentURI := h.ConsentURLs.ConsentURL(webapp.ConsentURLOptions{
+		ClientID:    client.ClientID(),
+		Scopes:      scopes,
+		RedirectURI: authorizeURI.String(),
+	})
+	return &httputil.ResultRedirect{URL: consentURI.String()}, nil
+}
+
 func (h *AuthorizationHandler) validateRequest(
 	client config.OAuthClientConfig,
 	r protocol.AuthorizationRequest,
@@ -210,10 +345,13 @@ func (h *AuthorizationHandler) validateRequest(
 
 	switch r.ResponseType() {
 	case "code":
+		// PKCE is required for public clients only; confidential clients
+		// authenticate themselves at the token endpoint.
 		if r.CodeChallenge() == "" {
-			return protocol.NewError("invalid_request", "PKCE code challenge is required")
-		}
-		if r.CodeChallengeMethod() != "S256" {
+			if !client.IsConfidential() {
+				return protocol.NewError("invalid_request", "PKCE code challenge is required")
+			}
+		} else if r.CodeChallengeMethod() != "S256" {
 			return protocol.NewError("invalid_request", "only 'S256' PKCE transform is supported")
 		}
 	case "none":
@@ -10,6 +10,7 @@ import (
 
 	. "github.com/smartystreets/goconvey/convey"
 
+	"github.com/authgear/authgear-server/pkg/lib/authn"
 	"github.com/authgear/authgear-server/pkg/lib/config"
 	"github.com/authgear/authgear-server/pkg/lib/oauth"
 	"github.com/authgear/authgear-server/pkg/lib/oauth/handler"
@@ -33,6 +34,7 @@ func TestAuthorizationHandler(t *testing.T) {
 			CodeGrants:     codeGrantStore,
 			OAuthURLs:      mockURLsProvider{},
 			WebAppURLs:     mockURLsProvider{},
+			ConsentURLs:    mockURLsProvider{},
 			ValidateScopes: func(config.OAuthClientConfig, []string) error { return nil },
 			CodeGenerator:  func() string { return "authz-code" },
 			Clock:          clock,
@@ -239,6 +241,180 @@ func TestAuthorizationHandler(t *testing.T) {
 				})
 			})
 		})
+		Convey("third-party client", func() {
+			h.Config.Clients = []config.OAuthClientConfig{{
+				"client_id":      "client-id",
+				"redirect_uris":  []interface{}{"https://example.com/"},
+				"is_first_party": false,
+			}}
+			h.Context = sessiontest.NewMockSession().
+				SetUserID("user-id").
+				SetSessionID("session-id").
+				ToContext(context.Background())
+			req := protocol.AuthorizationRequest{
+				"client_id":             "client-id",
+				"response_type":         "code",
+				"scope":                 "openid",
+				"code_challenge_method": "S256",
+				"code_challenge":        "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM",
+				"state":                 "my-state",
+			}
+
+			Convey("should request consent", func() {
+				resp := handle(req)
+				So(resp.Result().StatusCode, ShouldEqual, 302)
+				So(resp.Header().Get("Location"), ShouldEqual, "https://auth/consent")
+				So(authzStore.authzs, ShouldBeEmpty)
+				So(codeGrantStore.grants, ShouldBeEmpty)
+			})
+
+			Convey("should return consent_required for prompt=none", func() {
+				req["prompt"] = "none"
+				resp := handle(req)
+				So(resp.Result().StatusCode, ShouldEqual, 200)
+				So(resp.Body.String(), ShouldContainSubstring, "error=consent_required")
+			})
+
+			Convey("should return access_denied if consent is denied", func() {
+				req["x_consent_denied"] = "true"
+				resp := handle(req)
+				So(resp.Result().StatusCode, ShouldEqual, 200)
+				So(resp.Body.String(), ShouldContainSubstring, "error=access_denied")
+			})
+
+			Convey("with consent given", func() {
+				authzStore.authzs = []oauth.Authorization{{
+					ID:        "authz-id",
+					AppID:     "app-id",
+					ClientID:  "client-id",
+					UserID:    "user-id",
+					CreatedAt: time.Date(2020, 1, 31, 0, 0, 0, 0, time.UTC),
+					UpdatedAt: time.Date(2020, 1, 31, 0, 0, 0, 0, time.UTC),
+					Scopes:    []string{"openid"},
+				}}
+
+				Convey("should return authorization code", func() {
+					resp := handle(req)
+					So(resp.Result().StatusCode, ShouldEqual, 200)
+					So(codeGrantStore.grants, ShouldHaveLength, 1)
+					So(codeGrantStore.grants[0].AuthorizationID, ShouldEqual, "authz-id")
+				})
+
+				Convey("should request consent for prompt=consent", func() {
+					req["prompt"] = "consent"
+					resp := handle(req)
+					So(resp.Result().StatusCode, ShouldEqual, 302)
+					So(resp.Header().Get("Location"), ShouldEqual, "https://auth/consent")
+					So(codeGrantStore.grants, ShouldBeEmpty)
+				})
+
+				Convey("should request consent for additional scopes", func() {
+					req["scope"] = "openid offline_access"
+					resp := handle(req)
+					So(resp.Result().StatusCode, ShouldEqual, 302)
+					So(resp.Header().Get("Location"), ShouldEqual, "https://auth/consent")
+				})
+			})
+
+			Convey("should not request consent if skip_consent is set", func() {
+				h.Config.Clients[0]["skip_consent"] = true
+				resp := handle(req)
+				So(resp.Result().StatusCode, ShouldEqual, 200)
+				So(authzStore.authzs, ShouldHaveLength, 1)
+				So(codeGrantStore.grants, ShouldHaveLength, 1)
+			})
+		})
+		Convey("authentication requirements", func() {
+			h.Config.Clients = []config.OAuthClientConfig{{
+				"client_id":     "client-id",
+				"redirect_uris": []interface{}{"https://example.com/"},
+			}}
+			mockSession := sessiontest.NewMockSession().
+				SetUserID("user-id").
+				SetSessionID("session-id")
+			mockSession.Attrs.Claims = map[authn.ClaimName]interface{}{}
+			h.Context = mockSession.ToContext(context.Background())
+			req := protocol.AuthorizationRequest{
+				"client_id":             "client-id",
+				"response_type":         "code",
+				"scope":                 "openid",
+				"code_challenge_method": "S256",
+				"code_challenge":        "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM",
+			}
+
+			Convey("acr_values", func() {
+				req["acr_values"] = authn.ACRMFA
+
+				Convey("should request step-up if ACR is not satisfied", func() {
+					resp := handle(req)
+					So(resp.Result().StatusCode, ShouldEqual, 302)
+					So(resp.Header().Get("Location"), ShouldEqual, "https://auth/step_up")
+					So(codeGrantStore.grants, ShouldBeEmpty)
+				})
+
+				Convey("should return interaction_required for prompt=none", func() {
+					req["prompt"] = "none"
+					resp := handle(req)
+					So(resp.Result().StatusCode, ShouldEqual, 200)
+					So(resp.Body.String(), ShouldContainSubstring, "error=interaction_required")
+				})
+
+				Convey("should return authorization code if ACR is satisfied", func() {
+					mockSession.Attrs.SetACR(authn.ACRMFA)
+					resp := handle(req)
+					So(resp.Result().StatusCode, ShouldEqual, 200)
+					So(codeGrantStore.grants, ShouldHaveLength, 1)
+				})
+
+				Convey("should ignore unsupported values", func() {
+					req["acr_values"] = "urn:example:unknown"
+					resp := handle(req)
+					So(resp.Result().StatusCode, ShouldEqual, 200)
+					So(codeGrantStore.grants, ShouldHaveLength, 1)
+				})
+			})
+
+			Convey("should use client default_acr_values", func() {
+				h.Config.Clients[0]["default_acr_values"] = []interface{}{authn.ACRMFA}
+				resp := handle(req)
+				So(resp.Result().StatusCode, ShouldEqual, 302)
+				So(resp.Header().Get("Location"), ShouldEqual, "https://auth/step_up")
+			})
+
+			Convey("max_age", func() {
+				mockSession.Attrs.SetAuthTime(time.Date(2020, 1, 31, 23, 0, 0, 0, time.UTC))
+
+				Convey("should return authorization code if authentication is recent", func() {
+					req["max_age"] = "7200"
+					resp := handle(req)
+					So(resp.Result().StatusCode, ShouldEqual, 200)
+					So(codeGrantStore.grants, ShouldHaveLength, 1)
+				})
+
+				Convey("should request authentication if authentication is too old", func() {
+					req["max_age"] = "600"
+					resp := handle(req)
+					So(resp.Result().StatusCode, ShouldEqual, 302)
+					So(resp.Header().Get("Location"), ShouldEqual, "https://auth/authenticate")
+					So(codeGrantStore.grants, ShouldBeEmpty)
+				})
+
+				Convey("should return login_required for prompt=none", func() {
+					req["max_age"] = "600"
+					req["prompt"] = "none"
+					resp := handle(req)
+					So(resp.Result().StatusCode, ShouldEqual, 200)
+					So(resp.Body.String(), ShouldContainSubstring, "error=login_required")
+				})
+
+				Convey("should use client default_max_age", func() {
+					h.Config.Clients[0]["default_max_age"] = float64(600)
+					resp := handle(req)
+					So(resp.Result().StatusCode, ShouldEqual, 302)
+					So(resp.Header().Get("Location"), ShouldEqual, "https://auth/authenticate")
+				})
+			})
+		})
 		Convey("none response type", func() {
 			h.Config.Clients = []config.OAuthClientConfig{{
 				"client_id":      "client-id",
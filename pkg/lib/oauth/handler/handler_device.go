@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+	"github.com/authgear/authgear-server/pkg/util/httputil"
+	"github.com/authgear/authgear-server/pkg/util/log"
+	"github.com/authgear/authgear-server/pkg/util/slice"
+)
+
+const DeviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+const (
+	// DeviceCodeLifetime is the lifetime of device codes and user codes.
+	DeviceCodeLifetime = 10 * time.Minute
+	// DeviceCodePollingInterval is the default polling interval in seconds.
+	DeviceCodePollingInterval = 5
+)
+
+type DeviceURLProvider interface {
+	DeviceURL(userCode string) *url.URL
+}
+
+type DeviceAuthorizationHandlerLogger struct{ *log.Logger }
+
+func NewDeviceAuthorizationHandlerLogger(lf *log.Factory) DeviceAuthorizationHandlerLogger {
+	return DeviceAuthorizationHandlerLogger{lf.New("oauth-device-authz")}
+}
+
+// DeviceAuthorizationHandler implements the device authorization endpoint
+// as specified in RFC 8628.
+type DeviceAuthorizationHandler struct {
+	AppID               config.AppID
+	Logger              DeviceAuthorizationHandlerLogger
+	ClientAuthenticator *ClientAuthenticator
+
+	DeviceGrants   oauth.DeviceGrantStore
+	DeviceURLs     DeviceURLProvider
+	ValidateScopes ScopesValidator
+	GenerateToken  TokenGenerator
+	Clock          clock.Clock
+}
+
+func (h *DeviceAuthorizationHandler) Handle(r protocol.DeviceAuthorizationRequest) httputil.Result {
+	resp, err := h.doHandle(r)
+	if err != nil {
+		var oauthError *protocol.OAuthProtocolError
+		resultErr := tokenResultError{}
+		if errors.As(err, &oauthError) {
+			resultErr.Response = oauthError.Response
+		} else {
+			h.Logger.WithError(err).Error("device authorization handler failed")
+			resultErr.Response = protocol.NewErrorResponse("server_error", "internal server error")
+			resultErr.InternalError = true
+		}
+		return resultErr
+	}
+
+	return jsonResultOK{Response: resp}
+}
+
+func (h *DeviceAuthorizationHandler) doHandle(r protocol.DeviceAuthorizationRequest) (protocol.DeviceAuthorizationResponse, error) {
+	client, err := h.ClientAuthenticator.Authenticate(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if !slice.ContainsString(client.GrantTypes(), DeviceCodeGrantType) {
+		return nil, protocol.NewError("unauthorized_client", "grant type is not allowed for this client")
+	}
+
+	scopes := r.Scope()
+	if len(scopes) == 0 {
+		return nil, protocol.NewError("invalid_request", "scope is required")
+	}
+	if err := h.ValidateScopes(client, scopes); err != nil {
+		return nil, err
+	}
+
+	deviceCode := h.GenerateToken()
+	now := h.Clock.NowUTC()
+	grant := &oauth.DeviceGrant{
+		AppID:          string(h.AppID),
+		ClientID:       client.ClientID(),
+		DeviceCodeHash: oauth.HashToken(deviceCode),
+		UserCode:       oauth.GenerateUserCode(),
+
+		CreatedAt: now,
+		ExpireAt:  now.Add(DeviceCodeLifetime),
+		Scopes:    scopes,
+		Interval:  DeviceCodePollingInterval,
+		Status:    oauth.DeviceGrantStatusPending,
+	}
+	err = h.DeviceGrants.CreateDeviceGrant(grant)
+	if err != nil {
+		return nil, err
+	}
+
+	userCode := oauth.FormatUserCode(grant.UserCode)
+	resp := protocol.DeviceAuthorizationResponse{}
+	resp.DeviceCode(deviceCode)
+	resp.UserCode(userCode)
+	resp.VerificationURI(h.DeviceURLs.DeviceURL("").String())
+	resp.VerificationURIComplete(h.DeviceURLs.DeviceURL(userCode).String())
+	resp.ExpiresIn(int(DeviceCodeLifetime.Seconds()))
+	resp.Interval(grant.Interval)
+	return resp, nil
+}
+
+var InvalidUserCode = apierrors.Invalid.WithReason("InvalidUserCode")
+
+var ErrInvalidUserCode = InvalidUserCode.New("invalid or expired user code")
+
+// DeviceRequest is a pending device authorization request shown to user.
+type DeviceRequest struct {
+	UserCode string
+	Client   config.OAuthClientConfig
+	Scopes   []string
+}
+
+// DeviceService handles device authorization requests approved or denied by
+// users through the webapp.
+type DeviceService struct {
+	AppID          config.AppID
+	Config         *config.OAuthConfig
+	DeviceGrants   oauth.DeviceGrantStore
+	Authorizations oauth.AuthorizationStore
+	Clock          clock.Clock
+}
+
+func (s *DeviceService) getPendingGrant(userCode string) (*oauth.DeviceGrant, config.OAuthClientConfig, error) {
+	grant, err := s.DeviceGrants.GetDeviceGrantByUserCode(oauth.NormalizeUserCode(userCode))
+	if errors.Is(err, oauth.ErrGrantNotFound) {
+		return nil, nil, ErrInvalidUserCode
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	if grant.Status != oauth.DeviceGrantStatusPending || s.Clock.NowUTC().After(grant.ExpireAt) {
+		return nil, nil, ErrInvalidUserCode
+	}
+
+	client, ok := s.Config.GetClient(grant.ClientID)
+	if !ok {
+		return nil, nil, ErrInvalidUserCode
+	}
+
+	return grant, client, nil
+}
+
+func (s *DeviceService) Get(userCode string) (*DeviceRequest, error) {
+	grant, client, err := s.getPendingGrant(userCode)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeviceRequest{
+		UserCode: oauth.FormatUserCode(grant.UserCode),
+		Client:   client,
+		Scopes:   grant.Scopes,
+	}, nil
+}
+
+// Approve approves the device authorization request with the IDP session of
+// user.
+func (s *DeviceService) Approve(userCode string, userID string, sessionID string) error {
+	grant, client, err := s.getPendingGrant(userCode)
+	if err != nil {
+		return err
+	}
+
+	authz, err := checkAuthorization(
+		s.Authorizations,
+		s.Clock.NowUTC(),
+		s.AppID,
+		client.ClientID(),
+		userID,
+		grant.Scopes,
+	)
+	if err != nil {
+		return err
+	}
+
+	grant.Status = oauth.DeviceGrantStatusApproved
+	grant.AuthorizationID = authz.ID
+	grant.SessionID = sessionID
+	return s.DeviceGrants.UpdateDeviceGrant(grant)
+}
+
+func (s *DeviceService) Deny(userCode string) error {
+	grant, _, err := s.getPendingGrant(userCode)
+	if err != nil {
+		return err
+	}
+
+	grant.Status = oauth.DeviceGrantStatusDenied
+	return s.DeviceGrants.UpdateDeviceGrant(grant)
+}
@@ -0,0 +1,213 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/handler"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/oidc"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
+	"github.com/authgear/authgear-server/pkg/lib/session"
+	"github.com/authgear/authgear-server/pkg/lib/session/idpsession"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+	"github.com/authgear/authgear-server/pkg/util/httputil"
+)
+
+func TestDeviceAuthorizationGrant(t *testing.T) {
+	Convey("Device authorization grant", t, func() {
+		clk := clock.NewMockClockAt("2020-02-01T00:00:00Z")
+		deviceGrantStore := &mockDeviceGrantStore{}
+		authzStore := &mockAuthzStore{}
+		accessGrantStore := &mockAccessGrantStore{}
+		sessionProvider := &mockSessionProvider{
+			sessions: []idpsession.IDPSession{
+				{ID: "session-id", Attrs: session.Attrs{UserID: "user-id"}},
+			},
+		}
+		oauthConfig := &config.OAuthConfig{
+			Clients: []config.OAuthClientConfig{
+				{
+					"client_id":                     "tv-client",
+					"grant_types":                   []interface{}{handler.DeviceCodeGrantType},
+					"access_token_lifetime_seconds": float64(1800),
+				},
+				{
+					"client_id": "web-client",
+				},
+			},
+		}
+		clientAuthenticator := &handler.ClientAuthenticator{
+			Request:   &http.Request{Header: http.Header{}},
+			Config:    oauthConfig,
+			Endpoints: mockEndpointsProvider{},
+			Clock:     clk,
+		}
+
+		tokens := []string{"device-code", "access-token"}
+		generateToken := func() string {
+			token := tokens[0]
+			tokens = tokens[1:]
+			return token
+		}
+
+		deviceAuthzHandler := &handler.DeviceAuthorizationHandler{
+			AppID:               "app-id",
+			ClientAuthenticator: clientAuthenticator,
+			DeviceGrants:        deviceGrantStore,
+			DeviceURLs:          mockDeviceURLProvider{},
+			ValidateScopes:      oidc.ValidateScopes,
+			GenerateToken:       generateToken,
+			Clock:               clk,
+		}
+		tokenHandler := &handler.TokenHandler{
+			AppID:               "app-id",
+			Config:              oauthConfig,
+			Authorizations:      authzStore,
+			DeviceGrants:        deviceGrantStore,
+			AccessGrants:        accessGrantStore,
+			Sessions:            sessionProvider,
+			IDTokenIssuer:       mockIDTokenIssuer{},
+			GenerateToken:       generateToken,
+			Clock:               clk,
+			ClientAuthenticator: clientAuthenticator,
+		}
+		devices := &handler.DeviceService{
+			AppID:          "app-id",
+			Config:         oauthConfig,
+			DeviceGrants:   deviceGrantStore,
+			Authorizations: authzStore,
+			Clock:          clk,
+		}
+
+		write := func(result httputil.Result) (int, map[string]interface{}) {
+			req, _ := http.NewRequest("POST", "/", nil)
+			resp := httptest.NewRecorder()
+			result.WriteResponse(resp, req)
+
+			var body map[string]interface{}
+			err := json.Unmarshal(resp.Body.Bytes(), &body)
+			So(err, ShouldBeNil)
+			return resp.Result().StatusCode, body
+		}
+		poll := func() (int, map[string]interface{}) {
+			return write(tokenHandler.Handle(protocol.TokenRequest{
+				"grant_type":  handler.DeviceCodeGrantType,
+				"client_id":   "tv-client",
+				"device_code": "device-code",
+			}))
+		}
+
+		Convey("should reject clients not allowed to use device grant", func() {
+			status, body := write(deviceAuthzHandler.Handle(protocol.DeviceAuthorizationRequest{
+				"client_id": "web-client",
+				"scope":     "openid",
+			}))
+			So(status, ShouldEqual, 400)
+			So(body["error"], ShouldEqual, "unauthorized_client")
+		})
+
+		status, body := write(deviceAuthzHandler.Handle(protocol.DeviceAuthorizationRequest{
+			"client_id": "tv-client",
+			"scope":     "openid https://authgear.com/scopes/full-access",
+		}))
+		So(status, ShouldEqual, 200)
+		So(deviceGrantStore.grants, ShouldHaveLength, 1)
+		userCode := oauth.FormatUserCode(deviceGrantStore.grants[0].UserCode)
+
+		Convey("should issue device code and user code", func() {
+			So(body, ShouldResemble, map[string]interface{}{
+				"device_code":               "device-code",
+				"user_code":                 userCode,
+				"verification_uri":          "https://auth/device",
+				"verification_uri_complete": "https://auth/device?user_code=" + userCode,
+				"expires_in":                float64(600),
+				"interval":                  float64(5),
+			})
+		})
+
+		Convey("should return authorization_pending before user approval", func() {
+			status, body := poll()
+			So(status, ShouldEqual, 400)
+			So(body["error"], ShouldEqual, "authorization_pending")
+		})
+
+		Convey("should return slow_down if polling too frequently", func() {
+			poll()
+			clk.AdvanceSeconds(1)
+			status, body := poll()
+			So(status, ShouldEqual, 400)
+			So(body["error"], ShouldEqual, "slow_down")
+			So(deviceGrantStore.grants[0].Interval, ShouldEqual, 10)
+		})
+
+		Convey("should return expired_token after expiry", func() {
+			clk.AdvanceSeconds(601)
+			status, body := poll()
+			So(status, ShouldEqual, 400)
+			So(body["error"], ShouldEqual, "expired_token")
+
+			_, err := devices.Get(userCode)
+			So(apierrors.IsKind(err, handler.InvalidUserCode), ShouldBeTrue)
+		})
+
+		Convey("should return access_denied if user denied", func() {
+			err := devices.Deny(userCode)
+			So(err, ShouldBeNil)
+
+			status, body := poll()
+			So(status, ShouldEqual, 400)
+			So(body["error"], ShouldEqual, "access_denied")
+			So(deviceGrantStore.grants, ShouldBeEmpty)
+		})
+
+		Convey("should issue tokens after user approval", func() {
+			req, err := devices.Get(userCode)
+			So(err, ShouldBeNil)
+			So(req.Client.ClientID(), ShouldEqual, "tv-client")
+
+			err = devices.Approve(userCode, "user-id", "session-id")
+			So(err, ShouldBeNil)
+			So(authzStore.authzs, ShouldHaveLength, 1)
+
+			status, body := poll()
+			So(status, ShouldEqual, 200)
+			So(body, ShouldResemble, map[string]interface{}{
+				"access_token": oauth.EncodeAccessToken("access-token"),
+				"token_type":   "Bearer",
+				"expires_in":   float64(1800),
+				"id_token":     "id-token",
+			})
+
+			So(accessGrantStore.grants, ShouldHaveLength, 1)
+			So(accessGrantStore.grants[0].SessionKind, ShouldEqual, oauth.GrantSessionKindSession)
+			So(accessGrantStore.grants[0].SessionID, ShouldEqual, "session-id")
+
+			status, body = poll()
+			So(status, ShouldEqual, 400)
+			So(body["error"], ShouldEqual, "invalid_grant")
+		})
+
+		Convey("should issue tokens to one of concurrent polls only", func() {
+			err := devices.Approve(userCode, "user-id", "session-id")
+			So(err, ShouldBeNil)
+
+			tokenHandler.DeviceGrants = racingDeviceGrantStore{deviceGrantStore}
+			status, body := poll()
+			So(status, ShouldEqual, 400)
+			So(body["error"], ShouldEqual, "invalid_grant")
+			So(accessGrantStore.grants, ShouldBeEmpty)
+		})
+
+		Convey("should reject unknown user code", func() {
+			err := devices.Approve("BBBB-BBBB", "user-id", "session-id")
+			So(apierrors.IsKind(err, handler.InvalidUserCode), ShouldBeTrue)
+		})
+	})
+}
@@ -0,0 +1,231 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/lib/authn/user"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
+	"github.com/authgear/authgear-server/pkg/lib/session"
+	"github.com/authgear/authgear-server/pkg/lib/session/idpsession"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+	"github.com/authgear/authgear-server/pkg/util/httputil"
+	"github.com/authgear/authgear-server/pkg/util/log"
+)
+
+type IntrospectionHandlerLogger struct{ *log.Logger }
+
+func NewIntrospectionHandlerLogger(lf *log.Factory) IntrospectionHandlerLogger {
+	return IntrospectionHandlerLogger{lf.New("oauth-introspect")}
+}
+
+type IntrospectionUserProvider interface {
+	GetRaw(id string) (*user.User, error)
+}
+
+// IntrospectionHandler implements token introspection as specified in RFC 7662.
+type IntrospectionHandler struct {
+	Config              *config.OAuthConfig
+	Logger              IntrospectionHandlerLogger
+	ClientAuthenticator *ClientAuthenticator
+
+	Authorizations oauth.AuthorizationStore
+	OfflineGrants  oauth.OfflineGrantStore
+	AccessGrants   oauth.AccessGrantStore
+	Sessions       SessionProvider
+	Users          IntrospectionUserProvider
+	Clock          clock.Clock
+}
+
+func (h *IntrospectionHandler) Handle(r protocol.IntrospectionRequest) httputil.Result {
+	resp, err := h.doHandle(r)
+	if err != nil {
+		var oauthError *protocol.OAuthProtocolError
+		resultErr := tokenResultError{}
+		if errors.As(err, &oauthError) {
+			resultErr.Response = oauthError.Response
+		} else {
+			h.Logger.WithError(err).Error("introspection handler failed")
+			resultErr.Response = protocol.NewErrorResponse("server_error", "internal server error")
+			resultErr.InternalError = true
+		}
+		return resultErr
+	}
+
+	return jsonResultOK{Response: resp}
+}
+
+func (h *IntrospectionHandler) doHandle(r protocol.IntrospectionRequest) (protocol.IntrospectionResponse, error) {
+	client, err := h.ClientAuthenticator.Authenticate(r)
+	if err != nil {
+		return nil, err
+	}
+	if !client.IsConfidential() {
+		return nil, protocol.NewError("invalid_client", "client authentication is required")
+	}
+
+	if r.Token() == "" {
+		return nil, protocol.NewError("invalid_request", "token is required")
+	}
+
+	var resp protocol.IntrospectionResponse
+	token, grantID, err := oauth.DecodeRefreshToken(r.Token())
+	if err == nil {
+		resp, err = h.introspectOfflineGrant(token, grantID)
+	} else {
+		resp, err = h.introspectAccessGrant(r.Token())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if resp == nil {
+		resp = protocol.IntrospectionResponse{}
+		resp.Active(false)
+	}
+	return resp, nil
+}
+
+func (h *IntrospectionHandler) introspectOfflineGrant(token, grantID string) (protocol.IntrospectionResponse, error) {
+	offlineGrant, err := h.OfflineGrants.GetOfflineGrant(grantID)
+	if errors.Is(err, oauth.ErrGrantNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	tokenHash := oauth.HashToken(token)
+	if subtle.ConstantTimeCompare([]byte(tokenHash), []byte(offlineGrant.TokenHash)) != 1 {
+		return nil, nil
+	}
+
+	if h.Clock.NowUTC().After(offlineGrant.ExpireAt) {
+		return nil, nil
+	}
+
+	authz, err := h.getAuthorization(offlineGrant.AuthorizationID)
+	if err != nil || authz == nil {
+		return nil, err
+	}
+
+	if active, err := h.isUserActive(authz.UserID); err != nil || !active {
+		return nil, err
+	}
+
+	resp := h.makeResponse(authz.ClientID, authz.UserID, offlineGrant.Scopes,
+		offlineGrant.CreatedAt, offlineGrant.ExpireAt, &offlineGrant.Attrs)
+	return resp, nil
+}
+
+func (h *IntrospectionHandler) introspectAccessGrant(token string) (protocol.IntrospectionResponse, error) {
+	tokenHash := oauth.HashToken(token)
+	accessGrant, err := h.AccessGrants.GetAccessGrant(tokenHash)
+	if errors.Is(err, oauth.ErrGrantNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if h.Clock.NowUTC().After(accessGrant.ExpireAt) {
+		return nil, nil
+	}
+
+	if accessGrant.SessionKind == oauth.GrantSessionKindClient {
+		if _, ok := h.Config.GetClient(accessGrant.SessionID); !ok {
+			return nil, nil
+		}
+		resp := h.makeResponse(accessGrant.SessionID, accessGrant.SessionID, accessGrant.Scopes,
+			accessGrant.CreatedAt, accessGrant.ExpireAt, nil)
+		resp.TokenType("Bearer")
+		return resp, nil
+	}
+
+	authz, err := h.getAuthorization(accessGrant.AuthorizationID)
+	if err != nil || authz == nil {
+		return nil, err
+	}
+
+	if active, err := h.isUserActive(authz.UserID); err != nil || !active {
+		return nil, err
+	}
+
+	var attrs *session.Attrs
+	switch accessGrant.SessionKind {
+	case oauth.GrantSessionKindSession:
+		s, err := h.Sessions.Get(accessGrant.SessionID)
+		if errors.Is(err, idpsession.ErrSessionNotFound) {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		attrs = s.SessionAttrs()
+	case oauth.GrantSessionKindOffline:
+		g, err := h.OfflineGrants.GetOfflineGrant(accessGrant.SessionID)
+		if errors.Is(err, oauth.ErrGrantNotFound) {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		attrs = g.SessionAttrs()
+	default:
+		panic("oauth: introspecting unknown grant session kind")
+	}
+
+	resp := h.makeResponse(authz.ClientID, authz.UserID, accessGrant.Scopes,
+		accessGrant.CreatedAt, accessGrant.ExpireAt, attrs)
+	resp.TokenType("Bearer")
+	return resp, nil
+}
+
+func (h *IntrospectionHandler) getAuthorization(id string) (*oauth.Authorization, error) {
+	authz, err := h.Authorizations.GetByID(id)
+	if errors.Is(err, oauth.ErrAuthorizationNotFound) {
+		// Authorization does not exists (e.g. revoked)
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return authz, nil
+}
+
+// isUserActive reports whether tokens of the user are active.
+// Tokens of disabled or deleted users are inactive.
+func (h *IntrospectionHandler) isUserActive(userID string) (bool, error) {
+	u, err := h.Users.GetRaw(userID)
+	if errors.Is(err, user.ErrUserNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return u.CheckStatus(h.Clock.NowUTC()) == nil, nil
+}
+
+func (h *IntrospectionHandler) makeResponse(
+	clientID string,
+	subject string,
+	scopes []string,
+	issuedAt time.Time,
+	expireAt time.Time,
+	attrs *session.Attrs,
+) protocol.IntrospectionResponse {
+	resp := protocol.IntrospectionResponse{}
+	resp.Active(true)
+	resp.Scope(strings.Join(scopes, " "))
+	resp.ClientID(clientID)
+	resp.Subject(subject)
+	resp.IssuedAt(issuedAt.Unix())
+	resp.ExpiresAt(expireAt.Unix())
+	if attrs != nil {
+		if acr, ok := attrs.GetACR(); ok {
+			resp.ACR(acr)
+		}
+		if amr, ok := attrs.GetAMR(); ok {
+			resp.AMR(amr)
+		}
+	}
+	return resp
+}
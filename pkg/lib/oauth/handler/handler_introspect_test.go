@@ -0,0 +1,230 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/lib/authn"
+	"github.com/authgear/authgear-server/pkg/lib/authn/user"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/handler"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
+	"github.com/authgear/authgear-server/pkg/lib/session"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+)
+
+func TestIntrospectionHandler(t *testing.T) {
+	Convey("Introspection handler", t, func() {
+		clk := clock.NewMockClockAt("2020-02-01T00:00:00Z")
+		now := clk.NowUTC()
+		authzStore := &mockAuthzStore{}
+		offlineGrantStore := &mockOfflineGrantStore{}
+		accessGrantStore := &mockAccessGrantStore{}
+		userProvider := &mockUserProvider{
+			users: []user.User{{ID: "user-id"}},
+		}
+		oauthConfig := &config.OAuthConfig{
+			Clients: []config.OAuthClientConfig{
+				{"client_id": "public-client"},
+				{
+					"client_id":                  "resource-server",
+					"token_endpoint_auth_method": "client_secret_post",
+				},
+			},
+		}
+
+		h := &handler.IntrospectionHandler{
+			Config: oauthConfig,
+			ClientAuthenticator: &handler.ClientAuthenticator{
+				Request: &http.Request{Header: http.Header{}},
+				Config:  oauthConfig,
+				Secrets: &config.OAuthClientSecrets{
+					Items: []config.OAuthClientSecretItem{
+						{ClientID: "resource-server", ClientSecret: "resource-server-secret"},
+					},
+				},
+				Endpoints: mockEndpointsProvider{},
+				Clock:     clk,
+			},
+			Authorizations: authzStore,
+			OfflineGrants:  offlineGrantStore,
+			AccessGrants:   accessGrantStore,
+			Users:          userProvider,
+			Clock:          clk,
+		}
+		handle := func(token string) (int, map[string]interface{}) {
+			result := h.Handle(protocol.IntrospectionRequest{
+				"client_id":     "resource-server",
+				"client_secret": "resource-server-secret",
+				"token":         token,
+			})
+			req, _ := http.NewRequest("POST", "/introspect", nil)
+			resp := httptest.NewRecorder()
+			result.WriteResponse(resp, req)
+
+			var body map[string]interface{}
+			err := json.Unmarshal(resp.Body.Bytes(), &body)
+			So(err, ShouldBeNil)
+			return resp.Result().StatusCode, body
+		}
+
+		authzStore.authzs = []oauth.Authorization{
+			{ID: "authz-id", ClientID: "public-client", UserID: "user-id"},
+		}
+		offlineGrantStore.grants = []oauth.OfflineGrant{{
+			ID:              "offline-grant-id",
+			ClientID:        "public-client",
+			AuthorizationID: "authz-id",
+			CreatedAt:       now.Add(-time.Hour),
+			ExpireAt:        now.Add(time.Hour),
+			Scopes:          []string{"openid", "offline_access"},
+			TokenHash:       oauth.HashToken("refresh-token"),
+			Attrs: session.Attrs{
+				UserID: "user-id",
+				Claims: map[authn.ClaimName]interface{}{
+					authn.ClaimACR: "http://schemas.openid.net/pape/policies/2007/06/multi-factor",
+				},
+			},
+		}}
+		accessGrantStore.grants = []oauth.AccessGrant{
+			{
+				AuthorizationID: "authz-id",
+				SessionID:       "offline-grant-id",
+				SessionKind:     oauth.GrantSessionKindOffline,
+				CreatedAt:       now.Add(-time.Minute),
+				ExpireAt:        now.Add(time.Minute),
+				Scopes:          []string{"openid", "offline_access"},
+				TokenHash:       oauth.HashToken("access-token"),
+			},
+			{
+				AuthorizationID: "authz-id",
+				SessionID:       "offline-grant-id",
+				SessionKind:     oauth.GrantSessionKindOffline,
+				CreatedAt:       now.Add(-time.Hour),
+				ExpireAt:        now.Add(-time.Minute),
+				Scopes:          []string{"openid"},
+				TokenHash:       oauth.HashToken("expired-access-token"),
+			},
+			{
+				SessionID:   "resource-server",
+				SessionKind: oauth.GrantSessionKindClient,
+				CreatedAt:   now.Add(-time.Minute),
+				ExpireAt:    now.Add(time.Minute),
+				Scopes:      []string{"api:read"},
+				TokenHash:   oauth.HashToken("client-access-token"),
+			},
+			{
+				SessionID:   "resource-server",
+				SessionKind: oauth.GrantSessionKindClient,
+				CreatedAt:   now.Add(-time.Minute),
+				ExpireAt:    now.Add(time.Minute),
+				Scopes:      []string{"api:read"},
+				TokenHash:   oauth.HashToken("header.resource-server.signature"),
+			},
+		}
+
+		Convey("should introspect access token of user", func() {
+			status, body := handle(oauth.EncodeAccessToken("access-token"))
+			So(status, ShouldEqual, 200)
+			So(body, ShouldResemble, map[string]interface{}{
+				"active":     true,
+				"scope":      "openid offline_access",
+				"client_id":  "public-client",
+				"sub":        "user-id",
+				"token_type": "Bearer",
+				"iat":        float64(now.Add(-time.Minute).Unix()),
+				"exp":        float64(now.Add(time.Minute).Unix()),
+				"acr":        "http://schemas.openid.net/pape/policies/2007/06/multi-factor",
+			})
+		})
+
+		Convey("should introspect refresh token", func() {
+			status, body := handle(oauth.EncodeRefreshToken("refresh-token", "offline-grant-id"))
+			So(status, ShouldEqual, 200)
+			So(body["active"], ShouldBeTrue)
+			So(body["sub"], ShouldEqual, "user-id")
+			So(body["exp"], ShouldEqual, float64(now.Add(time.Hour).Unix()))
+		})
+
+		Convey("should introspect client credentials access token", func() {
+			status, body := handle(oauth.EncodeAccessToken("client-access-token"))
+			So(status, ShouldEqual, 200)
+			So(body["active"], ShouldBeTrue)
+			So(body["client_id"], ShouldEqual, "resource-server")
+			So(body["sub"], ShouldEqual, "resource-server")
+			So(body["scope"], ShouldEqual, "api:read")
+		})
+
+		Convey("should introspect JWT access token", func() {
+			status, body := handle("header.resource-server.signature")
+			So(status, ShouldEqual, 200)
+			So(body["active"], ShouldBeTrue)
+			So(body["sub"], ShouldEqual, "resource-server")
+		})
+
+		Convey("should report inactive tokens", func() {
+			for _, token := range []string{
+				oauth.EncodeAccessToken("unknown-token"),
+				oauth.EncodeAccessToken("expired-access-token"),
+				oauth.EncodeRefreshToken("wrong-token", "offline-grant-id"),
+			} {
+				status, body := handle(token)
+				So(status, ShouldEqual, 200)
+				So(body, ShouldResemble, map[string]interface{}{"active": false})
+			}
+
+			authzStore.authzs = nil
+			status, body := handle(oauth.EncodeAccessToken("access-token"))
+			So(status, ShouldEqual, 200)
+			So(body, ShouldResemble, map[string]interface{}{"active": false})
+		})
+
+		Convey("should report tokens of disabled users inactive", func() {
+			userProvider.users[0].IsDisabled = true
+
+			for _, token := range []string{
+				oauth.EncodeAccessToken("access-token"),
+				oauth.EncodeRefreshToken("refresh-token", "offline-grant-id"),
+			} {
+				status, body := handle(token)
+				So(status, ShouldEqual, 200)
+				So(body, ShouldResemble, map[string]interface{}{"active": false})
+			}
+		})
+
+		Convey("should report tokens of re-enabled users active", func() {
+			disabledUntil := now.Add(-time.Minute)
+			userProvider.users[0].IsDisabled = true
+			userProvider.users[0].DisabledUntil = &disabledUntil
+
+			status, body := handle(oauth.EncodeAccessToken("access-token"))
+			So(status, ShouldEqual, 200)
+			So(body["active"], ShouldBeTrue)
+		})
+
+		Convey("should report tokens of deleted users inactive", func() {
+			userProvider.users = nil
+
+			status, body := handle(oauth.EncodeRefreshToken("refresh-token", "offline-grant-id"))
+			So(status, ShouldEqual, 200)
+			So(body, ShouldResemble, map[string]interface{}{"active": false})
+		})
+
+		Convey("should require client authentication", func() {
+			result := h.Handle(protocol.IntrospectionRequest{
+				"client_id": "public-client",
+				"token":     oauth.EncodeAccessToken("access-token"),
+			})
+			req, _ := http.NewRequest("POST", "/introspect", nil)
+			resp := httptest.NewRecorder()
+			result.WriteResponse(resp, req)
+			So(resp.Result().StatusCode, ShouldEqual, 401)
+		})
+	})
+}
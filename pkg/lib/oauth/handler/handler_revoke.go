@@ -4,24 +4,60 @@ import (
 	"crypto/subtle"
 	"errors"
 
+	"github.com/authgear/authgear-server/pkg/lib/config"
 	"github.com/authgear/authgear-server/pkg/lib/oauth"
 	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
 )
 
 type RevokeHandler struct {
-	OfflineGrants oauth.OfflineGrantStore
-	AccessGrants  oauth.AccessGrantStore
+	Config              *config.OAuthConfig
+	ClientAuthenticator *ClientAuthenticator
+	Authorizations      oauth.AuthorizationStore
+	OfflineGrants       oauth.OfflineGrantStore
+	AccessGrants        oauth.AccessGrantStore
 }
 
 func (h *RevokeHandler) Handle(r protocol.RevokeRequest) error {
+	// Confidential clients must authenticate; requests without any client
+	// identification can only revoke tokens issued to public clients.
+	var client config.OAuthClientConfig
+	if r.ClientID() != "" || r.ClientSecret() != "" || r.ClientAssertion() != "" || h.hasBasicAuth() {
+		c, err := h.ClientAuthenticator.Authenticate(r)
+		if err != nil {
+			return err
+		}
+		client = c
+	}
+
 	token, grantID, err := oauth.DecodeRefreshToken(r.Token())
 	if err == nil {
-		return h.revokeOfflineGrant(token, grantID)
+		return h.revokeOfflineGrant(client, token, grantID)
+	}
+	return h.revokeAccessGrant(client, r.Token())
+}
+
+func (h *RevokeHandler) hasBasicAuth() bool {
+	if h.ClientAuthenticator == nil || h.ClientAuthenticator.Request == nil {
+		return false
+	}
+	_, _, ok := h.ClientAuthenticator.Request.BasicAuth()
+	return ok
+}
+
+// canRevoke reports whether the token issued to clientID can be revoked by
+// the requesting client.
+func (h *RevokeHandler) canRevoke(client config.OAuthClientConfig, clientID string) bool {
+	if client != nil {
+		return client.ClientID() == clientID
+	}
+	tokenClient, ok := h.Config.GetClient(clientID)
+	if !ok {
+		return true
 	}
-	return h.revokeAccessGrant(r.Token())
+	return !tokenClient.IsConfidential()
 }
 
-func (h *RevokeHandler) revokeOfflineGrant(token, grantID string) error {
+func (h *RevokeHandler) revokeOfflineGrant(client config.OAuthClientConfig, token, grantID string) error {
 	offlineGrant, err := h.OfflineGrants.GetOfflineGrant(grantID)
 	if errors.Is(err, oauth.ErrGrantNotFound) {
 		return nil
@@ -34,6 +70,10 @@ func (h *RevokeHandler) revokeOfflineGrant(token, grantID string) error {
 		return nil
 	}
 
+	if !h.canRevoke(client, offlineGrant.ClientID) {
+		return nil
+	}
+
 	err = h.OfflineGrants.DeleteOfflineGrant(offlineGrant)
 	if err != nil {
 		return err
@@ -42,7 +82,7 @@ func (h *RevokeHandler) revokeOfflineGrant(token, grantID string) error {
 	return nil
 }
 
-func (h *RevokeHandler) revokeAccessGrant(token string) error {
+func (h *RevokeHandler) revokeAccessGrant(client config.OAuthClientConfig, token string) error {
 	tokenHash := oauth.HashToken(token)
 	accessGrant, err := h.AccessGrants.GetAccessGrant(tokenHash)
 	if errors.Is(err, oauth.ErrGrantNotFound) {
@@ -51,6 +91,23 @@ func (h *RevokeHandler) revokeAccessGrant(token string) error {
 		return err
 	}
 
+	var clientID string
+	if accessGrant.SessionKind == oauth.GrantSessionKindClient {
+		clientID = accessGrant.SessionID
+	} else {
+		authz, err := h.Authorizations.GetByID(accessGrant.AuthorizationID)
+		if errors.Is(err, oauth.ErrAuthorizationNotFound) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		clientID = authz.ClientID
+	}
+
+	if !h.canRevoke(client, clientID) {
+		return nil
+	}
+
 	err = h.AccessGrants.DeleteAccessGrant(accessGrant)
 	if err != nil {
 		return err
@@ -6,24 +6,29 @@ import (
 	"encoding/base64"
 	"errors"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/authgear/authgear-server/pkg/api/apierrors"
+	"github.com/authgear/authgear-server/pkg/api/model"
 	"github.com/authgear/authgear-server/pkg/lib/config"
 	"github.com/authgear/authgear-server/pkg/lib/interaction"
 	interactionintents "github.com/authgear/authgear-server/pkg/lib/interaction/intents"
 	"github.com/authgear/authgear-server/pkg/lib/oauth"
 	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
+	"github.com/authgear/authgear-server/pkg/lib/ratelimit"
 	"github.com/authgear/authgear-server/pkg/lib/session"
 	"github.com/authgear/authgear-server/pkg/lib/session/access"
 	"github.com/authgear/authgear-server/pkg/lib/session/idpsession"
 	"github.com/authgear/authgear-server/pkg/util/clock"
 	"github.com/authgear/authgear-server/pkg/util/httputil"
 	"github.com/authgear/authgear-server/pkg/util/log"
+	"github.com/authgear/authgear-server/pkg/util/slice"
 	"github.com/authgear/authgear-server/pkg/util/uuid"
 )
 
 const AnonymousRequestGrantType = "urn:authgear:params:oauth:grant-type:anonymous-request"
+const ClientCredentialsGrantType = "client_credentials"
 
 // whitelistedGrantTypes is a list of grant types that would be always allowed
 // to all clients.
@@ -32,13 +37,25 @@ var whitelistedGrantTypes = []string{
 }
 
 type IDTokenIssuer interface {
-	IssueIDToken(client config.OAuthClientConfig, session session.Session, nonce string) (token string, err error)
+	IssueIDToken(client config.OAuthClientConfig, session session.Session, scopes []string, nonce string) (token string, err error)
+}
+
+type AccessTokenEncoding interface {
+	EncodeAccessToken(client config.OAuthClientConfig, grant *oauth.AccessGrant, subject string) (token string, err error)
 }
 
 type SessionProvider interface {
 	Get(id string) (*idpsession.IDPSession, error)
 }
 
+type UserProvider interface {
+	Get(id string) (*model.User, error)
+}
+
+type RateLimiter interface {
+	TakeToken(bucket ratelimit.Bucket) error
+}
+
 type TokenHandlerLogger struct{ *log.Logger }
 
 func NewTokenHandlerLogger(lf *log.Factory) TokenHandlerLogger {
@@ -46,38 +63,42 @@ func NewTokenHandlerLogger(lf *log.Factory) TokenHandlerLogger {
 }
 
 type TokenHandler struct {
-	Request    *http.Request
-	AppID      config.AppID
-	Config     *config.OAuthConfig
-	TrustProxy config.TrustProxy
-	Logger     TokenHandlerLogger
+	Request         *http.Request
+	AppID           config.AppID
+	Config          *config.OAuthConfig
+	RateLimitConfig *config.RateLimitConfig
+	TrustProxy      config.TrustProxy
+	Logger          TokenHandlerLogger
 
 	Authorizations oauth.AuthorizationStore
 	CodeGrants     oauth.CodeGrantStore
+	DeviceGrants   oauth.DeviceGrantStore
 	OfflineGrants  oauth.OfflineGrantStore
 	AccessGrants   oauth.AccessGrantStore
 	AccessEvents   *access.EventProvider
 	Sessions       SessionProvider
+	Users          UserProvider
 	Graphs         GraphService
 	IDTokenIssuer  IDTokenIssuer
 	GenerateToken  TokenGenerator
+	RateLimiter    RateLimiter
 	Clock          clock.Clock
+
+	AccessTokenEncoding AccessTokenEncoding
+
+	ClientAuthenticator *ClientAuthenticator
 }
 
 func (h *TokenHandler) Handle(r protocol.TokenRequest) httputil.Result {
-	client := resolveClient(h.Config, r)
-	if client == nil {
-		return tokenResultError{
-			Response: protocol.NewErrorResponse("invalid_client", "invalid client ID"),
-		}
-	}
-
-	result, err := h.doHandle(client, r)
+	result, err := h.doHandle(r)
 	if err != nil {
 		var oauthError *protocol.OAuthProtocolError
 		resultErr := tokenResultError{}
 		if errors.As(err, &oauthError) {
 			resultErr.Response = oauthError.Response
+		} else if apierrors.IsKind(err, ratelimit.RateLimited) {
+			resultErr.Response = protocol.NewErrorResponse("slow_down", err.Error())
+			resultErr.RateLimited = true
 		} else {
 			h.Logger.WithError(err).Error("authz handler failed")
 			resultErr.Response = protocol.NewErrorResponse("server_error", "internal server error")
@@ -89,11 +110,13 @@ func (h *TokenHandler) Handle(r protocol.TokenRequest) httputil.Result {
 	return result
 }
 
-func (h *TokenHandler) doHandle(
-	client config.OAuthClientConfig,
-	r protocol.TokenRequest,
-) (httputil.Result, error) {
-	if err := h.validateRequest(r); err != nil {
+func (h *TokenHandler) doHandle(r protocol.TokenRequest) (httputil.Result, error) {
+	client, err := h.ClientAuthenticator.Authenticate(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.validateRequest(client, r); err != nil {
 		return nil, err
 	}
 
@@ -125,18 +148,22 @@ func (h *TokenHandler) doHandle(
 		return tokenResultOK{Response: resp}, nil
 	case AnonymousRequestGrantType:
 		return h.handleAnonymousRequest(client, r)
+	case ClientCredentialsGrantType:
+		return h.handleClientCredentials(client, r)
+	case DeviceCodeGrantType:
+		return h.handleDeviceCode(client, r)
 	default:
 		panic("oauth: unexpected grant type")
 	}
 }
 
-func (h *TokenHandler) validateRequest(r protocol.TokenRequest) error {
+func (h *TokenHandler) validateRequest(client config.OAuthClientConfig, r protocol.TokenRequest) error {
 	switch r.GrantType() {
 	case "authorization_code":
 		if r.Code() == "" {
 			return protocol.NewError("invalid_request", "code is required")
 		}
-		if r.CodeVerifier() == "" {
+		if r.CodeVerifier() == "" && !client.IsConfidential() {
 			return protocol.NewError("invalid_request", "PKCE code verifier is required")
 		}
 	case "refresh_token":
@@ -147,6 +174,14 @@ func (h *TokenHandler) validateRequest(r protocol.TokenRequest) error {
 		if r.JWT() == "" {
 			return protocol.NewError("invalid_request", "jwt is required")
 		}
+	case DeviceCodeGrantType:
+		if r.DeviceCode() == "" {
+			return protocol.NewError("invalid_request", "device code is required")
+		}
+	case ClientCredentialsGrantType:
+		if !client.IsConfidential() {
+			return protocol.NewError("unauthorized_client", "client credentials grant is only allowed for confidential clients")
+		}
 	default:
 		return protocol.NewError("unsupported_grant_type", "grant type is not supported")
 	}
@@ -188,6 +223,10 @@ func (h *TokenHandler) handleAuthorizationCode(
 		return nil, err
 	}
 
+	if authz.ClientID != client.ClientID() {
+		return nil, errInvalidAuthzCode
+	}
+
 	sess, err := h.Sessions.Get(codeGrant.SessionID)
 	if errors.Is(err, idpsession.ErrSessionNotFound) {
 		return nil, errInvalidAuthzCode
@@ -195,7 +234,7 @@ func (h *TokenHandler) handleAuthorizationCode(
 		return nil, err
 	}
 
-	resp, err := h.issueTokensForAuthorizationCode(client, codeGrant, authz, sess)
+	resp, err := h.issueTokensForSession(client, codeGrant.Scopes, codeGrant.OIDCNonce, authz, sess)
 	if err != nil {
 		return nil, err
 	}
@@ -235,6 +274,23 @@ func (h *TokenHandler) handleRefreshToken(
 		return nil, errInvalidRefreshToken
 	}
 
+	if offlineGrant.ClientID != client.ClientID() {
+		return nil, errInvalidRefreshToken
+	}
+
+	// Offline grants of disabled users are deleted when they are used.
+	user, err := h.Users.Get(offlineGrant.Attrs.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsDisabled {
+		err = h.OfflineGrants.DeleteOfflineGrant(offlineGrant)
+		if err != nil {
+			return nil, err
+		}
+		return nil, errInvalidRefreshToken
+	}
+
 	authz, err := h.Authorizations.GetByID(offlineGrant.AuthorizationID)
 	if errors.Is(err, oauth.ErrAuthorizationNotFound) {
 		return nil, errInvalidRefreshToken
@@ -262,9 +318,18 @@ func (h *TokenHandler) handleAnonymousRequest(
 	client config.OAuthClientConfig,
 	r protocol.TokenRequest,
 ) (httputil.Result, error) {
+	err := h.RateLimiter.TakeToken(ratelimit.NewBucket(
+		"AnonymousRequestPerIP",
+		access.RemoteIP(h.Request, bool(h.TrustProxy)),
+		h.RateLimitConfig.AnonymousRequestPerIP,
+	))
+	if err != nil {
+		return nil, err
+	}
+
 	var graph *interaction.Graph
 	var attrs *session.Attrs
-	err := h.Graphs.DryRun("", func(ctx *interaction.Context) (*interaction.Graph, error) {
+	err = h.Graphs.DryRun("", func(ctx *interaction.Context) (*interaction.Graph, error) {
 		var err error
 		graph, err = h.Graphs.NewGraph(ctx, interactionintents.NewIntentLogin())
 		if err != nil {
@@ -332,7 +397,7 @@ func (h *TokenHandler) handleAnonymousRequest(
 		return nil, err
 	}
 
-	err = h.issueAccessGrant(client, scopes, authz.ID,
+	err = h.issueAccessGrant(client, scopes, authz,
 		offlineGrant.ID, oauth.GrantSessionKindOffline, resp)
 	if err != nil {
 		return nil, err
@@ -341,15 +406,137 @@ func (h *TokenHandler) handleAnonymousRequest(
 	return tokenResultOK{Response: resp}, nil
 }
 
-func (h *TokenHandler) issueTokensForAuthorizationCode(
+var errInvalidDeviceCode = protocol.NewError("invalid_grant", "invalid device code")
+
+func (h *TokenHandler) handleDeviceCode(
+	client config.OAuthClientConfig,
+	r protocol.TokenRequest,
+) (httputil.Result, error) {
+	deviceGrant, err := h.DeviceGrants.GetDeviceGrant(oauth.HashToken(r.DeviceCode()))
+	if errors.Is(err, oauth.ErrGrantNotFound) {
+		return nil, errInvalidDeviceCode
+	} else if err != nil {
+		return nil, err
+	}
+
+	if deviceGrant.ClientID != client.ClientID() {
+		return nil, errInvalidDeviceCode
+	}
+
+	now := h.Clock.NowUTC()
+	if now.After(deviceGrant.ExpireAt) {
+		return nil, protocol.NewError("expired_token", "device code is expired")
+	}
+
+	// Devices polling faster than the interval must slow down; the interval
+	// is increased by 5 seconds as specified in RFC 8628 section 3.5.
+	interval := time.Duration(deviceGrant.Interval) * time.Second
+	tooFast := !deviceGrant.LastPolledAt.IsZero() && now.Sub(deviceGrant.LastPolledAt) < interval
+	deviceGrant.LastPolledAt = now
+	if tooFast {
+		deviceGrant.Interval += DeviceCodePollingInterval
+	}
+
+	switch deviceGrant.Status {
+	case oauth.DeviceGrantStatusPending:
+		if err := h.DeviceGrants.UpdateDeviceGrant(deviceGrant); err != nil {
+			return nil, err
+		}
+		if tooFast {
+			return nil, protocol.NewError("slow_down", "polling too frequently")
+		}
+		return nil, protocol.NewError("authorization_pending", "authorization is pending")
+	case oauth.DeviceGrantStatusDenied:
+		if err := h.DeviceGrants.DeleteDeviceGrant(deviceGrant); err != nil {
+			return nil, err
+		}
+		return nil, protocol.NewError("access_denied", "authorization is denied")
+	case oauth.DeviceGrantStatusApproved:
+		break
+	default:
+		panic("oauth: unexpected device grant status")
+	}
+
+	// Device code can only be exchanged once; concurrent polls race on
+	// consuming the grant and only the winner is issued tokens.
+	err = h.DeviceGrants.ConsumeDeviceGrant(deviceGrant)
+	if errors.Is(err, oauth.ErrGrantNotFound) {
+		return nil, errInvalidDeviceCode
+	} else if err != nil {
+		return nil, err
+	}
+
+	authz, err := h.Authorizations.GetByID(deviceGrant.AuthorizationID)
+	if errors.Is(err, oauth.ErrAuthorizationNotFound) {
+		return nil, errInvalidDeviceCode
+	} else if err != nil {
+		return nil, err
+	}
+
+	sess, err := h.Sessions.Get(deviceGrant.SessionID)
+	if errors.Is(err, idpsession.ErrSessionNotFound) {
+		return nil, errInvalidDeviceCode
+	} else if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.issueTokensForSession(client, deviceGrant.Scopes, "", authz, sess)
+	if err != nil {
+		return nil, err
+	}
+
+	return tokenResultOK{Response: resp}, nil
+}
+
+// userScopes are scopes that can only be granted by users.
+var userScopes = []string{
+	"openid",
+	"offline_access",
+	"email",
+	"phone",
+	"profile",
+	oauth.FullAccessScope,
+}
+
+func (h *TokenHandler) handleClientCredentials(
+	client config.OAuthClientConfig,
+	r protocol.TokenRequest,
+) (httputil.Result, error) {
+	allowedScopes := client.Scopes()
+	scopes := r.Scope()
+	if len(scopes) == 0 {
+		scopes = allowedScopes
+	}
+	if len(scopes) == 0 {
+		return nil, protocol.NewError("invalid_scope", "no scope is allowed for this client")
+	}
+	for _, scope := range scopes {
+		if slice.ContainsString(userScopes, scope) || !slice.ContainsString(allowedScopes, scope) {
+			return nil, protocol.NewError("invalid_scope", "specified scope is not allowed")
+		}
+	}
+
+	resp := protocol.TokenResponse{}
+	err := h.issueAccessGrant(client, scopes, nil,
+		client.ClientID(), oauth.GrantSessionKindClient, resp)
+	if err != nil {
+		return nil, err
+	}
+	resp.Scope(strings.Join(scopes, " "))
+
+	return tokenResultOK{Response: resp}, nil
+}
+
+func (h *TokenHandler) issueTokensForSession(
 	client config.OAuthClientConfig,
-	code *oauth.CodeGrant,
+	scopes []string,
+	nonce string,
 	authz *oauth.Authorization,
 	s *idpsession.IDPSession,
 ) (protocol.TokenResponse, error) {
 	issueRefreshToken := false
 	issueIDToken := false
-	for _, scope := range code.Scopes {
+	for _, scope := range scopes {
 		switch scope {
 		case "offline_access":
 			issueRefreshToken = true
@@ -378,7 +565,7 @@ func (h *TokenHandler) issueTokensForAuthorizationCode(
 	var sessionKind oauth.GrantSessionKind
 	var atSession session.Session
 	if issueRefreshToken {
-		offlineGrant, err := h.issueOfflineGrant(client, code.Scopes, authz.ID, s.SessionAttrs(), resp)
+		offlineGrant, err := h.issueOfflineGrant(client, scopes, authz.ID, s.SessionAttrs(), resp)
 		if err != nil {
 			return nil, err
 		}
@@ -391,8 +578,8 @@ func (h *TokenHandler) issueTokensForAuthorizationCode(
 		sessionKind = oauth.GrantSessionKindSession
 	}
 
-	err := h.issueAccessGrant(client, code.Scopes,
-		authz.ID, sessionID, sessionKind, resp)
+	err := h.issueAccessGrant(client, scopes,
+		authz, sessionID, sessionKind, resp)
 	if err != nil {
 		return nil, err
 	}
@@ -401,7 +588,7 @@ func (h *TokenHandler) issueTokensForAuthorizationCode(
 		if h.IDTokenIssuer == nil {
 			return nil, errors.New("id token issuer is not provided")
 		}
-		idToken, err := h.IDTokenIssuer.IssueIDToken(client, atSession, code.OIDCNonce)
+		idToken, err := h.IDTokenIssuer.IssueIDToken(client, atSession, scopes, nonce)
 		if err != nil {
 			return nil, err
 		}
@@ -430,7 +617,7 @@ func (h *TokenHandler) issueTokensForRefreshToken(
 		if h.IDTokenIssuer == nil {
 			return nil, errors.New("id token issuer is not provided")
 		}
-		idToken, err := h.IDTokenIssuer.IssueIDToken(client, offlineGrant, "")
+		idToken, err := h.IDTokenIssuer.IssueIDToken(client, offlineGrant, offlineGrant.Scopes, "")
 		if err != nil {
 			return nil, err
 		}
@@ -438,7 +625,7 @@ func (h *TokenHandler) issueTokensForRefreshToken(
 	}
 
 	err := h.issueAccessGrant(client, offlineGrant.Scopes,
-		authz.ID, offlineGrant.ID, oauth.GrantSessionKindOffline, resp)
+		authz, offlineGrant.ID, oauth.GrantSessionKindOffline, resp)
 	if err != nil {
 		return nil, err
 	}
@@ -488,27 +675,50 @@ func (h *TokenHandler) issueOfflineGrant(
 	return offlineGrant, nil
 }
 
+// issueAccessGrant issues an access token. authz is nil if the token is not
+// issued to any user, e.g. with the client credentials grant.
 func (h *TokenHandler) issueAccessGrant(
 	client config.OAuthClientConfig,
 	scopes []string,
-	authzID string,
+	authz *oauth.Authorization,
 	sessionID string,
 	sessionKind oauth.GrantSessionKind,
 	resp protocol.TokenResponse,
 ) error {
-	token := h.GenerateToken()
 	now := h.Clock.NowUTC()
 
 	accessGrant := &oauth.AccessGrant{
-		AppID:           string(h.AppID),
-		AuthorizationID: authzID,
-		SessionID:       sessionID,
-		SessionKind:     sessionKind,
-		CreatedAt:       now,
-		ExpireAt:        now.Add(client.AccessTokenLifetime().Duration()),
-		Scopes:          scopes,
-		TokenHash:       oauth.HashToken(token),
+		AppID:       string(h.AppID),
+		SessionID:   sessionID,
+		SessionKind: sessionKind,
+		CreatedAt:   now,
+		ExpireAt:    now.Add(client.AccessTokenLifetime().Duration()),
+		Scopes:      scopes,
+	}
+	subject := client.ClientID()
+	if authz != nil {
+		accessGrant.AuthorizationID = authz.ID
+		subject = authz.UserID
+	}
+
+	var token string
+	switch client.AccessTokenFormat() {
+	case config.OAuthAccessTokenFormatJWT:
+		if h.AccessTokenEncoding == nil {
+			return errors.New("access token encoding is not provided")
+		}
+		jwt, err := h.AccessTokenEncoding.EncodeAccessToken(client, accessGrant, subject)
+		if err != nil {
+			return err
+		}
+		token = jwt
+	default:
+		token = h.GenerateToken()
 	}
+
+	// JWT access tokens are recorded as well, so that they can be revoked
+	// and introspected like opaque access tokens.
+	accessGrant.TokenHash = oauth.HashToken(token)
 	err := h.AccessGrants.CreateAccessGrant(accessGrant)
 	if err != nil {
 		return err
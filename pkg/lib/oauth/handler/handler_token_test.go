@@ -0,0 +1,165 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/handler"
+	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+)
+
+func TestTokenHandler(t *testing.T) {
+	Convey("Token handler", t, func() {
+		clk := clock.NewMockClockAt("2020-02-01T00:00:00Z")
+		accessGrantStore := &mockAccessGrantStore{}
+		oauthConfig := &config.OAuthConfig{
+			Clients: []config.OAuthClientConfig{
+				{
+					"client_id":                     "public-client",
+					"grant_types":                   []interface{}{"client_credentials"},
+					"scope":                         "api:read",
+					"access_token_lifetime_seconds": float64(1800),
+				},
+				{
+					"client_id":                     "service-client",
+					"grant_types":                   []interface{}{"client_credentials"},
+					"scope":                         "api:read api:write",
+					"token_endpoint_auth_method":    "client_secret_post",
+					"access_token_lifetime_seconds": float64(1800),
+				},
+			},
+		}
+
+		h := &handler.TokenHandler{
+			AppID:         "app-id",
+			Config:        oauthConfig,
+			AccessGrants:  accessGrantStore,
+			GenerateToken: func() string { return "access-token" },
+			Clock:         clk,
+
+			AccessTokenEncoding: mockAccessTokenEncoding{},
+			ClientAuthenticator: &handler.ClientAuthenticator{
+				Request: &http.Request{Header: http.Header{}},
+				Config:  oauthConfig,
+				Secrets: &config.OAuthClientSecrets{
+					Items: []config.OAuthClientSecretItem{
+						{ClientID: "service-client", ClientSecret: "service-secret"},
+					},
+				},
+				Endpoints: mockEndpointsProvider{},
+				Clock:     clk,
+			},
+		}
+		handle := func(r protocol.TokenRequest) *httptest.ResponseRecorder {
+			result := h.Handle(r)
+			req, _ := http.NewRequest("POST", "/token", nil)
+			resp := httptest.NewRecorder()
+			result.WriteResponse(resp, req)
+			return resp
+		}
+		decode := func(resp *httptest.ResponseRecorder) map[string]interface{} {
+			var body map[string]interface{}
+			err := json.Unmarshal(resp.Body.Bytes(), &body)
+			So(err, ShouldBeNil)
+			return body
+		}
+
+		Convey("client credentials grant", func() {
+			Convey("should issue access token to confidential client", func() {
+				resp := handle(protocol.TokenRequest{
+					"grant_type":    "client_credentials",
+					"client_id":     "service-client",
+					"client_secret": "service-secret",
+					"scope":         "api:read",
+				})
+				So(resp.Result().StatusCode, ShouldEqual, 200)
+				So(decode(resp), ShouldResemble, map[string]interface{}{
+					"access_token": oauth.EncodeAccessToken("access-token"),
+					"token_type":   "Bearer",
+					"expires_in":   float64(1800),
+					"scope":        "api:read",
+				})
+
+				So(accessGrantStore.grants, ShouldHaveLength, 1)
+				grant := accessGrantStore.grants[0]
+				So(grant.SessionKind, ShouldEqual, oauth.GrantSessionKindClient)
+				So(grant.SessionID, ShouldEqual, "service-client")
+				So(grant.AuthorizationID, ShouldEqual, "")
+				So(grant.Scopes, ShouldResemble, []string{"api:read"})
+			})
+
+			Convey("should issue JWT access token if configured", func() {
+				oauthConfig.Clients[1]["access_token_format"] = "jwt"
+				resp := handle(protocol.TokenRequest{
+					"grant_type":    "client_credentials",
+					"client_id":     "service-client",
+					"client_secret": "service-secret",
+					"scope":         "api:read",
+				})
+				So(resp.Result().StatusCode, ShouldEqual, 200)
+				So(decode(resp)["access_token"], ShouldEqual, "header.service-client.service-client")
+
+				So(accessGrantStore.grants, ShouldHaveLength, 1)
+				grant := accessGrantStore.grants[0]
+				So(grant.SessionKind, ShouldEqual, oauth.GrantSessionKindClient)
+				So(grant.TokenHash, ShouldEqual, oauth.HashToken("header.service-client.service-client"))
+			})
+
+			Convey("should default to registered scopes", func() {
+				resp := handle(protocol.TokenRequest{
+					"grant_type":    "client_credentials",
+					"client_id":     "service-client",
+					"client_secret": "service-secret",
+				})
+				So(resp.Result().StatusCode, ShouldEqual, 200)
+				So(decode(resp)["scope"], ShouldEqual, "api:read api:write")
+			})
+
+			Convey("should reject unregistered and user scopes", func() {
+				resp := handle(protocol.TokenRequest{
+					"grant_type":    "client_credentials",
+					"client_id":     "service-client",
+					"client_secret": "service-secret",
+					"scope":         "api:admin",
+				})
+				So(resp.Result().StatusCode, ShouldEqual, 400)
+				So(decode(resp)["error"], ShouldEqual, "invalid_scope")
+
+				resp = handle(protocol.TokenRequest{
+					"grant_type":    "client_credentials",
+					"client_id":     "service-client",
+					"client_secret": "service-secret",
+					"scope":         "openid",
+				})
+				So(resp.Result().StatusCode, ShouldEqual, 400)
+				So(decode(resp)["error"], ShouldEqual, "invalid_scope")
+			})
+
+			Convey("should reject public client", func() {
+				resp := handle(protocol.TokenRequest{
+					"grant_type": "client_credentials",
+					"client_id":  "public-client",
+				})
+				So(resp.Result().StatusCode, ShouldEqual, 400)
+				So(decode(resp)["error"], ShouldEqual, "unauthorized_client")
+			})
+
+			Convey("should reject unauthenticated client", func() {
+				resp := handle(protocol.TokenRequest{
+					"grant_type":    "client_credentials",
+					"client_id":     "service-client",
+					"client_secret": "wrong-secret",
+				})
+				So(resp.Result().StatusCode, ShouldEqual, 401)
+				So(decode(resp)["error"], ShouldEqual, "invalid_client")
+			})
+		})
+	})
+}
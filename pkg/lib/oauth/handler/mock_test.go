@@ -2,10 +2,15 @@ package handler_test
 
 import (
 	"net/url"
+	"time"
 
 	"github.com/authgear/authgear-server/pkg/auth/webapp"
+	"github.com/authgear/authgear-server/pkg/lib/authn/user"
+	"github.com/authgear/authgear-server/pkg/lib/config"
 	"github.com/authgear/authgear-server/pkg/lib/oauth"
 	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
+	"github.com/authgear/authgear-server/pkg/lib/session"
+	"github.com/authgear/authgear-server/pkg/lib/session/idpsession"
 	"github.com/authgear/authgear-server/pkg/util/httputil"
 )
 
@@ -16,10 +21,19 @@ func (mockURLsProvider) AuthorizeURL(r protocol.AuthorizationRequest) *url.URL {
 	return u
 }
 
+func (mockURLsProvider) ConsentURL(opts webapp.ConsentURLOptions) *url.URL {
+	u, _ := url.Parse("https://auth/consent")
+	return u
+}
+
 func (mockURLsProvider) AuthenticateURL(opts webapp.AuthenticateURLOptions) (httputil.Result, error) {
 	return &httputil.ResultRedirect{URL: "https://auth/authenticate"}, nil
 }
 
+func (mockURLsProvider) StepUpURL(opts webapp.StepUpURLOptions) (httputil.Result, error) {
+	return &httputil.ResultRedirect{URL: "https://auth/step_up"}, nil
+}
+
 type mockAuthzStore struct {
 	authzs []oauth.Authorization
 }
@@ -42,6 +56,17 @@ func (m *mockAuthzStore) GetByID(id string) (*oauth.Authorization, error) {
 	return nil, oauth.ErrAuthorizationNotFound
 }
 
+func (m *mockAuthzStore) ListByUserID(userID string) ([]*oauth.Authorization, error) {
+	var authzs []*oauth.Authorization
+	for _, a := range m.authzs {
+		if a.UserID == userID {
+			a := a
+			authzs = append(authzs, &a)
+		}
+	}
+	return authzs, nil
+}
+
 func (m *mockAuthzStore) Create(authz *oauth.Authorization) error {
 	m.authzs = append(m.authzs, *authz)
 	return nil
@@ -99,3 +124,222 @@ func (m *mockCodeGrantStore) DeleteCodeGrant(grant *oauth.CodeGrant) error {
 	m.grants = m.grants[:n]
 	return nil
 }
+
+type mockAccessGrantStore struct {
+	grants []oauth.AccessGrant
+}
+
+func (m *mockAccessGrantStore) GetAccessGrant(tokenHash string) (*oauth.AccessGrant, error) {
+	for _, g := range m.grants {
+		if g.TokenHash == tokenHash {
+			return &g, nil
+		}
+	}
+	return nil, oauth.ErrGrantNotFound
+}
+
+func (m *mockAccessGrantStore) CreateAccessGrant(grant *oauth.AccessGrant) error {
+	m.grants = append(m.grants, *grant)
+	return nil
+}
+
+func (m *mockAccessGrantStore) DeleteAccessGrant(grant *oauth.AccessGrant) error {
+	n := 0
+	for _, g := range m.grants {
+		if g.TokenHash != grant.TokenHash {
+			m.grants[n] = g
+			n++
+		}
+	}
+	m.grants = m.grants[:n]
+	return nil
+}
+
+type mockOfflineGrantStore struct {
+	grants []oauth.OfflineGrant
+}
+
+func (m *mockOfflineGrantStore) GetOfflineGrant(id string) (*oauth.OfflineGrant, error) {
+	for _, g := range m.grants {
+		if g.ID == id {
+			return &g, nil
+		}
+	}
+	return nil, oauth.ErrGrantNotFound
+}
+
+func (m *mockOfflineGrantStore) CreateOfflineGrant(grant *oauth.OfflineGrant) error {
+	m.grants = append(m.grants, *grant)
+	return nil
+}
+
+func (m *mockOfflineGrantStore) UpdateOfflineGrant(grant *oauth.OfflineGrant) error {
+	for i, g := range m.grants {
+		if g.ID == grant.ID {
+			m.grants[i] = *grant
+		}
+	}
+	return nil
+}
+
+func (m *mockOfflineGrantStore) DeleteOfflineGrant(grant *oauth.OfflineGrant) error {
+	n := 0
+	for _, g := range m.grants {
+		if g.ID != grant.ID {
+			m.grants[n] = g
+			n++
+		}
+	}
+	m.grants = m.grants[:n]
+	return nil
+}
+
+func (m *mockOfflineGrantStore) ListOfflineGrants(userID string) ([]*oauth.OfflineGrant, error) {
+	var grants []*oauth.OfflineGrant
+	for _, g := range m.grants {
+		if g.Attrs.UserID == userID {
+			gg := g
+			grants = append(grants, &gg)
+		}
+	}
+	return grants, nil
+}
+
+type mockDeviceGrantStore struct {
+	grants []oauth.DeviceGrant
+}
+
+func (m *mockDeviceGrantStore) GetDeviceGrant(deviceCodeHash string) (*oauth.DeviceGrant, error) {
+	for _, g := range m.grants {
+		if g.DeviceCodeHash == deviceCodeHash {
+			return &g, nil
+		}
+	}
+	return nil, oauth.ErrGrantNotFound
+}
+
+func (m *mockDeviceGrantStore) GetDeviceGrantByUserCode(userCode string) (*oauth.DeviceGrant, error) {
+	for _, g := range m.grants {
+		if g.UserCode == userCode {
+			return &g, nil
+		}
+	}
+	return nil, oauth.ErrGrantNotFound
+}
+
+func (m *mockDeviceGrantStore) CreateDeviceGrant(grant *oauth.DeviceGrant) error {
+	m.grants = append(m.grants, *grant)
+	return nil
+}
+
+func (m *mockDeviceGrantStore) UpdateDeviceGrant(grant *oauth.DeviceGrant) error {
+	for i, g := range m.grants {
+		if g.DeviceCodeHash == grant.DeviceCodeHash {
+			m.grants[i] = *grant
+		}
+	}
+	return nil
+}
+
+func (m *mockDeviceGrantStore) DeleteDeviceGrant(grant *oauth.DeviceGrant) error {
+	n := 0
+	for _, g := range m.grants {
+		if g.DeviceCodeHash != grant.DeviceCodeHash {
+			m.grants[n] = g
+			n++
+		}
+	}
+	m.grants = m.grants[:n]
+	return nil
+}
+
+func (m *mockDeviceGrantStore) ConsumeDeviceGrant(grant *oauth.DeviceGrant) error {
+	n := len(m.grants)
+	_ = m.DeleteDeviceGrant(grant)
+	if len(m.grants) == n {
+		return oauth.ErrGrantNotFound
+	}
+	return nil
+}
+
+// racingDeviceGrantStore simulates a concurrent poll consuming the device
+// grant after it is read.
+type racingDeviceGrantStore struct {
+	*mockDeviceGrantStore
+}
+
+func (m racingDeviceGrantStore) GetDeviceGrant(deviceCodeHash string) (*oauth.DeviceGrant, error) {
+	g, err := m.mockDeviceGrantStore.GetDeviceGrant(deviceCodeHash)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.ConsumeDeviceGrant(g); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+type mockClientAssertionStore struct {
+	jtis map[string]time.Time
+}
+
+func (m *mockClientAssertionStore) UseClientAssertion(clientID string, jti string, expireAt time.Time) error {
+	if m.jtis == nil {
+		m.jtis = map[string]time.Time{}
+	}
+	key := clientID + ":" + jti
+	if _, ok := m.jtis[key]; ok {
+		return oauth.ErrClientAssertionReplayed
+	}
+	m.jtis[key] = expireAt
+	return nil
+}
+
+type mockSessionProvider struct {
+	sessions []idpsession.IDPSession
+}
+
+func (m *mockSessionProvider) Get(id string) (*idpsession.IDPSession, error) {
+	for _, s := range m.sessions {
+		if s.ID == id {
+			return &s, nil
+		}
+	}
+	return nil, idpsession.ErrSessionNotFound
+}
+
+type mockUserProvider struct {
+	users []user.User
+}
+
+func (m *mockUserProvider) GetRaw(id string) (*user.User, error) {
+	for _, u := range m.users {
+		if u.ID == id {
+			return &u, nil
+		}
+	}
+	return nil, user.ErrUserNotFound
+}
+
+type mockDeviceURLProvider struct{}
+
+func (mockDeviceURLProvider) DeviceURL(userCode string) *url.URL {
+	if userCode == "" {
+		u, _ := url.Parse("https://auth/device")
+		return u
+	}
+	u, _ := url.Parse("https://auth/device?user_code=" + userCode)
+	return u
+}
+
+type mockIDTokenIssuer struct{}
+
+func (mockIDTokenIssuer) IssueIDToken(client config.OAuthClientConfig, s session.Session, scopes []string, nonce string) (string, error) {
+	return "id-token", nil
+}
+
+type mockAccessTokenEncoding struct{}
+
+func (mockAccessTokenEncoding) EncodeAccessToken(client config.OAuthClientConfig, grant *oauth.AccessGrant, subject string) (string, error) {
+	return "header." + subject + "." + client.ClientID(), nil
+}
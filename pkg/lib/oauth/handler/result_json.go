@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// jsonResultOK writes a successful JSON response of endpoints other than the
+// token endpoint.
+type jsonResultOK struct {
+	Response interface{}
+}
+
+func (t jsonResultOK) WriteResponse(rw http.ResponseWriter, r *http.Request) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.Header().Set("Cache-Control", "no-store")
+	rw.Header().Set("Pragma", "no-cache")
+	rw.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(rw)
+	err := encoder.Encode(t.Response)
+	if err != nil {
+		http.Error(rw, err.Error(), 500)
+	}
+}
+
+func (t jsonResultOK) IsInternalError() bool {
+	return false
+}
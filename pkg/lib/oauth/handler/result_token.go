@@ -13,6 +13,7 @@ type (
 	}
 	tokenResultError struct {
 		InternalError bool
+		RateLimited   bool
 		Response      protocol.ErrorResponse
 	}
 )
@@ -40,6 +41,13 @@ func (t tokenResultError) WriteResponse(rw http.ResponseWriter, r *http.Request)
 	rw.Header().Set("Pragma", "no-cache")
 	if t.InternalError {
 		rw.WriteHeader(http.StatusInternalServerError)
+	} else if t.RateLimited {
+		rw.WriteHeader(http.StatusTooManyRequests)
+	} else if t.Response["error"] == "invalid_client" {
+		if r.Header.Get("Authorization") != "" {
+			rw.Header().Set("WWW-Authenticate", `Basic realm="oauth"`)
+		}
+		rw.WriteHeader(http.StatusUnauthorized)
 	} else {
 		rw.WriteHeader(http.StatusBadRequest)
 	}
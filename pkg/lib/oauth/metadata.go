@@ -9,8 +9,27 @@ func (p *MetadataProvider) PopulateMetadata(meta map[string]interface{}) {
 	meta["token_endpoint"] = p.Endpoints.TokenEndpointURL().String()
 	meta["response_types_supported"] = []string{"code", "none"}
 	meta["response_modes_supported"] = []string{"query", "fragment", "form_post"}
-	meta["grant_types_supported"] = []string{"authorization_code", "refresh_token"}
+	meta["grant_types_supported"] = []string{
+		"authorization_code",
+		"refresh_token",
+		"client_credentials",
+		"urn:ietf:params:oauth:grant-type:device_code",
+	}
 	meta["code_challenge_methods_supported"] = []string{"S256"}
 	meta["revocation_endpoint"] = p.Endpoints.RevokeEndpointURL().String()
-
+	meta["token_endpoint_auth_methods_supported"] = []string{
+		"none",
+		"client_secret_basic",
+		"client_secret_post",
+		"private_key_jwt",
+	}
+	meta["token_endpoint_auth_signing_alg_values_supported"] = []string{"RS256", "ES256"}
+	meta["revocation_endpoint_auth_methods_supported"] = meta["token_endpoint_auth_methods_supported"]
+	meta["introspection_endpoint"] = p.Endpoints.IntrospectEndpointURL().String()
+	meta["introspection_endpoint_auth_methods_supported"] = []string{
+		"client_secret_basic",
+		"client_secret_post",
+		"private_key_jwt",
+	}
+	meta["device_authorization_endpoint"] = p.Endpoints.DeviceAuthorizationEndpointURL().String()
 }
@@ -0,0 +1,45 @@
+package oidc
+
+import (
+	"strings"
+
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jwt"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/util/jwtutil"
+	"github.com/authgear/authgear-server/pkg/util/uuid"
+)
+
+// AccessTokenType is the typ header of JWT access tokens, as specified in
+// RFC 9068.
+const AccessTokenType = "at+jwt"
+
+type AccessTokenEncoding struct {
+	Secrets   *config.OIDCKeyMaterials
+	Endpoints EndpointsProvider
+}
+
+// EncodeAccessToken encodes the access grant as a JWT access token. subject
+// is the user ID, or the client ID if the grant is not issued to any user.
+func (e *AccessTokenEncoding) EncodeAccessToken(client config.OAuthClientConfig, grant *oauth.AccessGrant, subject string) (string, error) {
+	claims := jwt.New()
+	_ = claims.Set(jwt.IssuerKey, e.Endpoints.BaseURL().String())
+	_ = claims.Set(jwt.AudienceKey, e.Endpoints.BaseURL().String())
+	_ = claims.Set(jwt.SubjectKey, subject)
+	_ = claims.Set(jwt.JwtIDKey, uuid.New())
+	_ = claims.Set(jwt.IssuedAtKey, grant.CreatedAt.Unix())
+	_ = claims.Set(jwt.ExpirationKey, grant.ExpireAt.Unix())
+	_ = claims.Set("client_id", client.ClientID())
+	_ = claims.Set("scope", strings.Join(grant.Scopes, " "))
+
+	jwk := e.Secrets.Set.Keys[0]
+
+	signed, err := jwtutil.SignWithType(claims, jwa.RS256, jwk, AccessTokenType)
+	if err != nil {
+		return "", err
+	}
+
+	return string(signed), nil
+}
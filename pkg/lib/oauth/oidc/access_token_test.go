@@ -0,0 +1,67 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jwk"
+	"github.com/lestrrat-go/jwx/jws"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/util/jwtutil"
+)
+
+func TestAccessTokenEncoding(t *testing.T) {
+	Convey("AccessTokenEncoding.EncodeAccessToken", t, func() {
+		// nolint: gosec
+		privKey, err := rsa.GenerateKey(rand.Reader, 512)
+		So(err, ShouldBeNil)
+		key, err := jwk.New(privKey)
+		So(err, ShouldBeNil)
+		_ = key.Set(jwk.KeyIDKey, "mykey")
+
+		encoding := &AccessTokenEncoding{
+			Secrets:   &config.OIDCKeyMaterials{Set: jwk.Set{Keys: []jwk.Key{key}}},
+			Endpoints: mockEndpointsProvider{},
+		}
+
+		t0 := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		client := config.OAuthClientConfig{"client_id": "client-id"}
+		grant := &oauth.AccessGrant{
+			CreatedAt: t0,
+			ExpireAt:  t0.Add(30 * time.Minute),
+			Scopes:    []string{"openid", "offline_access"},
+		}
+
+		token, err := encoding.EncodeAccessToken(client, grant, "user-id")
+		So(err, ShouldBeNil)
+
+		_, err = jws.Verify([]byte(token), jwa.RS256, &privKey.PublicKey)
+		So(err, ShouldBeNil)
+
+		hdr, payload, err := jwtutil.SplitWithoutVerify([]byte(token))
+		So(err, ShouldBeNil)
+		So(hdr.Type(), ShouldEqual, "at+jwt")
+		So(hdr.KeyID(), ShouldEqual, "mykey")
+
+		claims, err := payload.AsMap(context.Background())
+		So(err, ShouldBeNil)
+		So(claims["jti"], ShouldNotBeEmpty)
+		delete(claims, "jti")
+		So(claims, ShouldResemble, map[string]interface{}{
+			"iss":       "https://auth",
+			"aud":       []string{"https://auth"},
+			"sub":       "user-id",
+			"client_id": "client-id",
+			"scope":     "openid offline_access",
+			"iat":       t0,
+			"exp":       t0.Add(30 * time.Minute),
+		})
+	})
+}
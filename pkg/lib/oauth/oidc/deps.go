@@ -7,4 +7,5 @@ import (
 var DependencySet = wire.NewSet(
 	wire.Struct(new(MetadataProvider), "*"),
 	wire.Struct(new(IDTokenIssuer), "*"),
+	wire.Struct(new(AccessTokenEncoding), "*"),
 )
@@ -1,6 +1,7 @@
 package oidc
 
 import (
+	"sort"
 	"time"
 
 	"github.com/lestrrat-go/jwx/jwa"
@@ -9,7 +10,9 @@ import (
 
 	"github.com/authgear/authgear-server/pkg/api/model"
 	"github.com/authgear/authgear-server/pkg/lib/authn"
+	"github.com/authgear/authgear-server/pkg/lib/authn/identity"
 	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/feature/verification"
 	"github.com/authgear/authgear-server/pkg/lib/session"
 	"github.com/authgear/authgear-server/pkg/util/clock"
 	"github.com/authgear/authgear-server/pkg/util/jwkutil"
@@ -20,11 +23,22 @@ type UserProvider interface {
 	Get(id string) (*model.User, error)
 }
 
+type IdentityService interface {
+	ListByUser(userID string) ([]*identity.Info, error)
+}
+
+type VerificationService interface {
+	GetClaims(userID string) ([]*verification.Claim, error)
+}
+
 type IDTokenIssuer struct {
-	Secrets   *config.OIDCKeyMaterials
-	Endpoints EndpointsProvider
-	Users     UserProvider
-	Clock     clock.Clock
+	Secrets           *config.OIDCKeyMaterials
+	Endpoints         EndpointsProvider
+	Users             UserProvider
+	Identities        IdentityService
+	Verification      VerificationService
+	UserProfileConfig *config.UserProfileConfig
+	Clock             clock.Clock
 }
 
 // IDTokenValidDuration is the valid period of ID token.
@@ -35,8 +49,8 @@ func (ti *IDTokenIssuer) GetPublicKeySet() (*jwk.Set, error) {
 	return jwkutil.PublicKeySet(&ti.Secrets.Set)
 }
 
-func (ti *IDTokenIssuer) IssueIDToken(client config.OAuthClientConfig, s session.Session, nonce string) (string, error) {
-	claims, err := ti.LoadUserClaims(s)
+func (ti *IDTokenIssuer) IssueIDToken(client config.OAuthClientConfig, s session.Session, scopes []string, nonce string) (string, error) {
+	claims, err := ti.LoadUserClaims(s, scopes)
 	if err != nil {
 		return "", err
 	}
@@ -63,17 +77,110 @@ func (ti *IDTokenIssuer) IssueIDToken(client config.OAuthClientConfig, s session
 	return string(signed), nil
 }
 
-func (ti *IDTokenIssuer) LoadUserClaims(s session.Session) (jwt.Token, error) {
-	user, err := ti.Users.Get(s.SessionAttrs().UserID)
+// LoadUserClaims loads claims of the session user. Standard claims and
+// profile attributes are included only if requested by the scopes.
+func (ti *IDTokenIssuer) LoadUserClaims(s session.Session, scopes []string) (jwt.Token, error) {
+	userID := s.SessionAttrs().UserID
+	user, err := ti.Users.Get(userID)
 	if err != nil {
 		return nil, err
 	}
 
 	claims := jwt.New()
 	_ = claims.Set(jwt.IssuerKey, ti.Endpoints.BaseURL().String())
-	_ = claims.Set(jwt.SubjectKey, s.SessionAttrs().UserID)
+	_ = claims.Set(jwt.SubjectKey, userID)
 	_ = claims.Set(string(authn.ClaimUserIsAnonymous), user.IsAnonymous)
 	_ = claims.Set(string(authn.ClaimUserIsVerified), user.IsVerified)
 
+	requested := RequestedClaims(scopes)
+	if _, ok := requested[authn.ClaimUserProfile]; ok {
+		if profile := ti.profileClaims(user); len(profile) > 0 {
+			_ = claims.Set(string(authn.ClaimUserProfile), profile)
+		}
+	}
+	if len(requested) == 0 {
+		return claims, nil
+	}
+
+	standardClaims, err := ti.loadStandardClaims(user)
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range standardClaims {
+		if _, ok := requested[name]; ok {
+			_ = claims.Set(string(name), value)
+		}
+	}
+
+	return claims, nil
+}
+
+// profileClaims projects the profile attributes configured to be included in
+// claims.
+func (ti *IDTokenIssuer) profileClaims(user *model.User) map[string]interface{} {
+	profile := map[string]interface{}{}
+	for _, name := range ti.UserProfileConfig.IDTokenClaims {
+		if value, ok := user.Profile[name]; ok {
+			profile[name] = value
+		}
+	}
+	return profile
+}
+
+// loadStandardClaims derives standard claims from the login ID identities of
+// user. If user has multiple login IDs of the same type, the earliest one is
+// used.
+func (ti *IDTokenIssuer) loadStandardClaims(user *model.User) (map[authn.ClaimName]interface{}, error) {
+	identities, err := ti.Identities.ListByUser(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	verifiedClaims, err := ti.Verification.GetClaims(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	// Verified claims are keyed by the normalized claim value of identity.
+	isVerified := func(i *identity.Info, name authn.ClaimName) bool {
+		value, ok := i.Claims[string(name)].(string)
+		if !ok {
+			return false
+		}
+		for _, c := range verifiedClaims {
+			if c.Name == string(name) && c.Value == value {
+				return true
+			}
+		}
+		return false
+	}
+
+	var loginIDs []*identity.Info
+	for _, i := range identities {
+		if i.Type == authn.IdentityTypeLoginID {
+			loginIDs = append(loginIDs, i)
+		}
+	}
+	sort.SliceStable(loginIDs, func(i, j int) bool {
+		return loginIDs[i].CreatedAt.Before(loginIDs[j].CreatedAt)
+	})
+
+	claims := map[authn.ClaimName]interface{}{
+		authn.ClaimUpdatedAt: user.UpdatedAt.Unix(),
+	}
+	for _, i := range loginIDs {
+		for name, value := range i.StandardClaims() {
+			if _, ok := claims[name]; ok {
+				continue
+			}
+			claims[name] = value
+			switch name {
+			case authn.ClaimEmail:
+				claims[authn.ClaimEmailVerified] = isVerified(i, name)
+			case authn.ClaimPhoneNumber:
+				claims[authn.ClaimPhoneNumberVerified] = isVerified(i, name)
+			}
+		}
+	}
+
 	return claims, nil
 }
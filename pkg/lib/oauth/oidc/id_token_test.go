@@ -0,0 +1,138 @@
+package oidc
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/api/model"
+	"github.com/authgear/authgear-server/pkg/lib/authn"
+	"github.com/authgear/authgear-server/pkg/lib/authn/identity"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/feature/verification"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+	"github.com/authgear/authgear-server/pkg/lib/session"
+	"github.com/authgear/authgear-server/pkg/lib/session/idpsession"
+)
+
+type mockEndpointsProvider struct{}
+
+func (mockEndpointsProvider) BaseURL() *url.URL {
+	u, _ := url.Parse("https://auth")
+	return u
+}
+func (mockEndpointsProvider) JWKSEndpointURL() *url.URL       { return nil }
+func (mockEndpointsProvider) UserInfoEndpointURL() *url.URL   { return nil }
+func (mockEndpointsProvider) EndSessionEndpointURL() *url.URL { return nil }
+
+type mockUserProvider struct{ user *model.User }
+
+func (m mockUserProvider) Get(id string) (*model.User, error) { return m.user, nil }
+
+type mockIdentityService struct{ identities []*identity.Info }
+
+func (m mockIdentityService) ListByUser(userID string) ([]*identity.Info, error) {
+	return m.identities, nil
+}
+
+type mockVerificationService struct{ claims []*verification.Claim }
+
+func (m mockVerificationService) GetClaims(userID string) ([]*verification.Claim, error) {
+	return m.claims, nil
+}
+
+func loginID(typ config.LoginIDKeyType, value string, createdAt time.Time) *identity.Info {
+	return &identity.Info{
+		UserID:    "user-id",
+		CreatedAt: createdAt,
+		Type:      authn.IdentityTypeLoginID,
+		Claims: map[string]interface{}{
+			identity.IdentityClaimLoginIDType:          string(typ),
+			identity.IdentityClaimLoginIDOriginalValue: value,
+			string(typ): value,
+		},
+	}
+}
+
+func TestIDTokenIssuerLoadUserClaims(t *testing.T) {
+	Convey("IDTokenIssuer.LoadUserClaims", t, func() {
+		t0 := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		issuer := &IDTokenIssuer{
+			Endpoints: mockEndpointsProvider{},
+			Users: mockUserProvider{user: &model.User{
+				Meta: model.Meta{ID: "user-id", UpdatedAt: t0},
+				Profile: map[string]interface{}{
+					"nickname": "John",
+					"age":      float64(30),
+				},
+			}},
+			Identities: mockIdentityService{identities: []*identity.Info{
+				loginID(config.LoginIDKeyTypeEmail, "second@example.com", t0.Add(time.Hour)),
+				loginID(config.LoginIDKeyTypeEmail, "first@example.com", t0),
+				loginID(config.LoginIDKeyTypePhone, "+85298765432", t0),
+				loginID(config.LoginIDKeyTypeUsername, "johndoe", t0),
+			}},
+			Verification: mockVerificationService{claims: []*verification.Claim{
+				{UserID: "user-id", Name: "email", Value: "first@example.com"},
+			}},
+			UserProfileConfig: &config.UserProfileConfig{
+				IDTokenClaims: []string{"nickname", "nationality"},
+			},
+		}
+		s := &idpsession.IDPSession{ID: "session-id", Attrs: session.Attrs{UserID: "user-id"}}
+
+		Convey("should not include standard claims without scopes", func() {
+			claims, err := issuer.LoadUserClaims(s, []string{"openid"})
+			So(err, ShouldBeNil)
+			m, _ := claims.AsMap(context.Background())
+			So(m["sub"], ShouldEqual, "user-id")
+			So(m, ShouldNotContainKey, "email")
+			So(m, ShouldNotContainKey, "phone_number")
+			So(m, ShouldNotContainKey, "preferred_username")
+		})
+
+		Convey("should include configured profile attributes with profile scope", func() {
+			claims, err := issuer.LoadUserClaims(s, []string{"openid", "profile"})
+			So(err, ShouldBeNil)
+			m, _ := claims.AsMap(context.Background())
+			So(m[string(authn.ClaimUserProfile)], ShouldResemble, map[string]interface{}{
+				"nickname": "John",
+			})
+
+			claims, err = issuer.LoadUserClaims(s, []string{"openid"})
+			So(err, ShouldBeNil)
+			m, _ = claims.AsMap(context.Background())
+			So(m, ShouldNotContainKey, string(authn.ClaimUserProfile))
+
+			issuer.UserProfileConfig.IDTokenClaims = nil
+			claims, err = issuer.LoadUserClaims(s, []string{"openid", "profile"})
+			So(err, ShouldBeNil)
+			m, _ = claims.AsMap(context.Background())
+			So(m, ShouldNotContainKey, string(authn.ClaimUserProfile))
+		})
+
+		Convey("should include claims requested by scopes", func() {
+			claims, err := issuer.LoadUserClaims(s, []string{"openid", "email", "profile"})
+			So(err, ShouldBeNil)
+			m, _ := claims.AsMap(context.Background())
+			So(m["email"], ShouldEqual, "first@example.com")
+			So(m["email_verified"], ShouldEqual, true)
+			So(m["preferred_username"], ShouldEqual, "johndoe")
+			So(m["updated_at"], ShouldEqual, t0.Unix())
+			So(m, ShouldNotContainKey, "phone_number")
+		})
+
+		Convey("should include all claims with full access scope", func() {
+			claims, err := issuer.LoadUserClaims(s, []string{oauth.FullAccessScope})
+			So(err, ShouldBeNil)
+			m, _ := claims.AsMap(context.Background())
+			So(m["email"], ShouldEqual, "first@example.com")
+			So(m["phone_number"], ShouldEqual, "+85298765432")
+			So(m["phone_number_verified"], ShouldEqual, false)
+			So(m["preferred_username"], ShouldEqual, "johndoe")
+		})
+	})
+}
@@ -1,5 +1,9 @@
 package oidc
 
+import (
+	"github.com/authgear/authgear-server/pkg/lib/authn"
+)
+
 type MetadataProvider struct {
 	Endpoints EndpointsProvider
 }
@@ -15,9 +19,18 @@ func (p *MetadataProvider) PopulateMetadata(meta map[string]interface{}) {
 		"iat",
 		"exp",
 		"sub",
+		"acr",
+		"amr",
+		"auth_time",
+		"email",
+		"email_verified",
+		"phone_number",
+		"phone_number_verified",
+		"preferred_username",
+		"updated_at",
 	}
 	meta["jwks_uri"] = p.Endpoints.JWKSEndpointURL().String()
 	meta["userinfo_endpoint"] = p.Endpoints.UserInfoEndpointURL().String()
 	meta["end_session_endpoint"] = p.Endpoints.EndSessionEndpointURL().String()
-	// TODO(mfa): Declare acr_values_supported and support acr_values in authorization request.
+	meta["acr_values_supported"] = []string{authn.ACRMFA}
 }
@@ -1,6 +1,7 @@
 package oidc
 
 import (
+	"github.com/authgear/authgear-server/pkg/lib/authn"
 	"github.com/authgear/authgear-server/pkg/lib/config"
 	"github.com/authgear/authgear-server/pkg/lib/oauth"
 	"github.com/authgear/authgear-server/pkg/lib/oauth/protocol"
@@ -35,9 +36,47 @@ func ValidateScopes(client config.OAuthClientConfig, scopes []string) error {
 var AllowedScopes = []string{
 	"openid",
 	"offline_access",
+	ScopeEmail,
+	ScopePhone,
+	ScopeProfile,
 	oauth.FullAccessScope,
 }
 
+const (
+	ScopeEmail   = "email"
+	ScopePhone   = "phone"
+	ScopeProfile = "profile"
+)
+
+// ScopeClaims are the standard claims requested by the scopes, as specified
+// in OIDC core section 5.4. The profile scope requests the user profile
+// attributes configured in user_profile.id_token_claims as well.
+var ScopeClaims = map[string][]authn.ClaimName{
+	ScopeEmail:   {authn.ClaimEmail, authn.ClaimEmailVerified},
+	ScopePhone:   {authn.ClaimPhoneNumber, authn.ClaimPhoneNumberVerified},
+	ScopeProfile: {authn.ClaimPreferredUsername, authn.ClaimUpdatedAt, authn.ClaimUserProfile},
+}
+
+// RequestedClaims returns the claims requested by the scopes.
+// Full access scope requests all standard claims.
+func RequestedClaims(scopes []string) map[authn.ClaimName]struct{} {
+	claims := map[authn.ClaimName]struct{}{}
+	for _, scope := range scopes {
+		if scope == oauth.FullAccessScope {
+			for _, names := range ScopeClaims {
+				for _, name := range names {
+					claims[name] = struct{}{}
+				}
+			}
+			continue
+		}
+		for _, name := range ScopeClaims[scope] {
+			claims[name] = struct{}{}
+		}
+	}
+	return claims
+}
+
 func IsScopeAllowed(scope string) bool {
 	for _, s := range AllowedScopes {
 		if s == scope {
@@ -54,6 +54,28 @@ func (s *AuthorizationStore) GetByID(id string) (*oauth.Authorization, error) {
 	return s.scanAuthz(scanner)
 }
 
+func (s *AuthorizationStore) ListByUserID(userID string) ([]*oauth.Authorization, error) {
+	builder := s.selectQuery().
+		Where("user_id = ?", userID)
+
+	rows, err := s.SQLExecutor.QueryWith(builder)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var authzs []*oauth.Authorization
+	for rows.Next() {
+		authz, err := s.scanAuthz(rows)
+		if err != nil {
+			return nil, err
+		}
+		authzs = append(authzs, authz)
+	}
+
+	return authzs, nil
+}
+
 func (s *AuthorizationStore) scanAuthz(scn sqlx.ColScanner) (*oauth.Authorization, error) {
 	authz := &oauth.Authorization{}
 
@@ -1,6 +1,9 @@
 package protocol
 
-import "strings"
+import (
+	"strconv"
+	"strings"
+)
 
 type AuthorizationRequest map[string]string
 type AuthorizationResponse map[string]string
@@ -26,6 +29,17 @@ func (r AuthorizationResponse) State(v string) { r["state"] = v }
 
 func (r AuthorizationRequest) Nonce() string       { return r["nonce"] }
 func (r AuthorizationRequest) UILocales() []string { return parseSpaceDelimitedString(r["ui_locales"]) }
+func (r AuthorizationRequest) ACRValues() []string { return parseSpaceDelimitedString(r["acr_values"]) }
+
+func (r AuthorizationRequest) MaxAge() (int, bool) {
+	v, err := strconv.Atoi(r["max_age"])
+	if err != nil || v < 0 {
+		return 0, false
+	}
+	return v, true
+}
+
+func (r AuthorizationRequest) SetMaxAge(maxAge string) { r["max_age"] = maxAge }
 
 // PKCE extension
 
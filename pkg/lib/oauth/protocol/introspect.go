@@ -0,0 +1,24 @@
+package protocol
+
+type IntrospectionRequest map[string]string
+type IntrospectionResponse map[string]interface{}
+
+func (r IntrospectionRequest) Token() string         { return r["token"] }
+func (r IntrospectionRequest) TokenTypeHint() string { return r["token_type_hint"] }
+
+// Client authentication
+
+func (r IntrospectionRequest) ClientID() string            { return r["client_id"] }
+func (r IntrospectionRequest) ClientSecret() string        { return r["client_secret"] }
+func (r IntrospectionRequest) ClientAssertionType() string { return r["client_assertion_type"] }
+func (r IntrospectionRequest) ClientAssertion() string     { return r["client_assertion"] }
+
+func (r IntrospectionResponse) Active(v bool)      { r["active"] = v }
+func (r IntrospectionResponse) Scope(v string)     { r["scope"] = v }
+func (r IntrospectionResponse) ClientID(v string)  { r["client_id"] = v }
+func (r IntrospectionResponse) TokenType(v string) { r["token_type"] = v }
+func (r IntrospectionResponse) Subject(v string)   { r["sub"] = v }
+func (r IntrospectionResponse) ExpiresAt(v int64)  { r["exp"] = v }
+func (r IntrospectionResponse) IssuedAt(v int64)   { r["iat"] = v }
+func (r IntrospectionResponse) ACR(v string)       { r["acr"] = v }
+func (r IntrospectionResponse) AMR(v []string)     { r["amr"] = v }
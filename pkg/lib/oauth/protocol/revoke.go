@@ -4,3 +4,10 @@ type RevokeRequest map[string]string
 
 func (r RevokeRequest) Token() string         { return r["token"] }
 func (r RevokeRequest) TokenTypeHint() string { return r["token_type_hint"] }
+
+// Client authentication
+
+func (r RevokeRequest) ClientID() string            { return r["client_id"] }
+func (r RevokeRequest) ClientSecret() string        { return r["client_secret"] }
+func (r RevokeRequest) ClientAssertionType() string { return r["client_assertion_type"] }
+func (r RevokeRequest) ClientAssertion() string     { return r["client_assertion"] }
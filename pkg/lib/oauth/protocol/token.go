@@ -11,6 +11,13 @@ func (r TokenRequest) RedirectURI() string  { return r["redirect_uri"] }
 func (r TokenRequest) ClientID() string     { return r["client_id"] }
 func (r TokenRequest) RefreshToken() string { return r["refresh_token"] }
 func (r TokenRequest) JWT() string          { return r["jwt"] }
+func (r TokenRequest) Scope() []string      { return parseSpaceDelimitedString(r["scope"]) }
+
+// Client authentication
+
+func (r TokenRequest) ClientSecret() string        { return r["client_secret"] }
+func (r TokenRequest) ClientAssertionType() string { return r["client_assertion_type"] }
+func (r TokenRequest) ClientAssertion() string     { return r["client_assertion"] }
 
 func (r TokenResponse) AccessToken(v string)  { r["access_token"] = v }
 func (r TokenResponse) TokenType(v string)    { r["token_type"] = v }
@@ -22,6 +29,10 @@ func (r TokenResponse) Scope(v string)        { r["scope"] = v }
 
 func (r TokenResponse) IDToken(v string) { r["id_token"] = v }
 
+// Device authorization grant extension
+
+func (r TokenRequest) DeviceCode() string { return r["device_code"] }
+
 // PKCE extension
 
 func (r TokenRequest) CodeVerifier() string { return r["code_verifier"] }
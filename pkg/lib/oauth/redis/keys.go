@@ -6,6 +6,14 @@ func codeGrantKey(appID, codeHash string) string {
 	return fmt.Sprintf("%s:code-grant:%s", appID, codeHash)
 }
 
+func deviceGrantKey(appID, deviceCodeHash string) string {
+	return fmt.Sprintf("%s:device-grant:%s", appID, deviceCodeHash)
+}
+
+func deviceUserCodeKey(appID, userCode string) string {
+	return fmt.Sprintf("%s:device-user-code:%s", appID, userCode)
+}
+
 func accessGrantKey(appID, tokenHash string) string {
 	return fmt.Sprintf("%s:access-grant:%s", appID, tokenHash)
 }
@@ -17,3 +25,7 @@ func offlineGrantKey(appID, id string) string {
 func offlineGrantListKey(appID, userID string) string {
 	return fmt.Sprintf("%s:offline-grant-list:%s", appID, userID)
 }
+
+func clientAssertionKey(appID, clientID, jti string) string {
+	return fmt.Sprintf("%s:client-assertion:%s:%s", appID, clientID, jti)
+}
@@ -0,0 +1,27 @@
+package redis
+
+import (
+	"errors"
+	"time"
+
+	redigo "github.com/gomodule/redigo/redis"
+
+	"github.com/authgear/authgear-server/pkg/lib/infra/redis"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
+)
+
+func (s *GrantStore) UseClientAssertion(clientID string, jti string, expireAt time.Time) error {
+	ttl := expireAt.Sub(s.Clock.NowUTC())
+	if ttl <= 0 {
+		return nil
+	}
+
+	return s.Redis.WithConn(func(conn redis.Conn) error {
+		key := clientAssertionKey(string(s.AppID), clientID, jti)
+		_, err := redigo.String(conn.Do("SET", key, "1", "PX", toMilliseconds(ttl), "NX"))
+		if errors.Is(err, redigo.ErrNil) {
+			return oauth.ErrClientAssertionReplayed
+		}
+		return err
+	})
+}
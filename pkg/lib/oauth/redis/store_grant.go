@@ -101,6 +101,87 @@ func (s *GrantStore) DeleteCodeGrant(grant *oauth.CodeGrant) error {
 	})
 }
 
+// deviceGrantRetention is how long device grants are retained after expiry,
+// so that polling devices are told the device code is expired.
+const deviceGrantRetention = 10 * time.Minute
+
+func (s *GrantStore) GetDeviceGrant(deviceCodeHash string) (*oauth.DeviceGrant, error) {
+	g := &oauth.DeviceGrant{}
+	err := s.Redis.WithConn(func(conn redis.Conn) error {
+		return s.load(conn, deviceGrantKey(string(s.AppID), deviceCodeHash), g)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+func (s *GrantStore) GetDeviceGrantByUserCode(userCode string) (*oauth.DeviceGrant, error) {
+	g := &oauth.DeviceGrant{}
+	err := s.Redis.WithConn(func(conn redis.Conn) error {
+		deviceCodeHash, err := redigo.String(conn.Do("GET", deviceUserCodeKey(string(s.AppID), userCode)))
+		if errors.Is(err, redigo.ErrNil) {
+			return oauth.ErrGrantNotFound
+		} else if err != nil {
+			return err
+		}
+		return s.load(conn, deviceGrantKey(string(s.AppID), deviceCodeHash), g)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+func (s *GrantStore) CreateDeviceGrant(grant *oauth.DeviceGrant) error {
+	expireAt := grant.ExpireAt.Add(deviceGrantRetention)
+	return s.Redis.WithConn(func(conn redis.Conn) error {
+		ttl := toMilliseconds(expireAt.Sub(s.Clock.NowUTC()))
+		userCodeKey := deviceUserCodeKey(grant.AppID, grant.UserCode)
+		_, err := redigo.String(conn.Do("SET", userCodeKey, grant.DeviceCodeHash, "PX", ttl, "NX"))
+		if errors.Is(err, redigo.ErrNil) {
+			return errors.New("user code already exist")
+		} else if err != nil {
+			return err
+		}
+
+		return s.save(conn, deviceGrantKey(grant.AppID, grant.DeviceCodeHash), grant, expireAt, true)
+	})
+}
+
+func (s *GrantStore) UpdateDeviceGrant(grant *oauth.DeviceGrant) error {
+	return s.Redis.WithConn(func(conn redis.Conn) error {
+		return s.save(conn, deviceGrantKey(grant.AppID, grant.DeviceCodeHash), grant, grant.ExpireAt.Add(deviceGrantRetention), false)
+	})
+}
+
+func (s *GrantStore) DeleteDeviceGrant(grant *oauth.DeviceGrant) error {
+	return s.Redis.WithConn(func(conn redis.Conn) error {
+		err := s.del(conn, deviceUserCodeKey(grant.AppID, grant.UserCode))
+		if err != nil {
+			return err
+		}
+		return s.del(conn, deviceGrantKey(grant.AppID, grant.DeviceCodeHash))
+	})
+}
+
+// ConsumeDeviceGrant deletes the device grant and fails with
+// oauth.ErrGrantNotFound if it was already deleted, so that only one of
+// concurrent pollers can exchange the device code.
+func (s *GrantStore) ConsumeDeviceGrant(grant *oauth.DeviceGrant) error {
+	return s.Redis.WithConn(func(conn redis.Conn) error {
+		n, err := redigo.Int(conn.Do("DEL", deviceGrantKey(grant.AppID, grant.DeviceCodeHash)))
+		if err != nil {
+			return err
+		} else if n == 0 {
+			return oauth.ErrGrantNotFound
+		}
+		return s.del(conn, deviceUserCodeKey(grant.AppID, grant.UserCode))
+	})
+}
+
 func (s *GrantStore) GetAccessGrant(tokenHash string) (*oauth.AccessGrant, error) {
 	g := &oauth.AccessGrant{}
 	err := s.Redis.WithConn(func(conn redis.Conn) error {
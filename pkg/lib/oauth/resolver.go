@@ -19,6 +19,7 @@ type ResolverSessionProvider interface {
 
 type Resolver struct {
 	TrustProxy     config.TrustProxy
+	OAuthConfig    *config.OAuthConfig
 	Authorizations AuthorizationStore
 	AccessGrants   AccessGrantStore
 	OfflineGrants  OfflineGrantStore
@@ -46,6 +47,24 @@ func (re *Resolver) Resolve(rw http.ResponseWriter, r *http.Request) (session.Se
 		return nil, err
 	}
 
+	event := access.NewEvent(re.Clock.NowUTC(), r, bool(re.TrustProxy))
+
+	if grant.SessionKind == GrantSessionKindClient {
+		// Client credentials access tokens are not backed by any authorization.
+		if _, ok := re.OAuthConfig.GetClient(grant.SessionID); !ok {
+			return nil, session.ErrInvalidSession
+		}
+		return &ClientSession{
+			ClientID:  grant.SessionID,
+			Scopes:    grant.Scopes,
+			CreatedAt: grant.CreatedAt,
+			AccessInfo: access.Info{
+				InitialAccess: event,
+				LastAccess:    event,
+			},
+		}, nil
+	}
+
 	_, err = re.Authorizations.GetByID(grant.AuthorizationID)
 	if errors.Is(err, ErrAuthorizationNotFound) {
 		// Authorization does not exists (e.g. revoked)
@@ -55,7 +74,6 @@ func (re *Resolver) Resolve(rw http.ResponseWriter, r *http.Request) (session.Se
 	}
 
 	var authSession session.Session
-	event := access.NewEvent(re.Clock.NowUTC(), r, bool(re.TrustProxy))
 
 	switch grant.SessionKind {
 	case GrantSessionKindSession:
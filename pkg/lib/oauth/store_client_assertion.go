@@ -0,0 +1,9 @@
+package oauth
+
+import "time"
+
+type ClientAssertionStore interface {
+	// UseClientAssertion records the jti of a client assertion until expireAt.
+	// It returns ErrClientAssertionReplayed if the jti has been used.
+	UseClientAssertion(clientID string, jti string, expireAt time.Time) error
+}
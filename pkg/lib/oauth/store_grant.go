@@ -6,6 +6,15 @@ type CodeGrantStore interface {
 	DeleteCodeGrant(*CodeGrant) error
 }
 
+type DeviceGrantStore interface {
+	GetDeviceGrant(deviceCodeHash string) (*DeviceGrant, error)
+	GetDeviceGrantByUserCode(userCode string) (*DeviceGrant, error)
+	CreateDeviceGrant(*DeviceGrant) error
+	UpdateDeviceGrant(*DeviceGrant) error
+	DeleteDeviceGrant(*DeviceGrant) error
+	ConsumeDeviceGrant(*DeviceGrant) error
+}
+
 type OfflineGrantStore interface {
 	GetOfflineGrant(id string) (*OfflineGrant, error)
 	CreateOfflineGrant(*OfflineGrant) error
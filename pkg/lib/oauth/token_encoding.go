@@ -19,7 +19,9 @@ func DecodeAccessToken(encodedToken string) (token string, err error) {
 }
 
 func DecodeRefreshToken(encodedToken string) (token string, grantID string, err error) {
-	parts := strings.SplitN(encodedToken, ".", 2)
+	// JWT access tokens contain two separators, so they are never
+	// mistaken as refresh tokens.
+	parts := strings.Split(encodedToken, ".")
 	if len(parts) != 2 {
 		return "", "", errors.New("invalid refresh token")
 	}
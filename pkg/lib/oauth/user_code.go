@@ -0,0 +1,41 @@
+package oauth
+
+import (
+	"strings"
+
+	"github.com/authgear/authgear-server/pkg/util/rand"
+)
+
+const (
+	// userCodeAlphabet contains consonants only, so that user codes are
+	// unlikely to form words and are easy to type (RFC 8628 section 6.1).
+	userCodeAlphabet string = "BCDFGHJKLMNPQRSTVWXZ"
+	userCodeLength   int    = 8
+)
+
+// GenerateUserCode generates a normalized user code of device authorization
+// grant.
+func GenerateUserCode() string {
+	return rand.StringWithAlphabet(userCodeLength, userCodeAlphabet, rand.SecureRand)
+}
+
+// FormatUserCode formats a normalized user code for display, e.g. WDJB-MJHT.
+func FormatUserCode(userCode string) string {
+	if len(userCode) != userCodeLength {
+		return userCode
+	}
+	return userCode[:userCodeLength/2] + "-" + userCode[userCodeLength/2:]
+}
+
+// NormalizeUserCode normalizes a user code entered by user, ignoring case,
+// dashes and spaces.
+func NormalizeUserCode(input string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(input) {
+		if r == '-' || r == ' ' {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,32 @@
+package ratelimit
+
+import (
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+)
+
+// Bucket is a token bucket.
+// A token is taken from the bucket for every request.
+// The bucket is refilled continuously at the rate of Size tokens per
+// ResetPeriod, so an exhausted bucket is full again after ResetPeriod.
+// Bursts are therefore bounded by Size at any time.
+type Bucket struct {
+	// Name identifies the kind of the bucket, and is exposed in error info.
+	Name string
+	// Key identifies the bucket instance, e.g. the user ID or IP address.
+	Key         string
+	Enabled     bool
+	Size        int
+	ResetPeriod time.Duration
+}
+
+func NewBucket(name string, key string, cfg *config.RateLimitBucketConfig) Bucket {
+	return Bucket{
+		Name:        name,
+		Key:         key,
+		Enabled:     *cfg.Enabled,
+		Size:        cfg.Size,
+		ResetPeriod: cfg.ResetPeriod.Duration(),
+	}
+}
@@ -0,0 +1,10 @@
+package ratelimit
+
+import "github.com/google/wire"
+
+var DependencySet = wire.NewSet(
+	NewLogger,
+	wire.Struct(new(StorageRedis), "*"),
+	wire.Bind(new(Storage), new(*StorageRedis)),
+	wire.Struct(new(Limiter), "*"),
+)
@@ -0,0 +1,16 @@
+package ratelimit
+
+import (
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+)
+
+var RateLimited = apierrors.TooManyRequest.WithReason("RateLimited")
+
+func ErrTooManyRequests(bucket Bucket, retryAfter time.Duration) error {
+	return RateLimited.NewWithInfo("request rate limited", apierrors.Details{
+		"bucket_name":         bucket.Name,
+		"retry_after_seconds": int(retryAfter.Round(time.Second) / time.Second),
+	})
+}
@@ -0,0 +1,57 @@
+package ratelimit
+
+import (
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/util/log"
+)
+
+//go:generate mockgen -source=limiter.go -destination=limiter_mock_test.go -package ratelimit
+
+type Storage interface {
+	// TakeToken takes a token from the bucket identified by key.
+	// It returns the number of tokens remaining after taking the token.
+	// The number of remaining tokens is negative if the bucket is exhausted,
+	// and retryAfter is the duration after which a token is available.
+	TakeToken(key string, size int, resetPeriod time.Duration) (remaining int, retryAfter time.Duration, err error)
+}
+
+type Logger struct{ *log.Logger }
+
+func NewLogger(lf *log.Factory) Logger { return Logger{lf.New("rate-limit")} }
+
+type Limiter struct {
+	Logger  Logger
+	Storage Storage
+}
+
+// TakeToken takes a token from the bucket.
+// It returns ErrTooManyRequests if the bucket is exhausted.
+func (l *Limiter) TakeToken(bucket Bucket) error {
+	if !bucket.Enabled {
+		return nil
+	}
+
+	remaining, retryAfter, err := l.Storage.TakeToken(
+		bucketKey(bucket),
+		bucket.Size,
+		bucket.ResetPeriod,
+	)
+	if err != nil {
+		return err
+	}
+
+	if remaining < 0 {
+		l.Logger.WithFields(map[string]interface{}{
+			"bucket": bucket.Name,
+			"key":    bucket.Key,
+		}).Debug("rate limited")
+		return ErrTooManyRequests(bucket, retryAfter)
+	}
+
+	return nil
+}
+
+func bucketKey(bucket Bucket) string {
+	return bucket.Name + ":" + bucket.Key
+}
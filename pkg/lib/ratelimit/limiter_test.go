@@ -0,0 +1,60 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/authgear/authgear-server/pkg/api/apierrors"
+	"github.com/authgear/authgear-server/pkg/util/log"
+)
+
+func TestLimiter(t *testing.T) {
+	Convey("Limiter", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		storage := NewMockStorage(ctrl)
+		limiter := &Limiter{
+			Logger:  Logger{log.Null},
+			Storage: storage,
+		}
+
+		bucket := Bucket{
+			Name:        "TestBucket",
+			Key:         "user-id",
+			Enabled:     true,
+			Size:        10,
+			ResetPeriod: time.Minute,
+		}
+
+		Convey("should allow request if tokens remain", func() {
+			storage.EXPECT().TakeToken("TestBucket:user-id", 10, time.Minute).
+				Return(0, 30*time.Second, nil)
+
+			err := limiter.TakeToken(bucket)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("should reject request if bucket is exhausted", func() {
+			storage.EXPECT().TakeToken("TestBucket:user-id", 10, time.Minute).
+				Return(-1, 30*time.Second, nil)
+
+			err := limiter.TakeToken(bucket)
+			So(apierrors.IsKind(err, RateLimited), ShouldBeTrue)
+			So(apierrors.AsAPIError(err).Info, ShouldResemble, map[string]interface{}{
+				"bucket_name":         "TestBucket",
+				"retry_after_seconds": 30,
+			})
+		})
+
+		Convey("should skip disabled bucket", func() {
+			bucket.Enabled = false
+
+			err := limiter.TakeToken(bucket)
+			So(err, ShouldBeNil)
+		})
+	})
+}
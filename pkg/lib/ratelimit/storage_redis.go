@@ -0,0 +1,76 @@
+package ratelimit
+
+import (
+	"fmt"
+	"time"
+
+	goredis "github.com/gomodule/redigo/redis"
+
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/infra/redis"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+)
+
+// takeTokenScript refills the bucket according to the time elapsed since
+// it was last updated, then takes a token from it atomically. A missing
+// bucket is full. Rejected requests do not take tokens.
+var takeTokenScript = goredis.NewScript(1, `
+local size = tonumber(ARGV[1])
+local period = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local state = redis.call("HMGET", KEYS[1], "tokens", "updated_at")
+local tokens = tonumber(state[1])
+local updated_at = tonumber(state[2])
+if tokens == nil or updated_at == nil then
+	tokens = size
+	updated_at = now
+end
+
+local elapsed = math.max(now - updated_at, 0)
+tokens = math.min(tokens + elapsed * size / period, size)
+
+local remaining = -1
+local retry_after = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	remaining = math.floor(tokens)
+else
+	retry_after = math.ceil((1 - tokens) * period / size)
+end
+
+redis.call("HMSET", KEYS[1], "tokens", tostring(tokens), "updated_at", tostring(now))
+redis.call("PEXPIRE", KEYS[1], period)
+return {remaining, retry_after}
+`)
+
+type StorageRedis struct {
+	AppID config.AppID
+	Redis *redis.Handle
+	Clock clock.Clock
+}
+
+func (s *StorageRedis) TakeToken(key string, size int, resetPeriod time.Duration) (remaining int, retryAfter time.Duration, err error) {
+	now := s.Clock.NowUTC()
+	err = s.Redis.WithConn(func(conn redis.Conn) error {
+		result, err := goredis.Int64s(takeTokenScript.Do(
+			conn,
+			redisBucketKey(s.AppID, key),
+			size,
+			int64(resetPeriod/time.Millisecond),
+			now.UnixNano()/int64(time.Millisecond),
+		))
+		if err != nil {
+			return err
+		}
+
+		remaining = int(result[0])
+		retryAfter = time.Duration(result[1]) * time.Millisecond
+		return nil
+	})
+	return
+}
+
+func redisBucketKey(appID config.AppID, key string) string {
+	return fmt.Sprintf("app:%s:rate-limit:%s", appID, key)
+}
@@ -22,18 +22,21 @@ type Event struct {
 }
 
 func NewEvent(timestamp time.Time, req *http.Request, trustProxy bool) Event {
+	return Event{
+		Timestamp: timestamp,
+		RemoteIP:  RemoteIP(req, trustProxy),
+		UserAgent: req.UserAgent(),
+	}
+}
+
+func RemoteIP(req *http.Request, trustProxy bool) string {
 	remote := EventConnInfo{
 		RemoteAddr:    req.RemoteAddr,
 		XForwardedFor: req.Header.Get("X-Forwarded-For"),
 		XRealIP:       req.Header.Get("X-Real-IP"),
 		Forwarded:     req.Header.Get("Forwarded"),
 	}
-
-	return Event{
-		Timestamp: timestamp,
-		RemoteIP:  remote.IP(trustProxy),
-		UserAgent: req.UserAgent(),
-	}
+	return remote.IP(trustProxy)
 }
 
 type EventConnInfo struct {
@@ -1,6 +1,10 @@
 package session
 
-import "github.com/authgear/authgear-server/pkg/lib/authn"
+import (
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/lib/authn"
+)
 
 type Attrs struct {
 	UserID string                          `json:"user_id"`
@@ -21,8 +25,21 @@ func (a *Attrs) SetACR(value string) {
 }
 
 func (a *Attrs) GetAMR() ([]string, bool) {
-	amr, ok := a.Claims[authn.ClaimAMR].([]string)
-	return amr, ok
+	switch amr := a.Claims[authn.ClaimAMR].(type) {
+	case []string:
+		return amr, true
+	case []interface{}:
+		// Claims decoded from JSON.
+		var values []string
+		for _, v := range amr {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values, true
+	default:
+		return nil, false
+	}
 }
 
 func (a *Attrs) SetAMR(value []string) {
@@ -32,3 +49,19 @@ func (a *Attrs) SetAMR(value []string) {
 		delete(a.Claims, authn.ClaimAMR)
 	}
 }
+
+func (a *Attrs) GetAuthTime() (time.Time, bool) {
+	switch t := a.Claims[authn.ClaimAuthTime].(type) {
+	case int64:
+		return time.Unix(t, 0).UTC(), true
+	case float64:
+		// Claims decoded from JSON.
+		return time.Unix(int64(t), 0).UTC(), true
+	default:
+		return time.Time{}, false
+	}
+}
+
+func (a *Attrs) SetAuthTime(t time.Time) {
+	a.Claims[authn.ClaimAuthTime] = t.Unix()
+}
@@ -48,10 +48,15 @@ func GetSession(ctx context.Context) Session {
 	return actx.Session
 }
 
+// GetUserID returns the ID of the user of the session. It returns nil if
+// there is no session, or the session is not associated with a user.
 func GetUserID(ctx context.Context) *string {
 	actx := getContext(ctx)
 	if actx == nil || actx.Session == nil {
 		return nil
 	}
+	if actx.Session.SessionType() == TypeClientCredentials {
+		return nil
+	}
 	return &actx.Session.SessionAttrs().UserID
 }
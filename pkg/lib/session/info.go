@@ -9,6 +9,7 @@ func NewInfo(attrs *Attrs, isAnonymous bool, isVerified bool) *model.SessionInfo
 	amr, _ := attrs.GetAMR()
 	return &model.SessionInfo{
 		IsValid:       true,
+		Type:          model.SessionInfoTypeUser,
 		UserID:        attrs.UserID,
 		UserAnonymous: isAnonymous,
 		UserVerified:  isVerified,
@@ -16,3 +17,12 @@ func NewInfo(attrs *Attrs, isAnonymous bool, isVerified bool) *model.SessionInfo
 		SessionAMR:    amr,
 	}
 }
+
+func NewClientInfo(clientID string, scopes []string) *model.SessionInfo {
+	return &model.SessionInfo{
+		IsValid:     true,
+		Type:        model.SessionInfoTypeClient,
+		ClientID:    clientID,
+		ClientScope: scopes,
+	}
+}
@@ -23,6 +23,8 @@ type Middleware struct {
 	AccessTokenSessionResolver AccessTokenSessionResolver
 	AccessEvents               *access.EventProvider
 	Users                      UserProvider
+	IDPSessions                IDPSessionManager
+	AccessTokenSessions        AccessTokenSessionManager
 	Database                   *db.Handle
 }
 
@@ -53,13 +55,25 @@ func (m *Middleware) resolve(rw http.ResponseWriter, r *http.Request) (s Session
 		if s == nil {
 			return
 		}
-		_, err = m.Users.Get(s.SessionAttrs().UserID)
+		// Client credentials sessions have no user and no access event stream.
+		if s.SessionType() == TypeClientCredentials {
+			return
+		}
+		u, err := m.Users.Get(s.SessionAttrs().UserID)
 		if err != nil {
 			if errors.Is(err, user.ErrUserNotFound) {
 				err = ErrInvalidSession
 			}
 			return
 		}
+		if u.IsDisabled {
+			err = m.invalidateUserSessions(u.ID)
+			if err != nil {
+				return
+			}
+			err = ErrInvalidSession
+			return
+		}
 		event := s.GetAccessInfo().LastAccess
 		err = m.AccessEvents.RecordAccess(s.SessionID(), &event)
 		if err != nil {
@@ -91,3 +105,21 @@ func (m *Middleware) resolveSession(rw http.ResponseWriter, r *http.Request) (Se
 	}
 	return nil, nil
 }
+
+// invalidateUserSessions deletes all IDP sessions and offline grants of
+// the disabled user.
+func (m *Middleware) invalidateUserSessions(userID string) error {
+	for _, provider := range []ManagementService{m.IDPSessions, m.AccessTokenSessions} {
+		sessions, err := provider.List(userID)
+		if err != nil {
+			return err
+		}
+		for _, s := range sessions {
+			err = provider.Delete(s)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -12,6 +12,9 @@ type Type string
 const (
 	TypeIdentityProvider Type = "idp"
 	TypeOfflineGrant     Type = "offline_grant"
+	// TypeClientCredentials is the type of sessions of access tokens issued to
+	// clients with the client_credentials grant. These sessions have no user.
+	TypeClientCredentials Type = "client_credentials"
 )
 
 type Session interface {
@@ -0,0 +1,31 @@
+package tasks
+
+import (
+	"errors"
+)
+
+const DeleteScheduledUsers = "DeleteScheduledUsers"
+
+type DeleteScheduledUsersParam struct{}
+
+func (p *DeleteScheduledUsersParam) TaskName() string {
+	return DeleteScheduledUsers
+}
+
+const DeleteUser = "DeleteUser"
+
+type DeleteUserParam struct {
+	UserID string
+}
+
+func (p *DeleteUserParam) Validate() error {
+	if p.UserID == "" {
+		return errors.New("missing user ID")
+	}
+
+	return nil
+}
+
+func (p *DeleteUserParam) TaskName() string {
+	return DeleteUser
+}
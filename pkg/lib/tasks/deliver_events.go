@@ -0,0 +1,9 @@
+package tasks
+
+const DeliverEvents = "DeliverEvents"
+
+type DeliverEventsParam struct{}
+
+func (p *DeliverEventsParam) TaskName() string {
+	return DeliverEvents
+}
@@ -0,0 +1,23 @@
+package tasks
+
+import (
+	"errors"
+)
+
+const ExportUsers = "ExportUsers"
+
+type ExportUsersParam struct {
+	JobID string
+}
+
+func (p *ExportUsersParam) Validate() error {
+	if p.JobID == "" {
+		return errors.New("missing user export ID")
+	}
+
+	return nil
+}
+
+func (p *ExportUsersParam) TaskName() string {
+	return ExportUsers
+}
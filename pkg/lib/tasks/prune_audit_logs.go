@@ -0,0 +1,9 @@
+package tasks
+
+const PruneAuditLogs = "PruneAuditLogs"
+
+type PruneAuditLogsParam struct{}
+
+func (p *PruneAuditLogsParam) TaskName() string {
+	return PruneAuditLogs
+}
@@ -0,0 +1,9 @@
+package tasks
+
+const PruneUserExports = "PruneUserExports"
+
+type PruneUserExportsParam struct{}
+
+func (p *PruneUserExportsParam) TaskName() string {
+	return PruneUserExports
+}
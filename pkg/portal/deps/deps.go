@@ -25,6 +25,7 @@ var DependencySet = wire.NewSet(
 		"AppConfig",
 		"SentryHub",
 		"LoggerFactory",
+		"DatabasePool",
 		"ConfigSourceController",
 	),
 	wire.FieldsOf(new(*config.EnvironmentConfig),
@@ -7,6 +7,7 @@ import (
 
 	"github.com/authgear/authgear-server/pkg/lib/config"
 	"github.com/authgear/authgear-server/pkg/lib/config/configsource"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
 	portalconfig "github.com/authgear/authgear-server/pkg/portal/config"
 	"github.com/authgear/authgear-server/pkg/util/httproute"
 	"github.com/authgear/authgear-server/pkg/util/log"
@@ -21,6 +22,7 @@ type RootProvider struct {
 	AppConfig          *portalconfig.AppConfig
 	LoggerFactory      *log.Factory
 	SentryHub          *getsentry.Hub
+	DatabasePool       *db.Pool
 
 	ConfigSourceController *configsource.Controller
 }
@@ -56,6 +58,7 @@ func NewRootProvider(
 		AppConfig:          appConfig,
 		LoggerFactory:      loggerFactory,
 		SentryHub:          sentryHub,
+		DatabasePool:       db.NewPool(),
 	}, nil
 }
 
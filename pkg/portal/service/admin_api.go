@@ -14,7 +14,7 @@ import (
 )
 
 type AuthzAdder interface {
-	AddAuthz(auth config.AdminAPIAuth, appID config.AppID, authKey *config.AdminAPIAuthKey, hdr http.Header) (err error)
+	AddAuthz(auth config.AdminAPIAuth, appID config.AppID, authKey *config.AdminAPIAuthKey, subject string, hdr http.Header) (err error)
 }
 
 type AdminAPIService struct {
@@ -68,6 +68,6 @@ func (s *AdminAPIService) ResolveEndpoint(appID string) (*url.URL, error) {
 	}
 }
 
-func (s *AdminAPIService) AddAuthz(appID config.AppID, authKey *config.AdminAPIAuthKey, hdr http.Header) (err error) {
-	return s.AuthzAdder.AddAuthz(s.AdminAPIConfig.Auth, appID, authKey, hdr)
+func (s *AdminAPIService) AddAuthz(appID config.AppID, authKey *config.AdminAPIAuthKey, subject string, hdr http.Header) (err error) {
+	return s.AuthzAdder.AddAuthz(s.AdminAPIConfig.Auth, appID, authKey, subject, hdr)
 }
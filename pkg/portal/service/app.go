@@ -45,6 +45,10 @@ type AppAdminAPIService interface {
 	ResolveHost(appID string) (host string, err error)
 }
 
+type AppAuditLogService interface {
+	LogConfigUpdate(app *model.App, updateFiles []*model.AppConfigFile, deleteFiles []string) error
+}
+
 type AppServiceLogger struct{ *log.Logger }
 
 func NewAppServiceLogger(lf *log.Factory) AppServiceLogger {
@@ -57,6 +61,7 @@ type AppService struct {
 	AppConfigs  AppConfigService
 	AppAuthz    AppAuthzService
 	AppAdminAPI AppAdminAPIService
+	AuditLogs   AppAuditLogService
 }
 
 func (s *AppService) loadApp(id string) (*model.App, error) {
@@ -176,7 +181,17 @@ func (s *AppService) UpdateConfig(app *model.App, updateFiles []*model.AppConfig
 	}
 
 	err = s.AppConfigs.UpdateConfig(app.ID, updateFiles, deleteFiles)
-	return err
+	if err != nil {
+		return err
+	}
+
+	// The config is already updated, so failure to record it is not reported
+	// to the developer.
+	if err := s.AuditLogs.LogConfigUpdate(app, updateFiles, deleteFiles); err != nil {
+		s.Logger.WithError(err).Error("failed to record config update in audit log")
+	}
+
+	return nil
 }
 
 func (s *AppService) generateAppConfig(appID string) (string, *config.AppConfig, error) {
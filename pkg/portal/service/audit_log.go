@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/authgear/authgear-server/pkg/lib/audit"
+	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	"github.com/authgear/authgear-server/pkg/lib/session/access"
+	"github.com/authgear/authgear-server/pkg/portal/model"
+	"github.com/authgear/authgear-server/pkg/portal/session"
+	"github.com/authgear/authgear-server/pkg/util/clock"
+	"github.com/authgear/authgear-server/pkg/util/log"
+	"github.com/authgear/authgear-server/pkg/util/uuid"
+)
+
+// AuditLogService records the changes made in the portal in the audit log
+// of the app, which is stored in the database of the app.
+type AuditLogService struct {
+	Context       context.Context
+	Request       *http.Request
+	TrustProxy    config.TrustProxy
+	Clock         clock.Clock
+	DatabasePool  *db.Pool
+	LoggerFactory *log.Factory
+}
+
+func (s *AuditLogService) LogConfigUpdate(app *model.App, updateFiles []*model.AppConfigFile, deleteFiles []string) error {
+	var updatedPaths []string
+	for _, file := range updateFiles {
+		updatedPaths = append(updatedPaths, file.Path)
+	}
+
+	return s.log(app, audit.ActivityConfigUpdated, map[string]interface{}{
+		"updated_files": updatedPaths,
+		"deleted_files": deleteFiles,
+	})
+}
+
+func (s *AuditLogService) log(app *model.App, activity audit.Activity, data map[string]interface{}) error {
+	cfg := app.Context.Config
+	credentials := cfg.SecretConfig.LookupData(config.DatabaseCredentialsKey).(*config.DatabaseCredentials)
+	handle := db.NewHandle(s.Context, s.DatabasePool, cfg.AppConfig.Database, credentials, s.LoggerFactory)
+	store := &audit.StorePQ{
+		SQLBuilder:  db.NewSQLBuilder("auth", credentials.DatabaseSchema, string(cfg.AppConfig.ID)),
+		SQLExecutor: db.SQLExecutor{Context: s.Context, Database: handle},
+	}
+
+	now := s.Clock.NowUTC()
+	e := access.NewEvent(now, s.Request, bool(s.TrustProxy))
+	actor := audit.Actor{Type: audit.ActorTypePortal}
+	if sessionInfo := session.GetValidSessionInfo(s.Context); sessionInfo != nil {
+		actor.ID = sessionInfo.UserID
+	}
+
+	l := &audit.Log{
+		ID:        uuid.New(),
+		CreatedAt: now,
+		Activity:  activity,
+		Actor:     actor,
+		IPAddress: e.RemoteIP,
+		UserAgent: e.UserAgent,
+		Data:      data,
+	}
+
+	return handle.WithTx(func() error {
+		return store.Create([]*audit.Log{l})
+	})
+}
@@ -9,11 +9,13 @@ var DependencySet = wire.NewSet(
 	wire.Struct(new(AdminAPIService), "*"),
 	wire.Struct(new(AuthzService), "*"),
 	wire.Struct(new(ConfigService), "*"),
+	wire.Struct(new(AuditLogService), "*"),
 	NewConfigServiceLogger,
 	NewAppServiceLogger,
 
 	wire.Bind(new(AppAuthzService), new(*AuthzService)),
 	wire.Bind(new(AppConfigService), new(*ConfigService)),
 	wire.Bind(new(AppAdminAPIService), new(*AdminAPIService)),
+	wire.Bind(new(AppAuditLogService), new(*AuditLogService)),
 	wire.Bind(new(AuthzConfigService), new(*ConfigService)),
 )
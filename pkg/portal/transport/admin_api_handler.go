@@ -8,6 +8,7 @@ import (
 	"github.com/graphql-go/relay"
 
 	"github.com/authgear/authgear-server/pkg/lib/config"
+	"github.com/authgear/authgear-server/pkg/portal/session"
 	"github.com/authgear/authgear-server/pkg/util/httproute"
 	"github.com/authgear/authgear-server/pkg/util/log"
 )
@@ -29,7 +30,7 @@ type AdminAPIHostResolver interface {
 }
 
 type AdminAPIAuthzAdder interface {
-	AddAuthz(appID config.AppID, authKey *config.AdminAPIAuthKey, hdr http.Header) (err error)
+	AddAuthz(appID config.AppID, authKey *config.AdminAPIAuthKey, subject string, hdr http.Header) (err error)
 }
 
 type AdminAPILogger struct{ *log.Logger }
@@ -56,6 +57,12 @@ func (h *AdminAPIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	appID := resolved.ID
 
+	// The viewer is recorded as the actor in the audit log of the app.
+	var viewerID string
+	if sessionInfo := session.GetValidSessionInfo(r.Context()); sessionInfo != nil {
+		viewerID = sessionInfo.UserID
+	}
+
 	cfg, err := h.ConfigResolver.ResolveConfig(appID)
 	if err != nil {
 		h.Logger.WithError(err).Debugf("failed to resolve config: %v", appID)
@@ -101,6 +108,7 @@ func (h *AdminAPIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			err = h.AuthzAdder.AddAuthz(
 				config.AppID(appID),
 				authKey,
+				viewerID,
 				req.Header,
 			)
 			if err != nil {
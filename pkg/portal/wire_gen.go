@@ -103,12 +103,23 @@ func newGraphQLHandler(p *deps.RequestProvider) http.Handler {
 		ConfigSource:   configSource,
 		AuthzAdder:     adder,
 	}
+	trustProxy := environmentConfig.TrustProxy
+	pool := rootProvider.DatabasePool
+	auditLogService := &service.AuditLogService{
+		Context:       context,
+		Request:       request,
+		TrustProxy:    trustProxy,
+		Clock:         clock,
+		DatabasePool:  pool,
+		LoggerFactory: factory,
+	}
 	appService := &service.AppService{
 		Logger:      appServiceLogger,
 		AppConfig:   appConfig,
 		AppConfigs:  configService,
 		AppAuthz:    authzService,
 		AppAdminAPI: adminAPIService,
+		AuditLogs:   auditLogService,
 	}
 	appLoader := &loader.AppLoader{
 		Apps: appService,
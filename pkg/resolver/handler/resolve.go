@@ -6,6 +6,7 @@ import (
 	"github.com/authgear/authgear-server/pkg/api/model"
 	"github.com/authgear/authgear-server/pkg/lib/authn"
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
 	"github.com/authgear/authgear-server/pkg/lib/session"
 	"github.com/authgear/authgear-server/pkg/util/httproute"
 	"github.com/authgear/authgear-server/pkg/util/log"
@@ -58,7 +59,9 @@ func (h *ResolveHandler) resolve(r *http.Request) (*model.SessionInfo, error) {
 	s := session.GetSession(r.Context())
 
 	var info *model.SessionInfo
-	if valid && userID != nil && s != nil {
+	if valid && s != nil && s.SessionType() == session.TypeClientCredentials {
+		info = session.NewClientInfo(s.GetClientID(), oauth.SessionScopes(s))
+	} else if valid && userID != nil && s != nil {
 		identities, err := h.Identities.ListByUser(*userID)
 		if err != nil {
 			return nil, err
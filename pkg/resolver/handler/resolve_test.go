@@ -10,6 +10,7 @@ import (
 
 	"github.com/authgear/authgear-server/pkg/lib/authn"
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity"
+	"github.com/authgear/authgear-server/pkg/lib/oauth"
 	"github.com/authgear/authgear-server/pkg/lib/session"
 	"github.com/authgear/authgear-server/pkg/lib/session/idpsession"
 )
@@ -49,6 +50,7 @@ func TestResolveHandler(t *testing.T) {
 				So(resp.StatusCode, ShouldEqual, 200)
 				So(resp.Header, ShouldResemble, http.Header{
 					"X-Authgear-Session-Valid":  []string{"true"},
+					"X-Authgear-Session-Type":   []string{"user"},
 					"X-Authgear-User-Id":        []string{"user-id"},
 					"X-Authgear-User-Verified":  []string{"true"},
 					"X-Authgear-User-Anonymous": []string{"false"},
@@ -71,6 +73,7 @@ func TestResolveHandler(t *testing.T) {
 				So(resp.StatusCode, ShouldEqual, 200)
 				So(resp.Header, ShouldResemble, http.Header{
 					"X-Authgear-Session-Valid":  []string{"true"},
+					"X-Authgear-Session-Type":   []string{"user"},
 					"X-Authgear-User-Id":        []string{"user-id"},
 					"X-Authgear-User-Anonymous": []string{"true"},
 					"X-Authgear-User-Verified":  []string{"false"},
@@ -80,6 +83,26 @@ func TestResolveHandler(t *testing.T) {
 			})
 		})
 
+		Convey("should attach headers for client credentials sessions", func() {
+			s := &oauth.ClientSession{
+				ClientID: "client-id",
+				Scopes:   []string{"api:read", "api:write"},
+			}
+			r, _ := http.NewRequest("POST", "/", nil)
+			r = r.WithContext(session.WithSession(r.Context(), s))
+			rw := httptest.NewRecorder()
+			h.ServeHTTP(rw, r)
+
+			resp := rw.Result()
+			So(resp.StatusCode, ShouldEqual, 200)
+			So(resp.Header, ShouldResemble, http.Header{
+				"X-Authgear-Session-Valid": []string{"true"},
+				"X-Authgear-Session-Type":  []string{"client"},
+				"X-Authgear-Client-Id":     []string{"client-id"},
+				"X-Authgear-Client-Scope":  []string{"api:read api:write"},
+			})
+		})
+
 		Convey("should attach headers for invalid sessions", func() {
 			r, _ := http.NewRequest("POST", "/", nil)
 			r = r.WithContext(session.WithInvalidSession(r.Context()))
@@ -10,6 +10,7 @@ import (
 	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator/password"
 	service2 "github.com/authgear/authgear-server/pkg/lib/authn/authenticator/service"
 	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator/totp"
+	"github.com/authgear/authgear-server/pkg/lib/authn/authenticator/webauthn"
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity/anonymous"
 	"github.com/authgear/authgear-server/pkg/lib/authn/identity/loginid"
 	oauth2 "github.com/authgear/authgear-server/pkg/lib/authn/identity/oauth"
@@ -118,6 +119,7 @@ func newSessionMiddleware(p *deps.RequestProvider) httproute.Middleware {
 		TrustProxy:    trustProxy,
 		Clock:         clock,
 	}
+	oAuthConfig := appConfig.OAuth
 	secretConfig := config.SecretConfig
 	databaseCredentials := deps.ProvideDatabaseCredentials(secretConfig)
 	sqlBuilder := db.ProvideSQLBuilder(databaseCredentials, appID)
@@ -142,6 +144,7 @@ func newSessionMiddleware(p *deps.RequestProvider) httproute.Middleware {
 	}
 	oauthResolver := &oauth.Resolver{
 		TrustProxy:     trustProxy,
+		OAuthConfig:    oAuthConfig,
 		Authorizations: authorizationStore,
 		AccessGrants:   grantStore,
 		OfflineGrants:  grantStore,
@@ -232,6 +235,7 @@ func newSessionMiddleware(p *deps.RequestProvider) httproute.Middleware {
 		PasswordHistory: historyStore,
 		PasswordChecker: passwordChecker,
 		TaskQueue:       queue,
+		Database:        dbHandle,
 	}
 	totpStore := &totp.Store{
 		SQLBuilder:  sqlBuilder,
@@ -253,11 +257,28 @@ func newSessionMiddleware(p *deps.RequestProvider) httproute.Middleware {
 		Store:  oobStore,
 		Clock:  clock,
 	}
+	webauthnStore := &webauthn.Store{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	sessionStore := &webauthn.SessionStore{
+		Redis: handle,
+		AppID: appID,
+	}
+	authenticatorWebAuthnConfig := authenticatorConfig.WebAuthn
+	webauthnProvider := &webauthn.Provider{
+		Store:      webauthnStore,
+		Sessions:   sessionStore,
+		Config:     authenticatorWebAuthnConfig,
+		HTTPConfig: httpConfig,
+		Clock:      clock,
+	}
 	service3 := &service2.Service{
 		Store:    store2,
 		Password: passwordProvider,
 		TOTP:     totpProvider,
 		OOBOTP:   oobProvider,
+		WebAuthn: webauthnProvider,
 	}
 	verificationLogger := verification.NewLogger(factory)
 	verificationConfig := appConfig.Verification
@@ -290,12 +311,26 @@ func newSessionMiddleware(p *deps.RequestProvider) httproute.Middleware {
 		Store:        store,
 		Identities:   identityFacade,
 		Verification: verificationService,
+		Clock:        clock,
+	}
+	manager := &idpsession.Manager{
+		Store:         storeRedis,
+		Clock:         clock,
+		Config:        sessionConfig,
+		CookieFactory: cookieFactory,
+		CookieDef:     cookieDef,
+	}
+	sessionManager := &oauth.SessionManager{
+		Store: grantStore,
+		Clock: clock,
 	}
 	sessionMiddleware := &session.Middleware{
 		IDPSessionResolver:         resolver,
 		AccessTokenSessionResolver: oauthResolver,
 		AccessEvents:               eventProvider,
 		Users:                      queries,
+		IDPSessions:                manager,
+		AccessTokenSessions:        sessionManager,
 		Database:                   dbHandle,
 	}
 	return sessionMiddleware
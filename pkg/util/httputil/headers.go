@@ -25,3 +25,6 @@ func GetProto(r *http.Request, trustProxy bool) string {
 
 	return "http"
 }
+
+// RemoteIP is the IP address of the client making the current request.
+type RemoteIP string
@@ -12,6 +12,12 @@ import (
 )
 
 func Sign(t jwt.Token, alg jwa.SignatureAlgorithm, key interface{}) (token []byte, err error) {
+	return SignWithType(t, alg, key, "JWT")
+}
+
+// SignWithType is Sign with a custom typ header, e.g. at+jwt for JWT access
+// tokens.
+func SignWithType(t jwt.Token, alg jwa.SignatureAlgorithm, key interface{}, typ string) (token []byte, err error) {
 	buf, err := json.Marshal(t)
 	if err != nil {
 		return
@@ -24,7 +30,7 @@ func Sign(t jwt.Token, alg jwa.SignatureAlgorithm, key interface{}) (token []byt
 		return
 	}
 
-	err = hdr.Set("typ", "JWT")
+	err = hdr.Set("typ", typ)
 	if err != nil {
 		return
 	}
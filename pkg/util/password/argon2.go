@@ -0,0 +1,129 @@
+package password
+
+import (
+	"fmt"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	argon2DefaultMemory      = 64 * 1024
+	argon2DefaultIterations  = 3
+	argon2DefaultParallelism = 2
+	argon2DefaultKeyLength   = 32
+)
+
+// argon2Password implements the PHC string format of Argon2, e.g.
+// $argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash>
+type argon2Password struct {
+	variant string
+}
+
+var _ passwordFormat = argon2Password{}
+
+type argon2Hash struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+	salt        []byte
+	key         []byte
+}
+
+func (p argon2Password) ID() string {
+	return p.variant
+}
+
+func (p argon2Password) derive(password []byte, h *argon2Hash) []byte {
+	keyLen := uint32(len(h.key))
+	switch p.variant {
+	case "argon2i":
+		return argon2.Key(password, h.salt, h.iterations, h.memory, h.parallelism, keyLen)
+	default:
+		return argon2.IDKey(password, h.salt, h.iterations, h.memory, h.parallelism, keyLen)
+	}
+}
+
+func (p argon2Password) parse(hash []byte) (*argon2Hash, error) {
+	_, data, err := parsePasswordFormat(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	segments, err := splitSegments(data, 4)
+	if err != nil {
+		return nil, err
+	}
+	if segments[0] != fmt.Sprintf("v=%d", argon2.Version) {
+		return nil, errInvalidPasswordFormat
+	}
+
+	params, err := parseParams(segments[1])
+	if err != nil {
+		return nil, err
+	}
+	memory, err := intParam(params, "m", 8, 4*1024*1024)
+	if err != nil {
+		return nil, err
+	}
+	iterations, err := intParam(params, "t", 1, 1024)
+	if err != nil {
+		return nil, err
+	}
+	parallelism, err := intParam(params, "p", 1, 255)
+	if err != nil {
+		return nil, err
+	}
+
+	salt, err := decodeBase64(segments[2])
+	if err != nil {
+		return nil, err
+	}
+	key, err := decodeBase64(segments[3])
+	if err != nil {
+		return nil, err
+	}
+
+	return &argon2Hash{
+		memory:      uint32(memory),
+		iterations:  uint32(iterations),
+		parallelism: uint8(parallelism),
+		salt:        salt,
+		key:         key,
+	}, nil
+}
+
+func (p argon2Password) Hash(password []byte) ([]byte, error) {
+	salt, err := generateSalt()
+	if err != nil {
+		return nil, err
+	}
+
+	h := &argon2Hash{
+		memory:      argon2DefaultMemory,
+		iterations:  argon2DefaultIterations,
+		parallelism: argon2DefaultParallelism,
+		salt:        salt,
+		key:         make([]byte, argon2DefaultKeyLength),
+	}
+	key := p.derive(password, h)
+
+	data := fmt.Sprintf(
+		"v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, h.memory, h.iterations, h.parallelism,
+		encodeBase64(salt), encodeBase64(key),
+	)
+	return constructPasswordFormat([]byte(p.ID()), []byte(data)), nil
+}
+
+func (p argon2Password) Compare(password, hash []byte) error {
+	h, err := p.parse(hash)
+	if err != nil {
+		return err
+	}
+	return compareDerivedKey(h.key, p.derive(password, h))
+}
+
+func (p argon2Password) CheckHash(hash []byte) error {
+	_, err := p.parse(hash)
+	return err
+}
@@ -19,3 +19,8 @@ func (bcryptPassword) Hash(password []byte) ([]byte, error) {
 func (bcryptPassword) Compare(password, hash []byte) error {
 	return bcrypt.CompareHashAndPassword(hash, password)
 }
+
+func (bcryptPassword) CheckHash(hash []byte) error {
+	_, err := bcrypt.Cost(hash)
+	return err
+}
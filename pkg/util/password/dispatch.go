@@ -8,6 +8,7 @@ var defaultFormat passwordFormat
 var supportedFormats map[string]passwordFormat
 
 var ErrTooLong = errors.New("password is too long")
+var ErrMismatch = errors.New("password mismatch")
 
 func init() {
 	latestFormat = bcryptSHA512Password{}
@@ -16,6 +17,15 @@ func init() {
 	supportedFormats = map[string]passwordFormat{}
 	for _, fmt := range []passwordFormat{
 		bcryptSHA512Password{},
+		argon2Password{variant: "argon2id"},
+		argon2Password{variant: "argon2i"},
+		scryptPassword{},
+		pbkdf2Password{digest: "sha1"},
+		pbkdf2Password{digest: "sha256"},
+		pbkdf2Password{digest: "sha512"},
+		saltedSHAPassword{digest: "sha1"},
+		saltedSHAPassword{digest: "sha256"},
+		saltedSHAPassword{digest: "sha512"},
 	} {
 		supportedFormats[fmt.ID()] = fmt
 	}
@@ -54,6 +64,16 @@ func Compare(password, hash []byte) error {
 	return fmt.Compare(password, hash)
 }
 
+// CheckHash checks whether hash is a well-formed password hash in one of
+// the supported formats, without comparing it against any password.
+func CheckHash(hash []byte) error {
+	fmt, err := resolveFormat(hash)
+	if err != nil {
+		return err
+	}
+	return fmt.CheckHash(hash)
+}
+
 func TryMigrate(password []byte, hash *[]byte) (migrated bool, err error) {
 	// Do not enforce password length limit: migration of old password should
 	// not fail due to length limit
@@ -21,6 +21,9 @@ func (h *testHash) Compare(password, hash []byte) error {
 	h.testedPassword = password
 	return nil
 }
+func (h *testHash) CheckHash(hash []byte) error {
+	return nil
+}
 
 func TestDispatch(t *testing.T) {
 	Convey("Dispatching functions", t, func() {
@@ -9,6 +9,7 @@ type passwordFormat interface {
 	ID() string
 	Hash(password []byte) ([]byte, error)
 	Compare(password, hash []byte) error
+	CheckHash(hash []byte) error
 }
 
 var errInvalidPasswordFormat = errors.New("invalid password format")
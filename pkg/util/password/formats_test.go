@@ -0,0 +1,67 @@
+package password
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestImportedFormats(t *testing.T) {
+	Convey("Imported password formats", t, func() {
+		Convey("should compare known hashes", func() {
+			cases := []struct {
+				format passwordFormat
+				hash   string
+			}{
+				{argon2Password{variant: "argon2i"}, "$argon2i$v=19$m=65536,t=2,p=4$c29tZXNhbHQ$RdescudvJCsgt3ub+b+dWRWJTmaaJObG"},
+				{scryptPassword{}, "$scrypt$ln=10,r=8,p=1$c2FsdHNhbHRzYWx0c2FsdA$BVMRKqdiVYikKAaPR1wucsKUKvw4TuPLkdEYtoSHas4"},
+				{pbkdf2Password{digest: "sha256"}, "$pbkdf2-sha256$i=1000$c2FsdHNhbHRzYWx0c2FsdA$8nX7hwFEzIB8aPajJTYK8weHQc5Ngz0pFVAKvSu4jQA"},
+				{pbkdf2Password{digest: "sha1"}, "$pbkdf2-sha1$1000$c2FsdHNhbHRzYWx0c2FsdA$2FWw/oC7TQkskizC.81lWlmFAMM"},
+				{saltedSHAPassword{digest: "sha256"}, "$salted-sha256$pos=suffix$c2FsdHNhbHRzYWx0c2FsdA$qd7IuOWq547AnVslAFOt5bURGG1ZGVJmEWTfLKQZ8j4"},
+				{saltedSHAPassword{digest: "sha1"}, "$salted-sha1$pos=prefix$c2FsdHNhbHRzYWx0c2FsdA$JNqhqbopdP3Z9L/BE7c+Aago63g="},
+			}
+			for _, c := range cases {
+				h := []byte(c.hash)
+				So(c.format.CheckHash(h), ShouldBeNil)
+				So(c.format.Compare([]byte("password"), h), ShouldBeNil)
+				So(c.format.Compare([]byte("Password"), h), ShouldBeError, ErrMismatch)
+			}
+		})
+
+		Convey("should hash and compare", func() {
+			for _, f := range []passwordFormat{
+				argon2Password{variant: "argon2id"},
+				scryptPassword{},
+				pbkdf2Password{digest: "sha512"},
+				saltedSHAPassword{digest: "sha512"},
+			} {
+				h, err := f.Hash([]byte("password"))
+				So(err, ShouldBeNil)
+				So(string(h), ShouldStartWith, "$"+f.ID()+"$")
+				So(f.Compare([]byte("password"), h), ShouldBeNil)
+				So(f.Compare([]byte("Password"), h), ShouldBeError)
+			}
+		})
+
+		Convey("should reject malformed hashes", func() {
+			So(CheckHash([]byte("$argon2id$v=19$m=65536,t=3$c29tZXNhbHQ$RdescudvJCsgt3ub")), ShouldBeError)
+			So(CheckHash([]byte("$argon2id$v=16$m=65536,t=3,p=1$c29tZXNhbHQ$RdescudvJCsgt3ub")), ShouldBeError)
+			So(CheckHash([]byte("$scrypt$ln=10,r=8,p=1$c2FsdA")), ShouldBeError)
+			So(CheckHash([]byte("$pbkdf2-sha256$i=abc$c2FsdA$c2FsdA")), ShouldBeError)
+			So(CheckHash([]byte("$salted-sha256$pos=middle$c2FsdA$qd7IuOWq547AnVslAFOt5bURGG1ZGVJmEWTfLKQZ8j4")), ShouldBeError)
+			So(CheckHash([]byte("$2a$10$invalid")), ShouldBeError)
+			So(CheckHash([]byte("plaintext")), ShouldBeError)
+			So(CheckHash([]byte("$2a$10$qQybX3kAJNT2YqYRVmbfjO5EhgWm6vV4cWmXo2ZATAuBmCyJM2fKu")), ShouldBeNil)
+		})
+
+		Convey("should migrate to latest format", func() {
+			h := []byte("$pbkdf2-sha256$i=1000$c2FsdHNhbHRzYWx0c2FsdA$8nX7hwFEzIB8aPajJTYK8weHQc5Ngz0pFVAKvSu4jQA")
+			So(Compare([]byte("password"), h), ShouldBeNil)
+			migrated, err := TryMigrate([]byte("password"), &h)
+			So(err, ShouldBeNil)
+			So(migrated, ShouldBeTrue)
+			So(string(h), ShouldStartWith, "$bcrypt-sha512$")
+			So(Compare([]byte("password"), h), ShouldBeNil)
+		})
+	})
+}
@@ -0,0 +1,81 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"strconv"
+	"strings"
+)
+
+// saltLength is the length of salt generated when hashing with
+// formats that require an explicit salt.
+const saltLength = 16
+
+func generateSalt() ([]byte, error) {
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	return salt, nil
+}
+
+// splitSegments splits the data part of a formatted password hash
+// into n segments separated by '$'.
+func splitSegments(data []byte, n int) ([]string, error) {
+	segments := strings.Split(string(data), "$")
+	if len(segments) != n {
+		return nil, errInvalidPasswordFormat
+	}
+	return segments, nil
+}
+
+// parseParams parses comma separated key-value parameters, e.g. "m=65536,t=3,p=4".
+func parseParams(s string) (map[string]string, error) {
+	params := map[string]string{}
+	for _, kv := range strings.Split(s, ",") {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, errInvalidPasswordFormat
+		}
+		params[parts[0]] = parts[1]
+	}
+	return params, nil
+}
+
+func intParam(params map[string]string, key string, min int, max int) (int, error) {
+	s, ok := params[key]
+	if !ok {
+		return 0, errInvalidPasswordFormat
+	}
+	i, err := strconv.Atoi(s)
+	if err != nil || i < min || i > max {
+		return 0, errInvalidPasswordFormat
+	}
+	return i, nil
+}
+
+// encodeBase64 encodes b with standard base64 alphabet without padding,
+// as used by PHC string format.
+func encodeBase64(b []byte) string {
+	return base64.RawStdEncoding.EncodeToString(b)
+}
+
+// decodeBase64 decodes standard base64 with or without padding.
+// The adapted alphabet used by passlib ('.' instead of '+') is also accepted.
+func decodeBase64(s string) ([]byte, error) {
+	s = strings.TrimRight(s, "=")
+	s = strings.ReplaceAll(s, ".", "+")
+	b, err := base64.RawStdEncoding.DecodeString(s)
+	if err != nil || len(b) == 0 {
+		return nil, errInvalidPasswordFormat
+	}
+	return b, nil
+}
+
+func compareDerivedKey(expected []byte, actual []byte) error {
+	if len(expected) != len(actual) || subtle.ConstantTimeCompare(expected, actual) != 1 {
+		return ErrMismatch
+	}
+	return nil
+}
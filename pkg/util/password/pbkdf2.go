@@ -0,0 +1,112 @@
+package password
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"hash"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	pbkdf2DefaultIterations = 100000
+)
+
+// pbkdf2Password implements the PHC string format of PBKDF2, e.g.
+// $pbkdf2-sha256$i=100000$<salt>$<hash>
+// The iteration count without the "i=" prefix, as produced by passlib, is also accepted.
+type pbkdf2Password struct {
+	digest string
+}
+
+var _ passwordFormat = pbkdf2Password{}
+
+type pbkdf2Hash struct {
+	iterations int
+	salt       []byte
+	key        []byte
+}
+
+func (p pbkdf2Password) ID() string {
+	return "pbkdf2-" + p.digest
+}
+
+func (p pbkdf2Password) hashFunc() func() hash.Hash {
+	switch p.digest {
+	case "sha1":
+		return sha1.New
+	case "sha512":
+		return sha512.New
+	default:
+		return sha256.New
+	}
+}
+
+func (p pbkdf2Password) parse(h []byte) (*pbkdf2Hash, error) {
+	_, data, err := parsePasswordFormat(h)
+	if err != nil {
+		return nil, err
+	}
+
+	segments, err := splitSegments(data, 3)
+	if err != nil {
+		return nil, err
+	}
+
+	iterParam := segments[0]
+	if !strings.HasPrefix(iterParam, "i=") {
+		iterParam = "i=" + iterParam
+	}
+	params, err := parseParams(iterParam)
+	if err != nil {
+		return nil, err
+	}
+	iterations, err := intParam(params, "i", 1, 10000000)
+	if err != nil {
+		return nil, err
+	}
+
+	salt, err := decodeBase64(segments[1])
+	if err != nil {
+		return nil, err
+	}
+	key, err := decodeBase64(segments[2])
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbkdf2Hash{iterations: iterations, salt: salt, key: key}, nil
+}
+
+func (p pbkdf2Password) Hash(password []byte) ([]byte, error) {
+	salt, err := generateSalt()
+	if err != nil {
+		return nil, err
+	}
+
+	hashFunc := p.hashFunc()
+	key := pbkdf2.Key(password, salt, pbkdf2DefaultIterations, hashFunc().Size(), hashFunc)
+
+	data := fmt.Sprintf(
+		"i=%d$%s$%s",
+		pbkdf2DefaultIterations, encodeBase64(salt), encodeBase64(key),
+	)
+	return constructPasswordFormat([]byte(p.ID()), []byte(data)), nil
+}
+
+func (p pbkdf2Password) Compare(password, hash []byte) error {
+	h, err := p.parse(hash)
+	if err != nil {
+		return err
+	}
+	key := pbkdf2.Key(password, h.salt, h.iterations, len(h.key), p.hashFunc())
+	return compareDerivedKey(h.key, key)
+}
+
+func (p pbkdf2Password) CheckHash(hash []byte) error {
+	_, err := p.parse(hash)
+	return err
+}
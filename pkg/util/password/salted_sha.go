@@ -0,0 +1,121 @@
+package password
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"hash"
+)
+
+const (
+	saltPositionPrefix = "prefix"
+	saltPositionSuffix = "suffix"
+)
+
+// saltedSHAPassword implements a single round of salted SHA digest, e.g.
+// $salted-sha256$pos=prefix$<salt>$<hash>
+// where pos indicates whether the salt is prepended or appended to the password.
+// It is only intended for importing password hashes from legacy systems.
+type saltedSHAPassword struct {
+	digest string
+}
+
+var _ passwordFormat = saltedSHAPassword{}
+
+type saltedSHAHash struct {
+	position string
+	salt     []byte
+	digest   []byte
+}
+
+func (p saltedSHAPassword) ID() string {
+	return "salted-" + p.digest
+}
+
+func (p saltedSHAPassword) hashFunc() func() hash.Hash {
+	switch p.digest {
+	case "sha1":
+		return sha1.New
+	case "sha512":
+		return sha512.New
+	default:
+		return sha256.New
+	}
+}
+
+func (p saltedSHAPassword) derive(password []byte, h *saltedSHAHash) []byte {
+	d := p.hashFunc()()
+	if h.position == saltPositionPrefix {
+		d.Write(h.salt)
+		d.Write(password)
+	} else {
+		d.Write(password)
+		d.Write(h.salt)
+	}
+	return d.Sum(nil)
+}
+
+func (p saltedSHAPassword) parse(hash []byte) (*saltedSHAHash, error) {
+	_, data, err := parsePasswordFormat(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	segments, err := splitSegments(data, 3)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := parseParams(segments[0])
+	if err != nil {
+		return nil, err
+	}
+	position := params["pos"]
+	if position != saltPositionPrefix && position != saltPositionSuffix {
+		return nil, errInvalidPasswordFormat
+	}
+
+	salt, err := decodeBase64(segments[1])
+	if err != nil {
+		return nil, err
+	}
+	digest, err := decodeBase64(segments[2])
+	if err != nil {
+		return nil, err
+	}
+	if len(digest) != p.hashFunc()().Size() {
+		return nil, errInvalidPasswordFormat
+	}
+
+	return &saltedSHAHash{position: position, salt: salt, digest: digest}, nil
+}
+
+func (p saltedSHAPassword) Hash(password []byte) ([]byte, error) {
+	salt, err := generateSalt()
+	if err != nil {
+		return nil, err
+	}
+
+	h := &saltedSHAHash{position: saltPositionPrefix, salt: salt}
+	digest := p.derive(password, h)
+
+	data := fmt.Sprintf(
+		"pos=%s$%s$%s",
+		h.position, encodeBase64(salt), encodeBase64(digest),
+	)
+	return constructPasswordFormat([]byte(p.ID()), []byte(data)), nil
+}
+
+func (p saltedSHAPassword) Compare(password, hash []byte) error {
+	h, err := p.parse(hash)
+	if err != nil {
+		return err
+	}
+	return compareDerivedKey(h.digest, p.derive(password, h))
+}
+
+func (p saltedSHAPassword) CheckHash(hash []byte) error {
+	_, err := p.parse(hash)
+	return err
+}
@@ -0,0 +1,108 @@
+package password
+
+import (
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	scryptDefaultLogN      = 15
+	scryptDefaultR         = 8
+	scryptDefaultP         = 1
+	scryptDefaultKeyLength = 32
+)
+
+// scryptPassword implements the PHC string format of scrypt, e.g.
+// $scrypt$ln=15,r=8,p=1$<salt>$<hash>
+type scryptPassword struct{}
+
+var _ passwordFormat = scryptPassword{}
+
+type scryptHash struct {
+	logN int
+	r    int
+	p    int
+	salt []byte
+	key  []byte
+}
+
+func (p scryptPassword) ID() string {
+	return "scrypt"
+}
+
+func (p scryptPassword) parse(hash []byte) (*scryptHash, error) {
+	_, data, err := parsePasswordFormat(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	segments, err := splitSegments(data, 3)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := parseParams(segments[0])
+	if err != nil {
+		return nil, err
+	}
+	logN, err := intParam(params, "ln", 1, 24)
+	if err != nil {
+		return nil, err
+	}
+	r, err := intParam(params, "r", 1, 64)
+	if err != nil {
+		return nil, err
+	}
+	par, err := intParam(params, "p", 1, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	salt, err := decodeBase64(segments[1])
+	if err != nil {
+		return nil, err
+	}
+	key, err := decodeBase64(segments[2])
+	if err != nil {
+		return nil, err
+	}
+
+	return &scryptHash{logN: logN, r: r, p: par, salt: salt, key: key}, nil
+}
+
+func (p scryptPassword) Hash(password []byte) ([]byte, error) {
+	salt, err := generateSalt()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := scrypt.Key(password, salt, 1<<scryptDefaultLogN, scryptDefaultR, scryptDefaultP, scryptDefaultKeyLength)
+	if err != nil {
+		return nil, err
+	}
+
+	data := fmt.Sprintf(
+		"ln=%d,r=%d,p=%d$%s$%s",
+		scryptDefaultLogN, scryptDefaultR, scryptDefaultP,
+		encodeBase64(salt), encodeBase64(key),
+	)
+	return constructPasswordFormat([]byte(p.ID()), []byte(data)), nil
+}
+
+func (p scryptPassword) Compare(password, hash []byte) error {
+	h, err := p.parse(hash)
+	if err != nil {
+		return err
+	}
+	key, err := scrypt.Key(password, h.salt, 1<<h.logN, h.r, h.p, len(h.key))
+	if err != nil {
+		return err
+	}
+	return compareDerivedKey(h.key, key)
+}
+
+func (p scryptPassword) CheckHash(hash []byte) error {
+	_, err := p.parse(hash)
+	return err
+}
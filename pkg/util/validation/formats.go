@@ -6,6 +6,7 @@ import (
 	"net/url"
 	"path/filepath"
 
+	"github.com/iawaknahc/jsonschema/pkg/jsonpointer"
 	jsonschemaformat "github.com/iawaknahc/jsonschema/pkg/jsonschema/format"
 
 	"github.com/authgear/authgear-server/pkg/util/phone"
@@ -16,6 +17,7 @@ func init() {
 	jsonschemaformat.DefaultChecker["email"] = FormatEmail{AllowName: false}
 	jsonschemaformat.DefaultChecker["email-name-addr"] = FormatEmail{AllowName: true}
 	jsonschemaformat.DefaultChecker["uri"] = FormatURI{}
+	jsonschemaformat.DefaultChecker["json-pointer"] = FormatJSONPointer{}
 }
 
 // FormatPhone checks if input is a phone number in E.164 format.
@@ -88,3 +90,16 @@ func (f FormatURI) CheckFormat(value interface{}) error {
 
 	return nil
 }
+
+// FormatJSONPointer checks if input is a JSON pointer.
+type FormatJSONPointer struct{}
+
+func (f FormatJSONPointer) CheckFormat(value interface{}) error {
+	str, ok := value.(string)
+	if !ok {
+		return nil
+	}
+
+	_, err := jsonpointer.Parse(str)
+	return err
+}
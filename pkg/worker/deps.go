@@ -3,7 +3,11 @@ package worker
 import (
 	"github.com/google/wire"
 
+	"github.com/authgear/authgear-server/pkg/lib/audit"
 	"github.com/authgear/authgear-server/pkg/lib/deps"
+	"github.com/authgear/authgear-server/pkg/lib/feature/userdeletion"
+	"github.com/authgear/authgear-server/pkg/lib/feature/userexport"
+	"github.com/authgear/authgear-server/pkg/lib/hook"
 	"github.com/authgear/authgear-server/pkg/lib/infra/mail"
 	"github.com/authgear/authgear-server/pkg/lib/infra/sms"
 	"github.com/authgear/authgear-server/pkg/worker/tasks"
@@ -19,4 +23,9 @@ var DependencySet = wire.NewSet(
 	tasks.DependencySet,
 	wire.Bind(new(tasks.MailSender), new(*mail.Sender)),
 	wire.Bind(new(tasks.SMSClient), new(*sms.Client)),
+	wire.Bind(new(tasks.EventDeliveryService), new(*hook.DeliveryService)),
+	wire.Bind(new(tasks.UserDeletionService), new(*userdeletion.Service)),
+	wire.Bind(new(tasks.AuditLogPruner), new(*audit.Pruner)),
+	wire.Bind(new(tasks.UserExportService), new(*userexport.Service)),
+	wire.Bind(new(tasks.UserExportPruner), new(*userexport.Pruner)),
 )
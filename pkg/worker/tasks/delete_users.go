@@ -0,0 +1,90 @@
+package tasks
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	"github.com/authgear/authgear-server/pkg/lib/infra/task"
+	"github.com/authgear/authgear-server/pkg/lib/tasks"
+	"github.com/authgear/authgear-server/pkg/util/log"
+)
+
+func ConfigureDeleteScheduledUsersTask(registry task.Registry, t task.Task) {
+	registry.Register(tasks.DeleteScheduledUsers, t)
+}
+
+func ConfigureDeleteUserTask(registry task.Registry, t task.Task) {
+	registry.Register(tasks.DeleteUser, t)
+}
+
+type UserDeletionService interface {
+	ListScheduled() ([]string, error)
+	DeleteScheduled(userID string) error
+	PostponeScheduled(userID string) error
+}
+
+type DeleteUsersLogger struct{ *log.Logger }
+
+func NewDeleteUsersLogger(lf *log.Factory) DeleteUsersLogger {
+	return DeleteUsersLogger{lf.New("delete-users")}
+}
+
+// DeleteScheduledUsersTask enqueues a DeleteUser task for each user whose
+// scheduled deletion is due, so that each user is deleted in its own
+// transaction.
+type DeleteScheduledUsersTask struct {
+	Database  *db.Handle
+	Logger    DeleteUsersLogger
+	Deletion  UserDeletionService
+	TaskQueue task.Queue
+}
+
+func (t *DeleteScheduledUsersTask) Run(ctx context.Context, param task.Param) (err error) {
+	t.Logger.Debug("Listing users scheduled for deletion")
+
+	var userIDs []string
+	err = t.Database.ReadOnly(func() (err error) {
+		userIDs, err = t.Deletion.ListScheduled()
+		return
+	})
+	if err != nil {
+		return
+	}
+
+	for _, userID := range userIDs {
+		t.TaskQueue.Enqueue(&tasks.DeleteUserParam{UserID: userID})
+	}
+	return
+}
+
+type DeleteUserTask struct {
+	Database *db.Handle
+	Logger   DeleteUsersLogger
+	Deletion UserDeletionService
+}
+
+func (t *DeleteUserTask) Run(ctx context.Context, param task.Param) (err error) {
+	taskParam := param.(*tasks.DeleteUserParam)
+
+	logger := t.Logger.WithFields(logrus.Fields{"user_id": taskParam.UserID})
+	logger.Debug("Deleting scheduled user")
+
+	if err = taskParam.Validate(); err != nil {
+		return
+	}
+
+	err = t.Database.WithTx(func() error {
+		return t.Deletion.DeleteScheduled(taskParam.UserID)
+	})
+	if err != nil {
+		if postponeErr := t.Database.WithTx(func() error {
+			return t.Deletion.PostponeScheduled(taskParam.UserID)
+		}); postponeErr != nil {
+			logger.WithError(postponeErr).Error("Failed to postpone scheduled user deletion")
+		}
+	}
+
+	return
+}
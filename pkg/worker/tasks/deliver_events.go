@@ -0,0 +1,65 @@
+package tasks
+
+import (
+	"context"
+
+	"github.com/authgear/authgear-server/pkg/lib/hook"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	"github.com/authgear/authgear-server/pkg/lib/infra/task"
+	"github.com/authgear/authgear-server/pkg/lib/tasks"
+	"github.com/authgear/authgear-server/pkg/util/log"
+)
+
+func ConfigureDeliverEventsTask(registry task.Registry, t task.Task) {
+	registry.Register(tasks.DeliverEvents, t)
+}
+
+type EventDeliveryService interface {
+	ClaimPendingEvents() ([]*hook.StoredEvent, error)
+	DeliverEvent(e *hook.StoredEvent)
+	UpdateDelivery(e *hook.StoredEvent) error
+}
+
+type DeliverEventsLogger struct{ *log.Logger }
+
+func NewDeliverEventsLogger(lf *log.Factory) DeliverEventsLogger {
+	return DeliverEventsLogger{lf.New("deliver-events")}
+}
+
+// DeliverEventsTask delivers pending web-hook events in batches. The events
+// are delivered outside of transaction, so that the claimed events are not
+// locked while waiting for the web-hook handlers.
+type DeliverEventsTask struct {
+	Database *db.Handle
+	Logger   DeliverEventsLogger
+	Events   EventDeliveryService
+}
+
+func (t *DeliverEventsTask) Run(ctx context.Context, param task.Param) (err error) {
+	t.Logger.Debug("Delivering web-hook events")
+
+	for {
+		var events []*hook.StoredEvent
+		err = t.Database.WithTx(func() (err error) {
+			events, err = t.Events.ClaimPendingEvents()
+			return
+		})
+		if err != nil {
+			return
+		}
+		if len(events) == 0 {
+			return
+		}
+
+		for _, e := range events {
+			t.Events.DeliverEvent(e)
+
+			err = t.Database.WithTx(func() error {
+				return t.Events.UpdateDelivery(e)
+			})
+			if err != nil {
+				return
+			}
+		}
+	}
+}
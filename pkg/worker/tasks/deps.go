@@ -9,4 +9,15 @@ var DependencySet = wire.NewSet(
 	wire.Struct(new(PwHousekeeperTask), "*"),
 	NewSendMessagesLogger,
 	wire.Struct(new(SendMessagesTask), "*"),
+	NewDeliverEventsLogger,
+	wire.Struct(new(DeliverEventsTask), "*"),
+	NewDeleteUsersLogger,
+	wire.Struct(new(DeleteScheduledUsersTask), "*"),
+	wire.Struct(new(DeleteUserTask), "*"),
+	NewPruneAuditLogsLogger,
+	wire.Struct(new(PruneAuditLogsTask), "*"),
+	NewExportUsersLogger,
+	wire.Struct(new(ExportUsersTask), "*"),
+	NewPruneUserExportsLogger,
+	wire.Struct(new(PruneUserExportsTask), "*"),
 )
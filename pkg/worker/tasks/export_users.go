@@ -0,0 +1,92 @@
+package tasks
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/authgear/authgear-server/pkg/lib/feature/userexport"
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	"github.com/authgear/authgear-server/pkg/lib/infra/task"
+	"github.com/authgear/authgear-server/pkg/lib/tasks"
+	"github.com/authgear/authgear-server/pkg/util/log"
+)
+
+func ConfigureExportUsersTask(registry task.Registry, t task.Task) {
+	registry.Register(tasks.ExportUsers, t)
+}
+
+type UserExportService interface {
+	Start(jobID string) (*userexport.Job, *userexport.Cursor, error)
+	ExportBatch(job *userexport.Job, cursor *userexport.Cursor) (done bool, err error)
+	Complete(job *userexport.Job) error
+	Fail(jobID string, cause error) error
+}
+
+type ExportUsersLogger struct{ *log.Logger }
+
+func NewExportUsersLogger(lf *log.Factory) ExportUsersLogger {
+	return ExportUsersLogger{lf.New("export-users")}
+}
+
+// ExportUsersTask exports all users in batches, each in its own transaction,
+// so that the progress is visible while the export is running.
+type ExportUsersTask struct {
+	Database *db.Handle
+	Logger   ExportUsersLogger
+	Exports  UserExportService
+}
+
+func (t *ExportUsersTask) Run(ctx context.Context, param task.Param) (err error) {
+	taskParam := param.(*tasks.ExportUsersParam)
+
+	if err = taskParam.Validate(); err != nil {
+		return
+	}
+
+	logger := t.Logger.WithFields(logrus.Fields{"export_id": taskParam.JobID})
+	logger.Debug("Exporting users")
+
+	err = t.export(taskParam.JobID)
+	if err != nil {
+		if failErr := t.Database.WithTx(func() error {
+			return t.Exports.Fail(taskParam.JobID, err)
+		}); failErr != nil {
+			logger.WithError(failErr).Error("Failed to mark user export as failed")
+		}
+		return
+	}
+
+	logger.Debug("Exported users")
+	return
+}
+
+func (t *ExportUsersTask) export(jobID string) (err error) {
+	var job *userexport.Job
+	var cursor *userexport.Cursor
+	err = t.Database.WithTx(func() (err error) {
+		job, cursor, err = t.Exports.Start(jobID)
+		return
+	})
+	if err != nil {
+		return
+	}
+
+	for {
+		var done bool
+		err = t.Database.WithTx(func() (err error) {
+			done, err = t.Exports.ExportBatch(job, cursor)
+			return
+		})
+		if err != nil {
+			return
+		}
+		if done {
+			break
+		}
+	}
+
+	return t.Database.WithTx(func() error {
+		return t.Exports.Complete(job)
+	})
+}
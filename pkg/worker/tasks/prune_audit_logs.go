@@ -0,0 +1,49 @@
+package tasks
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	"github.com/authgear/authgear-server/pkg/lib/infra/task"
+	"github.com/authgear/authgear-server/pkg/lib/tasks"
+	"github.com/authgear/authgear-server/pkg/util/log"
+)
+
+func ConfigurePruneAuditLogsTask(registry task.Registry, t task.Task) {
+	registry.Register(tasks.PruneAuditLogs, t)
+}
+
+type AuditLogPruner interface {
+	Prune() (int64, error)
+}
+
+type PruneAuditLogsLogger struct{ *log.Logger }
+
+func NewPruneAuditLogsLogger(lf *log.Factory) PruneAuditLogsLogger {
+	return PruneAuditLogsLogger{lf.New("prune-audit-logs")}
+}
+
+// PruneAuditLogsTask deletes the audit logs older than the retention period.
+type PruneAuditLogsTask struct {
+	Database *db.Handle
+	Logger   PruneAuditLogsLogger
+	Pruner   AuditLogPruner
+}
+
+func (t *PruneAuditLogsTask) Run(ctx context.Context, param task.Param) (err error) {
+	var count int64
+	err = t.Database.WithTx(func() (err error) {
+		count, err = t.Pruner.Prune()
+		return
+	})
+	if err != nil {
+		return
+	}
+
+	if count > 0 {
+		t.Logger.WithFields(logrus.Fields{"count": count}).Debug("Pruned audit logs")
+	}
+	return
+}
@@ -0,0 +1,49 @@
+package tasks
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/authgear/authgear-server/pkg/lib/infra/db"
+	"github.com/authgear/authgear-server/pkg/lib/infra/task"
+	"github.com/authgear/authgear-server/pkg/lib/tasks"
+	"github.com/authgear/authgear-server/pkg/util/log"
+)
+
+func ConfigurePruneUserExportsTask(registry task.Registry, t task.Task) {
+	registry.Register(tasks.PruneUserExports, t)
+}
+
+type UserExportPruner interface {
+	Prune() (int64, error)
+}
+
+type PruneUserExportsLogger struct{ *log.Logger }
+
+func NewPruneUserExportsLogger(lf *log.Factory) PruneUserExportsLogger {
+	return PruneUserExportsLogger{lf.New("prune-user-exports")}
+}
+
+// PruneUserExportsTask deletes the expired user exports.
+type PruneUserExportsTask struct {
+	Database *db.Handle
+	Logger   PruneUserExportsLogger
+	Pruner   UserExportPruner
+}
+
+func (t *PruneUserExportsTask) Run(ctx context.Context, param task.Param) (err error) {
+	var count int64
+	err = t.Database.WithTx(func() (err error) {
+		count, err = t.Pruner.Prune()
+		return
+	})
+	if err != nil {
+		return
+	}
+
+	if count > 0 {
+		t.Logger.WithFields(logrus.Fields{"count": count}).Debug("Pruned user exports")
+	}
+	return
+}
@@ -31,3 +31,45 @@ func newSendMessagesTask(p *deps.TaskProvider) task.Task {
 		wire.Bind(new(task.Task), new(*authtask.SendMessagesTask)),
 	))
 }
+
+func newDeliverEventsTask(p *deps.TaskProvider) task.Task {
+	panic(wire.Build(
+		DependencySet,
+		wire.Bind(new(task.Task), new(*authtask.DeliverEventsTask)),
+	))
+}
+
+func newDeleteScheduledUsersTask(p *deps.TaskProvider) task.Task {
+	panic(wire.Build(
+		DependencySet,
+		wire.Bind(new(task.Task), new(*authtask.DeleteScheduledUsersTask)),
+	))
+}
+
+func newDeleteUserTask(p *deps.TaskProvider) task.Task {
+	panic(wire.Build(
+		DependencySet,
+		wire.Bind(new(task.Task), new(*authtask.DeleteUserTask)),
+	))
+}
+
+func newPruneAuditLogsTask(p *deps.TaskProvider) task.Task {
+	panic(wire.Build(
+		DependencySet,
+		wire.Bind(new(task.Task), new(*authtask.PruneAuditLogsTask)),
+	))
+}
+
+func newExportUsersTask(p *deps.TaskProvider) task.Task {
+	panic(wire.Build(
+		DependencySet,
+		wire.Bind(new(task.Task), new(*authtask.ExportUsersTask)),
+	))
+}
+
+func newPruneUserExportsTask(p *deps.TaskProvider) task.Task {
+	panic(wire.Build(
+		DependencySet,
+		wire.Bind(new(task.Task), new(*authtask.PruneUserExportsTask)),
+	))
+}
@@ -1,19 +1,91 @@
 package worker
 
 import (
+	"time"
+
+	"github.com/authgear/authgear-server/pkg/lib/config/configsource"
 	"github.com/authgear/authgear-server/pkg/lib/deps"
+	"github.com/authgear/authgear-server/pkg/lib/infra/task"
 	"github.com/authgear/authgear-server/pkg/lib/infra/task/executor"
+	libtasks "github.com/authgear/authgear-server/pkg/lib/tasks"
+	"github.com/authgear/authgear-server/pkg/util/log"
 	"github.com/authgear/authgear-server/pkg/worker/tasks"
 )
 
+// scheduledTaskInterval is the interval of retrying pending web-hook events
+// and deleting users scheduled for deletion.
+const scheduledTaskInterval = 1 * time.Minute
+
 type Worker struct {
 	Executor *executor.InProcessExecutor
+
+	logger *log.Logger
+	done   chan struct{}
 }
 
 func NewWorker(provider *deps.RootProvider) *Worker {
 	executor := newInProcessExecutor(provider)
 	tasks.ConfigurePwHousekeeperTask(executor, provider.Task(newPwHousekeeperTask))
 	tasks.ConfigureSendMessagesTask(executor, provider.Task(newSendMessagesTask))
+	tasks.ConfigureDeliverEventsTask(executor, provider.Task(newDeliverEventsTask))
+	tasks.ConfigureDeleteScheduledUsersTask(executor, provider.Task(newDeleteScheduledUsersTask))
+	tasks.ConfigureDeleteUserTask(executor, provider.Task(newDeleteUserTask))
+	tasks.ConfigurePruneAuditLogsTask(executor, provider.Task(newPruneAuditLogsTask))
+	tasks.ConfigureExportUsersTask(executor, provider.Task(newExportUsersTask))
+	tasks.ConfigurePruneUserExportsTask(executor, provider.Task(newPruneUserExportsTask))
+	return &Worker{
+		Executor: executor,
+		logger:   provider.LoggerFactory.New("worker"),
+	}
+}
+
+// StartScheduledTasks periodically delivers pending web-hook events and
+// deletes users scheduled for deletion of all apps.
+func (w *Worker) StartScheduledTasks(source *configsource.ConfigSource) {
+	done := make(chan struct{})
+	w.done = done
+
+	go func() {
+		ticker := time.NewTicker(scheduledTaskInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				w.runScheduledTasks(source)
+			case <-done:
+				return
+			}
+		}
+	}()
+}
+
+func (w *Worker) Stop() {
+	if w.done != nil {
+		close(w.done)
+		w.done = nil
+	}
+}
+
+func (w *Worker) runScheduledTasks(source *configsource.ConfigSource) {
+	appIDs, err := source.AppIDResolver.AllAppIDs()
+	if err != nil {
+		w.logger.WithError(err).Error("failed to list apps")
+		return
+	}
 
-	return &Worker{Executor: executor}
+	for _, appID := range appIDs {
+		appCtx, err := source.ContextResolver.ResolveContext(appID)
+		if err != nil {
+			w.logger.WithError(err).WithField("app_id", appID).Error("failed to resolve app")
+			continue
+		}
+		taskCtx := &task.Context{Config: appCtx.Config}
+		// Events are delivered even if the app has no handlers now,
+		// so that the pending events are marked as delivered.
+		w.Executor.Run(taskCtx, &libtasks.DeliverEventsParam{})
+		w.Executor.Run(taskCtx, &libtasks.DeleteScheduledUsersParam{})
+		w.Executor.Run(taskCtx, &libtasks.PruneAuditLogsParam{})
+		w.Executor.Run(taskCtx, &libtasks.PruneUserExportsParam{})
+	}
 }
@@ -16,6 +16,7 @@ export enum AuthenticatorType {
   OOB_OTP = "OOB_OTP",
   PASSWORD = "PASSWORD",
   TOTP = "TOTP",
+  WEBAUTHN = "WEBAUTHN",
 }
 
 export enum IdentityType {
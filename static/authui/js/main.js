@@ -316,6 +316,123 @@ window.addEventListener("load", function() {
     }
   }
 
+  // WebAuthn transmits binary data as ArrayBuffer, while the server
+  // encodes them in base64 or base64url.
+  function base64ToArrayBuffer(s) {
+    s = s.replace(/-/g, "+").replace(/_/g, "/");
+    while (s.length % 4 !== 0) {
+      s += "=";
+    }
+    var binary = window.atob(s);
+    var bytes = new Uint8Array(binary.length);
+    for (var i = 0; i < binary.length; i++) {
+      bytes[i] = binary.charCodeAt(i);
+    }
+    return bytes.buffer;
+  }
+
+  function arrayBufferToBase64URL(buf) {
+    if (buf == null) {
+      return null;
+    }
+    var bytes = new Uint8Array(buf);
+    var binary = "";
+    for (var i = 0; i < bytes.length; i++) {
+      binary += String.fromCharCode(bytes[i]);
+    }
+    return window.btoa(binary).replace(/\+/g, "-").replace(/\//g, "_").replace(/=+$/, "");
+  }
+
+  function decodeCredentialDescriptors(descriptors) {
+    if (descriptors == null) {
+      return descriptors;
+    }
+    for (var i = 0; i < descriptors.length; i++) {
+      descriptors[i].id = base64ToArrayBuffer(descriptors[i].id);
+    }
+    return descriptors;
+  }
+
+  function createWebAuthnCredential(publicKey) {
+    publicKey.challenge = base64ToArrayBuffer(publicKey.challenge);
+    publicKey.user.id = base64ToArrayBuffer(publicKey.user.id);
+    publicKey.excludeCredentials = decodeCredentialDescriptors(publicKey.excludeCredentials);
+    return navigator.credentials.create({ publicKey: publicKey }).then(function(credential) {
+      return {
+        id: credential.id,
+        rawId: arrayBufferToBase64URL(credential.rawId),
+        type: credential.type,
+        response: {
+          clientDataJSON: arrayBufferToBase64URL(credential.response.clientDataJSON),
+          attestationObject: arrayBufferToBase64URL(credential.response.attestationObject)
+        }
+      };
+    });
+  }
+
+  function getWebAuthnCredential(publicKey) {
+    publicKey.challenge = base64ToArrayBuffer(publicKey.challenge);
+    publicKey.allowCredentials = decodeCredentialDescriptors(publicKey.allowCredentials);
+    return navigator.credentials.get({ publicKey: publicKey }).then(function(credential) {
+      return {
+        id: credential.id,
+        rawId: arrayBufferToBase64URL(credential.rawId),
+        type: credential.type,
+        response: {
+          clientDataJSON: arrayBufferToBase64URL(credential.response.clientDataJSON),
+          authenticatorData: arrayBufferToBase64URL(credential.response.authenticatorData),
+          signature: arrayBufferToBase64URL(credential.response.signature),
+          userHandle: arrayBufferToBase64URL(credential.response.userHandle)
+        }
+      };
+    });
+  }
+
+  // The form is submitted again after the response of the ceremony is filled.
+  // Therefore this must be attached before other submit listeners.
+  function attachWebAuthn() {
+    var els = document.querySelectorAll("form[data-webauthn-options]");
+    for (var i = 0; i < els.length; ++i) {
+      els[i].addEventListener("submit", function(e) {
+        var form = e.currentTarget;
+        var responseInput = form.querySelector('input[name="x_response"]');
+        if (responseInput.value !== "") {
+          return;
+        }
+
+        e.preventDefault();
+        e.stopImmediatePropagation();
+
+        var errorEl = form.querySelector(".webauthn-error");
+        if (window.PublicKeyCredential == null) {
+          if (errorEl != null) {
+            errorEl.removeAttribute("hidden");
+          }
+          return;
+        }
+
+        var options = JSON.parse(form.getAttribute("data-webauthn-options"));
+        var ceremony = form.getAttribute("data-webauthn-ceremony");
+        var promise;
+        if (ceremony === "create") {
+          promise = createWebAuthnCredential(options.publicKey);
+        } else {
+          promise = getWebAuthnCredential(options.publicKey);
+        }
+
+        promise.then(function(response) {
+          responseInput.value = JSON.stringify(response);
+          form.dispatchEvent(new Event("submit", { cancelable: true }));
+        }, function(err) {
+          console.error(err);
+          if (errorEl != null) {
+            errorEl.removeAttribute("hidden");
+          }
+        });
+      });
+    }
+  }
+
   function attachHistoryListener() {
     window.addEventListener("popstate", function(e) {
       var meta = document.querySelector('meta[name="x-authgear-request-url"]');
@@ -338,6 +455,7 @@ window.addEventListener("load", function() {
   attachBackButtonClick();
   attachPasswordPolicyCheck();
   attachResendButtonBehavior();
+  attachWebAuthn();
   attachFormSubmitOnceOnly();
   attachFormSubmitXHR();
   attachHistoryListener();